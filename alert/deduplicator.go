@@ -0,0 +1,108 @@
+package alert
+
+import (
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/longhorn/longhorn-manager/types"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+type sentAlert struct {
+	alert      *types.Alert
+	lastSentAt time.Time
+}
+
+// Deduplicator remembers the alerts sent to a sink, so that a firing alert is only sent again after the repeat
+// interval, and a resolved alert is sent once it no longer fires. The sent alerts are kept in the AlertSink status,
+// so that they survive a restart or an owner change of the controller.
+type Deduplicator struct {
+	sent map[string]*sentAlert
+}
+
+// NewDeduplicator returns a Deduplicator remembering the sent alerts recorded in the AlertSink status.
+func NewDeduplicator(sentAlerts []longhorn.AlertSinkSentAlert) *Deduplicator {
+	d := &Deduplicator{
+		sent: map[string]*sentAlert{},
+	}
+	for _, s := range sentAlerts {
+		alert := &types.Alert{
+			Name:     s.Name,
+			Severity: s.Severity,
+			Status:   types.AlertStatusFiring,
+			Kind:     s.Kind,
+			Resource: s.ResourceName,
+			Node:     s.Node,
+			Message:  s.Message,
+			StartsAt: s.StartsAt,
+		}
+		d.sent[alert.Fingerprint()] = &sentAlert{alert: alert, lastSentAt: s.LastSentAt.Time}
+	}
+	return d
+}
+
+// SentAlerts returns the sent alerts to be recorded in the AlertSink status, sorted by fingerprint.
+func (d *Deduplicator) SentAlerts() []longhorn.AlertSinkSentAlert {
+	fingerprints := make([]string, 0, len(d.sent))
+	for fingerprint := range d.sent {
+		fingerprints = append(fingerprints, fingerprint)
+	}
+	sort.Strings(fingerprints)
+
+	sentAlerts := make([]longhorn.AlertSinkSentAlert, 0, len(fingerprints))
+	for _, fingerprint := range fingerprints {
+		sent := d.sent[fingerprint]
+		sentAlerts = append(sentAlerts, longhorn.AlertSinkSentAlert{
+			Name:         sent.alert.Name,
+			Severity:     sent.alert.Severity,
+			Kind:         sent.alert.Kind,
+			ResourceName: sent.alert.Resource,
+			Node:         sent.alert.Node,
+			Message:      sent.alert.Message,
+			StartsAt:     sent.alert.StartsAt,
+			LastSentAt:   metav1.Time{Time: sent.lastSentAt.UTC().Truncate(time.Second)},
+		})
+	}
+	return sentAlerts
+}
+
+// Pending returns the firing alerts due to be sent, and the sent alerts that are resolved. The silenced alerts are
+// not sent, but are not resolved either. The alerts are not recorded as sent until Sent is called.
+func (d *Deduplicator) Pending(alerts []*types.Alert, repeatInterval time.Duration, now time.Time) (firing, resolved []*types.Alert) {
+	current := map[string]bool{}
+	for _, alert := range alerts {
+		current[alert.Fingerprint()] = true
+		if alert.Silenced {
+			continue
+		}
+		sent, ok := d.sent[alert.Fingerprint()]
+		if !ok || (repeatInterval > 0 && now.Sub(sent.lastSentAt) >= repeatInterval) {
+			firing = append(firing, alert)
+		}
+	}
+
+	for fingerprint, sent := range d.sent {
+		if current[fingerprint] {
+			continue
+		}
+		alert := *sent.alert
+		alert.Status = types.AlertStatusResolved
+		alert.EndsAt = now.UTC().Format(time.RFC3339)
+		resolved = append(resolved, &alert)
+	}
+	sort.Slice(resolved, func(i, j int) bool { return resolved[i].Fingerprint() < resolved[j].Fingerprint() })
+	return firing, resolved
+}
+
+// Sent records the firing alerts as sent at the time, and forgets the resolved alerts.
+func (d *Deduplicator) Sent(firing, resolved []*types.Alert, now time.Time) {
+	for _, alert := range firing {
+		d.sent[alert.Fingerprint()] = &sentAlert{alert: alert, lastSentAt: now}
+	}
+	for _, alert := range resolved {
+		delete(d.sent, alert.Fingerprint())
+	}
+}
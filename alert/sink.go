@@ -0,0 +1,184 @@
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/longhorn/longhorn-manager/types"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+const (
+	webhookSinkTimeout = 10 * time.Second
+
+	// EventReasonResolvedSuffix is appended to the alert name as the reason of the event of a resolved alert.
+	EventReasonResolvedSuffix = "Resolved"
+)
+
+// Notification is the JSON body a webhook sink posts.
+type Notification struct {
+	Sink   string         `json:"sink"`
+	Alerts []*types.Alert `json:"alerts"`
+}
+
+// Sink is where the firing and resolved alerts are sent to.
+type Sink interface {
+	Send(ctx context.Context, alerts []*types.Alert) error
+}
+
+// NewSink returns the sink of the type of the AlertSink. The credential is the data of its credential secret, and
+// the event recorder is only used by an event sink.
+func NewSink(alertSink *longhorn.AlertSink, credential map[string]string, eventRecorder record.EventRecorder) (Sink, error) {
+	switch alertSink.Spec.Type {
+	case longhorn.AlertSinkTypeWebhook:
+		return NewWebhookSink(alertSink.Name, alertSink.Spec.URL, credential[types.AlertSinkCredentialWebhookAuthorization]), nil
+	case longhorn.AlertSinkTypeSMTP:
+		return NewSMTPSink(alertSink.Spec.SMTPAddress, alertSink.Spec.SMTPFrom, alertSink.Spec.SMTPTo,
+			credential[types.AlertSinkCredentialSMTPUsername], credential[types.AlertSinkCredentialSMTPPassword]), nil
+	case longhorn.AlertSinkTypeEvent:
+		return NewEventSink(eventRecorder), nil
+	}
+	return nil, fmt.Errorf("unknown alert sink type %v", alertSink.Spec.Type)
+}
+
+// WebhookSink posts the alerts to a URL as a JSON Notification.
+type WebhookSink struct {
+	name          string
+	url           string
+	authorization string
+	client        *http.Client
+}
+
+func NewWebhookSink(name, url, authorization string) *WebhookSink {
+	return &WebhookSink{
+		name:          name,
+		url:           url,
+		authorization: authorization,
+		client:        &http.Client{Timeout: webhookSinkTimeout},
+	}
+}
+
+func (s *WebhookSink) Send(ctx context.Context, alerts []*types.Alert) error {
+	body, err := json.Marshal(&Notification{Sink: s.name, Alerts: alerts})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the alerts")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.authorization != "" {
+		req.Header.Set("Authorization", s.authorization)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to post the alerts to %v", s.url)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to post the alerts to %v: %v", s.url, resp.Status)
+	}
+	return nil
+}
+
+// SMTPSink emails the alerts through an SMTP relay. The relay is authenticated with the username and password if
+// they are provided, which requires the relay to support STARTTLS unless it is on localhost.
+type SMTPSink struct {
+	address  string
+	from     string
+	to       []string
+	username string
+	password string
+}
+
+func NewSMTPSink(address, from string, to []string, username, password string) *SMTPSink {
+	return &SMTPSink{
+		address:  address,
+		from:     from,
+		to:       to,
+		username: username,
+		password: password,
+	}
+}
+
+func (s *SMTPSink) Send(ctx context.Context, alerts []*types.Alert) error {
+	var auth smtp.Auth
+	if s.username != "" {
+		host, _, err := net.SplitHostPort(s.address)
+		if err != nil {
+			return errors.Wrapf(err, "invalid SMTP relay address %v", s.address)
+		}
+		auth = smtp.PlainAuth("", s.username, s.password, host)
+	}
+
+	msg := formatSMTPMessage(s.from, s.to, alerts, time.Now())
+	if err := smtp.SendMail(s.address, auth, s.from, s.to, msg); err != nil {
+		return errors.Wrapf(err, "failed to email the alerts through %v", s.address)
+	}
+	return nil
+}
+
+func formatSMTPMessage(from string, to []string, alerts []*types.Alert, now time.Time) []byte {
+	firing, resolved := 0, 0
+	for _, alert := range alerts {
+		if alert.Status == types.AlertStatusResolved {
+			resolved++
+		} else {
+			firing++
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %v\r\n", from)
+	fmt.Fprintf(&b, "To: %v\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&b, "Subject: [Longhorn] %d firing, %d resolved alerts\r\n", firing, resolved)
+	fmt.Fprintf(&b, "Date: %v\r\n", now.Format(time.RFC1123Z))
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	for _, alert := range alerts {
+		fmt.Fprintf(&b, "[%v] %v (%v) %v %v: %v\r\n", strings.ToUpper(alert.Status), alert.Name, alert.Severity,
+			alert.Kind, alert.Resource, alert.Message)
+	}
+	return []byte(b.String())
+}
+
+// EventSink records the alerts as Kubernetes events of the Longhorn objects they are derived from. The reason of the
+// event is the alert name, suffixed by EventReasonResolvedSuffix for a resolved alert.
+type EventSink struct {
+	eventRecorder record.EventRecorder
+}
+
+func NewEventSink(eventRecorder record.EventRecorder) *EventSink {
+	return &EventSink{
+		eventRecorder: eventRecorder,
+	}
+}
+
+func (s *EventSink) Send(ctx context.Context, alerts []*types.Alert) error {
+	for _, alert := range alerts {
+		if alert.Object == nil {
+			continue
+		}
+		if alert.Status == types.AlertStatusResolved {
+			s.eventRecorder.Event(alert.Object, corev1.EventTypeNormal, alert.Name+EventReasonResolvedSuffix, alert.Message)
+		} else {
+			s.eventRecorder.Event(alert.Object, corev1.EventTypeWarning, alert.Name, alert.Message)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/longhorn/longhorn-manager/types"
+)
+
+func TestWebhookSink(t *testing.T) {
+	assert := require.New(t)
+
+	var notification Notification
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		assert.NoError(json.NewDecoder(r.Body).Decode(&notification))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	alerts := []*types.Alert{{Name: types.AlertNameVolumeFaulted, Status: types.AlertStatusFiring, Resource: "vol1"}}
+	err := NewWebhookSink("sink1", server.URL, "Bearer token").Send(context.Background(), alerts)
+	assert.NoError(err)
+	assert.Equal("Bearer token", authorization)
+	assert.Equal("sink1", notification.Sink)
+	assert.Len(notification.Alerts, 1)
+	assert.Equal("VolumeFaulted/vol1", notification.Alerts[0].Fingerprint())
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	err = NewWebhookSink("sink1", failing.URL, "").Send(context.Background(), alerts)
+	assert.Error(err)
+}
+
+func TestFormatSMTPMessage(t *testing.T) {
+	assert := require.New(t)
+
+	alerts := []*types.Alert{
+		{Name: types.AlertNameNodeDown, Severity: types.AlertSeverityCritical, Status: types.AlertStatusFiring, Kind: types.AlertKindNode, Resource: "node1", Message: "node node1 is down"},
+		{Name: types.AlertNameVolumeDegraded, Severity: types.AlertSeverityWarning, Status: types.AlertStatusResolved, Kind: types.AlertKindVolume, Resource: "vol1", Message: "volume vol1 is degraded"},
+	}
+	msg := string(formatSMTPMessage("longhorn@example.com", []string{"a@example.com", "b@example.com"}, alerts, time.Now()))
+
+	assert.Contains(msg, "To: a@example.com, b@example.com\r\n")
+	assert.Contains(msg, "Subject: [Longhorn] 1 firing, 1 resolved alerts\r\n")
+	assert.Contains(msg, "[FIRING] NodeDown (critical) Node node1: node node1 is down\r\n")
+	assert.Contains(msg, "[RESOLVED] VolumeDegraded (warning) Volume vol1: volume vol1 is degraded\r\n")
+	assert.True(strings.Index(msg, "\r\n\r\n") < strings.Index(msg, "[FIRING]"))
+}
+
+func TestDeduplicator(t *testing.T) {
+	assert := require.New(t)
+
+	now := time.Now()
+	d := NewDeduplicator(nil)
+	degraded := &types.Alert{Name: types.AlertNameVolumeDegraded, Status: types.AlertStatusFiring, Resource: "vol1"}
+	silenced := &types.Alert{Name: types.AlertNameVolumeDegraded, Status: types.AlertStatusFiring, Resource: "vol2", Silenced: true}
+
+	firing, resolved := d.Pending([]*types.Alert{degraded, silenced}, time.Hour, now)
+	assert.Equal([]*types.Alert{degraded}, firing)
+	assert.Empty(resolved)
+	d.Sent(firing, resolved, now)
+
+	// Remembered by a deduplicator built from the sent alerts
+	sentAlerts := d.SentAlerts()
+	assert.Len(sentAlerts, 1)
+	assert.Equal("vol1", sentAlerts[0].ResourceName)
+	restored := NewDeduplicator(sentAlerts)
+	firing, resolved = restored.Pending([]*types.Alert{degraded, silenced}, time.Hour, now.Add(time.Minute))
+	assert.Empty(firing)
+	assert.Empty(resolved)
+	firing, resolved = restored.Pending(nil, time.Hour, now.Add(time.Minute))
+	assert.Empty(firing)
+	assert.Len(resolved, 1)
+	assert.Equal("VolumeDegraded/vol1", resolved[0].Fingerprint())
+
+	// Not sent again before the repeat interval
+	firing, resolved = d.Pending([]*types.Alert{degraded, silenced}, time.Hour, now.Add(time.Minute))
+	assert.Empty(firing)
+	assert.Empty(resolved)
+
+	// Sent again after the repeat interval
+	firing, _ = d.Pending([]*types.Alert{degraded}, time.Hour, now.Add(time.Hour))
+	assert.Equal([]*types.Alert{degraded}, firing)
+
+	// Never sent again without a repeat interval
+	firing, _ = d.Pending([]*types.Alert{degraded}, 0, now.Add(24*time.Hour))
+	assert.Empty(firing)
+
+	// Resolved once it no longer fires, until Sent forgets it
+	firing, resolved = d.Pending(nil, time.Hour, now.Add(2*time.Minute))
+	assert.Empty(firing)
+	assert.Len(resolved, 1)
+	assert.Equal(types.AlertStatusResolved, resolved[0].Status)
+	assert.NotEmpty(resolved[0].EndsAt)
+	assert.Equal(types.AlertStatusFiring, degraded.Status)
+	d.Sent(firing, resolved, now.Add(2*time.Minute))
+
+	_, resolved = d.Pending(nil, time.Hour, now.Add(3*time.Minute))
+	assert.Empty(resolved)
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/rancher/go-rancher/api"
+
+	"github.com/longhorn/longhorn-manager/types"
+)
+
+// AlertList returns the firing alerts, including the silenced ones. When the API authentication is enabled, only the
+// alerts of the resources the client is allowed to list are returned.
+func (s *Server) AlertList(rw http.ResponseWriter, req *http.Request) error {
+	alerts, err := s.m.ListAlerts()
+	if err != nil {
+		return errors.Wrap(err, "failed to list alerts")
+	}
+
+	enabled, err := s.m.GetSettingAsBool(types.SettingNameAPIAuthentication)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get %v setting", types.SettingNameAPIAuthentication)
+	}
+	if enabled {
+		if alerts, err = filterAlertsByAccess(s.authorizer, req, alerts); err != nil {
+			return err
+		}
+	}
+
+	api.GetApiContext(req).Write(toAlertCollection(alerts))
+	return nil
+}
+
+// filterAlertsByAccess returns the alerts of the kinds whose resources the client of the request is allowed to list.
+func filterAlertsByAccess(authorizer *APIAuthorizer, req *http.Request, alerts []*types.Alert) ([]*types.Alert, error) {
+	allowedKinds := map[string]bool{}
+	for kind, access := range alertKindAccesses {
+		allowed, err := authorizer.isAllowed(req, access)
+		if err != nil {
+			return nil, err
+		}
+		allowedKinds[kind] = allowed
+	}
+
+	filtered := []*types.Alert{}
+	for _, alert := range alerts {
+		if allowedKinds[alert.Kind] {
+			filtered = append(filtered, alert)
+		}
+	}
+	return filtered, nil
+}
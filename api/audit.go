@@ -0,0 +1,143 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/longhorn/longhorn-manager/audit"
+	"github.com/longhorn/longhorn-manager/datastore"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+// auditMaxRequestSize is the max size of the request body kept in the audit record.
+const auditMaxRequestSize = 64 * 1024
+
+type auditResponseWriter struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (w *auditResponseWriter) WriteHeader(statusCode int) {
+	w.statusCode = statusCode
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *auditResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *auditResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(w.ResponseWriter).Hijack()
+}
+
+// auditHandler wraps the handler to write an audit record for each mutating request, when the audit log is enabled.
+func (s *Server) auditHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost && req.Method != http.MethodPut && req.Method != http.MethodDelete || !s.auditor.Enabled() {
+			h.ServeHTTP(rw, req)
+			return
+		}
+
+		record := &audit.Record{
+			Source:        audit.SourceAPI,
+			SourceAddress: req.RemoteAddr,
+			Operation:     req.Method,
+			Action:        req.URL.Query().Get("action"),
+			Path:          req.URL.Path,
+			Request:       getAuditRequestBody(req),
+		}
+		resource := ""
+		if collection, name, _, err := getAPIRouteTarget(req); err == nil {
+			record.Resource = collection
+			record.Name = name
+			if access := getAPICollectionAccess(collection); access.group == longhorn.SchemeGroupVersion.Group && access.resource == collection {
+				resource = collection
+			}
+		}
+
+		var before []byte
+		if resource != "" && record.Name != "" {
+			before = s.getAuditObject(resource, record.Name)
+		}
+
+		writer := &auditResponseWriter{ResponseWriter: rw, statusCode: http.StatusOK}
+		h.ServeHTTP(writer, req.WithContext(audit.NewContext(req.Context(), record)))
+
+		record.StatusCode = writer.statusCode
+		switch {
+		case writer.statusCode == http.StatusUnauthorized || writer.statusCode == http.StatusForbidden:
+			record.Result = audit.ResultDenied
+		case writer.statusCode >= http.StatusBadRequest:
+			record.Result = audit.ResultFailure
+		default:
+			record.Result = audit.ResultSuccess
+		}
+		// The forwarded request is recorded with the change by the manager it is forwarded to
+		if before != nil && record.Result == audit.ResultSuccess && record.ForwardedTo == "" {
+			s.setAuditChange(record, resource, before)
+		}
+		s.auditor.Log(record)
+	})
+}
+
+// getAuditObject returns the JSON of the Longhorn resource the request is made on, or nil if it cannot be found.
+func (s *Server) getAuditObject(resource, name string) []byte {
+	obj, err := s.m.GetLonghornResourceRawUncached(resource, name)
+	if err != nil {
+		if !datastore.ErrorIsNotFound(err) {
+			logrus.WithError(err).Warnf("Failed to get %v %v for the audit record", resource, name)
+		}
+		return nil
+	}
+	return obj
+}
+
+// setAuditChange sets the diff from the object before the request to the object after it, or the deleted object if
+// it is gone after the request.
+func (s *Server) setAuditChange(record *audit.Record, resource string, before []byte) {
+	var err error
+	after := s.getAuditObject(resource, record.Name)
+	if after == nil {
+		record.Deleted, err = audit.TrimObject(before)
+	} else {
+		record.Diff, err = audit.GetDiff(before, after)
+		if string(record.Diff) == "{}" {
+			record.Diff = nil
+		}
+	}
+	if err != nil {
+		logrus.WithError(err).Warnf("Failed to get the change of %v %v for the audit record", resource, record.Name)
+	}
+}
+
+// getAuditRequestBody returns the JSON body of the request, and puts the body back for the handler. The bodies of
+// other content types, like the uploaded files, are not read.
+func getAuditRequestBody(req *http.Request) json.RawMessage {
+	if req.Body == nil || req.ContentLength == 0 || req.ContentLength > auditMaxRequestSize {
+		return nil
+	}
+	if mediaType, _, err := mime.ParseMediaType(req.Header.Get("Content-Type")); err != nil || mediaType != "application/json" {
+		return nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(req.Body, auditMaxRequestSize+1))
+	if err != nil {
+		logrus.WithError(err).Warnf("Failed to read the body of %v %v for the audit record", req.Method, req.URL.Path)
+	}
+	req.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), req.Body))
+
+	if err != nil || len(body) > auditMaxRequestSize || !json.Valid(body) {
+		return nil
+	}
+	return body
+}
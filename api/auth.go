@@ -0,0 +1,439 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gorilla/mux"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+
+	"github.com/longhorn/longhorn-manager/audit"
+	"github.com/longhorn/longhorn-manager/types"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+const (
+	// apiAuthCacheTTL is how long the result of a TokenReview or a SubjectAccessReview is reused, so that a client
+	// polling the API doesn't send a review to the Kubernetes API server for every request.
+	apiAuthCacheTTL = 10 * time.Second
+	// apiAuthCacheMaxEntries is the number of cached results above which the expired ones are purged.
+	apiAuthCacheMaxEntries = 1024
+
+	apiVerbGet    = "get"
+	apiVerbList   = "list"
+	apiVerbWatch  = "watch"
+	apiVerbCreate = "create"
+	apiVerbUpdate = "update"
+	apiVerbDelete = "delete"
+)
+
+// apiAccess is the Kubernetes resource and verb a call to the API is authorized against.
+type apiAccess struct {
+	group    string
+	resource string
+	verb     string
+}
+
+// apiCollectionAccesses lists the API collections that are not backed by the Longhorn resource of the same name.
+var apiCollectionAccesses = map[string]apiAccess{
+	"disktags": {group: longhorn.SchemeGroupVersion.Group, resource: "nodes"},
+	"nodetags": {group: longhorn.SchemeGroupVersion.Group, resource: "nodes"},
+	"events":   {group: "", resource: "events"},
+	"usage":    {group: longhorn.SchemeGroupVersion.Group, resource: "volumes"},
+	"alerts":   {group: longhorn.SchemeGroupVersion.Group, resource: "alertsinks"},
+}
+
+// alertKindAccesses lists the accesses to the resources the alerts of each kind expose the state of. The listed
+// alerts are filtered by them, in addition to the access to the alerts collection.
+var alertKindAccesses = map[string]apiAccess{
+	types.AlertKindVolume:       {group: longhorn.SchemeGroupVersion.Group, resource: "volumes", verb: apiVerbList},
+	types.AlertKindNode:         {group: longhorn.SchemeGroupVersion.Group, resource: "nodes", verb: apiVerbList},
+	types.AlertKindDisk:         {group: longhorn.SchemeGroupVersion.Group, resource: "nodes", verb: apiVerbList},
+	types.AlertKindBackup:       {group: longhorn.SchemeGroupVersion.Group, resource: "backups", verb: apiVerbList},
+	types.AlertKindBackupTarget: {group: longhorn.SchemeGroupVersion.Group, resource: "backuptargets", verb: apiVerbList},
+}
+
+// apiActionAccesses lists the actions that are authorized against another resource or verb. The other actions are
+// authorized as an update of the resource they are called on.
+var apiActionAccesses = map[string]apiAccess{
+	"snapshotCreate":      {group: longhorn.SchemeGroupVersion.Group, resource: "snapshots", verb: apiVerbCreate},
+	"snapshotList":        {group: longhorn.SchemeGroupVersion.Group, resource: "snapshots", verb: apiVerbList},
+	"snapshotGet":         {group: longhorn.SchemeGroupVersion.Group, resource: "snapshots", verb: apiVerbGet},
+	"snapshotDelete":      {group: longhorn.SchemeGroupVersion.Group, resource: "snapshots", verb: apiVerbDelete},
+	"snapshotPurge":       {group: longhorn.SchemeGroupVersion.Group, resource: "snapshots", verb: apiVerbDelete},
+	"snapshotCRCreate":    {group: longhorn.SchemeGroupVersion.Group, resource: "snapshots", verb: apiVerbCreate},
+	"snapshotCRList":      {group: longhorn.SchemeGroupVersion.Group, resource: "snapshots", verb: apiVerbList},
+	"snapshotCRGet":       {group: longhorn.SchemeGroupVersion.Group, resource: "snapshots", verb: apiVerbGet},
+	"snapshotCRDelete":    {group: longhorn.SchemeGroupVersion.Group, resource: "snapshots", verb: apiVerbDelete},
+	"snapshotBackup":      {group: longhorn.SchemeGroupVersion.Group, resource: "backups", verb: apiVerbCreate},
+	"snapshotExport":      {group: longhorn.SchemeGroupVersion.Group, resource: "backingimages", verb: apiVerbCreate},
+	"replicaRemove":       {group: longhorn.SchemeGroupVersion.Group, resource: "replicas", verb: apiVerbDelete},
+	"recurringJobList":    {group: longhorn.SchemeGroupVersion.Group, resource: "volumes", verb: apiVerbGet},
+	VolumeImportAction:    {group: longhorn.SchemeGroupVersion.Group, resource: "volumes", verb: apiVerbCreate},
+	"previewRevert":       {group: longhorn.SchemeGroupVersion.Group, resource: "volumes", verb: apiVerbCreate},
+	"previewRevertDelete": {group: longhorn.SchemeGroupVersion.Group, resource: "volumes", verb: apiVerbDelete},
+	"salvageReport":       {group: longhorn.SchemeGroupVersion.Group, resource: "replicas", verb: apiVerbList},
+	"salvagePreview":      {group: longhorn.SchemeGroupVersion.Group, resource: "replicas", verb: apiVerbList},
+	"pvCreate":            {group: "", resource: "persistentvolumes", verb: apiVerbCreate},
+	"pvcCreate":           {group: "", resource: "persistentvolumeclaims", verb: apiVerbCreate},
+
+	"backupList":         {group: longhorn.SchemeGroupVersion.Group, resource: "backups", verb: apiVerbList},
+	"backupListByVolume": {group: longhorn.SchemeGroupVersion.Group, resource: "backups", verb: apiVerbList},
+	"backupGet":          {group: longhorn.SchemeGroupVersion.Group, resource: "backups", verb: apiVerbGet},
+	"backupDelete":       {group: longhorn.SchemeGroupVersion.Group, resource: "backups", verb: apiVerbDelete},
+
+	"backupBackingImageCreate":  {group: longhorn.SchemeGroupVersion.Group, resource: "backupbackingimages", verb: apiVerbCreate},
+	"backupBackingImageRestore": {group: longhorn.SchemeGroupVersion.Group, resource: "backingimages", verb: apiVerbCreate},
+}
+
+// apiStatusError is an error returned to the API client with a specific HTTP status code. The code of the API error
+// is the text of the status code unless set.
+type apiStatusError struct {
+	statusCode int
+	code       string
+	err        error
+}
+
+func (e *apiStatusError) Error() string {
+	return e.err.Error()
+}
+
+func (e *apiStatusError) Unwrap() error {
+	return e.err
+}
+
+func newUnauthorizedError(format string, args ...interface{}) error {
+	return &apiStatusError{statusCode: http.StatusUnauthorized, err: fmt.Errorf(format, args...)}
+}
+
+func newForbiddenError(format string, args ...interface{}) error {
+	return &apiStatusError{statusCode: http.StatusForbidden, err: fmt.Errorf(format, args...)}
+}
+
+func newBadRequestError(format string, args ...interface{}) error {
+	return &apiStatusError{statusCode: http.StatusBadRequest, err: fmt.Errorf(format, args...)}
+}
+
+// AccessReviewer reviews the bearer tokens and the permissions of the API clients.
+type AccessReviewer interface {
+	CreateTokenReview(review *authenticationv1.TokenReview) (*authenticationv1.TokenReview, error)
+	CreateSubjectAccessReview(review *authorizationv1.SubjectAccessReview) (*authorizationv1.SubjectAccessReview, error)
+}
+
+type apiAuthCacheEntry struct {
+	user     authenticationv1.UserInfo
+	allowed  bool
+	reason   string
+	expireAt time.Time
+}
+
+// APIAuthorizer authenticates the API clients by the Kubernetes TokenReview API, and authorizes the calls by the
+// Kubernetes SubjectAccessReview API.
+type APIAuthorizer struct {
+	reviewer AccessReviewer
+	now      func() time.Time
+
+	lock  sync.Mutex
+	users map[string]*apiAuthCacheEntry
+	calls map[string]*apiAuthCacheEntry
+}
+
+func NewAPIAuthorizer(reviewer AccessReviewer) *APIAuthorizer {
+	return &APIAuthorizer{
+		reviewer: reviewer,
+		now:      time.Now,
+		users:    map[string]*apiAuthCacheEntry{},
+		calls:    map[string]*apiAuthCacheEntry{},
+	}
+}
+
+// Authorize authenticates the client of the request and checks that it is allowed to make the call. It returns the
+// authenticated user, and the errors carry the 401 or 403 status code for the API response.
+func (a *APIAuthorizer) Authorize(req *http.Request) (authenticationv1.UserInfo, error) {
+	access, name, err := getAPIAccess(req)
+	if err != nil {
+		return authenticationv1.UserInfo{}, errors.Wrap(err, "failed to get the access of the request")
+	}
+
+	user, tokenKey, err := a.authenticateRequest(req)
+	if err != nil {
+		return authenticationv1.UserInfo{}, err
+	}
+
+	callKey := strings.Join([]string{tokenKey, access.group, access.resource, access.verb, name}, "/")
+	entry, err := a.review(callKey, user, access, name)
+	if err != nil {
+		return user, err
+	}
+	if !entry.allowed {
+		message := fmt.Sprintf("user %v cannot %v resource %q in API group %q", user.Username, access.verb, access.resource, access.group)
+		if name != "" {
+			message = fmt.Sprintf("%v with name %v", message, name)
+		}
+		if entry.reason != "" {
+			message = fmt.Sprintf("%v: %v", message, entry.reason)
+		}
+		return user, newForbiddenError("%v", message)
+	}
+	return user, nil
+}
+
+// isAllowed returns if the client of the request is allowed the access, in addition to the call it is authorized for.
+func (a *APIAuthorizer) isAllowed(req *http.Request, access apiAccess) (bool, error) {
+	user, tokenKey, err := a.authenticateRequest(req)
+	if err != nil {
+		return false, err
+	}
+
+	callKey := strings.Join([]string{tokenKey, access.group, access.resource, access.verb, ""}, "/")
+	entry, err := a.review(callKey, user, access, "")
+	if err != nil {
+		return false, err
+	}
+	return entry.allowed, nil
+}
+
+// Authenticate returns the user of the bearer token of the request, without checking if the user is allowed to make
+// the call.
+func (a *APIAuthorizer) Authenticate(req *http.Request) (authenticationv1.UserInfo, error) {
+	user, _, err := a.authenticateRequest(req)
+	return user, err
+}
+
+func (a *APIAuthorizer) authenticateRequest(req *http.Request) (authenticationv1.UserInfo, string, error) {
+	token := getBearerToken(req)
+	if token == "" {
+		return authenticationv1.UserInfo{}, "", newUnauthorizedError("missing bearer token")
+	}
+
+	tokenHash := sha256.Sum256([]byte(token))
+	tokenKey := hex.EncodeToString(tokenHash[:])
+
+	user, err := a.authenticate(tokenKey, token)
+	return user, tokenKey, err
+}
+
+func (a *APIAuthorizer) authenticate(tokenKey, token string) (authenticationv1.UserInfo, error) {
+	if entry := a.getCacheEntry(a.users, tokenKey); entry != nil {
+		return entry.user, nil
+	}
+
+	review, err := a.reviewer.CreateTokenReview(&authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token: token,
+		},
+	})
+	if err != nil {
+		return authenticationv1.UserInfo{}, errors.Wrap(err, "failed to review the bearer token")
+	}
+	if !review.Status.Authenticated {
+		if review.Status.Error != "" {
+			return authenticationv1.UserInfo{}, newUnauthorizedError("invalid bearer token: %v", review.Status.Error)
+		}
+		return authenticationv1.UserInfo{}, newUnauthorizedError("invalid bearer token")
+	}
+
+	a.setCacheEntry(a.users, tokenKey, &apiAuthCacheEntry{user: review.Status.User})
+	return review.Status.User, nil
+}
+
+func (a *APIAuthorizer) review(callKey string, user authenticationv1.UserInfo, access apiAccess, name string) (*apiAuthCacheEntry, error) {
+	if entry := a.getCacheEntry(a.calls, callKey); entry != nil {
+		return entry, nil
+	}
+
+	extra := map[string]authorizationv1.ExtraValue{}
+	for key, value := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	review, err := a.reviewer.CreateSubjectAccessReview(&authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Group:    access.group,
+				Resource: access.resource,
+				Verb:     access.verb,
+				Name:     name,
+			},
+			User:   user.Username,
+			Groups: user.Groups,
+			UID:    user.UID,
+			Extra:  extra,
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to review the access of the request")
+	}
+
+	entry := &apiAuthCacheEntry{
+		user:    user,
+		allowed: review.Status.Allowed && !review.Status.Denied,
+		reason:  review.Status.Reason,
+	}
+	a.setCacheEntry(a.calls, callKey, entry)
+	return entry, nil
+}
+
+func (a *APIAuthorizer) getCacheEntry(cache map[string]*apiAuthCacheEntry, key string) *apiAuthCacheEntry {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	entry, ok := cache[key]
+	if !ok || a.now().After(entry.expireAt) {
+		return nil
+	}
+	return entry
+}
+
+func (a *APIAuthorizer) setCacheEntry(cache map[string]*apiAuthCacheEntry, key string, entry *apiAuthCacheEntry) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	now := a.now()
+	if len(cache) >= apiAuthCacheMaxEntries {
+		for k, e := range cache {
+			if now.After(e.expireAt) {
+				delete(cache, k)
+			}
+		}
+	}
+	entry.expireAt = now.Add(apiAuthCacheTTL)
+	cache[key] = entry
+}
+
+// getBearerToken returns the token in the `Authorization: Bearer` header, or the password of the basic
+// authentication, which is the only credential the API client can send.
+func getBearerToken(req *http.Request) string {
+	if authorization := req.Header.Get("Authorization"); strings.HasPrefix(authorization, "Bearer ") {
+		return strings.TrimSpace(strings.TrimPrefix(authorization, "Bearer "))
+	}
+	if _, password, ok := req.BasicAuth(); ok {
+		return password
+	}
+	return ""
+}
+
+// getAPIAccess returns the Kubernetes resource and verb the request is authorized against, and the name of the
+// resource if the request is made on a single object. It is derived from the route, the method and the action of the
+// request.
+func getAPIAccess(req *http.Request) (apiAccess, string, error) {
+	collection, name, watch, err := getAPIRouteTarget(req)
+	if err != nil {
+		return apiAccess{}, "", err
+	}
+
+	access := getAPICollectionAccess(collection)
+	if watch {
+		access.verb = apiVerbWatch
+		return access, "", nil
+	}
+
+	if action := req.URL.Query().Get("action"); action != "" {
+		if actionAccess, ok := apiActionAccesses[action]; ok {
+			if actionAccess.resource != access.resource {
+				name = ""
+			}
+			return actionAccess, name, nil
+		}
+		access.verb = apiVerbUpdate
+		return access, name, nil
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		access.verb = apiVerbList
+		if name != "" {
+			access.verb = apiVerbGet
+		}
+	case http.MethodPost:
+		access.verb = apiVerbCreate
+	case http.MethodPut:
+		access.verb = apiVerbUpdate
+	case http.MethodDelete:
+		access.verb = apiVerbDelete
+	default:
+		return apiAccess{}, "", fmt.Errorf("unknown method %v for API path %v", req.Method, req.URL.Path)
+	}
+	return access, name, nil
+}
+
+// getAPIRouteTarget returns the collection and the object name the request is made on, derived from the path
+// template of the matched route, for example `/v1/volumes/{name}`. The object name is the last variable of the
+// path, and the collection is the segment before it, so that `/v1/volumes/{name}/snapshots/{snapshotName}/export`
+// is made on the snapshot. It also returns if the request is on a websocket stream.
+func getAPIRouteTarget(req *http.Request) (collection, name string, watch bool, err error) {
+	route := mux.CurrentRoute(req)
+	if route == nil {
+		return "", "", false, fmt.Errorf("no route matched for %v", req.URL.Path)
+	}
+	template, err := route.GetPathTemplate()
+	if err != nil {
+		return "", "", false, err
+	}
+
+	segments := strings.Split(strings.Trim(template, "/"), "/")
+	if len(segments) < 2 {
+		return "", "", false, fmt.Errorf("unknown API path %v", template)
+	}
+
+	if segments[1] == "ws" {
+		return segments[len(segments)-1], "", true, nil
+	}
+
+	collection = segments[1]
+	for i := 2; i < len(segments); i++ {
+		if !isAPIPathVariable(segments[i]) {
+			continue
+		}
+		name = mux.Vars(req)[strings.Trim(segments[i], "{}")]
+		if !isAPIPathVariable(segments[i-1]) {
+			collection = segments[i-1]
+		}
+	}
+	return collection, name, false, nil
+}
+
+func isAPIPathVariable(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+func getAPICollectionAccess(collection string) apiAccess {
+	if access, ok := apiCollectionAccesses[collection]; ok {
+		return access
+	}
+	return apiAccess{group: longhorn.SchemeGroupVersion.Group, resource: collection}
+}
+
+// authorize wraps the handler to reject the requests that are not authenticated or authorized, when the API
+// authentication is enabled. When it is disabled, the clients are neither authenticated nor authorized, and the
+// audit records have no user.
+func (s *Server) authorize(h HandleFuncWithError) HandleFuncWithError {
+	return func(rw http.ResponseWriter, req *http.Request) error {
+		enabled, err := s.m.GetSettingAsBool(types.SettingNameAPIAuthentication)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get %v setting", types.SettingNameAPIAuthentication)
+		}
+		if !enabled {
+			return h(rw, req)
+		}
+
+		record := audit.FromContext(req.Context())
+		user, err := s.authorizer.Authorize(req)
+		if record != nil {
+			record.User = user.Username
+			record.Groups = user.Groups
+		}
+		if err != nil {
+			return err
+		}
+		return h(rw, req)
+	}
+}
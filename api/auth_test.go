@@ -0,0 +1,443 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rancher/go-rancher/client"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/longhorn/longhorn-manager/controller"
+	"github.com/longhorn/longhorn-manager/types"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+type fakeAccessReviewer struct {
+	users   map[string]authenticationv1.UserInfo
+	allowed map[string]bool
+
+	tokenReviews  int
+	accessReviews int
+}
+
+func (r *fakeAccessReviewer) CreateTokenReview(review *authenticationv1.TokenReview) (*authenticationv1.TokenReview, error) {
+	r.tokenReviews++
+	user, ok := r.users[review.Spec.Token]
+	review.Status = authenticationv1.TokenReviewStatus{Authenticated: ok, User: user}
+	if !ok {
+		review.Status.Error = "unknown token"
+	}
+	return review, nil
+}
+
+func (r *fakeAccessReviewer) CreateSubjectAccessReview(review *authorizationv1.SubjectAccessReview) (*authorizationv1.SubjectAccessReview, error) {
+	r.accessReviews++
+	attributes := review.Spec.ResourceAttributes
+	review.Status.Allowed = r.allowed[review.Spec.User+"/"+attributes.Verb+"/"+attributes.Resource]
+	return review, nil
+}
+
+func newTestAPIRouter(handler http.HandlerFunc) *mux.Router {
+	r := mux.NewRouter()
+	r.Methods("GET").Path("/v1/volumes").Handler(handler)
+	r.Methods("POST").Path("/v1/volumes").Handler(handler)
+	r.Methods("GET").Path("/v1/volumes/{name}").Handler(handler)
+	r.Methods("DELETE").Path("/v1/volumes/{name}").Handler(handler)
+	r.Methods("POST").Path("/v1/volumes/{name}").Queries("action", "{action}").Handler(handler)
+	r.Methods("GET").Path("/v1/volumes/{name}/snapshots/{snapshotName}/export").Handler(handler)
+	r.Methods("PUT").Path("/v1/nodes/{name}").Handler(handler)
+	r.Methods("GET").Path("/v1/disktags").Handler(handler)
+	r.Methods("GET").Path("/v1/events").Handler(handler)
+	r.Path("/v1/ws/volumes").Handler(handler)
+	r.Path("/v1/ws/{period}/volumes").Handler(handler)
+	return r
+}
+
+func TestGetAPIAccess(t *testing.T) {
+	assert := require.New(t)
+
+	group := longhorn.SchemeGroupVersion.Group
+
+	type testCase struct {
+		method string
+		path   string
+
+		expectedAccess apiAccess
+		expectedName   string
+	}
+	testCases := map[string]testCase{
+		"list collection": {
+			method:         http.MethodGet,
+			path:           "/v1/volumes",
+			expectedAccess: apiAccess{group: group, resource: "volumes", verb: apiVerbList},
+		},
+		"create in collection": {
+			method:         http.MethodPost,
+			path:           "/v1/volumes",
+			expectedAccess: apiAccess{group: group, resource: "volumes", verb: apiVerbCreate},
+		},
+		"get object": {
+			method:         http.MethodGet,
+			path:           "/v1/volumes/vol-1",
+			expectedAccess: apiAccess{group: group, resource: "volumes", verb: apiVerbGet},
+			expectedName:   "vol-1",
+		},
+		"delete object": {
+			method:         http.MethodDelete,
+			path:           "/v1/volumes/vol-1",
+			expectedAccess: apiAccess{group: group, resource: "volumes", verb: apiVerbDelete},
+			expectedName:   "vol-1",
+		},
+		"update object": {
+			method:         http.MethodPut,
+			path:           "/v1/nodes/node-1",
+			expectedAccess: apiAccess{group: group, resource: "nodes", verb: apiVerbUpdate},
+			expectedName:   "node-1",
+		},
+		"action as update of the object": {
+			method:         http.MethodPost,
+			path:           "/v1/volumes/vol-1?action=attach",
+			expectedAccess: apiAccess{group: group, resource: "volumes", verb: apiVerbUpdate},
+			expectedName:   "vol-1",
+		},
+		"action on the same resource": {
+			method:         http.MethodPost,
+			path:           "/v1/volumes/vol-1?action=previewRevertDelete",
+			expectedAccess: apiAccess{group: group, resource: "volumes", verb: apiVerbDelete},
+			expectedName:   "vol-1",
+		},
+		"action on another resource": {
+			method:         http.MethodPost,
+			path:           "/v1/volumes/vol-1?action=snapshotCreate",
+			expectedAccess: apiAccess{group: group, resource: "snapshots", verb: apiVerbCreate},
+		},
+		"snapshot export as creation of a backing image": {
+			method:         http.MethodPost,
+			path:           "/v1/volumes/vol-1?action=snapshotExport",
+			expectedAccess: apiAccess{group: group, resource: "backingimages", verb: apiVerbCreate},
+		},
+		"PVC creation as creation of a core resource": {
+			method:         http.MethodPost,
+			path:           "/v1/volumes/vol-1?action=pvcCreate",
+			expectedAccess: apiAccess{group: "", resource: "persistentvolumeclaims", verb: apiVerbCreate},
+		},
+		"PV creation as creation of a core resource": {
+			method:         http.MethodPost,
+			path:           "/v1/volumes/vol-1?action=pvCreate",
+			expectedAccess: apiAccess{group: "", resource: "persistentvolumes", verb: apiVerbCreate},
+		},
+		"nested object": {
+			method:         http.MethodGet,
+			path:           "/v1/volumes/vol-1/snapshots/snap-1/export",
+			expectedAccess: apiAccess{group: group, resource: "snapshots", verb: apiVerbGet},
+			expectedName:   "snap-1",
+		},
+		"collection of another resource": {
+			method:         http.MethodGet,
+			path:           "/v1/disktags",
+			expectedAccess: apiAccess{group: group, resource: "nodes", verb: apiVerbList},
+		},
+		"core collection": {
+			method:         http.MethodGet,
+			path:           "/v1/events",
+			expectedAccess: apiAccess{group: "", resource: "events", verb: apiVerbList},
+		},
+		"watch": {
+			method:         http.MethodGet,
+			path:           "/v1/ws/volumes",
+			expectedAccess: apiAccess{group: group, resource: "volumes", verb: apiVerbWatch},
+		},
+		"watch with period": {
+			method:         http.MethodGet,
+			path:           "/v1/ws/5s/volumes",
+			expectedAccess: apiAccess{group: group, resource: "volumes", verb: apiVerbWatch},
+		},
+	}
+
+	for name, tc := range testCases {
+		var (
+			access    apiAccess
+			accessErr error
+			objName   string
+		)
+		router := newTestAPIRouter(func(rw http.ResponseWriter, req *http.Request) {
+			access, objName, accessErr = getAPIAccess(req)
+		})
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tc.method, tc.path, nil))
+
+		assert.NoError(accessErr, name)
+		assert.Equal(tc.expectedAccess, access, name)
+		assert.Equal(tc.expectedName, objName, name)
+	}
+}
+
+func TestRouterUnauthenticatedPaths(t *testing.T) {
+	assert := require.New(t)
+
+	// The server has no manager to get the authentication setting from, so any of the paths going through the
+	// authorization would panic
+	router := NewRouter(&Server{fwd: &Fwd{}, wsc: &controller.WebsocketController{}})
+	for _, path := range []string{"/metrics", "/v1/schemas", "/v1/openapi.json"} {
+		rw := httptest.NewRecorder()
+		router.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(http.StatusOK, rw.Code, path)
+	}
+}
+
+func TestAPIAuthorizerAuthorize(t *testing.T) {
+	assert := require.New(t)
+
+	reviewer := &fakeAccessReviewer{
+		users: map[string]authenticationv1.UserInfo{
+			"admin-token":  {Username: "admin"},
+			"viewer-token": {Username: "viewer"},
+		},
+		allowed: map[string]bool{
+			"admin/get/volumes":  true,
+			"viewer/get/volumes": true,
+		},
+	}
+
+	type testCase struct {
+		method        string
+		path          string
+		authorization string
+
+		expectedUser       string
+		expectedStatusCode int
+	}
+	testCases := map[string]testCase{
+		"missing token": {
+			method:             http.MethodGet,
+			path:               "/v1/volumes/vol-1",
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		"invalid token": {
+			method:             http.MethodGet,
+			path:               "/v1/volumes/vol-1",
+			authorization:      "Bearer unknown-token",
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		"allowed": {
+			method:        http.MethodGet,
+			path:          "/v1/volumes/vol-1",
+			authorization: "Bearer viewer-token",
+			expectedUser:  "viewer",
+		},
+		"forbidden": {
+			method:             http.MethodDelete,
+			path:               "/v1/volumes/vol-1",
+			authorization:      "Bearer viewer-token",
+			expectedUser:       "viewer",
+			expectedStatusCode: http.StatusForbidden,
+		},
+	}
+
+	authorizer := NewAPIAuthorizer(reviewer)
+	for name, tc := range testCases {
+		var (
+			user         authenticationv1.UserInfo
+			authorizeErr error
+		)
+		router := newTestAPIRouter(func(rw http.ResponseWriter, req *http.Request) {
+			user, authorizeErr = authorizer.Authorize(req)
+		})
+		req := httptest.NewRequest(tc.method, tc.path, nil)
+		if tc.authorization != "" {
+			req.Header.Set("Authorization", tc.authorization)
+		}
+		router.ServeHTTP(httptest.NewRecorder(), req)
+
+		assert.Equal(tc.expectedUser, user.Username, name)
+		if tc.expectedStatusCode == 0 {
+			assert.NoError(authorizeErr, name)
+			continue
+		}
+		statusErr, ok := authorizeErr.(*apiStatusError)
+		assert.True(ok, name)
+		assert.Equal(tc.expectedStatusCode, statusErr.statusCode, name)
+	}
+}
+
+func TestAPIAuthorizerCacheExpiry(t *testing.T) {
+	assert := require.New(t)
+
+	reviewer := &fakeAccessReviewer{
+		users:   map[string]authenticationv1.UserInfo{"admin-token": {Username: "admin"}},
+		allowed: map[string]bool{"admin/get/volumes": true},
+	}
+	now := time.Now()
+	authorizer := NewAPIAuthorizer(reviewer)
+	authorizer.now = func() time.Time { return now }
+
+	var authorizeErr error
+	router := newTestAPIRouter(func(rw http.ResponseWriter, req *http.Request) {
+		_, authorizeErr = authorizer.Authorize(req)
+	})
+	authorize := func() {
+		req := httptest.NewRequest(http.MethodGet, "/v1/volumes/vol-1", nil)
+		req.Header.Set("Authorization", "Bearer admin-token")
+		router.ServeHTTP(httptest.NewRecorder(), req)
+		assert.NoError(authorizeErr)
+	}
+
+	authorize()
+	assert.Equal(1, reviewer.tokenReviews)
+	assert.Equal(1, reviewer.accessReviews)
+
+	now = now.Add(apiAuthCacheTTL / 2)
+	authorize()
+	assert.Equal(1, reviewer.tokenReviews)
+	assert.Equal(1, reviewer.accessReviews)
+
+	now = now.Add(apiAuthCacheTTL)
+	authorize()
+	assert.Equal(2, reviewer.tokenReviews)
+	assert.Equal(2, reviewer.accessReviews)
+}
+
+func TestFilterAlertsByAccess(t *testing.T) {
+	assert := require.New(t)
+
+	reviewer := &fakeAccessReviewer{
+		users: map[string]authenticationv1.UserInfo{
+			"admin-token":  {Username: "admin"},
+			"viewer-token": {Username: "viewer"},
+		},
+		allowed: map[string]bool{
+			"admin/list/volumes":       true,
+			"admin/list/nodes":         true,
+			"admin/list/backups":       true,
+			"admin/list/backuptargets": true,
+			"viewer/list/volumes":      true,
+		},
+	}
+	alerts := []*types.Alert{
+		{Name: types.AlertNameVolumeFaulted, Kind: types.AlertKindVolume, Resource: "vol-1"},
+		{Name: types.AlertNameNodeDown, Kind: types.AlertKindNode, Resource: "node-1"},
+		{Name: types.AlertNameDiskPressure, Kind: types.AlertKindDisk, Resource: "node-1/disk-1"},
+		{Name: types.AlertNameBackupFailed, Kind: types.AlertKindBackup, Resource: "backup-1"},
+		{Name: types.AlertNameBackupTargetUnavailable, Kind: types.AlertKindBackupTarget, Resource: "default"},
+	}
+
+	type testCase struct {
+		authorization string
+
+		expectedFingerprints []string
+		expectError          bool
+	}
+	testCases := map[string]testCase{
+		"all resources allowed": {
+			authorization: "Bearer admin-token",
+			expectedFingerprints: []string{
+				"VolumeFaulted/vol-1", "NodeDown/node-1", "DiskPressure/node-1/disk-1", "BackupFailed/backup-1",
+				"BackupTargetUnavailable/default",
+			},
+		},
+		"volumes allowed": {
+			authorization:        "Bearer viewer-token",
+			expectedFingerprints: []string{"VolumeFaulted/vol-1"},
+		},
+		"invalid token": {
+			authorization: "Bearer unknown-token",
+			expectError:   true,
+		},
+	}
+
+	authorizer := NewAPIAuthorizer(reviewer)
+	for name, tc := range testCases {
+		req := httptest.NewRequest(http.MethodGet, "/v1/alerts", nil)
+		req.Header.Set("Authorization", tc.authorization)
+
+		filtered, err := filterAlertsByAccess(authorizer, req, alerts)
+		if tc.expectError {
+			assert.Error(err, name)
+			continue
+		}
+		assert.NoError(err, name)
+		fingerprints := []string{}
+		for _, alert := range filtered {
+			fingerprints = append(fingerprints, alert.Fingerprint())
+		}
+		assert.Equal(tc.expectedFingerprints, fingerprints, name)
+	}
+}
+
+func TestHandleErrorStatusCode(t *testing.T) {
+	assert := require.New(t)
+
+	type testCase struct {
+		err error
+
+		expectedStatusCode      int
+		expectedCode            string
+		expectedWWWAuthenticate string
+	}
+	testCases := map[string]testCase{
+		"unauthorized": {
+			err:                     newUnauthorizedError("missing bearer token"),
+			expectedStatusCode:      http.StatusUnauthorized,
+			expectedCode:            "Unauthorized",
+			expectedWWWAuthenticate: `Bearer realm="longhorn"`,
+		},
+		"forbidden": {
+			err:                newForbiddenError("user viewer cannot delete resource"),
+			expectedStatusCode: http.StatusForbidden,
+			expectedCode:       "Forbidden",
+		},
+		"internal": {
+			err:                fmt.Errorf("failed to create volume"),
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedCode:       "Internal Server Error",
+		},
+		"storage quota exceeded": {
+			err: errors.Wrap(&apierrors.StatusError{ErrStatus: metav1.Status{
+				Code:    http.StatusForbidden,
+				Reason:  metav1.StatusReasonForbidden,
+				Message: "admission webhook denied the request: volume vol namespace default exceeds the storage quota quota",
+				Details: &metav1.StatusDetails{Causes: []metav1.StatusCause{{Type: types.StorageQuotaExceededCode}}},
+			}}, "failed to create volume"),
+			expectedStatusCode: http.StatusForbidden,
+			expectedCode:       types.StorageQuotaExceededCode,
+		},
+		"rejected by another webhook": {
+			err: errors.Wrap(&apierrors.StatusError{ErrStatus: metav1.Status{
+				Code:    http.StatusUnprocessableEntity,
+				Reason:  metav1.StatusReasonInvalid,
+				Message: "admission webhook denied the request: invalid volume",
+				Details: &metav1.StatusDetails{Causes: []metav1.StatusCause{{Type: metav1.CauseTypeFieldValueInvalid}}},
+			}}, "failed to create volume"),
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedCode:       "Internal Server Error",
+		},
+	}
+
+	schemas := NewSchema()
+	for name, tc := range testCases {
+		handler := HandleError(schemas, func(rw http.ResponseWriter, req *http.Request) error {
+			return tc.err
+		})
+		rw := httptest.NewRecorder()
+		handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/v1/volumes", nil))
+
+		assert.Equal(tc.expectedStatusCode, rw.Code, name)
+		assert.Equal(tc.expectedWWWAuthenticate, rw.Header().Get("WWW-Authenticate"), name)
+
+		apiErr := client.ServerApiError{}
+		assert.NoError(json.Unmarshal(rw.Body.Bytes(), &apiErr), name)
+		assert.Equal(tc.expectedCode, apiErr.Code, name)
+	}
+}
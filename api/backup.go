@@ -181,7 +181,11 @@ func (s *Server) BackupTargetSync(w http.ResponseWriter, req *http.Request) erro
 func (s *Server) BackupVolumeList(w http.ResponseWriter, req *http.Request) error {
 	apiContext := api.GetApiContext(req)
 
-	bvs, err := s.backupVolumeList(apiContext)
+	opts, err := getListOptions(req, backupVolumeFieldGetters.fields())
+	if err != nil {
+		return err
+	}
+	bvs, err := s.backupVolumeList(apiContext, opts)
 	if err != nil {
 		return err
 	}
@@ -189,12 +193,21 @@ func (s *Server) BackupVolumeList(w http.ResponseWriter, req *http.Request) erro
 	return nil
 }
 
-func (s *Server) backupVolumeList(apiContext *api.ApiContext) (*client.GenericCollection, error) {
+// backupVolumeFieldGetters are the field filters supported by the backup volume list.
+var backupVolumeFieldGetters = listFieldGetters[*longhorn.BackupVolume]{
+	"volume":       func(bv *longhorn.BackupVolume) string { return bv.Spec.VolumeName },
+	"backupTarget": func(bv *longhorn.BackupVolume) string { return bv.Spec.BackupTargetName },
+}
+
+func (s *Server) backupVolumeList(apiContext *api.ApiContext, opts *listOptions) (*client.GenericCollection, error) {
 	bvs, err := s.m.ListBackupVolumesSorted()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list backup volume")
 	}
-	return toBackupVolumeCollection(bvs, apiContext), nil
+	bvs, pagination := filterAndPaginateObjects(bvs, backupVolumeFieldGetters, opts, apiContext)
+	resp := toBackupVolumeCollection(bvs, apiContext)
+	resp.Pagination = pagination
+	return resp, nil
 }
 
 func (s *Server) BackupVolumeGet(w http.ResponseWriter, req *http.Request) error {
@@ -311,12 +324,22 @@ func (s *Server) BackupListByVolume(w http.ResponseWriter, req *http.Request) er
 	return nil
 }
 
-func (s *Server) backupListAll(apiContext *api.ApiContext) (*client.GenericCollection, error) {
+// backupFieldGetters are the field filters supported by the backup list.
+var backupFieldGetters = listFieldGetters[*longhorn.Backup]{
+	"volume":       func(b *longhorn.Backup) string { return b.Status.VolumeName },
+	"backupTarget": func(b *longhorn.Backup) string { return b.Status.BackupTargetName },
+	"state":        func(b *longhorn.Backup) string { return string(b.Status.State) },
+}
+
+func (s *Server) backupListAll(apiContext *api.ApiContext, opts *listOptions) (*client.GenericCollection, error) {
 	bs, err := s.m.ListAllBackupsSorted()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list all backups")
 	}
-	return toBackupCollection(bs), nil
+	bs, pagination := filterAndPaginateObjects(bs, backupFieldGetters, opts, apiContext)
+	resp := toBackupCollection(bs)
+	resp.Pagination = pagination
+	return resp, nil
 }
 
 func (s *Server) BackupGet(w http.ResponseWriter, req *http.Request) error {
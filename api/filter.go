@@ -0,0 +1,209 @@
+package api
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rancher/go-rancher/api"
+	"github.com/rancher/go-rancher/client"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ListQueryLabelSelector = "labelSelector"
+	ListQueryLimit         = "limit"
+	ListQueryContinue      = "continue"
+	// ListQueryIncremental makes a websocket stream send the changes of the list instead of the full list after the
+	// first message.
+	ListQueryIncremental = "incremental"
+
+	listQueryAction = "action"
+)
+
+// listOptions are the label selector, the field filters and the pagination of a list request, taken from the query
+// parameters. The query parameters other than the reserved ones are the field filters, and a filter that is not
+// supported by the listed type fails the request. The query parameters of a list without field filters are ignored.
+type listOptions struct {
+	labelSelector labels.Selector
+	fields        map[string]string
+	limit         int
+	continueFrom  string
+	incremental   bool
+	// query is kept to build the link to the next page, since the URL builder of the API context drops the query.
+	query url.Values
+}
+
+// listFieldGetters maps the names of the field filters supported by a type to the getters of the field values.
+type listFieldGetters[T metav1.Object] map[string]func(obj T) string
+
+// fields returns the sorted names of the supported field filters.
+func (g listFieldGetters[T]) fields() []string {
+	fields := make([]string, 0, len(g))
+	for field := range g {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// getListOptions returns the list options of the request. The fields are the names of the field filters supported by
+// the listed type, and no fields means the list ignores the other query parameters.
+func getListOptions(req *http.Request, fields []string) (*listOptions, error) {
+	opts := &listOptions{
+		labelSelector: labels.Everything(),
+		fields:        map[string]string{},
+	}
+	query := req.URL.Query()
+	opts.query = query
+
+	if value := query.Get(ListQueryLabelSelector); value != "" {
+		selector, err := labels.Parse(value)
+		if err != nil {
+			return nil, newBadRequestError("invalid %v %q: %v", ListQueryLabelSelector, value, err)
+		}
+		opts.labelSelector = selector
+	}
+
+	// The websocket streams always send the whole filtered list
+	if !isStreamRequest(req) {
+		if value := query.Get(ListQueryLimit); value != "" {
+			limit, err := strconv.Atoi(value)
+			if err != nil || limit < 0 {
+				return nil, newBadRequestError("invalid %v %q: should be a non-negative integer", ListQueryLimit, value)
+			}
+			opts.limit = limit
+		}
+		if value := query.Get(ListQueryContinue); value != "" {
+			continueFrom, err := base64.RawURLEncoding.DecodeString(value)
+			if err != nil {
+				return nil, newBadRequestError("invalid %v token %q", ListQueryContinue, value)
+			}
+			opts.continueFrom = string(continueFrom)
+		}
+	} else {
+		opts.incremental, _ = strconv.ParseBool(query.Get(ListQueryIncremental))
+	}
+
+	for key, values := range query {
+		switch key {
+		case ListQueryLabelSelector, ListQueryLimit, ListQueryContinue, ListQueryIncremental, listQueryAction:
+			continue
+		}
+		if len(values) == 0 || values[0] == "" || len(fields) == 0 {
+			continue
+		}
+		if !slices.Contains(fields, key) {
+			return nil, newBadRequestError("unknown filter %q: should be one of %v", key, strings.Join(fields, ", "))
+		}
+		opts.fields[key] = values[0]
+	}
+	return opts, nil
+}
+
+func isStreamRequest(req *http.Request) bool {
+	return strings.HasPrefix(req.URL.Path, "/v1/ws/")
+}
+
+// filterObjects returns the objects matching the label selector and the supported field filters. The field values
+// are compared case-insensitively, and a filter matches if the value is any of its comma separated values.
+func filterObjects[T metav1.Object](objects []T, opts *listOptions, getters listFieldGetters[T]) []T {
+	filtered := []T{}
+	for _, obj := range objects {
+		if !opts.labelSelector.Matches(labels.Set(obj.GetLabels())) {
+			continue
+		}
+		matched := true
+		for field, value := range opts.fields {
+			getter, ok := getters[field]
+			if !ok || !matchFieldFilter(getter(obj), value) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			filtered = append(filtered, obj)
+		}
+	}
+	return filtered
+}
+
+func matchFieldFilter(actual, filter string) bool {
+	for _, value := range strings.Split(filter, ",") {
+		if strings.EqualFold(actual, strings.TrimSpace(value)) {
+			return true
+		}
+	}
+	return false
+}
+
+// paginateObjects sorts the objects by name and returns the page after the continue token. The pagination in the
+// response carries the total number of the objects, and the link to the next page if there are more.
+func paginateObjects[T metav1.Object](objects []T, opts *listOptions, apiContext *api.ApiContext) ([]T, *client.Pagination) {
+	sort.SliceStable(objects, func(i, j int) bool {
+		return objects[i].GetName() < objects[j].GetName()
+	})
+	if opts.limit == 0 && opts.continueFrom == "" {
+		return objects, nil
+	}
+
+	total := int64(len(objects))
+	pagination := &client.Pagination{
+		Total: &total,
+	}
+
+	start := 0
+	if opts.continueFrom != "" {
+		pagination.Marker = base64.RawURLEncoding.EncodeToString([]byte(opts.continueFrom))
+		start = sort.Search(len(objects), func(i int) bool {
+			return objects[i].GetName() > opts.continueFrom
+		})
+	}
+	end := len(objects)
+	if opts.limit > 0 {
+		limit := int64(opts.limit)
+		pagination.Limit = &limit
+		if start+opts.limit < end {
+			end = start + opts.limit
+		}
+	}
+
+	page := objects[start:end]
+	if end < len(objects) && len(page) > 0 {
+		pagination.Partial = true
+		pagination.Next = getListPageLink(apiContext, opts, page[len(page)-1].GetName())
+	}
+	return page, pagination
+}
+
+func getListPageLink(apiContext *api.ApiContext, opts *listOptions, continueFrom string) string {
+	if apiContext == nil || apiContext.UrlBuilder == nil {
+		return ""
+	}
+	u, err := url.Parse(apiContext.UrlBuilder.Current())
+	if err != nil {
+		return ""
+	}
+	query := url.Values{}
+	for key, values := range opts.query {
+		query[key] = values
+	}
+	query.Set(ListQueryContinue, base64.RawURLEncoding.EncodeToString([]byte(continueFrom)))
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// filterAndPaginateObjects applies the list options of the request to the objects.
+func filterAndPaginateObjects[T metav1.Object](objects []T, getters listFieldGetters[T], opts *listOptions, apiContext *api.ApiContext) ([]T, *client.Pagination) {
+	if opts == nil {
+		return objects, nil
+	}
+	return paginateObjects(filterObjects(objects, opts, getters), opts, apiContext)
+}
@@ -0,0 +1,162 @@
+package api
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+func TestGetListOptions(t *testing.T) {
+	assert := require.New(t)
+
+	type testCase struct {
+		path   string
+		fields []string
+
+		expectedFields      map[string]string
+		expectedLimit       int
+		expectedContinue    string
+		expectedIncremental bool
+		expectedStatusCode  int
+	}
+	testCases := map[string]testCase{
+		"no options": {
+			path:           "/v1/volumes",
+			fields:         volumeFieldGetters.fields(),
+			expectedFields: map[string]string{},
+		},
+		"supported fields": {
+			path:           "/v1/volumes?state=attached&robustness=healthy,degraded&node=",
+			fields:         volumeFieldGetters.fields(),
+			expectedFields: map[string]string{"state": "attached", "robustness": "healthy,degraded"},
+		},
+		"unknown field": {
+			path:               "/v1/volumes?stat=attached",
+			fields:             volumeFieldGetters.fields(),
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"query parameter of a list without filters": {
+			path:           "/v1/ws/settings?state=attached",
+			expectedFields: map[string]string{},
+		},
+		"pagination": {
+			path:             "/v1/volumes?limit=2&continue=" + base64.RawURLEncoding.EncodeToString([]byte("vol-2")),
+			fields:           volumeFieldGetters.fields(),
+			expectedFields:   map[string]string{},
+			expectedLimit:    2,
+			expectedContinue: "vol-2",
+		},
+		"invalid limit": {
+			path:               "/v1/volumes?limit=-1",
+			fields:             volumeFieldGetters.fields(),
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"invalid continue": {
+			path:               "/v1/volumes?continue=%21%21",
+			fields:             volumeFieldGetters.fields(),
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"invalid label selector": {
+			path:               "/v1/volumes?labelSelector=a%3D%3D%3Db",
+			fields:             volumeFieldGetters.fields(),
+			expectedStatusCode: http.StatusBadRequest,
+		},
+		"incremental stream without pagination": {
+			path:                "/v1/ws/volumes?incremental=true&limit=2",
+			fields:              volumeFieldGetters.fields(),
+			expectedFields:      map[string]string{},
+			expectedIncremental: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		opts, err := getListOptions(httptest.NewRequest(http.MethodGet, tc.path, nil), tc.fields)
+		if tc.expectedStatusCode != 0 {
+			statusErr, ok := err.(*apiStatusError)
+			assert.True(ok, name)
+			assert.Equal(tc.expectedStatusCode, statusErr.statusCode, name)
+			continue
+		}
+		assert.NoError(err, name)
+		assert.Equal(tc.expectedFields, opts.fields, name)
+		assert.Equal(tc.expectedLimit, opts.limit, name)
+		assert.Equal(tc.expectedContinue, opts.continueFrom, name)
+		assert.Equal(tc.expectedIncremental, opts.incremental, name)
+	}
+}
+
+func TestFilterAndPaginateObjects(t *testing.T) {
+	assert := require.New(t)
+
+	newVolume := func(name, state, zone string) *longhorn.Volume {
+		return &longhorn.Volume{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"zone": zone}},
+			Status:     longhorn.VolumeStatus{State: longhorn.VolumeState(state)},
+		}
+	}
+	volumes := []*longhorn.Volume{
+		newVolume("vol-4", "attached", "zone-b"),
+		newVolume("vol-1", "attached", "zone-a"),
+		newVolume("vol-3", "detached", "zone-a"),
+		newVolume("vol-2", "Attached", "zone-a"),
+	}
+
+	type testCase struct {
+		path string
+
+		expectedNames   []string
+		expectedPartial bool
+	}
+	testCases := map[string]testCase{
+		"all sorted by name": {
+			path:          "/v1/volumes",
+			expectedNames: []string{"vol-1", "vol-2", "vol-3", "vol-4"},
+		},
+		"field filter ignoring case": {
+			path:          "/v1/volumes?state=attached",
+			expectedNames: []string{"vol-1", "vol-2", "vol-4"},
+		},
+		"field filter with multiple values": {
+			path:          "/v1/volumes?state=detached,%20attached",
+			expectedNames: []string{"vol-1", "vol-2", "vol-3", "vol-4"},
+		},
+		"label selector and field filter": {
+			path:          "/v1/volumes?labelSelector=zone%3Dzone-a&state=attached",
+			expectedNames: []string{"vol-1", "vol-2"},
+		},
+		"first page": {
+			path:            "/v1/volumes?limit=2",
+			expectedNames:   []string{"vol-1", "vol-2"},
+			expectedPartial: true,
+		},
+		"last page": {
+			path:          "/v1/volumes?limit=2&continue=" + base64.RawURLEncoding.EncodeToString([]byte("vol-2")),
+			expectedNames: []string{"vol-3", "vol-4"},
+		},
+	}
+
+	for name, tc := range testCases {
+		opts, err := getListOptions(httptest.NewRequest(http.MethodGet, tc.path, nil), volumeFieldGetters.fields())
+		assert.NoError(err, name)
+
+		objects := append([]*longhorn.Volume{}, volumes...)
+		filtered, pagination := filterAndPaginateObjects(objects, volumeFieldGetters, opts, nil)
+		names := []string{}
+		for _, v := range filtered {
+			names = append(names, v.Name)
+		}
+		assert.Equal(tc.expectedNames, names, name)
+		if pagination != nil {
+			assert.Equal(tc.expectedPartial, pagination.Partial, name)
+		} else {
+			assert.False(tc.expectedPartial, name)
+		}
+	}
+}
@@ -14,6 +14,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 
+	"github.com/longhorn/longhorn-manager/audit"
 	"github.com/longhorn/longhorn-manager/engineapi"
 	"github.com/longhorn/longhorn-manager/manager"
 	"github.com/longhorn/longhorn-manager/types"
@@ -125,6 +126,9 @@ func (f *Fwd) Handler(proxyHandler ProxyRequestHandler, parametersGetFunc Parame
 			}
 		}
 		if requireProxy {
+			if record := audit.FromContext(req.Context()); record != nil {
+				record.ForwardedTo = req.URL.Host
+			}
 			f.proxy.ServeHTTP(w, req)
 			return nil
 		}
@@ -192,23 +196,31 @@ func (f *Fwd) HandleProxyRequestForBackingImageUpload(parameters map[string]stri
 
 func UploadParametersForBackingImage(m *manager.VolumeManager) func(req *http.Request) (map[string]string, error) {
 	return func(req *http.Request) (map[string]string, error) {
-		name := mux.Vars(req)["name"]
-		pod, err := m.GetBackingImageDataSourcePod(name)
+		address, err := getBackingImageUploadAddress(m, mux.Vars(req)["name"])
 		if err != nil {
 			return nil, err
 		}
-		if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" {
-			return nil, fmt.Errorf("backing image data source pod is not ready for uploading")
-		}
-		bids, err := m.GetBackingImageDataSource(name)
-		if err != nil {
-			return nil, errors.Wrapf(err, "error getting backing image %s", name)
-		}
-		if bids.Status.CurrentState != longhorn.BackingImageStatePending {
-			return nil, fmt.Errorf("upload server for backing image %s has not been initiated", name)
-		}
-		return map[string]string{ParameterKeyAddress: net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(engineapi.BackingImageDataSourceDefaultPort))}, nil
+		return map[string]string{ParameterKeyAddress: address}, nil
+	}
+}
+
+// getBackingImageUploadAddress returns the address of the upload server of the backing image data source pod.
+func getBackingImageUploadAddress(m *manager.VolumeManager, name string) (string, error) {
+	pod, err := m.GetBackingImageDataSourcePod(name)
+	if err != nil {
+		return "", err
+	}
+	if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" {
+		return "", fmt.Errorf("backing image data source pod is not ready for uploading")
+	}
+	bids, err := m.GetBackingImageDataSource(name)
+	if err != nil {
+		return "", errors.Wrapf(err, "error getting backing image %s", name)
 	}
+	if bids.Status.CurrentState != longhorn.BackingImageStatePending {
+		return "", fmt.Errorf("upload server for backing image %s has not been initiated", name)
+	}
+	return net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(engineapi.BackingImageDataSourceDefaultPort)), nil
 }
 
 func (f *Fwd) HandleProxyRequestForBackingImageDownload(parameters map[string]string, req *http.Request) (proxyRequired bool, err error) {
@@ -241,39 +253,44 @@ func (f *Fwd) HandleProxyRequestForBackingImageDownload(parameters map[string]st
 
 func DownloadParametersFromBackingImage(m *manager.VolumeManager) func(req *http.Request) (map[string]string, error) {
 	return func(req *http.Request) (map[string]string, error) {
-		name := mux.Vars(req)["name"]
-		bi, err := m.GetBackingImage(name)
-		if err != nil {
-			return nil, err
-		}
+		return getBackingImageDownloadParameters(m, mux.Vars(req)["name"])
+	}
+}
 
-		var targetBIM *longhorn.BackingImageManager
-		for diskUUID, fStatus := range bi.Status.DiskFileStatusMap {
-			if fStatus.State != longhorn.BackingImageStateReady {
-				continue
-			}
-			bim, err := m.GetDefaultBackingImageManagersByDiskUUID(diskUUID)
-			if err != nil {
-				return nil, err
-			}
-			targetBIM = bim
-			break
-		}
-		if targetBIM == nil {
-			return nil, fmt.Errorf("failed to find a default backing image manager for backing image %v download", name)
-		}
+// getBackingImageDownloadParameters prepares the download of the backing image from a backing image manager with a
+// ready file, and returns the address and the file path to download from.
+func getBackingImageDownloadParameters(m *manager.VolumeManager, name string) (map[string]string, error) {
+	bi, err := m.GetBackingImage(name)
+	if err != nil {
+		return nil, err
+	}
 
-		cli, err := engineapi.NewBackingImageManagerClient(targetBIM)
-		if err != nil {
-			return nil, err
+	var targetBIM *longhorn.BackingImageManager
+	for diskUUID, fStatus := range bi.Status.DiskFileStatusMap {
+		if fStatus.State != longhorn.BackingImageStateReady {
+			continue
 		}
-		filePath, address, err := cli.PrepareDownload(name, bi.Status.UUID)
+		bim, err := m.GetDefaultBackingImageManagersByDiskUUID(diskUUID)
 		if err != nil {
 			return nil, err
 		}
-		return map[string]string{
-			ParameterKeyFilePath: filePath,
-			ParameterKeyAddress:  address,
-		}, nil
+		targetBIM = bim
+		break
+	}
+	if targetBIM == nil {
+		return nil, fmt.Errorf("failed to find a default backing image manager for backing image %v download", name)
+	}
+
+	cli, err := engineapi.NewBackingImageManagerClient(targetBIM)
+	if err != nil {
+		return nil, err
+	}
+	filePath, address, err := cli.PrepareDownload(name, bi.Status.UUID)
+	if err != nil {
+		return nil, err
 	}
+	return map[string]string{
+		ParameterKeyFilePath: filePath,
+		ParameterKeyAddress:  address,
+	}, nil
 }
@@ -12,6 +12,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 
+	"github.com/longhorn/longhorn-manager/audit"
 	"github.com/longhorn/longhorn-manager/controller"
 	"github.com/longhorn/longhorn-manager/datastore"
 	"github.com/longhorn/longhorn-manager/engineapi"
@@ -75,6 +76,7 @@ type Volume struct {
 
 	Conditions       map[string]longhorn.Condition `json:"conditions"`
 	KubernetesStatus longhorn.KubernetesStatus     `json:"kubernetesStatus"`
+	PVCNamespace     string                        `json:"pvcNamespace"`
 	CloneStatus      longhorn.VolumeCloneStatus    `json:"cloneStatus"`
 	Ready            bool                          `json:"ready"`
 
@@ -83,6 +85,13 @@ type Volume struct {
 	ShareState        longhorn.ShareManagerState       `json:"shareState"`
 	OfflineRebuilding longhorn.VolumeOfflineRebuilding `json:"offlineRebuilding"`
 
+	TieringPolicy longhorn.VolumeTieringPolicy `json:"tieringPolicy"`
+	StorageTier   longhorn.StorageTier         `json:"storageTier"`
+
+	TopologySpreadConstraints []longhorn.TopologySpreadConstraint `json:"topologySpreadConstraints"`
+
+	NFSExportOptions longhorn.NFSExportOptions `json:"nfsExportOptions"`
+
 	Migratable bool `json:"migratable"`
 
 	Encrypted bool `json:"encrypted"`
@@ -331,6 +340,11 @@ type SnapshotCRInput struct {
 	Labels map[string]string `json:"labels"`
 }
 
+type SnapshotExportInput struct {
+	Name   string `json:"name"`
+	Format string `json:"format"`
+}
+
 type BackupInput struct {
 	Name string `json:"name"`
 }
@@ -343,6 +357,18 @@ type SalvageInput struct {
 	Names []string `json:"names"`
 }
 
+type PreviewRevertInput struct {
+	// The snapshot to revert to
+	Name   string `json:"name"`
+	TTL    string `json:"ttl"`
+	NodeID string `json:"nodeID"`
+}
+
+type RevertPreviewInput struct {
+	// The revert preview volume
+	Name string `json:"name"`
+}
+
 type EngineUpgradeInput struct {
 	Image string `json:"image"`
 }
@@ -423,6 +449,13 @@ type UpdateOfflineRebuildingInput struct {
 	OfflineRebuilding string `json:"offlineRebuilding"`
 }
 
+type UpdateTieringPolicyInput struct {
+	HotDiskSelector       []string `json:"hotDiskSelector"`
+	ColdDiskSelector      []string `json:"coldDiskSelector"`
+	ColdAfterDetachedDays int64    `json:"coldAfterDetachedDays"`
+	ColdBelowIOPS         int64    `json:"coldBelowIOPS"`
+}
+
 type PVCreateInput struct {
 	PVName string `json:"pvName"`
 	FSType string `json:"fsType"`
@@ -457,6 +490,7 @@ type Node struct {
 	Tags                      []string                      `json:"tags"`
 	Region                    string                        `json:"region"`
 	Zone                      string                        `json:"zone"`
+	Topology                  map[string]string             `json:"topology"`
 	InstanceManagerCPURequest int                           `json:"instanceManagerCPURequest"`
 	AutoEvicting              bool                          `json:"autoEvicting"`
 }
@@ -469,6 +503,8 @@ type DiskStatus struct {
 	ScheduledReplica      map[string]int64              `json:"scheduledReplica"`
 	ScheduledBackingImage map[string]int64              `json:"scheduledBackingImage"`
 	DiskUUID              string                        `json:"diskUUID"`
+	StorageGrowthRate     int64                         `json:"storageGrowthRate"`
+	ProjectedFullTime     string                        `json:"projectedFullTime"`
 }
 
 type DiskInfo struct {
@@ -482,6 +518,12 @@ type DiskUpdateInput struct {
 	Disks map[string]longhorn.DiskSpec `json:"disks"`
 }
 
+type DiskReplaceInput struct {
+	DiskName   string `json:"diskName"`
+	Path       string `json:"path"`
+	DiskDriver string `json:"diskDriver"`
+}
+
 type Event struct {
 	client.Resource
 	Event     corev1.Event `json:"event"`
@@ -540,6 +582,68 @@ type Tag struct {
 	TagType string `json:"tagType"`
 }
 
+type Usage struct {
+	client.Resource
+	Date         string            `json:"date"`
+	Group        map[string]string `json:"group"`
+	VolumeCount  int               `json:"volumeCount"`
+	Size         int64             `json:"size"`
+	ActualSize   int64             `json:"actualSize"`
+	SnapshotSize int64             `json:"snapshotSize"`
+	BackupSize   map[string]int64  `json:"backupSize"`
+}
+
+type SalvageCandidate struct {
+	client.Resource
+	Replica            string   `json:"replica"`
+	NodeID             string   `json:"nodeID"`
+	DiskID             string   `json:"diskID"`
+	FailedAt           string   `json:"failedAt"`
+	Unusable           string   `json:"unusable"`
+	Rank               int      `json:"rank"`
+	Recommended        bool     `json:"recommended"`
+	RevisionCounter    int64    `json:"revisionCounter"`
+	HeadName           string   `json:"headName"`
+	HeadSize           int64    `json:"headSize"`
+	HeadLastModifiedAt string   `json:"headLastModifiedAt"`
+	Dirty              bool     `json:"dirty"`
+	Chain              []string `json:"chain"`
+	MissingSnapshots   []string `json:"missingSnapshots"`
+	Reasons            []string `json:"reasons"`
+}
+
+type SalvageReport struct {
+	client.Resource
+	Volume                  string             `json:"volume"`
+	RevisionCounterDisabled bool               `json:"revisionCounterDisabled"`
+	Candidates              []SalvageCandidate `json:"candidates"`
+	Recommended             []string           `json:"recommended"`
+}
+
+type SalvagePreview struct {
+	client.Resource
+	Volume        string   `json:"volume"`
+	Replicas      []string `json:"replicas"`
+	SourceReplica string   `json:"sourceReplica"`
+	Chain         []string `json:"chain"`
+	LostSnapshots []string `json:"lostSnapshots"`
+	Warnings      []string `json:"warnings"`
+}
+
+type Alert struct {
+	client.Resource
+	Name         string `json:"name"`
+	Severity     string `json:"severity"`
+	Status       string `json:"status"`
+	Kind         string `json:"kind"`
+	ResourceName string `json:"resourceName"`
+	Node         string `json:"node"`
+	Message      string `json:"message"`
+	StartsAt     string `json:"startsAt"`
+	EndsAt       string `json:"endsAt"`
+	Silenced     bool   `json:"silenced"`
+}
+
 type BackupStatus struct {
 	client.Resource
 	Name      string `json:"id"`
@@ -566,21 +670,29 @@ type RestoreStatus struct {
 
 type PurgeStatus struct {
 	client.Resource
-	Error     string `json:"error"`
-	IsPurging bool   `json:"isPurging"`
-	Progress  int    `json:"progress"`
-	Replica   string `json:"replica"`
-	State     string `json:"state"`
+	Error                     string `json:"error"`
+	IsPurging                 bool   `json:"isPurging"`
+	Progress                  int    `json:"progress"`
+	Replica                   string `json:"replica"`
+	State                     string `json:"state"`
+	TotalBytes                int64  `json:"totalBytes"`
+	EstimatedTransferredBytes int64  `json:"estimatedTransferredBytes"`
+	BytesPerSecond            int64  `json:"bytesPerSecond"`
+	ETASeconds                int64  `json:"etaSeconds"`
 }
 
 type RebuildStatus struct {
 	client.Resource
-	Error        string `json:"error"`
-	IsRebuilding bool   `json:"isRebuilding"`
-	Progress     int    `json:"progress"`
-	Replica      string `json:"replica"`
-	State        string `json:"state"`
-	FromReplica  string `json:"fromReplica"`
+	Error                     string `json:"error"`
+	IsRebuilding              bool   `json:"isRebuilding"`
+	Progress                  int    `json:"progress"`
+	Replica                   string `json:"replica"`
+	State                     string `json:"state"`
+	FromReplica               string `json:"fromReplica"`
+	TotalBytes                int64  `json:"totalBytes"`
+	EstimatedTransferredBytes int64  `json:"estimatedTransferredBytes"`
+	BytesPerSecond            int64  `json:"bytesPerSecond"`
+	ETASeconds                int64  `json:"etaSeconds"`
 }
 
 type InstanceManager struct {
@@ -654,6 +766,7 @@ func NewSchema() *client.Schemas {
 	schemas.AddType("detachInput", DetachInput{})
 	schemas.AddType("snapshotInput", SnapshotInput{})
 	schemas.AddType("snapshotCRInput", SnapshotCRInput{})
+	schemas.AddType("snapshotExportInput", SnapshotExportInput{})
 	schemas.AddType("backup", Backup{})
 	schemas.AddType("backupInput", BackupInput{})
 	schemas.AddType("backupStatus", BackupStatus{})
@@ -664,6 +777,8 @@ func NewSchema() *client.Schemas {
 	schemas.AddType("rebuildStatus", RebuildStatus{})
 	schemas.AddType("replicaRemoveInput", ReplicaRemoveInput{})
 	schemas.AddType("salvageInput", SalvageInput{})
+	schemas.AddType("previewRevertInput", PreviewRevertInput{})
+	schemas.AddType("revertPreviewInput", RevertPreviewInput{})
 	schemas.AddType("activateInput", ActivateInput{})
 	schemas.AddType("expandInput", ExpandInput{})
 	schemas.AddType("engineUpgradeInput", EngineUpgradeInput{})
@@ -686,8 +801,12 @@ func NewSchema() *client.Schemas {
 	schemas.AddType("UpdateFreezeFilesystemForSnapshotInput", UpdateFreezeFilesystemForSnapshotInput{})
 	schemas.AddType("UpdateBackupTargetInput", UpdateBackupTargetInput{})
 	schemas.AddType("UpdateOfflineRebuildingInput", UpdateOfflineRebuildingInput{})
+	schemas.AddType("UpdateTieringPolicyInput", UpdateTieringPolicyInput{})
 	schemas.AddType("workloadStatus", longhorn.WorkloadStatus{})
 	schemas.AddType("cloneStatus", longhorn.VolumeCloneStatus{})
+	schemas.AddType("tieringPolicy", longhorn.VolumeTieringPolicy{})
+	schemas.AddType("topologySpreadConstraint", longhorn.TopologySpreadConstraint{})
+	schemas.AddType("nfsExportOptions", longhorn.NFSExportOptions{})
 	schemas.AddType("empty", Empty{})
 
 	schemas.AddType("volumeRecurringJob", VolumeRecurringJob{})
@@ -708,6 +827,11 @@ func NewSchema() *client.Schemas {
 	schemas.AddType("supportBundleInitateInput", SupportBundleInitateInput{})
 
 	schemas.AddType("tag", Tag{})
+	schemas.AddType("usage", Usage{})
+	schemas.AddType("alert", Alert{})
+	schemas.AddType("salvageCandidate", SalvageCandidate{})
+	salvageReportSchema(schemas.AddType("salvageReport", SalvageReport{}))
+	schemas.AddType("salvagePreview", SalvagePreview{})
 
 	schemas.AddType("instanceManager", InstanceManager{})
 	schemas.AddType("instanceProcess", longhorn.InstanceProcess{})
@@ -731,6 +855,7 @@ func NewSchema() *client.Schemas {
 	backingImageSchema(schemas.AddType("backingImage", BackingImage{}))
 	nodeSchema(schemas.AddType("node", Node{}))
 	diskSchema(schemas.AddType("diskUpdateInput", DiskUpdateInput{}))
+	schemas.AddType("diskReplaceInput", DiskReplaceInput{})
 	diskInfoSchema(schemas.AddType("diskInfo", DiskInfo{}))
 	kubernetesStatusSchema(schemas.AddType("kubernetesStatus", longhorn.KubernetesStatus{}))
 	backupTargetListOutputSchema(schemas.AddType("backupTargetListOutput", BackupTargetListOutput{}))
@@ -753,6 +878,10 @@ func nodeSchema(node *client.Schema) {
 			Input:  "diskUpdateInput",
 			Output: "node",
 		},
+		"replaceDisk": {
+			Input:  "diskReplaceInput",
+			Output: "node",
+		},
 	}
 
 	allowScheduling := node.ResourceFields["allowScheduling"]
@@ -811,8 +940,10 @@ func backingImageSchema(backingImage *client.Schema) {
 			Input:  "backingImageCleanupInput",
 			Output: "backingImage",
 		},
-		BackingImageUpload:         {},
-		"backupBackingImageCreate": {},
+		BackingImageUpload: {},
+		"backupBackingImageCreate": {
+			Input: "backupBackingImage",
+		},
 		"updateMinNumberOfCopies": {
 			Input:  "updateMinNumberOfCopiesInput",
 			Output: "backingImage",
@@ -1005,6 +1136,13 @@ func volumeSchema(volume *client.Schema) {
 			Input:  "salvageInput",
 			Output: "volume",
 		},
+		"salvageReport": {
+			Output: "salvageReport",
+		},
+		"salvagePreview": {
+			Input:  "salvageInput",
+			Output: "salvagePreview",
+		},
 		"activate": {
 			Input:  "activateInput",
 			Output: "volume",
@@ -1045,10 +1183,26 @@ func volumeSchema(volume *client.Schema) {
 			Input:  "snapshotInput",
 			Output: "snapshot",
 		},
+		"previewRevert": {
+			Input:  "previewRevertInput",
+			Output: "volume",
+		},
+		"previewRevertPromote": {
+			Input:  "revertPreviewInput",
+			Output: "volume",
+		},
+		"previewRevertDelete": {
+			Input:  "revertPreviewInput",
+			Output: "volume",
+		},
 		"snapshotBackup": {
 			Input:  "snapshotInput",
 			Output: "volume",
 		},
+		"snapshotExport": {
+			Input:  "snapshotExportInput",
+			Output: "backingImage",
+		},
 
 		"snapshotCRCreate": {
 			Input:  "snapshotCRInput",
@@ -1081,15 +1235,18 @@ func volumeSchema(volume *client.Schema) {
 		},
 
 		"updateReplicaCount": {
-			Input: "UpdateReplicaCountInput",
+			Input:  "UpdateReplicaCountInput",
+			Output: "volume",
 		},
 
 		"updateReplicaAutoBalance": {
-			Input: "ReplicaAutoBalance",
+			Input:  "UpdateReplicaAutoBalanceInput",
+			Output: "volume",
 		},
 
 		"updateDataLocality": {
-			Input: "UpdateDataLocalityInput",
+			Input:  "UpdateDataLocalityInput",
+			Output: "volume",
 		},
 
 		"updateAccessMode": {
@@ -1098,47 +1255,63 @@ func volumeSchema(volume *client.Schema) {
 		},
 
 		"updateSnapshotDataIntegrity": {
-			Input: "UpdateSnapshotDataIntegrityInput",
+			Input:  "UpdateSnapshotDataIntegrityInput",
+			Output: "volume",
 		},
 
 		"updateSnapshotMaxCount": {
-			Input: "UpdateSnapshotMaxCountInput",
+			Input:  "UpdateSnapshotMaxCountInput",
+			Output: "volume",
 		},
 
 		"updateSnapshotMaxSize": {
-			Input: "UpdateSnapshotMaxSizeInput",
+			Input:  "UpdateSnapshotMaxSizeInput",
+			Output: "volume",
 		},
 
 		"updateReplicaRebuildingBandwidthLimit": {
-			Input: "UpdateReplicaRebuildingBandwidthLimitInput",
+			Input:  "UpdateReplicaRebuildingBandwidthLimitInput",
+			Output: "volume",
+		},
+
+		"updateTieringPolicy": {
+			Input:  "UpdateTieringPolicyInput",
+			Output: "volume",
 		},
 
 		"updateBackupCompressionMethod": {
-			Input: "UpdateBackupCompressionMethodInput",
+			Input:  "UpdateBackupCompressionInput",
+			Output: "volume",
 		},
 
 		"updateUnmapMarkSnapChainRemoved": {
-			Input: "UpdateUnmapMarkSnapChainRemovedInput",
+			Input:  "UpdateUnmapMarkSnapChainRemovedInput",
+			Output: "volume",
 		},
 
 		"updateReplicaSoftAntiAffinity": {
-			Input: "UpdateReplicaSoftAntiAffinityInput",
+			Input:  "UpdateReplicaSoftAntiAffinityInput",
+			Output: "volume",
 		},
 
 		"updateReplicaZoneSoftAntiAffinity": {
-			Input: "UpdateReplicaZoneSoftAntiAffinityInput",
+			Input:  "UpdateReplicaZoneSoftAntiAffinityInput",
+			Output: "volume",
 		},
 
 		"updateReplicaDiskSoftAntiAffinity": {
-			Input: "UpdateReplicaDiskSoftAntiAffinityInput",
+			Input:  "UpdateReplicaDiskSoftAntiAffinityInput",
+			Output: "volume",
 		},
 
 		"updateFreezeFilesystemForSnapshot": {
-			Input: "UpdateFreezeFilesystemForSnapshotInput",
+			Input:  "UpdateFreezeFilesystemForSnapshotInput",
+			Output: "volume",
 		},
 
 		"updateBackupTargetName": {
-			Input: "UpdateBackupTargetInput",
+			Input:  "UpdateBackupTargetInput",
+			Output: "volume",
 		},
 
 		"pvCreate": {
@@ -1159,7 +1332,8 @@ func volumeSchema(volume *client.Schema) {
 		},
 
 		"engineUpgrade": {
-			Input: "engineUpgradeInput",
+			Input:  "engineUpgradeInput",
+			Output: "volume",
 		},
 	}
 	volume.ResourceFields["controllers"] = client.Field{
@@ -1292,6 +1466,10 @@ func volumeSchema(volume *client.Schema) {
 	nodeSelector.Create = true
 	volume.ResourceFields["nodeSelector"] = nodeSelector
 
+	pvcNamespace := volume.ResourceFields["pvcNamespace"]
+	pvcNamespace.Create = true
+	volume.ResourceFields["pvcNamespace"] = pvcNamespace
+
 	kubernetesStatus := volume.ResourceFields["kubernetesStatus"]
 	kubernetesStatus.Type = "kubernetesStatus"
 	volume.ResourceFields["kubernetesStatus"] = kubernetesStatus
@@ -1300,6 +1478,21 @@ func volumeSchema(volume *client.Schema) {
 	cloneStatus.Type = "cloneStatus"
 	volume.ResourceFields["cloneStatus"] = cloneStatus
 
+	tieringPolicy := volume.ResourceFields["tieringPolicy"]
+	tieringPolicy.Type = "tieringPolicy"
+	tieringPolicy.Create = true
+	volume.ResourceFields["tieringPolicy"] = tieringPolicy
+
+	nfsExportOptions := volume.ResourceFields["nfsExportOptions"]
+	nfsExportOptions.Type = "nfsExportOptions"
+	nfsExportOptions.Create = true
+	volume.ResourceFields["nfsExportOptions"] = nfsExportOptions
+
+	topologySpreadConstraints := volume.ResourceFields["topologySpreadConstraints"]
+	topologySpreadConstraints.Type = "array[topologySpreadConstraint]"
+	topologySpreadConstraints.Create = true
+	volume.ResourceFields["topologySpreadConstraints"] = topologySpreadConstraints
+
 	backupStatus := volume.ResourceFields["backupStatus"]
 	backupStatus.Type = "array[backupStatus]"
 	volume.ResourceFields["backupStatus"] = backupStatus
@@ -1384,6 +1577,12 @@ func systemRestoreSchema(systemRestore *client.Schema) {
 	systemRestore.ResourceFields["systemBackup"] = systemBackup
 }
 
+func salvageReportSchema(salvageReport *client.Schema) {
+	candidates := salvageReport.ResourceFields["candidates"]
+	candidates.Type = "array[salvageCandidate]"
+	salvageReport.ResourceFields["candidates"] = candidates
+}
+
 func snapshotCRListOutputSchema(snapshotList *client.Schema) {
 	data := snapshotList.ResourceFields["data"]
 	data.Type = "array[snapshotCR]"
@@ -1508,6 +1707,12 @@ func toVolumeResource(v *longhorn.Volume, ves []*longhorn.Engine, vrs []*longhor
 					IsPurging: purgeStatus[replica].IsPurging,
 					Progress:  purgeStatus[replica].Progress,
 					State:     purgeStatus[replica].State,
+
+					TotalBytes:                purgeStatus[replica].TotalBytes,
+					EstimatedTransferredBytes: purgeStatus[replica].EstimatedTransferredBytes,
+					BytesPerSecond:            purgeStatus[replica].BytesPerSecond,
+					ETASeconds: types.GetProgressETASeconds(purgeStatus[replica].TotalBytes, purgeStatus[replica].EstimatedTransferredBytes,
+						purgeStatus[replica].BytesPerSecond, purgeStatus[replica].SampledAt, time.Now()),
 				})
 			}
 		}
@@ -1523,6 +1728,12 @@ func toVolumeResource(v *longhorn.Volume, ves []*longhorn.Engine, vrs []*longhor
 					Progress:     rebuildStatus[replica].Progress,
 					State:        rebuildStatus[replica].State,
 					FromReplica:  datastore.ReplicaAddressToReplicaName(rebuildStatus[replica].FromReplicaAddress, vrs),
+
+					TotalBytes:                rebuildStatus[replica].TotalBytes,
+					EstimatedTransferredBytes: rebuildStatus[replica].EstimatedTransferredBytes,
+					BytesPerSecond:            rebuildStatus[replica].BytesPerSecond,
+					ETASeconds: types.GetProgressETASeconds(rebuildStatus[replica].TotalBytes, rebuildStatus[replica].EstimatedTransferredBytes,
+						rebuildStatus[replica].BytesPerSecond, rebuildStatus[replica].SampledAt, time.Now()),
 				})
 			}
 		}
@@ -1657,12 +1868,20 @@ func toVolumeResource(v *longhorn.Volume, ves []*longhorn.Engine, vrs []*longhor
 		ShareState:        v.Status.ShareState,
 		OfflineRebuilding: v.Spec.OfflineRebuilding,
 
+		TieringPolicy: v.Spec.TieringPolicy,
+		StorageTier:   v.Status.StorageTier,
+
+		TopologySpreadConstraints: v.Spec.TopologySpreadConstraints,
+
+		NFSExportOptions: v.Spec.NFSExportOptions,
+
 		Migratable: v.Spec.Migratable,
 
 		Encrypted: v.Spec.Encrypted,
 
 		Conditions:       sliceToMap(v.Status.Conditions),
 		KubernetesStatus: v.Status.KubernetesStatus,
+		PVCNamespace:     types.GetVolumePVCNamespace(v),
 		CloneStatus:      v.Status.CloneStatus,
 
 		Controllers:      controllers,
@@ -1683,13 +1902,18 @@ func toVolumeResource(v *longhorn.Volume, ves []*longhorn.Engine, vrs []*longhor
 
 	if v.Status.Robustness == longhorn.VolumeRobustnessFaulted {
 		actions["salvage"] = struct{}{}
+		actions["salvageReport"] = struct{}{}
+		actions["salvagePreview"] = struct{}{}
 	} else {
 
 		actions["snapshotCRCreate"] = struct{}{}
 		actions["snapshotCRGet"] = struct{}{}
 		actions["snapshotCRList"] = struct{}{}
 		actions["snapshotCRDelete"] = struct{}{}
+		actions["previewRevert"] = struct{}{}
+		actions["previewRevertDelete"] = struct{}{}
 		actions["snapshotBackup"] = struct{}{}
+		actions["snapshotExport"] = struct{}{}
 
 		switch v.Status.State {
 		case longhorn.VolumeStateDetached:
@@ -1709,6 +1933,7 @@ func toVolumeResource(v *longhorn.Volume, ves []*longhorn.Engine, vrs []*longhor
 			actions["updateSnapshotMaxCount"] = struct{}{}
 			actions["updateSnapshotMaxSize"] = struct{}{}
 			actions["updateReplicaRebuildingBandwidthLimit"] = struct{}{}
+			actions["updateTieringPolicy"] = struct{}{}
 			actions["updateBackupCompressionMethod"] = struct{}{}
 			actions["updateReplicaSoftAntiAffinity"] = struct{}{}
 			actions["updateReplicaZoneSoftAntiAffinity"] = struct{}{}
@@ -1734,6 +1959,7 @@ func toVolumeResource(v *longhorn.Volume, ves []*longhorn.Engine, vrs []*longhor
 			actions["snapshotGet"] = struct{}{}
 			actions["snapshotDelete"] = struct{}{}
 			actions["snapshotRevert"] = struct{}{}
+			actions["previewRevertPromote"] = struct{}{}
 			actions["replicaRemove"] = struct{}{}
 			actions["engineUpgrade"] = struct{}{}
 			actions["updateReplicaCount"] = struct{}{}
@@ -1744,6 +1970,7 @@ func toVolumeResource(v *longhorn.Volume, ves []*longhorn.Engine, vrs []*longhor
 			actions["updateSnapshotMaxCount"] = struct{}{}
 			actions["updateSnapshotMaxSize"] = struct{}{}
 			actions["updateReplicaRebuildingBandwidthLimit"] = struct{}{}
+			actions["updateTieringPolicy"] = struct{}{}
 			actions["updateBackupCompressionMethod"] = struct{}{}
 			actions["updateReplicaSoftAntiAffinity"] = struct{}{}
 			actions["updateReplicaZoneSoftAntiAffinity"] = struct{}{}
@@ -1804,7 +2031,7 @@ func toSnapshotCRResource(s *longhorn.Snapshot) *SnapshotCR {
 	}
 }
 
-func toSnapshotCRCollection(snapCRs map[string]*longhorn.Snapshot) *client.GenericCollection {
+func toSnapshotCRCollection(snapCRs []*longhorn.Snapshot) *client.GenericCollection {
 	data := []interface{}{}
 
 	for _, v := range snapCRs {
@@ -2145,16 +2372,20 @@ func toBackingImageCollection(bis []*longhorn.BackingImage, apiContext *api.ApiC
 }
 
 type Server struct {
-	m   *manager.VolumeManager
-	wsc *controller.WebsocketController
-	fwd *Fwd
+	m          *manager.VolumeManager
+	wsc        *controller.WebsocketController
+	fwd        *Fwd
+	authorizer *APIAuthorizer
+	auditor    *audit.Logger
 }
 
 func NewServer(m *manager.VolumeManager, wsc *controller.WebsocketController) *Server {
 	s := &Server{
-		m:   m,
-		wsc: wsc,
-		fwd: NewFwd(m),
+		m:          m,
+		wsc:        wsc,
+		fwd:        NewFwd(m),
+		authorizer: NewAPIAuthorizer(m),
+		auditor:    audit.NewLogger(m.GetCurrentNodeID(), m.GetSettingAuditLogDestination),
 	}
 	return s
 }
@@ -2175,6 +2406,7 @@ func toNodeResource(node *longhorn.Node, address string, apiContext *api.ApiCont
 		Tags:                      node.Spec.Tags,
 		Region:                    node.Status.Region,
 		Zone:                      node.Status.Zone,
+		Topology:                  node.Status.Topology,
 		InstanceManagerCPURequest: node.Spec.InstanceManagerCPURequest,
 		AutoEvicting:              node.Status.AutoEvicting,
 	}
@@ -2193,6 +2425,8 @@ func toNodeResource(node *longhorn.Node, address string, apiContext *api.ApiCont
 				ScheduledReplica:      node.Status.DiskStatus[name].ScheduledReplica,
 				ScheduledBackingImage: node.Status.DiskStatus[name].ScheduledBackingImage,
 				DiskUUID:              node.Status.DiskStatus[name].DiskUUID,
+				StorageGrowthRate:     node.Status.DiskStatus[name].StorageGrowthRate,
+				ProjectedFullTime:     node.Status.DiskStatus[name].ProjectedFullTime,
 			}
 		}
 		disks[name] = di
@@ -2200,7 +2434,8 @@ func toNodeResource(node *longhorn.Node, address string, apiContext *api.ApiCont
 	n.Disks = disks
 
 	n.Actions = map[string]string{
-		"diskUpdate": apiContext.UrlBuilder.ActionLink(n.Resource, "diskUpdate"),
+		"diskUpdate":  apiContext.UrlBuilder.ActionLink(n.Resource, "diskUpdate"),
+		"replaceDisk": apiContext.UrlBuilder.ActionLink(n.Resource, "replaceDisk"),
 	}
 
 	return n
@@ -2348,6 +2583,108 @@ func toTagCollection(tags []string, tagType string, apiContext *api.ApiContext)
 	return &client.GenericCollection{Data: data, Collection: client.Collection{ResourceType: "tag"}}
 }
 
+func toUsageCollection(totals []*types.UsageTotal) *client.GenericCollection {
+	data := []interface{}{}
+	for _, total := range totals {
+		data = append(data, &Usage{
+			Resource: client.Resource{
+				Type:  "usage",
+				Links: map[string]string{},
+			},
+			Date:         total.Date,
+			Group:        total.Group,
+			VolumeCount:  total.VolumeCount,
+			Size:         total.Size,
+			ActualSize:   total.ActualSize,
+			SnapshotSize: total.SnapshotSize,
+			BackupSize:   total.BackupSize,
+		})
+	}
+	return &client.GenericCollection{Data: data, Collection: client.Collection{ResourceType: "usage"}}
+}
+
+func toSalvageReportResource(report *types.SalvageReport) *SalvageReport {
+	candidates := []SalvageCandidate{}
+	for _, c := range report.Candidates {
+		candidate := SalvageCandidate{
+			Resource: client.Resource{
+				Id:   c.Replica,
+				Type: "salvageCandidate",
+			},
+			Replica:          c.Replica,
+			NodeID:           c.NodeID,
+			DiskID:           c.DiskID,
+			FailedAt:         c.FailedAt,
+			Unusable:         c.Unusable,
+			Rank:             c.Rank,
+			Recommended:      c.Recommended,
+			Chain:            []string{},
+			MissingSnapshots: c.MissingSnapshots,
+			Reasons:          c.Reasons,
+		}
+		if c.Metadata != nil {
+			candidate.RevisionCounter = c.Metadata.RevisionCounter
+			candidate.HeadName = c.Metadata.HeadName
+			candidate.HeadSize = c.Metadata.HeadSize
+			candidate.HeadLastModifiedAt = c.Metadata.HeadLastModifiedAt
+			candidate.Dirty = c.Metadata.Dirty
+			if c.Metadata.Chain != nil {
+				candidate.Chain = c.Metadata.Chain
+			}
+		}
+		candidates = append(candidates, candidate)
+	}
+	return &SalvageReport{
+		Resource: client.Resource{
+			Id:   report.Volume,
+			Type: "salvageReport",
+		},
+		Volume:                  report.Volume,
+		RevisionCounterDisabled: report.RevisionCounterDisabled,
+		Candidates:              candidates,
+		Recommended:             report.Recommended,
+	}
+}
+
+func toSalvagePreviewResource(preview *types.SalvagePreview) *SalvagePreview {
+	return &SalvagePreview{
+		Resource: client.Resource{
+			Id:   preview.Volume,
+			Type: "salvagePreview",
+		},
+		Volume:        preview.Volume,
+		Replicas:      preview.Replicas,
+		SourceReplica: preview.SourceReplica,
+		Chain:         preview.Chain,
+		LostSnapshots: preview.LostSnapshots,
+		Warnings:      preview.Warnings,
+	}
+}
+
+func toAlertCollection(alerts []*types.Alert) *client.GenericCollection {
+	data := []interface{}{}
+	for _, alert := range alerts {
+		data = append(data, &Alert{
+			Resource: client.Resource{
+				Id:    alert.Fingerprint(),
+				Type:  "alert",
+				Links: map[string]string{},
+			},
+			Name:         alert.Name,
+			Severity:     alert.Severity,
+			Status:       alert.Status,
+			Kind:         alert.Kind,
+			ResourceName: alert.Resource,
+			Node:         alert.Node,
+			Message:      alert.Message,
+			StartsAt:     alert.StartsAt,
+			EndsAt:       alert.EndsAt,
+			Silenced:     alert.Silenced,
+		})
+	}
+	return &client.GenericCollection{Data: data, Collection: client.Collection{ResourceType: "alert"}}
+}
+
 func toInstanceManagerResource(im *longhorn.InstanceManager) *InstanceManager {
 	return &InstanceManager{
 		Resource: client.Resource{
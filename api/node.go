@@ -10,6 +10,7 @@ import (
 	"github.com/rancher/go-rancher/api"
 	"github.com/rancher/go-rancher/client"
 
+	"github.com/longhorn/longhorn-manager/types"
 	"github.com/longhorn/longhorn-manager/util"
 
 	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
@@ -18,7 +19,11 @@ import (
 func (s *Server) NodeList(rw http.ResponseWriter, req *http.Request) error {
 	apiContext := api.GetApiContext(req)
 
-	nodeList, err := s.nodeList(apiContext)
+	opts, err := getListOptions(req, nodeFieldGetters.fields())
+	if err != nil {
+		return err
+	}
+	nodeList, err := s.nodeList(apiContext, opts)
 	if err != nil {
 		return err
 	}
@@ -26,16 +31,31 @@ func (s *Server) NodeList(rw http.ResponseWriter, req *http.Request) error {
 	return nil
 }
 
-func (s *Server) nodeList(apiContext *api.ApiContext) (*client.GenericCollection, error) {
+// nodeFieldGetters are the field filters supported by the node list.
+var nodeFieldGetters = listFieldGetters[*longhorn.Node]{
+	"region": func(n *longhorn.Node) string { return n.Status.Region },
+	"zone":   func(n *longhorn.Node) string { return n.Status.Zone },
+	"ready": func(n *longhorn.Node) string {
+		return string(types.GetCondition(n.Status.Conditions, longhorn.NodeConditionTypeReady).Status)
+	},
+	"schedulable": func(n *longhorn.Node) string {
+		return string(types.GetCondition(n.Status.Conditions, longhorn.NodeConditionTypeSchedulable).Status)
+	},
+}
+
+func (s *Server) nodeList(apiContext *api.ApiContext, opts *listOptions) (*client.GenericCollection, error) {
 	nodeList, err := s.m.ListNodesSorted()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to list nodes")
 	}
+	nodeList, pagination := filterAndPaginateObjects(nodeList, nodeFieldGetters, opts, apiContext)
 	nodeIPMap, err := s.m.GetManagerNodeIPMap()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get node ip")
 	}
-	return toNodeCollection(nodeList, nodeIPMap, apiContext), nil
+	resp := toNodeCollection(nodeList, nodeIPMap, apiContext)
+	resp.Pagination = pagination
+	return resp, nil
 }
 
 func (s *Server) NodeGet(rw http.ResponseWriter, req *http.Request) error {
@@ -120,6 +140,34 @@ func (s *Server) DiskUpdate(rw http.ResponseWriter, req *http.Request) error {
 	return nil
 }
 
+func (s *Server) DiskReplace(rw http.ResponseWriter, req *http.Request) error {
+	var input DiskReplaceInput
+	apiContext := api.GetApiContext(req)
+	if err := apiContext.Read(&input); err != nil {
+		return err
+	}
+
+	id := mux.Vars(req)["name"]
+
+	nodeIPMap, err := s.m.GetManagerNodeIPMap()
+	if err != nil {
+		return errors.Wrap(err, "failed to get node ip")
+	}
+
+	obj, err := util.RetryOnConflictCause(func() (interface{}, error) {
+		return s.m.ReplaceDisk(id, input.DiskName, input.Path, longhorn.DiskDriver(input.DiskDriver))
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to replace disk %v on node %v", input.DiskName, id)
+	}
+	unode, ok := obj.(*longhorn.Node)
+	if !ok {
+		return fmt.Errorf("failed to convert to node %v object", id)
+	}
+	apiContext.Write(toNodeResource(unode, nodeIPMap[id], apiContext))
+	return nil
+}
+
 func (s *Server) NodeDelete(rw http.ResponseWriter, req *http.Request) error {
 	id := mux.Vars(req)["name"]
 	if err := s.m.DeleteNode(id); err != nil {
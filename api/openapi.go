@@ -0,0 +1,586 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/mux"
+	"github.com/rancher/go-rancher/client"
+)
+
+const (
+	OpenAPIVersion = "3.0.3"
+
+	openAPISchemaRefPrefix = "#/components/schemas/"
+	openAPIContentTypeJSON = "application/json"
+)
+
+type OpenAPIDocument struct {
+	OpenAPI    string                      `json:"openapi"`
+	Info       OpenAPIInfo                 `json:"info"`
+	Paths      map[string]*OpenAPIPathItem `json:"paths"`
+	Components OpenAPIComponents           `json:"components"`
+
+	operationIDs map[string]bool
+}
+
+type OpenAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type OpenAPIComponents struct {
+	Schemas map[string]*OpenAPISchema `json:"schemas"`
+}
+
+type OpenAPIPathItem struct {
+	Get    *OpenAPIOperation `json:"get,omitempty"`
+	Put    *OpenAPIOperation `json:"put,omitempty"`
+	Post   *OpenAPIOperation `json:"post,omitempty"`
+	Delete *OpenAPIOperation `json:"delete,omitempty"`
+}
+
+type OpenAPIOperation struct {
+	OperationID string                      `json:"operationId"`
+	Tags        []string                    `json:"tags,omitempty"`
+	Parameters  []*OpenAPIParameter         `json:"parameters,omitempty"`
+	RequestBody *OpenAPIRequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*OpenAPIResponse `json:"responses"`
+	// Actions lists the input and the output of each value of the `action` query parameter of the operation, which
+	// tells the request body and the response of the action apart.
+	Actions map[string]*OpenAPIAction `json:"x-longhorn-actions,omitempty"`
+}
+
+type OpenAPIParameter struct {
+	Name     string         `json:"name"`
+	In       string         `json:"in"`
+	Required bool           `json:"required,omitempty"`
+	Schema   *OpenAPISchema `json:"schema"`
+}
+
+type OpenAPIRequestBody struct {
+	Required bool                         `json:"required,omitempty"`
+	Content  map[string]*OpenAPIMediaType `json:"content"`
+}
+
+type OpenAPIResponse struct {
+	Description string                       `json:"description"`
+	Content     map[string]*OpenAPIMediaType `json:"content,omitempty"`
+}
+
+type OpenAPIMediaType struct {
+	Schema *OpenAPISchema `json:"schema,omitempty"`
+}
+
+type OpenAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Description          string                    `json:"description,omitempty"`
+	Enum                 []string                  `json:"enum,omitempty"`
+	Default              interface{}               `json:"default,omitempty"`
+	Nullable             bool                      `json:"nullable,omitempty"`
+	MinLength            *int64                    `json:"minLength,omitempty"`
+	MaxLength            *int64                    `json:"maxLength,omitempty"`
+	Minimum              *int64                    `json:"minimum,omitempty"`
+	Maximum              *int64                    `json:"maximum,omitempty"`
+	Items                *OpenAPISchema            `json:"items,omitempty"`
+	Properties           map[string]*OpenAPISchema `json:"properties,omitempty"`
+	AdditionalProperties *OpenAPISchema            `json:"additionalProperties,omitempty"`
+	Required             []string                  `json:"required,omitempty"`
+	AllOf                []*OpenAPISchema          `json:"allOf,omitempty"`
+	OneOf                []*OpenAPISchema          `json:"oneOf,omitempty"`
+	// Actions lists the input and the output of each action of a resource, which are also the request and the
+	// response of the operation of the action. It is used to generate the actions of the client types.
+	Actions map[string]*OpenAPIAction `json:"x-longhorn-actions,omitempty"`
+}
+
+// OpenAPIAction is the input and the output of an action. Either is nil if the action doesn't take or return a body.
+type OpenAPIAction struct {
+	Input  *OpenAPISchema `json:"input,omitempty"`
+	Output *OpenAPISchema `json:"output,omitempty"`
+}
+
+// NewOpenAPIDocument converts the API schemas to an OpenAPI document. The component schemas and their actions are
+// derived from the API schemas, and the paths are derived from the routes of the router if it's not nil.
+func NewOpenAPIDocument(schemas *client.Schemas, router *mux.Router) (*OpenAPIDocument, error) {
+	doc := &OpenAPIDocument{
+		OpenAPI: OpenAPIVersion,
+		Info: OpenAPIInfo{
+			Title:   "Longhorn Manager API",
+			Version: "v1",
+		},
+		Paths: map[string]*OpenAPIPathItem{},
+		Components: OpenAPIComponents{
+			Schemas: map[string]*OpenAPISchema{
+				"collection": openAPICollectionSchema(),
+			},
+		},
+	}
+
+	for _, schema := range schemas.Data {
+		doc.Components.Schemas[schema.Id] = openAPIComponentSchema(schemas, schema)
+	}
+
+	if router == nil {
+		return doc, nil
+	}
+
+	actionRoutes := map[string][]string{}
+	err := router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil || !strings.HasPrefix(path, "/v1/") || isStreamPath(path) {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil
+		}
+		if action := getRouteAction(route); action != "" {
+			actionRoutes[path] = append(actionRoutes[path], action)
+			return nil
+		}
+		for _, method := range methods {
+			addOpenAPIOperation(doc, schemas, path, strings.ToUpper(method))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	actionPaths := []string{}
+	for path := range actionRoutes {
+		actionPaths = append(actionPaths, path)
+	}
+	sort.Strings(actionPaths)
+	for _, path := range actionPaths {
+		actions := actionRoutes[path]
+		sort.Strings(actions)
+		addOpenAPIActionOperation(doc, schemas, path, actions)
+	}
+
+	return doc, nil
+}
+
+// OpenAPIHandler serves the OpenAPI document of the routes of the router. The document is built on the first request,
+// after all the routes are registered.
+func OpenAPIHandler(schemas *client.Schemas, router *mux.Router) http.Handler {
+	var (
+		once sync.Once
+		body []byte
+		err  error
+	)
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		once.Do(func() {
+			var doc *OpenAPIDocument
+			if doc, err = NewOpenAPIDocument(schemas, router); err == nil {
+				body, err = json.Marshal(doc)
+			}
+		})
+		if err != nil {
+			http.Error(rw, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		rw.Header().Set("Content-Type", openAPIContentTypeJSON)
+		_, _ = rw.Write(body)
+	})
+}
+
+func isStreamPath(path string) bool {
+	return strings.HasPrefix(path, "/v1/ws/")
+}
+
+func getRouteAction(route *mux.Route) string {
+	queries, err := route.GetQueriesTemplates()
+	if err != nil {
+		return ""
+	}
+	for _, query := range queries {
+		if value, ok := strings.CutPrefix(query, listQueryAction+"="); ok {
+			return value
+		}
+	}
+	return ""
+}
+
+func openAPIRef(name string) *OpenAPISchema {
+	return &OpenAPISchema{Ref: openAPISchemaRefPrefix + name}
+}
+
+func openAPICollectionSchema() *OpenAPISchema {
+	return &OpenAPISchema{
+		Type: "object",
+		Properties: map[string]*OpenAPISchema{
+			"type":         {Type: "string"},
+			"resourceType": {Type: "string"},
+			"links":        {Type: "object", AdditionalProperties: &OpenAPISchema{Type: "string"}},
+			"pagination": {
+				Type: "object",
+				Properties: map[string]*OpenAPISchema{
+					"marker":  {Type: "string"},
+					"next":    {Type: "string"},
+					"limit":   {Type: "integer", Format: "int64"},
+					"total":   {Type: "integer", Format: "int64"},
+					"partial": {Type: "boolean"},
+				},
+			},
+		},
+	}
+}
+
+func openAPIComponentSchema(schemas *client.Schemas, schema client.Schema) *OpenAPISchema {
+	component := &OpenAPISchema{
+		Type:       "object",
+		Properties: map[string]*OpenAPISchema{},
+	}
+	for name, field := range schema.ResourceFields {
+		property := openAPIFieldSchema(schemas, field.Type)
+		if property.Ref == "" {
+			property.Description = field.Description
+			property.Default = field.Default
+			property.Nullable = field.Nullable
+			property.MinLength = field.MinLength
+			property.MaxLength = field.MaxLength
+			property.Minimum = field.Min
+			property.Maximum = field.Max
+			if len(field.Options) > 0 {
+				property.Enum = field.Options
+			}
+		}
+		component.Properties[name] = property
+		if field.Required {
+			component.Required = append(component.Required, name)
+		}
+	}
+	sort.Strings(component.Required)
+
+	if len(schema.ResourceActions) > 0 {
+		component.Actions = map[string]*OpenAPIAction{}
+		for name, action := range schema.ResourceActions {
+			component.Actions[name] = openAPIAction(schemas, action)
+		}
+	}
+	return component
+}
+
+func openAPIAction(schemas *client.Schemas, action client.Action) *OpenAPIAction {
+	result := &OpenAPIAction{}
+	if action.Input != "" {
+		result.Input = openAPIFieldSchema(schemas, action.Input)
+	}
+	if action.Output != "" {
+		result.Output = openAPIFieldSchema(schemas, action.Output)
+	}
+	return result
+}
+
+// openAPIFieldSchema converts the type of a schema field, for example `array[controller]` or `map[string]`, to the
+// OpenAPI schema.
+func openAPIFieldSchema(schemas *client.Schemas, fieldType string) *OpenAPISchema {
+	switch {
+	case fieldType == "string" || fieldType == "password" || fieldType == "enum":
+		return &OpenAPISchema{Type: "string"}
+	case fieldType == "date":
+		return &OpenAPISchema{Type: "string", Format: "date-time"}
+	case fieldType == "int":
+		return &OpenAPISchema{Type: "integer", Format: "int64"}
+	case fieldType == "float":
+		return &OpenAPISchema{Type: "number", Format: "double"}
+	case fieldType == "bool" || fieldType == "boolean":
+		return &OpenAPISchema{Type: "boolean"}
+	case strings.HasPrefix(fieldType, "array[") && strings.HasSuffix(fieldType, "]"):
+		return &OpenAPISchema{
+			Type:  "array",
+			Items: openAPIFieldSchema(schemas, fieldType[len("array["):len(fieldType)-1]),
+		}
+	case strings.HasPrefix(fieldType, "map[") && strings.HasSuffix(fieldType, "]"):
+		return &OpenAPISchema{
+			Type:                 "object",
+			AdditionalProperties: openAPIFieldSchema(schemas, fieldType[len("map["):len(fieldType)-1]),
+		}
+	case strings.HasPrefix(fieldType, "reference[") && strings.HasSuffix(fieldType, "]"):
+		return &OpenAPISchema{Type: "string"}
+	}
+
+	// The struct fields not overridden by the schema have the Go type as the type, for example
+	// `longhorn.KubernetesStatus`, which may match a schema of the same type.
+	name := fieldType
+	if pkg, typeName, ok := strings.Cut(fieldType, "."); ok {
+		if pkg != "api" && pkg != "longhorn" {
+			return &OpenAPISchema{Type: "object"}
+		}
+		name = typeName
+	}
+	if schema, ok := schemas.CheckSchema(name); ok {
+		return openAPIRef(schema.Id)
+	}
+	return &OpenAPISchema{Type: "object"}
+}
+
+func openAPIJSONContent(schema *OpenAPISchema) map[string]*OpenAPIMediaType {
+	return map[string]*OpenAPIMediaType{
+		openAPIContentTypeJSON: {Schema: schema},
+	}
+}
+
+func openAPIResponses(schema *OpenAPISchema) map[string]*OpenAPIResponse {
+	responses := map[string]*OpenAPIResponse{
+		"200": {
+			Description: "OK",
+		},
+		"default": {
+			Description: "Error",
+			Content:     openAPIJSONContent(openAPIRef("error")),
+		},
+	}
+	if schema != nil {
+		responses["200"].Content = openAPIJSONContent(schema)
+	}
+	return responses
+}
+
+// getOpenAPIPathSchema returns the schema of the first segment of the path after the version, and the rest of the
+// segments.
+func getOpenAPIPathSchema(schemas *client.Schemas, path string) (*client.Schema, []string) {
+	segments := strings.Split(strings.TrimPrefix(path, "/v1/"), "/")
+	for i := range schemas.Data {
+		schema := &schemas.Data[i]
+		if schema.PluralName != "" && strings.EqualFold(schema.PluralName, segments[0]) {
+			return schema, segments[1:]
+		}
+	}
+	return nil, segments[1:]
+}
+
+func getOpenAPIPathParameters(path string) []*OpenAPIParameter {
+	parameters := []*OpenAPIParameter{}
+	for _, segment := range strings.Split(path, "/") {
+		if !strings.HasPrefix(segment, "{") || !strings.HasSuffix(segment, "}") {
+			continue
+		}
+		name := strings.SplitN(strings.Trim(segment, "{}"), ":", 2)[0]
+		parameters = append(parameters, &OpenAPIParameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   &OpenAPISchema{Type: "string"},
+		})
+	}
+	return parameters
+}
+
+func getOpenAPIPathItem(doc *OpenAPIDocument, path string) *OpenAPIPathItem {
+	item, ok := doc.Paths[path]
+	if !ok {
+		item = &OpenAPIPathItem{}
+		doc.Paths[path] = item
+	}
+	return item
+}
+
+// getOpenAPIOperationID returns the ID of the operation, named after the schema if the path is the collection or a
+// resource of the schema, otherwise after the path.
+func getOpenAPIOperationID(doc *OpenAPIDocument, schema *client.Schema, rest []string, method, path string) string {
+	id := ""
+	if schema != nil && len(rest) <= 1 {
+		name := schema.Id
+		if len(rest) == 0 && method != http.MethodPost {
+			name = schema.PluralName
+		}
+		switch method {
+		case http.MethodGet:
+			id = "get"
+			if len(rest) == 0 {
+				id = "list"
+			}
+		case http.MethodPost:
+			id = "create"
+		case http.MethodPut:
+			id = "update"
+		case http.MethodDelete:
+			id = "delete"
+		}
+		id += strings.ToUpper(name[:1]) + name[1:]
+	} else {
+		id = strings.ToLower(method)
+		for _, segment := range strings.Split(strings.TrimPrefix(path, "/v1/"), "/") {
+			if segment == "" || strings.HasPrefix(segment, "{") {
+				continue
+			}
+			segment = strings.Map(func(r rune) rune {
+				if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+					return r
+				}
+				return -1
+			}, segment)
+			id += strings.ToUpper(segment[:1]) + segment[1:]
+		}
+	}
+
+	return uniqueOpenAPIOperationID(doc, id)
+}
+
+// uniqueOpenAPIOperationID makes the ID unique in the document by a number suffix.
+func uniqueOpenAPIOperationID(doc *OpenAPIDocument, id string) string {
+	if doc.operationIDs == nil {
+		doc.operationIDs = map[string]bool{}
+	}
+	unique := id
+	for i := 2; doc.operationIDs[unique]; i++ {
+		unique = id + strconv.Itoa(i)
+	}
+	doc.operationIDs[unique] = true
+	return unique
+}
+
+func addOpenAPIOperation(doc *OpenAPIDocument, schemas *client.Schemas, path, method string) {
+	schema, rest := getOpenAPIPathSchema(schemas, path)
+	op := &OpenAPIOperation{
+		OperationID: getOpenAPIOperationID(doc, schema, rest, method, path),
+		Parameters:  getOpenAPIPathParameters(path),
+	}
+	var response *OpenAPISchema
+	if schema != nil {
+		op.Tags = []string{schema.Id}
+		switch {
+		case len(rest) == 0 && method == http.MethodGet:
+			response = &OpenAPISchema{
+				AllOf: []*OpenAPISchema{
+					openAPIRef("collection"),
+					{
+						Type: "object",
+						Properties: map[string]*OpenAPISchema{
+							"data": {Type: "array", Items: openAPIRef(schema.Id)},
+						},
+					},
+				},
+			}
+		case len(rest) == 0 && method == http.MethodPost, len(rest) == 1 && method == http.MethodPut:
+			op.RequestBody = &OpenAPIRequestBody{
+				Required: true,
+				Content:  openAPIJSONContent(openAPIRef(schema.Id)),
+			}
+			response = openAPIRef(schema.Id)
+		case len(rest) == 1 && method == http.MethodGet:
+			response = openAPIRef(schema.Id)
+		}
+	}
+	if len(op.Parameters) == 0 {
+		op.Parameters = nil
+	}
+	op.Responses = openAPIResponses(response)
+
+	item := getOpenAPIPathItem(doc, path)
+	switch method {
+	case http.MethodGet:
+		item.Get = op
+	case http.MethodPut:
+		item.Put = op
+	case http.MethodPost:
+		item.Post = op
+	case http.MethodDelete:
+		item.Delete = op
+	}
+}
+
+// addOpenAPIActionOperation adds the POST operation of the actions of a resource or a collection. An action is chosen
+// by the `action` query parameter, which is an enum of the actions, and the request body and the response are one of
+// the inputs and the outputs of the actions, listed per action in the x-longhorn-actions extension of the operation.
+// If the path has a POST operation without action already, e.g. the creation of a resource, the actions are merged into
+// it and the `action` query parameter is optional.
+func addOpenAPIActionOperation(doc *OpenAPIDocument, schemas *client.Schemas, path string, actions []string) {
+	schema, rest := getOpenAPIPathSchema(schemas, path)
+	item := getOpenAPIPathItem(doc, path)
+
+	var inputs, outputs []*OpenAPISchema
+	inputRequired := true
+	op := item.Post
+	if op != nil {
+		if op.RequestBody != nil {
+			inputs = append(inputs, op.RequestBody.Content[openAPIContentTypeJSON].Schema)
+		} else {
+			inputRequired = false
+		}
+		if content := op.Responses["200"].Content; content != nil {
+			outputs = append(outputs, content[openAPIContentTypeJSON].Schema)
+		}
+	} else {
+		op = &OpenAPIOperation{
+			Parameters: getOpenAPIPathParameters(path),
+		}
+		operationID := "postAction"
+		if schema != nil {
+			op.Tags = []string{schema.Id}
+			operationID = schema.Id + "Action"
+			if len(rest) == 0 {
+				operationID = schema.PluralName + "Action"
+			}
+		}
+		op.OperationID = uniqueOpenAPIOperationID(doc, operationID)
+	}
+	op.Parameters = append(op.Parameters, &OpenAPIParameter{
+		Name:     listQueryAction,
+		In:       "query",
+		Required: item.Post == nil,
+		Schema:   &OpenAPISchema{Type: "string", Enum: actions},
+	})
+
+	op.Actions = map[string]*OpenAPIAction{}
+	for _, action := range actions {
+		result := &OpenAPIAction{}
+		if schema != nil {
+			schemaAction, ok := schema.ResourceActions[action]
+			if len(rest) == 0 {
+				schemaAction, ok = schema.CollectionActions[action]
+			}
+			if ok {
+				result = openAPIAction(schemas, schemaAction)
+			}
+		}
+		if result.Input != nil {
+			inputs = append(inputs, result.Input)
+		} else {
+			inputRequired = false
+		}
+		if result.Output != nil {
+			outputs = append(outputs, result.Output)
+		}
+		op.Actions[action] = result
+	}
+
+	op.RequestBody = nil
+	if input := openAPIOneOf(inputs); input != nil {
+		op.RequestBody = &OpenAPIRequestBody{
+			Required: inputRequired,
+			Content:  openAPIJSONContent(input),
+		}
+	}
+	op.Responses = openAPIResponses(openAPIOneOf(outputs))
+
+	item.Post = op
+}
+
+// openAPIOneOf returns the schema matching one of the distinct schemas, or nil if there is none.
+func openAPIOneOf(schemas []*OpenAPISchema) *OpenAPISchema {
+	oneOf := []*OpenAPISchema{}
+	seen := map[string]bool{}
+	for _, schema := range schemas {
+		key, err := json.Marshal(schema)
+		if err != nil || seen[string(key)] {
+			continue
+		}
+		seen[string(key)] = true
+		oneOf = append(oneOf, schema)
+	}
+	switch len(oneOf) {
+	case 0:
+		return nil
+	case 1:
+		return oneOf[0]
+	}
+	return &OpenAPISchema{OneOf: oneOf}
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOpenAPIDocumentActions(t *testing.T) {
+	assert := require.New(t)
+
+	handler := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {})
+	router := mux.NewRouter()
+	router.Methods("GET").Path("/v1/volumes/{name}").Handler(handler)
+	for _, action := range []string{"attach", "snapshotList", "cancelExpansion"} {
+		router.Methods("POST").Path("/v1/volumes/{name}").Queries("action", action).Handler(handler)
+	}
+
+	router.Methods("POST").Path("/v1/volumes").Handler(handler)
+	router.Methods("POST").Path("/v1/volumes").Queries("action", VolumeImportAction).Handler(handler)
+
+	doc, err := NewOpenAPIDocument(NewSchema(), router)
+	assert.NoError(err)
+
+	for path := range doc.Paths {
+		assert.NotContains(path, "?", path)
+	}
+
+	pathItem := doc.Paths["/v1/volumes/{name}"]
+	assert.NotNil(pathItem)
+	assert.NotNil(pathItem.Get)
+	op := pathItem.Post
+	assert.NotNil(op)
+	assert.Equal("volumeAction", op.OperationID)
+	assert.Equal([]string{"volume"}, op.Tags)
+	assert.Len(op.Parameters, 2)
+	assert.Equal("name", op.Parameters[0].Name)
+	assert.Equal("action", op.Parameters[1].Name)
+	assert.Equal("query", op.Parameters[1].In)
+	assert.True(op.Parameters[1].Required)
+	assert.Equal([]string{"attach", "cancelExpansion", "snapshotList"}, op.Parameters[1].Schema.Enum)
+
+	// Some actions don't take a body, so the body is optional, and it's the only input of the actions
+	assert.NotNil(op.RequestBody)
+	assert.False(op.RequestBody.Required)
+	assert.Equal(openAPISchemaRefPrefix+"attachInput", op.RequestBody.Content[openAPIContentTypeJSON].Schema.Ref)
+	output := op.Responses["200"].Content[openAPIContentTypeJSON].Schema
+	assert.Equal([]*OpenAPISchema{openAPIRef("volume"), openAPIRef("snapshotListOutput")}, output.OneOf)
+
+	type testCase struct {
+		action string
+
+		expectedInput  string
+		expectedOutput string
+	}
+	testCases := map[string]testCase{
+		"action with input": {
+			action:         "attach",
+			expectedInput:  openAPISchemaRefPrefix + "attachInput",
+			expectedOutput: openAPISchemaRefPrefix + "volume",
+		},
+		"action without input": {
+			action:         "snapshotList",
+			expectedOutput: openAPISchemaRefPrefix + "snapshotListOutput",
+		},
+		"action without input and with the resource as output": {
+			action:         "cancelExpansion",
+			expectedOutput: openAPISchemaRefPrefix + "volume",
+		},
+	}
+
+	for name, tc := range testCases {
+		action, ok := op.Actions[tc.action]
+		assert.True(ok, name)
+		if tc.expectedInput == "" {
+			assert.Nil(action.Input, name)
+		} else {
+			assert.Equal(tc.expectedInput, action.Input.Ref, name)
+		}
+		assert.Equal(tc.expectedOutput, action.Output.Ref, name)
+	}
+
+	// The action of the collection is merged into the creation of the resource
+	op = doc.Paths["/v1/volumes"].Post
+	assert.NotNil(op)
+	assert.Equal("createVolume", op.OperationID)
+	assert.Len(op.Parameters, 1)
+	assert.Equal("action", op.Parameters[0].Name)
+	assert.False(op.Parameters[0].Required)
+	assert.Equal([]string{VolumeImportAction}, op.Parameters[0].Schema.Enum)
+	assert.Contains(op.Actions, VolumeImportAction)
+
+	actions := doc.Components.Schemas["volume"].Actions
+	assert.Equal(openAPISchemaRefPrefix+"attachInput", actions["attach"].Input.Ref)
+	assert.Equal(openAPISchemaRefPrefix+"volume", actions["attach"].Output.Ref)
+}
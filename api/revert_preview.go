@@ -0,0 +1,73 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gorilla/mux"
+
+	"github.com/rancher/go-rancher/api"
+)
+
+func (s *Server) PreviewRevert(rw http.ResponseWriter, req *http.Request) error {
+	var input PreviewRevertInput
+
+	apiContext := api.GetApiContext(req)
+	if err := apiContext.Read(&input); err != nil {
+		return errors.Wrap(err, "failed to read previewRevertInput")
+	}
+
+	var ttl time.Duration
+	if input.TTL != "" {
+		var err error
+		if ttl, err = time.ParseDuration(input.TTL); err != nil {
+			return newBadRequestError("invalid ttl %q: %v", input.TTL, err)
+		}
+	}
+
+	id := mux.Vars(req)["name"]
+
+	preview, err := s.m.PreviewRevert(id, input.Name, input.NodeID, ttl)
+	if err != nil {
+		return err
+	}
+
+	return s.responseWithVolume(rw, req, "", preview)
+}
+
+func (s *Server) PreviewRevertPromote(rw http.ResponseWriter, req *http.Request) error {
+	var input RevertPreviewInput
+
+	apiContext := api.GetApiContext(req)
+	if err := apiContext.Read(&input); err != nil {
+		return errors.Wrap(err, "failed to read revertPreviewInput")
+	}
+
+	id := mux.Vars(req)["name"]
+
+	v, err := s.m.PromoteRevertPreview(id, input.Name)
+	if err != nil {
+		return err
+	}
+
+	return s.responseWithVolume(rw, req, "", v)
+}
+
+func (s *Server) PreviewRevertDelete(rw http.ResponseWriter, req *http.Request) error {
+	var input RevertPreviewInput
+
+	apiContext := api.GetApiContext(req)
+	if err := apiContext.Read(&input); err != nil {
+		return errors.Wrap(err, "failed to read revertPreviewInput")
+	}
+
+	id := mux.Vars(req)["name"]
+
+	v, err := s.m.DeleteRevertPreview(id, input.Name)
+	if err != nil {
+		return err
+	}
+
+	return s.responseWithVolume(rw, req, "", v)
+}
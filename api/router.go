@@ -3,14 +3,17 @@ package api
 import (
 	"net/http"
 
+	"github.com/cockroachdb/errors"
 	"github.com/gorilla/mux"
 	"github.com/sirupsen/logrus"
 
 	"github.com/rancher/go-rancher/api"
 	"github.com/rancher/go-rancher/client"
 
+	"github.com/longhorn/longhorn-manager/audit"
 	"github.com/longhorn/longhorn-manager/datastore"
 	"github.com/longhorn/longhorn-manager/metrics_collector/registry"
+	"github.com/longhorn/longhorn-manager/types"
 )
 
 type HandleFuncWithError func(http.ResponseWriter, *http.Request) error
@@ -19,17 +22,32 @@ func HandleError(s *client.Schemas, t HandleFuncWithError) http.Handler {
 	return api.ApiHandler(s, http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
 		if err := t(rw, req); err != nil {
 			logrus.WithError(err).Warnf("HTTP handling error")
+			if record := audit.FromContext(req.Context()); record != nil {
+				record.Error = err.Error()
+			}
 
 			statusCode := http.StatusInternalServerError
-			if datastore.ErrorIsNotFound(err) {
+			code := ""
+			var statusErr *apiStatusError
+			if errors.As(err, &statusErr) {
+				statusCode, code = statusErr.statusCode, statusErr.code
+			} else if datastore.ErrorIsNotFound(err) {
 				statusCode = http.StatusNotFound
+			} else if datastore.ErrorIsStorageQuotaExceeded(err) {
+				statusCode, code = http.StatusForbidden, types.StorageQuotaExceededCode
+			}
+			if statusCode == http.StatusUnauthorized {
+				rw.Header().Set("WWW-Authenticate", `Bearer realm="longhorn"`)
+			}
+			if code == "" {
+				code = http.StatusText(statusCode)
 			}
-			writeErr(rw, req, err, statusCode)
+			writeErr(rw, req, err, statusCode, code)
 		}
 	}))
 }
 
-func writeErr(rw http.ResponseWriter, req *http.Request, err error, statusCode int) {
+func writeErr(rw http.ResponseWriter, req *http.Request, err error, statusCode int, code string) {
 	apiContext := api.GetApiContext(req)
 	rw.WriteHeader(statusCode)
 	writeErr := apiContext.WriteResource(&client.ServerApiError{
@@ -37,7 +55,7 @@ func writeErr(rw http.ResponseWriter, req *http.Request, err error, statusCode i
 			Type: "error",
 		},
 		Status:  statusCode,
-		Code:    http.StatusText(statusCode),
+		Code:    code,
 		Message: err.Error(),
 	})
 	if writeErr != nil {
@@ -48,7 +66,9 @@ func writeErr(rw http.ResponseWriter, req *http.Request, err error, statusCode i
 func NewRouter(s *Server) *mux.Router {
 	schemas := NewSchema()
 	r := mux.NewRouter().StrictSlash(true)
-	f := HandleError
+	f := func(schemas *client.Schemas, t HandleFuncWithError) http.Handler {
+		return tracingHandler(s.auditHandler(HandleError(schemas, s.authorize(t))))
+	}
 
 	versionsHandler := api.VersionsHandler(schemas, "v1")
 	versionHandler := api.VersionHandler(schemas, "v1")
@@ -59,6 +79,7 @@ func NewRouter(s *Server) *mux.Router {
 	r.Methods("GET").Path("/v1/apiversions/v1").Handler(versionHandler)
 	r.Methods("GET").Path("/v1/schemas").Handler(api.SchemasHandler(schemas))
 	r.Methods("GET").Path("/v1/schemas/{id}").Handler(api.SchemaHandler(schemas))
+	r.Methods("GET").Path("/v1/openapi.json").Handler(OpenAPIHandler(schemas, r))
 
 	r.Methods("GET").Path("/v1/settings").Handler(f(schemas, s.SettingList))
 	r.Methods("GET").Path("/v1/settings/{name}").Handler(f(schemas, s.SettingGet))
@@ -67,17 +88,21 @@ func NewRouter(s *Server) *mux.Router {
 	r.Methods("GET").Path("/v1/volumes").Handler(f(schemas, s.VolumeList))
 	r.Methods("GET").Path("/v1/volumes/{name}").Handler(f(schemas, s.VolumeGet))
 	r.Methods("DELETE").Path("/v1/volumes/{name}").Handler(f(schemas, s.VolumeDelete))
+	r.Methods("POST").Path("/v1/volumes").Queries("action", VolumeImportAction).Handler(f(schemas, s.VolumeImportFromFile))
 	r.Methods("POST").Path("/v1/volumes").Handler(f(schemas, s.fwd.Handler(s.fwd.HandleProxyRequestByNodeID, s.fwd.GetHTTPAddressByNodeID(NodeHasDefaultEngineImage(s.m)), s.VolumeCreate)))
 	volumeActions := map[string]func(http.ResponseWriter, *http.Request) error{
 		"attach":                                s.VolumeAttach,
 		"detach":                                s.VolumeDetach,
 		"salvage":                               s.VolumeSalvage,
+		"salvageReport":                         s.VolumeSalvageReport,
+		"salvagePreview":                        s.VolumeSalvagePreview,
 		"updateDataLocality":                    s.VolumeUpdateDataLocality,
 		"updateAccessMode":                      s.VolumeUpdateAccessMode,
 		"updateUnmapMarkSnapChainRemoved":       s.VolumeUpdateUnmapMarkSnapChainRemoved,
 		"updateSnapshotMaxCount":                s.VolumeUpdateSnapshotMaxCount,
 		"updateSnapshotMaxSize":                 s.VolumeUpdateSnapshotMaxSize,
 		"updateReplicaRebuildingBandwidthLimit": s.VolumeUpdateReplicaRebuildingBandwidthLimit,
+		"updateTieringPolicy":                   s.VolumeUpdateTieringPolicy,
 		"updateReplicaSoftAntiAffinity":         s.VolumeUpdateReplicaSoftAntiAffinity,
 		"updateReplicaZoneSoftAntiAffinity":     s.VolumeUpdateReplicaZoneSoftAntiAffinity,
 		"updateReplicaDiskSoftAntiAffinity":     s.VolumeUpdateReplicaDiskSoftAntiAffinity,
@@ -105,6 +130,11 @@ func NewRouter(s *Server) *mux.Router {
 		"snapshotDelete": s.fwd.Handler(s.fwd.HandleProxyRequestByNodeID, s.fwd.GetHTTPAddressByNodeID(OwnerIDFromVolume(s.m)), s.SnapshotDelete),
 		"snapshotRevert": s.fwd.Handler(s.fwd.HandleProxyRequestByNodeID, s.fwd.GetHTTPAddressByNodeID(OwnerIDFromVolume(s.m)), s.SnapshotRevert),
 		"snapshotBackup": s.fwd.Handler(s.fwd.HandleProxyRequestByNodeID, s.fwd.GetHTTPAddressByNodeID(OwnerIDFromVolume(s.m)), s.SnapshotBackup),
+		"snapshotExport": s.SnapshotExportCreate,
+
+		"previewRevert":        s.PreviewRevert,
+		"previewRevertPromote": s.fwd.Handler(s.fwd.HandleProxyRequestByNodeID, s.fwd.GetHTTPAddressByNodeID(OwnerIDFromVolume(s.m)), s.PreviewRevertPromote),
+		"previewRevertDelete":  s.PreviewRevertDelete,
 
 		"snapshotCRCreate": s.SnapshotCRCreate,
 		"snapshotCRList":   s.SnapshotCRList,
@@ -121,6 +151,8 @@ func NewRouter(s *Server) *mux.Router {
 	for name, action := range volumeActions {
 		r.Methods("POST").Path("/v1/volumes/{name}").Queries("action", name).Handler(f(schemas, action))
 	}
+	r.Methods("GET").Path("/v1/volumes/{name}/snapshots/{snapshotName}/export").Handler(f(schemas, s.SnapshotExport))
+	r.Methods("DELETE").Path("/v1/volumes/{name}/snapshots/{snapshotName}/export").Handler(f(schemas, s.SnapshotExportDelete))
 
 	r.Methods("POST").Path("/v1/backuptargets").Handler(f(schemas, s.BackupTargetCreate))
 	r.Methods("GET").Path("/v1/backuptargets/{backupTargetName}").Handler(f(schemas, s.BackupTargetGet))
@@ -155,7 +187,8 @@ func NewRouter(s *Server) *mux.Router {
 	r.Methods("PUT").Path("/v1/nodes/{name}").Handler(f(schemas, s.NodeUpdate))
 	r.Methods("DELETE").Path("/v1/nodes/{name}").Handler(f(schemas, s.NodeDelete))
 	nodeActions := map[string]func(http.ResponseWriter, *http.Request) error{
-		"diskUpdate": s.DiskUpdate,
+		"diskUpdate":  s.DiskUpdate,
+		"replaceDisk": s.DiskReplace,
 	}
 	for name, action := range nodeActions {
 		r.Methods("POST").Path("/v1/nodes/{name}").Queries("action", name).Handler(f(schemas, action))
@@ -171,6 +204,9 @@ func NewRouter(s *Server) *mux.Router {
 	r.Methods("GET").Path("/v1/disktags").Handler(f(schemas, s.DiskTagList))
 	r.Methods("GET").Path("/v1/nodetags").Handler(f(schemas, s.NodeTagList))
 
+	r.Methods("GET").Path("/v1/usage").Handler(f(schemas, s.UsageList))
+	r.Methods("GET").Path("/v1/alerts").Handler(f(schemas, s.AlertList))
+
 	r.Methods("GET").Path("/v1/instancemanagers").Handler(f(schemas, s.InstanceManagerList))
 	r.Methods("GET").Path("/v1/instancemanagers/{name}").Handler(f(schemas, s.InstanceManagerGet))
 
@@ -232,7 +268,7 @@ func NewRouter(s *Server) *mux.Router {
 	r.Path("/v1/ws/settings").Handler(f(schemas, settingListStream))
 	r.Path("/v1/ws/{period}/settings").Handler(f(schemas, settingListStream))
 
-	volumeListStream := NewStreamHandlerFunc("volumes", s.wsc.NewWatcher("volume", "engine", "replica", "backup"), s.volumeList)
+	volumeListStream := NewFilteredStreamHandlerFunc("volumes", s.wsc.NewWatcher("volume", "engine", "replica", "backup"), volumeFieldGetters.fields(), s.volumeList)
 	r.Path("/v1/ws/volumes").Handler(f(schemas, volumeListStream))
 	r.Path("/v1/ws/{period}/volumes").Handler(f(schemas, volumeListStream))
 
@@ -244,7 +280,7 @@ func NewRouter(s *Server) *mux.Router {
 	r.Path("/v1/ws/orphans").Handler(f(schemas, orphanListStream))
 	r.Path("/v1/ws/{period}/orphans").Handler(f(schemas, orphanListStream))
 
-	nodeListStream := NewStreamHandlerFunc("nodes", s.wsc.NewWatcher("node"), s.nodeList)
+	nodeListStream := NewFilteredStreamHandlerFunc("nodes", s.wsc.NewWatcher("node"), nodeFieldGetters.fields(), s.nodeList)
 	r.Path("/v1/ws/nodes").Handler(f(schemas, nodeListStream))
 	r.Path("/v1/ws/{period}/nodes").Handler(f(schemas, nodeListStream))
 
@@ -260,7 +296,7 @@ func NewRouter(s *Server) *mux.Router {
 	r.Path("/v1/ws/backupbackingimages").Handler(f(schemas, backupBackingImageStream))
 	r.Path("/v1/ws/{period}/backupbackingimages").Handler(f(schemas, backupBackingImageStream))
 
-	backupVolumeStream := NewStreamHandlerFunc("backupvolumes", s.wsc.NewWatcher("backupVolume"), s.backupVolumeList)
+	backupVolumeStream := NewFilteredStreamHandlerFunc("backupvolumes", s.wsc.NewWatcher("backupVolume"), backupVolumeFieldGetters.fields(), s.backupVolumeList)
 	r.Path("/v1/ws/backupvolumes").Handler(f(schemas, backupVolumeStream))
 	r.Path("/v1/ws/{period}/backupvolumes").Handler(f(schemas, backupVolumeStream))
 
@@ -268,15 +304,9 @@ func NewRouter(s *Server) *mux.Router {
 	r.Path("/v1/ws/backuptargets").Handler(f(schemas, backupTargetStream))
 	r.Path("/v1/ws/{period}/backuptargets").Handler(f(schemas, backupTargetStream))
 
-	// TODO:
-	// We haven't found a way to allow passing the volume name as a parameter to filter
-	// per-backup volume's backups change thru. WebSocket endpoint. Either by:
-	// - `/v1/ws/backups/{volName}`
-	// - `/v1/ws/backups?volName=<volName>`
-	// - `/v1/ws/backupvolumes/{backupName}`
-	// Once we enhance this part, the WebSocket endpoint could only send the updates of specific
-	// backup volume changes and decrease the traffic data it sends out.
-	backupStream := NewStreamHandlerFunc("backups", s.wsc.NewWatcher("backup"), s.backupListAll)
+	// The backups of a volume can be streamed by `/v1/ws/backups?volume=<volName>`, and `incremental=true` makes the
+	// stream send only the changed backups.
+	backupStream := NewFilteredStreamHandlerFunc("backups", s.wsc.NewWatcher("backup"), backupFieldGetters.fields(), s.backupListAll)
 	r.Path("/v1/ws/backups").Handler(f(schemas, backupStream))
 	r.Path("/v1/ws/{period}/backups").Handler(f(schemas, backupStream))
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"strconv"
 
 	"github.com/cockroachdb/errors"
 	"github.com/gorilla/mux"
@@ -245,6 +246,12 @@ func (s *Server) SnapshotCRCreate(w http.ResponseWriter, req *http.Request) (err
 	return nil
 }
 
+// snapshotCRFieldGetters are the field filters supported by the snapshot CR list.
+var snapshotCRFieldGetters = listFieldGetters[*longhorn.Snapshot]{
+	"readyToUse":  func(s *longhorn.Snapshot) string { return strconv.FormatBool(s.Status.ReadyToUse) },
+	"userCreated": func(s *longhorn.Snapshot) string { return strconv.FormatBool(s.Status.UserCreated) },
+}
+
 func (s *Server) SnapshotCRList(w http.ResponseWriter, req *http.Request) (err error) {
 	defer func() {
 		err = errors.Wrap(err, "failed to list snapshot CRs")
@@ -252,11 +259,25 @@ func (s *Server) SnapshotCRList(w http.ResponseWriter, req *http.Request) (err e
 
 	volName := mux.Vars(req)["name"]
 
+	opts, err := getListOptions(req, snapshotCRFieldGetters.fields())
+	if err != nil {
+		return err
+	}
+
 	snapCRsRO, err := s.m.ListSnapshotsCR(volName)
 	if err != nil {
 		return err
 	}
-	api.GetApiContext(req).Write(toSnapshotCRCollection(snapCRsRO))
+	snapCRs := make([]*longhorn.Snapshot, 0, len(snapCRsRO))
+	for _, snapCR := range snapCRsRO {
+		snapCRs = append(snapCRs, snapCR)
+	}
+
+	apiContext := api.GetApiContext(req)
+	snapCRs, pagination := filterAndPaginateObjects(snapCRs, snapshotCRFieldGetters, opts, apiContext)
+	resp := toSnapshotCRCollection(snapCRs)
+	resp.Pagination = pagination
+	apiContext.Write(resp)
 
 	return nil
 }
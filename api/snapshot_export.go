@@ -0,0 +1,356 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gorilla/mux"
+	"github.com/rancher/go-rancher/api"
+	"github.com/sirupsen/logrus"
+
+	"github.com/longhorn/longhorn-manager/types"
+	"github.com/longhorn/longhorn-manager/util"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+const (
+	SnapshotExportQueryFormat = "format"
+
+	// SnapshotExportHeaderChecksum is the SHA512 checksum of the exported file.
+	SnapshotExportHeaderChecksum = "X-Longhorn-Checksum-Sha512"
+	// SnapshotExportHeaderVirtualSize is the size of the exported snapshot.
+	SnapshotExportHeaderVirtualSize = "X-Longhorn-Virtual-Size"
+	// SnapshotExportHeaderRealSize is the size of the data of the exported file, without the holes of the sparse file.
+	SnapshotExportHeaderRealSize = "X-Longhorn-Real-Size"
+
+	// snapshotExportRetryAfter is the number of seconds the client waits before requesting an export in progress again.
+	snapshotExportRetryAfter = 10
+
+	VolumeImportAction        = "importFromFile"
+	VolumeImportQueryName     = "name"
+	VolumeImportQuerySize     = "size"
+	VolumeImportQueryVolSize  = "volumeSize"
+	VolumeImportQueryReplicas = "numberOfReplicas"
+	VolumeImportQueryEngine   = "dataEngine"
+	VolumeImportQueryChecksum = "checksum"
+
+	// volumeImportAttachTimeout is how long the import waits for the volume to be attached to the current node.
+	volumeImportAttachTimeout  = 5 * time.Minute
+	volumeImportAttachInterval = 2 * time.Second
+
+	volumeImportBufferSize = 4 << 20
+	volumeImportBlockSize  = 4096
+)
+
+// qcow2Magic is the magic number at the beginning of a qcow2 file.
+var qcow2Magic = []byte{'Q', 'F', 'I', 0xfb}
+
+func newServiceUnavailableError(format string, args ...interface{}) error {
+	return &apiStatusError{statusCode: http.StatusServiceUnavailable, err: fmt.Errorf(format, args...)}
+}
+
+func newUnsupportedMediaTypeError(format string, args ...interface{}) error {
+	return &apiStatusError{statusCode: http.StatusUnsupportedMediaType, err: fmt.Errorf(format, args...)}
+}
+
+// SnapshotExportCreate starts the export of the snapshot as a raw or qcow2 file, which is staged in a backing image
+// until it's downloaded by SnapshotExport. It returns the backing image, and postpones its garbage collection if it
+// exists already.
+func (s *Server) SnapshotExportCreate(rw http.ResponseWriter, req *http.Request) error {
+	var input SnapshotExportInput
+
+	apiContext := api.GetApiContext(req)
+	if err := apiContext.Read(&input); err != nil {
+		return err
+	}
+	if input.Name == "" {
+		return newBadRequestError("snapshot name is required")
+	}
+	if input.Format == "" {
+		input.Format = types.SnapshotExportFormatRAW
+	}
+	if err := types.ValidateSnapshotExportFormat(input.Format); err != nil {
+		return newBadRequestError("%v", err)
+	}
+
+	bi, err := s.m.ExportSnapshot(mux.Vars(req)["name"], input.Name, input.Format)
+	if err != nil {
+		return err
+	}
+	apiContext.Write(toBackingImageResource(bi, apiContext))
+	return nil
+}
+
+// SnapshotExport streams the snapshot exported by SnapshotExportCreate, which is proxied from a backing image manager
+// once the backing image staging it is ready. Until then the request fails with 503 and a Retry-After header.
+func (s *Server) SnapshotExport(rw http.ResponseWriter, req *http.Request) error {
+	volumeName, snapshotName := mux.Vars(req)["name"], mux.Vars(req)["snapshotName"]
+
+	format := req.URL.Query().Get(SnapshotExportQueryFormat)
+	if format == "" {
+		format = types.SnapshotExportFormatRAW
+	}
+	if err := types.ValidateSnapshotExportFormat(format); err != nil {
+		return newBadRequestError("%v", err)
+	}
+
+	bi, err := s.m.GetSnapshotExport(volumeName, snapshotName, format)
+	if err != nil {
+		return err
+	}
+
+	ready, failed, progress := false, true, 0
+	for _, fileStatus := range bi.Status.DiskFileStatusMap {
+		switch fileStatus.State {
+		case longhorn.BackingImageStateReady:
+			ready = true
+		case longhorn.BackingImageStateFailed:
+			continue
+		}
+		failed = false
+		progress = max(progress, fileStatus.Progress)
+	}
+	if !ready {
+		if failed && len(bi.Status.DiskFileStatusMap) != 0 {
+			return fmt.Errorf("failed to export snapshot %v of volume %v, delete the export to retry", snapshotName, volumeName)
+		}
+		rw.Header().Set("Retry-After", strconv.Itoa(snapshotExportRetryAfter))
+		return newServiceUnavailableError("snapshot %v of volume %v is being exported to backing image %v, %v%% done", snapshotName, volumeName, bi.Name, progress)
+	}
+
+	rw.Header().Set(SnapshotExportHeaderChecksum, bi.Status.Checksum)
+	rw.Header().Set(SnapshotExportHeaderVirtualSize, strconv.FormatInt(bi.Status.VirtualSize, 10))
+	rw.Header().Set(SnapshotExportHeaderRealSize, strconv.FormatInt(bi.Status.RealSize, 10))
+
+	download := s.fwd.Handler(s.fwd.HandleProxyRequestForBackingImageDownload,
+		func(req *http.Request) (map[string]string, error) {
+			return getBackingImageDownloadParameters(s.m, bi.Name)
+		},
+		func(rw http.ResponseWriter, req *http.Request) error {
+			return fmt.Errorf("failed to proxy the request to other servers for the export of snapshot %v of volume %v", snapshotName, volumeName)
+		})
+	return download(rw, req)
+}
+
+// SnapshotExportDelete deletes the exported file of the snapshot before it expires.
+func (s *Server) SnapshotExportDelete(rw http.ResponseWriter, req *http.Request) error {
+	volumeName, snapshotName := mux.Vars(req)["name"], mux.Vars(req)["snapshotName"]
+
+	format := req.URL.Query().Get(SnapshotExportQueryFormat)
+	if format == "" {
+		format = types.SnapshotExportFormatRAW
+	}
+	if err := types.ValidateSnapshotExportFormat(format); err != nil {
+		return newBadRequestError("%v", err)
+	}
+
+	return s.m.DeleteSnapshotExport(volumeName, snapshotName, format)
+}
+
+// VolumeImportFromFile creates a volume from the raw file in the body of the request, either the body itself or the
+// first file of a multipart body. The volume is attached to the current node, and the file is written straight to its
+// block device, skipping the blocks of zeros. The file is verified against the SHA512 checksum if it's given, and the
+// volume is deleted if the import fails. Only raw files are imported: a qcow2 file is rejected before the volume is
+// created, and should be uploaded as a backing image instead.
+func (s *Server) VolumeImportFromFile(rw http.ResponseWriter, req *http.Request) error {
+	query := req.URL.Query()
+
+	name := query.Get(VolumeImportQueryName)
+	if name == "" {
+		return newBadRequestError("%v is required", VolumeImportQueryName)
+	}
+	fileSize, err := strconv.ParseInt(query.Get(VolumeImportQuerySize), 10, 64)
+	if err != nil || fileSize <= 0 {
+		return newBadRequestError("invalid %v %q: should be the size of the file in bytes", VolumeImportQuerySize, query.Get(VolumeImportQuerySize))
+	}
+	size := util.RoundUpSize(fileSize)
+	if value := query.Get(VolumeImportQueryVolSize); value != "" {
+		if size, err = util.ConvertSize(value); err != nil {
+			return newBadRequestError("invalid %v %q: %v", VolumeImportQueryVolSize, value, err)
+		}
+		if size < fileSize {
+			return newBadRequestError("%v %v is smaller than the file of %v bytes", VolumeImportQueryVolSize, value, fileSize)
+		}
+	}
+	numberOfReplicas := 0
+	if value := query.Get(VolumeImportQueryReplicas); value != "" {
+		if numberOfReplicas, err = strconv.Atoi(value); err != nil {
+			return newBadRequestError("invalid %v %q: %v", VolumeImportQueryReplicas, value, err)
+		}
+	}
+	file, err := getVolumeImportFile(req)
+	if err != nil {
+		return newBadRequestError("%v", err)
+	}
+	if file, err = checkVolumeImportFileFormat(file); err != nil {
+		return err
+	}
+
+	if _, err := s.m.ImportVolumeFromFile(req.Context(), name, &longhorn.VolumeSpec{
+		Size:             size,
+		NumberOfReplicas: numberOfReplicas,
+		DataEngine:       longhorn.DataEngineType(query.Get(VolumeImportQueryEngine)),
+	}); err != nil {
+		return err
+	}
+
+	importErr := s.writeVolumeImportFile(req.Context(), name, file, fileSize, query.Get(VolumeImportQueryChecksum))
+	if err := s.m.FinishVolumeImport(context.Background(), name, importErr); err != nil {
+		logrus.WithError(err).Warnf("Failed to finish the import of volume %v", name)
+	}
+	if importErr != nil {
+		if errors.Is(importErr, errVolumeImportInvalidFile) {
+			return newBadRequestError("failed to import volume %v: %v", name, importErr)
+		}
+		return errors.Wrapf(importErr, "failed to import volume %v", name)
+	}
+
+	return s.responseWithVolume(rw, req, name, nil)
+}
+
+// getVolumeImportFile returns the first file of a multipart body, or the body itself.
+func getVolumeImportFile(req *http.Request) (io.Reader, error) {
+	mediaType, _, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" {
+		return req.Body, nil
+	}
+	reader, err := req.MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			if err == io.EOF {
+				return nil, fmt.Errorf("missing the file in the multipart body")
+			}
+			return nil, err
+		}
+		if part.FileName() != "" {
+			return part, nil
+		}
+	}
+}
+
+// checkVolumeImportFileFormat returns the file to read from, after rejecting a qcow2 file by its magic number.
+func checkVolumeImportFileFormat(file io.Reader) (io.Reader, error) {
+	reader := bufio.NewReader(file)
+	magic, err := reader.Peek(len(qcow2Magic))
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if bytes.Equal(magic, qcow2Magic) {
+		return nil, newUnsupportedMediaTypeError("importing a qcow2 file to a volume is not supported, convert it to raw first or upload it as a backing image")
+	}
+	return reader, nil
+}
+
+// writeVolumeImportFile waits for the block device of the volume attached to the current node, then writes the file
+// to it.
+func (s *Server) writeVolumeImportFile(ctx context.Context, name string, file io.Reader, fileSize int64, checksum string) error {
+	ctx, cancel := context.WithTimeout(ctx, volumeImportAttachTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(volumeImportAttachInterval)
+	defer ticker.Stop()
+	for {
+		v, err := s.m.Get(name)
+		if err != nil {
+			return err
+		}
+		if v.Status.State == longhorn.VolumeStateAttached && v.Status.CurrentNodeID == s.m.GetCurrentNodeID() {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for volume %v to be attached to node %v", name, s.m.GetCurrentNodeID())
+		case <-ticker.C:
+		}
+	}
+
+	devicePath := filepath.Join(types.VolumeImportDeviceDirectory, name)
+	device, err := os.OpenFile(devicePath, os.O_WRONLY, 0)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open the block device of volume %v", name)
+	}
+	defer device.Close()
+
+	if err := copyVolumeImportFile(device, file, fileSize, checksum); err != nil {
+		return err
+	}
+	return device.Sync()
+}
+
+// errVolumeImportInvalidFile is returned for a file that can't be imported as sent.
+var errVolumeImportInvalidFile = errors.New("invalid file")
+
+// copyVolumeImportFile copies the raw file of the size to the block device of a new volume. The blocks of zeros are
+// skipped, since a new volume reads zeros there, so the volume stays as sparse as the file.
+func copyVolumeImportFile(dst io.WriterAt, src io.Reader, fileSize int64, checksum string) error {
+	hash := sha512.New()
+	buf := make([]byte, volumeImportBufferSize)
+
+	var offset int64
+	for offset < fileSize {
+		n, err := io.ReadFull(src, buf[:min(int64(len(buf)), fileSize-offset)])
+		if err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return errors.Wrapf(errVolumeImportInvalidFile, "the file is shorter than %v bytes", fileSize)
+			}
+			return err
+		}
+		data := buf[:n]
+		hash.Write(data)
+
+		for start := 0; start < len(data); {
+			end := min(start+volumeImportBlockSize, len(data))
+			if isZeroBlock(data[start:end]) {
+				start = end
+				continue
+			}
+			// Write the run of the blocks that aren't zeros at once
+			for end < len(data) {
+				next := min(end+volumeImportBlockSize, len(data))
+				if isZeroBlock(data[end:next]) {
+					break
+				}
+				end = next
+			}
+			if _, err := dst.WriteAt(data[start:end], offset+int64(start)); err != nil {
+				return err
+			}
+			start = end
+		}
+		offset += int64(n)
+	}
+
+	if n, _ := io.Copy(io.Discard, io.LimitReader(src, 1)); n != 0 {
+		return errors.Wrapf(errVolumeImportInvalidFile, "the file is longer than %v bytes", fileSize)
+	}
+	if checksum != "" && hex.EncodeToString(hash.Sum(nil)) != checksum {
+		return errors.Wrapf(errVolumeImportInvalidFile, "the SHA512 checksum of the file doesn't match %v", checksum)
+	}
+	return nil
+}
+
+func isZeroBlock(block []byte) bool {
+	for _, b := range block {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
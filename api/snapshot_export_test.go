@@ -0,0 +1,156 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/hex"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBlockDevice records the writes to a block device of a new volume, which reads zeros until written.
+type fakeBlockDevice struct {
+	data   []byte
+	writes int
+}
+
+func (d *fakeBlockDevice) WriteAt(p []byte, off int64) (int, error) {
+	d.writes++
+	copy(d.data[off:], p)
+	return len(p), nil
+}
+
+func TestCopyVolumeImportFile(t *testing.T) {
+	assert := require.New(t)
+
+	// A file with data at the beginning and at the end, and a hole of zeros in between
+	file := make([]byte, 3*volumeImportBlockSize+100)
+	copy(file, "data at the beginning")
+	copy(file[3*volumeImportBlockSize:], "data at the end")
+	sum := sha512.Sum512(file)
+	checksum := hex.EncodeToString(sum[:])
+
+	type testCase struct {
+		file     []byte
+		fileSize int64
+		checksum string
+
+		expectedWrites      int
+		expectedInvalidFile bool
+	}
+	testCases := map[string]testCase{
+		"sparse file": {
+			file:           file,
+			fileSize:       int64(len(file)),
+			expectedWrites: 2,
+		},
+		"matching checksum": {
+			file:           file,
+			fileSize:       int64(len(file)),
+			checksum:       checksum,
+			expectedWrites: 2,
+		},
+		"mismatching checksum": {
+			file:                file,
+			fileSize:            int64(len(file)),
+			checksum:            strings.Repeat("0", len(checksum)),
+			expectedInvalidFile: true,
+		},
+		"file shorter than the size": {
+			file:                file,
+			fileSize:            int64(len(file)) + 1,
+			expectedInvalidFile: true,
+		},
+		"file longer than the size": {
+			file:                file,
+			fileSize:            int64(len(file)) - 1,
+			expectedInvalidFile: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		device := &fakeBlockDevice{data: make([]byte, len(tc.file)+1)}
+		err := copyVolumeImportFile(device, bytes.NewReader(tc.file), tc.fileSize, tc.checksum)
+		if tc.expectedInvalidFile {
+			assert.True(errors.Is(err, errVolumeImportInvalidFile), name)
+			continue
+		}
+		assert.NoError(err, name)
+		assert.Equal(tc.file, device.data[:len(tc.file)], name)
+		assert.Equal(tc.expectedWrites, device.writes, name)
+	}
+}
+
+func TestCheckVolumeImportFileFormat(t *testing.T) {
+	assert := require.New(t)
+
+	type testCase struct {
+		file                []byte
+		expectedUnsupported bool
+	}
+	testCases := map[string]testCase{
+		"raw file": {
+			file: []byte("raw file"),
+		},
+		"file shorter than the magic number": {
+			file: []byte("QF"),
+		},
+		"empty file": {
+			file: []byte{},
+		},
+		"qcow2 file": {
+			file:                append([]byte{'Q', 'F', 'I', 0xfb}, []byte("qcow2 file")...),
+			expectedUnsupported: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		reader, err := checkVolumeImportFileFormat(bytes.NewReader(tc.file))
+		if tc.expectedUnsupported {
+			statusErr := &apiStatusError{}
+			assert.True(errors.As(err, &statusErr), name)
+			assert.Equal(http.StatusUnsupportedMediaType, statusErr.statusCode, name)
+			continue
+		}
+		assert.NoError(err, name)
+		data, err := io.ReadAll(reader)
+		assert.NoError(err, name)
+		assert.Equal(tc.file, data, name)
+	}
+}
+
+func TestGetVolumeImportFile(t *testing.T) {
+	assert := require.New(t)
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	assert.NoError(writer.WriteField("name", "vol-1"))
+	part, err := writer.CreateFormFile("chunk", "vol-1.raw")
+	assert.NoError(err)
+	_, err = part.Write([]byte("multipart file"))
+	assert.NoError(err)
+	assert.NoError(writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/volumes?action=importFromFile", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	file, err := getVolumeImportFile(req)
+	assert.NoError(err)
+	content, err := io.ReadAll(file)
+	assert.NoError(err)
+	assert.Equal("multipart file", string(content))
+
+	req = httptest.NewRequest(http.MethodPost, "/v1/volumes?action=importFromFile", strings.NewReader("raw file"))
+	req.Header.Set("Content-Type", "application/octet-stream")
+	file, err = getVolumeImportFile(req)
+	assert.NoError(err)
+	content, err = io.ReadAll(file)
+	assert.NoError(err)
+	assert.Equal("raw file", string(content))
+}
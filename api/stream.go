@@ -4,6 +4,7 @@ import (
 	"math/rand"
 	"net/http"
 	"reflect"
+	"sort"
 	"strconv"
 	"time"
 
@@ -34,8 +35,43 @@ func init() {
 	randFunc = rand.New(rand.NewSource(time.Now().UnixNano()))
 }
 
+const (
+	streamChangeAdd    = "add"
+	streamChangeUpdate = "update"
+	streamChangeDelete = "delete"
+)
+
+// streamChanges is the message of an incremental stream carrying the changes of the list since the last message.
+type streamChanges struct {
+	Type         string         `json:"type"`
+	ResourceType string         `json:"resourceType"`
+	Changes      []streamChange `json:"changes"`
+}
+
+type streamChange struct {
+	Action string                 `json:"action"`
+	ID     string                 `json:"id"`
+	Data   map[string]interface{} `json:"data,omitempty"`
+}
+
+// NewStreamHandlerFunc creates the handler of the websocket stream of a list that doesn't support the filters.
 func NewStreamHandlerFunc(streamType string, watcher *controller.Watcher, listFunc func(ctx *api.ApiContext) (*client.GenericCollection, error)) func(w http.ResponseWriter, r *http.Request) error {
+	return NewFilteredStreamHandlerFunc(streamType, watcher, nil, func(ctx *api.ApiContext, _ *listOptions) (*client.GenericCollection, error) {
+		return listFunc(ctx)
+	})
+}
+
+// NewFilteredStreamHandlerFunc creates the handler of the websocket stream of a list, filtered by the query parameters
+// of the request the same way as the list endpoint. The fields are the names of the supported field filters. If
+// `incremental=true` is set, only the first message carries the whole list, and the following messages carry the
+// added, updated and deleted resources.
+func NewFilteredStreamHandlerFunc(streamType string, watcher *controller.Watcher, fields []string, listFunc func(ctx *api.ApiContext, opts *listOptions) (*client.GenericCollection, error)) func(w http.ResponseWriter, r *http.Request) error {
 	return func(w http.ResponseWriter, r *http.Request) error {
+		opts, err := getListOptions(r, fields)
+		if err != nil {
+			return err
+		}
+
 		conn, err := upgrader.Upgrade(w, r, nil)
 		if err != nil {
 			return err
@@ -58,10 +94,16 @@ func NewStreamHandlerFunc(streamType string, watcher *controller.Watcher, listFu
 			}
 		}()
 
-		apiContext := api.GetApiContext(r)
+		writer := &streamWriter{
+			conn:       conn,
+			apiContext: api.GetApiContext(r),
+			listFunc: func(ctx *api.ApiContext) (*client.GenericCollection, error) {
+				return listFunc(ctx, opts)
+			},
+			incremental: opts.incremental,
+		}
 
-		resp, err := writeList(conn, nil, listFunc, apiContext)
-		if err != nil {
+		if err := writer.write(); err != nil {
 			return err
 		}
 
@@ -79,13 +121,13 @@ func NewStreamHandlerFunc(streamType string, watcher *controller.Watcher, listFu
 			case <-done:
 				return nil
 			case <-watcher.Events():
-				resp, err = writeList(conn, resp, listFunc, apiContext)
+				err = writer.write()
 			case <-keepAliveTicker.C:
 				err = conn.WriteControl(websocket.PingMessage, []byte{}, time.Now().Add(writeWait))
 				// WebsocketController doesn't include eventInformer so it only
 				// gets triggered here.
 				if streamType == "events" {
-					resp, err = writeList(conn, resp, listFunc, apiContext)
+					err = writer.write()
 				}
 			}
 			if err != nil {
@@ -95,30 +137,104 @@ func NewStreamHandlerFunc(streamType string, watcher *controller.Watcher, listFu
 	}
 }
 
-func writeList(conn *websocket.Conn, oldResp *client.GenericCollection, listFunc func(ctx *api.ApiContext) (*client.GenericCollection, error), apiContext *api.ApiContext) (*client.GenericCollection, error) {
-	newResp, err := listFunc(apiContext)
+type streamWriter struct {
+	conn        *websocket.Conn
+	apiContext  *api.ApiContext
+	listFunc    func(ctx *api.ApiContext) (*client.GenericCollection, error)
+	incremental bool
+
+	lastResp      *client.GenericCollection
+	lastResources map[string]map[string]interface{}
+}
+
+// write sends the list if it has changed since the last message. An incremental stream sends the changes instead
+// of the list after the first message.
+func (w *streamWriter) write() error {
+	newResp, err := w.listFunc(w.apiContext)
 	if err != nil {
-		return oldResp, err
+		return err
 	}
 
-	if oldResp != nil && reflect.DeepEqual(oldResp, newResp) {
-		return oldResp, nil
+	if w.lastResp != nil && reflect.DeepEqual(w.lastResp, newResp) {
+		return nil
 	}
-	data, err := apiContext.PopulateCollection(newResp)
+	data, err := w.apiContext.PopulateCollection(newResp)
 	if err != nil {
-		return oldResp, err
+		return err
+	}
+
+	var message interface{} = data
+	var resources map[string]map[string]interface{}
+	if w.incremental {
+		resources = getStreamResources(data)
+		if w.lastResources != nil {
+			changes := getStreamChanges(newResp.ResourceType, w.lastResources, resources)
+			if len(changes.Changes) == 0 {
+				w.lastResp = newResp
+				return nil
+			}
+			message = changes
+		}
 	}
 
-	err = conn.SetWriteDeadline(time.Now().Add(writeWait))
+	err = w.conn.SetWriteDeadline(time.Now().Add(writeWait))
 	if err != nil {
-		return oldResp, err
+		return err
 	}
-	err = conn.WriteJSON(data)
+	err = w.conn.WriteJSON(message)
 	if err != nil {
-		return oldResp, err
+		return err
+	}
+
+	w.lastResp = newResp
+	w.lastResources = resources
+	return nil
+}
+
+func getStreamResources(data map[string]interface{}) map[string]map[string]interface{} {
+	resources := map[string]map[string]interface{}{}
+	items, _ := data["data"].([]map[string]interface{})
+	for _, item := range items {
+		if id, ok := item["id"].(string); ok {
+			resources[id] = item
+		}
 	}
+	return resources
+}
 
-	return newResp, nil
+func getStreamChanges(resourceType string, oldResources, newResources map[string]map[string]interface{}) *streamChanges {
+	changes := &streamChanges{
+		Type:         "changes",
+		ResourceType: resourceType,
+		Changes:      []streamChange{},
+	}
+
+	ids := make([]string, 0, len(newResources))
+	for id := range newResources {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		oldResource, existed := oldResources[id]
+		switch {
+		case !existed:
+			changes.Changes = append(changes.Changes, streamChange{Action: streamChangeAdd, ID: id, Data: newResources[id]})
+		case !reflect.DeepEqual(oldResource, newResources[id]):
+			changes.Changes = append(changes.Changes, streamChange{Action: streamChangeUpdate, ID: id, Data: newResources[id]})
+		}
+	}
+
+	ids = ids[:0]
+	for id := range oldResources {
+		if _, exists := newResources[id]; !exists {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		changes.Changes = append(changes.Changes, streamChange{Action: streamChangeDelete, ID: id})
+	}
+	return changes
 }
 
 func maybeNewTicker(d time.Duration) *time.Ticker {
@@ -0,0 +1,38 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStreamChanges(t *testing.T) {
+	assert := require.New(t)
+
+	oldResources := getStreamResources(map[string]interface{}{
+		"data": []map[string]interface{}{
+			{"id": "vol-1", "state": "attached"},
+			{"id": "vol-2", "state": "detached"},
+			{"id": "vol-3", "state": "detached"},
+		},
+	})
+	newResources := getStreamResources(map[string]interface{}{
+		"data": []map[string]interface{}{
+			{"id": "vol-4", "state": "creating"},
+			{"id": "vol-2", "state": "attached"},
+			{"id": "vol-1", "state": "attached"},
+		},
+	})
+
+	changes := getStreamChanges("volume", oldResources, newResources)
+	assert.Equal("changes", changes.Type)
+	assert.Equal("volume", changes.ResourceType)
+	assert.Equal([]streamChange{
+		{Action: streamChangeUpdate, ID: "vol-2", Data: map[string]interface{}{"id": "vol-2", "state": "attached"}},
+		{Action: streamChangeAdd, ID: "vol-4", Data: map[string]interface{}{"id": "vol-4", "state": "creating"}},
+		{Action: streamChangeDelete, ID: "vol-3"},
+	}, changes.Changes)
+
+	changes = getStreamChanges("volume", newResources, newResources)
+	assert.Empty(changes.Changes)
+}
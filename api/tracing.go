@@ -0,0 +1,53 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/longhorn/longhorn-manager/tracing"
+)
+
+const apiTracerName = "github.com/longhorn/longhorn-manager/api"
+
+// tracingHandler wraps the handler to trace the mutating requests and the requests carrying a traceparent header.
+// The span is carried by the request context, so that the manager propagates it to the resources the request changes.
+func tracingHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost && req.Method != http.MethodPut && req.Method != http.MethodDelete &&
+			req.Header.Get(tracing.HeaderTraceParent) == "" {
+			h.ServeHTTP(rw, req)
+			return
+		}
+
+		spanName := req.Method + " " + req.URL.Path
+		attributes := []attribute.KeyValue{
+			attribute.String("http.request.method", req.Method),
+			attribute.String("url.path", req.URL.Path),
+		}
+		if collection, name, _, err := getAPIRouteTarget(req); err == nil {
+			spanName = fmt.Sprintf("%v %v", req.Method, collection)
+			attributes = append(attributes, attribute.String("longhorn.resource", collection), attribute.String("longhorn.name", name))
+		}
+		if action := req.URL.Query().Get("action"); action != "" {
+			spanName += " " + action
+			attributes = append(attributes, attribute.String("longhorn.action", action))
+		}
+
+		ctx := tracing.ExtractHTTP(req.Context(), req.Header)
+		ctx, span := tracing.Tracer(apiTracerName).Start(ctx, spanName,
+			trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attributes...))
+		defer span.End()
+
+		writer := &auditResponseWriter{ResponseWriter: rw, statusCode: http.StatusOK}
+		h.ServeHTTP(writer, req.WithContext(ctx))
+
+		span.SetAttributes(attribute.Int("http.response.status_code", writer.statusCode))
+		if writer.statusCode >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(writer.statusCode))
+		}
+	})
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"encoding/csv"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/rancher/go-rancher/api"
+
+	"github.com/longhorn/longhorn-manager/types"
+)
+
+const (
+	UsageQueryFrom    = "from"
+	UsageQueryTo      = "to"
+	UsageQueryGroupBy = "groupBy"
+	UsageQueryFormat  = "format"
+
+	UsageFormatJSON = "json"
+	UsageFormatCSV  = "csv"
+)
+
+// UsageList returns the daily totals of the storage consumed by the volumes, grouped by the comma separated
+// dimensions in the groupBy query, in JSON or CSV.
+func (s *Server) UsageList(rw http.ResponseWriter, req *http.Request) error {
+	query := req.URL.Query()
+
+	from, to := query.Get(UsageQueryFrom), query.Get(UsageQueryTo)
+	for name, value := range map[string]string{UsageQueryFrom: from, UsageQueryTo: to} {
+		if value == "" {
+			continue
+		}
+		if _, err := time.Parse(types.UsageDateLayout, value); err != nil {
+			return newBadRequestError("invalid %v %q: should be a date in the format %v", name, value, types.UsageDateLayout)
+		}
+	}
+
+	groupBy := []string{types.UsageGroupByNamespace}
+	if value, ok := query[UsageQueryGroupBy]; ok {
+		groupBy = nil
+		for _, dimension := range strings.Split(strings.Join(value, ","), ",") {
+			if dimension = strings.TrimSpace(dimension); dimension != "" {
+				groupBy = append(groupBy, dimension)
+			}
+		}
+	}
+	if err := types.ValidateUsageGroupBy(groupBy); err != nil {
+		return newBadRequestError("invalid %v: %v", UsageQueryGroupBy, err)
+	}
+
+	usages, err := s.m.ListDailyUsages(from, to)
+	if err != nil {
+		return errors.Wrap(err, "failed to list daily usages")
+	}
+	totals := types.AggregateDailyUsages(usages, groupBy)
+
+	switch format := query.Get(UsageQueryFormat); format {
+	case "", UsageFormatJSON:
+		api.GetApiContext(req).Write(toUsageCollection(totals))
+		return nil
+	case UsageFormatCSV:
+		rw.Header().Set("Content-Type", "text/csv")
+		rw.Header().Set("Content-Disposition", "attachment; filename=usage.csv")
+		return writeUsageCSV(rw, totals, groupBy)
+	default:
+		return newBadRequestError("invalid %v %q: should be %v or %v", UsageQueryFormat, format, UsageFormatJSON, UsageFormatCSV)
+	}
+}
+
+// writeUsageCSV writes a row per total, with a column per group dimension and a backup size column per backup
+// target.
+func writeUsageCSV(rw http.ResponseWriter, totals []*types.UsageTotal, groupBy []string) error {
+	backupTargets := map[string]bool{}
+	for _, total := range totals {
+		for backupTarget := range total.BackupSize {
+			backupTargets[backupTarget] = true
+		}
+	}
+	backupTargetNames := slices.Sorted(maps.Keys(backupTargets))
+
+	header := append([]string{"date"}, groupBy...)
+	header = append(header, "volumeCount", "size", "actualSize", "snapshotSize", "backupSize")
+	for _, backupTarget := range backupTargetNames {
+		header = append(header, "backupSize:"+backupTarget)
+	}
+
+	w := csv.NewWriter(rw)
+	if err := w.Write(header); err != nil {
+		return err
+	}
+	for _, total := range totals {
+		row := []string{total.Date}
+		for _, dimension := range groupBy {
+			row = append(row, total.Group[dimension])
+		}
+		var backupSize int64
+		for _, size := range total.BackupSize {
+			backupSize += size
+		}
+		row = append(row,
+			strconv.Itoa(total.VolumeCount),
+			strconv.FormatInt(total.Size, 10),
+			strconv.FormatInt(total.ActualSize, 10),
+			strconv.FormatInt(total.SnapshotSize, 10),
+			strconv.FormatInt(backupSize, 10))
+		for _, backupTarget := range backupTargetNames {
+			row = append(row, strconv.FormatInt(total.BackupSize[backupTarget], 10))
+		}
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
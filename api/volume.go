@@ -26,7 +26,11 @@ func (s *Server) VolumeList(rw http.ResponseWriter, req *http.Request) (err erro
 
 	apiContext := api.GetApiContext(req)
 
-	resp, err := s.volumeList(apiContext)
+	opts, err := getListOptions(req, volumeFieldGetters.fields())
+	if err != nil {
+		return err
+	}
+	resp, err := s.volumeList(apiContext, opts)
 	if err != nil {
 		return err
 	}
@@ -36,13 +40,24 @@ func (s *Server) VolumeList(rw http.ResponseWriter, req *http.Request) (err erro
 	return nil
 }
 
-func (s *Server) volumeList(apiContext *api.ApiContext) (*client.GenericCollection, error) {
+// volumeFieldGetters are the field filters supported by the volume list.
+var volumeFieldGetters = listFieldGetters[*longhorn.Volume]{
+	"state":        func(v *longhorn.Volume) string { return string(v.Status.State) },
+	"robustness":   func(v *longhorn.Volume) string { return string(v.Status.Robustness) },
+	"node":         func(v *longhorn.Volume) string { return v.Status.CurrentNodeID },
+	"backupTarget": func(v *longhorn.Volume) string { return v.Spec.BackupTargetName },
+	"dataEngine":   func(v *longhorn.Volume) string { return string(v.Spec.DataEngine) },
+	"accessMode":   func(v *longhorn.Volume) string { return string(v.Spec.AccessMode) },
+}
+
+func (s *Server) volumeList(apiContext *api.ApiContext, opts *listOptions) (*client.GenericCollection, error) {
 	resp := &client.GenericCollection{}
 
 	volumes, err := s.m.ListSorted()
 	if err != nil {
 		return nil, err
 	}
+	volumes, resp.Pagination = filterAndPaginateObjects(volumes, volumeFieldGetters, opts, apiContext)
 
 	for _, v := range volumes {
 		controllers, err := s.m.GetEnginesSorted(v.Name)
@@ -210,7 +225,10 @@ func (s *Server) VolumeCreate(rw http.ResponseWriter, req *http.Request) error {
 		FreezeFilesystemForSnapshot:     volume.FreezeFilesystemForSnapshot,
 		BackupTargetName:                volume.BackupTargetName,
 		OfflineRebuilding:               volume.OfflineRebuilding,
-	}, volume.RecurringJobSelector)
+		TieringPolicy:                   volume.TieringPolicy,
+		TopologySpreadConstraints:       volume.TopologySpreadConstraints,
+		NFSExportOptions:                volume.NFSExportOptions,
+	}, volume.RecurringJobSelector, volume.PVCNamespace)
 	if err != nil {
 		return errors.Wrap(err, "failed to create volume")
 	}
@@ -237,7 +255,7 @@ func (s *Server) VolumeAttach(rw http.ResponseWriter, req *http.Request) error {
 	id := mux.Vars(req)["name"]
 
 	obj, err := util.RetryOnConflictCause(func() (interface{}, error) {
-		return s.m.Attach(id, input.HostID, input.DisableFrontend, input.AttachedBy, input.AttacherType, input.AttachmentID)
+		return s.m.Attach(req.Context(), id, input.HostID, input.DisableFrontend, input.AttachedBy, input.AttacherType, input.AttachmentID)
 	})
 	if err != nil {
 		return err
@@ -262,7 +280,7 @@ func (s *Server) VolumeDetach(rw http.ResponseWriter, req *http.Request) error {
 	id := mux.Vars(req)["name"]
 
 	obj, err := util.RetryOnConflictCause(func() (interface{}, error) {
-		return s.m.Detach(id, input.AttachmentID, input.HostID, input.ForceDetach)
+		return s.m.Detach(req.Context(), id, input.AttachmentID, input.HostID, input.ForceDetach)
 	})
 	if err != nil {
 		return err
@@ -299,6 +317,35 @@ func (s *Server) VolumeSalvage(rw http.ResponseWriter, req *http.Request) error
 	return s.responseWithVolume(rw, req, "", v)
 }
 
+func (s *Server) VolumeSalvageReport(rw http.ResponseWriter, req *http.Request) error {
+	id := mux.Vars(req)["name"]
+
+	report, err := s.m.SalvageReport(id)
+	if err != nil {
+		return err
+	}
+	api.GetApiContext(req).Write(toSalvageReportResource(report))
+	return nil
+}
+
+func (s *Server) VolumeSalvagePreview(rw http.ResponseWriter, req *http.Request) error {
+	var input SalvageInput
+
+	apiContext := api.GetApiContext(req)
+	if err := apiContext.Read(&input); err != nil {
+		return errors.Wrap(err, "failed to read salvageInput")
+	}
+
+	id := mux.Vars(req)["name"]
+
+	preview, err := s.m.SalvagePreview(id, input.Names)
+	if err != nil {
+		return err
+	}
+	apiContext.Write(toSalvagePreviewResource(preview))
+	return nil
+}
+
 func (s *Server) VolumeRecurringAdd(rw http.ResponseWriter, req *http.Request) error {
 	var input VolumeRecurringJobInput
 	volName := mux.Vars(req)["name"]
@@ -662,6 +709,36 @@ func (s *Server) VolumeCancelExpansion(rw http.ResponseWriter, req *http.Request
 	return s.responseWithVolume(rw, req, "", v)
 }
 
+func (s *Server) VolumeUpdateTieringPolicy(rw http.ResponseWriter, req *http.Request) error {
+	var input UpdateTieringPolicyInput
+
+	apiContext := api.GetApiContext(req)
+	if err := apiContext.Read(&input); err != nil {
+		return errors.Wrap(err, "failed to read tiering policy input")
+	}
+
+	id := mux.Vars(req)["name"]
+
+	policy := longhorn.VolumeTieringPolicy{
+		HotDiskSelector:       input.HotDiskSelector,
+		ColdDiskSelector:      input.ColdDiskSelector,
+		ColdAfterDetachedDays: input.ColdAfterDetachedDays,
+		ColdBelowIOPS:         input.ColdBelowIOPS,
+	}
+	obj, err := util.RetryOnConflictCause(func() (interface{}, error) {
+		return s.m.UpdateTieringPolicy(id, policy)
+	})
+	if err != nil {
+		return err
+	}
+	v, ok := obj.(*longhorn.Volume)
+	if !ok {
+		return fmt.Errorf("failed to convert to volume %v object", id)
+	}
+
+	return s.responseWithVolume(rw, req, "", v)
+}
+
 func (s *Server) VolumeOfflineRebuilding(rw http.ResponseWriter, req *http.Request) error {
 	var input UpdateOfflineRebuildingInput
 
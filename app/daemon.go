@@ -33,6 +33,7 @@ import (
 	"github.com/longhorn/longhorn-manager/datastore"
 	"github.com/longhorn/longhorn-manager/manager"
 	"github.com/longhorn/longhorn-manager/meta"
+	"github.com/longhorn/longhorn-manager/tracing"
 	"github.com/longhorn/longhorn-manager/types"
 	"github.com/longhorn/longhorn-manager/upgrade"
 	"github.com/longhorn/longhorn-manager/util"
@@ -314,6 +315,8 @@ func startManager(c *cli.Context) error {
 		return err
 	}
 
+	tracing.Init(currentNodeID, clients.Datastore.GetSettingTracingOTLPEndpoint, clients.Datastore.GetSettingTracingSamplingRatio, ctx.Done())
+
 	proxyConnCounter := util.NewAtomicCounter()
 
 	wsc, err := controller.StartControllers(logger, clients,
@@ -324,6 +327,9 @@ func startManager(c *cli.Context) error {
 	}
 
 	m := manager.NewVolumeManager(currentNodeID, clients.Datastore, proxyConnCounter)
+	if err := m.CleanupInterruptedVolumeImports(); err != nil {
+		logger.WithError(err).Warn("Failed to clean up interrupted volume imports")
+	}
 
 	metricscollector.InitMetricsCollectorSystem(logger, currentNodeID, clients.Datastore, kubeconfigPath, proxyConnCounter)
 
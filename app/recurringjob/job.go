@@ -30,8 +30,9 @@ func NewJob(name string, logger *logrus.Logger, managerURL string, recurringJob
 	}
 
 	clientOpts := &longhornclient.ClientOpts{
-		Url:     managerURL,
-		Timeout: HTTPClientTimout,
+		Url:       managerURL,
+		Timeout:   HTTPClientTimout,
+		TokenFile: types.ServiceAccountTokenFile,
 	}
 	apiClient, err := longhornclient.NewRancherClient(clientOpts)
 	if err != nil {
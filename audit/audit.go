@@ -0,0 +1,199 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/sirupsen/logrus"
+
+	jsonpatch "github.com/evanphx/json-patch"
+)
+
+const (
+	SourceAPI       = "api"
+	SourceAdmission = "admission"
+
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+	ResultDenied  = "denied"
+)
+
+// Record is an entry of the audit log.
+type Record struct {
+	Timestamp time.Time `json:"timestamp"`
+	Source    string    `json:"source"`
+	// Node is the node of the manager that handled the request.
+	Node string `json:"node"`
+	// User is the authenticated user of the request. For an API request, it is empty if the client sent no bearer
+	// token, which is allowed when the API authentication is disabled.
+	User          string   `json:"user"`
+	Groups        []string `json:"groups,omitempty"`
+	SourceAddress string   `json:"sourceAddress,omitempty"`
+	// Operation is the HTTP method of an API request, or the operation of an admission request.
+	Operation string `json:"operation"`
+	Resource  string `json:"resource"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Action    string `json:"action,omitempty"`
+	Path      string `json:"path,omitempty"`
+	// Request is the JSON body of an API request.
+	Request json.RawMessage `json:"request,omitempty"`
+	// Diff is the JSON merge patch from the object before the change to the object after the change. For a created
+	// object it is the whole object. An API request creating an object has no diff, since the object is only known
+	// by the request.
+	Diff json.RawMessage `json:"diff,omitempty"`
+	// Deleted is the object before the deletion.
+	Deleted json.RawMessage `json:"deleted,omitempty"`
+	// ForwardedTo is the address of the manager the API request is forwarded to. The forwarded request is recorded
+	// again by that manager.
+	ForwardedTo string `json:"forwardedTo,omitempty"`
+	Result      string `json:"result"`
+	StatusCode  int    `json:"statusCode,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+type recordContextKey struct{}
+
+// NewContext returns a context carrying the record, so that the handlers of the request can fill in the details only
+// known to them, such as the authenticated user or the error.
+func NewContext(ctx context.Context, record *Record) context.Context {
+	return context.WithValue(ctx, recordContextKey{}, record)
+}
+
+// FromContext returns the record carried by the context, or nil if there is none.
+func FromContext(ctx context.Context) *Record {
+	record, _ := ctx.Value(recordContextKey{}).(*Record)
+	return record
+}
+
+// Logger writes the audit records to the sink of the configured destination. The destination is looked up for every
+// record, so that a change takes effect without restarting the manager.
+type Logger struct {
+	nodeID         string
+	getDestination func() (string, error)
+
+	lock        sync.Mutex
+	destination string
+	sink        Sink
+}
+
+func NewLogger(nodeID string, getDestination func() (string, error)) *Logger {
+	return &Logger{
+		nodeID:         nodeID,
+		getDestination: getDestination,
+	}
+}
+
+// Log writes the record. Failures are logged rather than returned, since the audit log should not fail the
+// operation being audited.
+func (l *Logger) Log(record *Record) {
+	if l == nil || record == nil {
+		return
+	}
+
+	sink, err := l.getSink()
+	if err != nil {
+		logrus.WithError(err).Warn("Failed to get the audit log sink")
+		return
+	}
+	if sink == nil {
+		return
+	}
+
+	if record.Timestamp.IsZero() {
+		record.Timestamp = time.Now().UTC()
+	}
+	record.Node = l.nodeID
+	if err := sink.Write(record); err != nil {
+		logrus.WithError(err).Warnf("Failed to write the audit record of %v %v %v", record.Operation, record.Resource, record.Name)
+	}
+}
+
+// Enabled returns true if the audit log has a destination, so that the callers can skip building the records and
+// reading the objects for them when it's disabled.
+func (l *Logger) Enabled() bool {
+	if l == nil {
+		return false
+	}
+	sink, err := l.getSink()
+	if err != nil {
+		logrus.WithError(err).Warn("Failed to get the audit log sink")
+		return false
+	}
+	return sink != nil
+}
+
+func (l *Logger) getSink() (Sink, error) {
+	destination, err := l.getDestination()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the audit log destination")
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if destination == l.destination {
+		return l.sink, nil
+	}
+
+	if l.sink != nil {
+		if err := l.sink.Close(); err != nil {
+			logrus.WithError(err).Warnf("Failed to close the audit log sink of %v", l.destination)
+		}
+	}
+	l.sink = nil
+	l.destination = ""
+
+	if destination != "" {
+		sink, err := NewSink(destination)
+		if err != nil {
+			return nil, err
+		}
+		l.sink = sink
+	}
+	l.destination = destination
+	return l.sink, nil
+}
+
+// ignoredDiffFields are the metadata fields that change with every update and are left out of the diff.
+var ignoredDiffFields = []string{"managedFields", "resourceVersion", "generation"}
+
+// GetDiff returns the JSON merge patch from the old object to the new one. A nil old object means the object is
+// created.
+func GetDiff(oldObj, newObj []byte) (json.RawMessage, error) {
+	oldObj, err := TrimObject(oldObj)
+	if err != nil {
+		return nil, err
+	}
+	newObj, err = TrimObject(newObj)
+	if err != nil {
+		return nil, err
+	}
+
+	patch, err := jsonpatch.CreateMergePatch(oldObj, newObj)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the merge patch")
+	}
+	return patch, nil
+}
+
+// TrimObject removes the metadata fields that are not meaningful for the audit from the JSON object.
+func TrimObject(obj []byte) ([]byte, error) {
+	if len(obj) == 0 {
+		return []byte("{}"), nil
+	}
+
+	object := map[string]interface{}{}
+	if err := json.Unmarshal(obj, &object); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the object")
+	}
+	if metadata, ok := object["metadata"].(map[string]interface{}); ok {
+		for _, field := range ignoredDiffFields {
+			delete(metadata, field)
+		}
+	}
+	return json.Marshal(object)
+}
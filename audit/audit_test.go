@@ -0,0 +1,76 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDiff(t *testing.T) {
+	assert := require.New(t)
+
+	oldObj := []byte(`{"metadata":{"name":"vol","resourceVersion":"1","managedFields":[{"manager":"a"}]},"spec":{"numberOfReplicas":3,"dataLocality":"disabled"}}`)
+	newObj := []byte(`{"metadata":{"name":"vol","resourceVersion":"2","managedFields":[{"manager":"b"}]},"spec":{"numberOfReplicas":2,"dataLocality":"disabled"}}`)
+
+	diff, err := GetDiff(oldObj, newObj)
+	assert.NoError(err)
+	assert.JSONEq(`{"spec":{"numberOfReplicas":2}}`, string(diff))
+
+	diff, err = GetDiff(newObj, newObj)
+	assert.NoError(err)
+	assert.JSONEq(`{}`, string(diff))
+
+	diff, err = GetDiff(nil, newObj)
+	assert.NoError(err)
+	assert.JSONEq(`{"metadata":{"name":"vol"},"spec":{"numberOfReplicas":2,"dataLocality":"disabled"}}`, string(diff))
+}
+
+func TestFileSinkRotation(t *testing.T) {
+	assert := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "audit", "audit.log")
+	sink := NewFileSink(path, 200, 2)
+	defer func() {
+		_ = sink.Close()
+	}()
+
+	for i := 0; i < 10; i++ {
+		assert.NoError(sink.Write(&Record{Source: SourceAPI, Operation: "POST", Resource: "volumes", Name: strings.Repeat("v", 40)}))
+	}
+
+	for _, p := range []string{path, path + ".1", path + ".2"} {
+		info, err := os.Stat(p)
+		assert.NoError(err, p)
+		assert.LessOrEqual(info.Size(), int64(200), p)
+	}
+	_, err := os.Stat(path + ".3")
+	assert.True(os.IsNotExist(err))
+
+	// The sink reopens the file if it is rotated by another writer
+	assert.NoError(os.Rename(path, path+".moved"))
+	assert.NoError(sink.Write(&Record{Source: SourceAPI, Operation: "DELETE", Resource: "volumes", Name: "vol"}))
+	content, err := os.ReadFile(path)
+	assert.NoError(err)
+	assert.Contains(string(content), `"operation":"DELETE"`)
+}
+
+func TestLoggerEnabled(t *testing.T) {
+	assert := require.New(t)
+
+	var nilLogger *Logger
+	assert.False(nilLogger.Enabled())
+
+	destination := ""
+	logger := NewLogger("node-1", func() (string, error) { return destination, nil })
+	assert.False(logger.Enabled())
+
+	destination = filepath.Join(t.TempDir(), "audit.log")
+	assert.True(logger.Enabled())
+
+	// The sink of the previous destination is closed once the destination changes
+	destination = "relative/audit.log"
+	assert.False(logger.Enabled())
+}
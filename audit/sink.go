@@ -0,0 +1,229 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	fileSinkMaxSize    = 100 * 1024 * 1024
+	fileSinkMaxBackups = 5
+
+	webhookSinkTimeout   = 10 * time.Second
+	webhookSinkQueueSize = 1024
+)
+
+// Sink is where the audit records are written to.
+type Sink interface {
+	Write(record *Record) error
+	Close() error
+}
+
+// NewSink returns a WebhookSink for an HTTP(S) URL destination, or a FileSink for a file path destination.
+func NewSink(destination string) (Sink, error) {
+	if strings.HasPrefix(destination, "http://") || strings.HasPrefix(destination, "https://") {
+		return NewWebhookSink(destination), nil
+	}
+	if !filepath.IsAbs(destination) {
+		return nil, fmt.Errorf("invalid audit log destination %v", destination)
+	}
+	return NewFileSink(destination, fileSinkMaxSize, fileSinkMaxBackups), nil
+}
+
+// FileSink appends the records to a file as JSON lines, and rotates the file once it grows over the max size. The
+// rotated files are suffixed by the number, the larger the older.
+type FileSink struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	lock sync.Mutex
+	file *os.File
+	size int64
+}
+
+func NewFileSink(path string, maxSize int64, maxBackups int) *FileSink {
+	return &FileSink{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+}
+
+func (s *FileSink) Write(record *Record) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the audit record")
+	}
+	line = append(line, '\n')
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if err := s.open(); err != nil {
+		return err
+	}
+	if s.size > 0 && s.size+int64(len(line)) > s.maxSize {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := s.file.Write(line)
+	s.size += int64(n)
+	return err
+}
+
+// open opens the file if it is not opened yet, or if it has been rotated or removed by someone else, for example
+// the sink of another logger in the same process.
+func (s *FileSink) open() error {
+	info, err := os.Stat(s.path)
+	if s.file != nil {
+		if err == nil {
+			if current, statErr := s.file.Stat(); statErr == nil && os.SameFile(info, current) {
+				s.size = info.Size()
+				return nil
+			}
+		}
+		_ = s.file.Close()
+		s.file = nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return errors.Wrapf(err, "failed to create the directory of audit log %v", s.path)
+	}
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "failed to open audit log %v", s.path)
+	}
+	info, err = file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return errors.Wrapf(err, "failed to get the size of audit log %v", s.path)
+	}
+	s.file = file
+	s.size = info.Size()
+	return nil
+}
+
+func (s *FileSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		logrus.WithError(err).Warnf("Failed to close audit log %v before rotation", s.path)
+	}
+	s.file = nil
+
+	for i := s.maxBackups - 1; i >= 1; i-- {
+		if err := os.Rename(s.backupPath(i), s.backupPath(i+1)); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "failed to rotate audit log %v", s.backupPath(i))
+		}
+	}
+	if s.maxBackups > 0 {
+		if err := os.Rename(s.path, s.backupPath(1)); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "failed to rotate audit log %v", s.path)
+		}
+	} else if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to remove audit log %v", s.path)
+	}
+
+	return s.open()
+}
+
+func (s *FileSink) backupPath(index int) string {
+	return fmt.Sprintf("%v.%d", s.path, index)
+}
+
+func (s *FileSink) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+// WebhookSink sends each record by a POST request to the URL of a collector. The records are queued and sent in the
+// background, so a slow collector doesn't delay the audited operations. Records are dropped if the queue is full.
+type WebhookSink struct {
+	url    string
+	client *http.Client
+
+	lock   sync.RWMutex
+	queue  chan *Record
+	closed bool
+}
+
+func NewWebhookSink(url string) *WebhookSink {
+	s := &WebhookSink{
+		url:    url,
+		client: &http.Client{Timeout: webhookSinkTimeout},
+		queue:  make(chan *Record, webhookSinkQueueSize),
+	}
+	go s.run()
+	return s
+}
+
+func (s *WebhookSink) Write(record *Record) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.closed {
+		return fmt.Errorf("audit log sink of %v is closed", s.url)
+	}
+	select {
+	case s.queue <- record:
+		return nil
+	default:
+		return fmt.Errorf("audit log queue of %v is full", s.url)
+	}
+}
+
+func (s *WebhookSink) run() {
+	for record := range s.queue {
+		if err := s.send(record); err != nil {
+			logrus.WithError(err).Warnf("Failed to send the audit record of %v %v %v to %v", record.Operation, record.Resource, record.Name, s.url)
+		}
+	}
+}
+
+func (s *WebhookSink) send(record *Record) error {
+	body, err := json.Marshal(record)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the audit record")
+	}
+
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %v", resp.StatusCode)
+	}
+	return nil
+}
+
+func (s *WebhookSink) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !s.closed {
+		s.closed = true
+		close(s.queue)
+	}
+	return nil
+}
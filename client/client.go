@@ -1,5 +1,7 @@
 package client
 
+//go:generate go run ./generator/cmd
+
 import (
 	"net/http"
 
@@ -2,7 +2,6 @@ package client
 
 import (
 	"bytes"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -32,6 +31,9 @@ type ClientOpts struct {
 	AccessKey string
 	SecretKey string
 	Timeout   time.Duration
+	// TokenFile is the path of the file containing the bearer token to authenticate with. It is read for every
+	// request so that a rotated token is picked up. The basic authentication is used if the file cannot be read.
+	TokenFile string
 }
 
 type ApiError struct {
@@ -39,6 +41,7 @@ type ApiError struct {
 	Url        string
 	Msg        string
 	Status     string
+	Code       string
 	Body       string
 }
 
@@ -65,7 +68,9 @@ func newApiError(resp *http.Response, url string) *ApiError {
 	}
 
 	data := map[string]interface{}{}
+	var code string
 	if json.Unmarshal(contents, &data) == nil {
+		code, _ = data["code"].(string)
 		delete(data, "id")
 		delete(data, "links")
 		delete(data, "actions")
@@ -90,6 +95,7 @@ func newApiError(resp *http.Response, url string) *ApiError {
 		Msg:        formattedMsg,
 		StatusCode: resp.StatusCode,
 		Status:     resp.Status,
+		Code:       code,
 		Body:       body,
 	}
 }
@@ -158,7 +164,7 @@ func setupRancherBaseClient(rancherClient *RancherBaseClientImpl, opts *ClientOp
 		return err
 	}
 
-	req.SetBasicAuth(opts.AccessKey, opts.SecretKey)
+	setRequestAuth(req, opts)
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -182,10 +188,10 @@ func setupRancherBaseClient(rancherClient *RancherBaseClientImpl, opts *ClientOp
 
 	if schemasUrls != opts.Url {
 		req, err = http.NewRequest("GET", schemasUrls, nil)
-		req.SetBasicAuth(opts.AccessKey, opts.SecretKey)
 		if err != nil {
 			return err
 		}
+		setRequestAuth(req, opts)
 
 		resp, err = client.Do(req)
 		if err != nil {
@@ -231,7 +237,17 @@ func NewListOpts() *ListOpts {
 }
 
 func (rancherClient *RancherBaseClientImpl) setupRequest(req *http.Request) {
-	req.SetBasicAuth(rancherClient.Opts.AccessKey, rancherClient.Opts.SecretKey)
+	setRequestAuth(req, rancherClient.Opts)
+}
+
+func setRequestAuth(req *http.Request, opts *ClientOpts) {
+	if opts.TokenFile != "" {
+		if token, err := os.ReadFile(opts.TokenFile); err == nil && len(bytes.TrimSpace(token)) > 0 {
+			req.Header.Set("Authorization", "Bearer "+string(bytes.TrimSpace(token)))
+			return
+		}
+	}
+	req.SetBasicAuth(opts.AccessKey, opts.SecretKey)
 }
 
 func (rancherClient *RancherBaseClientImpl) newHttpClient() *http.Client {
@@ -278,8 +294,7 @@ func (rancherClient *RancherBaseClientImpl) Websocket(url string, headers map[st
 	}
 
 	if rancherClient.Opts != nil {
-		s := rancherClient.Opts.AccessKey + ":" + rancherClient.Opts.SecretKey
-		httpHeaders.Add("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(s)))
+		setRequestAuth(&http.Request{Header: httpHeaders}, rancherClient.Opts)
 	}
 
 	return dialer.Dial(url, http.Header(httpHeaders))
@@ -619,6 +634,11 @@ func (rancherClient *RancherBaseClientImpl) doAction(schemaType string, action s
 		fmt.Println("Response <= " + string(byteContent))
 	}
 
+	// The actions without output respond with an empty body
+	if respObject == nil || len(byteContent) == 0 {
+		return nil
+	}
+
 	return json.Unmarshal(byteContent, respObject)
 }
 
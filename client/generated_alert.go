@@ -0,0 +1,97 @@
+package client
+
+const (
+	ALERT_TYPE = "alert"
+)
+
+type Alert struct {
+	Resource `yaml:"-"`
+
+	EndsAt string `json:"endsAt,omitempty" yaml:"ends_at,omitempty"`
+
+	Kind string `json:"kind,omitempty" yaml:"kind,omitempty"`
+
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+
+	Node string `json:"node,omitempty" yaml:"node,omitempty"`
+
+	ResourceName string `json:"resourceName,omitempty" yaml:"resource_name,omitempty"`
+
+	Severity string `json:"severity,omitempty" yaml:"severity,omitempty"`
+
+	Silenced bool `json:"silenced,omitempty" yaml:"silenced,omitempty"`
+
+	StartsAt string `json:"startsAt,omitempty" yaml:"starts_at,omitempty"`
+
+	Status string `json:"status,omitempty" yaml:"status,omitempty"`
+}
+
+type AlertCollection struct {
+	Collection
+	Data   []Alert `json:"data,omitempty"`
+	client *AlertClient
+}
+
+type AlertClient struct {
+	rancherClient *RancherClient
+}
+
+type AlertOperations interface {
+	List(opts *ListOpts) (*AlertCollection, error)
+	Create(opts *Alert) (*Alert, error)
+	Update(existing *Alert, updates interface{}) (*Alert, error)
+	ById(id string) (*Alert, error)
+	Delete(container *Alert) error
+}
+
+func newAlertClient(rancherClient *RancherClient) *AlertClient {
+	return &AlertClient{
+		rancherClient: rancherClient,
+	}
+}
+
+func (c *AlertClient) Create(container *Alert) (*Alert, error) {
+	resp := &Alert{}
+	err := c.rancherClient.doCreate(ALERT_TYPE, container, resp)
+	return resp, err
+}
+
+func (c *AlertClient) Update(existing *Alert, updates interface{}) (*Alert, error) {
+	resp := &Alert{}
+	err := c.rancherClient.doUpdate(ALERT_TYPE, &existing.Resource, updates, resp)
+	return resp, err
+}
+
+func (c *AlertClient) List(opts *ListOpts) (*AlertCollection, error) {
+	resp := &AlertCollection{}
+	err := c.rancherClient.doList(ALERT_TYPE, opts, resp)
+	resp.client = c
+	return resp, err
+}
+
+func (cc *AlertCollection) Next() (*AlertCollection, error) {
+	if cc != nil && cc.Pagination != nil && cc.Pagination.Next != "" {
+		resp := &AlertCollection{}
+		err := cc.client.rancherClient.doNext(cc.Pagination.Next, resp)
+		resp.client = cc.client
+		return resp, err
+	}
+	return nil, nil
+}
+
+func (c *AlertClient) ById(id string) (*Alert, error) {
+	resp := &Alert{}
+	err := c.rancherClient.doById(ALERT_TYPE, id, resp)
+	if apiError, ok := err.(*ApiError); ok {
+		if apiError.StatusCode == 404 {
+			return nil, nil
+		}
+	}
+	return resp, err
+}
+
+func (c *AlertClient) Delete(container *Alert) error {
+	return c.rancherClient.doResourceDelete(ALERT_TYPE, &container.Resource)
+}
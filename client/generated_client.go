@@ -3,86 +3,102 @@ package client
 type RancherClient struct {
 	RancherBaseClient
 
-	ApiVersion                             ApiVersionOperations
-	Error                                  ErrorOperations
-	AttachInput                            AttachInputOperations
-	DetachInput                            DetachInputOperations
-	SnapshotInput                          SnapshotInputOperations
-	SnapshotCRInput                        SnapshotCRInputOperations
-	Backup                                 BackupOperations
-	BackupInput                            BackupInputOperations
-	BackupStatus                           BackupStatusOperations
-	SyncBackupResource                     SyncBackupResourceOperations
-	Orphan                                 OrphanOperations
-	RestoreStatus                          RestoreStatusOperations
-	PurgeStatus                            PurgeStatusOperations
-	RebuildStatus                          RebuildStatusOperations
-	ReplicaRemoveInput                     ReplicaRemoveInputOperations
-	SalvageInput                           SalvageInputOperations
-	ActivateInput                          ActivateInputOperations
-	ExpandInput                            ExpandInputOperations
-	EngineUpgradeInput                     EngineUpgradeInputOperations
-	Replica                                ReplicaOperations
-	Controller                             ControllerOperations
-	DiskUpdate                             DiskUpdateOperations
-	UpdateReplicaCountInput                UpdateReplicaCountInputOperations
-	UpdateReplicaAutoBalanceInput          UpdateReplicaAutoBalanceInputOperations
-	UpdateDataLocalityInput                UpdateDataLocalityInputOperations
-	UpdateAccessModeInput                  UpdateAccessModeInputOperations
-	UpdateSnapshotDataIntegrityInput       UpdateSnapshotDataIntegrityInputOperations
-	UpdateSnapshotMaxCountInput            UpdateSnapshotMaxCountInputOperations
-	UpdateSnapshotMaxSizeInput             UpdateSnapshotMaxSizeInputOperations
-	UpdateBackupCompressionInput           UpdateBackupCompressionInputOperations
-	UpdateUnmapMarkSnapChainRemovedInput   UpdateUnmapMarkSnapChainRemovedInputOperations
-	UpdateReplicaSoftAntiAffinityInput     UpdateReplicaSoftAntiAffinityInputOperations
-	UpdateReplicaZoneSoftAntiAffinityInput UpdateReplicaZoneSoftAntiAffinityInputOperations
-	UpdateReplicaDiskSoftAntiAffinityInput UpdateReplicaDiskSoftAntiAffinityInputOperations
-	UpdateFreezeFSForSnapshotInput         UpdateFreezeFSForSnapshotInputOperations
-	UpdateBackupTargetInput                UpdateBackupTargetInputOperations
-	UpdateOfflineRebuildingInput           UpdateOfflineRebuildingInputOperations
-	WorkloadStatus                         WorkloadStatusOperations
-	CloneStatus                            CloneStatusOperations
-	Empty                                  EmptyOperations
-	VolumeRecurringJob                     VolumeRecurringJobOperations
-	VolumeRecurringJobInput                VolumeRecurringJobInputOperations
-	PVCreateInput                          PVCreateInputOperations
-	PVCCreateInput                         PVCCreateInputOperations
-	SettingDefinition                      SettingDefinitionOperations
-	VolumeCondition                        VolumeConditionOperations
-	NodeCondition                          NodeConditionOperations
-	DiskCondition                          DiskConditionOperations
-	LonghornCondition                      LonghornConditionOperations
-	SupportBundle                          SupportBundleOperations
-	SupportBundleInitateInput              SupportBundleInitateInputOperations
-	Tag                                    TagOperations
-	InstanceManager                        InstanceManagerOperations
-	BackingImageDiskFileStatus             BackingImageDiskFileStatusOperations
-	BackingImageCleanupInput               BackingImageCleanupInputOperations
-	UpdateMinNumberOfCopiesInput           UpdateMinNumberOfCopiesInputOperations
-	BackingImageRestoreInput               BackingImageRestoreInputOperations
-	Attachment                             AttachmentOperations
-	VolumeAttachment                       VolumeAttachmentOperations
-	Volume                                 VolumeOperations
-	Snapshot                               SnapshotOperations
-	SnapshotCR                             SnapshotCROperations
-	BackupTarget                           BackupTargetOperations
-	BackupVolume                           BackupVolumeOperations
-	BackupBackingImage                     BackupBackingImageOperations
-	Setting                                SettingOperations
-	RecurringJob                           RecurringJobOperations
-	EngineImage                            EngineImageOperations
-	BackingImage                           BackingImageOperations
-	Node                                   NodeOperations
-	DiskUpdateInput                        DiskUpdateInputOperations
-	DiskInfo                               DiskInfoOperations
-	KubernetesStatus                       KubernetesStatusOperations
-	BackupTargetListOutput                 BackupTargetListOutputOperations
-	BackupVolumeListOutput                 BackupVolumeListOutputOperations
-	BackupListOutput                       BackupListOutputOperations
-	SnapshotListOutput                     SnapshotListOutputOperations
-	SystemBackup                           SystemBackupOperations
-	SystemRestore                          SystemRestoreOperations
-	SnapshotCRListOutput                   SnapshotCRListOutputOperations
+	PVCCreateInput                             PVCCreateInputOperations
+	PVCreateInput                              PVCreateInputOperations
+	UpdateAccessModeInput                      UpdateAccessModeInputOperations
+	UpdateBackupCompressionInput               UpdateBackupCompressionInputOperations
+	UpdateBackupTargetInput                    UpdateBackupTargetInputOperations
+	UpdateDataLocalityInput                    UpdateDataLocalityInputOperations
+	UpdateFreezeFSForSnapshotInput             UpdateFreezeFSForSnapshotInputOperations
+	UpdateOfflineRebuildingInput               UpdateOfflineRebuildingInputOperations
+	UpdateReplicaAutoBalanceInput              UpdateReplicaAutoBalanceInputOperations
+	UpdateReplicaCountInput                    UpdateReplicaCountInputOperations
+	UpdateReplicaDiskSoftAntiAffinityInput     UpdateReplicaDiskSoftAntiAffinityInputOperations
+	UpdateReplicaRebuildingBandwidthLimitInput UpdateReplicaRebuildingBandwidthLimitInputOperations
+	UpdateReplicaSoftAntiAffinityInput         UpdateReplicaSoftAntiAffinityInputOperations
+	UpdateReplicaZoneSoftAntiAffinityInput     UpdateReplicaZoneSoftAntiAffinityInputOperations
+	UpdateSnapshotDataIntegrityInput           UpdateSnapshotDataIntegrityInputOperations
+	UpdateSnapshotMaxCountInput                UpdateSnapshotMaxCountInputOperations
+	UpdateSnapshotMaxSizeInput                 UpdateSnapshotMaxSizeInputOperations
+	UpdateTieringPolicyInput                   UpdateTieringPolicyInputOperations
+	UpdateUnmapMarkSnapChainRemovedInput       UpdateUnmapMarkSnapChainRemovedInputOperations
+	ActivateInput                              ActivateInputOperations
+	Alert                                      AlertOperations
+	ApiVersion                                 ApiVersionOperations
+	AttachInput                                AttachInputOperations
+	Attachment                                 AttachmentOperations
+	BackingImage                               BackingImageOperations
+	BackingImageCleanupInput                   BackingImageCleanupInputOperations
+	BackingImageDiskFileStatus                 BackingImageDiskFileStatusOperations
+	BackingImageRestoreInput                   BackingImageRestoreInputOperations
+	Backup                                     BackupOperations
+	BackupBackingImage                         BackupBackingImageOperations
+	BackupInput                                BackupInputOperations
+	BackupListOutput                           BackupListOutputOperations
+	BackupStatus                               BackupStatusOperations
+	BackupTarget                               BackupTargetOperations
+	BackupTargetListOutput                     BackupTargetListOutputOperations
+	BackupVolume                               BackupVolumeOperations
+	BackupVolumeListOutput                     BackupVolumeListOutputOperations
+	CloneStatus                                CloneStatusOperations
+	Controller                                 ControllerOperations
+	DetachInput                                DetachInputOperations
+	DiskCondition                              DiskConditionOperations
+	DiskInfo                                   DiskInfoOperations
+	DiskReplaceInput                           DiskReplaceInputOperations
+	DiskUpdate                                 DiskUpdateOperations
+	DiskUpdateInput                            DiskUpdateInputOperations
+	Empty                                      EmptyOperations
+	EngineImage                                EngineImageOperations
+	EngineUpgradeInput                         EngineUpgradeInputOperations
+	Error                                      ErrorOperations
+	Event                                      EventOperations
+	ExpandInput                                ExpandInputOperations
+	InstanceManager                            InstanceManagerOperations
+	InstanceProcess                            InstanceProcessOperations
+	KubernetesStatus                           KubernetesStatusOperations
+	LonghornCondition                          LonghornConditionOperations
+	NfsExportOptions                           NfsExportOptionsOperations
+	Node                                       NodeOperations
+	NodeCondition                              NodeConditionOperations
+	Orphan                                     OrphanOperations
+	PreviewRevertInput                         PreviewRevertInputOperations
+	PurgeStatus                                PurgeStatusOperations
+	RebuildStatus                              RebuildStatusOperations
+	RecurringJob                               RecurringJobOperations
+	Replica                                    ReplicaOperations
+	ReplicaRemoveInput                         ReplicaRemoveInputOperations
+	RestoreStatus                              RestoreStatusOperations
+	RevertPreviewInput                         RevertPreviewInputOperations
+	SalvageCandidate                           SalvageCandidateOperations
+	SalvageInput                               SalvageInputOperations
+	SalvagePreview                             SalvagePreviewOperations
+	SalvageReport                              SalvageReportOperations
+	Setting                                    SettingOperations
+	SettingDefinition                          SettingDefinitionOperations
+	Snapshot                                   SnapshotOperations
+	SnapshotCR                                 SnapshotCROperations
+	SnapshotCRInput                            SnapshotCRInputOperations
+	SnapshotCRListOutput                       SnapshotCRListOutputOperations
+	SnapshotExportInput                        SnapshotExportInputOperations
+	SnapshotInput                              SnapshotInputOperations
+	SnapshotListOutput                         SnapshotListOutputOperations
+	SupportBundle                              SupportBundleOperations
+	SupportBundleInitateInput                  SupportBundleInitateInputOperations
+	SyncBackupResource                         SyncBackupResourceOperations
+	SystemBackup                               SystemBackupOperations
+	SystemRestore                              SystemRestoreOperations
+	Tag                                        TagOperations
+	TieringPolicy                              TieringPolicyOperations
+	TopologySpreadConstraint                   TopologySpreadConstraintOperations
+	UpdateMinNumberOfCopiesInput               UpdateMinNumberOfCopiesInputOperations
+	Usage                                      UsageOperations
+	Volume                                     VolumeOperations
+	VolumeAttachment                           VolumeAttachmentOperations
+	VolumeCondition                            VolumeConditionOperations
+	VolumeRecurringJob                         VolumeRecurringJobOperations
+	VolumeRecurringJobInput                    VolumeRecurringJobInputOperations
+	WorkloadStatus                             WorkloadStatusOperations
 }
 
 func constructClient(rancherBaseClient *RancherBaseClientImpl) *RancherClient {
@@ -90,86 +106,102 @@ func constructClient(rancherBaseClient *RancherBaseClientImpl) *RancherClient {
 		RancherBaseClient: rancherBaseClient,
 	}
 
+	client.PVCCreateInput = newPVCCreateInputClient(client)
+	client.PVCreateInput = newPVCreateInputClient(client)
+	client.UpdateAccessModeInput = newUpdateAccessModeInputClient(client)
+	client.UpdateBackupCompressionInput = newUpdateBackupCompressionInputClient(client)
+	client.UpdateBackupTargetInput = newUpdateBackupTargetInputClient(client)
+	client.UpdateDataLocalityInput = newUpdateDataLocalityInputClient(client)
+	client.UpdateFreezeFSForSnapshotInput = newUpdateFreezeFSForSnapshotInputClient(client)
+	client.UpdateOfflineRebuildingInput = newUpdateOfflineRebuildingInputClient(client)
+	client.UpdateReplicaAutoBalanceInput = newUpdateReplicaAutoBalanceInputClient(client)
+	client.UpdateReplicaCountInput = newUpdateReplicaCountInputClient(client)
+	client.UpdateReplicaDiskSoftAntiAffinityInput = newUpdateReplicaDiskSoftAntiAffinityInputClient(client)
+	client.UpdateReplicaRebuildingBandwidthLimitInput = newUpdateReplicaRebuildingBandwidthLimitInputClient(client)
+	client.UpdateReplicaSoftAntiAffinityInput = newUpdateReplicaSoftAntiAffinityInputClient(client)
+	client.UpdateReplicaZoneSoftAntiAffinityInput = newUpdateReplicaZoneSoftAntiAffinityInputClient(client)
+	client.UpdateSnapshotDataIntegrityInput = newUpdateSnapshotDataIntegrityInputClient(client)
+	client.UpdateSnapshotMaxCountInput = newUpdateSnapshotMaxCountInputClient(client)
+	client.UpdateSnapshotMaxSizeInput = newUpdateSnapshotMaxSizeInputClient(client)
+	client.UpdateTieringPolicyInput = newUpdateTieringPolicyInputClient(client)
+	client.UpdateUnmapMarkSnapChainRemovedInput = newUpdateUnmapMarkSnapChainRemovedInputClient(client)
+	client.ActivateInput = newActivateInputClient(client)
+	client.Alert = newAlertClient(client)
 	client.ApiVersion = newApiVersionClient(client)
-	client.Error = newErrorClient(client)
 	client.AttachInput = newAttachInputClient(client)
-	client.DetachInput = newDetachInputClient(client)
-	client.SnapshotInput = newSnapshotInputClient(client)
-	client.SnapshotCRInput = newSnapshotCRInputClient(client)
+	client.Attachment = newAttachmentClient(client)
+	client.BackingImage = newBackingImageClient(client)
+	client.BackingImageCleanupInput = newBackingImageCleanupInputClient(client)
+	client.BackingImageDiskFileStatus = newBackingImageDiskFileStatusClient(client)
+	client.BackingImageRestoreInput = newBackingImageRestoreInputClient(client)
 	client.Backup = newBackupClient(client)
+	client.BackupBackingImage = newBackupBackingImageClient(client)
 	client.BackupInput = newBackupInputClient(client)
+	client.BackupListOutput = newBackupListOutputClient(client)
 	client.BackupStatus = newBackupStatusClient(client)
-	client.SyncBackupResource = newSyncBackupResourceClient(client)
+	client.BackupTarget = newBackupTargetClient(client)
+	client.BackupTargetListOutput = newBackupTargetListOutputClient(client)
+	client.BackupVolume = newBackupVolumeClient(client)
+	client.BackupVolumeListOutput = newBackupVolumeListOutputClient(client)
+	client.CloneStatus = newCloneStatusClient(client)
+	client.Controller = newControllerClient(client)
+	client.DetachInput = newDetachInputClient(client)
+	client.DiskCondition = newDiskConditionClient(client)
+	client.DiskInfo = newDiskInfoClient(client)
+	client.DiskReplaceInput = newDiskReplaceInputClient(client)
+	client.DiskUpdate = newDiskUpdateClient(client)
+	client.DiskUpdateInput = newDiskUpdateInputClient(client)
+	client.Empty = newEmptyClient(client)
+	client.EngineImage = newEngineImageClient(client)
+	client.EngineUpgradeInput = newEngineUpgradeInputClient(client)
+	client.Error = newErrorClient(client)
+	client.Event = newEventClient(client)
+	client.ExpandInput = newExpandInputClient(client)
+	client.InstanceManager = newInstanceManagerClient(client)
+	client.InstanceProcess = newInstanceProcessClient(client)
+	client.KubernetesStatus = newKubernetesStatusClient(client)
+	client.LonghornCondition = newLonghornConditionClient(client)
+	client.NfsExportOptions = newNfsExportOptionsClient(client)
+	client.Node = newNodeClient(client)
+	client.NodeCondition = newNodeConditionClient(client)
 	client.Orphan = newOrphanClient(client)
-	client.RestoreStatus = newRestoreStatusClient(client)
+	client.PreviewRevertInput = newPreviewRevertInputClient(client)
 	client.PurgeStatus = newPurgeStatusClient(client)
 	client.RebuildStatus = newRebuildStatusClient(client)
+	client.RecurringJob = newRecurringJobClient(client)
+	client.Replica = newReplicaClient(client)
 	client.ReplicaRemoveInput = newReplicaRemoveInputClient(client)
+	client.RestoreStatus = newRestoreStatusClient(client)
+	client.RevertPreviewInput = newRevertPreviewInputClient(client)
+	client.SalvageCandidate = newSalvageCandidateClient(client)
 	client.SalvageInput = newSalvageInputClient(client)
-	client.ActivateInput = newActivateInputClient(client)
-	client.ExpandInput = newExpandInputClient(client)
-	client.EngineUpgradeInput = newEngineUpgradeInputClient(client)
-	client.Replica = newReplicaClient(client)
-	client.Controller = newControllerClient(client)
-	client.DiskUpdate = newDiskUpdateClient(client)
-	client.UpdateReplicaCountInput = newUpdateReplicaCountInputClient(client)
-	client.UpdateReplicaAutoBalanceInput = newUpdateReplicaAutoBalanceInputClient(client)
-	client.UpdateDataLocalityInput = newUpdateDataLocalityInputClient(client)
-	client.UpdateAccessModeInput = newUpdateAccessModeInputClient(client)
-	client.UpdateSnapshotDataIntegrityInput = newUpdateSnapshotDataIntegrityInputClient(client)
-	client.UpdateSnapshotMaxCountInput = newUpdateSnapshotMaxCountInputClient(client)
-	client.UpdateSnapshotMaxSizeInput = newUpdateSnapshotMaxSizeInputClient(client)
-	client.UpdateBackupCompressionInput = newUpdateBackupCompressionInputClient(client)
-	client.UpdateUnmapMarkSnapChainRemovedInput = newUpdateUnmapMarkSnapChainRemovedInputClient(client)
-	client.UpdateReplicaSoftAntiAffinityInput = newUpdateReplicaSoftAntiAffinityInputClient(client)
-	client.UpdateReplicaZoneSoftAntiAffinityInput = newUpdateReplicaZoneSoftAntiAffinityInputClient(client)
-	client.UpdateReplicaDiskSoftAntiAffinityInput = newUpdateReplicaDiskSoftAntiAffinityInputClient(client)
-	client.UpdateFreezeFSForSnapshotInput = newUpdateFreezeFSForSnapshotInputClient(client)
-	client.UpdateBackupTargetInput = newUpdateBackupTargetInputClient(client)
-	client.UpdateOfflineRebuildingInput = newUpdateOfflineRebuildingInputClient(client)
-	client.WorkloadStatus = newWorkloadStatusClient(client)
-	client.CloneStatus = newCloneStatusClient(client)
-	client.Empty = newEmptyClient(client)
-	client.VolumeRecurringJob = newVolumeRecurringJobClient(client)
-	client.VolumeRecurringJobInput = newVolumeRecurringJobInputClient(client)
-	client.PVCreateInput = newPVCreateInputClient(client)
-	client.PVCCreateInput = newPVCCreateInputClient(client)
+	client.SalvagePreview = newSalvagePreviewClient(client)
+	client.SalvageReport = newSalvageReportClient(client)
+	client.Setting = newSettingClient(client)
 	client.SettingDefinition = newSettingDefinitionClient(client)
-	client.VolumeCondition = newVolumeConditionClient(client)
-	client.NodeCondition = newNodeConditionClient(client)
-	client.DiskCondition = newDiskConditionClient(client)
-	client.LonghornCondition = newLonghornConditionClient(client)
-	client.SupportBundle = newSupportBundleClient(client)
-	client.SupportBundleInitateInput = newSupportBundleInitateInputClient(client)
-	client.Tag = newTagClient(client)
-	client.InstanceManager = newInstanceManagerClient(client)
-	client.BackingImageDiskFileStatus = newBackingImageDiskFileStatusClient(client)
-	client.BackingImageCleanupInput = newBackingImageCleanupInputClient(client)
-	client.UpdateMinNumberOfCopiesInput = newUpdateMinNumberOfCopiesInputClient(client)
-	client.BackingImageRestoreInput = newBackingImageRestoreInputClient(client)
-	client.Attachment = newAttachmentClient(client)
-	client.VolumeAttachment = newVolumeAttachmentClient(client)
-	client.Volume = newVolumeClient(client)
 	client.Snapshot = newSnapshotClient(client)
 	client.SnapshotCR = newSnapshotCRClient(client)
-	client.BackupTarget = newBackupTargetClient(client)
-	client.BackupVolume = newBackupVolumeClient(client)
-	client.BackupBackingImage = newBackupBackingImageClient(client)
-	client.Setting = newSettingClient(client)
-	client.RecurringJob = newRecurringJobClient(client)
-	client.EngineImage = newEngineImageClient(client)
-	client.BackingImage = newBackingImageClient(client)
-	client.Node = newNodeClient(client)
-	client.DiskUpdateInput = newDiskUpdateInputClient(client)
-	client.DiskInfo = newDiskInfoClient(client)
-	client.KubernetesStatus = newKubernetesStatusClient(client)
-	client.BackupTargetListOutput = newBackupTargetListOutputClient(client)
-	client.BackupVolumeListOutput = newBackupVolumeListOutputClient(client)
-	client.BackupListOutput = newBackupListOutputClient(client)
+	client.SnapshotCRInput = newSnapshotCRInputClient(client)
+	client.SnapshotCRListOutput = newSnapshotCRListOutputClient(client)
+	client.SnapshotExportInput = newSnapshotExportInputClient(client)
+	client.SnapshotInput = newSnapshotInputClient(client)
 	client.SnapshotListOutput = newSnapshotListOutputClient(client)
+	client.SupportBundle = newSupportBundleClient(client)
+	client.SupportBundleInitateInput = newSupportBundleInitateInputClient(client)
+	client.SyncBackupResource = newSyncBackupResourceClient(client)
 	client.SystemBackup = newSystemBackupClient(client)
 	client.SystemRestore = newSystemRestoreClient(client)
-	client.SnapshotCRListOutput = newSnapshotCRListOutputClient(client)
+	client.Tag = newTagClient(client)
+	client.TieringPolicy = newTieringPolicyClient(client)
+	client.TopologySpreadConstraint = newTopologySpreadConstraintClient(client)
+	client.UpdateMinNumberOfCopiesInput = newUpdateMinNumberOfCopiesInputClient(client)
+	client.Usage = newUsageClient(client)
+	client.Volume = newVolumeClient(client)
+	client.VolumeAttachment = newVolumeAttachmentClient(client)
+	client.VolumeCondition = newVolumeConditionClient(client)
+	client.VolumeRecurringJob = newVolumeRecurringJobClient(client)
+	client.VolumeRecurringJobInput = newVolumeRecurringJobInputClient(client)
+	client.WorkloadStatus = newWorkloadStatusClient(client)
 
 	return client
 }
@@ -21,12 +21,18 @@ type DiskInfo struct {
 
 	Path string `json:"path,omitempty" yaml:"path,omitempty"`
 
+	ProjectedFullTime string `json:"projectedFullTime,omitempty" yaml:"projected_full_time,omitempty"`
+
+	ReplacedDiskUUID string `json:"replacedDiskUUID,omitempty" yaml:"replaced_disk_uuid,omitempty"`
+
 	ScheduledBackingImage map[string]string `json:"scheduledBackingImage,omitempty" yaml:"scheduled_backing_image,omitempty"`
 
 	ScheduledReplica map[string]string `json:"scheduledReplica,omitempty" yaml:"scheduled_replica,omitempty"`
 
 	StorageAvailable int64 `json:"storageAvailable,omitempty" yaml:"storage_available,omitempty"`
 
+	StorageGrowthRate int64 `json:"storageGrowthRate,omitempty" yaml:"storage_growth_rate,omitempty"`
+
 	StorageMaximum int64 `json:"storageMaximum,omitempty" yaml:"storage_maximum,omitempty"`
 
 	StorageReserved int64 `json:"storageReserved,omitempty" yaml:"storage_reserved,omitempty"`
@@ -0,0 +1,83 @@
+package client
+
+const (
+	DISK_REPLACE_INPUT_TYPE = "diskReplaceInput"
+)
+
+type DiskReplaceInput struct {
+	Resource `yaml:"-"`
+
+	DiskDriver string `json:"diskDriver,omitempty" yaml:"disk_driver,omitempty"`
+
+	DiskName string `json:"diskName,omitempty" yaml:"disk_name,omitempty"`
+
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+}
+
+type DiskReplaceInputCollection struct {
+	Collection
+	Data   []DiskReplaceInput `json:"data,omitempty"`
+	client *DiskReplaceInputClient
+}
+
+type DiskReplaceInputClient struct {
+	rancherClient *RancherClient
+}
+
+type DiskReplaceInputOperations interface {
+	List(opts *ListOpts) (*DiskReplaceInputCollection, error)
+	Create(opts *DiskReplaceInput) (*DiskReplaceInput, error)
+	Update(existing *DiskReplaceInput, updates interface{}) (*DiskReplaceInput, error)
+	ById(id string) (*DiskReplaceInput, error)
+	Delete(container *DiskReplaceInput) error
+}
+
+func newDiskReplaceInputClient(rancherClient *RancherClient) *DiskReplaceInputClient {
+	return &DiskReplaceInputClient{
+		rancherClient: rancherClient,
+	}
+}
+
+func (c *DiskReplaceInputClient) Create(container *DiskReplaceInput) (*DiskReplaceInput, error) {
+	resp := &DiskReplaceInput{}
+	err := c.rancherClient.doCreate(DISK_REPLACE_INPUT_TYPE, container, resp)
+	return resp, err
+}
+
+func (c *DiskReplaceInputClient) Update(existing *DiskReplaceInput, updates interface{}) (*DiskReplaceInput, error) {
+	resp := &DiskReplaceInput{}
+	err := c.rancherClient.doUpdate(DISK_REPLACE_INPUT_TYPE, &existing.Resource, updates, resp)
+	return resp, err
+}
+
+func (c *DiskReplaceInputClient) List(opts *ListOpts) (*DiskReplaceInputCollection, error) {
+	resp := &DiskReplaceInputCollection{}
+	err := c.rancherClient.doList(DISK_REPLACE_INPUT_TYPE, opts, resp)
+	resp.client = c
+	return resp, err
+}
+
+func (cc *DiskReplaceInputCollection) Next() (*DiskReplaceInputCollection, error) {
+	if cc != nil && cc.Pagination != nil && cc.Pagination.Next != "" {
+		resp := &DiskReplaceInputCollection{}
+		err := cc.client.rancherClient.doNext(cc.Pagination.Next, resp)
+		resp.client = cc.client
+		return resp, err
+	}
+	return nil, nil
+}
+
+func (c *DiskReplaceInputClient) ById(id string) (*DiskReplaceInput, error) {
+	resp := &DiskReplaceInput{}
+	err := c.rancherClient.doById(DISK_REPLACE_INPUT_TYPE, id, resp)
+	if apiError, ok := err.(*ApiError); ok {
+		if apiError.StatusCode == 404 {
+			return nil, nil
+		}
+	}
+	return resp, err
+}
+
+func (c *DiskReplaceInputClient) Delete(container *DiskReplaceInput) error {
+	return c.rancherClient.doResourceDelete(DISK_REPLACE_INPUT_TYPE, &container.Resource)
+}
@@ -17,6 +17,8 @@ type DiskUpdate struct {
 
 	Path string `json:"path,omitempty" yaml:"path,omitempty"`
 
+	ReplacedDiskUUID string `json:"replacedDiskUUID,omitempty" yaml:"replaced_disk_uuid,omitempty"`
+
 	StorageReserved int64 `json:"storageReserved,omitempty" yaml:"storage_reserved,omitempty"`
 
 	Tags []string `json:"tags,omitempty" yaml:"tags,omitempty"`
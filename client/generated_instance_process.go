@@ -0,0 +1,81 @@
+package client
+
+const (
+	INSTANCE_PROCESS_TYPE = "instanceProcess"
+)
+
+type InstanceProcess struct {
+	Resource `yaml:"-"`
+
+	Spec interface{} `json:"spec,omitempty" yaml:"spec,omitempty"`
+
+	Status interface{} `json:"status,omitempty" yaml:"status,omitempty"`
+}
+
+type InstanceProcessCollection struct {
+	Collection
+	Data   []InstanceProcess `json:"data,omitempty"`
+	client *InstanceProcessClient
+}
+
+type InstanceProcessClient struct {
+	rancherClient *RancherClient
+}
+
+type InstanceProcessOperations interface {
+	List(opts *ListOpts) (*InstanceProcessCollection, error)
+	Create(opts *InstanceProcess) (*InstanceProcess, error)
+	Update(existing *InstanceProcess, updates interface{}) (*InstanceProcess, error)
+	ById(id string) (*InstanceProcess, error)
+	Delete(container *InstanceProcess) error
+}
+
+func newInstanceProcessClient(rancherClient *RancherClient) *InstanceProcessClient {
+	return &InstanceProcessClient{
+		rancherClient: rancherClient,
+	}
+}
+
+func (c *InstanceProcessClient) Create(container *InstanceProcess) (*InstanceProcess, error) {
+	resp := &InstanceProcess{}
+	err := c.rancherClient.doCreate(INSTANCE_PROCESS_TYPE, container, resp)
+	return resp, err
+}
+
+func (c *InstanceProcessClient) Update(existing *InstanceProcess, updates interface{}) (*InstanceProcess, error) {
+	resp := &InstanceProcess{}
+	err := c.rancherClient.doUpdate(INSTANCE_PROCESS_TYPE, &existing.Resource, updates, resp)
+	return resp, err
+}
+
+func (c *InstanceProcessClient) List(opts *ListOpts) (*InstanceProcessCollection, error) {
+	resp := &InstanceProcessCollection{}
+	err := c.rancherClient.doList(INSTANCE_PROCESS_TYPE, opts, resp)
+	resp.client = c
+	return resp, err
+}
+
+func (cc *InstanceProcessCollection) Next() (*InstanceProcessCollection, error) {
+	if cc != nil && cc.Pagination != nil && cc.Pagination.Next != "" {
+		resp := &InstanceProcessCollection{}
+		err := cc.client.rancherClient.doNext(cc.Pagination.Next, resp)
+		resp.client = cc.client
+		return resp, err
+	}
+	return nil, nil
+}
+
+func (c *InstanceProcessClient) ById(id string) (*InstanceProcess, error) {
+	resp := &InstanceProcess{}
+	err := c.rancherClient.doById(INSTANCE_PROCESS_TYPE, id, resp)
+	if apiError, ok := err.(*ApiError); ok {
+		if apiError.StatusCode == 404 {
+			return nil, nil
+		}
+	}
+	return resp, err
+}
+
+func (c *InstanceProcessClient) Delete(container *InstanceProcess) error {
+	return c.rancherClient.doResourceDelete(INSTANCE_PROCESS_TYPE, &container.Resource)
+}
@@ -0,0 +1,89 @@
+package client
+
+const (
+	NFS_EXPORT_OPTIONS_TYPE = "nfsExportOptions"
+)
+
+type NfsExportOptions struct {
+	Resource `yaml:"-"`
+
+	AllowedClients []string `json:"allowedClients,omitempty" yaml:"allowed_clients,omitempty"`
+
+	AnonGID int64 `json:"anonGID,omitempty" yaml:"anon_gid,omitempty"`
+
+	AnonUID int64 `json:"anonUID,omitempty" yaml:"anon_uid,omitempty"`
+
+	ReadOnly bool `json:"readOnly,omitempty" yaml:"read_only,omitempty"`
+
+	Sec string `json:"sec,omitempty" yaml:"sec,omitempty"`
+
+	Squash string `json:"squash,omitempty" yaml:"squash,omitempty"`
+}
+
+type NfsExportOptionsCollection struct {
+	Collection
+	Data   []NfsExportOptions `json:"data,omitempty"`
+	client *NfsExportOptionsClient
+}
+
+type NfsExportOptionsClient struct {
+	rancherClient *RancherClient
+}
+
+type NfsExportOptionsOperations interface {
+	List(opts *ListOpts) (*NfsExportOptionsCollection, error)
+	Create(opts *NfsExportOptions) (*NfsExportOptions, error)
+	Update(existing *NfsExportOptions, updates interface{}) (*NfsExportOptions, error)
+	ById(id string) (*NfsExportOptions, error)
+	Delete(container *NfsExportOptions) error
+}
+
+func newNfsExportOptionsClient(rancherClient *RancherClient) *NfsExportOptionsClient {
+	return &NfsExportOptionsClient{
+		rancherClient: rancherClient,
+	}
+}
+
+func (c *NfsExportOptionsClient) Create(container *NfsExportOptions) (*NfsExportOptions, error) {
+	resp := &NfsExportOptions{}
+	err := c.rancherClient.doCreate(NFS_EXPORT_OPTIONS_TYPE, container, resp)
+	return resp, err
+}
+
+func (c *NfsExportOptionsClient) Update(existing *NfsExportOptions, updates interface{}) (*NfsExportOptions, error) {
+	resp := &NfsExportOptions{}
+	err := c.rancherClient.doUpdate(NFS_EXPORT_OPTIONS_TYPE, &existing.Resource, updates, resp)
+	return resp, err
+}
+
+func (c *NfsExportOptionsClient) List(opts *ListOpts) (*NfsExportOptionsCollection, error) {
+	resp := &NfsExportOptionsCollection{}
+	err := c.rancherClient.doList(NFS_EXPORT_OPTIONS_TYPE, opts, resp)
+	resp.client = c
+	return resp, err
+}
+
+func (cc *NfsExportOptionsCollection) Next() (*NfsExportOptionsCollection, error) {
+	if cc != nil && cc.Pagination != nil && cc.Pagination.Next != "" {
+		resp := &NfsExportOptionsCollection{}
+		err := cc.client.rancherClient.doNext(cc.Pagination.Next, resp)
+		resp.client = cc.client
+		return resp, err
+	}
+	return nil, nil
+}
+
+func (c *NfsExportOptionsClient) ById(id string) (*NfsExportOptions, error) {
+	resp := &NfsExportOptions{}
+	err := c.rancherClient.doById(NFS_EXPORT_OPTIONS_TYPE, id, resp)
+	if apiError, ok := err.(*ApiError); ok {
+		if apiError.StatusCode == 404 {
+			return nil, nil
+		}
+	}
+	return resp, err
+}
+
+func (c *NfsExportOptionsClient) Delete(container *NfsExportOptions) error {
+	return c.rancherClient.doResourceDelete(NFS_EXPORT_OPTIONS_TYPE, &container.Resource)
+}
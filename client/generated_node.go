@@ -27,6 +27,8 @@ type Node struct {
 
 	Tags []string `json:"tags,omitempty" yaml:"tags,omitempty"`
 
+	Topology map[string]string `json:"topology,omitempty" yaml:"topology,omitempty"`
+
 	Zone string `json:"zone,omitempty" yaml:"zone,omitempty"`
 }
 
@@ -48,6 +50,8 @@ type NodeOperations interface {
 	Delete(container *Node) error
 
 	ActionDiskUpdate(*Node, *DiskUpdateInput) (*Node, error)
+
+	ActionReplaceDisk(*Node, *DiskReplaceInput) (*Node, error)
 }
 
 func newNodeClient(rancherClient *RancherClient) *NodeClient {
@@ -108,3 +112,12 @@ func (c *NodeClient) ActionDiskUpdate(resource *Node, input *DiskUpdateInput) (*
 
 	return resp, err
 }
+
+func (c *NodeClient) ActionReplaceDisk(resource *Node, input *DiskReplaceInput) (*Node, error) {
+
+	resp := &Node{}
+
+	err := c.rancherClient.doAction(NODE_TYPE, "replaceDisk", &resource.Resource, input, resp)
+
+	return resp, err
+}
@@ -0,0 +1,83 @@
+package client
+
+const (
+	PREVIEW_REVERT_INPUT_TYPE = "previewRevertInput"
+)
+
+type PreviewRevertInput struct {
+	Resource `yaml:"-"`
+
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+
+	NodeID string `json:"nodeID,omitempty" yaml:"node_id,omitempty"`
+
+	TTL string `json:"ttl,omitempty" yaml:"ttl,omitempty"`
+}
+
+type PreviewRevertInputCollection struct {
+	Collection
+	Data   []PreviewRevertInput `json:"data,omitempty"`
+	client *PreviewRevertInputClient
+}
+
+type PreviewRevertInputClient struct {
+	rancherClient *RancherClient
+}
+
+type PreviewRevertInputOperations interface {
+	List(opts *ListOpts) (*PreviewRevertInputCollection, error)
+	Create(opts *PreviewRevertInput) (*PreviewRevertInput, error)
+	Update(existing *PreviewRevertInput, updates interface{}) (*PreviewRevertInput, error)
+	ById(id string) (*PreviewRevertInput, error)
+	Delete(container *PreviewRevertInput) error
+}
+
+func newPreviewRevertInputClient(rancherClient *RancherClient) *PreviewRevertInputClient {
+	return &PreviewRevertInputClient{
+		rancherClient: rancherClient,
+	}
+}
+
+func (c *PreviewRevertInputClient) Create(container *PreviewRevertInput) (*PreviewRevertInput, error) {
+	resp := &PreviewRevertInput{}
+	err := c.rancherClient.doCreate(PREVIEW_REVERT_INPUT_TYPE, container, resp)
+	return resp, err
+}
+
+func (c *PreviewRevertInputClient) Update(existing *PreviewRevertInput, updates interface{}) (*PreviewRevertInput, error) {
+	resp := &PreviewRevertInput{}
+	err := c.rancherClient.doUpdate(PREVIEW_REVERT_INPUT_TYPE, &existing.Resource, updates, resp)
+	return resp, err
+}
+
+func (c *PreviewRevertInputClient) List(opts *ListOpts) (*PreviewRevertInputCollection, error) {
+	resp := &PreviewRevertInputCollection{}
+	err := c.rancherClient.doList(PREVIEW_REVERT_INPUT_TYPE, opts, resp)
+	resp.client = c
+	return resp, err
+}
+
+func (cc *PreviewRevertInputCollection) Next() (*PreviewRevertInputCollection, error) {
+	if cc != nil && cc.Pagination != nil && cc.Pagination.Next != "" {
+		resp := &PreviewRevertInputCollection{}
+		err := cc.client.rancherClient.doNext(cc.Pagination.Next, resp)
+		resp.client = cc.client
+		return resp, err
+	}
+	return nil, nil
+}
+
+func (c *PreviewRevertInputClient) ById(id string) (*PreviewRevertInput, error) {
+	resp := &PreviewRevertInput{}
+	err := c.rancherClient.doById(PREVIEW_REVERT_INPUT_TYPE, id, resp)
+	if apiError, ok := err.(*ApiError); ok {
+		if apiError.StatusCode == 404 {
+			return nil, nil
+		}
+	}
+	return resp, err
+}
+
+func (c *PreviewRevertInputClient) Delete(container *PreviewRevertInput) error {
+	return c.rancherClient.doResourceDelete(PREVIEW_REVERT_INPUT_TYPE, &container.Resource)
+}
@@ -7,8 +7,14 @@ const (
 type PurgeStatus struct {
 	Resource `yaml:"-"`
 
+	BytesPerSecond int64 `json:"bytesPerSecond,omitempty" yaml:"bytes_per_second,omitempty"`
+
 	Error string `json:"error,omitempty" yaml:"error,omitempty"`
 
+	EstimatedTransferredBytes int64 `json:"estimatedTransferredBytes,omitempty" yaml:"estimated_transferred_bytes,omitempty"`
+
+	EtaSeconds int64 `json:"etaSeconds,omitempty" yaml:"eta_seconds,omitempty"`
+
 	IsPurging bool `json:"isPurging,omitempty" yaml:"is_purging,omitempty"`
 
 	Progress int64 `json:"progress,omitempty" yaml:"progress,omitempty"`
@@ -16,6 +22,8 @@ type PurgeStatus struct {
 	Replica string `json:"replica,omitempty" yaml:"replica,omitempty"`
 
 	State string `json:"state,omitempty" yaml:"state,omitempty"`
+
+	TotalBytes int64 `json:"totalBytes,omitempty" yaml:"total_bytes,omitempty"`
 }
 
 type PurgeStatusCollection struct {
@@ -7,8 +7,14 @@ const (
 type RebuildStatus struct {
 	Resource `yaml:"-"`
 
+	BytesPerSecond int64 `json:"bytesPerSecond,omitempty" yaml:"bytes_per_second,omitempty"`
+
 	Error string `json:"error,omitempty" yaml:"error,omitempty"`
 
+	EstimatedTransferredBytes int64 `json:"estimatedTransferredBytes,omitempty" yaml:"estimated_transferred_bytes,omitempty"`
+
+	EtaSeconds int64 `json:"etaSeconds,omitempty" yaml:"eta_seconds,omitempty"`
+
 	FromReplica string `json:"fromReplica,omitempty" yaml:"from_replica,omitempty"`
 
 	IsRebuilding bool `json:"isRebuilding,omitempty" yaml:"is_rebuilding,omitempty"`
@@ -18,6 +24,8 @@ type RebuildStatus struct {
 	Replica string `json:"replica,omitempty" yaml:"replica,omitempty"`
 
 	State string `json:"state,omitempty" yaml:"state,omitempty"`
+
+	TotalBytes int64 `json:"totalBytes,omitempty" yaml:"total_bytes,omitempty"`
 }
 
 type RebuildStatusCollection struct {
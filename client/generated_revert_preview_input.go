@@ -0,0 +1,79 @@
+package client
+
+const (
+	REVERT_PREVIEW_INPUT_TYPE = "revertPreviewInput"
+)
+
+type RevertPreviewInput struct {
+	Resource `yaml:"-"`
+
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+}
+
+type RevertPreviewInputCollection struct {
+	Collection
+	Data   []RevertPreviewInput `json:"data,omitempty"`
+	client *RevertPreviewInputClient
+}
+
+type RevertPreviewInputClient struct {
+	rancherClient *RancherClient
+}
+
+type RevertPreviewInputOperations interface {
+	List(opts *ListOpts) (*RevertPreviewInputCollection, error)
+	Create(opts *RevertPreviewInput) (*RevertPreviewInput, error)
+	Update(existing *RevertPreviewInput, updates interface{}) (*RevertPreviewInput, error)
+	ById(id string) (*RevertPreviewInput, error)
+	Delete(container *RevertPreviewInput) error
+}
+
+func newRevertPreviewInputClient(rancherClient *RancherClient) *RevertPreviewInputClient {
+	return &RevertPreviewInputClient{
+		rancherClient: rancherClient,
+	}
+}
+
+func (c *RevertPreviewInputClient) Create(container *RevertPreviewInput) (*RevertPreviewInput, error) {
+	resp := &RevertPreviewInput{}
+	err := c.rancherClient.doCreate(REVERT_PREVIEW_INPUT_TYPE, container, resp)
+	return resp, err
+}
+
+func (c *RevertPreviewInputClient) Update(existing *RevertPreviewInput, updates interface{}) (*RevertPreviewInput, error) {
+	resp := &RevertPreviewInput{}
+	err := c.rancherClient.doUpdate(REVERT_PREVIEW_INPUT_TYPE, &existing.Resource, updates, resp)
+	return resp, err
+}
+
+func (c *RevertPreviewInputClient) List(opts *ListOpts) (*RevertPreviewInputCollection, error) {
+	resp := &RevertPreviewInputCollection{}
+	err := c.rancherClient.doList(REVERT_PREVIEW_INPUT_TYPE, opts, resp)
+	resp.client = c
+	return resp, err
+}
+
+func (cc *RevertPreviewInputCollection) Next() (*RevertPreviewInputCollection, error) {
+	if cc != nil && cc.Pagination != nil && cc.Pagination.Next != "" {
+		resp := &RevertPreviewInputCollection{}
+		err := cc.client.rancherClient.doNext(cc.Pagination.Next, resp)
+		resp.client = cc.client
+		return resp, err
+	}
+	return nil, nil
+}
+
+func (c *RevertPreviewInputClient) ById(id string) (*RevertPreviewInput, error) {
+	resp := &RevertPreviewInput{}
+	err := c.rancherClient.doById(REVERT_PREVIEW_INPUT_TYPE, id, resp)
+	if apiError, ok := err.(*ApiError); ok {
+		if apiError.StatusCode == 404 {
+			return nil, nil
+		}
+	}
+	return resp, err
+}
+
+func (c *RevertPreviewInputClient) Delete(container *RevertPreviewInput) error {
+	return c.rancherClient.doResourceDelete(REVERT_PREVIEW_INPUT_TYPE, &container.Resource)
+}
@@ -0,0 +1,107 @@
+package client
+
+const (
+	SALVAGE_CANDIDATE_TYPE = "salvageCandidate"
+)
+
+type SalvageCandidate struct {
+	Resource `yaml:"-"`
+
+	Chain []string `json:"chain,omitempty" yaml:"chain,omitempty"`
+
+	Dirty bool `json:"dirty,omitempty" yaml:"dirty,omitempty"`
+
+	DiskID string `json:"diskID,omitempty" yaml:"disk_id,omitempty"`
+
+	FailedAt string `json:"failedAt,omitempty" yaml:"failed_at,omitempty"`
+
+	HeadLastModifiedAt string `json:"headLastModifiedAt,omitempty" yaml:"head_last_modified_at,omitempty"`
+
+	HeadName string `json:"headName,omitempty" yaml:"head_name,omitempty"`
+
+	HeadSize int64 `json:"headSize,omitempty" yaml:"head_size,omitempty"`
+
+	MissingSnapshots []string `json:"missingSnapshots,omitempty" yaml:"missing_snapshots,omitempty"`
+
+	NodeID string `json:"nodeID,omitempty" yaml:"node_id,omitempty"`
+
+	Rank int64 `json:"rank,omitempty" yaml:"rank,omitempty"`
+
+	Reasons []string `json:"reasons,omitempty" yaml:"reasons,omitempty"`
+
+	Recommended bool `json:"recommended,omitempty" yaml:"recommended,omitempty"`
+
+	Replica string `json:"replica,omitempty" yaml:"replica,omitempty"`
+
+	RevisionCounter int64 `json:"revisionCounter,omitempty" yaml:"revision_counter,omitempty"`
+
+	Unusable string `json:"unusable,omitempty" yaml:"unusable,omitempty"`
+}
+
+type SalvageCandidateCollection struct {
+	Collection
+	Data   []SalvageCandidate `json:"data,omitempty"`
+	client *SalvageCandidateClient
+}
+
+type SalvageCandidateClient struct {
+	rancherClient *RancherClient
+}
+
+type SalvageCandidateOperations interface {
+	List(opts *ListOpts) (*SalvageCandidateCollection, error)
+	Create(opts *SalvageCandidate) (*SalvageCandidate, error)
+	Update(existing *SalvageCandidate, updates interface{}) (*SalvageCandidate, error)
+	ById(id string) (*SalvageCandidate, error)
+	Delete(container *SalvageCandidate) error
+}
+
+func newSalvageCandidateClient(rancherClient *RancherClient) *SalvageCandidateClient {
+	return &SalvageCandidateClient{
+		rancherClient: rancherClient,
+	}
+}
+
+func (c *SalvageCandidateClient) Create(container *SalvageCandidate) (*SalvageCandidate, error) {
+	resp := &SalvageCandidate{}
+	err := c.rancherClient.doCreate(SALVAGE_CANDIDATE_TYPE, container, resp)
+	return resp, err
+}
+
+func (c *SalvageCandidateClient) Update(existing *SalvageCandidate, updates interface{}) (*SalvageCandidate, error) {
+	resp := &SalvageCandidate{}
+	err := c.rancherClient.doUpdate(SALVAGE_CANDIDATE_TYPE, &existing.Resource, updates, resp)
+	return resp, err
+}
+
+func (c *SalvageCandidateClient) List(opts *ListOpts) (*SalvageCandidateCollection, error) {
+	resp := &SalvageCandidateCollection{}
+	err := c.rancherClient.doList(SALVAGE_CANDIDATE_TYPE, opts, resp)
+	resp.client = c
+	return resp, err
+}
+
+func (cc *SalvageCandidateCollection) Next() (*SalvageCandidateCollection, error) {
+	if cc != nil && cc.Pagination != nil && cc.Pagination.Next != "" {
+		resp := &SalvageCandidateCollection{}
+		err := cc.client.rancherClient.doNext(cc.Pagination.Next, resp)
+		resp.client = cc.client
+		return resp, err
+	}
+	return nil, nil
+}
+
+func (c *SalvageCandidateClient) ById(id string) (*SalvageCandidate, error) {
+	resp := &SalvageCandidate{}
+	err := c.rancherClient.doById(SALVAGE_CANDIDATE_TYPE, id, resp)
+	if apiError, ok := err.(*ApiError); ok {
+		if apiError.StatusCode == 404 {
+			return nil, nil
+		}
+	}
+	return resp, err
+}
+
+func (c *SalvageCandidateClient) Delete(container *SalvageCandidate) error {
+	return c.rancherClient.doResourceDelete(SALVAGE_CANDIDATE_TYPE, &container.Resource)
+}
@@ -0,0 +1,89 @@
+package client
+
+const (
+	SALVAGE_PREVIEW_TYPE = "salvagePreview"
+)
+
+type SalvagePreview struct {
+	Resource `yaml:"-"`
+
+	Chain []string `json:"chain,omitempty" yaml:"chain,omitempty"`
+
+	LostSnapshots []string `json:"lostSnapshots,omitempty" yaml:"lost_snapshots,omitempty"`
+
+	Replicas []string `json:"replicas,omitempty" yaml:"replicas,omitempty"`
+
+	SourceReplica string `json:"sourceReplica,omitempty" yaml:"source_replica,omitempty"`
+
+	Volume string `json:"volume,omitempty" yaml:"volume,omitempty"`
+
+	Warnings []string `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+}
+
+type SalvagePreviewCollection struct {
+	Collection
+	Data   []SalvagePreview `json:"data,omitempty"`
+	client *SalvagePreviewClient
+}
+
+type SalvagePreviewClient struct {
+	rancherClient *RancherClient
+}
+
+type SalvagePreviewOperations interface {
+	List(opts *ListOpts) (*SalvagePreviewCollection, error)
+	Create(opts *SalvagePreview) (*SalvagePreview, error)
+	Update(existing *SalvagePreview, updates interface{}) (*SalvagePreview, error)
+	ById(id string) (*SalvagePreview, error)
+	Delete(container *SalvagePreview) error
+}
+
+func newSalvagePreviewClient(rancherClient *RancherClient) *SalvagePreviewClient {
+	return &SalvagePreviewClient{
+		rancherClient: rancherClient,
+	}
+}
+
+func (c *SalvagePreviewClient) Create(container *SalvagePreview) (*SalvagePreview, error) {
+	resp := &SalvagePreview{}
+	err := c.rancherClient.doCreate(SALVAGE_PREVIEW_TYPE, container, resp)
+	return resp, err
+}
+
+func (c *SalvagePreviewClient) Update(existing *SalvagePreview, updates interface{}) (*SalvagePreview, error) {
+	resp := &SalvagePreview{}
+	err := c.rancherClient.doUpdate(SALVAGE_PREVIEW_TYPE, &existing.Resource, updates, resp)
+	return resp, err
+}
+
+func (c *SalvagePreviewClient) List(opts *ListOpts) (*SalvagePreviewCollection, error) {
+	resp := &SalvagePreviewCollection{}
+	err := c.rancherClient.doList(SALVAGE_PREVIEW_TYPE, opts, resp)
+	resp.client = c
+	return resp, err
+}
+
+func (cc *SalvagePreviewCollection) Next() (*SalvagePreviewCollection, error) {
+	if cc != nil && cc.Pagination != nil && cc.Pagination.Next != "" {
+		resp := &SalvagePreviewCollection{}
+		err := cc.client.rancherClient.doNext(cc.Pagination.Next, resp)
+		resp.client = cc.client
+		return resp, err
+	}
+	return nil, nil
+}
+
+func (c *SalvagePreviewClient) ById(id string) (*SalvagePreview, error) {
+	resp := &SalvagePreview{}
+	err := c.rancherClient.doById(SALVAGE_PREVIEW_TYPE, id, resp)
+	if apiError, ok := err.(*ApiError); ok {
+		if apiError.StatusCode == 404 {
+			return nil, nil
+		}
+	}
+	return resp, err
+}
+
+func (c *SalvagePreviewClient) Delete(container *SalvagePreview) error {
+	return c.rancherClient.doResourceDelete(SALVAGE_PREVIEW_TYPE, &container.Resource)
+}
@@ -0,0 +1,85 @@
+package client
+
+const (
+	SALVAGE_REPORT_TYPE = "salvageReport"
+)
+
+type SalvageReport struct {
+	Resource `yaml:"-"`
+
+	Candidates []SalvageCandidate `json:"candidates,omitempty" yaml:"candidates,omitempty"`
+
+	Recommended []string `json:"recommended,omitempty" yaml:"recommended,omitempty"`
+
+	RevisionCounterDisabled bool `json:"revisionCounterDisabled,omitempty" yaml:"revision_counter_disabled,omitempty"`
+
+	Volume string `json:"volume,omitempty" yaml:"volume,omitempty"`
+}
+
+type SalvageReportCollection struct {
+	Collection
+	Data   []SalvageReport `json:"data,omitempty"`
+	client *SalvageReportClient
+}
+
+type SalvageReportClient struct {
+	rancherClient *RancherClient
+}
+
+type SalvageReportOperations interface {
+	List(opts *ListOpts) (*SalvageReportCollection, error)
+	Create(opts *SalvageReport) (*SalvageReport, error)
+	Update(existing *SalvageReport, updates interface{}) (*SalvageReport, error)
+	ById(id string) (*SalvageReport, error)
+	Delete(container *SalvageReport) error
+}
+
+func newSalvageReportClient(rancherClient *RancherClient) *SalvageReportClient {
+	return &SalvageReportClient{
+		rancherClient: rancherClient,
+	}
+}
+
+func (c *SalvageReportClient) Create(container *SalvageReport) (*SalvageReport, error) {
+	resp := &SalvageReport{}
+	err := c.rancherClient.doCreate(SALVAGE_REPORT_TYPE, container, resp)
+	return resp, err
+}
+
+func (c *SalvageReportClient) Update(existing *SalvageReport, updates interface{}) (*SalvageReport, error) {
+	resp := &SalvageReport{}
+	err := c.rancherClient.doUpdate(SALVAGE_REPORT_TYPE, &existing.Resource, updates, resp)
+	return resp, err
+}
+
+func (c *SalvageReportClient) List(opts *ListOpts) (*SalvageReportCollection, error) {
+	resp := &SalvageReportCollection{}
+	err := c.rancherClient.doList(SALVAGE_REPORT_TYPE, opts, resp)
+	resp.client = c
+	return resp, err
+}
+
+func (cc *SalvageReportCollection) Next() (*SalvageReportCollection, error) {
+	if cc != nil && cc.Pagination != nil && cc.Pagination.Next != "" {
+		resp := &SalvageReportCollection{}
+		err := cc.client.rancherClient.doNext(cc.Pagination.Next, resp)
+		resp.client = cc.client
+		return resp, err
+	}
+	return nil, nil
+}
+
+func (c *SalvageReportClient) ById(id string) (*SalvageReport, error) {
+	resp := &SalvageReport{}
+	err := c.rancherClient.doById(SALVAGE_REPORT_TYPE, id, resp)
+	if apiError, ok := err.(*ApiError); ok {
+		if apiError.StatusCode == 404 {
+			return nil, nil
+		}
+	}
+	return resp, err
+}
+
+func (c *SalvageReportClient) Delete(container *SalvageReport) error {
+	return c.rancherClient.doResourceDelete(SALVAGE_REPORT_TYPE, &container.Resource)
+}
@@ -0,0 +1,81 @@
+package client
+
+const (
+	SNAPSHOT_EXPORT_INPUT_TYPE = "snapshotExportInput"
+)
+
+type SnapshotExportInput struct {
+	Resource `yaml:"-"`
+
+	Format string `json:"format,omitempty" yaml:"format,omitempty"`
+
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+}
+
+type SnapshotExportInputCollection struct {
+	Collection
+	Data   []SnapshotExportInput `json:"data,omitempty"`
+	client *SnapshotExportInputClient
+}
+
+type SnapshotExportInputClient struct {
+	rancherClient *RancherClient
+}
+
+type SnapshotExportInputOperations interface {
+	List(opts *ListOpts) (*SnapshotExportInputCollection, error)
+	Create(opts *SnapshotExportInput) (*SnapshotExportInput, error)
+	Update(existing *SnapshotExportInput, updates interface{}) (*SnapshotExportInput, error)
+	ById(id string) (*SnapshotExportInput, error)
+	Delete(container *SnapshotExportInput) error
+}
+
+func newSnapshotExportInputClient(rancherClient *RancherClient) *SnapshotExportInputClient {
+	return &SnapshotExportInputClient{
+		rancherClient: rancherClient,
+	}
+}
+
+func (c *SnapshotExportInputClient) Create(container *SnapshotExportInput) (*SnapshotExportInput, error) {
+	resp := &SnapshotExportInput{}
+	err := c.rancherClient.doCreate(SNAPSHOT_EXPORT_INPUT_TYPE, container, resp)
+	return resp, err
+}
+
+func (c *SnapshotExportInputClient) Update(existing *SnapshotExportInput, updates interface{}) (*SnapshotExportInput, error) {
+	resp := &SnapshotExportInput{}
+	err := c.rancherClient.doUpdate(SNAPSHOT_EXPORT_INPUT_TYPE, &existing.Resource, updates, resp)
+	return resp, err
+}
+
+func (c *SnapshotExportInputClient) List(opts *ListOpts) (*SnapshotExportInputCollection, error) {
+	resp := &SnapshotExportInputCollection{}
+	err := c.rancherClient.doList(SNAPSHOT_EXPORT_INPUT_TYPE, opts, resp)
+	resp.client = c
+	return resp, err
+}
+
+func (cc *SnapshotExportInputCollection) Next() (*SnapshotExportInputCollection, error) {
+	if cc != nil && cc.Pagination != nil && cc.Pagination.Next != "" {
+		resp := &SnapshotExportInputCollection{}
+		err := cc.client.rancherClient.doNext(cc.Pagination.Next, resp)
+		resp.client = cc.client
+		return resp, err
+	}
+	return nil, nil
+}
+
+func (c *SnapshotExportInputClient) ById(id string) (*SnapshotExportInput, error) {
+	resp := &SnapshotExportInput{}
+	err := c.rancherClient.doById(SNAPSHOT_EXPORT_INPUT_TYPE, id, resp)
+	if apiError, ok := err.(*ApiError); ok {
+		if apiError.StatusCode == 404 {
+			return nil, nil
+		}
+	}
+	return resp, err
+}
+
+func (c *SnapshotExportInputClient) Delete(container *SnapshotExportInput) error {
+	return c.rancherClient.doResourceDelete(SNAPSHOT_EXPORT_INPUT_TYPE, &container.Resource)
+}
@@ -0,0 +1,85 @@
+package client
+
+const (
+	TIERING_POLICY_TYPE = "tieringPolicy"
+)
+
+type TieringPolicy struct {
+	Resource `yaml:"-"`
+
+	ColdAfterDetachedDays int64 `json:"coldAfterDetachedDays,omitempty" yaml:"cold_after_detached_days,omitempty"`
+
+	ColdBelowIOPS int64 `json:"coldBelowIOPS,omitempty" yaml:"cold_below_iops,omitempty"`
+
+	ColdDiskSelector []string `json:"coldDiskSelector,omitempty" yaml:"cold_disk_selector,omitempty"`
+
+	HotDiskSelector []string `json:"hotDiskSelector,omitempty" yaml:"hot_disk_selector,omitempty"`
+}
+
+type TieringPolicyCollection struct {
+	Collection
+	Data   []TieringPolicy `json:"data,omitempty"`
+	client *TieringPolicyClient
+}
+
+type TieringPolicyClient struct {
+	rancherClient *RancherClient
+}
+
+type TieringPolicyOperations interface {
+	List(opts *ListOpts) (*TieringPolicyCollection, error)
+	Create(opts *TieringPolicy) (*TieringPolicy, error)
+	Update(existing *TieringPolicy, updates interface{}) (*TieringPolicy, error)
+	ById(id string) (*TieringPolicy, error)
+	Delete(container *TieringPolicy) error
+}
+
+func newTieringPolicyClient(rancherClient *RancherClient) *TieringPolicyClient {
+	return &TieringPolicyClient{
+		rancherClient: rancherClient,
+	}
+}
+
+func (c *TieringPolicyClient) Create(container *TieringPolicy) (*TieringPolicy, error) {
+	resp := &TieringPolicy{}
+	err := c.rancherClient.doCreate(TIERING_POLICY_TYPE, container, resp)
+	return resp, err
+}
+
+func (c *TieringPolicyClient) Update(existing *TieringPolicy, updates interface{}) (*TieringPolicy, error) {
+	resp := &TieringPolicy{}
+	err := c.rancherClient.doUpdate(TIERING_POLICY_TYPE, &existing.Resource, updates, resp)
+	return resp, err
+}
+
+func (c *TieringPolicyClient) List(opts *ListOpts) (*TieringPolicyCollection, error) {
+	resp := &TieringPolicyCollection{}
+	err := c.rancherClient.doList(TIERING_POLICY_TYPE, opts, resp)
+	resp.client = c
+	return resp, err
+}
+
+func (cc *TieringPolicyCollection) Next() (*TieringPolicyCollection, error) {
+	if cc != nil && cc.Pagination != nil && cc.Pagination.Next != "" {
+		resp := &TieringPolicyCollection{}
+		err := cc.client.rancherClient.doNext(cc.Pagination.Next, resp)
+		resp.client = cc.client
+		return resp, err
+	}
+	return nil, nil
+}
+
+func (c *TieringPolicyClient) ById(id string) (*TieringPolicy, error) {
+	resp := &TieringPolicy{}
+	err := c.rancherClient.doById(TIERING_POLICY_TYPE, id, resp)
+	if apiError, ok := err.(*ApiError); ok {
+		if apiError.StatusCode == 404 {
+			return nil, nil
+		}
+	}
+	return resp, err
+}
+
+func (c *TieringPolicyClient) Delete(container *TieringPolicy) error {
+	return c.rancherClient.doResourceDelete(TIERING_POLICY_TYPE, &container.Resource)
+}
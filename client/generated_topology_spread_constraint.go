@@ -0,0 +1,83 @@
+package client
+
+const (
+	TOPOLOGY_SPREAD_CONSTRAINT_TYPE = "topologySpreadConstraint"
+)
+
+type TopologySpreadConstraint struct {
+	Resource `yaml:"-"`
+
+	MaxReplicasPerDomain int64 `json:"maxReplicasPerDomain,omitempty" yaml:"max_replicas_per_domain,omitempty"`
+
+	MinDomains int64 `json:"minDomains,omitempty" yaml:"min_domains,omitempty"`
+
+	TopologyLevel string `json:"topologyLevel,omitempty" yaml:"topology_level,omitempty"`
+}
+
+type TopologySpreadConstraintCollection struct {
+	Collection
+	Data   []TopologySpreadConstraint `json:"data,omitempty"`
+	client *TopologySpreadConstraintClient
+}
+
+type TopologySpreadConstraintClient struct {
+	rancherClient *RancherClient
+}
+
+type TopologySpreadConstraintOperations interface {
+	List(opts *ListOpts) (*TopologySpreadConstraintCollection, error)
+	Create(opts *TopologySpreadConstraint) (*TopologySpreadConstraint, error)
+	Update(existing *TopologySpreadConstraint, updates interface{}) (*TopologySpreadConstraint, error)
+	ById(id string) (*TopologySpreadConstraint, error)
+	Delete(container *TopologySpreadConstraint) error
+}
+
+func newTopologySpreadConstraintClient(rancherClient *RancherClient) *TopologySpreadConstraintClient {
+	return &TopologySpreadConstraintClient{
+		rancherClient: rancherClient,
+	}
+}
+
+func (c *TopologySpreadConstraintClient) Create(container *TopologySpreadConstraint) (*TopologySpreadConstraint, error) {
+	resp := &TopologySpreadConstraint{}
+	err := c.rancherClient.doCreate(TOPOLOGY_SPREAD_CONSTRAINT_TYPE, container, resp)
+	return resp, err
+}
+
+func (c *TopologySpreadConstraintClient) Update(existing *TopologySpreadConstraint, updates interface{}) (*TopologySpreadConstraint, error) {
+	resp := &TopologySpreadConstraint{}
+	err := c.rancherClient.doUpdate(TOPOLOGY_SPREAD_CONSTRAINT_TYPE, &existing.Resource, updates, resp)
+	return resp, err
+}
+
+func (c *TopologySpreadConstraintClient) List(opts *ListOpts) (*TopologySpreadConstraintCollection, error) {
+	resp := &TopologySpreadConstraintCollection{}
+	err := c.rancherClient.doList(TOPOLOGY_SPREAD_CONSTRAINT_TYPE, opts, resp)
+	resp.client = c
+	return resp, err
+}
+
+func (cc *TopologySpreadConstraintCollection) Next() (*TopologySpreadConstraintCollection, error) {
+	if cc != nil && cc.Pagination != nil && cc.Pagination.Next != "" {
+		resp := &TopologySpreadConstraintCollection{}
+		err := cc.client.rancherClient.doNext(cc.Pagination.Next, resp)
+		resp.client = cc.client
+		return resp, err
+	}
+	return nil, nil
+}
+
+func (c *TopologySpreadConstraintClient) ById(id string) (*TopologySpreadConstraint, error) {
+	resp := &TopologySpreadConstraint{}
+	err := c.rancherClient.doById(TOPOLOGY_SPREAD_CONSTRAINT_TYPE, id, resp)
+	if apiError, ok := err.(*ApiError); ok {
+		if apiError.StatusCode == 404 {
+			return nil, nil
+		}
+	}
+	return resp, err
+}
+
+func (c *TopologySpreadConstraintClient) Delete(container *TopologySpreadConstraint) error {
+	return c.rancherClient.doResourceDelete(TOPOLOGY_SPREAD_CONSTRAINT_TYPE, &container.Resource)
+}
@@ -1,13 +1,13 @@
 package client
 
 const (
-	UPDATE_FREEZE_FSFOR_SNAPSHOT_INPUT_TYPE = "UpdateFreezeFSForSnapshotInput"
+	UPDATE_FREEZE_FSFOR_SNAPSHOT_INPUT_TYPE = "UpdateFreezeFilesystemForSnapshotInput"
 )
 
 type UpdateFreezeFSForSnapshotInput struct {
 	Resource `yaml:"-"`
 
-	FreezeFSForSnapshot string `json:"freezeFSForSnapshot,omitempty" yaml:"freeze_fsfor_snapshot,omitempty"`
+	FreezeFSForSnapshot string `json:"freezeFilesystemForSnapshot,omitempty" yaml:"freeze_filesystem_for_snapshot,omitempty"`
 }
 
 type UpdateFreezeFSForSnapshotInputCollection struct {
@@ -0,0 +1,85 @@
+package client
+
+const (
+	UPDATE_TIERING_POLICY_INPUT_TYPE = "UpdateTieringPolicyInput"
+)
+
+type UpdateTieringPolicyInput struct {
+	Resource `yaml:"-"`
+
+	ColdAfterDetachedDays int64 `json:"coldAfterDetachedDays,omitempty" yaml:"cold_after_detached_days,omitempty"`
+
+	ColdBelowIOPS int64 `json:"coldBelowIOPS,omitempty" yaml:"cold_below_iops,omitempty"`
+
+	ColdDiskSelector []string `json:"coldDiskSelector,omitempty" yaml:"cold_disk_selector,omitempty"`
+
+	HotDiskSelector []string `json:"hotDiskSelector,omitempty" yaml:"hot_disk_selector,omitempty"`
+}
+
+type UpdateTieringPolicyInputCollection struct {
+	Collection
+	Data   []UpdateTieringPolicyInput `json:"data,omitempty"`
+	client *UpdateTieringPolicyInputClient
+}
+
+type UpdateTieringPolicyInputClient struct {
+	rancherClient *RancherClient
+}
+
+type UpdateTieringPolicyInputOperations interface {
+	List(opts *ListOpts) (*UpdateTieringPolicyInputCollection, error)
+	Create(opts *UpdateTieringPolicyInput) (*UpdateTieringPolicyInput, error)
+	Update(existing *UpdateTieringPolicyInput, updates interface{}) (*UpdateTieringPolicyInput, error)
+	ById(id string) (*UpdateTieringPolicyInput, error)
+	Delete(container *UpdateTieringPolicyInput) error
+}
+
+func newUpdateTieringPolicyInputClient(rancherClient *RancherClient) *UpdateTieringPolicyInputClient {
+	return &UpdateTieringPolicyInputClient{
+		rancherClient: rancherClient,
+	}
+}
+
+func (c *UpdateTieringPolicyInputClient) Create(container *UpdateTieringPolicyInput) (*UpdateTieringPolicyInput, error) {
+	resp := &UpdateTieringPolicyInput{}
+	err := c.rancherClient.doCreate(UPDATE_TIERING_POLICY_INPUT_TYPE, container, resp)
+	return resp, err
+}
+
+func (c *UpdateTieringPolicyInputClient) Update(existing *UpdateTieringPolicyInput, updates interface{}) (*UpdateTieringPolicyInput, error) {
+	resp := &UpdateTieringPolicyInput{}
+	err := c.rancherClient.doUpdate(UPDATE_TIERING_POLICY_INPUT_TYPE, &existing.Resource, updates, resp)
+	return resp, err
+}
+
+func (c *UpdateTieringPolicyInputClient) List(opts *ListOpts) (*UpdateTieringPolicyInputCollection, error) {
+	resp := &UpdateTieringPolicyInputCollection{}
+	err := c.rancherClient.doList(UPDATE_TIERING_POLICY_INPUT_TYPE, opts, resp)
+	resp.client = c
+	return resp, err
+}
+
+func (cc *UpdateTieringPolicyInputCollection) Next() (*UpdateTieringPolicyInputCollection, error) {
+	if cc != nil && cc.Pagination != nil && cc.Pagination.Next != "" {
+		resp := &UpdateTieringPolicyInputCollection{}
+		err := cc.client.rancherClient.doNext(cc.Pagination.Next, resp)
+		resp.client = cc.client
+		return resp, err
+	}
+	return nil, nil
+}
+
+func (c *UpdateTieringPolicyInputClient) ById(id string) (*UpdateTieringPolicyInput, error) {
+	resp := &UpdateTieringPolicyInput{}
+	err := c.rancherClient.doById(UPDATE_TIERING_POLICY_INPUT_TYPE, id, resp)
+	if apiError, ok := err.(*ApiError); ok {
+		if apiError.StatusCode == 404 {
+			return nil, nil
+		}
+	}
+	return resp, err
+}
+
+func (c *UpdateTieringPolicyInputClient) Delete(container *UpdateTieringPolicyInput) error {
+	return c.rancherClient.doResourceDelete(UPDATE_TIERING_POLICY_INPUT_TYPE, &container.Resource)
+}
@@ -0,0 +1,91 @@
+package client
+
+const (
+	USAGE_TYPE = "usage"
+)
+
+type Usage struct {
+	Resource `yaml:"-"`
+
+	ActualSize int64 `json:"actualSize,omitempty" yaml:"actual_size,omitempty"`
+
+	BackupSize map[string]interface{} `json:"backupSize,omitempty" yaml:"backup_size,omitempty"`
+
+	Date string `json:"date,omitempty" yaml:"date,omitempty"`
+
+	Group map[string]string `json:"group,omitempty" yaml:"group,omitempty"`
+
+	Size int64 `json:"size,omitempty" yaml:"size,omitempty"`
+
+	SnapshotSize int64 `json:"snapshotSize,omitempty" yaml:"snapshot_size,omitempty"`
+
+	VolumeCount int64 `json:"volumeCount,omitempty" yaml:"volume_count,omitempty"`
+}
+
+type UsageCollection struct {
+	Collection
+	Data   []Usage `json:"data,omitempty"`
+	client *UsageClient
+}
+
+type UsageClient struct {
+	rancherClient *RancherClient
+}
+
+type UsageOperations interface {
+	List(opts *ListOpts) (*UsageCollection, error)
+	Create(opts *Usage) (*Usage, error)
+	Update(existing *Usage, updates interface{}) (*Usage, error)
+	ById(id string) (*Usage, error)
+	Delete(container *Usage) error
+}
+
+func newUsageClient(rancherClient *RancherClient) *UsageClient {
+	return &UsageClient{
+		rancherClient: rancherClient,
+	}
+}
+
+func (c *UsageClient) Create(container *Usage) (*Usage, error) {
+	resp := &Usage{}
+	err := c.rancherClient.doCreate(USAGE_TYPE, container, resp)
+	return resp, err
+}
+
+func (c *UsageClient) Update(existing *Usage, updates interface{}) (*Usage, error) {
+	resp := &Usage{}
+	err := c.rancherClient.doUpdate(USAGE_TYPE, &existing.Resource, updates, resp)
+	return resp, err
+}
+
+func (c *UsageClient) List(opts *ListOpts) (*UsageCollection, error) {
+	resp := &UsageCollection{}
+	err := c.rancherClient.doList(USAGE_TYPE, opts, resp)
+	resp.client = c
+	return resp, err
+}
+
+func (cc *UsageCollection) Next() (*UsageCollection, error) {
+	if cc != nil && cc.Pagination != nil && cc.Pagination.Next != "" {
+		resp := &UsageCollection{}
+		err := cc.client.rancherClient.doNext(cc.Pagination.Next, resp)
+		resp.client = cc.client
+		return resp, err
+	}
+	return nil, nil
+}
+
+func (c *UsageClient) ById(id string) (*Usage, error) {
+	resp := &Usage{}
+	err := c.rancherClient.doById(USAGE_TYPE, id, resp)
+	if apiError, ok := err.(*ApiError); ok {
+		if apiError.StatusCode == 404 {
+			return nil, nil
+		}
+	}
+	return resp, err
+}
+
+func (c *UsageClient) Delete(container *Usage) error {
+	return c.rancherClient.doResourceDelete(USAGE_TYPE, &container.Resource)
+}
@@ -43,7 +43,7 @@ type Volume struct {
 
 	Encrypted bool `json:"encrypted,omitempty" yaml:"encrypted,omitempty"`
 
-	FreezeFilesystemForSnapshot string `json:"freezeFSForSnapshot,omitempty" yaml:"freeze_fsfor_snapshot,omitempty"`
+	FreezeFilesystemForSnapshot string `json:"freezeFilesystemForSnapshot,omitempty" yaml:"freeze_filesystem_for_snapshot,omitempty"`
 
 	FromBackup string `json:"fromBackup,omitempty" yaml:"from_backup,omitempty"`
 
@@ -63,6 +63,8 @@ type Volume struct {
 
 	Name string `json:"name,omitempty" yaml:"name,omitempty"`
 
+	NfsExportOptions NfsExportOptions `json:"nfsExportOptions,omitempty" yaml:"nfs_export_options,omitempty"`
+
 	NodeSelector []string `json:"nodeSelector,omitempty" yaml:"node_selector,omitempty"`
 
 	NumberOfReplicas int64 `json:"numberOfReplicas,omitempty" yaml:"number_of_replicas,omitempty"`
@@ -71,6 +73,8 @@ type Volume struct {
 
 	PurgeStatus []PurgeStatus `json:"purgeStatus,omitempty" yaml:"purge_status,omitempty"`
 
+	PVCNamespace string `json:"pvcNamespace,omitempty" yaml:"pvc_namespace,omitempty"`
+
 	Ready bool `json:"ready,omitempty" yaml:"ready,omitempty"`
 
 	RebuildStatus []RebuildStatus `json:"rebuildStatus,omitempty" yaml:"rebuild_status,omitempty"`
@@ -81,6 +85,8 @@ type Volume struct {
 
 	ReplicaDiskSoftAntiAffinity string `json:"replicaDiskSoftAntiAffinity,omitempty" yaml:"replica_disk_soft_anti_affinity,omitempty"`
 
+	ReplicaRebuildingBandwidthLimit int64 `json:"replicaRebuildingBandwidthLimit,omitempty" yaml:"replica_rebuilding_bandwidth_limit,omitempty"`
+
 	ReplicaSoftAntiAffinity string `json:"replicaSoftAntiAffinity,omitempty" yaml:"replica_soft_anti_affinity,omitempty"`
 
 	ReplicaZoneSoftAntiAffinity string `json:"replicaZoneSoftAntiAffinity,omitempty" yaml:"replica_zone_soft_anti_affinity,omitempty"`
@@ -117,6 +123,12 @@ type Volume struct {
 
 	State string `json:"state,omitempty" yaml:"state,omitempty"`
 
+	StorageTier string `json:"storageTier,omitempty" yaml:"storage_tier,omitempty"`
+
+	TieringPolicy TieringPolicy `json:"tieringPolicy,omitempty" yaml:"tiering_policy,omitempty"`
+
+	TopologySpreadConstraints []TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty" yaml:"topology_spread_constraints,omitempty"`
+
 	UnmapMarkSnapChainRemoved string `json:"unmapMarkSnapChainRemoved,omitempty" yaml:"unmap_mark_snap_chain_removed,omitempty"`
 
 	VolumeAttachment VolumeAttachment `json:"volumeAttachment,omitempty" yaml:"volume_attachment,omitempty"`
@@ -147,10 +159,18 @@ type VolumeOperations interface {
 
 	ActionDetach(*Volume, *DetachInput) (*Volume, error)
 
+	ActionEngineUpgrade(*Volume, *EngineUpgradeInput) (*Volume, error)
+
 	ActionExpand(*Volume, *ExpandInput) (*Volume, error)
 
 	ActionOfflineReplicaRebuilding(*Volume, *UpdateOfflineRebuildingInput) (*Volume, error)
 
+	ActionPreviewRevert(*Volume, *PreviewRevertInput) (*Volume, error)
+
+	ActionPreviewRevertDelete(*Volume, *RevertPreviewInput) (*Volume, error)
+
+	ActionPreviewRevertPromote(*Volume, *RevertPreviewInput) (*Volume, error)
+
 	ActionPvCreate(*Volume, *PVCreateInput) (*Volume, error)
 
 	ActionPvcCreate(*Volume, *PVCCreateInput) (*Volume, error)
@@ -165,6 +185,10 @@ type VolumeOperations interface {
 
 	ActionSalvage(*Volume, *SalvageInput) (*Volume, error)
 
+	ActionSalvagePreview(*Volume, *SalvageInput) (*SalvagePreview, error)
+
+	ActionSalvageReport(*Volume) (*SalvageReport, error)
+
 	ActionSnapshotBackup(*Volume, *SnapshotInput) (*Volume, error)
 
 	ActionSnapshotCRCreate(*Volume, *SnapshotCRInput) (*SnapshotCR, error)
@@ -179,6 +203,8 @@ type VolumeOperations interface {
 
 	ActionSnapshotDelete(*Volume, *SnapshotInput) (*Volume, error)
 
+	ActionSnapshotExport(*Volume, *SnapshotExportInput) (*BackingImage, error)
+
 	ActionSnapshotGet(*Volume, *SnapshotInput) (*Snapshot, error)
 
 	ActionSnapshotList(*Volume) (*SnapshotListOutput, error)
@@ -190,6 +216,36 @@ type VolumeOperations interface {
 	ActionTrimFilesystem(*Volume) (*Volume, error)
 
 	ActionUpdateAccessMode(*Volume, *UpdateAccessModeInput) (*Volume, error)
+
+	ActionUpdateBackupCompressionMethod(*Volume, *UpdateBackupCompressionInput) (*Volume, error)
+
+	ActionUpdateBackupTargetName(*Volume, *UpdateBackupTargetInput) (*Volume, error)
+
+	ActionUpdateDataLocality(*Volume, *UpdateDataLocalityInput) (*Volume, error)
+
+	ActionUpdateFreezeFilesystemForSnapshot(*Volume, *UpdateFreezeFSForSnapshotInput) (*Volume, error)
+
+	ActionUpdateReplicaAutoBalance(*Volume, *UpdateReplicaAutoBalanceInput) (*Volume, error)
+
+	ActionUpdateReplicaCount(*Volume, *UpdateReplicaCountInput) (*Volume, error)
+
+	ActionUpdateReplicaDiskSoftAntiAffinity(*Volume, *UpdateReplicaDiskSoftAntiAffinityInput) (*Volume, error)
+
+	ActionUpdateReplicaRebuildingBandwidthLimit(*Volume, *UpdateReplicaRebuildingBandwidthLimitInput) (*Volume, error)
+
+	ActionUpdateReplicaSoftAntiAffinity(*Volume, *UpdateReplicaSoftAntiAffinityInput) (*Volume, error)
+
+	ActionUpdateReplicaZoneSoftAntiAffinity(*Volume, *UpdateReplicaZoneSoftAntiAffinityInput) (*Volume, error)
+
+	ActionUpdateSnapshotDataIntegrity(*Volume, *UpdateSnapshotDataIntegrityInput) (*Volume, error)
+
+	ActionUpdateSnapshotMaxCount(*Volume, *UpdateSnapshotMaxCountInput) (*Volume, error)
+
+	ActionUpdateSnapshotMaxSize(*Volume, *UpdateSnapshotMaxSizeInput) (*Volume, error)
+
+	ActionUpdateTieringPolicy(*Volume, *UpdateTieringPolicyInput) (*Volume, error)
+
+	ActionUpdateUnmapMarkSnapChainRemoved(*Volume, *UpdateUnmapMarkSnapChainRemovedInput) (*Volume, error)
 }
 
 func newVolumeClient(rancherClient *RancherClient) *VolumeClient {
@@ -278,6 +334,15 @@ func (c *VolumeClient) ActionDetach(resource *Volume, input *DetachInput) (*Volu
 	return resp, err
 }
 
+func (c *VolumeClient) ActionEngineUpgrade(resource *Volume, input *EngineUpgradeInput) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "engineUpgrade", &resource.Resource, input, resp)
+
+	return resp, err
+}
+
 func (c *VolumeClient) ActionExpand(resource *Volume, input *ExpandInput) (*Volume, error) {
 
 	resp := &Volume{}
@@ -296,6 +361,33 @@ func (c *VolumeClient) ActionOfflineReplicaRebuilding(resource *Volume, input *U
 	return resp, err
 }
 
+func (c *VolumeClient) ActionPreviewRevert(resource *Volume, input *PreviewRevertInput) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "previewRevert", &resource.Resource, input, resp)
+
+	return resp, err
+}
+
+func (c *VolumeClient) ActionPreviewRevertDelete(resource *Volume, input *RevertPreviewInput) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "previewRevertDelete", &resource.Resource, input, resp)
+
+	return resp, err
+}
+
+func (c *VolumeClient) ActionPreviewRevertPromote(resource *Volume, input *RevertPreviewInput) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "previewRevertPromote", &resource.Resource, input, resp)
+
+	return resp, err
+}
+
 func (c *VolumeClient) ActionPvCreate(resource *Volume, input *PVCreateInput) (*Volume, error) {
 
 	resp := &Volume{}
@@ -359,6 +451,24 @@ func (c *VolumeClient) ActionSalvage(resource *Volume, input *SalvageInput) (*Vo
 	return resp, err
 }
 
+func (c *VolumeClient) ActionSalvagePreview(resource *Volume, input *SalvageInput) (*SalvagePreview, error) {
+
+	resp := &SalvagePreview{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "salvagePreview", &resource.Resource, input, resp)
+
+	return resp, err
+}
+
+func (c *VolumeClient) ActionSalvageReport(resource *Volume) (*SalvageReport, error) {
+
+	resp := &SalvageReport{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "salvageReport", &resource.Resource, nil, resp)
+
+	return resp, err
+}
+
 func (c *VolumeClient) ActionSnapshotBackup(resource *Volume, input *SnapshotInput) (*Volume, error) {
 
 	resp := &Volume{}
@@ -422,6 +532,15 @@ func (c *VolumeClient) ActionSnapshotDelete(resource *Volume, input *SnapshotInp
 	return resp, err
 }
 
+func (c *VolumeClient) ActionSnapshotExport(resource *Volume, input *SnapshotExportInput) (*BackingImage, error) {
+
+	resp := &BackingImage{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "snapshotExport", &resource.Resource, input, resp)
+
+	return resp, err
+}
+
 func (c *VolumeClient) ActionSnapshotGet(resource *Volume, input *SnapshotInput) (*Snapshot, error) {
 
 	resp := &Snapshot{}
@@ -475,3 +594,138 @@ func (c *VolumeClient) ActionUpdateAccessMode(resource *Volume, input *UpdateAcc
 
 	return resp, err
 }
+
+func (c *VolumeClient) ActionUpdateBackupCompressionMethod(resource *Volume, input *UpdateBackupCompressionInput) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "updateBackupCompressionMethod", &resource.Resource, input, resp)
+
+	return resp, err
+}
+
+func (c *VolumeClient) ActionUpdateBackupTargetName(resource *Volume, input *UpdateBackupTargetInput) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "updateBackupTargetName", &resource.Resource, input, resp)
+
+	return resp, err
+}
+
+func (c *VolumeClient) ActionUpdateDataLocality(resource *Volume, input *UpdateDataLocalityInput) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "updateDataLocality", &resource.Resource, input, resp)
+
+	return resp, err
+}
+
+func (c *VolumeClient) ActionUpdateFreezeFilesystemForSnapshot(resource *Volume, input *UpdateFreezeFSForSnapshotInput) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "updateFreezeFilesystemForSnapshot", &resource.Resource, input, resp)
+
+	return resp, err
+}
+
+func (c *VolumeClient) ActionUpdateReplicaAutoBalance(resource *Volume, input *UpdateReplicaAutoBalanceInput) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "updateReplicaAutoBalance", &resource.Resource, input, resp)
+
+	return resp, err
+}
+
+func (c *VolumeClient) ActionUpdateReplicaCount(resource *Volume, input *UpdateReplicaCountInput) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "updateReplicaCount", &resource.Resource, input, resp)
+
+	return resp, err
+}
+
+func (c *VolumeClient) ActionUpdateReplicaDiskSoftAntiAffinity(resource *Volume, input *UpdateReplicaDiskSoftAntiAffinityInput) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "updateReplicaDiskSoftAntiAffinity", &resource.Resource, input, resp)
+
+	return resp, err
+}
+
+func (c *VolumeClient) ActionUpdateReplicaRebuildingBandwidthLimit(resource *Volume, input *UpdateReplicaRebuildingBandwidthLimitInput) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "updateReplicaRebuildingBandwidthLimit", &resource.Resource, input, resp)
+
+	return resp, err
+}
+
+func (c *VolumeClient) ActionUpdateReplicaSoftAntiAffinity(resource *Volume, input *UpdateReplicaSoftAntiAffinityInput) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "updateReplicaSoftAntiAffinity", &resource.Resource, input, resp)
+
+	return resp, err
+}
+
+func (c *VolumeClient) ActionUpdateReplicaZoneSoftAntiAffinity(resource *Volume, input *UpdateReplicaZoneSoftAntiAffinityInput) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "updateReplicaZoneSoftAntiAffinity", &resource.Resource, input, resp)
+
+	return resp, err
+}
+
+func (c *VolumeClient) ActionUpdateSnapshotDataIntegrity(resource *Volume, input *UpdateSnapshotDataIntegrityInput) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "updateSnapshotDataIntegrity", &resource.Resource, input, resp)
+
+	return resp, err
+}
+
+func (c *VolumeClient) ActionUpdateSnapshotMaxCount(resource *Volume, input *UpdateSnapshotMaxCountInput) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "updateSnapshotMaxCount", &resource.Resource, input, resp)
+
+	return resp, err
+}
+
+func (c *VolumeClient) ActionUpdateSnapshotMaxSize(resource *Volume, input *UpdateSnapshotMaxSizeInput) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "updateSnapshotMaxSize", &resource.Resource, input, resp)
+
+	return resp, err
+}
+
+func (c *VolumeClient) ActionUpdateTieringPolicy(resource *Volume, input *UpdateTieringPolicyInput) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "updateTieringPolicy", &resource.Resource, input, resp)
+
+	return resp, err
+}
+
+func (c *VolumeClient) ActionUpdateUnmapMarkSnapChainRemoved(resource *Volume, input *UpdateUnmapMarkSnapChainRemovedInput) (*Volume, error) {
+
+	resp := &Volume{}
+
+	err := c.rancherClient.doAction(VOLUME_TYPE, "updateUnmapMarkSnapChainRemoved", &resource.Resource, input, resp)
+
+	return resp, err
+}
@@ -0,0 +1,98 @@
+// The command generates the Longhorn API client in the current directory from the OpenAPI document of the API.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/longhorn/longhorn-manager/api"
+	"github.com/longhorn/longhorn-manager/client/generator"
+)
+
+func main() {
+	spec := flag.String("spec", "", "the file or the URL of the OpenAPI document, e.g. http://longhorn-backend:9500/v1/openapi.json. The document of the API schemas of this tree by default")
+	output := flag.String("output", ".", "the directory of the generated client")
+	flag.Parse()
+
+	if err := generate(*spec, *output); err != nil {
+		logrus.WithError(err).Fatal("Failed to generate the client")
+	}
+}
+
+func generate(spec, output string) error {
+	doc, err := loadOpenAPIDocument(spec)
+	if err != nil {
+		return err
+	}
+
+	files, err := generator.Generate(doc)
+	if err != nil {
+		return err
+	}
+
+	generated := map[string]bool{}
+	for _, file := range files {
+		if err := os.WriteFile(filepath.Join(output, file.Name), file.Content, 0644); err != nil {
+			return err
+		}
+		generated[file.Name] = true
+	}
+
+	// Remove the stale files of the types that are no longer in the document
+	paths, err := filepath.Glob(filepath.Join(output, "generated_*.go"))
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		if generated[filepath.Base(path)] {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+		logrus.Infof("Removed the stale file %v", path)
+	}
+
+	logrus.Infof("Generated %v files of the client in %v", len(files), output)
+	return nil
+}
+
+func loadOpenAPIDocument(spec string) (*api.OpenAPIDocument, error) {
+	if spec == "" {
+		return api.NewOpenAPIDocument(api.NewSchema(), nil)
+	}
+
+	var content []byte
+	if strings.HasPrefix(spec, "http://") || strings.HasPrefix(spec, "https://") {
+		resp, err := http.Get(spec)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to get the OpenAPI document from %v: %v", spec, resp.Status)
+		}
+		if content, err = io.ReadAll(resp.Body); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		if content, err = os.ReadFile(spec); err != nil {
+			return nil, err
+		}
+	}
+
+	doc := &api.OpenAPIDocument{}
+	if err := json.Unmarshal(content, doc); err != nil {
+		return nil, fmt.Errorf("failed to parse the OpenAPI document %v: %w", spec, err)
+	}
+	return doc, nil
+}
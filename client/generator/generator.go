@@ -0,0 +1,272 @@
+// Package generator generates the types of the Longhorn API client from the OpenAPI document of the API. The
+// generated code follows the code generated by rancher/go-rancher, which this package replaces.
+package generator
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"go/format"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/longhorn/longhorn-manager/api"
+)
+
+const (
+	schemaRefPrefix = "#/components/schemas/"
+
+	clientFileName = "generated_client.go"
+)
+
+var (
+	//go:embed *.tmpl
+	templateFS embed.FS
+
+	templates = template.Must(template.ParseFS(templateFS, "*.tmpl"))
+
+	underscoreRegexp = regexp.MustCompile(`([a-z])([A-Z])`)
+)
+
+var (
+	// skippedSchemas are the schemas covered by the base client rather than the generated types.
+	skippedSchemas = map[string]bool{
+		"collection": true,
+		"resource":   true,
+		"schema":     true,
+	}
+
+	// resourceFields are the fields of the embedded Resource.
+	resourceFields = map[string]bool{
+		"id":      true,
+		"type":    true,
+		"links":   true,
+		"actions": true,
+	}
+
+	// typeNames are the Go names of the types that are not named after their schema, kept for the compatibility of
+	// the client.
+	typeNames = map[string]string{
+		"UpdateFreezeFilesystemForSnapshotInput": "UpdateFreezeFSForSnapshotInput",
+	}
+
+	// fieldNames are the Go names of the fields that are not named after their schema field, by the schema and the
+	// field, kept for the compatibility of the client.
+	fieldNames = map[string]string{
+		"UpdateFreezeFilesystemForSnapshotInput.freezeFilesystemForSnapshot": "FreezeFSForSnapshot",
+		"previewRevertInput.ttl": "TTL",
+		"volume.pvcNamespace":    "PVCNamespace",
+	}
+
+	// fieldTypes are the Go types of the fields that are not typed after their schema field, by the schema and the
+	// field, kept for the compatibility of the client.
+	fieldTypes = map[string]string{
+		"diskInfo.conditions":           "map[string]interface{}",
+		"engineImage.nodeDeploymentMap": "map[string]bool",
+		"node.conditions":               "map[string]interface{}",
+		"node.disks":                    "map[string]interface{}",
+		"settingDefinition.floatRange":  "map[string]interface{}",
+		"settingDefinition.range":       "map[string]interface{}",
+		"snapshot.children":             "map[string]interface{}",
+		"snapshotCR.children":           "map[string]interface{}",
+		"usage.backupSize":              "map[string]interface{}",
+		"volume.conditions":             "map[string]interface{}",
+	}
+)
+
+// File is a generated file.
+type File struct {
+	Name    string
+	Content []byte
+}
+
+type typeField struct {
+	Name     string
+	JSONName string
+	YAMLName string
+	Type     string
+}
+
+type typeAction struct {
+	Name   string
+	Method string
+	Input  string
+	Output string
+}
+
+type typeData struct {
+	SchemaID string
+	Name     string
+	Const    string
+	Fields   []typeField
+	Actions  []typeAction
+}
+
+// Generate returns the generated files of the client types of the component schemas of the document, and of the
+// client of all the types. The files are sorted by name.
+func Generate(doc *api.OpenAPIDocument) ([]File, error) {
+	schemaIDs := []string{}
+	for id := range doc.Components.Schemas {
+		if !skippedSchemas[id] {
+			schemaIDs = append(schemaIDs, id)
+		}
+	}
+	sort.Strings(schemaIDs)
+
+	files := []File{}
+	names := []string{}
+	for _, id := range schemaIDs {
+		data := getTypeData(doc, id)
+		content, err := execute("type.go.tmpl", data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate the type of schema %v: %w", id, err)
+		}
+		files = append(files, File{
+			Name:    "generated_" + addUnderscore(data.Name) + ".go",
+			Content: content,
+		})
+		names = append(names, data.Name)
+	}
+
+	content, err := execute("client.go.tmpl", names)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate the client: %w", err)
+	}
+	files = append(files, File{
+		Name:    clientFileName,
+		Content: content,
+	})
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Name < files[j].Name
+	})
+	return files, nil
+}
+
+func execute(name string, data interface{}) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := templates.ExecuteTemplate(buf, name, data); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+func getTypeData(doc *api.OpenAPIDocument, id string) *typeData {
+	schema := doc.Components.Schemas[id]
+	name := getTypeName(id)
+	data := &typeData{
+		SchemaID: id,
+		Name:     name,
+		Const:    strings.ToUpper(addUnderscore(name)) + "_TYPE",
+	}
+
+	for fieldName, field := range schema.Properties {
+		if resourceFields[fieldName] {
+			continue
+		}
+		key := id + "." + fieldName
+		goName, ok := fieldNames[key]
+		if !ok {
+			goName = capitalize(fieldName)
+		}
+		goType, ok := fieldTypes[key]
+		if !ok {
+			goType = getGoType(field)
+		}
+		data.Fields = append(data.Fields, typeField{
+			Name:     goName,
+			JSONName: fieldName,
+			YAMLName: addUnderscore(fieldName),
+			Type:     goType,
+		})
+	}
+	sort.Slice(data.Fields, func(i, j int) bool {
+		return data.Fields[i].JSONName < data.Fields[j].JSONName
+	})
+
+	for actionName, action := range schema.Actions {
+		// Like the go-rancher generator, an action is generated only if its input and output are client types
+		output, ok := getActionType(doc, action.Output)
+		if !ok || output == "" {
+			continue
+		}
+		input, ok := getActionType(doc, action.Input)
+		if !ok {
+			continue
+		}
+		data.Actions = append(data.Actions, typeAction{
+			Name:   actionName,
+			Method: "Action" + capitalize(actionName),
+			Input:  input,
+			Output: output,
+		})
+	}
+	sort.Slice(data.Actions, func(i, j int) bool {
+		return data.Actions[i].Method < data.Actions[j].Method
+	})
+	return data
+}
+
+// getActionType returns the client type of the input or the output of an action, or an empty string if there is no
+// input or output. It returns false if the input or the output isn't a client type.
+func getActionType(doc *api.OpenAPIDocument, schema *api.OpenAPISchema) (string, bool) {
+	if schema == nil {
+		return "", true
+	}
+	id, ok := strings.CutPrefix(schema.Ref, schemaRefPrefix)
+	if !ok || skippedSchemas[id] {
+		return "", false
+	}
+	if _, ok := doc.Components.Schemas[id]; !ok {
+		return "", false
+	}
+	return getTypeName(id), true
+}
+
+// getGoType returns the Go type of a field schema.
+func getGoType(schema *api.OpenAPISchema) string {
+	if id, ok := strings.CutPrefix(schema.Ref, schemaRefPrefix); ok {
+		return getTypeName(id)
+	}
+	switch schema.Type {
+	case "string":
+		return "string"
+	case "integer":
+		return "int64"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		if schema.Items == nil {
+			return "[]interface{}"
+		}
+		return "[]" + getGoType(schema.Items)
+	case "object":
+		if schema.AdditionalProperties == nil {
+			return "interface{}"
+		}
+		return "map[string]" + getGoType(schema.AdditionalProperties)
+	}
+	return "interface{}"
+}
+
+func getTypeName(id string) string {
+	if name, ok := typeNames[id]; ok {
+		return name
+	}
+	return capitalize(id)
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func addUnderscore(s string) string {
+	return strings.ToLower(underscoreRegexp.ReplaceAllString(s, `${1}_${2}`))
+}
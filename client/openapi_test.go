@@ -0,0 +1,45 @@
+package client_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/longhorn/longhorn-manager/api"
+	"github.com/longhorn/longhorn-manager/client/generator"
+)
+
+// TestClientMatchesOpenAPI checks that the generated code in this package is the code generated from the OpenAPI
+// document of the API, so that a change of the API schemas can't be missed by the client.
+func TestClientMatchesOpenAPI(t *testing.T) {
+	assert := require.New(t)
+
+	doc, err := api.NewOpenAPIDocument(api.NewSchema(), nil)
+	assert.NoError(err)
+	files, err := generator.Generate(doc)
+	assert.NoError(err)
+
+	generated := map[string]bool{}
+	for _, file := range files {
+		generated[file.Name] = true
+		content, err := os.ReadFile(file.Name)
+		if os.IsNotExist(err) {
+			t.Errorf("missing the generated file %v, run go generate", file.Name)
+			continue
+		}
+		assert.NoError(err)
+		if string(content) != string(file.Content) {
+			t.Errorf("the generated file %v is out of date, run go generate", file.Name)
+		}
+	}
+
+	paths, err := filepath.Glob("generated_*.go")
+	assert.NoError(err)
+	for _, path := range paths {
+		if !generated[path] {
+			t.Errorf("the generated file %v is stale, run go generate", path)
+		}
+	}
+}
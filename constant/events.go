@@ -42,10 +42,11 @@ const (
 	EventReasonOrphaned = "Orphaned"
 	EventReasonUnknown  = "Unknown"
 
-	EventReasonEvictionAutomatic     = "EvictionAutomatic"
-	EventReasonEvictionUserRequested = "EvictionUserRequested"
-	EventReasonEvictionCanceled      = "EvictionCanceled"
-	EventReasonEvictionFailed        = "EvictionFailed"
+	EventReasonEvictionAutomatic      = "EvictionAutomatic"
+	EventReasonEvictionUserRequested  = "EvictionUserRequested"
+	EventReasonEvictionCanceled       = "EvictionCanceled"
+	EventReasonEvictionFailed         = "EvictionFailed"
+	EventReasonEvictionStorageTiering = "EvictionStorageTiering"
 
 	EventReasonDetachedUnexpectedly = "DetachedUnexpectedly"
 	EventReasonRemount              = "Remount"
@@ -73,4 +74,8 @@ const (
 	EventReasonMigrationFailed = "MigrationFailed"
 
 	EventReasonOrphanCleanupCompleted = "OrphanCleanupCompleted"
+
+	EventReasonDiskReplaced = "DiskReplaced"
+
+	EventReasonStorageTierChanged = "StorageTierChanged"
 )
@@ -0,0 +1,323 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/kubernetes/pkg/controller"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientset "k8s.io/client-go/kubernetes"
+	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	"github.com/longhorn/longhorn-manager/alert"
+	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/types"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+const (
+	AlertSinkControllerName = "longhorn-alert-sink"
+
+	alertSinkSendTimeout = 30 * time.Second
+)
+
+// AlertSinkController evaluates the alerts derived from the conditions the other controllers compute, and sends the
+// firing and resolved alerts to the alert sinks it is responsible for. Each sink is requeued every alert evaluation
+// interval.
+type AlertSinkController struct {
+	*baseController
+
+	// which namespace controller is running with
+	namespace string
+	// use as the OwnerID of the controller
+	controllerID string
+
+	kubeClient    clientset.Interface
+	eventRecorder record.EventRecorder
+
+	ds *datastore.DataStore
+
+	cacheSyncs []cache.InformerSynced
+}
+
+func NewAlertSinkController(
+	logger logrus.FieldLogger,
+	ds *datastore.DataStore,
+	scheme *runtime.Scheme,
+	kubeClient clientset.Interface,
+	namespace string,
+	controllerID string) (*AlertSinkController, error) {
+
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartLogging(logrus.Infof)
+	eventBroadcaster.StartRecordingToSink(&v1core.EventSinkImpl{Interface: v1core.New(kubeClient.CoreV1().RESTClient()).Events("")})
+
+	c := &AlertSinkController{
+		baseController: newBaseController(AlertSinkControllerName, logger),
+
+		namespace:    namespace,
+		controllerID: controllerID,
+
+		kubeClient:    kubeClient,
+		eventRecorder: eventBroadcaster.NewRecorder(scheme, corev1.EventSource{Component: AlertSinkControllerName + "-controller"}),
+
+		ds: ds,
+	}
+
+	var err error
+	if _, err = ds.AlertSinkInformer.AddEventHandlerWithResyncPeriod(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueAlertSink,
+		UpdateFunc: func(old, cur interface{}) { c.enqueueAlertSink(cur) },
+		DeleteFunc: c.enqueueAlertSink,
+	}, 0); err != nil {
+		return nil, err
+	}
+	c.cacheSyncs = append(c.cacheSyncs, ds.AlertSinkInformer.HasSynced, ds.AlertSilenceInformer.HasSynced)
+
+	return c, nil
+}
+
+func (c *AlertSinkController) enqueueAlertSink(obj interface{}) {
+	key, err := controller.KeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("couldn't get key for object %#v: %v", obj, err))
+		return
+	}
+
+	c.queue.Add(key)
+}
+
+func (c *AlertSinkController) Run(workers int, stopCh <-chan struct{}) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	c.logger.Info("Starting Longhorn AlertSink controller")
+	defer c.logger.Info("Shut down Longhorn AlertSink controller")
+
+	if !cache.WaitForNamedCacheSync(c.name, stopCh, c.cacheSyncs...) {
+		return
+	}
+	for i := 0; i < workers; i++ {
+		go wait.Until(c.worker, time.Second, stopCh)
+	}
+	<-stopCh
+}
+
+func (c *AlertSinkController) worker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *AlertSinkController) processNextWorkItem() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	err := c.syncAlertSink(c.newReconcileLogger(), key.(string))
+	c.handleErr(err, key)
+
+	return true
+}
+
+func (c *AlertSinkController) handleErr(err error, key interface{}) {
+	if err == nil {
+		c.queue.Forget(key)
+		return
+	}
+
+	log := c.logger.WithField("AlertSink", key)
+
+	if c.queue.NumRequeues(key) < maxRetries {
+		handleReconcileErrorLogging(log, err, "Failed to sync AlertSink")
+		c.queue.AddRateLimited(key)
+		return
+	}
+
+	utilruntime.HandleError(err)
+	handleReconcileErrorLogging(log, err, "Dropping Longhorn AlertSink out of the queue")
+	c.queue.Forget(key)
+}
+
+func getLoggerForAlertSink(logger logrus.FieldLogger, alertSink *longhorn.AlertSink) *logrus.Entry {
+	return logger.WithField("alertSink", alertSink.Name)
+}
+
+func (c *AlertSinkController) syncAlertSink(logger logrus.FieldLogger, key string) (err error) {
+	defer func() {
+		err = errors.Wrapf(err, "%v: fail to sync AlertSink %v", c.name, key)
+	}()
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	if namespace != c.namespace {
+		return nil
+	}
+
+	return c.reconcile(logger, key, name)
+}
+
+func (c *AlertSinkController) reconcile(logger logrus.FieldLogger, key, name string) (err error) {
+	alertSink, err := c.ds.GetAlertSink(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	log := getLoggerForAlertSink(logger, alertSink)
+
+	if !c.isResponsibleFor(alertSink) {
+		return nil
+	}
+
+	if alertSink.Status.OwnerID != c.controllerID {
+		alertSink.Status.OwnerID = c.controllerID
+		alertSink, err = c.ds.UpdateAlertSinkStatus(alertSink)
+		if err != nil {
+			// we don't mind others coming first
+			if apierrors.IsConflict(errors.Cause(err)) {
+				return nil
+			}
+			return err
+		}
+		log.Infof("Alert sink got new owner %v", c.controllerID)
+	}
+
+	interval, err := c.ds.GetSettingAsInt(types.SettingNameAlertEvaluationInterval)
+	if err != nil {
+		return err
+	}
+	defer c.queue.AddAfter(key, time.Duration(interval)*time.Second)
+
+	existingAlertSink := alertSink.DeepCopy()
+	defer func() {
+		if reflect.DeepEqual(existingAlertSink.Status, alertSink.Status) {
+			return
+		}
+		if _, updateErr := c.ds.UpdateAlertSinkStatus(alertSink); updateErr != nil && !apierrors.IsConflict(errors.Cause(updateErr)) {
+			log.WithError(updateErr).Warn("Failed to update alert sink status")
+		}
+	}()
+
+	alerts, err := c.ds.ListAlerts()
+	if err != nil {
+		return err
+	}
+	if len(alertSink.Spec.AlertNames) > 0 {
+		isNotSent := func(name string) bool { return !slices.Contains(alertSink.Spec.AlertNames, name) }
+		alerts = slices.DeleteFunc(alerts, func(a *types.Alert) bool { return isNotSent(a.Name) })
+		// The alerts no longer sent to the sink are forgotten rather than sent as resolved
+		alertSink.Status.SentAlerts = slices.DeleteFunc(slices.Clone(alertSink.Status.SentAlerts),
+			func(a longhorn.AlertSinkSentAlert) bool { return isNotSent(a.Name) })
+	}
+	firingAlerts := 0
+	for _, a := range alerts {
+		if !a.Silenced {
+			firingAlerts++
+		}
+	}
+	alertSink.Status.FiringAlerts = firingAlerts
+
+	deduplicator := alert.NewDeduplicator(alertSink.Status.SentAlerts)
+	now := time.Now()
+	firing, resolved := deduplicator.Pending(alerts, alertSink.Spec.RepeatInterval.Duration, now)
+	toSend := firing
+	if alertSink.Spec.SendResolved {
+		for _, a := range resolved {
+			c.setResolvedAlertObject(a)
+		}
+		toSend = append(toSend, resolved...)
+	}
+	if len(toSend) == 0 {
+		deduplicator.Sent(firing, resolved, now)
+		alertSink.Status.SentAlerts = deduplicator.SentAlerts()
+		return nil
+	}
+
+	if err := c.send(alertSink, toSend); err != nil {
+		// Keep the alerts pending so they are sent in the next evaluation
+		alertSink.Status.LastError = err.Error()
+		log.WithError(err).Warnf("Failed to send %v alerts", len(toSend))
+		return nil
+	}
+	deduplicator.Sent(firing, resolved, now)
+	alertSink.Status.SentAlerts = deduplicator.SentAlerts()
+	alertSink.Status.LastSentAt = metav1.Time{Time: now.UTC()}
+	alertSink.Status.LastError = ""
+
+	return nil
+}
+
+func (c *AlertSinkController) send(alertSink *longhorn.AlertSink, alerts []*types.Alert) error {
+	credential := map[string]string{}
+	if alertSink.Spec.CredentialSecret != "" {
+		secret, err := c.ds.GetSecretRO(c.namespace, alertSink.Spec.CredentialSecret)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get credential secret %v", alertSink.Spec.CredentialSecret)
+		}
+		for key, value := range secret.Data {
+			credential[key] = string(value)
+		}
+	}
+
+	sink, err := alert.NewSink(alertSink, credential, c.eventRecorder)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), alertSinkSendTimeout)
+	defer cancel()
+	return sink.Send(ctx, alerts)
+}
+
+// setResolvedAlertObject sets the object of a resolved alert restored from the AlertSink status, so that an event
+// sink records its event. The object is left unset if it no longer exists.
+func (c *AlertSinkController) setResolvedAlertObject(a *types.Alert) {
+	if a.Object != nil {
+		return
+	}
+
+	var object runtime.Object
+	var err error
+	switch a.Kind {
+	case types.AlertKindVolume:
+		object, err = c.ds.GetVolumeRO(a.Resource)
+	case types.AlertKindNode, types.AlertKindDisk:
+		object, err = c.ds.GetNodeRO(a.Node)
+	case types.AlertKindBackup:
+		object, err = c.ds.GetBackupRO(a.Resource)
+	case types.AlertKindBackupTarget:
+		object, err = c.ds.GetBackupTargetRO(a.Resource)
+	default:
+		return
+	}
+	if err != nil {
+		return
+	}
+	a.Object = object
+}
+
+func (c *AlertSinkController) isResponsibleFor(alertSink *longhorn.AlertSink) bool {
+	return isControllerResponsibleFor(c.controllerID, c.ds, alertSink.Name, "", alertSink.Status.OwnerID)
+}
@@ -0,0 +1,159 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/kubernetes/pkg/controller"
+
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/types"
+	"github.com/longhorn/longhorn-manager/util"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+	lhfake "github.com/longhorn/longhorn-manager/k8s/pkg/client/clientset/versioned/fake"
+
+	. "gopkg.in/check.v1"
+)
+
+const (
+	TestAlertSinkName = "test-alert-sink"
+)
+
+func newTestAlertSinkController(lhClient *lhfake.Clientset, kubeClient *fake.Clientset, extensionsClient *apiextensionsfake.Clientset, informerFactories *util.InformerFactories) (*AlertSinkController, *record.FakeRecorder, error) {
+	ds := datastore.NewDataStore(TestNamespace, lhClient, kubeClient, extensionsClient, informerFactories)
+
+	logger := logrus.StandardLogger()
+	c, err := NewAlertSinkController(logger, ds, scheme.Scheme, kubeClient, TestNamespace, TestNode1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	eventRecorder := record.NewFakeRecorder(100)
+	c.eventRecorder = eventRecorder
+	for index := range c.cacheSyncs {
+		c.cacheSyncs[index] = alwaysReady
+	}
+	return c, eventRecorder, nil
+}
+
+func newSentVolumeDegradedAlert(lastSentAt time.Time) longhorn.AlertSinkSentAlert {
+	return longhorn.AlertSinkSentAlert{
+		Name:         types.AlertNameVolumeDegraded,
+		Severity:     types.AlertSeverityWarning,
+		Kind:         types.AlertKindVolume,
+		ResourceName: TestVolumeName,
+		Message:      fmt.Sprintf("volume %v is degraded", TestVolumeName),
+		LastSentAt:   metav1.Time{Time: lastSentAt.UTC().Truncate(time.Second)},
+	}
+}
+
+func (s *TestSuite) TestReconcileAlertSink(c *C) {
+	type testCase struct {
+		robustness longhorn.VolumeRobustness
+		alertNames []string
+		sentAlerts []longhorn.AlertSinkSentAlert
+
+		expectEventReasons []string
+		expectSentAlerts   []string
+	}
+	testCases := map[string]testCase{
+		"first send": {
+			robustness:         longhorn.VolumeRobustnessDegraded,
+			expectEventReasons: []string{types.AlertNameVolumeDegraded},
+			expectSentAlerts:   []string{"VolumeDegraded/" + TestVolumeName},
+		},
+		"already sent before the controller restart or the owner change": {
+			robustness:       longhorn.VolumeRobustnessDegraded,
+			sentAlerts:       []longhorn.AlertSinkSentAlert{newSentVolumeDegradedAlert(time.Now().Add(-time.Minute))},
+			expectSentAlerts: []string{"VolumeDegraded/" + TestVolumeName},
+		},
+		"sent again after the repeat interval": {
+			robustness:         longhorn.VolumeRobustnessDegraded,
+			sentAlerts:         []longhorn.AlertSinkSentAlert{newSentVolumeDegradedAlert(time.Now().Add(-2 * time.Hour))},
+			expectEventReasons: []string{types.AlertNameVolumeDegraded},
+			expectSentAlerts:   []string{"VolumeDegraded/" + TestVolumeName},
+		},
+		"resolved before the controller restart or the owner change": {
+			robustness:         longhorn.VolumeRobustnessHealthy,
+			sentAlerts:         []longhorn.AlertSinkSentAlert{newSentVolumeDegradedAlert(time.Now().Add(-time.Minute))},
+			expectEventReasons: []string{types.AlertNameVolumeDegraded + "Resolved"},
+		},
+		"no longer sent to the sink": {
+			robustness: longhorn.VolumeRobustnessDegraded,
+			alertNames: []string{types.AlertNameVolumeFaulted},
+			sentAlerts: []longhorn.AlertSinkSentAlert{newSentVolumeDegradedAlert(time.Now().Add(-time.Minute))},
+		},
+	}
+
+	for name, tc := range testCases {
+		fmt.Printf("testing %v\n", name)
+
+		kubeClient := fake.NewSimpleClientset()
+		lhClient := lhfake.NewSimpleClientset()
+		extensionsClient := apiextensionsfake.NewSimpleClientset()
+		informerFactories := util.NewInformerFactories(TestNamespace, kubeClient, lhClient, controller.NoResyncPeriodFunc())
+
+		volumeIndexer := informerFactories.LhInformerFactory.Longhorn().V1beta2().Volumes().Informer().GetIndexer()
+		alertSinkIndexer := informerFactories.LhInformerFactory.Longhorn().V1beta2().AlertSinks().Informer().GetIndexer()
+
+		alertSinkController, eventRecorder, err := newTestAlertSinkController(lhClient, kubeClient, extensionsClient, informerFactories)
+		c.Assert(err, IsNil)
+
+		vol := newVolume(TestVolumeName, 2)
+		vol.Namespace = TestNamespace
+		vol.Status.Robustness = tc.robustness
+		c.Assert(volumeIndexer.Add(vol), IsNil)
+
+		alertSink := &longhorn.AlertSink{
+			ObjectMeta: metav1.ObjectMeta{Name: TestAlertSinkName, Namespace: TestNamespace},
+			Spec: longhorn.AlertSinkSpec{
+				Type:           longhorn.AlertSinkTypeEvent,
+				AlertNames:     tc.alertNames,
+				RepeatInterval: metav1.Duration{Duration: time.Hour},
+				SendResolved:   true,
+			},
+			Status: longhorn.AlertSinkStatus{
+				OwnerID:    TestNode1,
+				SentAlerts: tc.sentAlerts,
+			},
+		}
+		alertSink, err = lhClient.LonghornV1beta2().AlertSinks(TestNamespace).Create(context.TODO(), alertSink, metav1.CreateOptions{})
+		c.Assert(err, IsNil)
+		c.Assert(alertSinkIndexer.Add(alertSink), IsNil)
+
+		err = alertSinkController.reconcile(alertSinkController.logger, TestNamespace+"/"+TestAlertSinkName, TestAlertSinkName)
+		c.Assert(err, IsNil, Commentf("%v", name))
+
+		eventReasons := []string{}
+		for len(eventRecorder.Events) > 0 {
+			// The events of the fake recorder are formatted as "<type> <reason> <message>"
+			eventReasons = append(eventReasons, strings.Fields(<-eventRecorder.Events)[1])
+		}
+		if tc.expectEventReasons == nil {
+			tc.expectEventReasons = []string{}
+		}
+		c.Assert(eventReasons, DeepEquals, tc.expectEventReasons, Commentf("%v", name))
+
+		alertSink, err = lhClient.LonghornV1beta2().AlertSinks(TestNamespace).Get(context.TODO(), TestAlertSinkName, metav1.GetOptions{})
+		c.Assert(err, IsNil)
+		sentAlerts := []string{}
+		for _, sent := range alertSink.Status.SentAlerts {
+			sentAlerts = append(sentAlerts, sent.Name+"/"+sent.ResourceName)
+		}
+		if tc.expectSentAlerts == nil {
+			tc.expectSentAlerts = []string{}
+		}
+		c.Assert(sentAlerts, DeepEquals, tc.expectSentAlerts, Commentf("%v", name))
+	}
+}
@@ -175,7 +175,7 @@ func (bic *BackingImageController) processNextWorkItem() bool {
 	}
 	defer bic.queue.Done(key)
 
-	err := bic.syncBackingImage(key.(string))
+	err := bic.syncBackingImage(bic.newReconcileLogger(), key.(string))
 	bic.handleErr(err, key)
 
 	return true
@@ -207,7 +207,7 @@ func getLoggerForBackingImage(logger logrus.FieldLogger, bi *longhorn.BackingIma
 	)
 }
 
-func (bic *BackingImageController) syncBackingImage(key string) (err error) {
+func (bic *BackingImageController) syncBackingImage(logger logrus.FieldLogger, key string) (err error) {
 	defer func() {
 		err = errors.Wrapf(err, "failed to sync backing image for %v", key)
 	}()
@@ -227,7 +227,7 @@ func (bic *BackingImageController) syncBackingImage(key string) (err error) {
 		return errors.Wrapf(err, "failed to get backing image %v", name)
 	}
 
-	log := getLoggerForBackingImage(bic.logger, backingImage)
+	log := getLoggerForBackingImage(logger, backingImage)
 
 	if !bic.isResponsibleFor(backingImage) {
 		return nil
@@ -313,6 +313,13 @@ func (bic *BackingImageController) syncBackingImage(key string) (err error) {
 		return bic.ds.RemoveFinalizerForBackingImage(backingImage)
 	}
 
+	if types.IsSnapshotExportBackingImage(backingImage) {
+		expired, err := bic.handleSnapshotExportExpiration(backingImage)
+		if err != nil || expired {
+			return err
+		}
+	}
+
 	if backingImage.Status.DiskFileStatusMap == nil {
 		backingImage.Status.DiskFileStatusMap = map[string]*longhorn.BackingImageDiskFileStatus{}
 	}
@@ -356,6 +363,29 @@ func (bic *BackingImageController) syncBackingImage(key string) (err error) {
 	return nil
 }
 
+// handleSnapshotExportExpiration deletes the backing image staging the export of a snapshot once it expires. It returns
+// true if the backing image is expired.
+func (bic *BackingImageController) handleSnapshotExportExpiration(bi *longhorn.BackingImage) (expired bool, err error) {
+	expireAt, err := types.GetSnapshotExportExpireAt(bi)
+	if err != nil {
+		return false, err
+	}
+	if time.Now().Before(expireAt) {
+		key, err := controller.KeyFunc(bi)
+		if err != nil {
+			return false, err
+		}
+		bic.queue.AddAfter(key, time.Until(expireAt))
+		return false, nil
+	}
+
+	if err := bic.ds.DeleteBackingImage(bi.Name); err != nil && !apierrors.IsNotFound(err) {
+		return false, err
+	}
+	getLoggerForBackingImage(bic.logger, bi).Info("Deleted expired backing image of snapshot export")
+	return true, nil
+}
+
 func (bic *BackingImageController) handleV2BackingImage(bi *longhorn.BackingImage) (err error) {
 	if err := bic.prepareFirstV2Copy(bi); err != nil {
 		return errors.Wrapf(err, "failed to prepare the first v2 backing image")
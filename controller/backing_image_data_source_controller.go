@@ -191,7 +191,7 @@ func (c *BackingImageDataSourceController) processNextWorkItem() bool {
 	}
 	defer c.queue.Done(key)
 
-	err := c.syncBackingImageDataSource(key.(string))
+	err := c.syncBackingImageDataSource(c.newReconcileLogger(), key.(string))
 	c.handleErr(err, key)
 
 	return true
@@ -216,10 +216,9 @@ func (c *BackingImageDataSourceController) handleErr(err error, key interface{})
 }
 
 func getLoggerForBackingImageDataSource(logger logrus.FieldLogger, bids *longhorn.BackingImageDataSource) *logrus.Entry {
-	return logger.WithFields(
+	return getLoggerForResource(logger, "", "", "", bids.Spec.NodeID).WithFields(
 		logrus.Fields{
 			"backingImageDataSource": bids.Name,
-			"nodeID":                 bids.Spec.NodeID,
 			"diskUUID":               bids.Spec.DiskUUID,
 			"sourceType":             bids.Spec.SourceType,
 			"parameters":             bids.Spec.Parameters,
@@ -242,7 +241,7 @@ func (c *BackingImageDataSourceController) getEngineClientProxy(e *longhorn.Engi
 	return engineapi.GetCompatibleClient(e, engineCliClient, c.ds, c.logger, c.proxyConnCounter)
 }
 
-func (c *BackingImageDataSourceController) syncBackingImageDataSource(key string) (err error) {
+func (c *BackingImageDataSourceController) syncBackingImageDataSource(logger logrus.FieldLogger, key string) (err error) {
 	defer func() {
 		err = errors.Wrapf(err, "failed to sync backing image data source for %v", key)
 	}()
@@ -262,7 +261,7 @@ func (c *BackingImageDataSourceController) syncBackingImageDataSource(key string
 		return errors.Wrap(err, "failed to get backing image data source")
 	}
 
-	log := getLoggerForBackingImageDataSource(c.logger, bids)
+	log := getLoggerForBackingImageDataSource(logger, bids)
 
 	if !c.isResponsibleFor(bids) {
 		return nil
@@ -728,21 +727,11 @@ func (c *BackingImageDataSourceController) generateBackingImageDataSourcePodMani
 			return nil, errors.Wrapf(err, "failed to get the backup target %v", bids.Spec.Parameters[longhorn.DataSourceTypeRestoreParameterBackupTargetName])
 		}
 
-		backupType, err := util.CheckBackupType(backupTarget.Spec.BackupTargetURL)
+		credential, err = c.ds.GetBackupTargetCredential(backupTarget)
 		if err != nil {
 			return nil, err
 		}
 
-		if types.BackupStoreRequireCredential(backupType) {
-			if backupTarget.Spec.CredentialSecret == "" {
-				return nil, fmt.Errorf("failed to access %s without credential secret", backupType)
-			}
-			credential, err = c.ds.GetCredentialFromSecret(backupTarget.Spec.CredentialSecret)
-			if err != nil {
-				return nil, err
-			}
-		}
-
 		for key, value := range credential {
 			cmd = append(cmd, "--credential", fmt.Sprintf("%s=%s", key, value))
 		}
@@ -861,6 +850,13 @@ func (c *BackingImageDataSourceController) generateBackingImageDataSourcePodMani
 		podSpec.Annotations[nadAnnot] = types.CreateCniAnnotationFromSetting(storageNetwork)
 	}
 
+	webIdentityTokenRequired, err := c.ds.IsWebIdentityTokenRequired()
+	if err != nil {
+		return nil, err
+	}
+	if webIdentityTokenRequired {
+		types.AddWebIdentityTokenToPod(podSpec)
+	}
 	types.AddGoCoverDirToPod(podSpec)
 	return podSpec, nil
 }
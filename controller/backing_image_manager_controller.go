@@ -215,7 +215,7 @@ func (c *BackingImageManagerController) processNextWorkItem() bool {
 	}
 	defer c.queue.Done(key)
 
-	err := c.syncBackingImageManager(key.(string))
+	err := c.syncBackingImageManager(c.newReconcileLogger(), key.(string))
 	c.handleErr(err, key)
 
 	return true
@@ -240,16 +240,15 @@ func (c *BackingImageManagerController) handleErr(err error, key interface{}) {
 }
 
 func getLoggerForBackingImageManager(logger logrus.FieldLogger, bim *longhorn.BackingImageManager) *logrus.Entry {
-	return logger.WithFields(
+	return getLoggerForResource(logger, "", "", "", bim.Spec.NodeID).WithFields(
 		logrus.Fields{
 			"backingImageManager": bim.Name,
-			"nodeID":              bim.Spec.NodeID,
 			"diskUUID":            bim.Spec.DiskUUID,
 		},
 	)
 }
 
-func (c *BackingImageManagerController) syncBackingImageManager(key string) (err error) {
+func (c *BackingImageManagerController) syncBackingImageManager(logger logrus.FieldLogger, key string) (err error) {
 	defer func() {
 		err = errors.Wrapf(err, "BackingImageManagerController failed to sync %v", key)
 	}()
@@ -269,7 +268,7 @@ func (c *BackingImageManagerController) syncBackingImageManager(key string) (err
 		return errors.Wrap(err, "failed to get backing image manager")
 	}
 
-	log := getLoggerForBackingImageManager(c.logger, bim)
+	log := getLoggerForBackingImageManager(logger, bim)
 
 	if !c.isResponsibleFor(bim) {
 		return nil
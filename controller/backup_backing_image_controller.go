@@ -136,7 +136,7 @@ func (bc *BackupBackingImageController) processNextWorkItem() bool {
 		return false
 	}
 	defer bc.queue.Done(key)
-	err := bc.syncHandler(key.(string))
+	err := bc.syncHandler(bc.newReconcileLogger(), key.(string))
 	bc.handleErr(err, key)
 	return true
 }
@@ -151,7 +151,7 @@ func (bc *BackupBackingImageController) handleErr(err error, key interface{}) {
 	bc.queue.AddRateLimited(key)
 }
 
-func (bc *BackupBackingImageController) syncHandler(key string) (err error) {
+func (bc *BackupBackingImageController) syncHandler(logger logrus.FieldLogger, key string) (err error) {
 	defer func() {
 		err = errors.Wrapf(err, "%v: failed to sync backup backing image %v", bc.name, key)
 	}()
@@ -163,7 +163,7 @@ func (bc *BackupBackingImageController) syncHandler(key string) (err error) {
 	if namespace != bc.namespace {
 		return nil
 	}
-	return bc.reconcile(name)
+	return bc.reconcile(logger, name)
 }
 
 func getLoggerForBackupBackingImage(logger logrus.FieldLogger, backupbackingimage *longhorn.BackupBackingImage) *logrus.Entry {
@@ -174,7 +174,7 @@ func getLoggerForBackupBackingImage(logger logrus.FieldLogger, backupbackingimag
 	)
 }
 
-func (bc *BackupBackingImageController) reconcile(backupBackingImageName string) (err error) {
+func (bc *BackupBackingImageController) reconcile(logger logrus.FieldLogger, backupBackingImageName string) (err error) {
 	bbi, err := bc.ds.GetBackupBackingImage(backupBackingImageName)
 	if err != nil {
 		if !apierrors.IsNotFound(err) {
@@ -196,10 +196,10 @@ func (bc *BackupBackingImageController) reconcile(backupBackingImageName string)
 			}
 			return err
 		}
-		bc.logger.Infof("Backup backing image got new owner %v", bc.controllerID)
+		logger.Infof("Backup backing image got new owner %v", bc.controllerID)
 	}
 
-	log := getLoggerForBackupBackingImage(bc.logger, bbi)
+	log := getLoggerForBackupBackingImage(logger, bbi)
 
 	// Get backup target with the backup backing image spec backup target name
 	backupTarget, err := bc.ds.GetBackupTargetRO(bbi.Spec.BackupTargetName)
@@ -28,6 +28,7 @@ import (
 
 	"github.com/longhorn/longhorn-manager/datastore"
 	"github.com/longhorn/longhorn-manager/engineapi"
+	"github.com/longhorn/longhorn-manager/metrics_collector/operation"
 	"github.com/longhorn/longhorn-manager/types"
 	"github.com/longhorn/longhorn-manager/util"
 
@@ -187,7 +188,7 @@ func (bc *BackupController) processNextWorkItem() bool {
 		return false
 	}
 	defer bc.queue.Done(key)
-	err := bc.syncHandler(key.(string))
+	err := bc.syncHandler(bc.newReconcileLogger(), key.(string))
 	bc.handleErr(err, key)
 	return true
 }
@@ -203,7 +204,7 @@ func (bc *BackupController) handleErr(err error, key interface{}) {
 	bc.queue.AddRateLimited(key)
 }
 
-func (bc *BackupController) syncHandler(key string) (err error) {
+func (bc *BackupController) syncHandler(logger logrus.FieldLogger, key string) (err error) {
 	defer func() {
 		err = errors.Wrapf(err, "%v: failed to sync backup %v", bc.name, key)
 	}()
@@ -215,11 +216,11 @@ func (bc *BackupController) syncHandler(key string) (err error) {
 	if namespace != bc.namespace {
 		return nil
 	}
-	return bc.reconcile(name)
+	return bc.reconcile(logger, name)
 }
 
 func getLoggerForBackup(logger logrus.FieldLogger, backup *longhorn.Backup) *logrus.Entry {
-	return logger.WithFields(
+	return getLoggerForResource(logger, backup.Status.VolumeName, "", "", "").WithFields(
 		logrus.Fields{
 			"backup": backup.Name,
 		},
@@ -250,7 +251,7 @@ func (bc *BackupController) isBackupNotBeingUsedForVolumeRestore(backupName, bac
 	return true, nil
 }
 
-func (bc *BackupController) reconcile(backupName string) (err error) {
+func (bc *BackupController) reconcile(logger logrus.FieldLogger, backupName string) (err error) {
 	// Get Backup CR
 	backup, err := bc.ds.GetBackup(backupName)
 	if err != nil {
@@ -284,7 +285,7 @@ func (bc *BackupController) reconcile(backupName string) (err error) {
 		}
 	}
 
-	log := getLoggerForBackup(bc.logger, backup)
+	log := getLoggerForBackup(logger, backup)
 
 	// Get BackupTarget CR
 	backupTargetName := ""
@@ -400,6 +401,7 @@ func (bc *BackupController) reconcile(backupName string) (err error) {
 			err = nil // nolint: ineffassign
 			return
 		}
+		bc.recordBackupOperation(existingBackupState, backup)
 		if backup.Status.State == longhorn.BackupStateCompleted && existingBackupState != backup.Status.State {
 			if err := bc.syncBackupVolume(backupTargetName, canonicalBackupVolumeName); err != nil {
 				log.Warnf("Failed to sync backup volume %v for backup target %v", canonicalBackupVolumeName, backupTargetName)
@@ -1052,6 +1054,27 @@ func (bc *BackupController) syncBackupStatusWithSnapshotCreationTimeAndVolumeSiz
 	backup.Status.SnapshotCreatedAt = snap.Created
 }
 
+// recordBackupOperation records the time from the creation of the backup CR until the backup completes or fails. The
+// backups synced from the backup target are not recorded.
+func (bc *BackupController) recordBackupOperation(existingBackupState longhorn.BackupState, backup *longhorn.Backup) {
+	if backup.Spec.SnapshotName == "" || existingBackupState == backup.Status.State {
+		return
+	}
+	if existingBackupState == longhorn.BackupStateCompleted || existingBackupState == longhorn.BackupStateError {
+		return
+	}
+	if backup.Status.State != longhorn.BackupStateCompleted && backup.Status.State != longhorn.BackupStateError {
+		return
+	}
+
+	dataEngine := ""
+	if volume, err := bc.ds.GetVolumeRO(backup.Status.VolumeName); err == nil {
+		dataEngine = string(volume.Spec.DataEngine)
+	}
+	operation.ObserveDuration(operation.OperationBackup, dataEngine, operation.Outcome(backup.Status.State == longhorn.BackupStateError),
+		backup.CreationTimestamp.Time)
+}
+
 func (bc *BackupController) backupInFinalState(backup *longhorn.Backup) bool {
 	return backup.Status.State == longhorn.BackupStateCompleted ||
 		backup.Status.State == longhorn.BackupStateError ||
@@ -3,6 +3,7 @@ package controller
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"sync"
@@ -52,11 +53,18 @@ type BackupTargetController struct {
 	bsTimerMap     map[string]*BackupStoreTimer
 	bsTimerMapLock *sync.RWMutex
 
+	// backup target prober map is responsible for updating the backupTarget.status.health
+	bpMap     map[string]*BackupTargetProber
+	bpMapLock *sync.Mutex
+
 	ds *datastore.DataStore
 
 	cacheSyncs []cache.InformerSynced
 
 	proxyConnCounter util.Counter
+
+	// the image of the NFS server serving the node path of a file backup target
+	shareManagerImage string
 }
 
 type BackupStoreTimer struct {
@@ -77,6 +85,7 @@ func NewBackupTargetController(
 	kubeClient clientset.Interface,
 	controllerID string,
 	namespace string,
+	shareManagerImage string,
 	proxyConnCounter util.Counter) (*BackupTargetController, error) {
 	eventBroadcaster := record.NewBroadcaster()
 	eventBroadcaster.StartLogging(logrus.Infof)
@@ -94,12 +103,17 @@ func NewBackupTargetController(
 		bsTimerMap:     map[string]*BackupStoreTimer{},
 		bsTimerMapLock: &sync.RWMutex{},
 
+		bpMap:     map[string]*BackupTargetProber{},
+		bpMapLock: &sync.Mutex{},
+
 		ds: ds,
 
 		kubeClient:    kubeClient,
 		eventRecorder: eventBroadcaster.NewRecorder(scheme, corev1.EventSource{Component: "longhorn-backup-target-controller"}),
 
 		proxyConnCounter: proxyConnCounter,
+
+		shareManagerImage: shareManagerImage,
 	}
 
 	var err error
@@ -129,6 +143,18 @@ func NewBackupTargetController(
 	}
 	btc.cacheSyncs = append(btc.cacheSyncs, ds.EngineImageInformer.HasSynced)
 
+	if _, err = ds.PodInformer.AddEventHandlerWithResyncPeriod(cache.FilteringResourceEventHandler{
+		FilterFunc: isFileBackupTargetNFSServerPod,
+		Handler: cache.ResourceEventHandlerFuncs{
+			AddFunc:    btc.enqueueBackupTargetForPod,
+			UpdateFunc: func(old, cur interface{}) { btc.enqueueBackupTargetForPod(cur) },
+			DeleteFunc: btc.enqueueBackupTargetForPod,
+		},
+	}, 0); err != nil {
+		return nil, err
+	}
+	btc.cacheSyncs = append(btc.cacheSyncs, ds.PodInformer.HasSynced)
+
 	return btc, nil
 }
 
@@ -142,6 +168,40 @@ func (btc *BackupTargetController) enqueueBackupTarget(obj interface{}) {
 	btc.queue.Add(key)
 }
 
+func getPodFromObject(obj interface{}) (*corev1.Pod, bool) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		deletedState, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			return nil, false
+		}
+		// use the last known state, to enqueue, dependent objects
+		pod, ok = deletedState.Obj.(*corev1.Pod)
+		if !ok {
+			return nil, false
+		}
+	}
+	return pod, true
+}
+
+func isFileBackupTargetNFSServerPod(obj interface{}) bool {
+	pod, ok := getPodFromObject(obj)
+	if !ok {
+		return false
+	}
+	name, ok := pod.Labels[types.GetLonghornLabelKey(types.LonghornLabelBackupTarget)]
+	return ok && pod.Name == types.GetFileBackupTargetNFSServerName(name)
+}
+
+func (btc *BackupTargetController) enqueueBackupTargetForPod(obj interface{}) {
+	pod, ok := getPodFromObject(obj)
+	if !ok {
+		utilruntime.HandleError(fmt.Errorf("couldn't get pod from object %#v", obj))
+		return
+	}
+	btc.queue.Add(pod.Namespace + "/" + pod.Labels[types.GetLonghornLabelKey(types.LonghornLabelBackupTarget)])
+}
+
 func (btc *BackupTargetController) enqueueEngineImage(obj interface{}) {
 	ei, ok := obj.(*longhorn.EngineImage)
 	if !ok {
@@ -193,12 +253,12 @@ func (btc *BackupTargetController) processNextWorkItem() bool {
 		return false
 	}
 	defer btc.queue.Done(key)
-	err := btc.syncHandler(key.(string))
+	err := btc.syncHandler(btc.newReconcileLogger(), key.(string))
 	btc.handleErr(err, key)
 	return true
 }
 
-func (btc *BackupTargetController) syncHandler(key string) (err error) {
+func (btc *BackupTargetController) syncHandler(logger logrus.FieldLogger, key string) (err error) {
 	defer func() {
 		err = errors.Wrapf(err, "%v: failed to sync %v", btc.name, key)
 	}()
@@ -211,7 +271,7 @@ func (btc *BackupTargetController) syncHandler(key string) (err error) {
 		// Not ours, skip it
 		return nil
 	}
-	return btc.reconcile(name)
+	return btc.reconcile(logger, name)
 }
 
 func (btc *BackupTargetController) handleErr(err error, key interface{}) {
@@ -277,29 +337,23 @@ func newBackupTargetClient(ds *datastore.DataStore, backupTarget *longhorn.Backu
 		err = errors.Wrapf(err, "failed to get %v backup target client on %v", backupTarget.Name, engineImage)
 	}()
 
-	backupType, err := util.CheckBackupType(backupTarget.Spec.BackupTargetURL)
+	credential, err := ds.GetBackupTargetCredential(backupTarget)
 	if err != nil {
 		return nil, err
 	}
 
-	var credential map[string]string
-	if types.BackupStoreRequireCredential(backupType) {
-		if backupTarget.Spec.CredentialSecret == "" {
-			return nil, fmt.Errorf("could not access %s without credential secret", backupType)
-		}
-		credential, err = ds.GetCredentialFromSecret(backupTarget.Spec.CredentialSecret)
-		if err != nil {
-			return nil, err
-		}
-	}
-
 	executeTimeout, err := ds.GetSettingAsInt(types.SettingNameBackupExecutionTimeout)
 	if err != nil {
 		return nil, err
 	}
 	timeout := time.Duration(executeTimeout) * time.Minute
 
-	return engineapi.NewBackupTargetClient(engineImage, backupTarget.Spec.BackupTargetURL, credential, timeout), nil
+	backupTargetURL, err := ds.GetBackupTargetAccessURL(backupTarget)
+	if err != nil {
+		return nil, err
+	}
+
+	return engineapi.NewBackupTargetClient(engineImage, backupTargetURL, credential, timeout), nil
 }
 
 func newBackupTargetClientFromDefaultEngineImage(ds *datastore.DataStore, backupTarget *longhorn.BackupTarget) (*engineapi.BackupTargetClient, error) {
@@ -311,7 +365,7 @@ func newBackupTargetClientFromDefaultEngineImage(ds *datastore.DataStore, backup
 	return newBackupTargetClient(ds, backupTarget, defaultEngineImage)
 }
 
-func (btc *BackupTargetController) reconcile(name string) (err error) {
+func (btc *BackupTargetController) reconcile(logger logrus.FieldLogger, name string) (err error) {
 	backupTarget, err := btc.ds.GetBackupTarget(name)
 	if err != nil {
 		if !apierrors.IsNotFound(err) {
@@ -320,7 +374,7 @@ func (btc *BackupTargetController) reconcile(name string) (err error) {
 		return nil
 	}
 
-	log := getLoggerForBackupTarget(btc.logger, backupTarget)
+	log := getLoggerForBackupTarget(logger, backupTarget)
 
 	// Every controller should do the clean up even it is not responsible for the CR
 	if backupTarget.Spec.BackupTargetURL == "" {
@@ -353,6 +407,18 @@ func (btc *BackupTargetController) reconcile(name string) (err error) {
 		}
 	}
 
+	if err := btc.syncFileBackupTargetAttachmentTicket(backupTarget); err != nil {
+		return err
+	}
+
+	if err := btc.syncFileBackupTargetNFSServer(backupTarget, log); err != nil {
+		return err
+	}
+
+	if err := btc.syncManagerWebIdentityToken(backupTarget, log); err != nil {
+		return err
+	}
+
 	// start a BackupStoreTimer and keep it in a map[string]*BackupStoreTimer
 	stopTimer := func(backupTargetName string) {
 		_, exists := btc.bsTimerMap[backupTargetName]
@@ -367,6 +433,7 @@ func (btc *BackupTargetController) reconcile(name string) (err error) {
 		defer btc.bsTimerMapLock.Unlock()
 
 		stopTimer(backupTarget.Name)
+		btc.stopBackupTargetProber(backupTarget.Name)
 
 		if err := btc.cleanUpAllBackupRelatedResources(backupTarget.Name); err != nil {
 			return err
@@ -374,6 +441,12 @@ func (btc *BackupTargetController) reconcile(name string) (err error) {
 		return btc.ds.RemoveFinalizerForBackupTarget(backupTarget)
 	}
 
+	if backupTarget.Spec.BackupTargetURL == "" {
+		btc.stopBackupTargetProber(backupTarget.Name)
+	} else {
+		btc.startBackupTargetProber(backupTarget.Name, log)
+	}
+
 	btc.bsTimerMapLock.Lock()
 	if backupTarget.Spec.PollInterval.Duration == time.Duration(0) ||
 		(btc.bsTimerMap[name] != nil && btc.bsTimerMap[name].pollInterval != backupTarget.Spec.PollInterval.Duration) {
@@ -443,6 +516,9 @@ func (btc *BackupTargetController) reconcile(name string) (err error) {
 		backupTarget.Status.Conditions = types.SetCondition(backupTarget.Status.Conditions,
 			longhorn.BackupTargetConditionTypeUnavailable, longhorn.ConditionStatusTrue,
 			longhorn.BackupTargetConditionReasonUnavailable, "backup target URL is empty")
+		backupTarget.Status.Health = longhorn.BackupTargetHealth{}
+		backupTarget.Status.Conditions = types.SetCondition(backupTarget.Status.Conditions,
+			longhorn.BackupTargetConditionTypeDegraded, longhorn.ConditionStatusFalse, "", "")
 
 		if err := btc.cleanUpAllBackupRelatedResources(backupTarget.Name); err != nil {
 			return err
@@ -1008,3 +1084,305 @@ func (bst *BackupStoreTimer) Start() {
 func (bst *BackupStoreTimer) Stop() {
 	bst.cancel()
 }
+
+func (btc *BackupTargetController) startBackupTargetProber(name string, log logrus.FieldLogger) {
+	btc.bpMapLock.Lock()
+	defer btc.bpMapLock.Unlock()
+
+	if btc.bpMap[name] != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	btc.bpMap[name] = &BackupTargetProber{
+		logger:       log.WithField("component", "backup-target-prober"),
+		controllerID: btc.controllerID,
+		ds:           btc.ds,
+
+		btName: name,
+		window: newBackupTargetProbeWindow(backupTargetProbeWindowSize),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	go btc.bpMap[name].Start()
+}
+
+func (btc *BackupTargetController) stopBackupTargetProber(name string) {
+	btc.bpMapLock.Lock()
+	defer btc.bpMapLock.Unlock()
+
+	if btc.bpMap[name] != nil {
+		btc.bpMap[name].Stop()
+		delete(btc.bpMap, name)
+	}
+}
+
+// syncManagerWebIdentityToken projects the service account token into the longhorn-manager pods if the credential of
+// the backup target assumes a role with it, since the managers list the backup target and the system backups. The
+// daemonset rolls out the managers again once, the projection is kept when the credential changes later.
+func (btc *BackupTargetController) syncManagerWebIdentityToken(backupTarget *longhorn.BackupTarget, log logrus.FieldLogger) error {
+	required, err := btc.ds.IsBackupTargetWebIdentityTokenRequired(backupTarget)
+	if err != nil || !required {
+		return err
+	}
+
+	daemonSet, err := btc.ds.GetDaemonSet(types.LonghornManagerDaemonSetName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get daemonset %v to project the web identity token", types.LonghornManagerDaemonSetName)
+	}
+	daemonSet = daemonSet.DeepCopy()
+	if !types.AddWebIdentityTokenToDaemonSet(daemonSet) {
+		return nil
+	}
+
+	log.Infof("Projecting the web identity token into the %v pods", types.LonghornManagerDaemonSetName)
+	_, err = btc.ds.UpdateDaemonSet(daemonSet)
+	return err
+}
+
+// syncFileBackupTargetAttachmentTicket keeps the volume storing the backups of a file backup target shared, by adding
+// an attachment ticket consuming its share like a workload. The ticket is removed from the other volumes once the
+// backup target is deleted or its URL is changed.
+func (btc *BackupTargetController) syncFileBackupTargetAttachmentTicket(backupTarget *longhorn.BackupTarget) error {
+	volumeName := ""
+	if backupTarget.DeletionTimestamp.IsZero() && util.GetSchemeFromURL(backupTarget.Spec.BackupTargetURL) == types.BackupStoreTypeFile {
+		var err error
+		if volumeName, _, _, err = types.ParseFileBackupTargetURL(backupTarget.Spec.BackupTargetURL); err != nil {
+			return err
+		}
+	}
+
+	vas, err := btc.ds.ListLHVolumeAttachmentsRO()
+	if err != nil {
+		return err
+	}
+	attachmentTicketID := longhorn.GetAttachmentTicketID(longhorn.AttacherTypeBackupTargetController, backupTarget.Name)
+	for _, va := range vas {
+		_, exists := va.Spec.AttachmentTickets[attachmentTicketID]
+		expected := va.Spec.Volume == volumeName
+		if exists == expected {
+			continue
+		}
+
+		va = va.DeepCopy()
+		if expected {
+			createOrUpdateAttachmentTicket(va, attachmentTicketID, btc.controllerID, longhorn.FalseValue, longhorn.AttacherTypeBackupTargetController)
+		} else {
+			delete(va.Spec.AttachmentTickets, attachmentTicketID)
+		}
+		if _, err := btc.ds.UpdateLHVolumeAttachment(va); err != nil {
+			return err
+		}
+		getLoggerForBackupTarget(btc.logger, backupTarget).Infof("Updated attachment ticket %v of volume %v storing the backups", attachmentTicketID, va.Spec.Volume)
+	}
+	return nil
+}
+
+// syncFileBackupTargetNFSServer runs the NFS server serving the node path of a file backup target on the node, with a
+// service the backup store is accessed through. The server isn't moved to another node when the node is down, since
+// the backups are only on the node. The server is removed once the backup target is deleted or doesn't store the
+// backups on a node anymore.
+func (btc *BackupTargetController) syncFileBackupTargetNFSServer(backupTarget *longhorn.BackupTarget, log logrus.FieldLogger) error {
+	nodeID, dir := "", ""
+	if backupTarget.DeletionTimestamp.IsZero() && util.GetSchemeFromURL(backupTarget.Spec.BackupTargetURL) == types.BackupStoreTypeFile {
+		var err error
+		if _, nodeID, dir, err = types.ParseFileBackupTargetURL(backupTarget.Spec.BackupTargetURL); err != nil {
+			return err
+		}
+	}
+
+	name := types.GetFileBackupTargetNFSServerName(backupTarget.Name)
+	pod, err := btc.ds.GetPodRO(btc.namespace, name)
+	if err != nil {
+		return err
+	}
+	if pod != nil && pod.DeletionTimestamp == nil && (nodeID == "" || isFileBackupTargetNFSServerPodOutdated(pod, nodeID, dir, btc.shareManagerImage)) {
+		log.Infof("Deleting NFS server pod %v serving the backups on node %v", name, pod.Spec.NodeName)
+		if err := btc.ds.DeletePod(name); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		pod = nil
+	}
+
+	if nodeID == "" {
+		if err := btc.ds.DeleteService(btc.namespace, name); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+
+	if _, err := btc.ds.GetService(btc.namespace, name); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		log.Infof("Creating NFS server service %v serving the backups", name)
+		if _, err := btc.ds.CreateService(btc.namespace, btc.newFileBackupTargetNFSServerService(backupTarget)); err != nil && !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+
+	if pod != nil {
+		return nil
+	}
+	pod, err = btc.newFileBackupTargetNFSServerPod(backupTarget, nodeID, dir)
+	if err != nil {
+		return err
+	}
+	log.Infof("Creating NFS server pod %v serving the backups on node %v", name, nodeID)
+	if _, err := btc.ds.CreatePod(pod); err != nil && !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+	return nil
+}
+
+func isFileBackupTargetNFSServerPodOutdated(pod *corev1.Pod, nodeID, dir, image string) bool {
+	if pod.Status.Phase == corev1.PodFailed || pod.Status.Phase == corev1.PodSucceeded {
+		return true
+	}
+	if pod.Spec.NodeName != nodeID || len(pod.Spec.Containers) == 0 || pod.Spec.Containers[0].Image != image {
+		return true
+	}
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Name == fileBackupTargetNFSServerVolumeBackupstore {
+			return volume.HostPath == nil || volume.HostPath.Path != dir
+		}
+	}
+	return true
+}
+
+func (btc *BackupTargetController) newFileBackupTargetNFSServerService(backupTarget *longhorn.BackupTarget) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            types.GetFileBackupTargetNFSServerName(backupTarget.Name),
+			Namespace:       btc.namespace,
+			Labels:          types.GetFileBackupTargetNFSServerLabels(backupTarget.Name),
+			OwnerReferences: datastore.GetOwnerReferencesForBackupTarget(backupTarget),
+		},
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeClusterIP,
+			Selector: types.GetFileBackupTargetNFSServerLabels(backupTarget.Name),
+			Ports: []corev1.ServicePort{
+				{
+					Name:     "nfs",
+					Port:     2049,
+					Protocol: corev1.ProtocolTCP,
+				},
+			},
+		},
+	}
+}
+
+const (
+	fileBackupTargetNFSServerVolumeBackupstore = "backupstore"
+	fileBackupTargetNFSServerVolumeConfig      = "nfs-config"
+)
+
+func (btc *BackupTargetController) newFileBackupTargetNFSServerPod(backupTarget *longhorn.BackupTarget, nodeID, dir string) (*corev1.Pod, error) {
+	tolerations, err := btc.ds.GetSettingTaintToleration()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get taint toleration setting before creating NFS server pod")
+	}
+	imagePullPolicy, err := btc.ds.GetSettingImagePullPolicy()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get image pull policy before creating NFS server pod")
+	}
+	registrySecret, err := btc.ds.GetSettingWithAutoFillingRO(types.SettingNameRegistrySecret)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get registry secret setting before creating NFS server pod")
+	}
+	priorityClass, err := btc.ds.GetSettingWithAutoFillingRO(types.SettingNamePriorityClass)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get priority class setting before creating NFS server pod")
+	}
+
+	privileged := true
+	hostPathType := corev1.HostPathDirectoryOrCreate
+	configVolumeMount := corev1.VolumeMount{
+		Name:      fileBackupTargetNFSServerVolumeConfig,
+		MountPath: filepath.Dir(types.NFSGaneshaConfigPath),
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            types.GetFileBackupTargetNFSServerName(backupTarget.Name),
+			Namespace:       btc.namespace,
+			Labels:          types.GetFileBackupTargetNFSServerLabels(backupTarget.Name),
+			OwnerReferences: datastore.GetOwnerReferencesForBackupTarget(backupTarget),
+		},
+		Spec: corev1.PodSpec{
+			// The pod is bound to the node storing the backups without being scheduled
+			NodeName:          nodeID,
+			Tolerations:       util.GetDistinctTolerations(tolerations),
+			PriorityClassName: priorityClass.Value,
+			InitContainers: []corev1.Container{
+				{
+					Name:            fileBackupTargetNFSServerVolumeConfig,
+					Image:           btc.shareManagerImage,
+					ImagePullPolicy: imagePullPolicy,
+					Command:         []string{"sh", "-c", fmt.Sprintf("printf '%%s' \"$NFS_CONFIG\" > %v", types.NFSGaneshaConfigPath)},
+					Env: []corev1.EnvVar{
+						{
+							Name:  "NFS_CONFIG",
+							Value: types.GetFileBackupTargetNFSServerConfig(),
+						},
+					},
+					VolumeMounts: []corev1.VolumeMount{configVolumeMount},
+				},
+			},
+			Containers: []corev1.Container{
+				{
+					Name:            "nfs-server",
+					Image:           btc.shareManagerImage,
+					ImagePullPolicy: imagePullPolicy,
+					Command:         []string{"ganesha.nfsd", "-F", "-p", "/var/run/ganesha.pid", "-f", types.NFSGaneshaConfigPath},
+					ReadinessProbe: &corev1.Probe{
+						ProbeHandler: corev1.ProbeHandler{
+							Exec: &corev1.ExecAction{
+								Command: []string{"cat", "/var/run/ganesha.pid"},
+							},
+						},
+						InitialDelaySeconds: datastore.PodProbeInitialDelay,
+						TimeoutSeconds:      datastore.PodProbeTimeoutSeconds,
+						PeriodSeconds:       datastore.PodProbePeriodSeconds,
+						FailureThreshold:    datastore.PodLivenessProbeFailureThreshold,
+					},
+					SecurityContext: &corev1.SecurityContext{
+						Privileged: &privileged,
+					},
+					VolumeMounts: []corev1.VolumeMount{
+						configVolumeMount,
+						{
+							Name:      fileBackupTargetNFSServerVolumeBackupstore,
+							MountPath: types.FileBackupTargetNFSExportPath,
+						},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{
+					Name: fileBackupTargetNFSServerVolumeConfig,
+					VolumeSource: corev1.VolumeSource{
+						EmptyDir: &corev1.EmptyDirVolumeSource{},
+					},
+				},
+				{
+					Name: fileBackupTargetNFSServerVolumeBackupstore,
+					VolumeSource: corev1.VolumeSource{
+						HostPath: &corev1.HostPathVolumeSource{
+							Path: dir,
+							Type: &hostPathType,
+						},
+					},
+				},
+			},
+			RestartPolicy: corev1.RestartPolicyAlways,
+		},
+	}
+
+	if registrySecret.Value != "" {
+		pod.Spec.ImagePullSecrets = []corev1.LocalObjectReference{
+			{
+				Name: registrySecret.Value,
+			},
+		}
+	}
+	return pod, nil
+}
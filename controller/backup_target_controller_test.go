@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/kubernetes/pkg/controller"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/types"
+	"github.com/longhorn/longhorn-manager/util"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+	lhfake "github.com/longhorn/longhorn-manager/k8s/pkg/client/clientset/versioned/fake"
+
+	. "gopkg.in/check.v1"
+)
+
+func (s *TestSuite) TestSyncFileBackupTargetNFSServer(c *C) {
+	type testCase struct {
+		backupTargetURL string
+		existingPodNode string
+		existingPodDir  string
+
+		expectPodNode string
+		expectPodDir  string
+	}
+	testCases := map[string]testCase{
+		"node path": {
+			backupTargetURL: "file://" + TestNode1 + "/mnt/backupstore",
+			expectPodNode:   TestNode1,
+			expectPodDir:    "/mnt/backupstore",
+		},
+		"node path moved to another node": {
+			backupTargetURL: "file://" + TestNode2 + "/mnt/backupstore",
+			existingPodNode: TestNode1,
+			existingPodDir:  "/mnt/backupstore",
+			expectPodNode:   TestNode2,
+			expectPodDir:    "/mnt/backupstore",
+		},
+		"unchanged node path": {
+			backupTargetURL: "file://" + TestNode1 + "/mnt/backupstore",
+			existingPodNode: TestNode1,
+			existingPodDir:  "/mnt/backupstore",
+			expectPodNode:   TestNode1,
+			expectPodDir:    "/mnt/backupstore",
+		},
+		"volume path": {
+			backupTargetURL: "file:///backupstore?volume=" + TestVolumeName,
+			existingPodNode: TestNode1,
+			existingPodDir:  "/mnt/backupstore",
+		},
+		"remote backup store": {
+			backupTargetURL: "s3://backupbucket@us-east-1/backupstore",
+		},
+	}
+
+	for name, tc := range testCases {
+		fmt.Printf("testing %v\n", name)
+
+		kubeClient := fake.NewSimpleClientset()
+		lhClient := lhfake.NewSimpleClientset()
+		extensionsClient := apiextensionsfake.NewSimpleClientset()
+		informerFactories := util.NewInformerFactories(TestNamespace, kubeClient, lhClient, controller.NoResyncPeriodFunc())
+		ds := datastore.NewDataStore(TestNamespace, lhClient, kubeClient, extensionsClient, informerFactories)
+
+		btc, err := NewBackupTargetController(logrus.StandardLogger(), ds, scheme.Scheme, kubeClient, TestNode1, TestNamespace, TestShareManagerImage, util.NewAtomicCounter())
+		c.Assert(err, IsNil)
+
+		backupTarget := &longhorn.BackupTarget{
+			ObjectMeta: metav1.ObjectMeta{Name: types.DefaultBackupTargetName, Namespace: TestNamespace},
+			Spec:       longhorn.BackupTargetSpec{BackupTargetURL: tc.backupTargetURL},
+		}
+		name := types.GetFileBackupTargetNFSServerName(backupTarget.Name)
+		if tc.existingPodNode != "" {
+			pod, err := btc.newFileBackupTargetNFSServerPod(backupTarget, tc.existingPodNode, tc.existingPodDir)
+			c.Assert(err, IsNil)
+			pod, err = kubeClient.CoreV1().Pods(TestNamespace).Create(context.TODO(), pod, metav1.CreateOptions{})
+			c.Assert(err, IsNil)
+			c.Assert(informerFactories.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer().Add(pod), IsNil)
+		}
+
+		err = btc.syncFileBackupTargetNFSServer(backupTarget, btc.logger)
+		c.Assert(err, IsNil, Commentf("%v", name))
+
+		pod, err := kubeClient.CoreV1().Pods(TestNamespace).Get(context.TODO(), name, metav1.GetOptions{})
+		_, serviceErr := kubeClient.CoreV1().Services(TestNamespace).Get(context.TODO(), name, metav1.GetOptions{})
+		if tc.expectPodNode == "" {
+			c.Assert(apierrors.IsNotFound(err), Equals, true, Commentf("%v", name))
+			c.Assert(apierrors.IsNotFound(serviceErr), Equals, true, Commentf("%v", name))
+			continue
+		}
+		c.Assert(err, IsNil, Commentf("%v", name))
+		c.Assert(serviceErr, IsNil, Commentf("%v", name))
+		c.Assert(pod.Spec.NodeName, Equals, tc.expectPodNode, Commentf("%v", name))
+		c.Assert(pod.Spec.Containers[0].Image, Equals, TestShareManagerImage, Commentf("%v", name))
+		c.Assert(isFileBackupTargetNFSServerPod(pod), Equals, true, Commentf("%v", name))
+		for _, volume := range pod.Spec.Volumes {
+			if volume.Name == fileBackupTargetNFSServerVolumeBackupstore {
+				c.Assert(volume.HostPath.Path, Equals, tc.expectPodDir, Commentf("%v", name))
+				c.Assert(*volume.HostPath.Type, Equals, corev1.HostPathDirectoryOrCreate, Commentf("%v", name))
+			}
+		}
+	}
+}
@@ -0,0 +1,376 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/sirupsen/logrus"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	systembackupstore "github.com/longhorn/backupstore/systembackup"
+
+	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/engineapi"
+	"github.com/longhorn/longhorn-manager/types"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+const (
+	// backupTargetProbeWindowSize is the number of the recent probes the health statistics are computed from.
+	backupTargetProbeWindowSize = 30
+	// backupTargetProbeRecheckInterval is how often a disabled prober checks whether the probing is enabled again.
+	backupTargetProbeRecheckInterval = 30 * time.Second
+	// backupTargetProbeMinCount is the number of probes required before the error rate can mark the backup target as
+	// degraded, so that a single failure right after the start doesn't flip the condition.
+	backupTargetProbeMinCount = 3
+
+	// The engine binary only writes and removes arbitrary objects in a backup store as system backups. The probe object
+	// is stored as a system backup of a dedicated version in a backup store nested in the backupstore directory of the
+	// backup target, which is neither listed as a system backup of this cluster nor of the clusters sharing the backup
+	// target. The backupstore directory always exists on a backup target in use, which the NFS and CIFS backup stores
+	// need to mount the nested one.
+	backupTargetProbeObjectPrefix  = "longhorn-backup-target-probe-"
+	backupTargetProbeObjectVersion = "probe"
+	backupTargetProbeObjectSize    = 4096
+	backupTargetProbeStoreSubDir   = "backupstore"
+)
+
+type backupTargetProbeResult struct {
+	latency  time.Duration
+	readOnly bool
+	err      error
+}
+
+// backupTargetProbeStore is the backup store the prober lists the backup volumes of, and puts, lists, gets and
+// deletes the probe object in.
+type backupTargetProbeStore interface {
+	engineapi.SystemBackupOperationInterface
+	BackupVolumeNameList() ([]string, error)
+}
+
+// backupTargetProbeWindow keeps the results of the recent probes in a ring buffer.
+type backupTargetProbeWindow struct {
+	results []backupTargetProbeResult
+	next    int
+}
+
+func newBackupTargetProbeWindow(size int) *backupTargetProbeWindow {
+	return &backupTargetProbeWindow{
+		results: make([]backupTargetProbeResult, 0, size),
+	}
+}
+
+func (w *backupTargetProbeWindow) add(result backupTargetProbeResult) {
+	if len(w.results) < cap(w.results) {
+		w.results = append(w.results, result)
+		return
+	}
+	w.results[w.next] = result
+	w.next = (w.next + 1) % len(w.results)
+}
+
+// errorRate returns the percentage of the failed probes in the window.
+func (w *backupTargetProbeWindow) errorRate() int {
+	if len(w.results) == 0 {
+		return 0
+	}
+	failed := 0
+	for _, result := range w.results {
+		if result.err != nil {
+			failed++
+		}
+	}
+	return failed * 100 / len(w.results)
+}
+
+// latencyPercentile returns the latency in milliseconds below which the given percentage of the successful probes
+// in the window fall, using the nearest-rank method.
+func (w *backupTargetProbeWindow) latencyPercentile(percentile float64) int64 {
+	latencies := []time.Duration{}
+	for _, result := range w.results {
+		if result.err == nil {
+			latencies = append(latencies, result.latency)
+		}
+	}
+	if len(latencies) == 0 {
+		return 0
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+	rank := int(math.Ceil(percentile / 100 * float64(len(latencies))))
+	if rank < 1 {
+		rank = 1
+	}
+	return latencies[rank-1].Milliseconds()
+}
+
+// classifyBackupTargetError returns the class of an error returned by the backup store, which mostly comes from the
+// output of the engine binary and can only be classified by its message.
+func classifyBackupTargetError(err error) longhorn.BackupTargetErrorClass {
+	if err == nil {
+		return longhorn.BackupTargetErrorClassNone
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return longhorn.BackupTargetErrorClassTimeout
+	}
+
+	msg := strings.ToLower(err.Error())
+	switch {
+	case containsAny(msg, "no such host", "server misbehaving", "name resolution", "lookup "):
+		return longhorn.BackupTargetErrorClassDNS
+	case containsAny(msg, "timeout", "timed out", "deadline exceeded"):
+		return longhorn.BackupTargetErrorClassTimeout
+	case containsAny(msg, "accessdenied", "access denied", "invalidaccesskeyid", "signaturedoesnotmatch",
+		"expiredtoken", "forbidden", "unauthorized", "permission denied", "status code: 401", "status code: 403",
+		"credential"):
+		return longhorn.BackupTargetErrorClassAuth
+	case containsAny(msg, "internalerror", "internal server error", "serviceunavailable", "service unavailable",
+		"slowdown", "bad gateway", "status code: 500", "status code: 502", "status code: 503", "status code: 504"):
+		return longhorn.BackupTargetErrorClassServer
+	}
+	return longhorn.BackupTargetErrorClassUnknown
+}
+
+func containsAny(s string, substrs ...string) bool {
+	for _, substr := range substrs {
+		if strings.Contains(s, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// isBackupTargetWriteDenied returns true if the error tells the backup store refuses writes, like the read-only backup
+// target of a disaster recovery cluster does.
+func isBackupTargetWriteDenied(err error) bool {
+	return classifyBackupTargetError(err) == longhorn.BackupTargetErrorClassAuth ||
+		strings.Contains(strings.ToLower(err.Error()), "read-only file system")
+}
+
+// BackupTargetProber periodically lists a backup target and does put, list, get and delete round-trips of a probe
+// object against it, so the controller can tell the backup target is getting slow or flaky before the backups
+// actually fail.
+type BackupTargetProber struct {
+	logger       logrus.FieldLogger
+	controllerID string
+	ds           *datastore.DataStore
+
+	btName string
+	url    string
+	window *backupTargetProbeWindow
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func (bp *BackupTargetProber) Start() {
+	bp.logger.Info("Starting backup target prober")
+
+	for {
+		interval, err := bp.ds.GetSettingAsInt(types.SettingNameBackupTargetHealthProbeInterval)
+		if err != nil {
+			bp.logger.WithError(err).Warnf("Failed to get %v setting", types.SettingNameBackupTargetHealthProbeInterval)
+		}
+
+		delay := time.Duration(interval) * time.Second
+		if interval > 0 {
+			if err := bp.probe(); err != nil {
+				bp.logger.WithError(err).Warn("Failed to probe backup target")
+			}
+		} else {
+			delay = backupTargetProbeRecheckInterval
+		}
+
+		select {
+		case <-bp.ctx.Done():
+			bp.logger.Info("Stopped backup target prober")
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (bp *BackupTargetProber) Stop() {
+	bp.cancel()
+}
+
+func (bp *BackupTargetProber) probe() error {
+	backupTarget, err := bp.ds.GetBackupTargetRO(bp.btName)
+	if err != nil {
+		return err
+	}
+	if backupTarget.Status.OwnerID != bp.controllerID || backupTarget.Spec.BackupTargetURL == "" {
+		return nil
+	}
+	if backupTarget.Spec.BackupTargetURL != bp.url {
+		// The results of the previous backup store don't tell anything about the current one
+		bp.url = backupTarget.Spec.BackupTargetURL
+		bp.window = newBackupTargetProbeWindow(backupTargetProbeWindowSize)
+	}
+
+	result := backupTargetProbeResult{}
+	backupTargetClient, err := newBackupTargetClientFromDefaultEngineImage(bp.ds, backupTarget)
+	if err == nil {
+		var probeClient *engineapi.BackupTargetClient
+		if probeClient, err = newBackupTargetProbeClient(backupTargetClient); err == nil {
+			start := time.Now()
+			result.readOnly, err = probeBackupTarget(backupTargetClient, probeClient, backupTargetProbeObjectPrefix+bp.controllerID, SystemBackupTempDir)
+			result.latency = time.Since(start)
+		}
+	}
+	result.err = err
+	bp.window.add(result)
+
+	return bp.updateHealth(result)
+}
+
+// newBackupTargetProbeClient returns a copy of the backup target client pointing to the backup store nested in the
+// backupstore directory of the backup target, where the probe object is stored.
+func newBackupTargetProbeClient(backupTargetClient *engineapi.BackupTargetClient) (*engineapi.BackupTargetClient, error) {
+	u, err := url.Parse(backupTargetClient.URL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse backup target URL %v", backupTargetClient.URL)
+	}
+	u.Path = path.Join("/", u.Path, backupTargetProbeStoreSubDir)
+
+	probeClient := *backupTargetClient
+	probeClient.URL = u.String()
+	return &probeClient, nil
+}
+
+// probeBackupTarget lists the backup volumes of the backup target, then puts a small probe object of random content
+// in the probe store, lists it, gets it back and compares the content, then deletes it. A probe object left over by a
+// previous probe failing to delete it is replaced. If the backup target refuses the put, it is read-only and healthy
+// as long as it can be listed.
+func probeBackupTarget(backupTargetClient, probeClient backupTargetProbeStore, name, tempDir string) (readOnly bool, err error) {
+	if _, err := backupTargetClient.BackupVolumeNameList(); err != nil {
+		return false, errors.Wrap(err, "failed to list backup volumes")
+	}
+
+	content := make([]byte, backupTargetProbeObjectSize)
+	if _, err := rand.Read(content); err != nil {
+		return false, errors.Wrap(err, "failed to generate the probe object")
+	}
+
+	uploadPath := filepath.Join(tempDir, name+"-put")
+	downloadPath := filepath.Join(tempDir, name+"-get")
+	defer func() {
+		_ = os.Remove(uploadPath)
+		_ = os.Remove(downloadPath)
+	}()
+	if err := os.WriteFile(uploadPath, content, 0600); err != nil {
+		return false, errors.Wrap(err, "failed to write the probe object")
+	}
+
+	_, err = probeClient.UploadSystemBackup(name, uploadPath, backupTargetProbeObjectVersion, "", "", "")
+	if err != nil && types.ErrorAlreadyExists(err) {
+		if err = deleteBackupTargetProbeObject(probeClient, name); err == nil {
+			_, err = probeClient.UploadSystemBackup(name, uploadPath, backupTargetProbeObjectVersion, "", "", "")
+		}
+	}
+	if err != nil {
+		if isBackupTargetWriteDenied(err) {
+			return true, nil
+		}
+		return false, errors.Wrapf(err, "failed to put probe object %v", name)
+	}
+	defer func() {
+		if deleteErr := deleteBackupTargetProbeObject(probeClient, name); deleteErr != nil && err == nil {
+			err = errors.Wrapf(deleteErr, "failed to delete probe object %v", name)
+		}
+	}()
+
+	probeObjects, err := probeClient.ListSystemBackup()
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to list probe object %v", name)
+	}
+	if _, exists := probeObjects[systembackupstore.Name(name)]; !exists {
+		return false, fmt.Errorf("probe object %v put in the backup target is not listed", name)
+	}
+
+	if err := probeClient.DownloadSystemBackup(name, backupTargetProbeObjectVersion, downloadPath); err != nil {
+		return false, errors.Wrapf(err, "failed to get probe object %v", name)
+	}
+	downloaded, err := os.ReadFile(downloadPath)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to read probe object %v", name)
+	}
+	if !bytes.Equal(content, downloaded) {
+		return false, fmt.Errorf("probe object %v got from the backup target differs from the one put", name)
+	}
+	return false, nil
+}
+
+func deleteBackupTargetProbeObject(backupTargetClient engineapi.SystemBackupOperationInterface, name string) error {
+	_, err := backupTargetClient.DeleteSystemBackup(&longhorn.SystemBackup{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status:     longhorn.SystemBackupStatus{Version: backupTargetProbeObjectVersion},
+	})
+	return err
+}
+
+func (bp *BackupTargetProber) updateHealth(result backupTargetProbeResult) error {
+	errorRateThreshold, err := bp.ds.GetSettingAsInt(types.SettingNameBackupTargetDegradedErrorRateThreshold)
+	if err != nil {
+		return err
+	}
+	latencyThreshold, err := bp.ds.GetSettingAsInt(types.SettingNameBackupTargetDegradedLatencyThreshold)
+	if err != nil {
+		return err
+	}
+
+	backupTarget, err := bp.ds.GetBackupTarget(bp.btName)
+	if err != nil {
+		return err
+	}
+
+	now := metav1.Time{Time: time.Now().UTC()}
+	health := &backupTarget.Status.Health
+	health.LastProbedAt = now
+	health.ProbeCount = len(bp.window.results)
+	health.ErrorRate = bp.window.errorRate()
+	health.LatencyP50 = bp.window.latencyPercentile(50)
+	health.LatencyP95 = bp.window.latencyPercentile(95)
+	health.LatencyP99 = bp.window.latencyPercentile(99)
+	if result.err != nil {
+		health.LastErrorClass = classifyBackupTargetError(result.err)
+		health.LastError = result.err.Error()
+		health.LastErrorAt = now
+	} else {
+		health.ReadOnly = result.readOnly
+	}
+
+	switch {
+	case health.ProbeCount >= backupTargetProbeMinCount && health.ErrorRate >= int(errorRateThreshold):
+		backupTarget.Status.Conditions = types.SetCondition(backupTarget.Status.Conditions,
+			longhorn.BackupTargetConditionTypeDegraded, longhorn.ConditionStatusTrue,
+			longhorn.BackupTargetConditionReasonHighErrorRate,
+			fmt.Sprintf("%v%% of the recent probes failed, last error (%v): %v", health.ErrorRate, health.LastErrorClass, health.LastError))
+	case health.LatencyP95 >= latencyThreshold:
+		backupTarget.Status.Conditions = types.SetCondition(backupTarget.Status.Conditions,
+			longhorn.BackupTargetConditionTypeDegraded, longhorn.ConditionStatusTrue,
+			longhorn.BackupTargetConditionReasonHighLatency,
+			fmt.Sprintf("the 95th percentile latency of the recent probes is %vms", health.LatencyP95))
+	default:
+		backupTarget.Status.Conditions = types.SetCondition(backupTarget.Status.Conditions,
+			longhorn.BackupTargetConditionTypeDegraded, longhorn.ConditionStatusFalse, "", "")
+	}
+
+	if _, err := bp.ds.UpdateBackupTargetStatus(backupTarget); err != nil && !apierrors.IsConflict(errors.Cause(err)) {
+		return err
+	}
+	return nil
+}
@@ -131,12 +131,12 @@ func (bvc *BackupVolumeController) processNextWorkItem() bool {
 		return false
 	}
 	defer bvc.queue.Done(key)
-	err := bvc.syncHandler(key.(string))
+	err := bvc.syncHandler(bvc.newReconcileLogger(), key.(string))
 	bvc.handleErr(err, key)
 	return true
 }
 
-func (bvc *BackupVolumeController) syncHandler(key string) (err error) {
+func (bvc *BackupVolumeController) syncHandler(logger logrus.FieldLogger, key string) (err error) {
 	defer func() {
 		err = errors.Wrapf(err, "%v: failed to sync backup volume %v", bvc.name, key)
 	}()
@@ -149,7 +149,7 @@ func (bvc *BackupVolumeController) syncHandler(key string) (err error) {
 		// Not ours, skip it
 		return nil
 	}
-	return bvc.reconcile(name)
+	return bvc.reconcile(logger, name)
 }
 
 func (bvc *BackupVolumeController) handleErr(err error, key interface{}) {
@@ -178,7 +178,7 @@ func getLoggerForBackupVolume(logger logrus.FieldLogger, backupVolume *longhorn.
 	)
 }
 
-func (bvc *BackupVolumeController) reconcile(backupVolumeName string) (err error) {
+func (bvc *BackupVolumeController) reconcile(logger logrus.FieldLogger, backupVolumeName string) (err error) {
 	// Get BackupVolume CR
 	backupVolume, err := bvc.ds.GetBackupVolume(backupVolumeName)
 	if err != nil {
@@ -212,7 +212,7 @@ func (bvc *BackupVolumeController) reconcile(backupVolumeName string) (err error
 		}
 	}
 
-	log := getLoggerForBackupVolume(bvc.logger, backupVolume)
+	log := getLoggerForBackupVolume(logger, backupVolume)
 
 	// Get the backup target of the backup volume
 	backupTarget, err := bvc.ds.GetBackupTargetRO(backupVolume.Spec.BackupTargetName)
@@ -1,11 +1,18 @@
 package controller
 
 import (
+	"strings"
+
 	"github.com/sirupsen/logrus"
 
 	"k8s.io/client-go/util/workqueue"
+
+	"github.com/longhorn/longhorn-manager/logging"
 )
 
+// controllerTracerName is the instrumentation scope of the spans of the controllers.
+const controllerTracerName = "github.com/longhorn/longhorn-manager/controller"
+
 var (
 	// maxRetries is the number of times a deployment will be retried before it is dropped out of the queue.
 	// With the current rate-limiter in use (5ms*2^(maxRetries-1)) the following numbers represent the times
@@ -15,6 +22,57 @@ var (
 	maxRetries = 3
 )
 
+// controllerNames are the names of the controllers of longhorn manager. Their logging components are registered
+// up front, so that the setting component-log-levels is validated by the webhook before the controllers are created.
+var controllerNames = []string{
+	"kubernetes-pdb",
+	"longhorn-backing-image",
+	"longhorn-backing-image-data-source",
+	"longhorn-backing-image-manager",
+	"longhorn-backup",
+	"longhorn-backup-backing-image",
+	"longhorn-backup-target",
+	"longhorn-backup-volume",
+	"longhorn-engine",
+	"longhorn-engine-image",
+	"longhorn-instance-manager",
+	"longhorn-kubernetes-configmap-controller",
+	"longhorn-kubernetes-node",
+	"longhorn-kubernetes-pod",
+	"longhorn-kubernetes-pv",
+	"longhorn-kubernetes-secret-controller",
+	"longhorn-node",
+	"longhorn-orphan",
+	"longhorn-recurring-job",
+	"longhorn-replica",
+	"longhorn-setting",
+	"longhorn-share-manager",
+	"longhorn-snapshot",
+	"longhorn-support-bundle",
+	"longhorn-uninstall",
+	"longhorn-volume",
+	"longhorn-volume-attachment",
+	"longhorn-volume-clone",
+	"longhorn-volume-eviction",
+	"longhorn-volume-expansion",
+	"longhorn-volume-rebuilding",
+	"longhorn-volume-restore",
+	"longhorn-websocket",
+	AlertSinkControllerName,
+	KubernetesEndpointControllerName,
+	StorageQuotaControllerName,
+	SystemBackupControllerName,
+	SystemRestoreControllerName,
+	SystemRolloutControllerName,
+	VolumePolicyControllerName,
+}
+
+func init() {
+	for _, name := range controllerNames {
+		logging.RegisterComponents(getControllerComponentName(name))
+	}
+}
+
 type baseController struct {
 	name   string
 	logger *logrus.Entry
@@ -31,9 +89,31 @@ func newBaseControllerWithQueue(name string, logger logrus.FieldLogger,
 	queue workqueue.TypedRateLimitingInterface[any]) *baseController {
 	c := &baseController{
 		name:   name,
-		logger: logger.WithField("controller", name),
+		logger: logging.WithComponent(logger, getControllerComponentName(name)).WithField(logging.FieldController, name),
 		queue:  queue,
 	}
 
 	return c
 }
+
+// getControllerComponentName returns the logging component of the controller, whose log level can be set on its own.
+// For example, it is engine-controller for the controller longhorn-engine.
+func getControllerComponentName(name string) string {
+	component := strings.TrimPrefix(name, "longhorn-")
+	if !strings.HasSuffix(component, "-controller") {
+		component += "-controller"
+	}
+	return component
+}
+
+// newReconcileLogger returns the controller logger with a new reconcile ID correlating the logs of a reconciliation.
+// It is created once per work item and passed down to the sync of the item.
+func (c *baseController) newReconcileLogger() *logrus.Entry {
+	return c.logger.WithField(logging.FieldReconcileID, logging.NewReconcileID())
+}
+
+// getLoggerForResource returns the logger with the fields correlating the logs of the resource across the controllers.
+// The empty fields are omitted.
+func getLoggerForResource(logger logrus.FieldLogger, volume, engine, replica, node string) *logrus.Entry {
+	return logger.WithFields(logging.ResourceFields(volume, engine, replica, node))
+}
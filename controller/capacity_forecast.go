@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+const (
+	// diskUsageSampleInterval is the minimum interval between two usage samples kept in the disk status.
+	diskUsageSampleInterval = time.Hour
+	// diskUsageHistoryWindow is how long the usage samples of a disk are kept for the growth rate computation.
+	diskUsageHistoryWindow = 7 * 24 * time.Hour
+	// diskUsageMinSamples is the minimum number of samples required before a growth rate is reported.
+	diskUsageMinSamples = 6
+	// diskProjectionMaxDays caps the projection, a disk that takes longer than this to fill is considered not growing.
+	diskProjectionMaxDays = 10 * 365
+)
+
+// recordDiskUsageSample adds a sample of the actual replica usage of a disk if the sample interval has elapsed since
+// the last one, and drops the samples that fall out of the history window. It returns the samples and true if the
+// sample is recorded.
+func recordDiskUsageSample(samples []longhorn.DiskUsageSample, now time.Time, replicaUsage int64) ([]longhorn.DiskUsageSample, bool) {
+	if len(samples) > 0 && now.Sub(samples[len(samples)-1].Timestamp.Time) < diskUsageSampleInterval {
+		return samples, false
+	}
+	samples = append(samples, longhorn.DiskUsageSample{
+		Timestamp:    metav1.Time{Time: now.UTC().Truncate(time.Second)},
+		ReplicaUsage: replicaUsage,
+	})
+
+	start := 0
+	for start < len(samples) && now.Sub(samples[start].Timestamp.Time) > diskUsageHistoryWindow {
+		start++
+	}
+	return samples[start:], true
+}
+
+// getDiskUsageGrowthRate returns the growth rate of the replica usage of a disk in bytes per day, computed by the least
+// squares linear regression of the samples. It returns false if there are not enough samples.
+func getDiskUsageGrowthRate(samples []longhorn.DiskUsageSample) (float64, bool) {
+	n := len(samples)
+	if n < diskUsageMinSamples {
+		return 0, false
+	}
+
+	origin := samples[0].Timestamp.Time
+	var sumX, sumY, sumXY, sumXX float64
+	for _, sample := range samples {
+		x := sample.Timestamp.Sub(origin).Hours() / 24
+		y := float64(sample.ReplicaUsage)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+
+	denominator := float64(n)*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0, false
+	}
+	return (float64(n)*sumXY - sumX*sumY) / denominator, true
+}
+
+// projectDiskFullTime returns the time at which the usable space of the disk runs out at the given growth rate. It
+// returns false if the usage is not growing or the disk is not going to be full in the foreseeable future.
+func projectDiskFullTime(from time.Time, available, reserved int64, growthRate float64) (time.Time, bool) {
+	remaining := available - reserved
+	if remaining <= 0 {
+		return from, true
+	}
+	if growthRate <= 0 {
+		return time.Time{}, false
+	}
+
+	days := float64(remaining) / growthRate
+	if days > diskProjectionMaxDays {
+		return time.Time{}, false
+	}
+	return from.Add(time.Duration(days * float64(24*time.Hour))), true
+}
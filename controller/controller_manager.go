@@ -14,6 +14,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 
+	"github.com/longhorn/longhorn-manager/controller/monitor"
 	"github.com/longhorn/longhorn-manager/datastore"
 	"github.com/longhorn/longhorn-manager/engineapi"
 	"github.com/longhorn/longhorn-manager/types"
@@ -68,7 +69,7 @@ func StartControllers(logger logrus.FieldLogger, clients *client.Clients,
 	if err != nil {
 		return nil, err
 	}
-	backupTargetController, err := NewBackupTargetController(logger, ds, scheme, kubeClient, controllerID, namespace, proxyConnCounter)
+	backupTargetController, err := NewBackupTargetController(logger, ds, scheme, kubeClient, controllerID, namespace, shareManagerImage, proxyConnCounter)
 	if err != nil {
 		return nil, err
 	}
@@ -128,6 +129,18 @@ func StartControllers(logger logrus.FieldLogger, clients *client.Clients,
 	if err != nil {
 		return nil, err
 	}
+	volumePolicyController, err := NewVolumePolicyController(logger, ds, namespace, controllerID)
+	if err != nil {
+		return nil, err
+	}
+	storageQuotaController, err := NewStorageQuotaController(logger, ds, namespace, controllerID)
+	if err != nil {
+		return nil, err
+	}
+	alertSinkController, err := NewAlertSinkController(logger, ds, scheme, kubeClient, namespace, controllerID)
+	if err != nil {
+		return nil, err
+	}
 	volumeAttachmentController, err := NewLonghornVolumeAttachmentController(logger, ds, scheme, kubeClient, controllerID, namespace)
 	if err != nil {
 		return nil, err
@@ -206,6 +219,9 @@ func StartControllers(logger logrus.FieldLogger, clients *client.Clients,
 	go supportBundleController.Run(Workers, stopCh)
 	go systemBackupController.Run(Workers, stopCh)
 	go systemRestoreController.Run(Workers, stopCh)
+	go volumePolicyController.Run(Workers, stopCh)
+	go storageQuotaController.Run(Workers, stopCh)
+	go alertSinkController.Run(Workers, stopCh)
 	go volumeAttachmentController.Run(Workers, stopCh)
 	go volumeRestoreController.Run(Workers, stopCh)
 	go volumeRebuildingController.Run(Workers, stopCh)
@@ -213,6 +229,19 @@ func StartControllers(logger logrus.FieldLogger, clients *client.Clients,
 	go volumeCloneController.Run(Workers, stopCh)
 	go volumeExpansionController.Run(Workers, stopCh)
 
+	// Start the usage accounting, the samples are taken by the same node as the extra info collection of the settings
+	usageMonitor, err := monitor.NewUsageMonitor(logger.WithField("monitor", "usage"), ds, controllerID, func() bool {
+		responsibleNodeID, err := getResponsibleNodeID(ds)
+		return err == nil && responsibleNodeID == controllerID
+	})
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		<-stopCh
+		usageMonitor.Stop()
+	}()
+
 	// Start goroutines for Kubernetes controllers
 	go kubernetesPVController.Run(Workers, stopCh)
 	go kubernetesNodeController.Run(Workers, stopCh)
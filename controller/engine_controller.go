@@ -15,6 +15,9 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/sirupsen/logrus"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
 	"golang.org/x/time/rate"
 
 	"k8s.io/apimachinery/pkg/runtime"
@@ -39,6 +42,8 @@ import (
 	"github.com/longhorn/longhorn-manager/constant"
 	"github.com/longhorn/longhorn-manager/datastore"
 	"github.com/longhorn/longhorn-manager/engineapi"
+	"github.com/longhorn/longhorn-manager/metrics_collector/operation"
+	"github.com/longhorn/longhorn-manager/tracing"
 	"github.com/longhorn/longhorn-manager/types"
 	"github.com/longhorn/longhorn-manager/util"
 
@@ -67,6 +72,9 @@ var (
 	sizeUpdateLimit = 30 * time.Second
 	// number of consecutive actual size updates allowed during bursts
 	sizeUpdateBurst = 3
+
+	// amount of time between the IOPS samples for the volume tiering policy
+	iopsSampleInterval = 1 * time.Minute
 )
 
 const (
@@ -117,6 +125,18 @@ type EngineMonitor struct {
 
 	expansionUpdateTime time.Time
 
+	// The start times of the engine operations in progress, for the operation metrics
+	expansionStartTime time.Time
+	purgeStartTime     time.Time
+	restoreStartTime   time.Time
+
+	// The progress samples of the rebuilding and purging replicas, keyed by the replica address
+	rebuildSamples map[string]*progressSample
+	purgeSamples   map[string]*progressSample
+
+	// The last time the IOPS was sampled for the volume tiering policy
+	iopsSampledAt time.Time
+
 	controllerID string
 	// used to notify the controller that monitoring has stopped
 	monitorVoluntaryStopCh chan struct{}
@@ -219,7 +239,7 @@ func (ec *EngineController) processNextWorkItem() bool {
 	}
 	defer ec.queue.Done(key)
 
-	err := ec.syncEngine(key.(string))
+	err := ec.syncEngine(ec.newReconcileLogger(), key.(string))
 	ec.handleErr(err, key)
 
 	return true
@@ -244,7 +264,12 @@ func (ec *EngineController) handleErr(err error, key interface{}) {
 }
 
 func getLoggerForEngine(logger logrus.FieldLogger, e *longhorn.Engine) *logrus.Entry {
-	return logger.WithField("engine", e.Name)
+	return getLoggerForResource(logger, e.Spec.VolumeName, e.Name, "", e.Spec.NodeID).WithFields(
+		logrus.Fields{
+			"currentState": e.Status.CurrentState,
+			"image":        e.Spec.Image,
+		},
+	)
 }
 
 func (ec *EngineController) getEngineClientProxy(e *longhorn.Engine, image string) (engineapi.EngineClientProxy, error) {
@@ -256,7 +281,7 @@ func (ec *EngineController) getEngineClientProxy(e *longhorn.Engine, image strin
 	return engineapi.GetCompatibleClient(e, engineCliClient, ec.ds, ec.logger, ec.proxyConnCounter)
 }
 
-func (ec *EngineController) syncEngine(key string) (err error) {
+func (ec *EngineController) syncEngine(logger logrus.FieldLogger, key string) (err error) {
 	defer func() {
 		err = errors.Wrapf(err, "failed to sync engine for %v", key)
 	}()
@@ -269,7 +294,7 @@ func (ec *EngineController) syncEngine(key string) (err error) {
 		return nil
 	}
 
-	log := ec.logger.WithField("engine", name)
+	log := logger.WithField("engine", name)
 	engine, err := ec.ds.GetEngine(name)
 	if err != nil {
 		if datastore.ErrorIsNotFound(err) {
@@ -304,7 +329,15 @@ func (ec *EngineController) syncEngine(key string) (err error) {
 		log.Infof("Engine got new owner %v", ec.controllerID)
 	}
 
+	changed := false
+	_, span := tracing.StartSpanForObject(engine, controllerTracerName, "EngineController.syncEngine",
+		trace.WithAttributes(attribute.String("longhorn.engine", engine.Name), attribute.String("longhorn.volume", engine.Spec.VolumeName)))
+	defer func() {
+		tracing.EndReconcileSpan(span, changed, err)
+	}()
+
 	if engine.DeletionTimestamp != nil {
+		changed = true
 		if err := ec.DeleteInstance(engine); err != nil {
 			return errors.Wrapf(err, "failed to clean up the related engine instance before deleting engine %v", engine.Name)
 		}
@@ -315,6 +348,7 @@ func (ec *EngineController) syncEngine(key string) (err error) {
 	defer func() {
 		// we're going to update engine assume things changes
 		if err == nil && !reflect.DeepEqual(existingEngine.Status, engine.Status) {
+			changed = true
 			_, err = ec.ds.UpdateEngineStatus(engine)
 		}
 		// requeue if it's conflict
@@ -436,11 +470,17 @@ func (ec *EngineController) enqueueInstanceManagerChange(obj interface{}) {
 	}
 }
 
-func (ec *EngineController) CreateInstance(obj interface{}) (*longhorn.InstanceProcess, error) {
+func (ec *EngineController) CreateInstance(obj interface{}) (ip *longhorn.InstanceProcess, err error) {
 	e, ok := obj.(*longhorn.Engine)
 	if !ok {
 		return nil, fmt.Errorf("invalid object for engine process creation: %v", obj)
 	}
+
+	ctx, span := tracing.StartSpanForObject(e, controllerTracerName, "EngineController.CreateInstance",
+		trace.WithAttributes(attribute.String("longhorn.engine", e.Name), attribute.String("longhorn.node", e.Spec.NodeID)))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
 	if e.Spec.VolumeName == "" || e.Spec.NodeID == "" {
 		return nil, fmt.Errorf("missing parameters for engine instance creation: %v", e)
 	}
@@ -504,6 +544,12 @@ func (ec *EngineController) CreateInstance(obj interface{}) (*longhorn.InstanceP
 		return nil, errors.Wrapf(err, "failed to update engine %v status.starting to true before sending instance create request", engineName)
 	}
 
+	_, imSpan := tracing.StartChildSpan(ctx, controllerTracerName, "InstanceManager.EngineInstanceCreate",
+		trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attribute.String("longhorn.instance_manager", im.Name)))
+	defer func() {
+		tracing.EndSpan(imSpan, err)
+	}()
+
 	return c.EngineInstanceCreate(&engineapi.EngineInstanceCreateRequest{
 		Engine:                           e,
 		VolumeFrontend:                   frontend,
@@ -567,6 +613,12 @@ func (ec *EngineController) DeleteInstance(obj interface{}) (err error) {
 
 	log.Info("Deleting engine instance")
 
+	ctx, span := tracing.StartSpanForObject(e, controllerTracerName, "EngineController.DeleteInstance",
+		trace.WithAttributes(attribute.String("longhorn.engine", e.Name), attribute.String("longhorn.node", e.Spec.NodeID)))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
 	defer func() {
 		if err != nil {
 			log.WithError(err).Warnf("Failed to delete engine %v", e.Name)
@@ -588,7 +640,10 @@ func (ec *EngineController) DeleteInstance(obj interface{}) (err error) {
 		}
 	}(c)
 
+	_, imSpan := tracing.StartChildSpan(ctx, controllerTracerName, "InstanceManager.InstanceDelete",
+		trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attribute.String("longhorn.instance_manager", im.Name)))
 	err = c.InstanceDelete(e.Spec.DataEngine, e.Name, "", string(longhorn.InstanceManagerTypeEngine), "", true)
+	tracing.EndSpan(imSpan, err)
 	if err != nil && !types.ErrorIsNotFound(err) {
 		return err
 	}
@@ -911,12 +966,16 @@ func (m *EngineMonitor) refresh(engine *longhorn.Engine) error {
 				engine.Status.LastExpansionFailedAt = volumeInfo.LastExpansionFailedAt
 				m.expansionUpdateTime = time.Now()
 				m.expansionBackoff.Next(engine.Name, time.Now())
+				operation.ObserveDuration(operation.OperationExpansion, string(engine.Spec.DataEngine), operation.OutcomeFailure, m.expansionStartTime)
+				m.expansionStartTime = time.Time{}
 			}
 		}
 		if engine.Status.CurrentSize != 0 && engine.Status.CurrentSize != volumeInfo.Size {
 			m.eventRecorder.Eventf(engine, corev1.EventTypeNormal, constant.EventReasonSucceededExpansion,
 				"Engine successfully expand size from %v to %v", engine.Status.CurrentSize, volumeInfo.Size)
 			m.expansionUpdateTime = time.Now()
+			operation.ObserveDuration(operation.OperationExpansion, string(engine.Spec.DataEngine), operation.OutcomeSuccess, m.expansionStartTime)
+			m.expansionStartTime = time.Time{}
 		}
 		engine.Status.CurrentSize = volumeInfo.Size
 		engine.Status.IsExpanding = volumeInfo.IsExpanding
@@ -937,6 +996,7 @@ func (m *EngineMonitor) refresh(engine *longhorn.Engine) error {
 		if err := m.checkAndApplyRebuildQoS(engine, engineClientProxy, rebuildStatus); err != nil {
 			return err
 		}
+		m.updateRebuildProgress(engine, rebuildStatus, time.Now())
 		engine.Status.RebuildStatus = rebuildStatus
 
 		// It's meaningless to sync the trim related field for old engines or engines in old engine instance managers
@@ -974,6 +1034,7 @@ func (m *EngineMonitor) refresh(engine *longhorn.Engine) error {
 		// For incompatible running engine, the current size is always `engine.Spec.VolumeSize`.
 		engine.Status.CurrentSize = engine.Spec.VolumeSize
 		engine.Status.RebuildStatus = map[string]*longhorn.RebuildStatus{}
+		m.rebuildSamples = nil
 	}
 
 	// TODO: Check if the purge failure is handled somewhere else
@@ -981,9 +1042,13 @@ func (m *EngineMonitor) refresh(engine *longhorn.Engine) error {
 	if err != nil {
 		m.logger.WithError(err).Warn("Failed to get snapshot purge status")
 	} else {
+		m.recordSnapshotPurge(engine.Status.PurgeStatus, purgeStatus, string(engine.Spec.DataEngine))
+		m.updatePurgeProgress(engine, purgeStatus, time.Now())
 		engine.Status.PurgeStatus = purgeStatus
 	}
 
+	m.updateLowIOPSSince(engine, engineClientProxy, time.Now())
+
 	removeInvalidEngineOpStatus(engine)
 
 	// Make sure the engine object is updated before engineapi calls.
@@ -1021,6 +1086,9 @@ func (m *EngineMonitor) refresh(engine *longhorn.Engine) error {
 			if err := engineClientProxy.VolumeExpand(engine); err != nil {
 				return err
 			}
+			if m.expansionStartTime.IsZero() {
+				m.expansionStartTime = time.Now()
+			}
 		}
 		return nil
 	}
@@ -1052,6 +1120,8 @@ func (m *EngineMonitor) refresh(engine *longhorn.Engine) error {
 			if err := m.acquireRestoringCounter(false); err != nil {
 				m.logger.WithError(err).Warn("Engine Monitor: Failed to unacquire restoring counter")
 			}
+			operation.ObserveDuration(operation.OperationRestore, string(engine.Spec.DataEngine), operation.Outcome(!isBackupRestoreCompleted), m.restoreStartTime)
+			m.restoreStartTime = time.Time{}
 		}
 
 		// Ignore size change here to maintain rate limiting. (If we wanted to update status based on a size change,
@@ -1527,6 +1597,9 @@ func (m *EngineMonitor) restoreBackup(engine *longhorn.Engine, rsMap map[string]
 		lastRestoredBackup = engine.Status.LastRestoredBackup
 		restoreErrorHandler = handleRestoreErrorForCompatibleEngine
 	}
+	if m.restoreStartTime.IsZero() {
+		m.restoreStartTime = time.Now()
+	}
 	if err = engineClientProxy.BackupRestore(engine, backupTargetClient.URL, engine.Spec.RequestedBackupRestore, backupVolume.Spec.VolumeName, lastRestoredBackup, backupTargetClient.Credential, int(concurrentLimit)); err != nil {
 		if extraErr := restoreErrorHandler(mlog, engine, rsMap, m.restoreBackoff, err); extraErr != nil {
 			return extraErr
@@ -1539,6 +1612,165 @@ func (m *EngineMonitor) restoreBackup(engine *longhorn.Engine, rsMap map[string]
 	return nil
 }
 
+// progressSample is the progress of a rebuilding or purging replica when the engine monitor last saw it change.
+type progressSample struct {
+	startTime                 time.Time
+	startBytes                int64
+	sampleTime                time.Time
+	progress                  int
+	totalBytes                int64
+	estimatedTransferredBytes int64
+	bytesPerSecond            int64
+}
+
+// sampleProgress returns the sample of an operation at the progress percentage. The engine doesn't report the bytes it
+// processed, so they are estimated from the progress and the total bytes. The total bytes are kept from the first
+// sample, since the snapshots change during the operation, and the rate is averaged since the first sample. A new
+// sample is taken only when the progress changes.
+func sampleProgress(previous *progressSample, now time.Time, progress int, totalBytes int64) *progressSample {
+	if previous == nil {
+		return &progressSample{
+			startTime:                 now,
+			startBytes:                totalBytes * int64(progress) / 100,
+			sampleTime:                now,
+			progress:                  progress,
+			totalBytes:                totalBytes,
+			estimatedTransferredBytes: totalBytes * int64(progress) / 100,
+		}
+	}
+	if previous.progress == progress {
+		return previous
+	}
+
+	sample := *previous
+	sample.sampleTime = now
+	sample.progress = progress
+	sample.estimatedTransferredBytes = sample.totalBytes * int64(progress) / 100
+	if elapsed := now.Sub(sample.startTime).Seconds(); elapsed > 0 && sample.estimatedTransferredBytes > sample.startBytes {
+		sample.bytesPerSecond = int64(float64(sample.estimatedTransferredBytes-sample.startBytes) / elapsed)
+	}
+	return &sample
+}
+
+// updateRebuildProgress fills in the estimated transferred bytes, rate and sample time of the rebuilding replicas. The
+// size of the data to be rebuilt is estimated from the snapshots of the engine, or the volume size if they are not
+// known.
+func (m *EngineMonitor) updateRebuildProgress(engine *longhorn.Engine, rebuildStatus map[string]*longhorn.RebuildStatus, now time.Time) {
+	totalBytes := getEngineSnapshotsSize(engine)
+	if totalBytes == 0 {
+		totalBytes = engine.Spec.VolumeSize
+	}
+
+	samples := map[string]*progressSample{}
+	for addr, status := range rebuildStatus {
+		if status == nil || !status.IsRebuilding {
+			continue
+		}
+		sample := sampleProgress(m.rebuildSamples[addr], now, status.Progress, totalBytes)
+		samples[addr] = sample
+		status.TotalBytes = sample.totalBytes
+		status.EstimatedTransferredBytes = sample.estimatedTransferredBytes
+		status.BytesPerSecond = sample.bytesPerSecond
+		status.SampledAt = sample.sampleTime.UTC().Format(time.RFC3339)
+	}
+	m.rebuildSamples = samples
+}
+
+// updatePurgeProgress fills in the estimated processed bytes, rate and sample time of the purging replicas. The size of
+// the data to be purged is estimated from the snapshots marked as removed.
+func (m *EngineMonitor) updatePurgeProgress(engine *longhorn.Engine, purgeStatus map[string]*longhorn.PurgeStatus, now time.Time) {
+	var totalBytes int64
+	for _, snapshot := range engine.Status.Snapshots {
+		if snapshot == nil || !snapshot.Removed {
+			continue
+		}
+		if size, err := strconv.ParseInt(snapshot.Size, 10, 64); err == nil {
+			totalBytes += size
+		}
+	}
+
+	samples := map[string]*progressSample{}
+	for addr, status := range purgeStatus {
+		if status == nil || !status.IsPurging {
+			continue
+		}
+		sample := sampleProgress(m.purgeSamples[addr], now, status.Progress, totalBytes)
+		samples[addr] = sample
+		status.TotalBytes = sample.totalBytes
+		status.EstimatedTransferredBytes = sample.estimatedTransferredBytes
+		status.BytesPerSecond = sample.bytesPerSecond
+		status.SampledAt = sample.sampleTime.UTC().Format(time.RFC3339)
+	}
+	m.purgeSamples = samples
+}
+
+// recordSnapshotPurge records the purge duration once none of the replicas is purging anymore.
+func (m *EngineMonitor) recordSnapshotPurge(existingPurgeStatus, purgeStatus map[string]*longhorn.PurgeStatus, dataEngine string) {
+	if !isSnapshotPurging(existingPurgeStatus) && isSnapshotPurging(purgeStatus) {
+		m.purgeStartTime = time.Now()
+		return
+	}
+	if m.purgeStartTime.IsZero() || isSnapshotPurging(purgeStatus) {
+		return
+	}
+	failed := false
+	for _, status := range purgeStatus {
+		if status.Error != "" {
+			failed = true
+			break
+		}
+	}
+	operation.ObserveDuration(operation.OperationPurge, dataEngine, operation.Outcome(failed), m.purgeStartTime)
+	m.purgeStartTime = time.Time{}
+}
+
+func isSnapshotPurging(purgeStatus map[string]*longhorn.PurgeStatus) bool {
+	for _, status := range purgeStatus {
+		if status.IsPurging {
+			return true
+		}
+	}
+	return false
+}
+
+// updateLowIOPSSince samples the IOPS of the engine every iopsSampleInterval if the volume tiering policy moves the
+// volume with low IOPS to the cold tier, and records since when the IOPS has stayed below the threshold of the policy.
+func (m *EngineMonitor) updateLowIOPSSince(engine *longhorn.Engine, engineClientProxy engineapi.EngineClientProxy, now time.Time) {
+	if now.Sub(m.iopsSampledAt) < iopsSampleInterval {
+		return
+	}
+	m.iopsSampledAt = now
+
+	volume, err := m.ds.GetVolumeRO(engine.Spec.VolumeName)
+	if err != nil {
+		m.logger.WithError(err).Warn("Failed to get volume for sampling IOPS")
+		return
+	}
+	if !types.IsVolumeTieringEnabled(volume) || volume.Spec.TieringPolicy.ColdBelowIOPS == 0 {
+		engine.Status.LowIOPSSince = ""
+		return
+	}
+
+	metrics, err := engineClientProxy.MetricsGet(engine)
+	if err != nil {
+		m.logger.WithError(err).Warn("Failed to get metrics for sampling IOPS")
+		return
+	}
+	engine.Status.LowIOPSSince = getLowIOPSSince(engine.Status.LowIOPSSince, metrics, volume.Spec.TieringPolicy.ColdBelowIOPS, now)
+}
+
+// getLowIOPSSince returns since when the sampled IOPS has stayed below the threshold, or an empty string if the IOPS is
+// not low.
+func getLowIOPSSince(lowIOPSSince string, metrics *engineapi.Metrics, threshold int64, now time.Time) string {
+	if metrics.ReadIOPS+metrics.WriteIOPS >= uint64(threshold) {
+		return ""
+	}
+	if lowIOPSSince == "" {
+		return now.UTC().Format(time.RFC3339)
+	}
+	return lowIOPSSince
+}
+
 func (m *EngineMonitor) isReachedConcurrentVolumeBackupRestoreLimit() (isUnderLimit bool, err error) {
 	limit, err := m.ds.GetSettingAsInt(types.SettingNameConcurrentBackupRestorePerNodeLimit)
 	if err != nil {
@@ -1915,11 +2147,14 @@ func (ec *EngineController) startRebuilding(e *longhorn.Engine, replicaName, add
 		}
 
 		// start rebuild
+		rebuildStartTime := time.Now()
 		if e.Spec.RequestedBackupRestore != "" {
 			if e.Spec.NodeID != "" {
 				ec.eventRecorder.Eventf(e, corev1.EventTypeNormal, constant.EventReasonRebuilding,
 					"Start rebuilding replica %v with Address %v for restore engine %v and volume %v", replicaName, addr, e.Name, e.Spec.VolumeName)
 				err = engineClientProxy.ReplicaAdd(e, replicaName, replicaURL, true, fastReplicaRebuild, localSync, fileSyncHTTPClientTimeout, 0)
+			} else {
+				rebuildStartTime = time.Time{}
 			}
 		} else {
 			ec.eventRecorder.Eventf(e, corev1.EventTypeNormal, constant.EventReasonRebuilding,
@@ -1932,6 +2167,12 @@ func (ec *EngineController) startRebuilding(e *longhorn.Engine, replicaName, add
 			err = ec.waitForV2EngineRebuild(e, replicaName, grpcTimeoutSeconds)
 		}
 
+		if !rebuildStartTime.IsZero() {
+			outcome := operation.Outcome(err != nil)
+			operation.ObserveDuration(operation.OperationRebuild, string(e.Spec.DataEngine), outcome, rebuildStartTime)
+			operation.ObserveRebuildEstimatedBytes(string(e.Spec.DataEngine), outcome, getEngineSnapshotsSize(e))
+		}
+
 		if err != nil {
 			replicaRebuildErrMsg := err.Error()
 
@@ -2004,6 +2245,23 @@ func (ec *EngineController) startRebuilding(e *longhorn.Engine, replicaName, add
 	return nil
 }
 
+// getEngineSnapshotsSize returns the total size of the snapshots and the volume head of the engine, which estimates
+// the size of the data to be rebuilt for a replica.
+func getEngineSnapshotsSize(e *longhorn.Engine) int64 {
+	var size int64
+	for _, snapshot := range e.Status.Snapshots {
+		if snapshot == nil {
+			continue
+		}
+		snapshotSize, err := strconv.ParseInt(snapshot.Size, 10, 64)
+		if err != nil {
+			continue
+		}
+		size += snapshotSize
+	}
+	return size
+}
+
 // getFileLocalSync retrieves details for local file sync between the target replica
 // and another eligible replica on the same node. It returns an object with the source
 // and target paths for the local sync, or nil if no other eligible replica is found.
@@ -2184,7 +2442,10 @@ func (ec *EngineController) Upgrade(e *longhorn.Engine, log *logrus.Entry) (err
 		// will cause live replica to be removed. Volume controller should filter those.
 		if version.ClientVersion.GitCommit != version.ServerVersion.GitCommit {
 			log.Infof("Upgrading engine from %v to %v", e.Status.CurrentImage, e.Spec.Image)
-			if err := ec.UpgradeEngineInstance(e, log); err != nil {
+			startTime := time.Now()
+			err := ec.UpgradeEngineInstance(e, log)
+			operation.ObserveDuration(operation.OperationUpgrade, string(e.Spec.DataEngine), operation.Outcome(err != nil), startTime)
+			if err != nil {
 				return err
 			}
 		}
@@ -153,7 +153,7 @@ func (ic *EngineImageController) processNextWorkItem() bool {
 	}
 	defer ic.queue.Done(key)
 
-	err := ic.syncEngineImage(key.(string))
+	err := ic.syncEngineImage(ic.newReconcileLogger(), key.(string))
 	ic.handleErr(err, key)
 
 	return true
@@ -186,7 +186,7 @@ func getLoggerForEngineImage(logger logrus.FieldLogger, ei *longhorn.EngineImage
 	)
 }
 
-func (ic *EngineImageController) syncEngineImage(key string) (err error) {
+func (ic *EngineImageController) syncEngineImage(logger logrus.FieldLogger, key string) (err error) {
 	defer func() {
 		err = errors.Wrapf(err, "failed to sync engine image for %v", key)
 	}()
@@ -205,7 +205,7 @@ func (ic *EngineImageController) syncEngineImage(key string) (err error) {
 		}
 		return errors.Wrapf(err, "failed to get engine image")
 	}
-	log := getLoggerForEngineImage(ic.logger, engineImage)
+	log := getLoggerForEngineImage(logger, engineImage)
 
 	// check isResponsibleFor here
 	isResponsible, err := ic.isResponsibleFor(engineImage)
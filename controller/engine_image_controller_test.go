@@ -267,7 +267,7 @@ func (s *TestSuite) TestEngineImage(c *C) {
 			c.Assert(err, IsNil)
 		}
 		engineImageControllerKey := fmt.Sprintf("%s/%s", TestNamespace, getTestEngineImageName())
-		err = ic.syncEngineImage(engineImageControllerKey)
+		err = ic.syncEngineImage(ic.newReconcileLogger(), engineImageControllerKey)
 		c.Assert(err, IsNil)
 
 		ei, err = lhClient.LonghornV1beta2().EngineImages(TestNamespace).Get(context.TODO(), getTestEngineImageName(), metav1.GetOptions{})
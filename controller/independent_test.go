@@ -1,10 +1,23 @@
 package controller
 
 import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	systembackupstore "github.com/longhorn/backupstore/systembackup"
+
+	"github.com/longhorn/longhorn-manager/engineapi"
+	"github.com/longhorn/longhorn-manager/metrics_collector/operation"
+	"github.com/longhorn/longhorn-manager/metrics_collector/registry"
+	"github.com/longhorn/longhorn-manager/types"
 	"github.com/longhorn/longhorn-manager/util"
 
 	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
@@ -185,3 +198,515 @@ func (s *TestSuite) TestBackingImageCleanup(c *C) {
 	BackingImageDiskFileCleanup(node, bi, bidsTemplate, time.Duration(0), 3)
 	c.Assert(bi.Spec.DiskFileSpecMap, DeepEquals, expectedBI.Spec.DiskFileSpecMap)
 }
+
+func (s *TestSuite) TestDiskCapacityForecast(c *C) {
+	const gib = int64(1 << 30)
+	start := time.Now().UTC().Truncate(time.Second)
+
+	var samples []longhorn.DiskUsageSample
+	var recorded bool
+	for i := 0; i < diskUsageMinSamples-1; i++ {
+		// The replica usage grows by 1 GiB every sample interval
+		samples, recorded = recordDiskUsageSample(samples, start.Add(time.Duration(i)*diskUsageSampleInterval), int64(i)*gib)
+		c.Assert(recorded, Equals, true)
+	}
+	_, ok := getDiskUsageGrowthRate(samples)
+	c.Assert(ok, Equals, false)
+
+	// A sample within the sample interval is ignored
+	samples, recorded = recordDiskUsageSample(samples, start.Add(time.Duration(diskUsageMinSamples-2)*diskUsageSampleInterval+time.Minute), 100*gib)
+	c.Assert(recorded, Equals, false)
+
+	samples, recorded = recordDiskUsageSample(samples, start.Add(time.Duration(diskUsageMinSamples-1)*diskUsageSampleInterval), int64(diskUsageMinSamples-1)*gib)
+	c.Assert(recorded, Equals, true)
+	growthRate, ok := getDiskUsageGrowthRate(samples)
+	c.Assert(ok, Equals, true)
+	samplesPerDay := float64(24 * time.Hour / diskUsageSampleInterval)
+	c.Assert(math.Abs(growthRate-samplesPerDay*float64(gib)) < 1, Equals, true)
+
+	// The samples kept in the status survive the controller restart
+	node := &longhorn.Node{Status: longhorn.NodeStatus{DiskStatus: map[string]*longhorn.DiskStatus{"disk": {UsageHistory: samples}}}}
+	restarted := node.DeepCopy()
+	restartedGrowthRate, ok := getDiskUsageGrowthRate(restarted.Status.DiskStatus["disk"].UsageHistory)
+	c.Assert(ok, Equals, true)
+	c.Assert(restartedGrowthRate, Equals, growthRate)
+
+	// Samples out of the history window are dropped
+	samples, recorded = recordDiskUsageSample(samples, start.Add(diskUsageHistoryWindow+diskUsageSampleInterval), 0)
+	c.Assert(recorded, Equals, true)
+	c.Assert(len(samples), Equals, diskUsageMinSamples)
+
+	// The remaining space excluding the reserved storage runs out in half a day
+	from := start
+	fullTime, ok := projectDiskFullTime(from, 60*gib, 10*gib, 100*float64(gib))
+	c.Assert(ok, Equals, true)
+	c.Assert(fullTime.Equal(from.Add(12*time.Hour)), Equals, true)
+
+	fullTime, ok = projectDiskFullTime(from, 10*gib, 20*gib, 100*float64(gib))
+	c.Assert(ok, Equals, true)
+	c.Assert(fullTime.Equal(from), Equals, true)
+
+	_, ok = projectDiskFullTime(from, 60*gib, 0, 0)
+	c.Assert(ok, Equals, false)
+	_, ok = projectDiskFullTime(from, 60*gib, 0, -float64(gib))
+	c.Assert(ok, Equals, false)
+}
+
+func (s *TestSuite) TestBackupTargetProbeWindow(c *C) {
+	window := newBackupTargetProbeWindow(4)
+	c.Assert(window.errorRate(), Equals, 0)
+	c.Assert(window.latencyPercentile(50), Equals, int64(0))
+
+	for _, latency := range []time.Duration{400, 100, 300, 200} {
+		window.add(backupTargetProbeResult{latency: latency * time.Millisecond})
+	}
+	c.Assert(window.errorRate(), Equals, 0)
+	c.Assert(window.latencyPercentile(50), Equals, int64(200))
+	c.Assert(window.latencyPercentile(95), Equals, int64(400))
+
+	// The oldest results are replaced and the failed probes don't count in the latency
+	window.add(backupTargetProbeResult{latency: time.Second, err: fmt.Errorf("failed")})
+	window.add(backupTargetProbeResult{latency: time.Second, err: fmt.Errorf("failed")})
+	c.Assert(len(window.results), Equals, 4)
+	c.Assert(window.errorRate(), Equals, 50)
+	c.Assert(window.latencyPercentile(50), Equals, int64(200))
+	c.Assert(window.latencyPercentile(99), Equals, int64(300))
+}
+
+func (s *TestSuite) TestClassifyBackupTargetError(c *C) {
+	testCases := map[string]struct {
+		err           error
+		expectedClass longhorn.BackupTargetErrorClass
+	}{
+		"no error": {
+			nil, longhorn.BackupTargetErrorClassNone,
+		},
+		"context deadline": {
+			fmt.Errorf("failed to list: %w", context.DeadlineExceeded), longhorn.BackupTargetErrorClassTimeout,
+		},
+		"s3 access denied": {
+			fmt.Errorf("AccessDenied: Access Denied\n\tstatus code: 403"), longhorn.BackupTargetErrorClassAuth,
+		},
+		"dns": {
+			fmt.Errorf("dial tcp: lookup minio.default on 10.43.0.10:53: no such host"), longhorn.BackupTargetErrorClassDNS,
+		},
+		"nfs mount timeout": {
+			fmt.Errorf("cannot mount nfs: mount.nfs: Connection timed out"), longhorn.BackupTargetErrorClassTimeout,
+		},
+		"s3 service unavailable": {
+			fmt.Errorf("ServiceUnavailable: Please reduce your request rate\n\tstatus code: 503"), longhorn.BackupTargetErrorClassServer,
+		},
+		"unknown": {
+			fmt.Errorf("unexpected end of JSON input"), longhorn.BackupTargetErrorClassUnknown,
+		},
+	}
+
+	for name, tc := range testCases {
+		fmt.Printf("testing %v\n", name)
+		c.Assert(classifyBackupTargetError(tc.err), Equals, tc.expectedClass, Commentf("%v", name))
+	}
+}
+
+// fakeProbeBackupStore keeps the objects put by the backup target prober in memory.
+type fakeProbeBackupStore struct {
+	objects map[string][]byte
+
+	volumeListErr error
+	putErr        error
+	listErr       error
+	getErr        error
+	deleteErr     error
+	unlisted      bool
+	corrupt       bool
+}
+
+func (f *fakeProbeBackupStore) BackupVolumeNameList() ([]string, error) {
+	if f.volumeListErr != nil {
+		return nil, f.volumeListErr
+	}
+	return []string{}, nil
+}
+
+func (f *fakeProbeBackupStore) UploadSystemBackup(name, localFile, longhornVersion, longhornGitCommit, managerImage, engineImage string) (string, error) {
+	if f.putErr != nil {
+		return "", f.putErr
+	}
+	key := longhornVersion + "/" + name
+	if _, ok := f.objects[key]; ok {
+		return "", fmt.Errorf("system backup %v already exists", key)
+	}
+	content, err := os.ReadFile(localFile)
+	if err != nil {
+		return "", err
+	}
+	f.objects[key] = content
+	return "", nil
+}
+
+func (f *fakeProbeBackupStore) DownloadSystemBackup(name, version, downloadPath string) error {
+	if f.getErr != nil {
+		return f.getErr
+	}
+	content, ok := f.objects[version+"/"+name]
+	if !ok {
+		return fmt.Errorf("cannot find system backup %v", name)
+	}
+	if f.corrupt {
+		content = []byte("corrupted")
+	}
+	return os.WriteFile(downloadPath, content, 0600)
+}
+
+func (f *fakeProbeBackupStore) GetSystemBackupConfig(name, version string) (*systembackupstore.Config, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (f *fakeProbeBackupStore) ListSystemBackup() (systembackupstore.SystemBackups, error) {
+	if f.listErr != nil {
+		return nil, f.listErr
+	}
+	systemBackups := systembackupstore.SystemBackups{}
+	if f.unlisted {
+		return systemBackups, nil
+	}
+	for key := range f.objects {
+		name := key[strings.Index(key, "/")+1:]
+		systemBackups[systembackupstore.Name(name)] = systembackupstore.URI(key)
+	}
+	return systemBackups, nil
+}
+
+func (f *fakeProbeBackupStore) DeleteSystemBackup(systemBackup *longhorn.SystemBackup) (string, error) {
+	if f.deleteErr != nil {
+		return "", f.deleteErr
+	}
+	delete(f.objects, systemBackup.Status.Version+"/"+systemBackup.Name)
+	return "", nil
+}
+
+func (s *TestSuite) TestNewBackupTargetProbeClient(c *C) {
+	testCases := map[string]struct {
+		url         string
+		expectedURL string
+	}{
+		"s3":   {url: "s3://backupbucket@us-east-1/", expectedURL: "s3://backupbucket@us-east-1/backupstore"},
+		"nfs":  {url: "nfs://longhorn-test-nfs-svc.default:/opt/backupstore", expectedURL: "nfs://longhorn-test-nfs-svc.default:/opt/backupstore/backupstore"},
+		"cifs": {url: "cifs://longhorn-test-cifs-svc.default/backupstore", expectedURL: "cifs://longhorn-test-cifs-svc.default/backupstore/backupstore"},
+	}
+
+	for name, tc := range testCases {
+		fmt.Printf("testing %v\n", name)
+		probeClient, err := newBackupTargetProbeClient(&engineapi.BackupTargetClient{URL: tc.url})
+		c.Assert(err, IsNil, Commentf("%v", name))
+		c.Assert(probeClient.URL, Equals, tc.expectedURL, Commentf("%v", name))
+	}
+}
+
+func (s *TestSuite) TestProbeBackupTarget(c *C) {
+	name := backupTargetProbeObjectPrefix + TestNode1
+
+	testCases := map[string]struct {
+		store *fakeProbeBackupStore
+
+		expectError    bool
+		expectReadOnly bool
+		expectObjects  int
+	}{
+		"round-trip": {
+			store: &fakeProbeBackupStore{objects: map[string][]byte{}},
+		},
+		"left over probe object": {
+			store: &fakeProbeBackupStore{objects: map[string][]byte{backupTargetProbeObjectVersion + "/" + name: []byte("previous")}},
+		},
+		"list backup volumes failure": {
+			store:       &fakeProbeBackupStore{objects: map[string][]byte{}, volumeListErr: fmt.Errorf("no such host")},
+			expectError: true,
+		},
+		"read-only backup target": {
+			store:          &fakeProbeBackupStore{objects: map[string][]byte{}, putErr: fmt.Errorf("AccessDenied")},
+			expectReadOnly: true,
+		},
+		"read-only file system": {
+			store:          &fakeProbeBackupStore{objects: map[string][]byte{}, putErr: fmt.Errorf("open /mnt/nfs/probe: read-only file system")},
+			expectReadOnly: true,
+		},
+		"put failure": {
+			store:       &fakeProbeBackupStore{objects: map[string][]byte{}, putErr: fmt.Errorf("SlowDown")},
+			expectError: true,
+		},
+		"list failure": {
+			store:       &fakeProbeBackupStore{objects: map[string][]byte{}, listErr: fmt.Errorf("timed out")},
+			expectError: true,
+		},
+		"probe object not listed": {
+			store:       &fakeProbeBackupStore{objects: map[string][]byte{}, unlisted: true},
+			expectError: true,
+		},
+		"get failure": {
+			store:       &fakeProbeBackupStore{objects: map[string][]byte{}, getErr: fmt.Errorf("timed out")},
+			expectError: true,
+		},
+		"corrupted object": {
+			store:       &fakeProbeBackupStore{objects: map[string][]byte{}, corrupt: true},
+			expectError: true,
+		},
+		"delete failure": {
+			store:         &fakeProbeBackupStore{objects: map[string][]byte{}, deleteErr: fmt.Errorf("permission denied")},
+			expectError:   true,
+			expectObjects: 1,
+		},
+	}
+
+	for testName, tc := range testCases {
+		fmt.Printf("testing %v\n", testName)
+		tempDir := c.MkDir()
+		readOnly, err := probeBackupTarget(tc.store, tc.store, name, tempDir)
+		if tc.expectError {
+			c.Assert(err, NotNil, Commentf("%v", testName))
+		} else {
+			c.Assert(err, IsNil, Commentf("%v", testName))
+		}
+		c.Assert(readOnly, Equals, tc.expectReadOnly, Commentf("%v", testName))
+		c.Assert(len(tc.store.objects), Equals, tc.expectObjects, Commentf("%v", testName))
+
+		// The local copies of the probe object are removed
+		files, err := os.ReadDir(tempDir)
+		c.Assert(err, IsNil)
+		c.Assert(len(files), Equals, 0, Commentf("%v", testName))
+	}
+}
+
+func (s *TestSuite) TestGetEngineSnapshotsSize(c *C) {
+	e := &longhorn.Engine{
+		Status: longhorn.EngineStatus{
+			Snapshots: map[string]*longhorn.SnapshotInfo{
+				"snap-1":      {Name: "snap-1", Size: "1073741824"},
+				"snap-2":      {Name: "snap-2", Size: "4096"},
+				"volume-head": {Name: "volume-head", Size: "0"},
+				"invalid":     {Name: "invalid", Size: ""},
+				"nil":         nil,
+			},
+		},
+	}
+	c.Assert(getEngineSnapshotsSize(e), Equals, int64(1073741824+4096))
+	c.Assert(getEngineSnapshotsSize(&longhorn.Engine{}), Equals, int64(0))
+}
+
+func (s *TestSuite) TestRecordSnapshotPurge(c *C) {
+	m := &EngineMonitor{}
+	purging := map[string]*longhorn.PurgeStatus{
+		"replica-1": {IsPurging: true},
+		"replica-2": {IsPurging: false},
+	}
+	done := map[string]*longhorn.PurgeStatus{
+		"replica-1": {IsPurging: false, State: "complete"},
+		"replica-2": {IsPurging: false, State: "complete"},
+	}
+
+	m.recordSnapshotPurge(nil, purging, string(longhorn.DataEngineTypeV1))
+	c.Assert(m.purgeStartTime.IsZero(), Equals, false)
+	startTime := m.purgeStartTime
+
+	m.recordSnapshotPurge(purging, purging, string(longhorn.DataEngineTypeV1))
+	c.Assert(m.purgeStartTime, Equals, startTime)
+
+	m.recordSnapshotPurge(purging, done, string(longhorn.DataEngineTypeV1))
+	c.Assert(m.purgeStartTime.IsZero(), Equals, true)
+}
+
+func (s *TestSuite) TestSampleProgress(c *C) {
+	startTime := time.Now()
+	totalBytes := int64(100 * 1000 * 1000 * 1000)
+
+	sample := sampleProgress(nil, startTime, 10, totalBytes)
+	c.Assert(sample.estimatedTransferredBytes, Equals, totalBytes/10)
+	c.Assert(sample.bytesPerSecond, Equals, int64(0))
+
+	// The sample doesn't change until the progress does
+	unchanged := sampleProgress(sample, startTime.Add(10*time.Second), 10, totalBytes*2)
+	c.Assert(unchanged, Equals, sample)
+
+	// The total bytes are kept from the first sample, and the rate is averaged since then
+	sample = sampleProgress(sample, startTime.Add(100*time.Second), 20, totalBytes*2)
+	c.Assert(sample.totalBytes, Equals, totalBytes)
+	c.Assert(sample.estimatedTransferredBytes, Equals, totalBytes/5)
+	c.Assert(sample.bytesPerSecond, Equals, totalBytes/10/100)
+	c.Assert(sample.sampleTime, Equals, startTime.Add(100*time.Second))
+
+	sample = sampleProgress(sample, startTime.Add(1000*time.Second), 100, totalBytes)
+	c.Assert(sample.estimatedTransferredBytes, Equals, totalBytes)
+	c.Assert(sample.sampleTime, Equals, startTime.Add(1000*time.Second))
+}
+
+func (s *TestSuite) TestUpdateRebuildProgress(c *C) {
+	m := &EngineMonitor{}
+	e := &longhorn.Engine{
+		Spec: longhorn.EngineSpec{InstanceSpec: longhorn.InstanceSpec{VolumeSize: 1000}},
+	}
+	now := time.Now()
+	rebuildStatus := map[string]*longhorn.RebuildStatus{
+		"tcp://10.0.0.1:10000": {IsRebuilding: true, Progress: 10},
+		"tcp://10.0.0.2:10000": {IsRebuilding: false, Progress: 100, State: "complete"},
+	}
+	m.updateRebuildProgress(e, rebuildStatus, now)
+	c.Assert(rebuildStatus["tcp://10.0.0.1:10000"].TotalBytes, Equals, int64(1000))
+	c.Assert(rebuildStatus["tcp://10.0.0.1:10000"].EstimatedTransferredBytes, Equals, int64(100))
+	c.Assert(rebuildStatus["tcp://10.0.0.1:10000"].BytesPerSecond, Equals, int64(0))
+	c.Assert(rebuildStatus["tcp://10.0.0.2:10000"].TotalBytes, Equals, int64(0))
+	c.Assert(len(m.rebuildSamples), Equals, 1)
+
+	rebuildStatus = map[string]*longhorn.RebuildStatus{
+		"tcp://10.0.0.1:10000": {IsRebuilding: true, Progress: 60},
+	}
+	m.updateRebuildProgress(e, rebuildStatus, now.Add(10*time.Second))
+	c.Assert(rebuildStatus["tcp://10.0.0.1:10000"].EstimatedTransferredBytes, Equals, int64(600))
+	c.Assert(rebuildStatus["tcp://10.0.0.1:10000"].BytesPerSecond, Equals, int64(50))
+	sampled := *rebuildStatus["tcp://10.0.0.1:10000"]
+
+	// The status doesn't change on the refreshes until the progress does, so that the engine isn't updated every time
+	rebuildStatus = map[string]*longhorn.RebuildStatus{
+		"tcp://10.0.0.1:10000": {IsRebuilding: true, Progress: 60},
+	}
+	m.updateRebuildProgress(e, rebuildStatus, now.Add(14*time.Second))
+	c.Assert(*rebuildStatus["tcp://10.0.0.1:10000"], DeepEquals, sampled)
+
+	m.updateRebuildProgress(e, map[string]*longhorn.RebuildStatus{}, now.Add(20*time.Second))
+	c.Assert(len(m.rebuildSamples), Equals, 0)
+}
+
+func (s *TestSuite) TestGetPendingReplacementDiskRebuildingCount(c *C) {
+	newRebuildingReplica := func(name string, replacement bool) *longhorn.Replica {
+		r := &longhorn.Replica{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: longhorn.ReplicaSpec{
+				RebuildRetryCount: 1,
+			},
+		}
+		if replacement {
+			r.Labels = map[string]string{
+				types.GetLonghornLabelKey(types.LonghornLabelReplacementDiskRebuild): types.LonghornLabelValueEnabled,
+			}
+		}
+		return r
+	}
+
+	rebuilt := newRebuildingReplica("rebuilt", true)
+	rebuilt.Spec.HealthyAt = getTestNow()
+	rebuilt.Spec.RebuildRetryCount = 0
+	replicas := map[string]*longhorn.Replica{
+		"regular":     newRebuildingReplica("regular", false),
+		"pending":     newRebuildingReplica("pending", true),
+		"in-progress": newRebuildingReplica("in-progress", true),
+		"rebuilt":     rebuilt,
+	}
+	inProgressRebuildingMap := map[string]struct{}{"in-progress": {}}
+
+	c.Assert(getPendingReplacementDiskRebuildingCount(replicas, inProgressRebuildingMap), Equals, 1)
+	c.Assert(getPendingReplacementDiskRebuildingCount(replicas, map[string]struct{}{}), Equals, 2)
+}
+
+func (s *TestSuite) TestGetLowIOPSSince(c *C) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	earlier := "2024-01-01T00:00:00Z"
+
+	type testCase struct {
+		lowIOPSSince string
+		metrics      *engineapi.Metrics
+
+		expected string
+	}
+	testCases := map[string]testCase{
+		"becomes low": {
+			metrics:  &engineapi.Metrics{ReadIOPS: 3, WriteIOPS: 4},
+			expected: "2024-01-02T03:04:05Z",
+		},
+		"stays low": {
+			lowIOPSSince: earlier,
+			metrics:      &engineapi.Metrics{ReadIOPS: 3, WriteIOPS: 4},
+			expected:     earlier,
+		},
+		"becomes high": {
+			lowIOPSSince: earlier,
+			metrics:      &engineapi.Metrics{ReadIOPS: 5, WriteIOPS: 5},
+			expected:     "",
+		},
+	}
+
+	for name, tc := range testCases {
+		fmt.Printf("testing %v\n", name)
+		c.Assert(getLowIOPSSince(tc.lowIOPSSince, tc.metrics, 10, now), Equals, tc.expected, Commentf("%v", name))
+	}
+}
+
+func (s *TestSuite) TestUpdateLowIOPSSinceSampleInterval(c *C) {
+	now := time.Now()
+	lowIOPSSince := now.Add(-time.Hour).UTC().Format(time.RFC3339)
+
+	// Neither the volume nor the metrics are read until the sample interval elapses
+	m := &EngineMonitor{iopsSampledAt: now}
+	e := &longhorn.Engine{Status: longhorn.EngineStatus{LowIOPSSince: lowIOPSSince}}
+	m.updateLowIOPSSince(e, nil, now.Add(iopsSampleInterval/2))
+	c.Assert(e.Status.LowIOPSSince, Equals, lowIOPSSince)
+	c.Assert(m.iopsSampledAt, Equals, now)
+}
+
+func (s *TestSuite) TestObserveSnapshotCreation(c *C) {
+	// A data engine label of this test only, to count its observations in the shared registry
+	dataEngine := "test-observe-snapshot-creation"
+	getCount := func(outcome string) string {
+		rw := httptest.NewRecorder()
+		registry.Handler().ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		prefix := fmt.Sprintf("longhorn_volume_operation_duration_seconds_count{data_engine=%q,operation=%q,outcome=%q} ",
+			dataEngine, operation.OperationSnapshot, outcome)
+		for _, line := range strings.Split(rw.Body.String(), "\n") {
+			if strings.HasPrefix(line, prefix) {
+				return strings.TrimPrefix(line, prefix)
+			}
+		}
+		return ""
+	}
+
+	sc := &SnapshotController{}
+	snapshot := &longhorn.Snapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: "snap-1", CreationTimestamp: metav1.Now()},
+	}
+
+	sc.observeSnapshotCreation(snapshot, dataEngine, operation.OutcomeFailure)
+	c.Assert(getCount(operation.OutcomeFailure), Equals, "1")
+
+	// The retries and the eventual success of a failed creation are not recorded again
+	sc.observeSnapshotCreation(snapshot, dataEngine, operation.OutcomeFailure)
+	sc.observeSnapshotCreation(snapshot, dataEngine, operation.OutcomeSuccess)
+	c.Assert(getCount(operation.OutcomeFailure), Equals, "1")
+	c.Assert(getCount(operation.OutcomeSuccess), Equals, "")
+
+	// A snapshot recreated with the same name is recorded again once the deleted one is forgotten
+	sc.observedSnapshotCreations.Delete(snapshot.Name)
+	sc.observeSnapshotCreation(snapshot, dataEngine, operation.OutcomeSuccess)
+	c.Assert(getCount(operation.OutcomeSuccess), Equals, "1")
+}
+
+func (s *TestSuite) TestValidateControllerComponentLogLevels(c *C) {
+	type testCase struct {
+		value   string
+		isError bool
+	}
+	testCases := map[string]testCase{
+		"controllers": {
+			value: "engine-controller=debug; volume-policy-controller=trace; kubernetes-endpoint-controller=info; uninstall-controller=warn",
+		},
+		"misspelled controller": {
+			value:   "engine-controlers=debug",
+			isError: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		err := types.ValidateComponentLogLevels(tc.value)
+		if tc.isError {
+			c.Assert(err, NotNil, Commentf("%v", name))
+			continue
+		}
+		c.Assert(err, IsNil, Commentf("%v", name))
+	}
+}
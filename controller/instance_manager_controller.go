@@ -279,7 +279,7 @@ func (imc *InstanceManagerController) processNextWorkItem() bool {
 	}
 	defer imc.queue.Done(key)
 
-	err := imc.syncInstanceManager(key.(string))
+	err := imc.syncInstanceManager(imc.newReconcileLogger(), key.(string))
 	imc.handleErr(err, key)
 
 	return true
@@ -304,15 +304,10 @@ func (imc *InstanceManagerController) handleErr(err error, key interface{}) {
 }
 
 func getLoggerForInstanceManager(logger logrus.FieldLogger, im *longhorn.InstanceManager) *logrus.Entry {
-	return logger.WithFields(
-		logrus.Fields{
-			"instanceManager": im.Name,
-			"nodeID":          im.Spec.NodeID,
-		},
-	)
+	return getLoggerForResource(logger, "", "", "", im.Spec.NodeID).WithField("instanceManager", im.Name)
 }
 
-func (imc *InstanceManagerController) syncInstanceManager(key string) (err error) {
+func (imc *InstanceManagerController) syncInstanceManager(logger logrus.FieldLogger, key string) (err error) {
 	defer func() {
 		err = errors.Wrapf(err, "failed to sync instance manager for %v", key)
 	}()
@@ -327,13 +322,13 @@ func (imc *InstanceManagerController) syncInstanceManager(key string) (err error
 	im, err := imc.ds.GetInstanceManager(name)
 	if err != nil {
 		if datastore.ErrorIsNotFound(err) {
-			imc.logger.Warnf("Deleting instance manager pod %v since the instance manager is not found", name)
+			logger.Warnf("Deleting instance manager pod %v since the instance manager is not found", name)
 			return imc.cleanupInstanceManagerPod(name)
 		}
 		return errors.Wrap(err, "failed to get instance manager")
 	}
 
-	log := getLoggerForInstanceManager(imc.logger, im)
+	log := getLoggerForInstanceManager(logger, im)
 
 	if !imc.isResponsibleFor(im) {
 		return nil
@@ -1828,6 +1823,13 @@ func (imc *InstanceManagerController) createInstanceManagerPodSpec(im *longhorn.
 			},
 		})
 	}
+	webIdentityTokenRequired, err := imc.ds.IsWebIdentityTokenRequired()
+	if err != nil {
+		return nil, err
+	}
+	if webIdentityTokenRequired {
+		types.AddWebIdentityTokenToPod(podSpec)
+	}
 	types.AddGoCoverDirToPod(podSpec)
 
 	return podSpec, nil
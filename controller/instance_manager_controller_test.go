@@ -291,7 +291,7 @@ func (s *TestSuite) TestSyncInstanceManager(c *C) {
 			c.Assert(err, IsNil)
 		}
 
-		err = imc.syncInstanceManager(getKey(im, c))
+		err = imc.syncInstanceManager(imc.newReconcileLogger(), getKey(im, c))
 		c.Assert(err, IsNil)
 		podList, err := kubeClient.CoreV1().Pods(im.Namespace).List(context.TODO(), metav1.ListOptions{})
 		c.Assert(err, IsNil)
@@ -122,7 +122,7 @@ func (kc *KubernetesConfigMapController) processNextWorkItem() bool {
 		return false
 	}
 	defer kc.queue.Done(key)
-	err := kc.syncHandler(key.(string))
+	err := kc.syncHandler(kc.newReconcileLogger(), key.(string))
 	kc.handleErr(err, key)
 	return true
 }
@@ -145,7 +145,7 @@ func (kc *KubernetesConfigMapController) handleErr(err error, key interface{}) {
 	utilruntime.HandleError(err)
 }
 
-func (kc *KubernetesConfigMapController) syncHandler(key string) (err error) {
+func (kc *KubernetesConfigMapController) syncHandler(logger logrus.FieldLogger, key string) (err error) {
 	defer func() {
 		err = errors.Wrapf(err, "failed to sync config map %v", key)
 	}()
@@ -155,14 +155,14 @@ func (kc *KubernetesConfigMapController) syncHandler(key string) (err error) {
 		return err
 	}
 
-	if err := kc.reconcile(namespace, cfmName); err != nil {
+	if err := kc.reconcile(logger, namespace, cfmName); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (kc *KubernetesConfigMapController) reconcile(namespace, cfmName string) error {
+func (kc *KubernetesConfigMapController) reconcile(logger logrus.FieldLogger, namespace, cfmName string) error {
 	if namespace != kc.namespace {
 		return nil
 	}
@@ -206,7 +206,7 @@ func (kc *KubernetesConfigMapController) reconcile(namespace, cfmName string) er
 			return err
 		}
 
-		kc.logger.Infof("Updated the default Longhorn StorageClass: %v", storageclass)
+		logger.Infof("Updated the default Longhorn StorageClass: %v", storageclass)
 	case types.DefaultDefaultSettingConfigMapName:
 		if err := kc.ds.UpdateCustomizedSettings(nil); err != nil {
 			return errors.Wrap(err, "failed to update built-in settings with customized values")
@@ -142,7 +142,7 @@ func (c *KubernetesEndpointController) processNextWorkItem() bool {
 	}
 	defer c.queue.Done(key)
 
-	err := c.sync(key.(string))
+	err := c.sync(c.newReconcileLogger(), key.(string))
 	c.handleErr(err, key)
 
 	return true
@@ -159,7 +159,7 @@ func (c *KubernetesEndpointController) handleErr(err error, key interface{}) {
 	c.queue.AddRateLimited(key)
 }
 
-func (c *KubernetesEndpointController) sync(key string) (err error) {
+func (c *KubernetesEndpointController) sync(logger logrus.FieldLogger, key string) (err error) {
 	defer func() {
 		err = errors.Wrapf(err, "failed to sync Kubernetes Endpoint %v", key)
 	}()
@@ -172,14 +172,14 @@ func (c *KubernetesEndpointController) sync(key string) (err error) {
 		return nil
 	}
 
-	return c.reconcile(name)
+	return c.reconcile(logger, name)
 }
 
 func getLoggerForKubernetesEndpoint(logger logrus.FieldLogger, endpoint *corev1.Endpoints) *logrus.Entry { // nolint: staticcheck
 	return logger.WithField("endpoint", endpoint.Name)
 }
 
-func (c *KubernetesEndpointController) reconcile(endpointName string) (err error) {
+func (c *KubernetesEndpointController) reconcile(logger logrus.FieldLogger, endpointName string) (err error) {
 	endpoint, err := c.ds.GetKubernetesEndpointRO(endpointName)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
@@ -189,7 +189,7 @@ func (c *KubernetesEndpointController) reconcile(endpointName string) (err error
 		return err
 	}
 
-	log := getLoggerForKubernetesEndpoint(c.logger, endpoint)
+	log := getLoggerForKubernetesEndpoint(logger, endpoint)
 
 	if endpoint.OwnerReferences == nil {
 		log.Debug("Aborting Kubernetes Endpoint reconcile due to missing owner references")
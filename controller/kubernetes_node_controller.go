@@ -145,7 +145,7 @@ func (knc *KubernetesNodeController) processNextWorkItem() bool {
 	}
 	defer knc.queue.Done(key)
 
-	err := knc.syncKubernetesNode(key.(string))
+	err := knc.syncKubernetesNode(knc.newReconcileLogger(), key.(string))
 	knc.handleErr(err, key)
 
 	return true
@@ -169,7 +169,7 @@ func (knc *KubernetesNodeController) handleErr(err error, key interface{}) {
 	knc.queue.Forget(key)
 }
 
-func (knc *KubernetesNodeController) syncKubernetesNode(key string) (err error) {
+func (knc *KubernetesNodeController) syncKubernetesNode(logger logrus.FieldLogger, key string) (err error) {
 	defer func() {
 		err = errors.Wrapf(err, "failed to sync node %v", key)
 	}()
@@ -183,11 +183,11 @@ func (knc *KubernetesNodeController) syncKubernetesNode(key string) (err error)
 		if !datastore.ErrorIsNotFound(err) {
 			return err
 		}
-		knc.logger.Warnf("Kubernetes node %v has been deleted", key)
+		logger.Warnf("Kubernetes node %v has been deleted", key)
 	}
 
 	if kubeNode == nil {
-		knc.logger.Infof("Cleaning up Longhorn node %v since failed to find the related kubernetes node", name)
+		logger.Infof("Cleaning up Longhorn node %v since failed to find the related kubernetes node", name)
 		if err := knc.ds.DeleteNode(name); err != nil {
 			return err
 		}
@@ -211,7 +211,7 @@ func (knc *KubernetesNodeController) syncKubernetesNode(key string) (err error)
 		}
 		// requeue if it's conflict
 		if apierrors.IsConflict(errors.Cause(err)) {
-			knc.logger.WithError(err).Debugf("Requeue %v due to conflict", key)
+			logger.WithError(err).Debugf("Requeue %v due to conflict", key)
 			knc.enqueueLonghornNode(node)
 			err = nil
 		}
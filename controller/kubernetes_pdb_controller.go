@@ -110,7 +110,7 @@ func (pc *KubernetesPDBController) processNextWorkItem() bool {
 		return false
 	}
 	defer pc.queue.Done(key)
-	err := pc.syncHandler(key.(string))
+	err := pc.syncHandler(pc.newReconcileLogger(), key.(string))
 	pc.handleErr(err, key)
 	return true
 }
@@ -126,7 +126,7 @@ func (pc *KubernetesPDBController) handleErr(err error, key interface{}) {
 	pc.queue.AddRateLimited(key)
 }
 
-func (pc *KubernetesPDBController) syncHandler(key string) (err error) {
+func (pc *KubernetesPDBController) syncHandler(logger logrus.FieldLogger, key string) (err error) {
 	defer func() {
 		err = errors.Wrapf(err, "failed to sync PDB %v", key)
 	}()
@@ -142,10 +142,10 @@ func (pc *KubernetesPDBController) syncHandler(key string) (err error) {
 	if _, ok := targetingDeployment[name]; !ok {
 		return nil
 	}
-	return pc.reconcile(name)
+	return pc.reconcile(logger, name)
 }
 
-func (pc *KubernetesPDBController) reconcile(name string) (err error) {
+func (pc *KubernetesPDBController) reconcile(logger logrus.FieldLogger, name string) (err error) {
 	pdbName := name
 	pdb, err := pc.ds.GetPDBRO(pdbName)
 	if err != nil && !datastore.ErrorIsNotFound(err) {
@@ -155,7 +155,7 @@ func (pc *KubernetesPDBController) reconcile(name string) (err error) {
 	deployment, err := pc.ds.GetDeployment(name)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			return deletePDBObject(pc.logger, pc.ds, pdb)
+			return deletePDBObject(logger, pc.ds, pdb)
 		}
 		return err
 	}
@@ -172,14 +172,14 @@ func (pc *KubernetesPDBController) reconcile(name string) (err error) {
 		}
 	}
 	if !hasInUsedVolume {
-		return deletePDBObject(pc.logger, pc.ds, pdb)
+		return deletePDBObject(logger, pc.ds, pdb)
 	}
 
 	if pdb != nil {
 		return nil
 	}
 
-	pc.logger.Infof("Creating PDB %v", pdbName)
+	logger.Infof("Creating PDB %v", pdbName)
 	pdb = generatePDBManifest(pdbName, pc.namespace, deployment.Spec.Selector)
 	if _, err := pc.ds.CreatePDB(pdb); err != nil && !apierrors.IsAlreadyExists(err) {
 		return err
@@ -116,7 +116,7 @@ func (kc *KubernetesPodController) processNextWorkItem() bool {
 		return false
 	}
 	defer kc.queue.Done(key)
-	err := kc.syncHandler(key.(string))
+	err := kc.syncHandler(kc.newReconcileLogger(), key.(string))
 	kc.handleErr(err, key)
 	return true
 }
@@ -140,13 +140,10 @@ func (kc *KubernetesPodController) handleErr(err error, key interface{}) {
 }
 
 func getLoggerForPod(logger logrus.FieldLogger, pod *corev1.Pod) *logrus.Entry {
-	return logger.WithFields(logrus.Fields{
-		"pod":  pod.Name,
-		"node": pod.Spec.NodeName,
-	})
+	return getLoggerForResource(logger, "", "", "", pod.Spec.NodeName).WithField("pod", pod.Name)
 }
 
-func (kc *KubernetesPodController) syncHandler(key string) (err error) {
+func (kc *KubernetesPodController) syncHandler(logger logrus.FieldLogger, key string) (err error) {
 	defer func() {
 		err = errors.Wrapf(err, "failed to sync pod %v", key)
 	}()
@@ -164,7 +161,7 @@ func (kc *KubernetesPodController) syncHandler(key string) (err error) {
 	}
 	nodeID := pod.Spec.NodeName
 	if nodeID == "" {
-		kc.logger.WithField("pod", pod.Name).Trace("skipping pod check since pod is not scheduled yet")
+		logger.WithField("pod", pod.Name).Trace("skipping pod check since pod is not scheduled yet")
 		return nil
 	}
 
@@ -262,8 +259,8 @@ func (kc *KubernetesPodController) handleWorkloadPodDeletionIfCSIPluginPodIsDown
 			return err
 		}
 
-		// Exclude non-RWX volumes.
-		if volume.Spec.AccessMode != longhorn.AccessModeReadWriteMany {
+		// Exclude volumes not served by a share manager.
+		if !types.IsSharedAccessMode(volume.Spec.AccessMode) {
 			_log.Debugf("%s. Volume access mode is %v", logSkip, volume.Spec.AccessMode)
 			continue
 		}
@@ -133,7 +133,7 @@ func (kc *KubernetesPVController) processNextWorkItem() bool {
 	}
 	defer kc.queue.Done(key)
 
-	err := kc.syncKubernetesStatus(key.(string))
+	err := kc.syncKubernetesStatus(kc.newReconcileLogger(), key.(string))
 	kc.handleErr(err, key)
 
 	return true
@@ -157,7 +157,7 @@ func (kc *KubernetesPVController) handleErr(err error, key interface{}) {
 	kc.queue.Forget(key)
 }
 
-func (kc *KubernetesPVController) syncKubernetesStatus(key string) (err error) {
+func (kc *KubernetesPVController) syncKubernetesStatus(logger logrus.FieldLogger, key string) (err error) {
 	defer func() {
 		err = errors.Wrapf(err, "failed to sync PV %v", key)
 	}()
@@ -207,7 +207,7 @@ func (kc *KubernetesPVController) syncKubernetesStatus(key string) (err error) {
 		}
 		// requeue if it's conflict
 		if apierrors.IsConflict(errors.Cause(err)) {
-			kc.logger.WithError(err).Debugf("Requeue for volume %v due to conflict", volumeName)
+			logger.WithError(err).Debugf("Requeue for volume %v due to conflict", volumeName)
 			kc.enqueueVolumeChange(volume)
 			err = nil
 		}
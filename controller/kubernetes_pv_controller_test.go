@@ -514,7 +514,7 @@ func (s *TestSuite) runKubernetesTestCases(c *C, testCases map[string]*Kubernete
 		}
 
 		if pv != nil {
-			err = kc.syncKubernetesStatus(getKey(pv, c))
+			err = kc.syncKubernetesStatus(kc.newReconcileLogger(), getKey(pv, c))
 			c.Assert(err, IsNil)
 		}
 
@@ -119,7 +119,7 @@ func (ks *KubernetesSecretController) processNextWorkItem() bool {
 		return false
 	}
 	defer ks.queue.Done(key)
-	err := ks.syncHandler(key.(string))
+	err := ks.syncHandler(ks.newReconcileLogger(), key.(string))
 	ks.handleErr(err, key)
 	return true
 }
@@ -142,7 +142,7 @@ func (ks *KubernetesSecretController) handleErr(err error, key interface{}) {
 	utilruntime.HandleError(err)
 }
 
-func (ks *KubernetesSecretController) syncHandler(key string) (err error) {
+func (ks *KubernetesSecretController) syncHandler(logger logrus.FieldLogger, key string) (err error) {
 	defer func() {
 		err = errors.Wrapf(err, "%v: failed to sync %v", ks.name, key)
 	}()
@@ -156,20 +156,20 @@ func (ks *KubernetesSecretController) syncHandler(key string) (err error) {
 		return nil
 	}
 
-	if err := ks.reconcileSecret(namespace, secretName); err != nil {
+	if err := ks.reconcileSecret(logger, namespace, secretName); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (ks *KubernetesSecretController) reconcileSecret(namespace, secretName string) error {
+func (ks *KubernetesSecretController) reconcileSecret(logger logrus.FieldLogger, namespace, secretName string) error {
 	// Get default backup target
 	backupTarget, err := ks.ds.GetBackupTargetRO(types.DefaultBackupTargetName)
 	if err != nil {
 		if !apierrors.IsNotFound(err) {
 			return err
 		}
-		ks.logger.Warnf("Failed to find the %s backup target", types.DefaultBackupTargetName)
+		logger.Warnf("Failed to find the %s backup target", types.DefaultBackupTargetName)
 		return nil
 	}
 
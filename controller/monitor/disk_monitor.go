@@ -281,7 +281,10 @@ func (m *DiskMonitor) collectDiskData(node *longhorn.Node) map[string]*Collected
 			diskDriver := disk.DiskDriver
 			if node.Status.DiskStatus != nil {
 				if diskStatus, ok := node.Status.DiskStatus[diskName]; ok {
-					diskUUID = diskStatus.DiskUUID
+					// A replacement disk must not inherit the identity of the disk it replaces.
+					if diskStatus.DiskUUID != disk.ReplacedDiskUUID {
+						diskUUID = diskStatus.DiskUUID
+					}
 					if diskStatus.DiskDriver != "" {
 						diskDriver = diskStatus.DiskDriver
 					}
@@ -149,10 +149,10 @@ func (m *EnvironmentCheckMonitor) collectEnvironmentCheckData(node *longhorn.Nod
 		}
 	}
 
-	return m.environmentCheck(kubeNode)
+	return m.environmentCheck(node.Namespace, kubeNode)
 }
 
-func (m *EnvironmentCheckMonitor) environmentCheck(kubeNode *corev1.Node) *CollectedEnvironmentCheckInfo {
+func (m *EnvironmentCheckMonitor) environmentCheck(namespace string, kubeNode *corev1.Node) *CollectedEnvironmentCheckInfo {
 	collectedData := &CollectedEnvironmentCheckInfo{
 		conditions: []longhorn.Condition{},
 	}
@@ -175,9 +175,85 @@ func (m *EnvironmentCheckMonitor) environmentCheck(kubeNode *corev1.Node) *Colle
 		m.checkHugePages(kubeNode, collectedData)
 	}
 
+	m.syncEnvironmentCheckPlugins(namespace, namespaces, isV2DataEngine, collectedData)
+
 	return collectedData
 }
 
+// syncEnvironmentCheckPlugins runs the declarative checks defined in the environment check ConfigMap. Each check
+// reports a node condition named after the check, carrying the remediation hint when the check does not pass.
+func (m *EnvironmentCheckMonitor) syncEnvironmentCheckPlugins(namespace string, namespaces []lhtypes.Namespace, isV2DataEngine bool, collectedData *CollectedEnvironmentCheckInfo) {
+	cm, err := m.ds.GetConfigMapRO(namespace, types.DefaultEnvironmentCheckConfigMapName)
+	if err != nil {
+		if !datastore.ErrorIsNotFound(err) {
+			m.logger.WithError(err).Warnf("Failed to get ConfigMap %v", types.DefaultEnvironmentCheckConfigMapName)
+		}
+		return
+	}
+
+	checks, invalidChecks, err := types.GetEnvironmentChecks(cm)
+	if err != nil {
+		m.logger.WithError(err).Warn("Failed to parse environment checks")
+		return
+	}
+	for _, err := range invalidChecks {
+		m.logger.WithError(err).Warn("Skipped invalid environment check")
+	}
+	if len(checks) == 0 {
+		return
+	}
+
+	isV1DataEngine, err := m.ds.GetSettingAsBool(types.SettingNameV1DataEngine)
+	if err != nil {
+		m.logger.WithError(err).Debug("Failed to fetch v1-data-engine setting")
+		isV1DataEngine = true
+	}
+
+	nsexec, err := lhns.NewNamespaceExecutor(lhtypes.ProcessNone, lhtypes.HostProcDirectory, namespaces)
+	if err != nil {
+		for _, check := range checks {
+			collectedData.conditions = types.SetCondition(collectedData.conditions, check.Name, longhorn.ConditionStatusFalse,
+				string(longhorn.NodeConditionReasonNamespaceExecutorErr),
+				fmt.Sprintf("Failed to get namespace executor: %v", err.Error()))
+		}
+		return
+	}
+
+	for _, check := range checks {
+		if (check.DataEngine == longhorn.DataEngineTypeV1 && !isV1DataEngine) ||
+			(check.DataEngine == longhorn.DataEngineTypeV2 && !isV2DataEngine) {
+			continue
+		}
+
+		output, err := nsexec.Execute(nil, check.Command[0], check.Command[1:], lhtypes.ExecuteDefaultTimeout)
+		output = strings.TrimSpace(output)
+
+		var failure string
+		switch {
+		case err != nil:
+			failure = fmt.Sprintf("Command %v failed: %v", check.Command, err)
+		case !check.IsOutputExpected(output):
+			failure = fmt.Sprintf("Command %v output %q does not match %q", check.Command, output, check.ExpectedOutput)
+		}
+
+		if failure == "" {
+			collectedData.conditions = types.SetCondition(collectedData.conditions, check.Name, longhorn.ConditionStatusTrue,
+				string(longhorn.NodeConditionReasonEnvironmentCheckPassed), "")
+			continue
+		}
+
+		if check.Remediation != "" {
+			failure = fmt.Sprintf("%v. Remediation: %v", failure, check.Remediation)
+		}
+		reason := longhorn.NodeConditionReasonEnvironmentCheckFailed
+		if check.Severity == types.EnvironmentCheckSeverityWarning {
+			reason = longhorn.NodeConditionReasonEnvironmentCheckWarning
+		}
+		collectedData.conditions = types.SetCondition(collectedData.conditions, check.Name, longhorn.ConditionStatusFalse,
+			string(reason), failure)
+	}
+}
+
 func (m *EnvironmentCheckMonitor) syncPackagesInstalled(kubeNode *corev1.Node, namespaces []lhtypes.Namespace, collectedData *CollectedEnvironmentCheckInfo) {
 	osImage := strings.ToLower(kubeNode.Status.NodeInfo.OSImage)
 
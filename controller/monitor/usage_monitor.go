@@ -0,0 +1,231 @@
+package monitor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/types"
+	"github.com/longhorn/longhorn-manager/util"
+)
+
+const (
+	UsageMonitorSyncPeriod = 5 * time.Minute
+)
+
+// UsageMonitor periodically samples the storage consumed by the volumes, and persists the averages over the day for
+// the usage accounting. Only one node takes the samples at a time.
+type UsageMonitor struct {
+	*baseMonitor
+
+	nodeName string
+	// isSampler returns true if the node is responsible for taking the samples
+	isSampler func() bool
+
+	collectedDataLock sync.RWMutex
+	collectedData     *types.DailyUsage
+}
+
+func NewUsageMonitor(logger logrus.FieldLogger, ds *datastore.DataStore, nodeName string, isSampler func() bool) (*UsageMonitor, error) {
+	ctx, quit := context.WithCancel(context.Background())
+
+	m := &UsageMonitor{
+		baseMonitor: newBaseMonitor(ctx, quit, logger, ds, UsageMonitorSyncPeriod),
+
+		nodeName:  nodeName,
+		isSampler: isSampler,
+
+		collectedDataLock: sync.RWMutex{},
+		collectedData:     &types.DailyUsage{},
+	}
+
+	go m.Start()
+
+	return m, nil
+}
+
+func (m *UsageMonitor) Start() {
+	if err := wait.PollUntilContextCancel(m.ctx, m.syncPeriod, false, func(context.Context) (bool, error) {
+		if err := m.run(); err != nil {
+			m.logger.WithError(err).Warn("Failed to sample the usage of the volumes")
+		}
+		return false, nil
+	}); err != nil {
+		if errors.Is(err, context.Canceled) {
+			m.logger.WithError(err).Warn("Usage monitor is stopped")
+		} else {
+			m.logger.WithError(err).Error("Failed to start usage monitor")
+		}
+	}
+}
+
+func (m *UsageMonitor) Stop() {
+	m.quit()
+}
+
+func (m *UsageMonitor) RunOnce() error {
+	return m.run()
+}
+
+func (m *UsageMonitor) UpdateConfiguration(map[string]interface{}) error {
+	return nil
+}
+
+func (m *UsageMonitor) GetCollectedData() (interface{}, error) {
+	m.collectedDataLock.RLock()
+	defer m.collectedDataLock.RUnlock()
+
+	return m.collectedData, nil
+}
+
+func (m *UsageMonitor) run() error {
+	if !m.isSampler() {
+		return nil
+	}
+
+	now := time.Now()
+	samples, err := m.sampleVolumeUsages()
+	if err != nil {
+		return err
+	}
+
+	// The samples complete the usage of the previous day up to its end, then start the usage of the day
+	previousDate := types.GetUsageDate(now.AddDate(0, 0, -1))
+	if _, err := m.addSamples(previousDate, samples, now, false); err != nil {
+		return err
+	}
+	usage, err := m.addSamples(types.GetUsageDate(now), samples, now, true)
+	if err != nil {
+		return err
+	}
+
+	func() {
+		m.collectedDataLock.Lock()
+		defer m.collectedDataLock.Unlock()
+		m.collectedData = usage
+	}()
+
+	return m.deleteExpiredDailyUsages(now)
+}
+
+// addSamples adds the samples to the daily usage of the date and persists it. The daily usage is created only if
+// create is true.
+func (m *UsageMonitor) addSamples(date string, samples map[string]*types.VolumeUsage, now time.Time, create bool) (*types.DailyUsage, error) {
+	usage, err := m.ds.GetDailyUsage(date)
+	if err != nil {
+		if !datastore.ErrorIsNotFound(err) {
+			return nil, errors.Wrapf(err, "failed to get the daily usage of %v", date)
+		}
+		if !create {
+			return nil, nil
+		}
+		usage = &types.DailyUsage{Date: date}
+	}
+
+	lastSampleTime := usage.LastSampleTime
+	if err := usage.AddSamples(samples, now); err != nil {
+		return nil, err
+	}
+	if usage.LastSampleTime == lastSampleTime {
+		// The day is already accounted up to its end
+		return usage, nil
+	}
+	usage.Sampler = m.nodeName
+
+	if err := m.ds.CreateOrUpdateDailyUsage(usage); err != nil {
+		return nil, errors.Wrapf(err, "failed to persist the daily usage of %v", date)
+	}
+	return usage, nil
+}
+
+// sampleVolumeUsages returns the storage consumed by the volumes now. The backups of the deleted volumes are
+// attributed by the Kubernetes status recorded when they were taken.
+func (m *UsageMonitor) sampleVolumeUsages() (map[string]*types.VolumeUsage, error) {
+	samples := map[string]*types.VolumeUsage{}
+
+	volumes, err := m.ds.ListVolumesRO()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list volumes")
+	}
+	for _, v := range volumes {
+		samples[v.Name] = &types.VolumeUsage{
+			Volume:           v.Name,
+			Namespace:        types.GetVolumePVCNamespace(v),
+			PVC:              v.Status.KubernetesStatus.PVCName,
+			Labels:           v.Labels,
+			NumberOfReplicas: v.Spec.NumberOfReplicas,
+			Size:             v.Spec.Size,
+			ActualSize:       v.Status.ActualSize * int64(v.Spec.NumberOfReplicas),
+			BackupSize:       map[string]int64{},
+		}
+	}
+
+	snapshots, err := m.ds.ListSnapshotsRO(labels.Everything())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list snapshots")
+	}
+	for _, snapshot := range snapshots {
+		if sample, ok := samples[snapshot.Spec.Volume]; ok {
+			sample.SnapshotSize += snapshot.Status.Size * int64(sample.NumberOfReplicas)
+		}
+	}
+
+	backups, err := m.ds.ListBackupsRO()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list backups")
+	}
+	for _, backup := range backups {
+		if backup.Status.VolumeName == "" || backup.Status.Size == "" {
+			continue
+		}
+		size, err := util.ConvertSize(backup.Status.Size)
+		if err != nil {
+			m.logger.WithError(err).Warnf("Failed to parse the size of backup %v", backup.Name)
+			continue
+		}
+
+		sample, ok := samples[backup.Status.VolumeName]
+		if !ok {
+			sample = &types.VolumeUsage{
+				Volume:     backup.Status.VolumeName,
+				BackupSize: map[string]int64{},
+			}
+			if kubeStatus := types.GetBackupKubernetesStatus(backup); kubeStatus != nil {
+				sample.Namespace = kubeStatus.Namespace
+				sample.PVC = kubeStatus.PVCName
+			}
+			samples[backup.Status.VolumeName] = sample
+		}
+		backupTargetName := backup.Labels[types.LonghornLabelBackupTarget]
+		if backupTargetName == "" {
+			backupTargetName = types.DefaultBackupTargetName
+		}
+		sample.BackupSize[backupTargetName] += size
+	}
+
+	return samples, nil
+}
+
+func (m *UsageMonitor) deleteExpiredDailyUsages(now time.Time) error {
+	usages, err := m.ds.ListDailyUsages()
+	if err != nil {
+		return errors.Wrap(err, "failed to list daily usages")
+	}
+	oldestDate := types.GetUsageDate(now.AddDate(0, 0, -types.UsageRetentionDays))
+	for _, usage := range usages {
+		if usage.Date >= oldestDate {
+			continue
+		}
+		if err := m.ds.DeleteDailyUsage(usage.Date); err != nil {
+			return errors.Wrapf(err, "failed to delete the daily usage of %v", usage.Date)
+		}
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package controller
 import (
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -196,7 +197,9 @@ func (nc *NodeController) isResponsibleForSetting(obj interface{}) bool {
 	return types.SettingName(setting.Name) == types.SettingNameStorageMinimalAvailablePercentage ||
 		types.SettingName(setting.Name) == types.SettingNameBackingImageCleanupWaitInterval ||
 		types.SettingName(setting.Name) == types.SettingNameOrphanResourceAutoDeletion ||
-		types.SettingName(setting.Name) == types.SettingNameNodeDrainPolicy
+		types.SettingName(setting.Name) == types.SettingNameNodeDrainPolicy ||
+		types.SettingName(setting.Name) == types.SettingNameStorageCapacityForecastDays ||
+		types.SettingName(setting.Name) == types.SettingNameTopologyLevels
 }
 
 func (nc *NodeController) isResponsibleForReplica(obj interface{}) bool {
@@ -311,7 +314,7 @@ func (nc *NodeController) processNextWorkItem() bool {
 	}
 	defer nc.queue.Done(key)
 
-	err := nc.syncNode(key.(string))
+	err := nc.syncNode(nc.newReconcileLogger(), key.(string))
 	nc.handleErr(err, key)
 
 	return true
@@ -336,10 +339,10 @@ func (nc *NodeController) handleErr(err error, key interface{}) {
 }
 
 func getLoggerForNode(logger logrus.FieldLogger, n *longhorn.Node) *logrus.Entry {
-	return logger.WithField("node", n.Name)
+	return getLoggerForResource(logger, "", "", "", n.Name)
 }
 
-func (nc *NodeController) syncNode(key string) (err error) {
+func (nc *NodeController) syncNode(logger logrus.FieldLogger, key string) (err error) {
 	defer func() {
 		err = errors.Wrapf(err, "failed to sync node for %v", key)
 	}()
@@ -355,13 +358,13 @@ func (nc *NodeController) syncNode(key string) (err error) {
 	node, err := nc.ds.GetNode(name)
 	if err != nil {
 		if datastore.ErrorIsNotFound(err) {
-			nc.logger.Errorf("Longhorn node %v has been deleted", key)
+			logger.Errorf("Longhorn node %v has been deleted", key)
 			return nil
 		}
 		return err
 	}
 
-	log := getLoggerForNode(nc.logger, node)
+	log := getLoggerForNode(logger, node)
 
 	if node.DeletionTimestamp != nil {
 		nc.eventRecorder.Eventf(node, corev1.EventTypeWarning, constant.EventReasonDelete, "Deleting node %v", node.Name)
@@ -370,6 +373,14 @@ func (nc *NodeController) syncNode(key string) (err error) {
 
 	existingNode := node.DeepCopy()
 	defer func() {
+		// The spec is changed only to clear the finished disk replacements
+		if err == nil && !reflect.DeepEqual(existingNode.Spec, node.Spec) {
+			var updatedNode *longhorn.Node
+			if updatedNode, err = nc.ds.UpdateNode(node); err == nil {
+				updatedNode.Status = node.Status
+				node = updatedNode
+			}
+		}
 		// we're going to update node assume things changes
 		if err == nil && !reflect.DeepEqual(existingNode.Status, node.Status) {
 			_, err = nc.ds.UpdateNodeStatus(node)
@@ -416,6 +427,12 @@ func (nc *NodeController) syncNode(key string) (err error) {
 
 	node.Status.Region, node.Status.Zone = types.GetRegionAndZone(kubeNode.Labels)
 
+	topologyLevels, err := nc.ds.GetSettingTopologyLevels()
+	if err != nil {
+		return err
+	}
+	node.Status.Topology = types.GetTopologyDomains(kubeNode.Labels, topologyLevels)
+
 	if nc.controllerID != node.Name {
 		return nil
 	}
@@ -458,6 +475,10 @@ func (nc *NodeController) syncNode(key string) (err error) {
 		nc.syncEnvironmentCheckConditions(node, collectedEnvironmentCheckConditions)
 	}
 
+	if err := nc.syncDiskCapacityForecast(node); err != nil {
+		return err
+	}
+
 	_, err = nc.createSnapshotMonitor()
 	if err != nil {
 		return errors.Wrap(err, "failed to create a snapshot monitor")
@@ -691,6 +712,114 @@ func (nc *NodeController) syncEnvironmentCheckConditions(node *longhorn.Node, co
 	if !isV2DataEngine {
 		node.Status.Conditions = types.RemoveCondition(node.Status.Conditions, longhorn.NodeConditionTypeHugePagesAvailable)
 	}
+
+	// Remove the conditions of the declarative environment checks that were deleted or skipped
+	collectedConditionTypes := map[string]bool{}
+	for _, condition := range conditions {
+		collectedConditionTypes[condition.Type] = true
+	}
+	for _, condition := range node.Status.Conditions {
+		if types.IsEnvironmentCheckCondition(condition) && !collectedConditionTypes[condition.Type] {
+			node.Status.Conditions = types.RemoveCondition(node.Status.Conditions, condition.Type)
+		}
+	}
+}
+
+// syncDiskCapacityForecast records the actual usage of the replicas on the ready disks in the disk status, projects
+// when each disk will become full at the recent growth rate of the replicas, and sets the CapacityAtRisk node
+// condition if any disk is projected to become full within the forecast days.
+func (nc *NodeController) syncDiskCapacityForecast(node *longhorn.Node) error {
+	forecastDays, err := nc.ds.GetSettingAsInt(types.SettingNameStorageCapacityForecastDays)
+	if err != nil {
+		return err
+	}
+
+	replicaUsages, err := nc.getDiskReplicaUsages(node)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	atRiskDisks := []string{}
+	for diskName, diskStatus := range node.Status.DiskStatus {
+		if diskStatus.DiskUUID == "" ||
+			types.GetCondition(diskStatus.Conditions, longhorn.DiskConditionTypeReady).Status != longhorn.ConditionStatusTrue {
+			continue
+		}
+
+		var recorded bool
+		diskStatus.UsageHistory, recorded = recordDiskUsageSample(diskStatus.UsageHistory, now, replicaUsages[diskStatus.DiskUUID])
+		if !recorded {
+			// Keep the current projection until the next sample
+			if types.IsDiskCapacityAtRisk(diskStatus, forecastDays, now) {
+				atRiskDisks = append(atRiskDisks, diskName)
+			}
+			continue
+		}
+
+		diskStatus.StorageGrowthRate = 0
+		diskStatus.ProjectedFullTime = ""
+		growthRate, ok := getDiskUsageGrowthRate(diskStatus.UsageHistory)
+		if !ok {
+			continue
+		}
+		diskStatus.StorageGrowthRate = int64(growthRate)
+
+		fullTime, ok := projectDiskFullTime(now, diskStatus.StorageAvailable, node.Spec.Disks[diskName].StorageReserved, growthRate)
+		if !ok {
+			continue
+		}
+		diskStatus.ProjectedFullTime = fullTime.UTC().Format(time.RFC3339)
+		if types.IsDiskCapacityAtRisk(diskStatus, forecastDays, now) {
+			atRiskDisks = append(atRiskDisks, diskName)
+		}
+	}
+
+	if len(atRiskDisks) > 0 {
+		sort.Strings(atRiskDisks)
+		node.Status.Conditions = types.SetConditionAndRecord(node.Status.Conditions,
+			longhorn.NodeConditionTypeCapacityAtRisk, longhorn.ConditionStatusTrue,
+			longhorn.NodeConditionReasonDiskProjectedFull,
+			fmt.Sprintf("Disks %v on node %v are projected to become full within %v days", atRiskDisks, node.Name, forecastDays),
+			nc.eventRecorder, node, corev1.EventTypeWarning)
+	} else {
+		node.Status.Conditions = types.SetCondition(node.Status.Conditions,
+			longhorn.NodeConditionTypeCapacityAtRisk, longhorn.ConditionStatusFalse, "", "")
+	}
+
+	return nil
+}
+
+// getDiskReplicaUsages returns the sum of the actual sizes of the replicas on each disk of the node, keyed by the disk
+// UUID. The actual size of a replica is the actual size of its volume.
+func (nc *NodeController) getDiskReplicaUsages(node *longhorn.Node) (map[string]int64, error) {
+	replicas, err := nc.ds.ListReplicasByNodeRO(node.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	usages := map[string]int64{}
+	volumes := map[string]*longhorn.Volume{}
+	for _, r := range replicas {
+		if r.Spec.DiskID == "" {
+			continue
+		}
+		v, ok := volumes[r.Spec.VolumeName]
+		if !ok {
+			v, err = nc.ds.GetVolumeRO(r.Spec.VolumeName)
+			if err != nil {
+				if !datastore.ErrorIsNotFound(err) {
+					return nil, err
+				}
+				v = nil
+			}
+			volumes[r.Spec.VolumeName] = v
+		}
+		if v != nil {
+			usages[r.Spec.DiskID] += v.Status.ActualSize
+		}
+	}
+	return usages, nil
 }
 
 func (nc *NodeController) findNotReadyAndReadyDiskMaps(node *longhorn.Node, collectedDataInfo map[string]*monitor.CollectedDiskInfo) (notReadyDiskInfoMap, readyDiskInfoMap map[string]map[string]*monitor.CollectedDiskInfo) {
@@ -727,7 +856,7 @@ func (nc *NodeController) findNotReadyAndReadyDiskMaps(node *longhorn.Node, coll
 			if diskInfo.DiskUUID == "" {
 				errorMessage = fmt.Sprintf("Disk %v(%v) on node %v is not ready: cannot find disk config file, maybe due to a mount error",
 					diskName, diskInfo.Path, node.Name)
-			} else if node.Status.DiskStatus[diskName].DiskUUID != diskInfo.DiskUUID {
+			} else if node.Status.DiskStatus[diskName].DiskUUID != diskInfo.DiskUUID && !isDiskReplacement(node, diskName) {
 				errorMessage = fmt.Sprintf("Disk %v(%v) on node %v is not ready: record diskUUID doesn't match the one on the disk ",
 					diskName, diskInfo.Path, node.Name)
 			}
@@ -765,6 +894,16 @@ func (nc *NodeController) findNotReadyAndReadyDiskMaps(node *longhorn.Node, coll
 				continue
 			}
 
+			if isDiskReplacement(node, diskName) && node.Status.DiskStatus[diskName].DiskUUID != diskInfo.DiskUUID {
+				nc.eventRecorder.Eventf(node, corev1.EventTypeNormal, constant.EventReasonDiskReplaced,
+					"Disk %v(%v) on node %v replaced disk UUID %v with %v",
+					diskName, diskInfo.Path, node.Name, node.Status.DiskStatus[diskName].DiskUUID, diskInfo.DiskUUID)
+			}
+
+			if node.Status.DiskStatus[diskName].DiskUUID != diskInfo.DiskUUID {
+				// The usage history of the replaced disk doesn't apply to the new disk
+				node.Status.DiskStatus[diskName].UsageHistory = nil
+			}
 			node.Status.DiskStatus[diskName].DiskUUID = diskInfo.DiskUUID
 			node.Status.DiskStatus[diskName].DiskDriver = diskInfo.DiskDriver
 			node.Status.DiskStatus[diskName].DiskName = diskInfo.DiskName
@@ -777,6 +916,16 @@ func (nc *NodeController) findNotReadyAndReadyDiskMaps(node *longhorn.Node, coll
 	return notReadyDiskInfoMap, readyDiskInfoMap
 }
 
+// isDiskReplacement returns true if the disk is requested to replace the failed disk currently recorded in the status,
+// so a different disk UUID collected from the disk is expected rather than a filesystem change.
+func isDiskReplacement(node *longhorn.Node, diskName string) bool {
+	diskStatus, ok := node.Status.DiskStatus[diskName]
+	if !ok || diskStatus.DiskUUID == "" {
+		return false
+	}
+	return node.Spec.Disks[diskName].ReplacedDiskUUID == diskStatus.DiskUUID
+}
+
 func (nc *NodeController) updateNotReadyDiskStatusReadyCondition(node *longhorn.Node, diskInfoMap map[string]*monitor.CollectedDiskInfo) {
 	for diskName, info := range diskInfoMap {
 		if info.Condition == nil {
@@ -872,6 +1021,18 @@ func (nc *NodeController) updateDiskStatusSchedulableCondition(node *longhorn.No
 				}
 			}
 
+			if disk.ReplacedDiskUUID != "" && disk.ReplacedDiskUUID != diskStatus.DiskUUID {
+				moved, err := nc.moveReplicasToReplacementDisk(node, diskName, disk, diskStatus)
+				if err != nil {
+					return err
+				}
+				if moved {
+					log.Infof("Finished replacing disk %v(%v) with %v", diskName, disk.ReplacedDiskUUID, diskStatus.DiskUUID)
+					disk.ReplacedDiskUUID = ""
+					node.Spec.Disks[diskName] = disk
+				}
+			}
+
 			// sync replicas as well as calculate storage scheduled
 			replicas, err := nc.ds.ListReplicasByDiskUUID(diskStatus.DiskUUID)
 			if err != nil {
@@ -932,6 +1093,76 @@ func (nc *NodeController) updateDiskStatusSchedulableCondition(node *longhorn.No
 	return nil
 }
 
+// moveReplicasToReplacementDisk points the replicas left on a replaced disk to its replacement. The replicas are marked
+// as failed without any data, so the volume controller prefers reusing them over scheduling new ones and rebuilds them
+// in place on the replacement disk, ahead of the other rebuildings on the node. Replicas of volumes without any other
+// copy of the data are left untouched until the volume has one, or the replicas are removed. It returns true once all
+// the replicas are moved, so the replacement is done.
+func (nc *NodeController) moveReplicasToReplacementDisk(node *longhorn.Node, diskName string, disk longhorn.DiskSpec, diskStatus *longhorn.DiskStatus) (bool, error) {
+	log := getLoggerForNode(nc.logger, node).WithFields(logrus.Fields{
+		"disk":             diskName,
+		"diskUUID":         diskStatus.DiskUUID,
+		"replacedDiskUUID": disk.ReplacedDiskUUID,
+	})
+
+	replicas, err := nc.ds.ListReplicasByDiskUUID(disk.ReplacedDiskUUID)
+	if err != nil {
+		return false, err
+	}
+
+	moved := true
+	for _, replica := range replicas {
+		if replica.Spec.NodeID != node.Name {
+			continue
+		}
+
+		volumeReplicas, err := nc.ds.ListVolumeReplicasRO(replica.Spec.VolumeName)
+		if err != nil {
+			return false, err
+		}
+		if !hasReplicaDataOutsideDisk(volumeReplicas, disk.ReplacedDiskUUID) {
+			// Keep the replaced disk until the replica is moved or removed, otherwise it's left on a disk that is gone
+			log.Warnf("Skipped moving replica %v to the replacement disk since volume %v has no other replica with data, will retry once it has one",
+				replica.Name, replica.Spec.VolumeName)
+			moved = false
+			continue
+		}
+
+		replica.Spec.DiskID = diskStatus.DiskUUID
+		replica.Spec.DiskPath = disk.Path
+		replica.Spec.HealthyAt = ""
+		replica.Spec.LastHealthyAt = ""
+		replica.Spec.RebuildRetryCount = 0
+		setReplicaFailedAt(replica, util.Now())
+		if replica.Labels == nil {
+			replica.Labels = map[string]string{}
+		}
+		replica.Labels[types.GetLonghornLabelKey(types.LonghornLabelReplacementDiskRebuild)] = types.LonghornLabelValueEnabled
+		if _, err := nc.ds.UpdateReplica(replica); err != nil {
+			log.WithError(err).Warnf("Failed to move replica %v to the replacement disk, will enqueue then resync node", replica.Name)
+			nc.enqueueNode(node)
+			moved = false
+			continue
+		}
+		log.Infof("Moved replica %v of volume %v to the replacement disk for rebuilding", replica.Name, replica.Spec.VolumeName)
+	}
+
+	return moved, nil
+}
+
+// hasReplicaDataOutsideDisk returns true if any replica that has ever been healthy resides outside the given disk.
+func hasReplicaDataOutsideDisk(replicas map[string]*longhorn.Replica, diskUUID string) bool {
+	for _, r := range replicas {
+		if r.Spec.DiskID == diskUUID {
+			continue
+		}
+		if r.Spec.HealthyAt != "" && r.Spec.FailedAt == "" {
+			return true
+		}
+	}
+	return false
+}
+
 func (nc *NodeController) syncNodeStatus(pod *corev1.Pod, node *longhorn.Node) error {
 	// sync bidirectional mount propagation for node status to check whether the node could deploy CSI driver
 	var mgrContainer *corev1.Container
@@ -1762,8 +1993,9 @@ func (nc *NodeController) syncReplicaEvictionRequested(node *longhorn.Node, kube
 				}
 			}
 
-			if replica.Spec.EvictionRequested && !node.Spec.EvictionRequested && !diskSpec.EvictionRequested {
-				// We don't consider the node to be auto evicting if eviction was manually requested.
+			if replica.Spec.EvictionRequested && !node.Spec.EvictionRequested && !diskSpec.EvictionRequested &&
+				reason != constant.EventReasonEvictionStorageTiering {
+				// We don't consider the node to be auto evicting if eviction was manually requested or is for storage tiering.
 				node.Status.AutoEvicting = true
 			}
 		}
@@ -1809,6 +2041,11 @@ func (nc *NodeController) shouldEvictReplica(node *longhorn.Node, kubeNode *core
 	if node.Spec.EvictionRequested || diskSpec.EvictionRequested {
 		return true, constant.EventReasonEvictionUserRequested, nil
 	}
+	if shouldMigrate, err := nc.shouldMigrateReplicaForStorageTiering(diskSpec, replica); err != nil {
+		return false, "", err
+	} else if shouldMigrate {
+		return true, constant.EventReasonEvictionStorageTiering, nil
+	}
 	if !kubeNode.Spec.Unschedulable {
 		// Node drain policy only takes effect on cordoned nodes.
 		return false, constant.EventReasonEvictionCanceled, nil
@@ -1838,6 +2075,44 @@ func (nc *NodeController) shouldEvictReplica(node *longhorn.Node, kubeNode *core
 	return false, constant.EventReasonEvictionCanceled, nil
 }
 
+// shouldMigrateReplicaForStorageTiering returns true if the replica is on a disk outside the current storage tier of its
+// volume. The replicas of a volume are migrated one at a time and only while no replica is degraded, so the volume keeps
+// its redundancy during the migration.
+func (nc *NodeController) shouldMigrateReplicaForStorageTiering(diskSpec *longhorn.DiskSpec, replica *longhorn.Replica) (bool, error) {
+	volume, err := nc.ds.GetVolumeRO(replica.Spec.VolumeName)
+	if err != nil {
+		if datastore.ErrorIsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if !types.IsVolumeTieringEnabled(volume) || volume.Status.StorageTier == longhorn.StorageTierNone {
+		return false, nil
+	}
+	if types.IsSelectorsInTags(diskSpec.Tags, types.GetVolumeDiskSelector(volume), true) {
+		return false, nil
+	}
+	if replica.Spec.EvictionRequested {
+		// Keep the ongoing migration
+		return true, nil
+	}
+	if volume.Status.Robustness == longhorn.VolumeRobustnessDegraded || volume.Status.Robustness == longhorn.VolumeRobustnessFaulted {
+		return false, nil
+	}
+
+	replicas, err := nc.ds.ListVolumeReplicasRO(volume.Name)
+	if err != nil {
+		return false, err
+	}
+	for _, r := range replicas {
+		if r.Name != replica.Name && r.Spec.EvictionRequested {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
 func isNodeOrDisksEvictionRequested(node *longhorn.Node) bool {
 	if node.Spec.EvictionRequested {
 		return true
@@ -187,6 +187,7 @@ func (s *NodeControllerSuite) TestManagerPodUp(c *C) {
 				Conditions: []longhorn.Condition{
 					newNodeCondition(longhorn.NodeConditionTypeSchedulable, longhorn.ConditionStatusTrue, ""),
 					newNodeCondition(longhorn.NodeConditionTypeReady, longhorn.ConditionStatusTrue, ""),
+					newNodeCondition(longhorn.NodeConditionTypeCapacityAtRisk, longhorn.ConditionStatusFalse, ""),
 					newNodeCondition(longhorn.NodeConditionTypeMountPropagation, longhorn.ConditionStatusTrue, ""),
 				},
 			},
@@ -212,7 +213,7 @@ func (s *NodeControllerSuite) TestManagerPodUp(c *C) {
 			c.Assert(err, IsNil)
 		}
 
-		err = s.controller.syncNode(getKey(node, c))
+		err = s.controller.syncNode(s.controller.newReconcileLogger(), getKey(node, c))
 		c.Assert(err, IsNil)
 
 		n, err := s.lhClient.LonghornV1beta2().Nodes(TestNamespace).Get(context.TODO(), node.Name, metav1.GetOptions{})
@@ -273,6 +274,7 @@ func (s *NodeControllerSuite) TestManagerPodDown(c *C) {
 				Conditions: []longhorn.Condition{
 					newNodeCondition(longhorn.NodeConditionTypeSchedulable, longhorn.ConditionStatusTrue, ""),
 					newNodeCondition(longhorn.NodeConditionTypeReady, longhorn.ConditionStatusFalse, longhorn.NodeConditionReasonManagerPodDown),
+					newNodeCondition(longhorn.NodeConditionTypeCapacityAtRisk, longhorn.ConditionStatusFalse, ""),
 					newNodeCondition(longhorn.NodeConditionTypeMountPropagation, longhorn.ConditionStatusFalse, longhorn.NodeConditionReasonNoMountPropagationSupport),
 				},
 			},
@@ -298,7 +300,7 @@ func (s *NodeControllerSuite) TestManagerPodDown(c *C) {
 			c.Assert(err, IsNil)
 		}
 
-		err = s.controller.syncNode(getKey(node, c))
+		err = s.controller.syncNode(s.controller.newReconcileLogger(), getKey(node, c))
 		c.Assert(err, IsNil)
 
 		n, err := s.lhClient.LonghornV1beta2().Nodes(TestNamespace).Get(context.TODO(), node.Name, metav1.GetOptions{})
@@ -359,6 +361,7 @@ func (s *NodeControllerSuite) TestKubeNodeDown(c *C) {
 				Conditions: []longhorn.Condition{
 					newNodeCondition(longhorn.NodeConditionTypeSchedulable, longhorn.ConditionStatusTrue, ""),
 					newNodeCondition(longhorn.NodeConditionTypeReady, longhorn.ConditionStatusFalse, longhorn.NodeConditionReasonKubernetesNodeNotReady),
+					newNodeCondition(longhorn.NodeConditionTypeCapacityAtRisk, longhorn.ConditionStatusFalse, ""),
 					newNodeCondition(longhorn.NodeConditionTypeMountPropagation, longhorn.ConditionStatusTrue, ""),
 				},
 			},
@@ -384,7 +387,7 @@ func (s *NodeControllerSuite) TestKubeNodeDown(c *C) {
 			c.Assert(err, IsNil)
 		}
 
-		err = s.controller.syncNode(getKey(node, c))
+		err = s.controller.syncNode(s.controller.newReconcileLogger(), getKey(node, c))
 		c.Assert(err, IsNil)
 
 		n, err := s.lhClient.LonghornV1beta2().Nodes(TestNamespace).Get(context.TODO(), node.Name, metav1.GetOptions{})
@@ -445,6 +448,7 @@ func (s *NodeControllerSuite) TestKubeNodePressure(c *C) {
 				Conditions: []longhorn.Condition{
 					newNodeCondition(longhorn.NodeConditionTypeSchedulable, longhorn.ConditionStatusTrue, ""),
 					newNodeCondition(longhorn.NodeConditionTypeReady, longhorn.ConditionStatusFalse, longhorn.NodeConditionReasonKubernetesNodePressure),
+					newNodeCondition(longhorn.NodeConditionTypeCapacityAtRisk, longhorn.ConditionStatusFalse, ""),
 					newNodeCondition(longhorn.NodeConditionTypeMountPropagation, longhorn.ConditionStatusTrue, ""),
 				},
 			},
@@ -470,7 +474,7 @@ func (s *NodeControllerSuite) TestKubeNodePressure(c *C) {
 			c.Assert(err, IsNil)
 		}
 
-		err = s.controller.syncNode(getKey(node, c))
+		err = s.controller.syncNode(s.controller.newReconcileLogger(), getKey(node, c))
 		c.Assert(err, IsNil)
 
 		n, err := s.lhClient.LonghornV1beta2().Nodes(TestNamespace).Get(context.TODO(), node.Name, metav1.GetOptions{})
@@ -566,6 +570,7 @@ func (s *NodeControllerSuite) TestUpdateDiskStatus(c *C) {
 				Conditions: []longhorn.Condition{
 					newNodeCondition(longhorn.NodeConditionTypeSchedulable, longhorn.ConditionStatusTrue, ""),
 					newNodeCondition(longhorn.NodeConditionTypeReady, longhorn.ConditionStatusTrue, ""),
+					newNodeCondition(longhorn.NodeConditionTypeCapacityAtRisk, longhorn.ConditionStatusFalse, ""),
 					newNodeCondition(longhorn.NodeConditionTypeMountPropagation, longhorn.ConditionStatusTrue, ""),
 				},
 				DiskStatus: map[string]*longhorn.DiskStatus{
@@ -624,7 +629,7 @@ func (s *NodeControllerSuite) TestUpdateDiskStatus(c *C) {
 			c.Assert(err, IsNil)
 		}
 
-		err = s.controller.syncNode(getKey(node, c))
+		err = s.controller.syncNode(s.controller.newReconcileLogger(), getKey(node, c))
 		c.Assert(err, IsNil)
 
 		n, err := s.lhClient.LonghornV1beta2().Nodes(TestNamespace).Get(context.TODO(), node.Name, metav1.GetOptions{})
@@ -717,6 +722,7 @@ func (s *NodeControllerSuite) TestCleanDiskStatus(c *C) {
 				Conditions: []longhorn.Condition{
 					newNodeCondition(longhorn.NodeConditionTypeSchedulable, longhorn.ConditionStatusTrue, ""),
 					newNodeCondition(longhorn.NodeConditionTypeReady, longhorn.ConditionStatusTrue, ""),
+					newNodeCondition(longhorn.NodeConditionTypeCapacityAtRisk, longhorn.ConditionStatusFalse, ""),
 					newNodeCondition(longhorn.NodeConditionTypeMountPropagation, longhorn.ConditionStatusTrue, ""),
 				},
 				DiskStatus: map[string]*longhorn.DiskStatus{
@@ -771,7 +777,7 @@ func (s *NodeControllerSuite) TestCleanDiskStatus(c *C) {
 			c.Assert(err, IsNil)
 		}
 
-		err = s.controller.syncNode(getKey(node, c))
+		err = s.controller.syncNode(s.controller.newReconcileLogger(), getKey(node, c))
 		c.Assert(err, IsNil)
 
 		n, err := s.lhClient.LonghornV1beta2().Nodes(TestNamespace).Get(context.TODO(), node.Name, metav1.GetOptions{})
@@ -874,6 +880,7 @@ func (s *NodeControllerSuite) TestDisableDiskOnFilesystemChange(c *C) {
 				Conditions: []longhorn.Condition{
 					newNodeCondition(longhorn.NodeConditionTypeSchedulable, longhorn.ConditionStatusTrue, ""),
 					newNodeCondition(longhorn.NodeConditionTypeReady, longhorn.ConditionStatusTrue, ""),
+					newNodeCondition(longhorn.NodeConditionTypeCapacityAtRisk, longhorn.ConditionStatusFalse, ""),
 					newNodeCondition(longhorn.NodeConditionTypeMountPropagation, longhorn.ConditionStatusTrue, ""),
 				},
 				DiskStatus: map[string]*longhorn.DiskStatus{
@@ -928,7 +935,7 @@ func (s *NodeControllerSuite) TestDisableDiskOnFilesystemChange(c *C) {
 			c.Assert(err, IsNil)
 		}
 
-		err = s.controller.syncNode(getKey(node, c))
+		err = s.controller.syncNode(s.controller.newReconcileLogger(), getKey(node, c))
 		c.Assert(err, IsNil)
 
 		n, err := s.lhClient.LonghornV1beta2().Nodes(TestNamespace).Get(context.TODO(), node.Name, metav1.GetOptions{})
@@ -941,6 +948,157 @@ func (s *NodeControllerSuite) TestDisableDiskOnFilesystemChange(c *C) {
 	s.checkOrphans(c, expectation)
 }
 
+// newReplaceDiskFixture returns the fixture of a node whose disk replaces the failed disk of the UUID, with the
+// replicas.
+func newReplaceDiskFixture(failedDiskUUID string, replicas ...*longhorn.Replica) *NodeControllerFixture {
+	node1 := newNode(TestNode1, TestNamespace, true, longhorn.ConditionStatusUnknown, "")
+	node1.Spec.Disks = map[string]longhorn.DiskSpec{
+		TestDiskID1: {
+			Type:             longhorn.DiskTypeFilesystem,
+			Path:             TestDefaultDataPath,
+			DiskDriver:       longhorn.DiskDriverNone,
+			AllowScheduling:  true,
+			StorageReserved:  0,
+			ReplacedDiskUUID: failedDiskUUID,
+		},
+	}
+	node1.Status.DiskStatus = map[string]*longhorn.DiskStatus{
+		TestDiskID1: {
+			Conditions: []longhorn.Condition{
+				newNodeCondition(longhorn.DiskConditionTypeSchedulable, longhorn.ConditionStatusFalse, string(longhorn.DiskConditionReasonDiskNotReady)),
+				newNodeCondition(longhorn.DiskConditionTypeReady, longhorn.ConditionStatusFalse, string(longhorn.DiskConditionReasonNoDiskInfo)),
+			},
+			DiskName:            TestDiskID1,
+			DiskUUID:            failedDiskUUID,
+			Type:                longhorn.DiskTypeFilesystem,
+			FSType:              TestDiskPathFSType,
+			DiskPath:            "/var/lib/failed-disk",
+			InstanceManagerName: TestInstanceManagerName,
+		},
+	}
+
+	return &NodeControllerFixture{
+		lhNodes: map[string]*longhorn.Node{
+			TestNode1: node1,
+		},
+		lhReplicas: replicas,
+		lhSettings: map[string]*longhorn.Setting{
+			string(types.SettingNameDefaultInstanceManagerImage): newDefaultInstanceManagerImageSetting(),
+		},
+		lhInstanceManagers: map[string]*longhorn.InstanceManager{
+			TestInstanceManagerName: DefaultInstanceManagerTestNode1,
+		},
+		lhOrphans: map[string]*longhorn.Orphan{
+			DefaultOrphanTestNode1.Name: DefaultOrphanTestNode1,
+		},
+		pods: map[string]*corev1.Pod{
+			TestDaemon1: newDaemonPod(corev1.PodRunning, TestDaemon1, TestNamespace, TestNode1, TestIP1, &MountPropagationBidirectional),
+		},
+		nodes: map[string]*corev1.Node{
+			TestNode1: newKubernetesNode(
+				TestNode1,
+				corev1.ConditionTrue,
+				corev1.ConditionFalse,
+				corev1.ConditionFalse,
+				corev1.ConditionFalse,
+				corev1.ConditionFalse,
+				corev1.ConditionTrue,
+			),
+		},
+	}
+}
+
+func (s *NodeControllerSuite) TestReplaceDisk(c *C) {
+	var err error
+
+	failedDiskUUID := "failed-uuid"
+
+	vol := newVolume(TestVolumeName, 2)
+	eng := newEngineForVolume(vol)
+
+	failedReplica := newReplicaForVolume(vol, eng, TestNode1, failedDiskUUID)
+	failedReplica.Spec.HealthyAt = getTestNow()
+	failedReplica.Spec.LastHealthyAt = getTestNow()
+	failedReplica.Spec.FailedAt = getTestNow()
+	healthyReplica := newReplicaForVolume(vol, eng, TestNode2, TestDiskID2)
+	healthyReplica.Spec.HealthyAt = getTestNow()
+
+	fixture := newReplaceDiskFixture(failedDiskUUID, failedReplica, healthyReplica)
+	node1 := fixture.lhNodes[TestNode1]
+
+	s.initTest(c, fixture)
+
+	err = s.controller.diskMonitor.RunOnce()
+	c.Assert(err, IsNil)
+	err = s.controller.environmentCheckMonitor.RunOnce()
+	c.Assert(err, IsNil)
+
+	err = s.controller.syncNode(s.controller.newReconcileLogger(), getKey(node1, c))
+	c.Assert(err, IsNil)
+
+	// The replacement disk adopts the identity collected from the new disk instead of being marked as changed
+	n, err := s.lhClient.LonghornV1beta2().Nodes(TestNamespace).Get(context.TODO(), node1.Name, metav1.GetOptions{})
+	c.Assert(err, IsNil)
+	diskStatus := n.Status.DiskStatus[TestDiskID1]
+	c.Assert(diskStatus.DiskUUID, Equals, TestDiskID1)
+	c.Assert(types.GetCondition(diskStatus.Conditions, longhorn.DiskConditionTypeReady).Status, Equals, longhorn.ConditionStatusTrue)
+	// The replacement is done once the replicas are moved
+	c.Assert(n.Spec.Disks[TestDiskID1].ReplacedDiskUUID, Equals, "")
+
+	// The replica on the failed disk is moved to the replacement disk without any data
+	r, err := s.lhClient.LonghornV1beta2().Replicas(TestNamespace).Get(context.TODO(), failedReplica.Name, metav1.GetOptions{})
+	c.Assert(err, IsNil)
+	c.Assert(r.Spec.NodeID, Equals, TestNode1)
+	c.Assert(r.Spec.DiskID, Equals, TestDiskID1)
+	c.Assert(r.Spec.DiskPath, Equals, TestDefaultDataPath)
+	c.Assert(r.Spec.HealthyAt, Equals, "")
+	c.Assert(r.Spec.LastHealthyAt, Equals, "")
+	c.Assert(r.Spec.FailedAt, Not(Equals), "")
+	c.Assert(datastore.IsReplacementDiskRebuildReplica(r), Equals, true)
+
+	r, err = s.lhClient.LonghornV1beta2().Replicas(TestNamespace).Get(context.TODO(), healthyReplica.Name, metav1.GetOptions{})
+	c.Assert(err, IsNil)
+	c.Assert(r.Spec.DiskID, Equals, TestDiskID2)
+}
+
+func (s *NodeControllerSuite) TestReplaceDiskWithoutOtherReplicaData(c *C) {
+	var err error
+
+	failedDiskUUID := "failed-uuid"
+
+	vol := newVolume(TestVolumeName, 1)
+	eng := newEngineForVolume(vol)
+
+	failedReplica := newReplicaForVolume(vol, eng, TestNode1, failedDiskUUID)
+	failedReplica.Spec.HealthyAt = getTestNow()
+	failedReplica.Spec.LastHealthyAt = getTestNow()
+	failedReplica.Spec.FailedAt = getTestNow()
+
+	fixture := newReplaceDiskFixture(failedDiskUUID, failedReplica)
+	node1 := fixture.lhNodes[TestNode1]
+
+	s.initTest(c, fixture)
+
+	err = s.controller.diskMonitor.RunOnce()
+	c.Assert(err, IsNil)
+	err = s.controller.environmentCheckMonitor.RunOnce()
+	c.Assert(err, IsNil)
+
+	err = s.controller.syncNode(s.controller.newReconcileLogger(), getKey(node1, c))
+	c.Assert(err, IsNil)
+
+	// The replaced disk is kept, so that the replica without any other copy of its data isn't orphaned
+	n, err := s.lhClient.LonghornV1beta2().Nodes(TestNamespace).Get(context.TODO(), node1.Name, metav1.GetOptions{})
+	c.Assert(err, IsNil)
+	c.Assert(n.Spec.Disks[TestDiskID1].ReplacedDiskUUID, Equals, failedDiskUUID)
+
+	r, err := s.lhClient.LonghornV1beta2().Replicas(TestNamespace).Get(context.TODO(), failedReplica.Name, metav1.GetOptions{})
+	c.Assert(err, IsNil)
+	c.Assert(r.Spec.DiskID, Equals, failedDiskUUID)
+	c.Assert(r.Spec.HealthyAt, Equals, getTestNow())
+	c.Assert(datastore.IsReplacementDiskRebuildReplica(r), Equals, false)
+}
+
 func (s *NodeControllerSuite) TestCreateDefaultInstanceManager(c *C) {
 	var err error
 
@@ -1002,6 +1160,7 @@ func (s *NodeControllerSuite) TestCreateDefaultInstanceManager(c *C) {
 				Conditions: []longhorn.Condition{
 					newNodeCondition(longhorn.NodeConditionTypeSchedulable, longhorn.ConditionStatusTrue, ""),
 					newNodeCondition(longhorn.NodeConditionTypeReady, longhorn.ConditionStatusTrue, ""),
+					newNodeCondition(longhorn.NodeConditionTypeCapacityAtRisk, longhorn.ConditionStatusFalse, ""),
 					newNodeCondition(longhorn.NodeConditionTypeMountPropagation, longhorn.ConditionStatusTrue, ""),
 				},
 				DiskStatus: map[string]*longhorn.DiskStatus{
@@ -1050,7 +1209,7 @@ func (s *NodeControllerSuite) TestCreateDefaultInstanceManager(c *C) {
 			c.Assert(err, IsNil)
 		}
 
-		err = s.controller.syncNode(getKey(node, c))
+		err = s.controller.syncNode(s.controller.newReconcileLogger(), getKey(node, c))
 		c.Assert(err, IsNil)
 
 		n, err := s.lhClient.LonghornV1beta2().Nodes(TestNamespace).Get(context.TODO(), node.Name, metav1.GetOptions{})
@@ -1147,6 +1306,7 @@ func (s *NodeControllerSuite) TestCleanupRedundantInstanceManagers(c *C) {
 				Conditions: []longhorn.Condition{
 					newNodeCondition(longhorn.NodeConditionTypeSchedulable, longhorn.ConditionStatusTrue, ""),
 					newNodeCondition(longhorn.NodeConditionTypeReady, longhorn.ConditionStatusTrue, ""),
+					newNodeCondition(longhorn.NodeConditionTypeCapacityAtRisk, longhorn.ConditionStatusFalse, ""),
 					newNodeCondition(longhorn.NodeConditionTypeMountPropagation, longhorn.ConditionStatusTrue, ""),
 				},
 				DiskStatus: map[string]*longhorn.DiskStatus{
@@ -1196,7 +1356,7 @@ func (s *NodeControllerSuite) TestCleanupRedundantInstanceManagers(c *C) {
 			c.Assert(err, IsNil)
 		}
 
-		err = s.controller.syncNode(getKey(node, c))
+		err = s.controller.syncNode(s.controller.newReconcileLogger(), getKey(node, c))
 		c.Assert(err, IsNil)
 
 		n, err := s.lhClient.LonghornV1beta2().Nodes(TestNamespace).Get(context.TODO(), node.Name, metav1.GetOptions{})
@@ -1262,6 +1422,7 @@ func (s *NodeControllerSuite) TestCleanupAllInstanceManagers(c *C) {
 				Conditions: []longhorn.Condition{
 					newNodeCondition(longhorn.NodeConditionTypeSchedulable, longhorn.ConditionStatusTrue, ""),
 					newNodeCondition(longhorn.NodeConditionTypeReady, longhorn.ConditionStatusTrue, ""),
+					newNodeCondition(longhorn.NodeConditionTypeCapacityAtRisk, longhorn.ConditionStatusFalse, ""),
 					newNodeCondition(longhorn.NodeConditionTypeMountPropagation, longhorn.ConditionStatusTrue, ""),
 				},
 				DiskStatus: map[string]*longhorn.DiskStatus{},
@@ -1290,7 +1451,7 @@ func (s *NodeControllerSuite) TestCleanupAllInstanceManagers(c *C) {
 			c.Assert(err, IsNil)
 		}
 
-		err = s.controller.syncNode(getKey(node, c))
+		err = s.controller.syncNode(s.controller.newReconcileLogger(), getKey(node, c))
 		c.Assert(err, IsNil)
 
 		n, err := s.lhClient.LonghornV1beta2().Nodes(TestNamespace).Get(context.TODO(), node.Name, metav1.GetOptions{})
@@ -1384,7 +1545,7 @@ func (s *NodeControllerSuite) TestEventOnNotReady(c *C) {
 			c.Assert(err, IsNil)
 		}
 
-		err = s.controller.syncNode(getKey(node, c))
+		err = s.controller.syncNode(s.controller.newReconcileLogger(), getKey(node, c))
 		c.Assert(err, IsNil)
 	}
 
@@ -1473,7 +1634,7 @@ func (s *NodeControllerSuite) TestEventOnDiskPressure(c *C) {
 			c.Assert(err, IsNil)
 		}
 
-		err = s.controller.syncNode(getKey(node, c))
+		err = s.controller.syncNode(s.controller.newReconcileLogger(), getKey(node, c))
 		c.Assert(err, IsNil)
 	}
 
@@ -1562,7 +1723,7 @@ func (s *NodeControllerSuite) TestEventOnMemoryPressure(c *C) {
 			c.Assert(err, IsNil)
 		}
 
-		err = s.controller.syncNode(getKey(node, c))
+		err = s.controller.syncNode(s.controller.newReconcileLogger(), getKey(node, c))
 		c.Assert(err, IsNil)
 	}
 
@@ -1651,7 +1812,7 @@ func (s *NodeControllerSuite) TestEventOnPidPressure(c *C) {
 			c.Assert(err, IsNil)
 		}
 
-		err = s.controller.syncNode(getKey(node, c))
+		err = s.controller.syncNode(s.controller.newReconcileLogger(), getKey(node, c))
 		c.Assert(err, IsNil)
 	}
 
@@ -1740,7 +1901,7 @@ func (s *NodeControllerSuite) TestEventOnNetworkPressure(c *C) {
 			c.Assert(err, IsNil)
 		}
 
-		err = s.controller.syncNode(getKey(node, c))
+		err = s.controller.syncNode(s.controller.newReconcileLogger(), getKey(node, c))
 		c.Assert(err, IsNil)
 	}
 
@@ -1835,7 +1996,7 @@ func (s *NodeControllerSuite) TestNoEventOnUnknownTrueNodeCondition(c *C) {
 			c.Assert(err, IsNil)
 		}
 
-		err = s.controller.syncNode(getKey(node, c))
+		err = s.controller.syncNode(s.controller.newReconcileLogger(), getKey(node, c))
 		c.Assert(err, IsNil)
 	}
 
@@ -2124,6 +2285,7 @@ CONFIG_NFS_V4_2=y`
 						Conditions: []longhorn.Condition{
 							newNodeCondition(longhorn.NodeConditionTypeSchedulable, longhorn.ConditionStatusTrue, ""),
 							newNodeCondition(longhorn.NodeConditionTypeReady, longhorn.ConditionStatusTrue, ""),
+							newNodeCondition(longhorn.NodeConditionTypeCapacityAtRisk, longhorn.ConditionStatusFalse, ""),
 							newNodeCondition(longhorn.NodeConditionTypeMountPropagation, longhorn.ConditionStatusTrue, ""),
 						},
 					},
@@ -2144,7 +2306,7 @@ CONFIG_NFS_V4_2=y`
 					c.Assert(err, IsNil)
 				}
 
-				err = s.controller.syncNode(getKey(node, c))
+				err = s.controller.syncNode(s.controller.newReconcileLogger(), getKey(node, c))
 				c.Assert(err, IsNil)
 
 				n, err := s.lhClient.LonghornV1beta2().Nodes(TestNamespace).Get(context.TODO(), node.Name, metav1.GetOptions{})
@@ -2172,7 +2334,8 @@ func (s *NodeControllerSuite) checkNodeConditions(c *C, expectation *NodeControl
 
 func (s *NodeControllerSuite) checkDiskConditions(c *C, expectation *NodeControllerExpectation, node *longhorn.Node) {
 	// Check that all disk status conditions match the expected disk status
-	// conditions - save for the last transition timestamp and the actual message
+	// conditions - save for the last transition timestamp and the actual message,
+	// and the usage samples recorded at the sync time
 	for fsid, diskStatus := range node.Status.DiskStatus {
 		for idx, condition := range diskStatus.Conditions {
 			if condition.Status != longhorn.ConditionStatusUnknown {
@@ -2182,6 +2345,10 @@ func (s *NodeControllerSuite) checkDiskConditions(c *C, expectation *NodeControl
 			condition.Message = ""
 			diskStatus.Conditions[idx] = condition
 		}
+		for _, sample := range diskStatus.UsageHistory {
+			c.Assert(sample.Timestamp.IsZero(), Equals, false)
+		}
+		diskStatus.UsageHistory = nil
 		node.Status.DiskStatus[fsid] = diskStatus
 	}
 	c.Assert(node.Status.DiskStatus, DeepEquals, expectation.nodeStatus[node.Name].DiskStatus)
@@ -196,7 +196,7 @@ func (oc *OrphanController) processNextWorkItem() bool {
 		return false
 	}
 	defer oc.queue.Done(key)
-	err := oc.syncOrphan(key.(string))
+	err := oc.syncOrphan(oc.newReconcileLogger(), key.(string))
 	oc.handleErr(err, key)
 	return true
 }
@@ -219,7 +219,7 @@ func (oc *OrphanController) handleErr(err error, key interface{}) {
 	oc.queue.Forget(key)
 }
 
-func (oc *OrphanController) syncOrphan(key string) (err error) {
+func (oc *OrphanController) syncOrphan(logger logrus.FieldLogger, key string) (err error) {
 	defer func() {
 		err = errors.Wrapf(err, "failed to sync orphan %v", key)
 	}()
@@ -231,10 +231,10 @@ func (oc *OrphanController) syncOrphan(key string) (err error) {
 	if namespace != oc.namespace {
 		return nil
 	}
-	return oc.reconcile(name)
+	return oc.reconcile(logger, name)
 }
 
-func (oc *OrphanController) reconcile(orphanName string) (err error) {
+func (oc *OrphanController) reconcile(logger logrus.FieldLogger, orphanName string) (err error) {
 	orphan, err := oc.ds.GetOrphan(orphanName)
 	if err != nil {
 		if !datastore.ErrorIsNotFound(err) {
@@ -243,7 +243,7 @@ func (oc *OrphanController) reconcile(orphanName string) (err error) {
 		return nil
 	}
 
-	log := getLoggerForOrphan(oc.logger, orphan)
+	log := getLoggerForOrphan(logger, orphan)
 
 	if !oc.isResponsibleFor(orphan) {
 		return nil
@@ -307,7 +307,7 @@ func (oc *OrphanController) reconcile(orphanName string) (err error) {
 }
 
 func getLoggerForOrphan(logger logrus.FieldLogger, orphan *longhorn.Orphan) *logrus.Entry {
-	return logger.WithFields(
+	return getLoggerForResource(logger, "", "", "", orphan.Spec.NodeID).WithFields(
 		logrus.Fields{
 			"orphan": orphan.Name,
 		},
@@ -129,7 +129,7 @@ func (c *RecurringJobController) processNextWorkItem() bool {
 	}
 	defer c.queue.Done(key)
 
-	err := c.syncRecurringJob(key.(string))
+	err := c.syncRecurringJob(c.newReconcileLogger(), key.(string))
 	c.handleErr(err, key)
 
 	return true
@@ -161,7 +161,7 @@ func getLoggerForRecurringJob(logger logrus.FieldLogger, recurringJob *longhorn.
 	)
 }
 
-func (c *RecurringJobController) syncRecurringJob(key string) (err error) {
+func (c *RecurringJobController) syncRecurringJob(logger logrus.FieldLogger, key string) (err error) {
 	defer func() {
 		err = errors.Wrapf(err, "failed to sync recurring job %v", key)
 	}()
@@ -175,7 +175,7 @@ func (c *RecurringJobController) syncRecurringJob(key string) (err error) {
 
 	recurringJob, err := c.ds.GetRecurringJob(name)
 	if err != nil {
-		log := c.logger.WithField("recurringJob", name)
+		log := logger.WithField("recurringJob", name)
 		if !datastore.ErrorIsNotFound(err) {
 			return errors.Wrap(err, "failed to retrieve recurring job from datastore")
 		}
@@ -202,7 +202,7 @@ func (c *RecurringJobController) syncRecurringJob(key string) (err error) {
 		return nil
 	}
 
-	log := getLoggerForRecurringJob(c.logger, recurringJob)
+	log := getLoggerForRecurringJob(logger, recurringJob)
 
 	if !c.isResponsibleFor(recurringJob) {
 		return nil
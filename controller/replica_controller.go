@@ -14,6 +14,9 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/sirupsen/logrus"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/cache"
@@ -32,7 +35,9 @@ import (
 
 	"github.com/longhorn/longhorn-manager/datastore"
 	"github.com/longhorn/longhorn-manager/engineapi"
+	"github.com/longhorn/longhorn-manager/tracing"
 	"github.com/longhorn/longhorn-manager/types"
+	"github.com/longhorn/longhorn-manager/util"
 
 	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
 )
@@ -172,7 +177,7 @@ func (rc *ReplicaController) processNextWorkItem() bool {
 	}
 	defer rc.queue.Done(key)
 
-	err := rc.syncReplica(key.(string))
+	err := rc.syncReplica(rc.newReconcileLogger(), key.(string))
 	rc.handleErr(err, key)
 
 	return true
@@ -197,16 +202,10 @@ func (rc *ReplicaController) handleErr(err error, key interface{}) {
 }
 
 func getLoggerForReplica(logger logrus.FieldLogger, r *longhorn.Replica) *logrus.Entry {
-	return logger.WithFields(
-		logrus.Fields{
-			"replica": r.Name,
-			"nodeID":  r.Spec.NodeID,
-			"ownerID": r.Status.OwnerID,
-		},
-	)
+	return getLoggerForResource(logger, r.Spec.VolumeName, "", r.Name, r.Spec.NodeID).WithField("ownerID", r.Status.OwnerID)
 }
 
-func (rc *ReplicaController) syncReplica(key string) (err error) {
+func (rc *ReplicaController) syncReplica(logger logrus.FieldLogger, key string) (err error) {
 	defer func() {
 		err = errors.Wrapf(err, "failed to sync replica for %v", key)
 	}()
@@ -228,7 +227,7 @@ func (rc *ReplicaController) syncReplica(key string) (err error) {
 	}
 	dataPath := types.GetReplicaDataPath(replica.Spec.DiskPath, replica.Spec.DataDirectoryName)
 
-	log := getLoggerForReplica(rc.logger, replica)
+	log := getLoggerForReplica(logger, replica)
 
 	isResponsible, err := rc.isResponsibleFor(replica)
 	if err != nil {
@@ -298,7 +297,50 @@ func (rc *ReplicaController) syncReplica(key string) (err error) {
 		}
 	}()
 
-	return rc.instanceHandler.ReconcileInstanceState(replica, &replica.Spec.InstanceSpec, &replica.Status.InstanceStatus)
+	if err := rc.instanceHandler.ReconcileInstanceState(replica, &replica.Spec.InstanceSpec, &replica.Status.InstanceStatus); err != nil {
+		return err
+	}
+
+	rc.inspectSalvageMetadata(replica)
+
+	return nil
+}
+
+// inspectSalvageMetadata records the metadata of the data of a failed v1 replica once the replica is stopped, so that
+// the failed replicas of a faulted volume can be compared before a salvage. The metadata is inspected once per failure
+// and cleared when the replica is no longer failed.
+func (rc *ReplicaController) inspectSalvageMetadata(r *longhorn.Replica) {
+	if r.Spec.FailedAt == "" {
+		r.Status.SalvageMetadata = nil
+		return
+	}
+	if !types.IsDataEngineV1(r.Spec.DataEngine) || r.Spec.NodeID != rc.controllerID ||
+		r.Status.CurrentState != longhorn.InstanceStateStopped {
+		return
+	}
+	if r.Status.SalvageMetadata != nil && r.Status.SalvageMetadata.FailedAt == r.Spec.FailedAt {
+		return
+	}
+
+	metadata := &longhorn.ReplicaSalvageMetadata{
+		FailedAt:    r.Spec.FailedAt,
+		InspectedAt: util.Now(),
+	}
+	dataPath := types.GetReplicaDataPath(r.Spec.DiskPath, r.Spec.DataDirectoryName)
+	data, err := util.GetReplicaDataMetadata(dataPath, r.Spec.RevisionCounterDisabled)
+	if err != nil {
+		getLoggerForReplica(rc.logger, r).WithError(err).Warn("Failed to inspect the data of the failed replica")
+		metadata.Error = err.Error()
+	} else {
+		metadata.RevisionCounter = data.RevisionCounter
+		metadata.HeadName = data.HeadName
+		metadata.HeadSize = data.HeadSize
+		metadata.HeadLastModifiedAt = data.HeadLastModifiedAt.UTC().Format(time.RFC3339Nano)
+		metadata.Dirty = data.Dirty
+		metadata.Rebuilding = data.Rebuilding
+		metadata.Chain = data.Chain
+	}
+	r.Status.SalvageMetadata = metadata
 }
 
 func (rc *ReplicaController) enqueueReplica(obj interface{}) {
@@ -311,7 +353,7 @@ func (rc *ReplicaController) enqueueReplica(obj interface{}) {
 	rc.queue.Add(key)
 }
 
-func (rc *ReplicaController) CreateInstance(obj interface{}) (*longhorn.InstanceProcess, error) {
+func (rc *ReplicaController) CreateInstance(obj interface{}) (ip *longhorn.InstanceProcess, err error) {
 	r, ok := obj.(*longhorn.Replica)
 	if !ok {
 		return nil, fmt.Errorf("invalid object for replica instance creation: %v", obj)
@@ -322,7 +364,12 @@ func (rc *ReplicaController) CreateInstance(obj interface{}) (*longhorn.Instance
 		return nil, fmt.Errorf("missing parameters for replica instance creation: %v", r)
 	}
 
-	var err error
+	ctx, span := tracing.StartSpanForObject(r, controllerTracerName, "ReplicaController.CreateInstance",
+		trace.WithAttributes(attribute.String("longhorn.replica", r.Name), attribute.String("longhorn.node", r.Spec.NodeID)))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
 	backingImagePath := ""
 	if r.Spec.BackingImage != "" {
 		if backingImagePath, err = rc.GetBackingImagePathForReplicaStarting(r); err != nil {
@@ -392,6 +439,12 @@ func (rc *ReplicaController) CreateInstance(obj interface{}) (*longhorn.Instance
 		return nil, errors.Wrapf(err, "failed to update replica %v status.starting to true before sending instance create request", r.Name)
 	}
 
+	_, imSpan := tracing.StartChildSpan(ctx, controllerTracerName, "InstanceManager.ReplicaInstanceCreate",
+		trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attribute.String("longhorn.instance_manager", im.Name)))
+	defer func() {
+		tracing.EndSpan(imSpan, err)
+	}()
+
 	return c.ReplicaInstanceCreate(&engineapi.ReplicaInstanceCreateRequest{
 		Replica:             r,
 		DiskName:            diskName,
@@ -528,11 +581,36 @@ func (rc *ReplicaController) CanStartRebuildingReplica(r *longhorn.Replica) (boo
 		return false, nil
 	}
 
+	// The replicas moved to a replacement disk take the free slots before the other rebuilding replicas.
+	if !datastore.IsReplacementDiskRebuildReplica(r) {
+		pendingReplacementRebuildings := getPendingReplacementDiskRebuildingCount(rsMap, rc.inProgressRebuildingMap)
+		if len(rc.inProgressRebuildingMap)+pendingReplacementRebuildings >= int(concurrentRebuildingLimit) {
+			log.Infof("Replica rebuilding waits for %v pending rebuildings of the replicas on the replacement disks on this node",
+				pendingReplacementRebuildings)
+			return false, nil
+		}
+	}
+
 	rc.inProgressRebuildingMap[r.Name] = struct{}{}
 
 	return true, nil
 }
 
+// getPendingReplacementDiskRebuildingCount returns the number of the replicas moved to a replacement disk that are
+// waiting to start rebuilding.
+func getPendingReplacementDiskRebuildingCount(replicas map[string]*longhorn.Replica, inProgressRebuildingMap map[string]struct{}) int {
+	count := 0
+	for _, r := range replicas {
+		if _, ok := inProgressRebuildingMap[r.Name]; ok {
+			continue
+		}
+		if IsRebuildingReplica(r) && datastore.IsReplacementDiskRebuildReplica(r) {
+			count++
+		}
+	}
+	return count
+}
+
 func (rc *ReplicaController) DeleteInstance(obj interface{}) (err error) {
 	r, ok := obj.(*longhorn.Replica)
 	if !ok {
@@ -575,6 +653,12 @@ func (rc *ReplicaController) DeleteInstance(obj interface{}) (err error) {
 		return err
 	}
 
+	ctx, span := tracing.StartSpanForObject(r, controllerTracerName, "ReplicaController.DeleteInstance",
+		trace.WithAttributes(attribute.String("longhorn.replica", r.Name), attribute.String("longhorn.node", r.Spec.NodeID)))
+	defer func() {
+		tracing.EndSpan(span, err)
+	}()
+
 	defer func() {
 		if err != nil {
 			log.WithError(err).Warnf("Failed to delete replica process %v", r.Name)
@@ -604,7 +688,10 @@ func (rc *ReplicaController) DeleteInstance(obj interface{}) (err error) {
 
 	log.WithField("cleanupRequired", cleanupRequired).Infof("Deleting replica instance on disk %v", r.Spec.DiskPath)
 
+	_, imSpan := tracing.StartChildSpan(ctx, controllerTracerName, "InstanceManager.InstanceDelete",
+		trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attribute.String("longhorn.instance_manager", im.Name)))
 	err = c.InstanceDelete(r.Spec.DataEngine, r.Name, "", string(longhorn.InstanceManagerTypeReplica), r.Spec.DiskID, cleanupRequired)
+	tracing.EndSpan(imSpan, err)
 	if err != nil && !types.ErrorIsNotFound(err) {
 		return err
 	}
@@ -39,6 +39,7 @@ import (
 
 	"github.com/longhorn/longhorn-manager/constant"
 	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/logging"
 	"github.com/longhorn/longhorn-manager/types"
 	"github.com/longhorn/longhorn-manager/util"
 
@@ -174,7 +175,7 @@ func (sc *SettingController) processNextWorkItem() bool {
 	}
 	defer sc.queue.Done(key)
 
-	err := sc.syncSetting(key.(string))
+	err := sc.syncSetting(sc.newReconcileLogger(), key.(string))
 	sc.handleErr(err, key)
 
 	return true
@@ -198,7 +199,7 @@ func (sc *SettingController) handleErr(err error, key interface{}) {
 	sc.queue.Forget(key)
 }
 
-func (sc *SettingController) syncSetting(key string) (err error) {
+func (sc *SettingController) syncSetting(logger logrus.FieldLogger, key string) (err error) {
 	defer func() {
 		err = errors.Wrapf(err, "failed to sync setting for %v", key)
 	}()
@@ -211,7 +212,7 @@ func (sc *SettingController) syncSetting(key string) (err error) {
 	defer func() {
 		setting, dsErr := sc.ds.GetSettingExact(types.SettingName(name))
 		if dsErr != nil {
-			sc.logger.WithError(dsErr).Warnf("Failed to get setting: %v", name)
+			logger.WithError(dsErr).Warnf("Failed to get setting: %v", name)
 			return
 		}
 		existingApplied := setting.Status.Applied
@@ -222,7 +223,7 @@ func (sc *SettingController) syncSetting(key string) (err error) {
 		}
 		if setting.Status.Applied != existingApplied {
 			if _, dsErr := sc.ds.UpdateSettingStatus(setting); dsErr != nil {
-				sc.logger.WithError(dsErr).Warnf("Failed to update setting: %v", name)
+				logger.WithError(dsErr).Warnf("Failed to update setting: %v", name)
 			}
 		}
 	}()
@@ -252,6 +253,14 @@ func (sc *SettingController) syncNonDangerZoneSettingsForManagedComponents(setti
 		if err := sc.updateLogLevel(settingName); err != nil {
 			return err
 		}
+	case types.SettingNameLogFormat:
+		if err := sc.updateLogFormat(settingName); err != nil {
+			return err
+		}
+	case types.SettingNameComponentLogLevels:
+		if err := sc.updateComponentLogLevels(settingName); err != nil {
+			return err
+		}
 	case types.SettingNameDefaultLonghornStaticStorageClass:
 		if err := sc.syncDefaultLonghornStaticStorageClass(); err != nil {
 			return err
@@ -854,7 +863,33 @@ func (sc *SettingController) updateLogLevel(settingName types.SettingName) error
 	}
 	if oldLevel != newLevel {
 		logrus.Warnf("Updating log level from %v to %v", oldLevel, newLevel)
-		logrus.SetLevel(newLevel)
+		logging.SetLevel(newLevel)
+	}
+
+	return nil
+}
+
+func (sc *SettingController) updateLogFormat(settingName types.SettingName) error {
+	setting, err := sc.ds.GetSettingWithAutoFillingRO(settingName)
+	if err != nil {
+		return err
+	}
+	return logging.SetFormat(setting.Value)
+}
+
+func (sc *SettingController) updateComponentLogLevels(settingName types.SettingName) error {
+	setting, err := sc.ds.GetSettingWithAutoFillingRO(settingName)
+	if err != nil {
+		return err
+	}
+	newLevels, err := logging.ParseComponentLevels(setting.Value)
+	if err != nil {
+		return err
+	}
+	oldLevels := logging.ComponentLevels()
+	logging.SetComponentLevels(newLevels)
+	if newValue := logging.FormatComponentLevels(logging.ComponentLevels()); newValue != logging.FormatComponentLevels(oldLevels) {
+		logrus.Warnf("Updated component log levels to %v", newValue)
 	}
 
 	return nil
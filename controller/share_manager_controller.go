@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"path/filepath"
 	"reflect"
 	"regexp"
 	"strings"
@@ -133,10 +134,9 @@ func NewShareManagerController(
 }
 
 func getLoggerForShareManager(logger logrus.FieldLogger, sm *longhorn.ShareManager) *logrus.Entry {
-	return logger.WithFields(
+	return getLoggerForResource(logger, sm.Name, "", "", "").WithFields(
 		logrus.Fields{
 			"shareManager": sm.Name,
-			"volume":       sm.Name,
 			"owner":        sm.Status.OwnerID,
 			"state":        sm.Status.State,
 		},
@@ -301,7 +301,7 @@ func (c *ShareManagerController) processNextWorkItem() bool {
 		return false
 	}
 	defer c.queue.Done(key)
-	err := c.syncShareManager(key.(string))
+	err := c.syncShareManager(c.newReconcileLogger(), key.(string))
 	c.handleErr(err, key)
 	return true
 }
@@ -324,7 +324,7 @@ func (c *ShareManagerController) handleErr(err error, key interface{}) {
 	utilruntime.HandleError(err)
 }
 
-func (c *ShareManagerController) syncShareManager(key string) (err error) {
+func (c *ShareManagerController) syncShareManager(logger logrus.FieldLogger, key string) (err error) {
 	defer func() {
 		err = errors.Wrapf(err, "failed to sync %v", key)
 	}()
@@ -343,7 +343,7 @@ func (c *ShareManagerController) syncShareManager(key string) (err error) {
 		}
 		return nil
 	}
-	log := getLoggerForShareManager(c.logger, sm)
+	log := getLoggerForShareManager(logger, sm)
 
 	isResponsible, err := c.isResponsibleFor(sm)
 	if err != nil {
@@ -484,7 +484,7 @@ func (c *ShareManagerController) isShareManagerRequiredForVolume(sm *longhorn.Sh
 		return false
 	}
 
-	if volume.Spec.AccessMode != longhorn.AccessModeReadWriteMany {
+	if !types.IsSharedAccessMode(volume.Spec.AccessMode) {
 		return false
 	}
 
@@ -509,7 +509,7 @@ func (c *ShareManagerController) isShareManagerRequiredForVolume(sm *longhorn.Sh
 	}
 
 	for _, attachmentTicket := range va.Spec.AttachmentTickets {
-		if isRegularRWXVolume(volume) && isCSIAttacherTicket(attachmentTicket) {
+		if isRegularRWXVolume(volume) && isShareConsumerTicket(attachmentTicket) {
 			return true
 		}
 	}
@@ -1629,6 +1629,37 @@ func (c *ShareManagerController) createPodManifest(sm *longhorn.ShareManager, da
 		},
 	}
 
+	// The NFS server config exporting the volume with the export options is written before the share manager starts,
+	// the volumes exported with the default options keep the config written by the share manager
+	if !reflect.DeepEqual(sm.Spec.NFSExportOptions, longhorn.NFSExportOptions{}) {
+		nfsConfigVolumeMount := corev1.VolumeMount{
+			Name:      "nfs-config",
+			MountPath: filepath.Dir(types.NFSGaneshaConfigPath),
+		}
+		podSpec.Spec.InitContainers = []corev1.Container{
+			{
+				Name:            "nfs-config",
+				Image:           sm.Spec.Image,
+				ImagePullPolicy: pullPolicy,
+				Command:         []string{"sh", "-c", fmt.Sprintf("printf '%%s' \"$NFS_CONFIG\" > %v", types.NFSGaneshaConfigPath)},
+				Env: []corev1.EnvVar{
+					{
+						Name:  "NFS_CONFIG",
+						Value: types.GetNFSGaneshaConfig(sm.Name, sm.Spec.NFSExportOptions, nfsConfig.leaseLifetime, nfsConfig.gracePeriod),
+					},
+				},
+				VolumeMounts: []corev1.VolumeMount{nfsConfigVolumeMount},
+			},
+		}
+		podSpec.Spec.Containers[0].VolumeMounts = append(podSpec.Spec.Containers[0].VolumeMounts, nfsConfigVolumeMount)
+		podSpec.Spec.Volumes = append(podSpec.Spec.Volumes, corev1.Volume{
+			Name: "nfs-config",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+	}
+
 	if registrySecret != "" {
 		podSpec.Spec.ImagePullSecrets = []corev1.LocalObjectReference{
 			{
@@ -2,9 +2,14 @@ package controller
 
 import (
 	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
 	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"reflect"
+	"strings"
 	"testing"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
 )
 
 func TestShareManagerController_splitFormatOptions(t *testing.T) {
@@ -146,3 +151,59 @@ func TestShareManagerController_splitFormatOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestShareManagerController_createPodManifestNFSConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		accessMode longhorn.AccessMode
+		options    longhorn.NFSExportOptions
+		want       string
+	}{
+		{
+			name:       "rwx volume with the default export options",
+			accessMode: longhorn.AccessModeReadWriteMany,
+		},
+		{
+			name:       "rwx volume with allowed clients",
+			accessMode: longhorn.AccessModeReadWriteMany,
+			options:    longhorn.NFSExportOptions{AllowedClients: []string{"10.0.0.0/8"}},
+			want:       "\tCLIENT {\n\t\tClients = 10.0.0.0/8;\n\t\tAccess_Type = RW;\n\t}\n",
+		},
+		{
+			name:       "rox volume",
+			accessMode: longhorn.AccessModeReadOnlyMany,
+			want:       "\tAccess_Type = RO;\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &ShareManagerController{
+				baseController: newBaseController("test-controller", logrus.StandardLogger()),
+			}
+			volume := &longhorn.Volume{
+				ObjectMeta: metav1.ObjectMeta{Name: "vol"},
+				Spec:       longhorn.VolumeSpec{AccessMode: tt.accessMode, NFSExportOptions: tt.options},
+			}
+			sm := &longhorn.ShareManager{
+				ObjectMeta: metav1.ObjectMeta{Name: "vol"},
+				Spec:       longhorn.ShareManagerSpec{Image: "share-manager", NFSExportOptions: getShareManagerNFSExportOptions(volume)},
+			}
+			pod := c.createPodManifest(sm, longhorn.DataEngineTypeV1, map[string]string{}, nil, nil, corev1.PullIfNotPresent, nil, "", "",
+				nil, "ext4", nil, nil, "", nil, &nfsServerConfig{leaseLifetime: 60, gracePeriod: 90})
+
+			if tt.want == "" {
+				if len(pod.Spec.InitContainers) != 0 {
+					t.Errorf("createPodManifest() has init containers %v, want none", pod.Spec.InitContainers)
+				}
+				return
+			}
+			if len(pod.Spec.InitContainers) != 1 {
+				t.Fatalf("createPodManifest() has init containers %v, want the NFS config one", pod.Spec.InitContainers)
+			}
+			config := pod.Spec.InitContainers[0].Env[0].Value
+			if !strings.Contains(config, "\tExport_Id = 1;#Volume=vol\n") || !strings.Contains(config, tt.want) {
+				t.Errorf("createPodManifest() has NFS config %v, want it to contain %q", config, tt.want)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/cockroachdb/errors"
@@ -25,6 +26,7 @@ import (
 	"github.com/longhorn/longhorn-manager/constant"
 	"github.com/longhorn/longhorn-manager/datastore"
 	"github.com/longhorn/longhorn-manager/engineapi"
+	"github.com/longhorn/longhorn-manager/metrics_collector/operation"
 	"github.com/longhorn/longhorn-manager/types"
 	"github.com/longhorn/longhorn-manager/util"
 
@@ -51,6 +53,10 @@ type SnapshotController struct {
 	engineClientCollection engineapi.EngineClientCollection
 
 	proxyConnCounter util.Counter
+
+	// The names of the snapshots whose creation outcome is recorded, so that the outcome is recorded once per snapshot
+	// rather than on every retry of a failed creation.
+	observedSnapshotCreations sync.Map
 }
 
 func NewSnapshotController(
@@ -350,7 +356,7 @@ func (sc *SnapshotController) processNextWorkItem() bool {
 		return false
 	}
 	defer sc.queue.Done(key)
-	err := sc.syncHandler(key.(string))
+	err := sc.syncHandler(sc.newReconcileLogger(), key.(string))
 	sc.handleErr(err, key)
 	return true
 }
@@ -366,7 +372,7 @@ func (sc *SnapshotController) handleErr(err error, key interface{}) {
 	sc.queue.AddRateLimited(key)
 }
 
-func (sc *SnapshotController) syncHandler(key string) (err error) {
+func (sc *SnapshotController) syncHandler(logger logrus.FieldLogger, key string) (err error) {
 	defer func() {
 		err = errors.Wrapf(err, "%v: failed to sync snapshot %v", sc.name, key)
 	}()
@@ -378,15 +384,16 @@ func (sc *SnapshotController) syncHandler(key string) (err error) {
 	if namespace != sc.namespace {
 		return nil
 	}
-	return sc.reconcile(name)
+	return sc.reconcile(logger, name)
 }
 
-func (sc *SnapshotController) reconcile(snapshotName string) (err error) {
+func (sc *SnapshotController) reconcile(logger logrus.FieldLogger, snapshotName string) (err error) {
 	snapshot, err := sc.ds.GetSnapshot(snapshotName)
 	if err != nil {
 		if !apierrors.IsNotFound(err) {
 			return err
 		}
+		sc.observedSnapshotCreations.Delete(snapshotName)
 		return nil
 	}
 
@@ -412,6 +419,7 @@ func (sc *SnapshotController) reconcile(snapshotName string) (err error) {
 			return
 		}
 		sc.generatingEventsForSnapshot(existingSnapshot, snapshot)
+		sc.recordSnapshotCreation(existingSnapshot, snapshot)
 	}()
 
 	// deleting snapshotCR
@@ -453,7 +461,7 @@ func (sc *SnapshotController) reconcile(snapshotName string) (err error) {
 			return err
 		}
 		if disablePurge {
-			sc.logger.Warnf("Cannot start SnapshotPurge to delete snapshot %v while %v setting is true",
+			logger.Warnf("Cannot start SnapshotPurge to delete snapshot %v while %v setting is true",
 				snapshot.Name, types.SettingNameDisableSnapshotPurge)
 			// Like above, we do not want to keep the volume stuck in an attached state if it is not possible to purge
 			// this snapshot.
@@ -480,13 +488,13 @@ func (sc *SnapshotController) reconcile(snapshotName string) (err error) {
 			}
 			childSnapshot, err := sc.ds.GetSnapshot(childSnapshotName)
 			if err != nil {
-				sc.logger.WithError(err).Errorf("Failed to get the child snapshot %s during snapshot %s deletion", childSnapshotName, snapshotName)
+				logger.WithError(err).Errorf("Failed to get the child snapshot %s during snapshot %s deletion", childSnapshotName, snapshotName)
 				continue
 			}
 			if childSnapshot.Status.Checksum != "" {
 				childSnapshot.Status.Checksum = ""
 				if _, err = sc.ds.UpdateSnapshotStatus(childSnapshot); err != nil {
-					sc.logger.WithError(err).Errorf("Failed to clean up the child snapshot %s checksum during snapshot %s deletion", childSnapshotName, snapshotName)
+					logger.WithError(err).Errorf("Failed to clean up the child snapshot %s checksum during snapshot %s deletion", childSnapshotName, snapshotName)
 					continue
 				}
 			}
@@ -539,6 +547,7 @@ func (sc *SnapshotController) reconcile(snapshotName string) (err error) {
 		}
 		err = sc.handleSnapshotCreate(snapshot, engine)
 		if err != nil {
+			sc.observeSnapshotCreation(snapshot, string(engine.Spec.DataEngine), operation.OutcomeFailure)
 			snapshot.Status.Error = err.Error()
 			return reconcileError{error: err, shouldUpdateObject: true}
 		}
@@ -664,6 +673,29 @@ func (sc *SnapshotController) generatingEventsForSnapshot(existingSnapshot, snap
 	}
 }
 
+// recordSnapshotCreation records the time from the creation of the snapshot CR until the snapshot is taken. The failed
+// attempts are recorded when the engine fails to take the snapshot.
+func (sc *SnapshotController) recordSnapshotCreation(existingSnapshot, snapshot *longhorn.Snapshot) {
+	if !snapshot.Spec.CreateSnapshot || existingSnapshot.Status.CreationTime != "" || snapshot.Status.CreationTime == "" {
+		return
+	}
+	volume, err := sc.ds.GetVolumeRO(snapshot.Spec.Volume)
+	if err != nil {
+		sc.logger.WithError(err).Warnf("Failed to get volume %v to record the creation of snapshot %v", snapshot.Spec.Volume, snapshot.Name)
+		return
+	}
+	sc.observeSnapshotCreation(snapshot, string(volume.Spec.DataEngine), operation.OutcomeSuccess)
+}
+
+// observeSnapshotCreation records the outcome of the creation of the snapshot unless an outcome is recorded already. A
+// snapshot that is taken after failed attempts is recorded as failed by the first attempt only.
+func (sc *SnapshotController) observeSnapshotCreation(snapshot *longhorn.Snapshot, dataEngine, outcome string) {
+	if _, observed := sc.observedSnapshotCreations.LoadOrStore(snapshot.Name, struct{}{}); observed {
+		return
+	}
+	operation.ObserveDuration(operation.OperationSnapshot, dataEngine, outcome, snapshot.CreationTimestamp.Time)
+}
+
 func (sc *SnapshotController) isVolumeDeletedOrBeingDeleted(volumeName string) (bool, error) {
 	volume, err := sc.ds.GetVolumeRO(volumeName)
 	if err != nil {
@@ -810,7 +842,7 @@ func (sc *SnapshotController) isResponsibleFor(snap *longhorn.Snapshot) (bool, e
 }
 
 func getLoggerForSnapshot(logger logrus.FieldLogger, snap *longhorn.Snapshot) *logrus.Entry {
-	return logger.WithFields(
+	return getLoggerForResource(logger, snap.Spec.Volume, "", "", "").WithFields(
 		logrus.Fields{
 			"snapshot": snap.Name,
 		},
@@ -0,0 +1,256 @@
+package controller
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/kubernetes/pkg/controller"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+
+	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/types"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+const (
+	StorageQuotaControllerName = "longhorn-storage-quota"
+)
+
+// StorageQuotaController reports the storage consumed by the namespaces of the storage quotas. The quotas are
+// enforced on the new volumes, snapshots and backups by the admission webhook.
+type StorageQuotaController struct {
+	*baseController
+
+	// which namespace controller is running with
+	namespace string
+	// use as the OwnerID of the controller
+	controllerID string
+
+	ds *datastore.DataStore
+
+	cacheSyncs []cache.InformerSynced
+}
+
+func NewStorageQuotaController(
+	logger logrus.FieldLogger,
+	ds *datastore.DataStore,
+	namespace string,
+	controllerID string) (*StorageQuotaController, error) {
+
+	c := &StorageQuotaController{
+		baseController: newBaseController(StorageQuotaControllerName, logger),
+
+		namespace:    namespace,
+		controllerID: controllerID,
+
+		ds: ds,
+	}
+
+	var err error
+	if _, err = ds.StorageQuotaInformer.AddEventHandlerWithResyncPeriod(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueStorageQuota,
+		UpdateFunc: func(old, cur interface{}) { c.enqueueStorageQuota(cur) },
+		DeleteFunc: c.enqueueStorageQuota,
+	}, 0); err != nil {
+		return nil, err
+	}
+	c.cacheSyncs = append(c.cacheSyncs, ds.StorageQuotaInformer.HasSynced)
+
+	if _, err = ds.VolumeInformer.AddEventHandlerWithResyncPeriod(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueStorageQuotas,
+		UpdateFunc: func(old, cur interface{}) { c.enqueueStorageQuotas(cur) },
+		DeleteFunc: c.enqueueStorageQuotas,
+	}, 0); err != nil {
+		return nil, err
+	}
+	c.cacheSyncs = append(c.cacheSyncs, ds.VolumeInformer.HasSynced)
+
+	if _, err = ds.SnapshotInformer.AddEventHandlerWithResyncPeriod(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueStorageQuotas,
+		UpdateFunc: func(old, cur interface{}) { c.enqueueStorageQuotas(cur) },
+		DeleteFunc: c.enqueueStorageQuotas,
+	}, 0); err != nil {
+		return nil, err
+	}
+	c.cacheSyncs = append(c.cacheSyncs, ds.SnapshotInformer.HasSynced)
+
+	if _, err = ds.BackupInformer.AddEventHandlerWithResyncPeriod(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueStorageQuotas,
+		UpdateFunc: func(old, cur interface{}) { c.enqueueStorageQuotas(cur) },
+		DeleteFunc: c.enqueueStorageQuotas,
+	}, 0); err != nil {
+		return nil, err
+	}
+	c.cacheSyncs = append(c.cacheSyncs, ds.BackupInformer.HasSynced)
+
+	return c, nil
+}
+
+func (c *StorageQuotaController) enqueueStorageQuota(obj interface{}) {
+	key, err := controller.KeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("couldn't get key for object %#v: %v", obj, err))
+		return
+	}
+
+	c.queue.Add(key)
+}
+
+// enqueueStorageQuotas enqueues all quotas, since the namespace of a changed volume, snapshot or backup is not known
+// without looking up its volume.
+func (c *StorageQuotaController) enqueueStorageQuotas(obj interface{}) {
+	quotas, err := c.ds.ListStorageQuotasRO()
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to list storage quotas: %v", err))
+		return
+	}
+	for _, quota := range quotas {
+		c.enqueueStorageQuota(quota)
+	}
+}
+
+func (c *StorageQuotaController) Run(workers int, stopCh <-chan struct{}) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	c.logger.Info("Starting Longhorn StorageQuota controller")
+	defer c.logger.Info("Shut down Longhorn StorageQuota controller")
+
+	if !cache.WaitForNamedCacheSync(c.name, stopCh, c.cacheSyncs...) {
+		return
+	}
+	for i := 0; i < workers; i++ {
+		go wait.Until(c.worker, time.Second, stopCh)
+	}
+	<-stopCh
+}
+
+func (c *StorageQuotaController) worker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *StorageQuotaController) processNextWorkItem() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	err := c.syncStorageQuota(c.newReconcileLogger(), key.(string))
+	c.handleErr(err, key)
+
+	return true
+}
+
+func (c *StorageQuotaController) handleErr(err error, key interface{}) {
+	if err == nil {
+		c.queue.Forget(key)
+		return
+	}
+
+	log := c.logger.WithField("StorageQuota", key)
+
+	if c.queue.NumRequeues(key) < maxRetries {
+		handleReconcileErrorLogging(log, err, "Failed to sync StorageQuota")
+		c.queue.AddRateLimited(key)
+		return
+	}
+
+	utilruntime.HandleError(err)
+	handleReconcileErrorLogging(log, err, "Dropping Longhorn StorageQuota out of the queue")
+	c.queue.Forget(key)
+}
+
+func getLoggerForStorageQuota(logger logrus.FieldLogger, quota *longhorn.StorageQuota) *logrus.Entry {
+	return logger.WithField("storageQuota", quota.Name)
+}
+
+func (c *StorageQuotaController) syncStorageQuota(logger logrus.FieldLogger, key string) (err error) {
+	defer func() {
+		err = errors.Wrapf(err, "%v: fail to sync StorageQuota %v", c.name, key)
+	}()
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	if namespace != c.namespace {
+		return nil
+	}
+
+	return c.reconcile(logger, name)
+}
+
+func (c *StorageQuotaController) reconcile(logger logrus.FieldLogger, name string) (err error) {
+	quota, err := c.ds.GetStorageQuota(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	log := getLoggerForStorageQuota(logger, quota)
+
+	if !c.isResponsibleFor(quota) {
+		return nil
+	}
+
+	if quota.Status.OwnerID != c.controllerID {
+		quota.Status.OwnerID = c.controllerID
+		quota, err = c.ds.UpdateStorageQuotaStatus(quota)
+		if err != nil {
+			// we don't mind others coming first
+			if apierrors.IsConflict(errors.Cause(err)) {
+				return nil
+			}
+			return err
+		}
+		log.Infof("Storage quota got new owner %v", c.controllerID)
+	}
+
+	existingQuota := quota.DeepCopy()
+	defer func() {
+		if err != nil || reflect.DeepEqual(existingQuota.Status, quota.Status) {
+			return
+		}
+		if _, err = c.ds.UpdateStorageQuotaStatus(quota); err != nil && apierrors.IsConflict(errors.Cause(err)) {
+			log.WithError(err).Debugf("Requeue %v due to conflict", name)
+			c.enqueueStorageQuota(quota)
+			err = nil
+		}
+	}()
+
+	usage, err := c.ds.GetStorageQuotaUsage(quota.Spec.Namespace)
+	if err != nil {
+		return err
+	}
+	quota.Status.Usage = *usage
+
+	if violations := types.GetStorageQuotaViolations(&quota.Spec, usage); len(violations) > 0 {
+		quota.Status.Conditions = types.SetCondition(quota.Status.Conditions,
+			longhorn.StorageQuotaConditionTypeExceeded, longhorn.ConditionStatusTrue, longhorn.StorageQuotaConditionReasonExceeded,
+			strings.Join(violations, ", "))
+	} else {
+		quota.Status.Conditions = types.SetCondition(quota.Status.Conditions,
+			longhorn.StorageQuotaConditionTypeExceeded, longhorn.ConditionStatusFalse, "", "")
+	}
+
+	return nil
+}
+
+func (c *StorageQuotaController) isResponsibleFor(quota *longhorn.StorageQuota) bool {
+	return isControllerResponsibleFor(c.controllerID, c.ds, quota.Name, "", quota.Status.OwnerID)
+}
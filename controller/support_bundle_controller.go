@@ -175,7 +175,7 @@ func (c *SupportBundleController) processNextWorkItem() bool {
 	}
 	defer c.queue.Done(key)
 
-	err := c.syncSupportBundle(key.(string))
+	err := c.syncSupportBundle(c.newReconcileLogger(), key.(string))
 	c.handleErr(err, key)
 
 	return true
@@ -277,7 +277,7 @@ func getLoggerForSupportBundle(logger logrus.FieldLogger, name string) *logrus.E
 	)
 }
 
-func (c *SupportBundleController) syncSupportBundle(key string) (err error) {
+func (c *SupportBundleController) syncSupportBundle(logger logrus.FieldLogger, key string) (err error) {
 	defer func() {
 		err = errors.Wrapf(err, "%v: failed to sync SupportBundle %v", c.name, key)
 	}()
@@ -291,10 +291,10 @@ func (c *SupportBundleController) syncSupportBundle(key string) (err error) {
 		return nil
 	}
 
-	return c.reconcile(name)
+	return c.reconcile(logger, name)
 }
 
-func (c *SupportBundleController) reconcile(name string) (err error) {
+func (c *SupportBundleController) reconcile(logger logrus.FieldLogger, name string) (err error) {
 	supportBundle, err := c.ds.GetSupportBundle(name)
 	if err != nil {
 		if !apierrors.IsNotFound(err) {
@@ -303,7 +303,7 @@ func (c *SupportBundleController) reconcile(name string) (err error) {
 		return nil
 	}
 
-	log := getLoggerForSupportBundle(c.logger, supportBundle.Name)
+	log := getLoggerForSupportBundle(logger, supportBundle.Name)
 
 	if !c.isResponsibleFor(supportBundle) {
 		return nil
@@ -186,7 +186,7 @@ func (s *TestSuite) TestReconcileSupportBundle(c *C) {
 		}
 		c.Assert(supportBundle, NotNil)
 
-		err = supportBundleController.reconcile(tc.supportBundleNames[0])
+		err = supportBundleController.reconcile(supportBundleController.newReconcileLogger(), tc.supportBundleNames[0])
 		c.Assert(err, IsNil)
 
 		for _, supportBundleName := range tc.supportBundleNames {
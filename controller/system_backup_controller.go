@@ -191,7 +191,7 @@ func (c *SystemBackupController) processNextWorkItem() bool {
 	}
 	defer c.queue.Done(key)
 
-	err := c.syncSystemBackup(key.(string))
+	err := c.syncSystemBackup(c.newReconcileLogger(), key.(string))
 	c.handleErr(err, key)
 
 	return true
@@ -254,7 +254,7 @@ func (c *SystemBackupController) isResponsibleFor(systemBackup *longhorn.SystemB
 	return isControllerResponsibleFor(c.controllerID, c.ds, systemBackup.Name, "", systemBackup.Status.OwnerID)
 }
 
-func (c *SystemBackupController) syncSystemBackup(key string) (err error) {
+func (c *SystemBackupController) syncSystemBackup(logger logrus.FieldLogger, key string) (err error) {
 	defer func() {
 		err = errors.Wrapf(err, "%v: failed to sync SystemBackup %v", c.name, key)
 	}()
@@ -281,7 +281,7 @@ func (c *SystemBackupController) syncSystemBackup(key string) (err error) {
 		}
 	}
 
-	return c.reconcile(name, backupTargetClient, backupTarget)
+	return c.reconcile(logger, name, backupTargetClient, backupTarget)
 }
 
 func getLoggerForSystemBackup(logger logrus.FieldLogger, systemBackup *longhorn.SystemBackup) logrus.FieldLogger {
@@ -324,7 +324,7 @@ func (c *SystemBackupController) updateSystemBackupRecord(record *systemBackupRe
 	record.message = message
 }
 
-func (c *SystemBackupController) reconcile(name string, backupTargetClient engineapi.SystemBackupOperationInterface, backupTarget *longhorn.BackupTarget) (err error) {
+func (c *SystemBackupController) reconcile(logger logrus.FieldLogger, name string, backupTargetClient engineapi.SystemBackupOperationInterface, backupTarget *longhorn.BackupTarget) (err error) {
 	systemBackup, err := c.ds.GetSystemBackup(name)
 	if err != nil {
 		if !apierrors.IsNotFound(err) {
@@ -337,7 +337,7 @@ func (c *SystemBackupController) reconcile(name string, backupTargetClient engin
 		return nil
 	}
 
-	log := getLoggerForSystemBackup(c.logger, systemBackup)
+	log := getLoggerForSystemBackup(logger, systemBackup)
 
 	if systemBackup.Status.OwnerID != c.controllerID {
 		systemBackup.Status.OwnerID = c.controllerID
@@ -775,6 +775,11 @@ func (c *SystemBackupController) backupVolumesAlways(systemBackup *longhorn.Syst
 			continue
 		}
 
+		if isVolumeStoringItsBackups(c.ds, volume) {
+			c.logger.Infof("Skip backup for volume %v storing the backups of its backup target", volume.Name)
+			continue
+		}
+
 		volumeBackupName := bsutil.GenerateName("system-backup")
 
 		snapshot, err := c.createVolumeSnapshot(ctx, volume, volumeBackupName)
@@ -816,6 +821,11 @@ func (c *SystemBackupController) backupVolumesIfNotPresent(systemBackup *longhor
 			continue
 		}
 
+		if isVolumeStoringItsBackups(c.ds, volume) {
+			c.logger.Infof("Skip backup for volume %v storing the backups of its backup target", volume.Name)
+			continue
+		}
+
 		volumeBackupName := bsutil.GenerateName("system-backup")
 
 		snapshot, err := c.createVolumeSnapshot(ctx, volume, volumeBackupName)
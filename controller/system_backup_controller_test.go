@@ -393,7 +393,7 @@ func (s *TestSuite) TestReconcileSystemBackup(c *C) {
 		default:
 			defaultBackupTarget, err := lhClient.LonghornV1beta2().BackupTargets(TestNamespace).Get(context.TODO(), types.DefaultBackupTargetName, metav1.GetOptions{})
 			c.Assert(err, IsNil)
-			err = systemBackupController.reconcile(tc.systemBackupName, backupTargetClient, defaultBackupTarget)
+			err = systemBackupController.reconcile(systemBackupController.newReconcileLogger(), tc.systemBackupName, backupTargetClient, defaultBackupTarget)
 			if tc.expectError {
 				c.Assert(err, NotNil)
 			} else {
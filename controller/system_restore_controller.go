@@ -151,7 +151,7 @@ func (c *SystemRestoreController) processNextWorkItem() bool {
 	}
 	defer c.queue.Done(key)
 
-	err := c.syncSystemRestore(key.(string))
+	err := c.syncSystemRestore(c.newReconcileLogger(), key.(string))
 	c.handleErr(err, key)
 
 	return true
@@ -190,7 +190,7 @@ func (c *SystemRestoreController) LogNormalState(record *systemRestoreRecord, sy
 	c.eventRecorder.Eventf(systemRestore, corev1.EventTypeNormal, record.reason, record.message)
 }
 
-func (c *SystemRestoreController) syncSystemRestore(key string) (err error) {
+func (c *SystemRestoreController) syncSystemRestore(logger logrus.FieldLogger, key string) (err error) {
 	defer func() {
 		err = errors.Wrapf(err, "%v: fail to sync SystemRestore %v", c.name, key)
 	}()
@@ -214,10 +214,10 @@ func (c *SystemRestoreController) syncSystemRestore(key string) (err error) {
 		return err
 	}
 
-	return c.reconcile(name, backupTargetClient)
+	return c.reconcile(logger, name, backupTargetClient)
 }
 
-func (c *SystemRestoreController) reconcile(name string, backupTargetClient engineapi.SystemBackupOperationInterface) (err error) {
+func (c *SystemRestoreController) reconcile(logger logrus.FieldLogger, name string, backupTargetClient engineapi.SystemBackupOperationInterface) (err error) {
 	systemRestore, err := c.ds.GetSystemRestore(name)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
@@ -226,7 +226,7 @@ func (c *SystemRestoreController) reconcile(name string, backupTargetClient engi
 		return err
 	}
 
-	log := getLoggerForSystemRestore(c.logger, systemRestore)
+	log := getLoggerForSystemRestore(logger, systemRestore)
 
 	if !c.isResponsibleFor(systemRestore) {
 		return nil
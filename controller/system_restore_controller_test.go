@@ -136,7 +136,7 @@ func (s *TestSuite) TestReconcileSystemRestore(c *C) {
 			}
 		}
 
-		err = systemRestoreController.reconcile(systemRestoreName, backupTargetClient)
+		err = systemRestoreController.reconcile(systemRestoreController.newReconcileLogger(), systemRestoreName, backupTargetClient)
 		if tc.expectError {
 			c.Assert(err, NotNil)
 		} else {
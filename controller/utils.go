@@ -17,6 +17,7 @@ import (
 
 	"github.com/longhorn/longhorn-manager/datastore"
 	"github.com/longhorn/longhorn-manager/types"
+	"github.com/longhorn/longhorn-manager/util"
 
 	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
 )
@@ -135,11 +136,13 @@ func setReplicaFailedAt(r *longhorn.Replica, timestamp string) {
 	}
 }
 
+// isRegularRWXVolume returns true if the volume is served by a share manager. A ROX volume is served the same way as a
+// non-migratable RWX volume.
 func isRegularRWXVolume(v *longhorn.Volume) bool {
 	if v == nil {
 		return false
 	}
-	return v.Spec.AccessMode == longhorn.AccessModeReadWriteMany && !v.Spec.Migratable
+	return types.IsSharedAccessMode(v.Spec.AccessMode) && !v.Spec.Migratable
 }
 
 func checkIfRemoteDataCleanupIsNeeded(obj runtime.Object, bt *longhorn.BackupTarget) (bool, error) {
@@ -154,6 +157,17 @@ func checkIfRemoteDataCleanupIsNeeded(obj runtime.Object, bt *longhorn.BackupTar
 	return !exists && isBackupTargetAvailable(bt), nil
 }
 
+// isVolumeStoringItsBackups returns true if the volume stores the backups of its file backup target, so it cannot be
+// backed up to it.
+func isVolumeStoringItsBackups(ds *datastore.DataStore, volume *longhorn.Volume) bool {
+	backupTarget, err := ds.GetBackupTargetRO(volume.Spec.BackupTargetName)
+	if err != nil || util.GetSchemeFromURL(backupTarget.Spec.BackupTargetURL) != types.BackupStoreTypeFile {
+		return false
+	}
+	volumeName, _, _, err := types.ParseFileBackupTargetURL(backupTarget.Spec.BackupTargetURL)
+	return err == nil && volumeName == volume.Name
+}
+
 // isBackupTargetAvailable returns a boolean that the backup target is available for true and not available for false
 func isBackupTargetAvailable(backupTarget *longhorn.BackupTarget) bool {
 	return backupTarget != nil &&
@@ -8,6 +8,9 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/sirupsen/logrus"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/cache"
@@ -22,6 +25,7 @@ import (
 
 	"github.com/longhorn/longhorn-manager/constant"
 	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/tracing"
 	"github.com/longhorn/longhorn-manager/types"
 	"github.com/longhorn/longhorn-manager/util"
 
@@ -290,10 +294,18 @@ func (vac *VolumeAttachmentController) reconcile(vaName string) (err error) {
 		return nil
 	}
 
+	changed := false
+	ctx, span := tracing.StartSpanForObject(va, controllerTracerName, "VolumeAttachmentController.reconcile",
+		trace.WithAttributes(attribute.String("longhorn.volume", va.Spec.Volume)))
+	defer func() {
+		tracing.EndReconcileSpan(span, changed, err)
+	}()
+
 	vol, err := vac.ds.GetVolume(va.Spec.Volume)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			if !va.DeletionTimestamp.IsZero() {
+				changed = true
 				return vac.ds.RemoveFinalizerForLHVolumeAttachment(va)
 			}
 			return nil
@@ -308,17 +320,21 @@ func (vac *VolumeAttachmentController) reconcile(vaName string) (err error) {
 			return
 		}
 		if !reflect.DeepEqual(existingVol.Spec, vol.Spec) {
+			changed = true
+			tracing.InjectAnnotations(ctx, vol)
 			if _, err = vac.ds.UpdateVolume(vol); err != nil {
 				return
 			}
 
 		}
 		if !reflect.DeepEqual(existingVA.Spec, va.Spec) {
+			changed = true
 			if _, err = vac.ds.UpdateLHVolumeAttachment(va); err != nil {
 				return
 			}
 		}
 		if !reflect.DeepEqual(existingVA.Status, va.Status) {
+			changed = true
 			if _, err = vac.ds.UpdateLHVolumeAttachmentStatus(va); err != nil {
 				return
 			}
@@ -899,7 +915,7 @@ func (vac *VolumeAttachmentController) isResponsibleFor(va *longhorn.VolumeAttac
 }
 
 func getLoggerForLHVolumeAttachment(logger logrus.FieldLogger, va *longhorn.VolumeAttachment) *logrus.Entry {
-	return logger.WithFields(
+	return getLoggerForResource(logger, va.Spec.Volume, "", "", "").WithFields(
 		logrus.Fields{
 			"longhornVolumeAttachment": va.Name,
 		},
@@ -919,14 +935,16 @@ func getLoggerForMigratingLHVolumeAttachment(logger logrus.FieldLogger, va *long
 }
 
 func isCSIAttacherTicketOfRegularRWXVolume(attachmentTicket *longhorn.AttachmentTicket, v *longhorn.Volume) bool {
-	return isRegularRWXVolume(v) && isCSIAttacherTicket(attachmentTicket)
+	return isRegularRWXVolume(v) && isShareConsumerTicket(attachmentTicket)
 }
 
-func isCSIAttacherTicket(ticket *longhorn.AttachmentTicket) bool {
+// isShareConsumerTicket returns true if the ticket is satisfied by the share of a regular RWX volume rather than by
+// attaching the volume to the node of the ticket.
+func isShareConsumerTicket(ticket *longhorn.AttachmentTicket) bool {
 	if ticket == nil {
 		return false
 	}
-	return ticket.Type == longhorn.AttacherTypeCSIAttacher
+	return ticket.Type == longhorn.AttacherTypeCSIAttacher || ticket.Type == longhorn.AttacherTypeBackupTargetController
 }
 
 func isMigratingCSIAttacherTicket(attachmentTicket *longhorn.AttachmentTicket, vol *longhorn.Volume) bool {
@@ -939,7 +957,7 @@ func isMigratingCSIAttacherTicket(attachmentTicket *longhorn.AttachmentTicket, v
 }
 
 func isVolumeShareAvailable(vol *longhorn.Volume) bool {
-	return vol.Spec.AccessMode == longhorn.AccessModeReadWriteMany &&
+	return types.IsSharedAccessMode(vol.Spec.AccessMode) &&
 		vol.Status.ShareState == longhorn.ShareManagerStateRunning &&
 		vol.Status.ShareEndpoint != ""
 }
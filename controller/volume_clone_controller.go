@@ -16,6 +16,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientset "k8s.io/client-go/kubernetes"
 
@@ -26,6 +27,10 @@ import (
 	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
 )
 
+const (
+	revertPreviewCleanupRetryPeriod = 10 * time.Second
+)
+
 type VolumeCloneController struct {
 	*baseController
 
@@ -192,6 +197,13 @@ func (vcc *VolumeCloneController) reconcile(volName string) (err error) {
 		return nil
 	}
 
+	if types.IsRevertPreviewVolume(vol) {
+		expired, err := vcc.reconcileRevertPreview(vol)
+		if err != nil || expired {
+			return err
+		}
+	}
+
 	va, err := vcc.ds.GetLHVolumeAttachmentByVolumeName(volName)
 	if err != nil {
 		if !apierrors.IsNotFound(err) {
@@ -248,6 +260,162 @@ func (vcc *VolumeCloneController) reconcile(volName string) (err error) {
 	return nil
 }
 
+// reconcileRevertPreview mounts the revert preview volume read-only in a maintenance pod once the clone completes, and
+// garbage collects the volume with the pod after the expiration. It returns true if the volume is expired.
+func (vcc *VolumeCloneController) reconcileRevertPreview(vol *longhorn.Volume) (expired bool, err error) {
+	expireAt, err := types.GetRevertPreviewExpireAt(vol)
+	if err != nil {
+		return false, err
+	}
+	if !time.Now().Before(expireAt) {
+		return true, vcc.cleanupRevertPreview(vol)
+	}
+	vcc.enqueueVolumeAfter(vol, time.Until(expireAt))
+
+	if vol.Status.CloneStatus.State != longhorn.VolumeCloneStateCompleted {
+		return false, nil
+	}
+	return false, vcc.createRevertPreviewPod(vol)
+}
+
+func (vcc *VolumeCloneController) createRevertPreviewPod(vol *longhorn.Volume) error {
+	storageClassName, err := vcc.ds.GetSettingValueExisted(types.SettingNameDefaultLonghornStaticStorageClass)
+	if err != nil {
+		return err
+	}
+
+	if _, err := vcc.ds.GetPersistentVolumeRO(vol.Name); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		// Mount the clone with the filesystem of the volume to revert
+		fsType := "ext4"
+		source, err := vcc.ds.GetVolumeRO(vol.Labels[types.GetLonghornLabelKey(types.LonghornLabelRevertPreviewOf)])
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		if source != nil && source.Status.KubernetesStatus.PVName != "" {
+			sourcePV, err := vcc.ds.GetPersistentVolumeRO(source.Status.KubernetesStatus.PVName)
+			if err != nil && !apierrors.IsNotFound(err) {
+				return err
+			}
+			if sourcePV != nil && sourcePV.Spec.CSI != nil && sourcePV.Spec.CSI.FSType != "" {
+				fsType = sourcePV.Spec.CSI.FSType
+			}
+		}
+		if _, err := vcc.ds.CreatePersistentVolume(datastore.NewPVManifestForVolume(vol, vol.Name, storageClassName, fsType)); err != nil && !apierrors.IsAlreadyExists(err) {
+			return errors.Wrapf(err, "failed to create PV for revert preview volume %v", vol.Name)
+		}
+	}
+
+	if _, err := vcc.ds.GetPersistentVolumeClaimRO(vcc.namespace, vol.Name); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		pvc := datastore.NewPVCManifestForVolume(vol, vol.Name, vcc.namespace, vol.Name, storageClassName)
+		pvc.OwnerReferences = datastore.GetOwnerReferencesForVolume(vol)
+		if _, err := vcc.ds.CreatePersistentVolumeClaim(vcc.namespace, pvc); err != nil && !apierrors.IsAlreadyExists(err) {
+			return errors.Wrapf(err, "failed to create PVC for revert preview volume %v", vol.Name)
+		}
+	}
+
+	podName := types.GetRevertPreviewPodName(vol.Name)
+	if existingPod, err := vcc.ds.GetPodRO(vcc.namespace, podName); err != nil || existingPod != nil {
+		return err
+	}
+	pod, err := vcc.newRevertPreviewPodManifest(vol, podName)
+	if err != nil {
+		return err
+	}
+	if _, err := vcc.ds.CreatePod(pod); err != nil && !apierrors.IsAlreadyExists(err) {
+		return errors.Wrapf(err, "failed to create maintenance pod for revert preview volume %v", vol.Name)
+	}
+	vcc.eventRecorder.Eventf(vol, corev1.EventTypeNormal, constant.EventReasonCreate,
+		"Mounted revert preview volume %v read-only at %v in pod %v", vol.Name, types.RevertPreviewMountPath, podName)
+	return nil
+}
+
+func (vcc *VolumeCloneController) newRevertPreviewPodManifest(vol *longhorn.Volume, podName string) (*corev1.Pod, error) {
+	tolerations, err := vcc.ds.GetSettingTaintToleration()
+	if err != nil {
+		return nil, err
+	}
+	imagePullPolicy, err := vcc.ds.GetSettingImagePullPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            podName,
+			Namespace:       vcc.namespace,
+			OwnerReferences: datastore.GetOwnerReferencesForVolume(vol),
+			Labels: types.GetRevertPreviewLabels(vol.Labels[types.GetLonghornLabelKey(types.LonghornLabelRevertPreviewOf)],
+				vol.Labels[types.GetLonghornLabelKey(types.LonghornLabelRevertPreviewSnapshot)]),
+		},
+		Spec: corev1.PodSpec{
+			NodeName:    vol.Annotations[types.GetLonghornLabelKey(types.RevertPreviewNodeAnnotationKeySuffix)],
+			Tolerations: tolerations,
+			Containers: []corev1.Container{
+				{
+					Name: "revert-preview",
+					// The engine image is always available on the nodes, and has a shell to inspect the data
+					Image:           vol.Spec.Image,
+					ImagePullPolicy: imagePullPolicy,
+					Command:         []string{"sleep", "infinity"},
+					VolumeMounts: []corev1.VolumeMount{
+						{
+							Name:      "preview",
+							MountPath: types.RevertPreviewMountPath,
+							ReadOnly:  true,
+						},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{
+					Name: "preview",
+					VolumeSource: corev1.VolumeSource{
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: vol.Name,
+							ReadOnly:  true,
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// cleanupRevertPreview deletes the maintenance pod, the PVC and the PV of the revert preview volume, then the volume once
+// the PV is gone.
+func (vcc *VolumeCloneController) cleanupRevertPreview(vol *longhorn.Volume) error {
+	if err := vcc.ds.DeletePod(types.GetRevertPreviewPodName(vol.Name)); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err := vcc.ds.DeletePersistentVolumeClaim(vcc.namespace, vol.Name); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err := vcc.ds.DeletePersistentVolume(vol.Name); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if _, err := vcc.ds.GetPersistentVolumeRO(vol.Name); err == nil {
+		vcc.enqueueVolumeAfter(vol, revertPreviewCleanupRetryPeriod)
+		return nil
+	} else if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	if vol.DeletionTimestamp != nil {
+		return nil
+	}
+	if err := vcc.ds.DeleteVolume(vol.Name); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	vcc.logger.Infof("Deleted expired revert preview volume %v", vol.Name)
+	return nil
+}
+
 func (vcc *VolumeCloneController) isResponsibleFor(vol *longhorn.Volume) bool {
 	return vcc.controllerID == vol.Status.OwnerID
 }
@@ -0,0 +1,208 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/kubernetes/pkg/controller"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/types"
+	"github.com/longhorn/longhorn-manager/util"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+	lhfake "github.com/longhorn/longhorn-manager/k8s/pkg/client/clientset/versioned/fake"
+
+	. "gopkg.in/check.v1"
+)
+
+const (
+	TestRevertPreviewVolumeName = "test-volume-preview"
+	TestRevertPreviewSnapshot   = "test-snapshot"
+)
+
+func newTestVolumeCloneController(lhClient *lhfake.Clientset, kubeClient *fake.Clientset, extensionsClient *apiextensionsfake.Clientset, informerFactories *util.InformerFactories) (*VolumeCloneController, error) {
+	ds := datastore.NewDataStore(TestNamespace, lhClient, kubeClient, extensionsClient, informerFactories)
+
+	logger := logrus.StandardLogger()
+	vcc, err := NewVolumeCloneController(logger, ds, scheme.Scheme, kubeClient, TestNode1, TestNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	vcc.eventRecorder = record.NewFakeRecorder(100)
+	for index := range vcc.cacheSyncs {
+		vcc.cacheSyncs[index] = alwaysReady
+	}
+	return vcc, nil
+}
+
+func newRevertPreviewVolume(expireAt time.Time, cloneState longhorn.VolumeCloneState) *longhorn.Volume {
+	v := newVolume(TestRevertPreviewVolumeName, 1)
+	v.Namespace = TestNamespace
+	v.Labels = types.GetRevertPreviewLabels(TestVolumeName, TestRevertPreviewSnapshot)
+	v.Annotations = map[string]string{
+		types.GetLonghornLabelKey(types.RevertPreviewExpireAtAnnotationKeySuffix): expireAt.UTC().Format(time.RFC3339),
+		types.GetLonghornLabelKey(types.RevertPreviewNodeAnnotationKeySuffix):     TestNode1,
+	}
+	v.Status.CloneStatus.State = cloneState
+	return v
+}
+
+func (s *TestSuite) TestReconcileRevertPreview(c *C) {
+	podName := types.GetRevertPreviewPodName(TestRevertPreviewVolumeName)
+
+	type testCase struct {
+		expireAt   time.Time
+		cloneState longhorn.VolumeCloneState
+		deleting   bool
+		sourcePV   *corev1.PersistentVolume
+		existing   bool
+		pvRemoved  bool
+
+		expectExpired       bool
+		expectPod           bool
+		expectFSType        string
+		expectVolumeDeleted bool
+	}
+	testCases := map[string]testCase{
+		"clone in progress": {
+			expireAt:   time.Now().Add(time.Hour),
+			cloneState: longhorn.VolumeCloneStateInitiated,
+		},
+		"clone completed": {
+			expireAt:     time.Now().Add(time.Hour),
+			cloneState:   longhorn.VolumeCloneStateCompleted,
+			expectPod:    true,
+			expectFSType: "ext4",
+		},
+		"clone completed with the filesystem of the source volume": {
+			expireAt:   time.Now().Add(time.Hour),
+			cloneState: longhorn.VolumeCloneStateCompleted,
+			sourcePV: &corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: TestPVName},
+				Spec: corev1.PersistentVolumeSpec{
+					PersistentVolumeSource: corev1.PersistentVolumeSource{
+						CSI: &corev1.CSIPersistentVolumeSource{Driver: types.LonghornDriverName, FSType: "xfs"},
+					},
+				},
+			},
+			expectPod:    true,
+			expectFSType: "xfs",
+		},
+		"pod already created": {
+			expireAt:     time.Now().Add(time.Hour),
+			cloneState:   longhorn.VolumeCloneStateCompleted,
+			existing:     true,
+			expectPod:    true,
+			expectFSType: "ext4",
+		},
+		"expired while the PV is removed": {
+			expireAt:      time.Now().Add(-time.Minute),
+			cloneState:    longhorn.VolumeCloneStateCompleted,
+			existing:      true,
+			expectExpired: true,
+		},
+		"expired after the PV is removed": {
+			expireAt:            time.Now().Add(-time.Minute),
+			cloneState:          longhorn.VolumeCloneStateCompleted,
+			existing:            true,
+			pvRemoved:           true,
+			expectExpired:       true,
+			expectVolumeDeleted: true,
+		},
+		"expired and deleting": {
+			expireAt:      time.Now().Add(-time.Minute),
+			cloneState:    longhorn.VolumeCloneStateCompleted,
+			deleting:      true,
+			expectExpired: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		fmt.Printf("testing %v\n", name)
+
+		kubeClient := fake.NewSimpleClientset()
+		lhClient := lhfake.NewSimpleClientset()
+		extensionsClient := apiextensionsfake.NewSimpleClientset()
+		informerFactories := util.NewInformerFactories(TestNamespace, kubeClient, lhClient, controller.NoResyncPeriodFunc())
+
+		volumeIndexer := informerFactories.LhInformerFactory.Longhorn().V1beta2().Volumes().Informer().GetIndexer()
+		pvIndexer := informerFactories.KubeInformerFactory.Core().V1().PersistentVolumes().Informer().GetIndexer()
+		pvcIndexer := informerFactories.KubeInformerFactory.Core().V1().PersistentVolumeClaims().Informer().GetIndexer()
+		podIndexer := informerFactories.KubeInformerFactory.Core().V1().Pods().Informer().GetIndexer()
+
+		vcc, err := newTestVolumeCloneController(lhClient, kubeClient, extensionsClient, informerFactories)
+		c.Assert(err, IsNil)
+
+		vol := newRevertPreviewVolume(tc.expireAt, tc.cloneState)
+		if tc.deleting {
+			now := metav1.Now()
+			vol.DeletionTimestamp = &now
+		}
+		vol, err = lhClient.LonghornV1beta2().Volumes(TestNamespace).Create(context.TODO(), vol, metav1.CreateOptions{})
+		c.Assert(err, IsNil)
+		c.Assert(volumeIndexer.Add(vol), IsNil)
+
+		if tc.sourcePV != nil {
+			source := newVolume(TestVolumeName, 2)
+			source.Namespace = TestNamespace
+			source.Status.KubernetesStatus.PVName = tc.sourcePV.Name
+			c.Assert(volumeIndexer.Add(source), IsNil)
+			c.Assert(pvIndexer.Add(tc.sourcePV), IsNil)
+		}
+
+		if tc.existing {
+			c.Assert(vcc.createRevertPreviewPod(vol), IsNil)
+			pv, err := kubeClient.CoreV1().PersistentVolumes().Get(context.TODO(), vol.Name, metav1.GetOptions{})
+			c.Assert(err, IsNil)
+			if !tc.pvRemoved {
+				c.Assert(pvIndexer.Add(pv), IsNil)
+			}
+			pvc, err := kubeClient.CoreV1().PersistentVolumeClaims(TestNamespace).Get(context.TODO(), vol.Name, metav1.GetOptions{})
+			c.Assert(err, IsNil)
+			c.Assert(pvcIndexer.Add(pvc), IsNil)
+			pod, err := kubeClient.CoreV1().Pods(TestNamespace).Get(context.TODO(), podName, metav1.GetOptions{})
+			c.Assert(err, IsNil)
+			c.Assert(podIndexer.Add(pod), IsNil)
+		}
+
+		expired, err := vcc.reconcileRevertPreview(vol)
+		c.Assert(err, IsNil, Commentf("%v", name))
+		c.Assert(expired, Equals, tc.expectExpired, Commentf("%v", name))
+
+		pod, err := kubeClient.CoreV1().Pods(TestNamespace).Get(context.TODO(), podName, metav1.GetOptions{})
+		if !tc.expectPod {
+			c.Assert(apierrors.IsNotFound(err), Equals, true, Commentf("%v", name))
+			_, err = kubeClient.CoreV1().PersistentVolumes().Get(context.TODO(), vol.Name, metav1.GetOptions{})
+			c.Assert(apierrors.IsNotFound(err), Equals, true, Commentf("%v", name))
+			_, err = kubeClient.CoreV1().PersistentVolumeClaims(TestNamespace).Get(context.TODO(), vol.Name, metav1.GetOptions{})
+			c.Assert(apierrors.IsNotFound(err), Equals, true, Commentf("%v", name))
+		} else {
+			c.Assert(err, IsNil, Commentf("%v", name))
+			c.Assert(pod.Spec.NodeName, Equals, TestNode1, Commentf("%v", name))
+			c.Assert(pod.Spec.Containers[0].VolumeMounts[0].ReadOnly, Equals, true, Commentf("%v", name))
+			c.Assert(pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName, Equals, vol.Name, Commentf("%v", name))
+
+			pv, err := kubeClient.CoreV1().PersistentVolumes().Get(context.TODO(), vol.Name, metav1.GetOptions{})
+			c.Assert(err, IsNil, Commentf("%v", name))
+			c.Assert(pv.Spec.CSI.FSType, Equals, tc.expectFSType, Commentf("%v", name))
+			_, err = kubeClient.CoreV1().PersistentVolumeClaims(TestNamespace).Get(context.TODO(), vol.Name, metav1.GetOptions{})
+			c.Assert(err, IsNil, Commentf("%v", name))
+		}
+
+		_, err = lhClient.LonghornV1beta2().Volumes(TestNamespace).Get(context.TODO(), vol.Name, metav1.GetOptions{})
+		c.Assert(apierrors.IsNotFound(err), Equals, tc.expectVolumeDeleted, Commentf("%v", name))
+	}
+}
@@ -13,6 +13,9 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/sirupsen/logrus"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -37,7 +40,9 @@ import (
 	"github.com/longhorn/longhorn-manager/constant"
 	"github.com/longhorn/longhorn-manager/datastore"
 	"github.com/longhorn/longhorn-manager/engineapi"
+	"github.com/longhorn/longhorn-manager/metrics_collector/operation"
 	"github.com/longhorn/longhorn-manager/scheduler"
+	"github.com/longhorn/longhorn-manager/tracing"
 	"github.com/longhorn/longhorn-manager/types"
 	"github.com/longhorn/longhorn-manager/util"
 
@@ -84,6 +89,9 @@ type VolumeController struct {
 
 	backoff *flowcontrol.Backoff
 
+	// keeps the start times of attachment and detachment for the operation metrics
+	operationTracker *operation.Tracker
+
 	// for unit test
 	nowHandler func() string
 
@@ -118,6 +126,8 @@ func NewVolumeController(
 
 		backoff: flowcontrol.NewBackOff(time.Minute, time.Minute*3),
 
+		operationTracker: operation.NewTracker(),
+
 		nowHandler: util.Now,
 
 		proxyConnCounter: proxyConnCounter,
@@ -233,7 +243,7 @@ func (c *VolumeController) processNextWorkItem() bool {
 	}
 	defer c.queue.Done(key)
 
-	err := c.syncVolume(key.(string))
+	err := c.syncVolume(c.newReconcileLogger(), key.(string))
 	c.handleErr(err, key)
 
 	return true
@@ -258,9 +268,8 @@ func (c *VolumeController) handleErr(err error, key interface{}) {
 }
 
 func getLoggerForVolume(logger logrus.FieldLogger, v *longhorn.Volume) *logrus.Entry {
-	log := logger.WithFields(
+	log := getLoggerForResource(logger, v.Name, "", "", v.Status.CurrentNodeID).WithFields(
 		logrus.Fields{
-			"volume":     v.Name,
 			"frontend":   v.Spec.Frontend,
 			"state":      v.Status.State,
 			"owner":      v.Status.OwnerID,
@@ -269,7 +278,7 @@ func getLoggerForVolume(logger logrus.FieldLogger, v *longhorn.Volume) *logrus.E
 		},
 	)
 
-	if v.Spec.AccessMode == longhorn.AccessModeReadWriteMany {
+	if types.IsSharedAccessMode(v.Spec.AccessMode) {
 		log = log.WithFields(
 			logrus.Fields{
 				"shareState":    v.Status.ShareState,
@@ -280,7 +289,33 @@ func getLoggerForVolume(logger logrus.FieldLogger, v *longhorn.Volume) *logrus.E
 	return log
 }
 
-func (c *VolumeController) syncVolume(key string) (err error) {
+// recordAttachmentOperation records the attachment or detachment duration once the volume leaves the attaching or
+// detaching state.
+func (c *VolumeController) recordAttachmentOperation(existingVolume, volume *longhorn.Volume) {
+	oldState, newState := existingVolume.Status.State, volume.Status.State
+	if oldState == newState {
+		return
+	}
+
+	dataEngine := string(volume.Spec.DataEngine)
+	switch oldState {
+	case longhorn.VolumeStateAttaching:
+		operation.ObserveDuration(operation.OperationAttach, dataEngine, operation.Outcome(newState != longhorn.VolumeStateAttached),
+			c.operationTracker.Finish(operation.OperationAttach, volume.Name))
+	case longhorn.VolumeStateDetaching:
+		operation.ObserveDuration(operation.OperationDetach, dataEngine, operation.Outcome(newState != longhorn.VolumeStateDetached),
+			c.operationTracker.Finish(operation.OperationDetach, volume.Name))
+	}
+
+	switch newState {
+	case longhorn.VolumeStateAttaching:
+		c.operationTracker.Start(operation.OperationAttach, volume.Name, time.Now())
+	case longhorn.VolumeStateDetaching:
+		c.operationTracker.Start(operation.OperationDetach, volume.Name, time.Now())
+	}
+}
+
+func (c *VolumeController) syncVolume(logger logrus.FieldLogger, key string) (err error) {
 	defer func() {
 		err = errors.Wrapf(err, "failed to sync %v", key)
 	}()
@@ -301,7 +336,7 @@ func (c *VolumeController) syncVolume(key string) (err error) {
 		return err
 	}
 
-	log := getLoggerForVolume(c.logger, volume)
+	log := getLoggerForVolume(logger, volume)
 
 	defaultEngineImage, err := c.ds.GetSettingValueExisted(types.SettingNameDefaultEngineImage)
 	if err != nil {
@@ -312,6 +347,7 @@ func (c *VolumeController) syncVolume(key string) (err error) {
 		return err
 	}
 	if !isResponsible {
+		c.operationTracker.Forget(volume.Name)
 		return nil
 	}
 
@@ -328,6 +364,13 @@ func (c *VolumeController) syncVolume(key string) (err error) {
 		log.Infof("Volume got new owner %v", c.controllerID)
 	}
 
+	changed := false
+	ctx, span := tracing.StartSpanForObject(volume, controllerTracerName, "VolumeController.syncVolume",
+		trace.WithAttributes(attribute.String("longhorn.volume", volume.Name)))
+	defer func() {
+		tracing.EndReconcileSpan(span, changed, err)
+	}()
+
 	engines, err := c.ds.ListVolumeEngines(volume.Name)
 	if err != nil {
 		return err
@@ -342,16 +385,18 @@ func (c *VolumeController) syncVolume(key string) (err error) {
 	}
 
 	if volume.DeletionTimestamp != nil {
+		changed = true
 		if volume.Status.State != longhorn.VolumeStateDeleting {
 			volume.Status.State = longhorn.VolumeStateDeleting
 			volume, err = c.ds.UpdateVolumeStatus(volume)
 			if err != nil {
 				return err
 			}
+			c.operationTracker.Forget(volume.Name)
 			c.eventRecorder.Eventf(volume, corev1.EventTypeNormal, constant.EventReasonDelete, "Deleting volume %v", volume.Name)
 		}
 
-		if volume.Spec.AccessMode == longhorn.AccessModeReadWriteMany && !volume.Spec.Migratable {
+		if types.IsSharedAccessMode(volume.Spec.AccessMode) && !volume.Spec.Migratable {
 			log.Info("Removing share manager for deleted volume")
 			if err := c.ds.DeleteShareManager(volume.Name); err != nil && !datastore.ErrorIsNotFound(err) {
 				return err
@@ -388,7 +433,7 @@ func (c *VolumeController) syncVolume(key string) (err error) {
 					}
 				}
 				if !replicaDeleted {
-					c.logger.Infof("Volume (%s) %v still has engines %v, so skip deleting its replicas until the engines have been deleted",
+					logger.Infof("Volume (%s) %v still has engines %v, so skip deleting its replicas until the engines have been deleted",
 						volume.Spec.DataEngine, volume.Name, engines)
 					return nil
 				}
@@ -457,6 +502,8 @@ func (c *VolumeController) syncVolume(key string) (err error) {
 		for k, r := range replicas {
 			if existingReplicas[k] == nil ||
 				!reflect.DeepEqual(existingReplicas[k].Spec, r.Spec) {
+				changed = true
+				tracing.InjectAnnotations(ctx, r)
 				if _, err := c.ds.UpdateReplica(r); err != nil {
 					lastErr = err
 				}
@@ -467,6 +514,8 @@ func (c *VolumeController) syncVolume(key string) (err error) {
 			for k, e := range engines {
 				if existingEngines[k] == nil ||
 					!reflect.DeepEqual(existingEngines[k].Spec, e.Spec) {
+					changed = true
+					tracing.InjectAnnotations(ctx, e)
 					if _, err := c.ds.UpdateEngine(e); err != nil {
 						lastErr = err
 					}
@@ -478,7 +527,10 @@ func (c *VolumeController) syncVolume(key string) (err error) {
 			// Make sure that we don't update condition's LastTransitionTime if the condition's values hasn't changed
 			handleConditionLastTransitionTime(&existingVolume.Status, &volume.Status)
 			if !reflect.DeepEqual(existingVolume.Status, volume.Status) {
-				_, lastErr = c.ds.UpdateVolumeStatus(volume)
+				changed = true
+				if _, lastErr = c.ds.UpdateVolumeStatus(volume); lastErr == nil {
+					c.recordAttachmentOperation(existingVolume, volume)
+				}
 			}
 		}
 		if err == nil {
@@ -536,6 +588,10 @@ func (c *VolumeController) syncVolume(key string) (err error) {
 		return err
 	}
 
+	if err := c.reconcileStorageTier(volume, engines); err != nil {
+		return err
+	}
+
 	if err := c.cleanupReplicas(volume, engines, replicas); err != nil {
 		return err
 	}
@@ -557,6 +613,77 @@ func handleConditionLastTransitionTime(existingStatus, newStatus *longhorn.Volum
 	}
 }
 
+// reconcileStorageTier decides the storage tier of the volume by its tiering policy. The volume is in the hot tier
+// while a workload attaches it with enough IOPS, and is moved to the cold tier once it has been idle, detached or with
+// low IOPS, for the configured days. The replicas on the disks of the other tier are then evicted by the node
+// controller.
+func (c *VolumeController) reconcileStorageTier(v *longhorn.Volume, es map[string]*longhorn.Engine) error {
+	if !types.IsVolumeTieringEnabled(v) {
+		v.Status.StorageTier = longhorn.StorageTierNone
+		v.Status.IdleSince = ""
+		return nil
+	}
+
+	va, err := c.ds.GetLHVolumeAttachmentByVolumeName(v.Name)
+	if err != nil {
+		if datastore.ErrorIsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	tier := longhorn.StorageTierHot
+	lowIOPSSince := ""
+	if e, err := c.ds.PickVolumeCurrentEngine(v, es); err == nil && e != nil {
+		lowIOPSSince = e.Status.LowIOPSSince
+	}
+	if isVolumeAttachedByWorkload(va) && lowIOPSSince == "" {
+		v.Status.IdleSince = ""
+	} else {
+		if v.Status.IdleSince == "" {
+			v.Status.IdleSince = lowIOPSSince
+		}
+		if v.Status.IdleSince == "" {
+			v.Status.IdleSince = util.Now()
+		}
+		idleSince, err := util.ParseTime(v.Status.IdleSince)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse idle time %v", v.Status.IdleSince)
+		}
+
+		coldAt := idleSince.Add(time.Duration(v.Spec.TieringPolicy.ColdAfterDetachedDays) * 24 * time.Hour)
+		if now := time.Now(); now.Before(coldAt) {
+			c.enqueueVolumeAfter(v, coldAt.Sub(now))
+		} else {
+			tier = longhorn.StorageTierCold
+		}
+	}
+
+	if v.Status.StorageTier != tier {
+		if v.Status.StorageTier != longhorn.StorageTierNone {
+			c.eventRecorder.Eventf(v, corev1.EventTypeNormal, constant.EventReasonStorageTierChanged,
+				"Moving volume %v from the %v tier to the %v tier", v.Name, v.Status.StorageTier, tier)
+		}
+		v.Status.StorageTier = tier
+	}
+
+	return nil
+}
+
+// isVolumeAttachedByWorkload returns true if the volume is requested to be attached by a workload rather than by
+// Longhorn itself for maintenance.
+func isVolumeAttachedByWorkload(va *longhorn.VolumeAttachment) bool {
+	for _, ticket := range va.Spec.AttachmentTickets {
+		switch ticket.Type {
+		case longhorn.AttacherTypeCSIAttacher,
+			longhorn.AttacherTypeLonghornAPI,
+			longhorn.AttacherTypeShareManagerController:
+			return true
+		}
+	}
+	return false
+}
+
 // EvictReplicas do creating one more replica for eviction, if requested
 func (c *VolumeController) EvictReplicas(v *longhorn.Volume,
 	e *longhorn.Engine, rs map[string]*longhorn.Replica, healthyCount int) (err error) {
@@ -742,6 +869,7 @@ func (c *VolumeController) ReconcileEngineReplicaState(v *longhorn.Volume, es ma
 				// Set HealthyAt to distinguish this replica from one that has never been rebuilt.
 				r.Spec.HealthyAt = now
 				r.Spec.RebuildRetryCount = 0
+				delete(r.Labels, types.GetLonghornLabelKey(types.LonghornLabelReplacementDiskRebuild))
 			}
 			// Set LastHealthyAt to record the last time this replica became RW in an engine.
 			if transitionTime, ok := e.Status.ReplicaTransitionTimeMap[rName]; !ok {
@@ -1744,6 +1872,45 @@ func (c *VolumeController) reconcileLogRequest(e *longhorn.Engine, rs map[string
 	}
 }
 
+// getCapacityAtRiskDisks returns the disks, in the form of <node>/<disk>, that hold the replicas and are projected to
+// become full within the forecast days.
+func (c *VolumeController) getCapacityAtRiskDisks(rs map[string]*longhorn.Replica) ([]string, error) {
+	forecastDays, err := c.ds.GetSettingAsInt(types.SettingNameStorageCapacityForecastDays)
+	if err != nil {
+		return nil, err
+	}
+	if forecastDays <= 0 {
+		return nil, nil
+	}
+
+	now := time.Now()
+	atRiskDisks := map[string]bool{}
+	for _, r := range rs {
+		if r.Spec.NodeID == "" || r.Spec.DiskID == "" {
+			continue
+		}
+		node, err := c.ds.GetNodeRO(r.Spec.NodeID)
+		if err != nil {
+			if datastore.ErrorIsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		for diskName, diskStatus := range node.Status.DiskStatus {
+			if diskStatus.DiskUUID == r.Spec.DiskID && types.IsDiskCapacityAtRisk(diskStatus, forecastDays, now) {
+				atRiskDisks[node.Name+"/"+diskName] = true
+			}
+		}
+	}
+
+	result := make([]string, 0, len(atRiskDisks))
+	for disk := range atRiskDisks {
+		result = append(result, disk)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
 func (c *VolumeController) reconcileVolumeCondition(v *longhorn.Volume, e *longhorn.Engine,
 	rs map[string]*longhorn.Replica, log *logrus.Entry) error {
 	numSnapshots := len(e.Status.Snapshots) - 1 // Counting volume-head here would be confusing.
@@ -1759,6 +1926,21 @@ func (c *VolumeController) reconcileVolumeCondition(v *longhorn.Volume, e *longh
 			"", "")
 	}
 
+	atRiskDisks, err := c.getCapacityAtRiskDisks(rs)
+	if err != nil {
+		return err
+	}
+	if len(atRiskDisks) > 0 {
+		v.Status.Conditions = types.SetCondition(v.Status.Conditions,
+			longhorn.VolumeConditionTypeCapacityAtRisk, longhorn.ConditionStatusTrue,
+			longhorn.VolumeConditionReasonDiskProjectedFull,
+			fmt.Sprintf("Disks %v of the volume replicas are projected to become full", atRiskDisks))
+	} else {
+		v.Status.Conditions = types.SetCondition(v.Status.Conditions,
+			longhorn.VolumeConditionTypeCapacityAtRisk, longhorn.ConditionStatusFalse,
+			"", "")
+	}
+
 	scheduled := true
 	aggregatedScheduledErrs := multierr.NewMultiError()
 	for _, r := range rs {
@@ -2333,7 +2515,9 @@ func (c *VolumeController) replenishReplicas(v *longhorn.Volume, e *longhorn.Eng
 		}
 
 		if reusableFailedReplica != nil {
-			if !c.backoff.IsInBackOffSinceUpdate(reusableFailedReplica.Name, time.Now()) {
+			// The replicas moved to a replacement disk have no data to retry with, so they are rebuilt without backoff.
+			if datastore.IsReplacementDiskRebuildReplica(reusableFailedReplica) ||
+				!c.backoff.IsInBackOffSinceUpdate(reusableFailedReplica.Name, time.Now()) {
 				log.Infof("Failed replica %v will be reused during rebuilding", reusableFailedReplica.Name)
 				setReplicaFailedAt(reusableFailedReplica, "")
 				reusableFailedReplica.Spec.HealthyAt = ""
@@ -4721,7 +4905,7 @@ func (c *VolumeController) ReconcileShareManagerState(volume *longhorn.Volume) e
 		return errors.Wrapf(err, "failed to get share manager for volume %v", volume.Name)
 	}
 
-	if volume.Spec.AccessMode != longhorn.AccessModeReadWriteMany || volume.Spec.Migratable {
+	if !types.IsSharedAccessMode(volume.Spec.AccessMode) || volume.Spec.Migratable {
 		if sm != nil {
 			log.Info("Removing share manager for non shared volume")
 			if err := c.ds.DeleteShareManager(volume.Name); err != nil && !datastore.ErrorIsNotFound(err) {
@@ -4749,6 +4933,15 @@ func (c *VolumeController) ReconcileShareManagerState(volume *longhorn.Volume) e
 		log.Infof("Updated image for share manager from %v to %v", sm.Spec.Image, c.smImage)
 	}
 
+	if exportOptions := getShareManagerNFSExportOptions(volume); !reflect.DeepEqual(sm.Spec.NFSExportOptions, exportOptions) {
+		sm.Spec.NFSExportOptions = exportOptions
+		if sm, err = c.ds.UpdateShareManager(sm); err != nil {
+			return err
+		}
+
+		log.Infof("Updated NFS export options for share manager to %+v", sm.Spec.NFSExportOptions)
+	}
+
 	// Give the workload pods a chance to restart when the share manager goes into error state.
 	// Easiest approach is to set the RemountRequestedAt variable.  Pods will make that decision
 	// in the kubernetes_pod_controller.
@@ -4773,13 +4966,24 @@ func (c *VolumeController) createShareManagerForVolume(volume *longhorn.Volume,
 			OwnerReferences: datastore.GetOwnerReferencesForVolume(volume),
 		},
 		Spec: longhorn.ShareManagerSpec{
-			Image: image,
+			Image:            image,
+			NFSExportOptions: getShareManagerNFSExportOptions(volume),
 		},
 	}
 
 	return c.ds.CreateShareManager(sm)
 }
 
+// getShareManagerNFSExportOptions returns the NFS export options of the share manager of the volume. A ROX volume is
+// always exported read-only.
+func getShareManagerNFSExportOptions(volume *longhorn.Volume) longhorn.NFSExportOptions {
+	options := volume.Spec.NFSExportOptions
+	if volume.Spec.AccessMode == longhorn.AccessModeReadOnlyMany {
+		options.ReadOnly = true
+	}
+	return options
+}
+
 // enqueueVolumesForBackupVolume enqueues the volumes which is/are DR volumes or
 // the volume name matches backup volume name
 func (c *VolumeController) enqueueVolumesForBackupVolume(obj interface{}) {
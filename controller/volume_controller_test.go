@@ -1150,6 +1150,10 @@ func newVolume(name string, replicaCount int) *longhorn.Volume {
 					Type:   string(longhorn.VolumeConditionTypeTooManySnapshots),
 					Status: longhorn.ConditionStatusFalse,
 				},
+				{
+					Type:   string(longhorn.VolumeConditionTypeCapacityAtRisk),
+					Status: longhorn.ConditionStatusFalse,
+				},
 				{
 					Type:   string(longhorn.VolumeConditionTypeScheduled),
 					Status: longhorn.ConditionStatusTrue,
@@ -1510,7 +1514,7 @@ func (s *TestSuite) runTestCases(c *C, testCases map[string]*VolumeTestCase) {
 			}
 		}
 
-		err = vc.syncVolume(getKey(v, c))
+		err = vc.syncVolume(vc.newReconcileLogger(), getKey(v, c))
 		c.Assert(err, IsNil)
 
 		retV, err := lhClient.LonghornV1beta2().Volumes(TestNamespace).Get(context.TODO(), v.Name, metav1.GetOptions{})
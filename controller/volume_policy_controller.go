@@ -0,0 +1,263 @@
+package controller
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/kubernetes/pkg/controller"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+
+	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/types"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+const (
+	VolumePolicyControllerName = "longhorn-volume-policy"
+)
+
+// VolumePolicyController reports the existing volumes violating the volume policies. The policies are enforced on
+// the new volumes and the updates by the admission webhook.
+type VolumePolicyController struct {
+	*baseController
+
+	// which namespace controller is running with
+	namespace string
+	// use as the OwnerID of the controller
+	controllerID string
+
+	ds *datastore.DataStore
+
+	cacheSyncs []cache.InformerSynced
+}
+
+func NewVolumePolicyController(
+	logger logrus.FieldLogger,
+	ds *datastore.DataStore,
+	namespace string,
+	controllerID string) (*VolumePolicyController, error) {
+
+	c := &VolumePolicyController{
+		baseController: newBaseController(VolumePolicyControllerName, logger),
+
+		namespace:    namespace,
+		controllerID: controllerID,
+
+		ds: ds,
+	}
+
+	var err error
+	if _, err = ds.VolumePolicyInformer.AddEventHandlerWithResyncPeriod(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueVolumePolicy,
+		UpdateFunc: func(old, cur interface{}) { c.enqueueVolumePolicy(cur) },
+		DeleteFunc: c.enqueueVolumePolicy,
+	}, 0); err != nil {
+		return nil, err
+	}
+	c.cacheSyncs = append(c.cacheSyncs, ds.VolumePolicyInformer.HasSynced)
+
+	if _, err = ds.VolumeInformer.AddEventHandlerWithResyncPeriod(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.enqueueVolumePoliciesForVolume,
+		UpdateFunc: func(old, cur interface{}) { c.enqueueVolumePoliciesForVolume(cur) },
+		DeleteFunc: c.enqueueVolumePoliciesForVolume,
+	}, 0); err != nil {
+		return nil, err
+	}
+	c.cacheSyncs = append(c.cacheSyncs, ds.VolumeInformer.HasSynced)
+
+	return c, nil
+}
+
+func (c *VolumePolicyController) enqueueVolumePolicy(obj interface{}) {
+	key, err := controller.KeyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("couldn't get key for object %#v: %v", obj, err))
+		return
+	}
+
+	c.queue.Add(key)
+}
+
+// enqueueVolumePoliciesForVolume enqueues all policies, since a changed volume may start or stop matching any of them.
+func (c *VolumePolicyController) enqueueVolumePoliciesForVolume(obj interface{}) {
+	policies, err := c.ds.ListVolumePoliciesRO()
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to list volume policies: %v", err))
+		return
+	}
+	for _, policy := range policies {
+		c.enqueueVolumePolicy(policy)
+	}
+}
+
+func (c *VolumePolicyController) Run(workers int, stopCh <-chan struct{}) {
+	defer utilruntime.HandleCrash()
+	defer c.queue.ShutDown()
+
+	c.logger.Info("Starting Longhorn VolumePolicy controller")
+	defer c.logger.Info("Shut down Longhorn VolumePolicy controller")
+
+	if !cache.WaitForNamedCacheSync(c.name, stopCh, c.cacheSyncs...) {
+		return
+	}
+	for i := 0; i < workers; i++ {
+		go wait.Until(c.worker, time.Second, stopCh)
+	}
+	<-stopCh
+}
+
+func (c *VolumePolicyController) worker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+func (c *VolumePolicyController) processNextWorkItem() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	err := c.syncVolumePolicy(c.newReconcileLogger(), key.(string))
+	c.handleErr(err, key)
+
+	return true
+}
+
+func (c *VolumePolicyController) handleErr(err error, key interface{}) {
+	if err == nil {
+		c.queue.Forget(key)
+		return
+	}
+
+	log := c.logger.WithField("VolumePolicy", key)
+
+	if c.queue.NumRequeues(key) < maxRetries {
+		handleReconcileErrorLogging(log, err, "Failed to sync VolumePolicy")
+		c.queue.AddRateLimited(key)
+		return
+	}
+
+	utilruntime.HandleError(err)
+	handleReconcileErrorLogging(log, err, "Dropping Longhorn VolumePolicy out of the queue")
+	c.queue.Forget(key)
+}
+
+func getLoggerForVolumePolicy(logger logrus.FieldLogger, policy *longhorn.VolumePolicy) *logrus.Entry {
+	return logger.WithField("volumePolicy", policy.Name)
+}
+
+func (c *VolumePolicyController) syncVolumePolicy(logger logrus.FieldLogger, key string) (err error) {
+	defer func() {
+		err = errors.Wrapf(err, "%v: fail to sync VolumePolicy %v", c.name, key)
+	}()
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	if namespace != c.namespace {
+		return nil
+	}
+
+	return c.reconcile(logger, name)
+}
+
+func (c *VolumePolicyController) reconcile(logger logrus.FieldLogger, name string) (err error) {
+	policy, err := c.ds.GetVolumePolicy(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	log := getLoggerForVolumePolicy(logger, policy)
+
+	if !c.isResponsibleFor(policy) {
+		return nil
+	}
+
+	if policy.Status.OwnerID != c.controllerID {
+		policy.Status.OwnerID = c.controllerID
+		policy, err = c.ds.UpdateVolumePolicyStatus(policy)
+		if err != nil {
+			// we don't mind others coming first
+			if apierrors.IsConflict(errors.Cause(err)) {
+				return nil
+			}
+			return err
+		}
+		log.Infof("Volume policy got new owner %v", c.controllerID)
+	}
+
+	existingPolicy := policy.DeepCopy()
+	defer func() {
+		if err != nil || reflect.DeepEqual(existingPolicy.Status, policy.Status) {
+			return
+		}
+		if _, err = c.ds.UpdateVolumePolicyStatus(policy); err != nil && apierrors.IsConflict(errors.Cause(err)) {
+			log.WithError(err).Debugf("Requeue %v due to conflict", name)
+			c.enqueueVolumePolicy(policy)
+			err = nil
+		}
+	}()
+
+	volumes, err := c.ds.ListVolumesRO()
+	if err != nil {
+		return err
+	}
+
+	matchedVolumeCount := 0
+	var violatingVolumes []longhorn.VolumePolicyViolation
+	for _, volume := range volumes {
+		matched, err := types.IsVolumePolicyMatched(policy, volume)
+		if err != nil {
+			return err
+		}
+		if !matched {
+			continue
+		}
+		matchedVolumeCount++
+
+		violations := types.GetVolumePolicyViolations(&policy.Spec, volume)
+		if len(violations) == 0 {
+			continue
+		}
+		violatingVolumes = append(violatingVolumes, longhorn.VolumePolicyViolation{
+			Volume:   volume.Name,
+			Messages: slices.Sorted(maps.Values(violations)),
+		})
+	}
+	sort.Slice(violatingVolumes, func(i, j int) bool { return violatingVolumes[i].Volume < violatingVolumes[j].Volume })
+
+	policy.Status.MatchedVolumeCount = matchedVolumeCount
+	policy.Status.ViolatingVolumes = violatingVolumes
+	if len(violatingVolumes) == 0 {
+		policy.Status.Conditions = types.SetCondition(policy.Status.Conditions,
+			longhorn.VolumePolicyConditionTypeCompliant, longhorn.ConditionStatusTrue, "", "")
+	} else {
+		policy.Status.Conditions = types.SetCondition(policy.Status.Conditions,
+			longhorn.VolumePolicyConditionTypeCompliant, longhorn.ConditionStatusFalse, longhorn.VolumePolicyConditionReasonViolatingVolumes,
+			fmt.Sprintf("%v of %v matched volumes violate the policy", len(violatingVolumes), matchedVolumeCount))
+	}
+
+	return nil
+}
+
+func (c *VolumePolicyController) isResponsibleFor(policy *longhorn.VolumePolicy) bool {
+	return isControllerResponsibleFor(c.controllerID, c.ds, policy.Name, "", policy.Status.OwnerID)
+}
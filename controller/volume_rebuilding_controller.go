@@ -313,7 +313,7 @@ func (vbc *VolumeRebuildingController) processNextWorkItem() bool {
 		return false
 	}
 	defer vbc.queue.Done(key)
-	err := vbc.syncHandler(key.(string))
+	err := vbc.syncHandler(vbc.newReconcileLogger(), key.(string))
 	vbc.handleErr(err, key)
 	return true
 }
@@ -329,7 +329,7 @@ func (vbc *VolumeRebuildingController) handleErr(err error, key interface{}) {
 	vbc.queue.AddRateLimited(key)
 }
 
-func (vbc *VolumeRebuildingController) syncHandler(key string) (err error) {
+func (vbc *VolumeRebuildingController) syncHandler(logger logrus.FieldLogger, key string) (err error) {
 	defer func() {
 		err = errors.Wrapf(err, "%v: failed to sync volume %v", vbc.name, key)
 	}()
@@ -341,10 +341,10 @@ func (vbc *VolumeRebuildingController) syncHandler(key string) (err error) {
 	if namespace != vbc.namespace {
 		return nil
 	}
-	return vbc.reconcile(name)
+	return vbc.reconcile(logger, name)
 }
 
-func (vbc *VolumeRebuildingController) reconcile(volName string) (err error) {
+func (vbc *VolumeRebuildingController) reconcile(logger logrus.FieldLogger, volName string) (err error) {
 	vol, err := vbc.ds.GetVolumeRO(volName)
 	if err != nil {
 		if !apierrors.IsNotFound(err) {
@@ -396,12 +396,12 @@ func (vbc *VolumeRebuildingController) reconcile(volName string) (err error) {
 	}
 
 	if !vol.DeletionTimestamp.IsZero() {
-		vbc.logger.Infof("Volume %v is deleting, skip offline rebuilding", volName)
+		logger.Infof("Volume %v is deleting, skip offline rebuilding", volName)
 		return nil
 	}
 
 	if vol.Status.Robustness == longhorn.VolumeRobustnessFaulted {
-		vbc.logger.Warnf("Volume %v is faulted, skip offline rebuilding", volName)
+		logger.Warnf("Volume %v is faulted, skip offline rebuilding", volName)
 		return nil
 	}
 
@@ -427,7 +427,7 @@ func (vbc *VolumeRebuildingController) reconcile(volName string) (err error) {
 		return err
 	}
 	if engine == nil {
-		vbc.logger.Warnf("Volume %v engine not found, skip offline rebuilding", volName)
+		logger.Warnf("Volume %v engine not found, skip offline rebuilding", volName)
 		return nil
 	}
 
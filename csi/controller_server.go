@@ -94,6 +94,7 @@ func NewControllerServer(apiClient *longhornclient.RancherClient, nodeID string)
 		accessModes: getVolumeCapabilityAccessModes(
 			[]csi.VolumeCapability_AccessMode_Mode{
 				csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER,
+				csi.VolumeCapability_AccessMode_MULTI_NODE_READER_ONLY,
 				csi.VolumeCapability_AccessMode_MULTI_NODE_MULTI_WRITER,
 			}),
 		log:         logrus.StandardLogger().WithField("component", "csi-controller-server"),
@@ -265,6 +266,11 @@ func (cs *ControllerServer) CreateVolume(ctx context.Context, req *csi.CreateVol
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	// a volume only requested in rox mode is shared with a read-only export and mounted read-only
+	if isReadOnlyManyRequested(volumeCaps) && !vol.Migratable {
+		vol.AccessMode = string(longhorn.AccessModeReadOnlyMany)
+	}
+
 	if err = cs.checkAndPrepareBackingImage(volumeID, vol.BackingImage, volumeParameters, vol.DataEngine); err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
@@ -278,6 +284,10 @@ func (cs *ControllerServer) CreateVolume(ctx context.Context, req *csi.CreateVol
 	// TODO: implement error response code for Longhorn API to differentiate different error type.
 	// For example, creating a volume from a non-existing snapshot should return codes.NotFound instead of codes.Internal
 	if err != nil {
+		var apiErr *longhornclient.ApiError
+		if errors.As(err, &apiErr) && apiErr.Code == types.StorageQuotaExceededCode {
+			return nil, status.Error(codes.ResourceExhausted, err.Error())
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
@@ -505,8 +515,12 @@ func (cs *ControllerServer) ControllerPublishVolume(ctx context.Context, req *cs
 		return nil, status.Errorf(codes.InvalidArgument, "volume %s invalid frontend type %s", volumeID, volume.Frontend)
 	}
 
-	if requiresSharedAccess(volume, volumeCapability) {
-		volume, err = cs.updateVolumeAccessMode(volume, longhorn.AccessModeReadWriteMany)
+	if requiresSharedAccess(volume, volumeCapability) && !types.IsSharedAccessMode(longhorn.AccessMode(volume.AccessMode)) {
+		accessMode := longhorn.AccessModeReadWriteMany
+		if isReadOnlyManyRequested([]*csi.VolumeCapability{volumeCapability}) && !volume.Migratable {
+			accessMode = longhorn.AccessModeReadOnlyMany
+		}
+		volume, err = cs.updateVolumeAccessMode(volume, accessMode)
 		if err != nil {
 			return nil, err
 		}
@@ -524,7 +538,7 @@ func (cs *ControllerServer) ControllerPublishVolume(ctx context.Context, req *cs
 
 	return cs.publishVolume(volume, nodeID, attachmentID, func() error {
 		checkVolumePublished := func(vol *longhornclient.Volume) bool {
-			isRegularRWXVolume := vol.AccessMode == string(longhorn.AccessModeReadWriteMany) && !vol.Migratable
+			isRegularRWXVolume := types.IsSharedAccessMode(longhorn.AccessMode(vol.AccessMode)) && !vol.Migratable
 			attachment, ok := vol.VolumeAttachment.Attachments[attachmentID]
 			if isRegularRWXVolume {
 				return ok && attachment.Satisfied
@@ -723,6 +737,10 @@ func (cs *ControllerServer) GetCapacity(ctx context.Context, req *csi.GetCapacit
 	if diskSelectorRaw, ok := scParameters["diskSelector"]; ok && len(diskSelectorRaw) > 0 {
 		diskSelector = strings.Split(diskSelectorRaw, ",")
 	}
+	// New volumes start in the hot tier
+	if hotDiskSelectorRaw, ok := scParameters["tieringHotDiskSelector"]; ok && len(hotDiskSelectorRaw) > 0 {
+		diskSelector = append(diskSelector, strings.Split(hotDiskSelectorRaw, ",")...)
+	}
 	allowEmptyDiskSelectorVolume, err := cs.getSettingAsBoolean(types.SettingNameAllowEmptyDiskSelectorVolume)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get setting %v: %v", types.SettingNameAllowEmptyDiskSelectorVolume, err)
@@ -1363,7 +1381,7 @@ func isEngineOnNodeAvailable(vol *longhornclient.Volume, node string) bool {
 }
 
 func isVolumeShareAvailable(vol *longhornclient.Volume) bool {
-	return vol.AccessMode == string(longhorn.AccessModeReadWriteMany) &&
+	return types.IsSharedAccessMode(longhorn.AccessMode(vol.AccessMode)) &&
 		vol.ShareState == string(longhorn.ShareManagerStateRunning) && vol.ShareEndpoint != ""
 }
 
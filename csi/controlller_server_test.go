@@ -3,6 +3,7 @@ package csi
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -340,3 +341,73 @@ func newSetting(name, value string) *longhorn.Setting {
 		Value: value,
 	}
 }
+
+func TestGetNodeTopologySegments(t *testing.T) {
+	labels := map[string]string{
+		types.KubernetesTopologyZoneLabelKey: "zone-1",
+		"topology.longhorn.io/rack":          "rack-1",
+		"unrelated":                          "value",
+	}
+
+	for name, test := range map[string]struct {
+		topologyLevels map[string]string
+		expected       map[string]string
+	}{
+		"no topology levels": {
+			topologyLevels: map[string]string{},
+			expected: map[string]string{
+				nodeTopologyKey: "node-0",
+			},
+		},
+		"topology levels": {
+			topologyLevels: map[string]string{
+				"rack": "topology.longhorn.io/rack",
+				"row":  "topology.longhorn.io/row",
+			},
+			expected: map[string]string{
+				nodeTopologyKey:                      "node-0",
+				types.KubernetesTopologyZoneLabelKey: "zone-1",
+				"topology.longhorn.io/rack":          "rack-1",
+			},
+		},
+	} {
+		segments := getNodeTopologySegments("node-0", labels, test.topologyLevels)
+		if !reflect.DeepEqual(segments, test.expected) {
+			t.Errorf("%v: expected segments: %v, but got: %v", name, test.expected, segments)
+		}
+	}
+}
+
+func TestIsReadOnlyManyRequested(t *testing.T) {
+	newCap := func(mode csi.VolumeCapability_AccessMode_Mode) *csi.VolumeCapability {
+		return &csi.VolumeCapability{AccessMode: &csi.VolumeCapability_AccessMode{Mode: mode}}
+	}
+
+	for _, test := range []struct {
+		caps     []*csi.VolumeCapability
+		expected bool
+	}{
+		{
+			expected: false,
+		},
+		{
+			caps:     []*csi.VolumeCapability{newCap(csi.VolumeCapability_AccessMode_MULTI_NODE_READER_ONLY)},
+			expected: true,
+		},
+		{
+			caps: []*csi.VolumeCapability{
+				newCap(csi.VolumeCapability_AccessMode_MULTI_NODE_READER_ONLY),
+				newCap(csi.VolumeCapability_AccessMode_MULTI_NODE_MULTI_WRITER),
+			},
+			expected: false,
+		},
+		{
+			caps:     []*csi.VolumeCapability{newCap(csi.VolumeCapability_AccessMode_SINGLE_NODE_WRITER)},
+			expected: false,
+		},
+	} {
+		if actual := isReadOnlyManyRequested(test.caps); actual != test.expected {
+			t.Errorf("expected read-only-many requested %v for capabilities %v, but got %v", test.expected, test.caps, actual)
+		}
+	}
+}
@@ -352,7 +352,7 @@ func csiDriverObjectGetFunc(kubeClient *clientset.Clientset, name, namespace str
 
 // CheckMountPropagationWithNode https://github.com/kubernetes/kubernetes/issues/66086#issuecomment-404346854
 func CheckMountPropagationWithNode(managerURL string) error {
-	clientOpts := &longhornclient.ClientOpts{Url: managerURL}
+	clientOpts := &longhornclient.ClientOpts{Url: managerURL, TokenFile: types.ServiceAccountTokenFile}
 	apiClient, err := longhornclient.NewRancherClient(clientOpts)
 	if err != nil {
 		return err
@@ -6,6 +6,8 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/sirupsen/logrus"
 
+	"github.com/longhorn/longhorn-manager/types"
+
 	longhornclient "github.com/longhorn/longhorn-manager/client"
 )
 
@@ -28,7 +30,7 @@ func (m *Manager) Run(driverName, nodeID, endpoint, identityVersion, managerURL
 	logrus.Infof("CSI Driver: %v version: %v, manager URL %v", driverName, identityVersion, managerURL)
 
 	// Longhorn API Client
-	clientOpts := &longhornclient.ClientOpts{Url: managerURL}
+	clientOpts := &longhornclient.ClientOpts{Url: managerURL, TokenFile: types.ServiceAccountTokenFile}
 	apiClient, err := initRancherClient(clientOpts)
 	if err != nil {
 		return err
@@ -7,6 +7,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -21,6 +22,7 @@ import (
 	"k8s.io/mount-utils"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clientset "k8s.io/client-go/kubernetes"
 	utilexec "k8s.io/utils/exec"
@@ -239,7 +241,7 @@ func (ns *NodeServer) NodePublishVolume(ctx context.Context, req *csi.NodePublis
 	}
 
 	mountOptions := []string{"bind"}
-	if req.GetReadonly() {
+	if req.GetReadonly() || longhorn.AccessMode(volume.AccessMode) == longhorn.AccessModeReadOnlyMany {
 		mountOptions = append(mountOptions, "ro")
 	}
 	mountOptions = append(mountOptions, volumeCapability.GetMount().GetMountFlags()...)
@@ -262,7 +264,7 @@ func (ns *NodeServer) collectWorkloadPodsStatus(volume *longhornclient.Volume, l
 	return podsStatus
 }
 
-func (ns *NodeServer) nodeStageSharedVolume(volumeID, shareEndpoint, targetPath string, mounter mount.Interface, customMountOptions []string) error {
+func (ns *NodeServer) nodeStageSharedVolume(volumeID, shareEndpoint, targetPath string, mounter mount.Interface, customMountOptions []string, readOnly bool) error {
 	log := ns.log.WithFields(logrus.Fields{"function": "nodeStageSharedVolume"})
 
 	isMnt, err := ensureMountPoint(targetPath, mounter)
@@ -303,12 +305,13 @@ func (ns *NodeServer) nodeStageSharedVolume(volumeID, shareEndpoint, targetPath
 	if len(customMountOptions) != 0 {
 		mountOptions = customMountOptions
 	}
+	mountOptions = getSharedVolumeMountOptions(mountOptions, readOnly)
 
 	log.Infof("Mounting shared volume %v on node %v via share endpoint %v with mount options %v", volumeID, ns.nodeID, shareEndpoint, mountOptions)
 	if err := mounter.Mount(export, targetPath, fsType, mountOptions); err != nil {
 		if len(customMountOptions) == 0 && strings.Contains(err.Error(), "an incorrect mount option was specified") {
 			log.WithError(err).Warnf("Failed to mount volume %v with default mount options, retrying with soft mount", volumeID)
-			mountOptions = append(defaultMountOptions, []string{"soft"}...)
+			mountOptions = getSharedVolumeMountOptions(append(defaultMountOptions, []string{"soft"}...), readOnly)
 			err = mounter.Mount(export, targetPath, fsType, mountOptions)
 			if err == nil {
 				return nil
@@ -330,6 +333,21 @@ func (ns *NodeServer) nodeStageSharedVolume(volumeID, shareEndpoint, targetPath
 	return nil
 }
 
+// getSharedVolumeMountOptions returns the NFS mount options of a shared volume. A ROX volume is mounted read-only on
+// top of its read-only export, so that the writes fail on the client instead of the share manager.
+func getSharedVolumeMountOptions(mountOptions []string, readOnly bool) []string {
+	if !readOnly || slices.Contains(mountOptions, "ro") {
+		return mountOptions
+	}
+	options := make([]string, 0, len(mountOptions)+1)
+	for _, option := range mountOptions {
+		if option != "rw" {
+			options = append(options, option)
+		}
+	}
+	return append(options, "ro")
+}
+
 func (ns *NodeServer) nodeStageMountVolume(volumeID, devicePath, stagingTargetPath, fsType string, mountFlags []string, mounter *mount.SafeFormatAndMount) error {
 	log := ns.log.WithFields(logrus.Fields{"function": "NodePublishVolume"})
 
@@ -466,7 +484,7 @@ func (ns *NodeServer) NodeStageVolume(ctx context.Context, req *csi.NodeStageVol
 	}
 
 	if requiresSharedAccess(volume, volumeCapability) && !volume.Migratable {
-		if volume.AccessMode != string(longhorn.AccessModeReadWriteMany) {
+		if !types.IsSharedAccessMode(longhorn.AccessMode(volume.AccessMode)) {
 			return nil, status.Errorf(codes.FailedPrecondition, "volume %s requires shared access but is not marked for shared use", volumeID)
 		}
 
@@ -481,7 +499,8 @@ func (ns *NodeServer) NodeStageVolume(ctx context.Context, req *csi.NodeStageVol
 			mountOptions = strings.Split(req.VolumeContext["nfsOptions"], ",")
 		}
 
-		if err := ns.nodeStageSharedVolume(volumeID, volume.ShareEndpoint, stagingTargetPath, mounter, mountOptions); err != nil {
+		readOnly := longhorn.AccessMode(volume.AccessMode) == longhorn.AccessModeReadOnlyMany
+		if err := ns.nodeStageSharedVolume(volumeID, volume.ShareEndpoint, stagingTargetPath, mounter, mountOptions, readOnly); err != nil {
 			return nil, err
 		}
 
@@ -810,7 +829,7 @@ func (ns *NodeServer) NodeExpandVolume(ctx context.Context, req *csi.NodeExpandV
 	}
 
 	if requiresSharedAccess(volume, volumeCapability) && !volume.Migratable {
-		if volume.AccessMode != string(longhorn.AccessModeReadWriteMany) {
+		if !types.IsSharedAccessMode(longhorn.AccessMode(volume.AccessMode)) {
 			return nil, status.Errorf(codes.FailedPrecondition, "volume %s requires shared access but is not marked for shared use", volumeID)
 		}
 
@@ -890,13 +909,31 @@ func (ns *NodeServer) NodeExpandVolume(ctx context.Context, req *csi.NodeExpandV
 }
 
 func (ns *NodeServer) NodeGetInfo(ctx context.Context, req *csi.NodeGetInfoRequest) (*csi.NodeGetInfoResponse, error) {
+	labels := map[string]string{}
+	topologyLevels := map[string]string{}
+
+	// Best effort to report the topology domains of the node once the topology levels are defined, the node is always
+	// accessible by its hostname
+	if setting, err := ns.lhClient.LonghornV1beta2().Settings(ns.lhNamespace).Get(ctx, string(types.SettingNameTopologyLevels), metav1.GetOptions{}); err != nil {
+		if !apierrors.IsNotFound(err) {
+			ns.log.WithError(err).Warnf("Failed to get setting %v for accessible topology", types.SettingNameTopologyLevels)
+		}
+	} else if topologyLevels, err = types.UnmarshalTopologyLevels(setting.Value); err != nil {
+		ns.log.WithError(err).Warnf("Failed to parse setting %v for accessible topology", types.SettingNameTopologyLevels)
+	}
+	if len(topologyLevels) > 0 {
+		if kubeNode, err := ns.kubeClient.CoreV1().Nodes().Get(ctx, ns.nodeID, metav1.GetOptions{}); err != nil {
+			ns.log.WithError(err).Warnf("Failed to get Kubernetes node %v for accessible topology", ns.nodeID)
+		} else {
+			labels = kubeNode.Labels
+		}
+	}
+
 	return &csi.NodeGetInfoResponse{
 		NodeId:            ns.nodeID,
 		MaxVolumesPerNode: 0, // technically the scsi kernel limit is the max limit of volumes
 		AccessibleTopology: &csi.Topology{
-			Segments: map[string]string{
-				nodeTopologyKey: ns.nodeID,
-			},
+			Segments: getNodeTopologySegments(ns.nodeID, labels, topologyLevels),
 		},
 	}, nil
 }
@@ -1017,7 +1054,7 @@ func restageRequired(volume *longhornclient.Volume,
 		return true, fmt.Errorf("always unstage v2 volume %v", volumeID)
 	}
 
-	if isStorageNetworkConfigured && volume.AccessMode == string(longhorn.AccessModeReadWriteMany) {
+	if isStorageNetworkConfigured && types.IsSharedAccessMode(longhorn.AccessMode(volume.AccessMode)) {
 		return true, fmt.Errorf("always unstage RWX volume %v when storage network is configured", volumeID)
 	}
 
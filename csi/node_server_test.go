@@ -0,0 +1,67 @@
+package csi
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/mount-utils"
+)
+
+func TestNodeStageSharedVolumeMountOptions(t *testing.T) {
+	type testCase struct {
+		customMountOptions []string
+		readOnly           bool
+
+		expectedMountOptions []string
+	}
+	testCases := map[string]testCase{
+		"rwx": {
+			expectedMountOptions: []string{"vers=4.1", "noresvport", "timeo=600", "retrans=5", "softerr"},
+		},
+		"rox": {
+			readOnly:             true,
+			expectedMountOptions: []string{"vers=4.1", "noresvport", "timeo=600", "retrans=5", "softerr", "ro"},
+		},
+		"rox with custom mount options": {
+			customMountOptions:   []string{"vers=4.2", "rw", "hard"},
+			readOnly:             true,
+			expectedMountOptions: []string{"vers=4.2", "hard", "ro"},
+		},
+		"rox with custom read-only mount options": {
+			customMountOptions:   []string{"vers=4.2", "ro"},
+			readOnly:             true,
+			expectedMountOptions: []string{"vers=4.2", "ro"},
+		},
+	}
+
+	ns := &NodeServer{
+		nodeID: "node-1",
+		log:    logrus.StandardLogger().WithField("component", "csi-node-server"),
+	}
+	for name, tc := range testCases {
+		mounter := mount.NewFakeMounter(nil)
+		targetPath := filepath.Join(t.TempDir(), "staging")
+
+		err := ns.nodeStageSharedVolume("vol", "nfs://10.0.0.1/vol", targetPath, mounter, tc.customMountOptions, tc.readOnly)
+		if err != nil {
+			t.Fatalf("%v: unexpected error: %v", name, err)
+		}
+
+		mountPoints, err := mounter.List()
+		if err != nil {
+			t.Fatalf("%v: failed to list mount points: %v", name, err)
+		}
+		if len(mountPoints) != 1 {
+			t.Fatalf("%v: expected 1 mount point, got %+v", name, mountPoints)
+		}
+		mountPoint := mountPoints[0]
+		if mountPoint.Device != "10.0.0.1:/vol" || mountPoint.Path != targetPath || mountPoint.Type != "nfs" {
+			t.Errorf("%v: unexpected mount point %+v", name, mountPoint)
+		}
+		if !reflect.DeepEqual(mountPoint.Opts, tc.expectedMountOptions) {
+			t.Errorf("%v: expected mount options %v, got %v", name, tc.expectedMountOptions, mountPoint.Opts)
+		}
+	}
+}
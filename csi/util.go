@@ -38,6 +38,9 @@ const (
 	tempTestMountPointValidStatusFile = ".longhorn-volume-mount-point-test.tmp"
 
 	nodeTopologyKey = "kubernetes.io/hostname"
+
+	// pvcNamespaceParameter is passed by the CSI provisioner with the flag --extra-create-metadata
+	pvcNamespaceParameter = "csi.storage.k8s.io/pvc/namespace"
 )
 
 // NewForcedParamsExec creates a osExecutor that allows for adding additional params to later occurring Run calls
@@ -110,6 +113,10 @@ func getVolumeOptions(volumeID string, volOptions map[string]string) (*longhornc
 		vol.StaleReplicaTimeout = defaultStaleReplicaTimeout
 	}
 
+	if pvcNamespace, ok := volOptions[pvcNamespaceParameter]; ok {
+		vol.PVCNamespace = pvcNamespace
+	}
+
 	if share, ok := volOptions["share"]; ok {
 		isShared, err := strconv.ParseBool(share)
 		if err != nil {
@@ -249,6 +256,86 @@ func getVolumeOptions(volumeID string, volOptions map[string]string) (*longhornc
 		vol.NodeSelector = strings.Split(nodeSelector, ",")
 	}
 
+	if hotDiskSelector, ok := volOptions["tieringHotDiskSelector"]; ok {
+		vol.TieringPolicy.HotDiskSelector = strings.Split(hotDiskSelector, ",")
+	}
+
+	if coldDiskSelector, ok := volOptions["tieringColdDiskSelector"]; ok {
+		vol.TieringPolicy.ColdDiskSelector = strings.Split(coldDiskSelector, ",")
+	}
+
+	if coldAfterDetachedDays, ok := volOptions["tieringColdAfterDetachedDays"]; ok {
+		days, err := strconv.ParseInt(coldAfterDetachedDays, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid parameter tieringColdAfterDetachedDays")
+		}
+		if days < 0 {
+			return nil, fmt.Errorf("invalid parameter tieringColdAfterDetachedDays: %v cannot be negative", days)
+		}
+		vol.TieringPolicy.ColdAfterDetachedDays = days
+	}
+
+	if coldBelowIOPS, ok := volOptions["tieringColdBelowIOPS"]; ok {
+		iops, err := strconv.ParseInt(coldBelowIOPS, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid parameter tieringColdBelowIOPS")
+		}
+		if iops < 0 {
+			return nil, fmt.Errorf("invalid parameter tieringColdBelowIOPS: %v cannot be negative", iops)
+		}
+		vol.TieringPolicy.ColdBelowIOPS = iops
+	}
+
+	if squash, ok := volOptions["nfsExportSquash"]; ok {
+		vol.NfsExportOptions.Squash = squash
+	}
+
+	if anonUID, ok := volOptions["nfsExportAnonUID"]; ok {
+		uid, err := strconv.ParseInt(anonUID, 10, 64)
+		if err != nil || uid < 0 {
+			return nil, errors.Wrap(err, "invalid parameter nfsExportAnonUID")
+		}
+		vol.NfsExportOptions.AnonUID = uid
+	}
+
+	if anonGID, ok := volOptions["nfsExportAnonGID"]; ok {
+		gid, err := strconv.ParseInt(anonGID, 10, 64)
+		if err != nil || gid < 0 {
+			return nil, errors.Wrap(err, "invalid parameter nfsExportAnonGID")
+		}
+		vol.NfsExportOptions.AnonGID = gid
+	}
+
+	if allowedClients, ok := volOptions["nfsExportAllowedClients"]; ok {
+		vol.NfsExportOptions.AllowedClients = strings.Split(allowedClients, ",")
+	}
+
+	if sec, ok := volOptions["nfsExportSec"]; ok {
+		vol.NfsExportOptions.Sec = sec
+	}
+
+	if readOnly, ok := volOptions["nfsExportReadOnly"]; ok {
+		isReadOnly, err := strconv.ParseBool(readOnly)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid parameter nfsExportReadOnly")
+		}
+		vol.NfsExportOptions.ReadOnly = isReadOnly
+	}
+
+	if topologySpreadConstraints, ok := volOptions["topologySpreadConstraints"]; ok {
+		constraints, err := types.ParseTopologySpreadConstraints(topologySpreadConstraints)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid parameter topologySpreadConstraints")
+		}
+		for _, constraint := range constraints {
+			vol.TopologySpreadConstraints = append(vol.TopologySpreadConstraints, longhornclient.TopologySpreadConstraint{
+				TopologyLevel:        constraint.TopologyLevel,
+				MaxReplicasPerDomain: int64(constraint.MaxReplicasPerDomain),
+				MinDomains:           int64(constraint.MinDomains),
+			})
+		}
+	}
+
 	vol.DataEngine = string(longhorn.DataEngineTypeV1)
 	if driver, ok := volOptions["dataEngine"]; ok {
 		vol.DataEngine = driver
@@ -461,7 +548,7 @@ func makeFile(pathname string) error {
 func requiresSharedAccess(vol *longhornclient.Volume, cap *csi.VolumeCapability) bool {
 	isSharedVolume := false
 	if vol != nil {
-		isSharedVolume = vol.AccessMode == string(longhorn.AccessModeReadWriteMany) || vol.Migratable
+		isSharedVolume = types.IsSharedAccessMode(longhorn.AccessMode(vol.AccessMode)) || vol.Migratable
 	}
 
 	mode := csi.VolumeCapability_AccessMode_UNKNOWN
@@ -475,10 +562,49 @@ func requiresSharedAccess(vol *longhornclient.Volume, cap *csi.VolumeCapability)
 		mode == csi.VolumeCapability_AccessMode_MULTI_NODE_MULTI_WRITER
 }
 
+// isReadOnlyManyRequested checks if the volume is only requested to be read by multiple nodes, so that it can be
+// shared with a read-only export.
+func isReadOnlyManyRequested(caps []*csi.VolumeCapability) bool {
+	if len(caps) == 0 {
+		return false
+	}
+	for _, cap := range caps {
+		if cap == nil || cap.AccessMode == nil || cap.AccessMode.Mode != csi.VolumeCapability_AccessMode_MULTI_NODE_READER_ONLY {
+			return false
+		}
+	}
+	return true
+}
+
 func getStageBlockVolumePath(stagingTargetPath, volumeID string) string {
 	return filepath.Join(stagingTargetPath, volumeID)
 }
 
+// getNodeTopologySegments returns the accessible topology segments of a node. Besides the hostname, the segments
+// contain the region, the zone, and the topology levels defined in the topology-levels setting, keyed by the node
+// label keys, if the node has the labels. The hostname is the only segment until the topology levels are defined, so
+// the topology of the existing PVs is kept.
+func getNodeTopologySegments(nodeID string, labels, topologyLevels map[string]string) map[string]string {
+	segments := map[string]string{
+		nodeTopologyKey: nodeID,
+	}
+	if len(topologyLevels) == 0 {
+		return segments
+	}
+
+	labelKeys := []string{types.KubernetesTopologyRegionLabelKey, types.KubernetesTopologyZoneLabelKey}
+	for _, labelKey := range topologyLevels {
+		labelKeys = append(labelKeys, labelKey)
+	}
+	for _, labelKey := range labelKeys {
+		if value, ok := labels[labelKey]; ok && value != "" {
+			segments[labelKey] = value
+		}
+	}
+
+	return segments
+}
+
 func parseNodeID(topology *csi.Topology) (string, error) {
 	if topology == nil || topology.Segments == nil {
 		return "", fmt.Errorf("missing accessible topology request parameter")
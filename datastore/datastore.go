@@ -84,6 +84,8 @@ type DataStore struct {
 	OrphanInformer                 cache.SharedInformer
 	snapshotLister                 lhlisters.SnapshotLister
 	SnapshotInformer               cache.SharedInformer
+	storageQuotaLister             lhlisters.StorageQuotaLister
+	StorageQuotaInformer           cache.SharedInformer
 	supportBundleLister            lhlisters.SupportBundleLister
 	SupportBundleInformer          cache.SharedInformer
 	systemBackupLister             lhlisters.SystemBackupLister
@@ -92,6 +94,12 @@ type DataStore struct {
 	SystemRestoreInformer          cache.SharedInformer
 	lhVolumeAttachmentLister       lhlisters.VolumeAttachmentLister
 	LHVolumeAttachmentInformer     cache.SharedInformer
+	volumePolicyLister             lhlisters.VolumePolicyLister
+	VolumePolicyInformer           cache.SharedInformer
+	alertSinkLister                lhlisters.AlertSinkLister
+	AlertSinkInformer              cache.SharedInformer
+	alertSilenceLister             lhlisters.AlertSilenceLister
+	AlertSilenceInformer           cache.SharedInformer
 
 	kubeClient                    clientset.Interface
 	podLister                     corelisters.PodLister
@@ -173,6 +181,8 @@ func NewDataStore(namespace string, lhClient lhclientset.Interface, kubeClient c
 	cacheSyncs = append(cacheSyncs, orphanInformer.Informer().HasSynced)
 	snapshotInformer := informerFactories.LhInformerFactory.Longhorn().V1beta2().Snapshots()
 	cacheSyncs = append(cacheSyncs, snapshotInformer.Informer().HasSynced)
+	storageQuotaInformer := informerFactories.LhInformerFactory.Longhorn().V1beta2().StorageQuotas()
+	cacheSyncs = append(cacheSyncs, storageQuotaInformer.Informer().HasSynced)
 	supportBundleInformer := informerFactories.LhInformerFactory.Longhorn().V1beta2().SupportBundles()
 	cacheSyncs = append(cacheSyncs, supportBundleInformer.Informer().HasSynced)
 	systemBackupInformer := informerFactories.LhInformerFactory.Longhorn().V1beta2().SystemBackups()
@@ -181,6 +191,12 @@ func NewDataStore(namespace string, lhClient lhclientset.Interface, kubeClient c
 	cacheSyncs = append(cacheSyncs, systemRestoreInformer.Informer().HasSynced)
 	lhVolumeAttachmentInformer := informerFactories.LhInformerFactory.Longhorn().V1beta2().VolumeAttachments()
 	cacheSyncs = append(cacheSyncs, lhVolumeAttachmentInformer.Informer().HasSynced)
+	volumePolicyInformer := informerFactories.LhInformerFactory.Longhorn().V1beta2().VolumePolicies()
+	cacheSyncs = append(cacheSyncs, volumePolicyInformer.Informer().HasSynced)
+	alertSinkInformer := informerFactories.LhInformerFactory.Longhorn().V1beta2().AlertSinks()
+	cacheSyncs = append(cacheSyncs, alertSinkInformer.Informer().HasSynced)
+	alertSilenceInformer := informerFactories.LhInformerFactory.Longhorn().V1beta2().AlertSilences()
+	cacheSyncs = append(cacheSyncs, alertSilenceInformer.Informer().HasSynced)
 
 	// Kube Informers
 	podInformer := informerFactories.KubeInformerFactory.Core().V1().Pods()
@@ -262,6 +278,8 @@ func NewDataStore(namespace string, lhClient lhclientset.Interface, kubeClient c
 		OrphanInformer:                 orphanInformer.Informer(),
 		snapshotLister:                 snapshotInformer.Lister(),
 		SnapshotInformer:               snapshotInformer.Informer(),
+		storageQuotaLister:             storageQuotaInformer.Lister(),
+		StorageQuotaInformer:           storageQuotaInformer.Informer(),
 		supportBundleLister:            supportBundleInformer.Lister(),
 		SupportBundleInformer:          supportBundleInformer.Informer(),
 		systemBackupLister:             systemBackupInformer.Lister(),
@@ -270,6 +288,12 @@ func NewDataStore(namespace string, lhClient lhclientset.Interface, kubeClient c
 		SystemRestoreInformer:          systemRestoreInformer.Informer(),
 		lhVolumeAttachmentLister:       lhVolumeAttachmentInformer.Lister(),
 		LHVolumeAttachmentInformer:     lhVolumeAttachmentInformer.Informer(),
+		volumePolicyLister:             volumePolicyInformer.Lister(),
+		VolumePolicyInformer:           volumePolicyInformer.Informer(),
+		alertSinkLister:                alertSinkInformer.Lister(),
+		AlertSinkInformer:              alertSinkInformer.Informer(),
+		alertSilenceLister:             alertSilenceInformer.Lister(),
+		AlertSilenceInformer:           alertSilenceInformer.Informer(),
 
 		kubeClient:                    kubeClient,
 		podLister:                     podInformer.Lister(),
@@ -323,6 +347,26 @@ func ErrorIsNotFound(err error) bool {
 	return errors.Is(err, &types.NotFoundError{}) || apierrors.IsNotFound(err)
 }
 
+// ErrorIsStorageQuotaExceeded checks if given error is the rejection of a
+// request by a storage quota, either returned by types.CheckStorageQuota or
+// passed through the Kubernetes API by the admission webhook
+func ErrorIsStorageQuotaExceeded(err error) bool {
+	var quotaErr *types.StorageQuotaExceededError
+	if errors.As(err, &quotaErr) {
+		return true
+	}
+	var status apierrors.APIStatus
+	if !errors.As(err, &status) || status.Status().Details == nil {
+		return false
+	}
+	for _, cause := range status.Status().Details.Causes {
+		if cause.Type == types.StorageQuotaExceededCode {
+			return true
+		}
+	}
+	return false
+}
+
 // ErrorIsConflict checks if given error match
 // metav1.StatusReasonConflict
 func ErrorIsConflict(err error) bool {
@@ -18,6 +18,8 @@ import (
 	"k8s.io/client-go/rest"
 
 	appsv1 "k8s.io/api/apps/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -893,6 +895,152 @@ func (s *DataStore) DeleteConfigMap(namespace, name string) error {
 	return nil
 }
 
+// GetDailyUsage returns the daily usage of the given date persisted in the ConfigMap shards
+func (s *DataStore) GetDailyUsage(date string) (*types.DailyUsage, error) {
+	configMaps, err := s.listDailyUsageConfigMaps(date)
+	if err != nil {
+		return nil, err
+	}
+	if len(configMaps) == 0 {
+		return nil, apierrors.NewNotFound(corev1.Resource("configmap"), types.GetUsageConfigMapName(date, 0))
+	}
+
+	shards := []*types.DailyUsage{}
+	for _, configMap := range configMaps {
+		shard, err := parseDailyUsage(configMap)
+		if err != nil {
+			return nil, err
+		}
+		shards = append(shards, shard)
+	}
+	return types.MergeDailyUsages(shards), nil
+}
+
+// ListDailyUsages returns the persisted daily usages sorted by the dates
+func (s *DataStore) ListDailyUsages() ([]*types.DailyUsage, error) {
+	configMaps, err := s.listDailyUsageConfigMaps("")
+	if err != nil {
+		return nil, err
+	}
+
+	shardsByDate := map[string][]*types.DailyUsage{}
+	for _, configMap := range configMaps {
+		shard, err := parseDailyUsage(configMap)
+		if err != nil {
+			return nil, err
+		}
+		shardsByDate[shard.Date] = append(shardsByDate[shard.Date], shard)
+	}
+
+	usages := []*types.DailyUsage{}
+	for _, shards := range shardsByDate {
+		usages = append(usages, types.MergeDailyUsages(shards))
+	}
+	sort.Slice(usages, func(i, j int) bool { return usages[i].Date < usages[j].Date })
+	return usages, nil
+}
+
+// listDailyUsageConfigMaps returns the ConfigMap shards of the daily usage of the given date, or of all the dates if
+// the date is empty
+func (s *DataStore) listDailyUsageConfigMaps(date string) ([]*corev1.ConfigMap, error) {
+	selector := types.GetLonghornLabelKey(types.LonghornLabelUsageDate)
+	if date != "" {
+		selector += "=" + date
+	}
+	labelSelector, err := labels.Parse(selector)
+	if err != nil {
+		return nil, err
+	}
+	return s.configMapLister.ConfigMaps(s.namespace).List(labelSelector)
+}
+
+func parseDailyUsage(configMap *corev1.ConfigMap) (*types.DailyUsage, error) {
+	usage := &types.DailyUsage{}
+	if err := json.Unmarshal([]byte(configMap.Data[types.UsageConfigMapDataKey]), usage); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the daily usage in ConfigMap %v", configMap.Name)
+	}
+	return usage, nil
+}
+
+// CreateOrUpdateDailyUsage persists the daily usage in the ConfigMap shards of its date, so that the ConfigMaps don't
+// exceed the size limit of the objects however many volumes there are. The shards no longer needed are deleted.
+func (s *DataStore) CreateOrUpdateDailyUsage(usage *types.DailyUsage) error {
+	shards, err := types.SplitDailyUsage(usage, types.UsageConfigMapMaxDataSize)
+	if err != nil {
+		return err
+	}
+
+	names := map[string]bool{}
+	for i, shard := range shards {
+		name := types.GetUsageConfigMapName(usage.Date, i)
+		if err := s.createOrUpdateDailyUsageShard(name, shard); err != nil {
+			return errors.Wrapf(err, "failed to persist the daily usage in ConfigMap %v", name)
+		}
+		names[name] = true
+	}
+
+	configMaps, err := s.listDailyUsageConfigMaps(usage.Date)
+	if err != nil {
+		return err
+	}
+	for _, configMap := range configMaps {
+		if names[configMap.Name] {
+			continue
+		}
+		if err := s.DeleteConfigMap(s.namespace, configMap.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *DataStore) createOrUpdateDailyUsageShard(name string, shard *types.DailyUsage) error {
+	data, err := json.Marshal(shard)
+	if err != nil {
+		return err
+	}
+
+	configMap, err := s.GetConfigMap(s.namespace, name)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		_, err = s.CreateConfigMap(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					types.GetLonghornLabelKey(types.LonghornLabelUsageDate): shard.Date,
+				},
+			},
+			Data: map[string]string{
+				types.UsageConfigMapDataKey: string(data),
+			},
+		})
+		return err
+	}
+
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+	configMap.Data[types.UsageConfigMapDataKey] = string(data)
+	_, err = s.UpdateConfigMap(configMap)
+	return err
+}
+
+// DeleteDailyUsage deletes the ConfigMap shards of the daily usage of the given date
+func (s *DataStore) DeleteDailyUsage(date string) error {
+	configMaps, err := s.listDailyUsageConfigMaps(date)
+	if err != nil {
+		return err
+	}
+	for _, configMap := range configMaps {
+		if err := s.DeleteConfigMap(s.namespace, configMap.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // GetSecretRO gets Secret with the given namespace and name
 // This function returns direct reference to the internal cache object and should not be mutated.
 // Consider using this function when you can guarantee read only access and don't want the overhead of deep copies
@@ -1105,9 +1253,12 @@ func NewPVManifestForVolume(v *longhorn.Volume, pvName, storageClassName, fsType
 	}
 
 	accessMode := corev1.ReadWriteOnce
-	if v.Spec.AccessMode == longhorn.AccessModeReadWriteMany {
+	switch v.Spec.AccessMode {
+	case longhorn.AccessModeReadWriteMany:
 		accessMode = corev1.ReadWriteMany
 		volAttributes["migratable"] = strconv.FormatBool(v.Spec.Migratable)
+	case longhorn.AccessModeReadOnlyMany:
+		accessMode = corev1.ReadOnlyMany
 	}
 
 	return NewPVManifest(v.Spec.Size, pvName, v.Name, storageClassName, fsType, volAttributes, accessMode)
@@ -1149,8 +1300,11 @@ func NewPVManifest(size int64, pvName, volumeName, storageClassName, fsType stri
 // NewPVCManifestForVolume returns a new PersistentVolumeClaim object for a longhorn volume
 func NewPVCManifestForVolume(v *longhorn.Volume, pvName, ns, pvcName, storageClassName string) *corev1.PersistentVolumeClaim {
 	accessMode := corev1.ReadWriteOnce
-	if v.Spec.AccessMode == longhorn.AccessModeReadWriteMany {
+	switch v.Spec.AccessMode {
+	case longhorn.AccessModeReadWriteMany:
 		accessMode = corev1.ReadWriteMany
+	case longhorn.AccessModeReadOnlyMany:
+		accessMode = corev1.ReadOnlyMany
 	}
 
 	return NewPVCManifest(v.Spec.Size, pvName, ns, pvcName, storageClassName, accessMode)
@@ -1371,3 +1525,17 @@ func (s *DataStore) GetRoleBinding(name string) (*rbacv1.RoleBinding, error) {
 func (s *DataStore) UpdateRoleBinding(roleBinding *rbacv1.RoleBinding) (*rbacv1.RoleBinding, error) {
 	return s.kubeClient.RbacV1().RoleBindings(s.namespace).Update(context.TODO(), roleBinding, metav1.UpdateOptions{})
 }
+
+// CreateTokenReview asks the API server to authenticate the bearer token in the given TokenReview
+func (s *DataStore) CreateTokenReview(review *authenticationv1.TokenReview) (*authenticationv1.TokenReview, error) {
+	return s.kubeClient.AuthenticationV1().TokenReviews().Create(context.TODO(), review, metav1.CreateOptions{})
+}
+
+// CreateSubjectAccessReview asks the API server whether the user in the given SubjectAccessReview is allowed to
+// perform the action. The namespace of the resource attributes is set to the Longhorn namespace.
+func (s *DataStore) CreateSubjectAccessReview(review *authorizationv1.SubjectAccessReview) (*authorizationv1.SubjectAccessReview, error) {
+	if review.Spec.ResourceAttributes != nil {
+		review.Spec.ResourceAttributes.Namespace = s.namespace
+	}
+	return s.kubeClient.AuthorizationV1().SubjectAccessReviews().Create(context.TODO(), review, metav1.CreateOptions{})
+}
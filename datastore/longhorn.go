@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -34,6 +35,7 @@ import (
 	"github.com/longhorn/longhorn-manager/util"
 
 	lhutils "github.com/longhorn/go-common-libs/utils"
+	etypes "github.com/longhorn/longhorn-engine/pkg/types"
 	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
 )
 
@@ -770,7 +772,7 @@ func (s *DataStore) AreAllRWXVolumesDetached() (bool, error) {
 		return false, err
 	}
 	for _, volume := range volumes {
-		if volume.Spec.AccessMode != longhorn.AccessModeReadWriteMany {
+		if !types.IsSharedAccessMode(volume.Spec.AccessMode) {
 			continue
 		}
 
@@ -1096,6 +1098,10 @@ func (s *DataStore) GetCredentialFromSecret(secretName string) (map[string]strin
 	credentialSecret[types.AWSSecretKey] = string(secret.Data[types.AWSSecretKey])
 	credentialSecret[types.AWSEndPoint] = string(secret.Data[types.AWSEndPoint])
 	credentialSecret[types.AWSCert] = string(secret.Data[types.AWSCert])
+	credentialSecret[types.AWSRoleArn] = string(secret.Data[types.AWSRoleArn])
+	credentialSecret[types.AWSWebIdentityTokenFile] = string(secret.Data[types.AWSWebIdentityTokenFile])
+	credentialSecret[types.AWSRoleSessionName] = string(secret.Data[types.AWSRoleSessionName])
+	credentialSecret[types.AWSSTSEndpoint] = string(secret.Data[types.AWSSTSEndpoint])
 	credentialSecret[types.CIFSUsername] = string(secret.Data[types.CIFSUsername])
 	credentialSecret[types.CIFSPassword] = string(secret.Data[types.CIFSPassword])
 	credentialSecret[types.AZBlobAccountName] = string(secret.Data[types.AZBlobAccountName])
@@ -1109,6 +1115,142 @@ func (s *DataStore) GetCredentialFromSecret(secretName string) (map[string]strin
 	return credentialSecret, nil
 }
 
+// GetBackupTargetCredential returns the credential to access the backup target, or nil if the backup store doesn't
+// take a credential or uses the credentials of the pod.
+func (s *DataStore) GetBackupTargetCredential(backupTarget *longhorn.BackupTarget) (map[string]string, error) {
+	backupType, err := util.CheckBackupType(backupTarget.Spec.BackupTargetURL)
+	if err != nil {
+		return nil, err
+	}
+	if !types.BackupStoreRequireCredential(backupType) {
+		return nil, nil
+	}
+	if backupTarget.Spec.CredentialSecret == "" {
+		return nil, fmt.Errorf("cannot access %s without credential secret", backupType)
+	}
+	return s.GetCredentialFromSecret(backupTarget.Spec.CredentialSecret)
+}
+
+// IsBackupTargetWebIdentityTokenRequired returns true if the credential of the backup target assumes a role with the
+// service account token projected into the pods accessing the backup target.
+func (s *DataStore) IsBackupTargetWebIdentityTokenRequired(backupTarget *longhorn.BackupTarget) (bool, error) {
+	backupType, err := util.CheckBackupType(backupTarget.Spec.BackupTargetURL)
+	if err != nil || backupType != types.BackupStoreTypeS3 || backupTarget.Spec.CredentialSecret == "" {
+		return false, nil
+	}
+	credential, err := s.GetCredentialFromSecret(backupTarget.Spec.CredentialSecret)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return types.RequireProjectedWebIdentityToken(credential), nil
+}
+
+// IsWebIdentityTokenRequired returns true if any backup target requires the service account token projected into the
+// pods accessing the backup targets, see IsBackupTargetWebIdentityTokenRequired.
+func (s *DataStore) IsWebIdentityTokenRequired() (bool, error) {
+	backupTargets, err := s.ListBackupTargetsRO()
+	if err != nil {
+		return false, err
+	}
+	for _, backupTarget := range backupTargets {
+		required, err := s.IsBackupTargetWebIdentityTokenRequired(backupTarget)
+		if err != nil {
+			return false, err
+		}
+		if required {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// GetBackupTargetAccessURL returns the URL the backup store of the backup target is accessed with. A file backup
+// target is accessed through NFS:
+//   - The directory of a volume is accessed through the share of the volume, so it's unavailable while the volume isn't
+//     shared, e.g. when the node of the share manager is down and the share manager hasn't failed over yet.
+//   - The directory of a node is accessed through the NFS server running on the node, so it's unavailable while the
+//     node is down, since the backups cannot fail over to another node.
+func (s *DataStore) GetBackupTargetAccessURL(backupTarget *longhorn.BackupTarget) (string, error) {
+	backupType, err := util.CheckBackupType(backupTarget.Spec.BackupTargetURL)
+	if err != nil || backupType != types.BackupStoreTypeFile {
+		return backupTarget.Spec.BackupTargetURL, err
+	}
+
+	volumeName, nodeID, dir, err := types.ParseFileBackupTargetURL(backupTarget.Spec.BackupTargetURL)
+	if err != nil {
+		return "", err
+	}
+	if nodeID != "" {
+		return s.getFileBackupTargetNodeAccessURL(backupTarget.Name, nodeID)
+	}
+
+	v, err := s.GetVolumeRO(volumeName)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get volume %v storing the backups", volumeName)
+	}
+	if v.Spec.AccessMode != longhorn.AccessModeReadWriteMany || v.Spec.Migratable {
+		return "", fmt.Errorf("volume %v storing the backups must be a non-migratable %v volume", volumeName, longhorn.AccessModeReadWriteMany)
+	}
+	if v.Status.ShareState != longhorn.ShareManagerStateRunning || v.Status.ShareEndpoint == "" {
+		return "", fmt.Errorf("volume %v storing the backups is not shared yet, share state is %v", volumeName, v.Status.ShareState)
+	}
+	return types.GetFileBackupTargetNFSURL(v.Status.ShareEndpoint, dir)
+}
+
+func (s *DataStore) getFileBackupTargetNodeAccessURL(backupTargetName, nodeID string) (string, error) {
+	isDown, err := s.IsNodeDownOrDeleted(nodeID)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to check if node %v storing the backups is down", nodeID)
+	}
+	if isDown {
+		return "", fmt.Errorf("node %v storing the backups is down or deleted, the backup target is unavailable until the node is back", nodeID)
+	}
+
+	name := types.GetFileBackupTargetNFSServerName(backupTargetName)
+	pod, err := s.GetPodRO(s.namespace, name)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get NFS server pod %v serving the backups", name)
+	}
+	if pod == nil || pod.Status.Phase != corev1.PodRunning {
+		return "", fmt.Errorf("NFS server pod %v serving the backups on node %v is not running yet", name, nodeID)
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady && condition.Status != corev1.ConditionTrue {
+			return "", fmt.Errorf("NFS server pod %v serving the backups on node %v is not ready yet", name, nodeID)
+		}
+	}
+
+	service, err := s.GetService(s.namespace, name)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get NFS server service %v serving the backups", name)
+	}
+	if service.Spec.ClusterIP == "" || service.Spec.ClusterIP == corev1.ClusterIPNone {
+		return "", fmt.Errorf("NFS server service %v serving the backups has no cluster IP", name)
+	}
+	return types.GetFileBackupTargetNFSURL(fmt.Sprintf("%v://%v/%v", types.BackupStoreTypeNFS, service.Spec.ClusterIP, types.FileBackupTargetNFSExportName), "/")
+}
+
+// GetBackupTargetStoredInVolume returns the file backup target storing its backups in the volume, or nil if there is
+// none.
+func (s *DataStore) GetBackupTargetStoredInVolume(volumeName string) (*longhorn.BackupTarget, error) {
+	backupTargets, err := s.ListBackupTargetsRO()
+	if err != nil {
+		return nil, err
+	}
+	for _, backupTarget := range backupTargets {
+		if util.GetSchemeFromURL(backupTarget.Spec.BackupTargetURL) != types.BackupStoreTypeFile {
+			continue
+		}
+		if name, _, _, err := types.ParseFileBackupTargetURL(backupTarget.Spec.BackupTargetURL); err == nil && name == volumeName {
+			return backupTarget, nil
+		}
+	}
+	return nil, nil
+}
+
 func CheckVolume(v *longhorn.Volume) error {
 	size, err := util.ConvertSize(v.Spec.Size)
 	if err != nil {
@@ -1340,7 +1482,7 @@ func (s *DataStore) IsRegularRWXVolume(volumeName string) (bool, error) {
 		}
 		return false, err
 	}
-	return v.Spec.AccessMode == longhorn.AccessModeReadWriteMany && !v.Spec.Migratable, nil
+	return types.IsSharedAccessMode(v.Spec.AccessMode) && !v.Spec.Migratable, nil
 }
 
 func MarshalLabelToVolumeRecurringJob(labels map[string]string) map[string]*longhorn.VolumeRecurringJob {
@@ -2122,6 +2264,13 @@ func (s *DataStore) getRunningReplicaInstanceManagerRO(r *longhorn.Replica) (im
 	return im, nil
 }
 
+// IsReplacementDiskRebuildReplica returns true if the replica was moved from a replaced disk and has not been rebuilt
+// yet. Such replicas are rebuilt ahead of the others.
+func IsReplacementDiskRebuildReplica(r *longhorn.Replica) bool {
+	return r.Labels[types.GetLonghornLabelKey(types.LonghornLabelReplacementDiskRebuild)] == types.LonghornLabelValueEnabled &&
+		r.Spec.HealthyAt == ""
+}
+
 // IsReplicaRebuildingFailed returns true if the rebuilding replica failed not caused by network issues.
 func IsReplicaRebuildingFailed(reusableFailedReplica *longhorn.Replica) bool {
 	replicaRebuildFailedCondition := types.GetCondition(reusableFailedReplica.Status.Conditions, longhorn.ReplicaConditionTypeRebuildFailed)
@@ -4042,6 +4191,40 @@ func (s *DataStore) GetSettingSystemManagedComponentsNodeSelector() (map[string]
 	return nodeSelector, nil
 }
 
+// GetSettingTopologyLevels returns the topology levels beyond region and zone, mapped to the node label keys.
+func (s *DataStore) GetSettingTopologyLevels() (map[string]string, error) {
+	setting, err := s.GetSettingWithAutoFillingRO(types.SettingNameTopologyLevels)
+	if err != nil {
+		return nil, err
+	}
+	return types.UnmarshalTopologyLevels(setting.Value)
+}
+
+// GetSettingAuditLogDestination returns the destination of the audit log, or an empty string if the audit log is
+// disabled.
+func (s *DataStore) GetSettingAuditLogDestination() (string, error) {
+	setting, err := s.GetSettingWithAutoFillingRO(types.SettingNameAuditLogDestination)
+	if err != nil {
+		return "", err
+	}
+	return setting.Value, nil
+}
+
+// GetSettingTracingOTLPEndpoint returns the URL of the collector the trace spans are exported to, or an empty string if
+// tracing is disabled.
+func (s *DataStore) GetSettingTracingOTLPEndpoint() (string, error) {
+	setting, err := s.GetSettingWithAutoFillingRO(types.SettingNameTracingOTLPEndpoint)
+	if err != nil {
+		return "", err
+	}
+	return setting.Value, nil
+}
+
+// GetSettingTracingSamplingRatio returns the ratio of the new traces that are sampled.
+func (s *DataStore) GetSettingTracingSamplingRatio() (float64, error) {
+	return s.GetSettingAsFloat(types.SettingNameTracingSamplingRatio)
+}
+
 // GetSettingOrphanResourceAutoDeletion get the setting and return a flag collection of orphaned resource types.
 // Flag is true when the auto deletion is enabled to an orphaned resource type.
 // Returns error if the setting is invalid
@@ -4068,6 +4251,7 @@ func (s *DataStore) ResetMonitoringEngineStatus(e *longhorn.Engine) (*longhorn.E
 	e.Status.RebuildStatus = nil
 	e.Status.LastExpansionFailedAt = ""
 	e.Status.LastExpansionError = ""
+	e.Status.LowIOPSSince = ""
 	ret, err := s.UpdateEngineStatus(e)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to reset engine status for %v", e.Name)
@@ -4888,6 +5072,17 @@ func (s *DataStore) ValidateBackupTargetURL(backupTargetName, backupTargetURL st
 	if err := checkBackupTargetURLFormat(u); err != nil {
 		return err
 	}
+	if u.Scheme == types.BackupStoreTypeFile {
+		_, nodeID, _, err := types.ParseFileBackupTargetURL(backupTargetURL)
+		if err != nil {
+			return err
+		}
+		if nodeID != "" {
+			if _, err := s.GetNodeRO(nodeID); err != nil {
+				return errors.Wrapf(err, "failed to get node %v storing the backups", nodeID)
+			}
+		}
+	}
 	if err := s.validateBackupTargetURLExisting(backupTargetName, u); err != nil {
 		return err
 	}
@@ -4945,7 +5140,7 @@ func (s *DataStore) validateBackupTargetURLExisting(backupTargetName string, u *
 
 func getBackupTargetPath(u *url.URL) (backupTargetPath string, err error) {
 	switch u.Scheme {
-	case types.BackupStoreTypeAZBlob, types.BackupStoreTypeS3:
+	case types.BackupStoreTypeAZBlob, types.BackupStoreTypeS3, types.BackupStoreTypeFile:
 		backupTargetPath = strings.ToLower(u.String())
 	case types.BackupStoreTypeCIFS, types.BackupStoreTypeNFS:
 		backupTargetPath = strings.ToLower(strings.TrimRight(u.Host+u.Path, "/"))
@@ -6790,3 +6985,297 @@ func (s *DataStore) GetAllDiskUUIDFirstFourChar() (map[string]bool, error) {
 
 	return firstFourCharSet, nil
 }
+
+// UpdateVolumePolicyStatus updates Longhorn VolumePolicy resource status and verifies update
+func (s *DataStore) UpdateVolumePolicyStatus(policy *longhorn.VolumePolicy) (*longhorn.VolumePolicy, error) {
+	obj, err := s.lhClient.LonghornV1beta2().VolumePolicies(s.namespace).UpdateStatus(context.TODO(), policy, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	verifyUpdate(policy.Name, obj, func(name string) (k8sruntime.Object, error) {
+		return s.GetVolumePolicyRO(name)
+	})
+
+	return obj, nil
+}
+
+// GetVolumePolicy returns a copy of VolumePolicy with the given obj name
+func (s *DataStore) GetVolumePolicy(name string) (*longhorn.VolumePolicy, error) {
+	resultRO, err := s.GetVolumePolicyRO(name)
+	if err != nil {
+		return nil, err
+	}
+	// Cannot use cached object from lister
+	return resultRO.DeepCopy(), nil
+}
+
+// GetVolumePolicyRO returns the VolumePolicy with the given CR name
+func (s *DataStore) GetVolumePolicyRO(name string) (*longhorn.VolumePolicy, error) {
+	return s.volumePolicyLister.VolumePolicies(s.namespace).Get(name)
+}
+
+// ListVolumePoliciesRO returns a list of all VolumePolicies for the given namespace
+func (s *DataStore) ListVolumePoliciesRO() ([]*longhorn.VolumePolicy, error) {
+	return s.volumePolicyLister.VolumePolicies(s.namespace).List(labels.Everything())
+}
+
+// UpdateStorageQuotaStatus updates Longhorn StorageQuota resource status and verifies update
+func (s *DataStore) UpdateStorageQuotaStatus(quota *longhorn.StorageQuota) (*longhorn.StorageQuota, error) {
+	obj, err := s.lhClient.LonghornV1beta2().StorageQuotas(s.namespace).UpdateStatus(context.TODO(), quota, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	verifyUpdate(quota.Name, obj, func(name string) (k8sruntime.Object, error) {
+		return s.GetStorageQuotaRO(name)
+	})
+
+	return obj, nil
+}
+
+// GetStorageQuota returns a copy of StorageQuota with the given obj name
+func (s *DataStore) GetStorageQuota(name string) (*longhorn.StorageQuota, error) {
+	resultRO, err := s.GetStorageQuotaRO(name)
+	if err != nil {
+		return nil, err
+	}
+	// Cannot use cached object from lister
+	return resultRO.DeepCopy(), nil
+}
+
+// GetStorageQuotaRO returns the StorageQuota with the given CR name
+func (s *DataStore) GetStorageQuotaRO(name string) (*longhorn.StorageQuota, error) {
+	return s.storageQuotaLister.StorageQuotas(s.namespace).Get(name)
+}
+
+// ListStorageQuotasRO returns a list of all StorageQuotas for the given namespace
+func (s *DataStore) ListStorageQuotasRO() ([]*longhorn.StorageQuota, error) {
+	return s.storageQuotaLister.StorageQuotas(s.namespace).List(labels.Everything())
+}
+
+// GetStorageQuotaForNamespaceRO returns the StorageQuota applying to the volumes of the given PVC namespace, or nil if
+// there is none
+func (s *DataStore) GetStorageQuotaForNamespaceRO(namespace string) (*longhorn.StorageQuota, error) {
+	if namespace == "" {
+		return nil, nil
+	}
+	quotas, err := s.ListStorageQuotasRO()
+	if err != nil {
+		return nil, err
+	}
+	for _, quota := range quotas {
+		if quota.Spec.Namespace == namespace {
+			return quota, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetStorageQuotaUsage returns the storage consumed by the volumes of the given PVC namespace. The snapshots are
+// attributed by their volumes, and the backups by the namespace recorded when they were taken, falling back to their
+// volumes.
+func (s *DataStore) GetStorageQuotaUsage(namespace string) (*longhorn.StorageQuotaUsage, error) {
+	usage := &longhorn.StorageQuotaUsage{}
+
+	volumes, err := s.ListVolumesRO()
+	if err != nil {
+		return nil, err
+	}
+	volumeNamespaces := map[string]string{}
+	numberOfReplicas := map[string]int{}
+	for _, v := range volumes {
+		volumeNamespaces[v.Name] = types.GetVolumePVCNamespace(v)
+		if volumeNamespaces[v.Name] != namespace {
+			continue
+		}
+		numberOfReplicas[v.Name] = v.Spec.NumberOfReplicas
+		usage.ProvisionedSize += v.Spec.Size * int64(v.Spec.NumberOfReplicas)
+	}
+
+	snapshots, err := s.ListSnapshotsRO(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, snapshot := range snapshots {
+		replicas, ok := numberOfReplicas[snapshot.Spec.Volume]
+		if !ok {
+			continue
+		}
+		// The removed snapshots consume the space until they are purged, but no longer count against the number
+		usage.SnapshotSize += snapshot.Status.Size * int64(replicas)
+		if snapshot.DeletionTimestamp == nil && !snapshot.Status.MarkRemoved {
+			usage.SnapshotCount++
+		}
+	}
+
+	backups, err := s.ListBackupsRO()
+	if err != nil {
+		return nil, err
+	}
+	for _, backup := range backups {
+		backupNamespace := types.GetBackupPVCNamespace(backup)
+		if backupNamespace == "" {
+			backupNamespace = volumeNamespaces[backup.Status.VolumeName]
+		}
+		if backupNamespace != namespace || backup.Status.NewlyUploadedDataSize == "" {
+			continue
+		}
+		size, err := util.ConvertSize(backup.Status.NewlyUploadedDataSize)
+		if err != nil {
+			logrus.WithError(err).Warnf("Failed to parse the newly uploaded data size of backup %v", backup.Name)
+			continue
+		}
+		usage.BackupSize += size
+	}
+
+	return usage, nil
+}
+
+// ReserveStorageQuota checks the increment of the usage of the given PVC namespace against the storage quota of the
+// namespace, and adds it to the usage in the status of the quota. The usage in the status also counts the increments
+// reserved since the storage quota controller last counted the usage, and the status is updated with the resource
+// version of the quota read from the API server, so that concurrent requests cannot exceed the quota together. Returns
+// a *types.StorageQuotaExceededError if the increment exceeds the quota.
+func (s *DataStore) ReserveStorageQuota(namespace string, increment *longhorn.StorageQuotaUsage) error {
+	quota, err := s.GetStorageQuotaForNamespaceRO(namespace)
+	if err != nil || quota == nil {
+		return err
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latest, err := s.lhClient.LonghornV1beta2().StorageQuotas(s.namespace).Get(context.TODO(), quota.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		usage, err := s.GetStorageQuotaUsage(namespace)
+		if err != nil {
+			return err
+		}
+		usage.ProvisionedSize = max(usage.ProvisionedSize, latest.Status.Usage.ProvisionedSize)
+		usage.SnapshotSize = max(usage.SnapshotSize, latest.Status.Usage.SnapshotSize)
+		usage.SnapshotCount = max(usage.SnapshotCount, latest.Status.Usage.SnapshotCount)
+		usage.BackupSize = max(usage.BackupSize, latest.Status.Usage.BackupSize)
+
+		if err := types.CheckStorageQuota(latest, usage, increment); err != nil {
+			return err
+		}
+
+		latest.Status.Usage = longhorn.StorageQuotaUsage{
+			ProvisionedSize: usage.ProvisionedSize + increment.ProvisionedSize,
+			SnapshotSize:    usage.SnapshotSize + increment.SnapshotSize,
+			SnapshotCount:   usage.SnapshotCount + increment.SnapshotCount,
+			BackupSize:      usage.BackupSize + increment.BackupSize,
+		}
+		_, err = s.lhClient.LonghornV1beta2().StorageQuotas(s.namespace).UpdateStatus(context.TODO(), latest, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// GetSnapshotStorageQuotaIncrement returns the usage a new snapshot of the volume is going to consume. The snapshot
+// takes the data of the volume head, so the size of the volume head is the space the snapshot is going to consume.
+func (s *DataStore) GetSnapshotStorageQuotaIncrement(volume *longhorn.Volume) (*longhorn.StorageQuotaUsage, error) {
+	increment := &longhorn.StorageQuotaUsage{SnapshotCount: 1}
+	// The size of the volume head is unknown if the current engine cannot be picked, for example, during a migration
+	engine, err := s.GetVolumeCurrentEngine(volume.Name)
+	if err != nil || engine == nil {
+		return increment, nil
+	}
+	if head, ok := engine.Status.Snapshots[etypes.VolumeHeadName]; ok && head != nil && head.Size != "" {
+		headSize, err := util.ConvertSize(head.Size)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse the size of the volume head of volume %v", volume.Name)
+		}
+		increment.SnapshotSize = headSize * int64(volume.Spec.NumberOfReplicas)
+	}
+	return increment, nil
+}
+
+// UpdateAlertSinkStatus updates Longhorn AlertSink resource status and verifies update
+func (s *DataStore) UpdateAlertSinkStatus(sink *longhorn.AlertSink) (*longhorn.AlertSink, error) {
+	obj, err := s.lhClient.LonghornV1beta2().AlertSinks(s.namespace).UpdateStatus(context.TODO(), sink, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	verifyUpdate(sink.Name, obj, func(name string) (k8sruntime.Object, error) {
+		return s.GetAlertSinkRO(name)
+	})
+
+	return obj, nil
+}
+
+// GetAlertSink returns a copy of AlertSink with the given obj name
+func (s *DataStore) GetAlertSink(name string) (*longhorn.AlertSink, error) {
+	resultRO, err := s.GetAlertSinkRO(name)
+	if err != nil {
+		return nil, err
+	}
+	// Cannot use cached object from lister
+	return resultRO.DeepCopy(), nil
+}
+
+// GetAlertSinkRO returns the AlertSink with the given CR name
+func (s *DataStore) GetAlertSinkRO(name string) (*longhorn.AlertSink, error) {
+	return s.alertSinkLister.AlertSinks(s.namespace).Get(name)
+}
+
+// ListAlertSinksRO returns a list of all AlertSinks for the given namespace
+func (s *DataStore) ListAlertSinksRO() ([]*longhorn.AlertSink, error) {
+	return s.alertSinkLister.AlertSinks(s.namespace).List(labels.Everything())
+}
+
+// ListAlertSilencesRO returns a list of all AlertSilences for the given namespace
+func (s *DataStore) ListAlertSilencesRO() ([]*longhorn.AlertSilence, error) {
+	return s.alertSilenceLister.AlertSilences(s.namespace).List(labels.Everything())
+}
+
+// ListAlerts evaluates the alerts of the volumes, nodes, backups and backup targets, and marks the ones matched by
+// the unexpired AlertSilences as silenced
+func (s *DataStore) ListAlerts() ([]*types.Alert, error) {
+	alerts := []*types.Alert{}
+
+	volumes, err := s.ListVolumesRO()
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range volumes {
+		alerts = append(alerts, types.GetVolumeAlerts(v)...)
+	}
+
+	nodes, err := s.ListNodesRO()
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodes {
+		alerts = append(alerts, types.GetNodeAlerts(node)...)
+	}
+
+	backups, err := s.ListBackupsRO()
+	if err != nil {
+		return nil, err
+	}
+	for _, backup := range backups {
+		alerts = append(alerts, types.GetBackupAlerts(backup)...)
+	}
+
+	backupTargets, err := s.ListBackupTargetsRO()
+	if err != nil {
+		return nil, err
+	}
+	for _, backupTarget := range backupTargets {
+		alerts = append(alerts, types.GetBackupTargetAlerts(backupTarget)...)
+	}
+
+	silences, err := s.ListAlertSilencesRO()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for _, alert := range alerts {
+		alert.Silenced = types.IsAlertSilenced(alert, silences, now)
+	}
+
+	sort.Slice(alerts, func(i, j int) bool { return alerts[i].Fingerprint() < alerts[j].Fingerprint() })
+	return alerts, nil
+}
@@ -403,6 +403,13 @@ func (s *DataStore) GetLonghornSnapshotUncached(name string) (*longhorn.Snapshot
 	return s.lhClient.LonghornV1beta2().Snapshots(s.namespace).Get(context.TODO(), name, metav1.GetOptions{})
 }
 
+// GetLonghornResourceRawUncached returns the JSON of the Longhorn resource of the given plural name in the Longhorn
+// namespace directly from the API server. It is used by the audit log to record the object right before and after a
+// change, which the cache may not have caught up with yet.
+func (s *DataStore) GetLonghornResourceRawUncached(resource, name string) ([]byte, error) {
+	return s.lhClient.LonghornV1beta2().RESTClient().Get().Namespace(s.namespace).Resource(resource).Name(name).DoRaw(context.TODO())
+}
+
 // GetAllBackingImages returns an uncached list of BackingImage in Longhorn
 // namespace directly from the API server.
 // Using cached informers should be preferred but current lister doesn't have a
@@ -53,34 +53,31 @@ func NewBackupTargetClientFromBackupTarget(backupTarget *longhorn.BackupTarget,
 		return nil, err
 	}
 
-	backupType, err := util.CheckBackupType(backupTarget.Spec.BackupTargetURL)
+	credential, err := ds.GetBackupTargetCredential(backupTarget)
 	if err != nil {
 		return nil, err
 	}
 
-	var credential map[string]string
-	if types.BackupStoreRequireCredential(backupType) {
-		if backupTarget.Spec.CredentialSecret == "" {
-			return nil, errors.Errorf("cannot access %s without credential secret", backupType)
-		}
-
-		credential, err = ds.GetCredentialFromSecret(backupTarget.Spec.CredentialSecret)
-		if err != nil {
-			return nil, err
-		}
-	}
-
 	executeTimeout, err := ds.GetSettingAsInt(types.SettingNameBackupExecutionTimeout)
 	if err != nil {
 		return nil, err
 	}
 	timeout := time.Duration(executeTimeout) * time.Minute
 
-	return NewBackupTargetClient(defaultEngineImage, backupTarget.Spec.BackupTargetURL, credential, timeout), nil
+	backupTargetURL, err := ds.GetBackupTargetAccessURL(backupTarget)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewBackupTargetClient(defaultEngineImage, backupTargetURL, credential, timeout), nil
 }
 
+// getEngineBinaryDirectoryForImage returns the directory of the engine binary of an engine image. It's replaced in the
+// tests by the directory of a fake engine binary.
+var getEngineBinaryDirectoryForImage = types.GetEngineBinaryDirectoryOnHostForImage
+
 func (btc *BackupTargetClient) LonghornEngineBinary() string {
-	return filepath.Join(types.GetEngineBinaryDirectoryOnHostForImage(btc.Image), "longhorn")
+	return filepath.Join(getEngineBinaryDirectoryForImage(btc.Image), "longhorn")
 }
 
 // getBackupCredentialEnv returns the environment variables as KEY=VALUE in string slice
@@ -104,13 +101,22 @@ func getBackupCredentialEnv(backupTarget string, credential map[string]string) (
 		if credential[types.AWSSecretKey] == "" {
 			missingKeys = append(missingKeys, types.AWSSecretKey)
 		}
-		// If AWS IAM Role not present, then the AWS credentials must be exists
-		if credential[types.AWSIAMRoleArn] == "" && len(missingKeys) > 0 {
+		// If neither AWS IAM Role nor web identity role present, then the AWS credentials must be exists
+		if credential[types.AWSIAMRoleArn] == "" && credential[types.AWSRoleArn] == "" && len(missingKeys) > 0 {
 			return nil, fmt.Errorf("could not backup to %s, missing %v in the secret", backupType, missingKeys)
 		}
 		if len(missingKeys) == 0 {
 			envs = append(envs, fmt.Sprintf("%s=%s", types.AWSAccessKey, credential[types.AWSAccessKey]))
 			envs = append(envs, fmt.Sprintf("%s=%s", types.AWSSecretKey, credential[types.AWSSecretKey]))
+		} else if credential[types.AWSRoleArn] != "" {
+			tokenFile := credential[types.AWSWebIdentityTokenFile]
+			if tokenFile == "" {
+				tokenFile = types.DefaultAWSWebIdentityTokenFile
+			}
+			envs = append(envs, fmt.Sprintf("%s=%s", types.AWSRoleArn, credential[types.AWSRoleArn]))
+			envs = append(envs, fmt.Sprintf("%s=%s", types.AWSWebIdentityTokenFile, tokenFile))
+			envs = append(envs, fmt.Sprintf("%s=%s", types.AWSRoleSessionName, credential[types.AWSRoleSessionName]))
+			envs = append(envs, fmt.Sprintf("%s=%s", types.AWSSTSEndpoint, credential[types.AWSSTSEndpoint]))
 		}
 		envs = append(envs, fmt.Sprintf("%s=%s", types.AWSEndPoint, credential[types.AWSEndPoint]))
 		envs = append(envs, fmt.Sprintf("%s=%s", types.AWSCert, credential[types.AWSCert]))
@@ -1,12 +1,22 @@
 package engineapi
 
 import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/longhorn/longhorn-manager/types"
+
 	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
 )
 
@@ -122,6 +132,15 @@ func TestGetBackupCredentialEnv(t *testing.T) {
 				"AWS_IAM_ROLE_ARN":      "AWS_IAM_ARN: arn:aws:iam::013456789:role/longhorn",
 			},
 		},
+		{
+			name:         "provides AWS web identity role",
+			backupTarget: "s3://backupbucket@us-east-1/",
+			credential: map[string]string{
+				"AWS_ROLE_ARN":                "arn:aws:iam::013456789:role/longhorn",
+				"AWS_WEB_IDENTITY_TOKEN_FILE": "/var/run/secrets/sts/token",
+				"AWS_ENDPOINT_URL_STS":        "http://minio.default:9000",
+			},
+		},
 		{
 			name:         "provides nfs backup target",
 			backupTarget: "nfs://longhorn-test-nfs-svc.default:/opt/backupstore",
@@ -250,3 +269,135 @@ func TestConvertEngineBackupState(t *testing.T) {
 		})
 	}
 }
+
+const (
+	testWebIdentityRoleArn = "arn:aws:iam::013456789:role/longhorn"
+	testWebIdentityToken   = "projected-service-account-token"
+
+	// testBackupStoreHelperEnv makes the test binary act as the engine binary, see TestBackupStoreHelperProcess.
+	testBackupStoreHelperEnv = "LONGHORN_TEST_BACKUP_STORE_HELPER"
+)
+
+// newSTSEmulator returns an STS endpoint issuing temporary credentials for the AssumeRoleWithWebIdentity requests of
+// the role with the token.
+func newSTSEmulator(t *testing.T, roleArn, token string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if err := req.ParseForm(); err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if req.Form.Get("Action") != "AssumeRoleWithWebIdentity" ||
+			req.Form.Get("RoleArn") != roleArn ||
+			req.Form.Get("WebIdentityToken") != token {
+			http.Error(rw, "<ErrorResponse><Error><Code>AccessDenied</Code></Error></ErrorResponse>", http.StatusForbidden)
+			return
+		}
+		fmt.Fprint(rw, `<AssumeRoleWithWebIdentityResponse><AssumeRoleWithWebIdentityResult><Credentials>`+
+			`<AccessKeyId>ASIAEMULATOR</AccessKeyId><SecretAccessKey>secret</SecretAccessKey><SessionToken>session</SessionToken>`+
+			`</Credentials></AssumeRoleWithWebIdentityResult></AssumeRoleWithWebIdentityResponse>`)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// TestBackupStoreHelperProcess isn't a real test. It acts as the engine binary listing the backup volumes in
+// TestBackupVolumeNameListWithWebIdentity, assuming the role with the web identity token as the backup store does.
+func TestBackupStoreHelperProcess(t *testing.T) {
+	if os.Getenv(testBackupStoreHelperEnv) != "1" {
+		return
+	}
+
+	exit := func(err error) {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	token, err := os.ReadFile(os.Getenv(types.AWSWebIdentityTokenFile))
+	if err != nil {
+		exit(err)
+	}
+	resp, err := http.PostForm(os.Getenv(types.AWSSTSEndpoint), url.Values{
+		"Action":           {"AssumeRoleWithWebIdentity"},
+		"Version":          {"2011-06-15"},
+		"RoleArn":          {os.Getenv(types.AWSRoleArn)},
+		"RoleSessionName":  {os.Getenv(types.AWSRoleSessionName)},
+		"WebIdentityToken": {string(token)},
+	})
+	if err != nil {
+		exit(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		exit(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		exit(fmt.Errorf("failed to assume role: %s", body))
+	}
+	var result struct {
+		AccessKeyID string `xml:"AssumeRoleWithWebIdentityResult>Credentials>AccessKeyId"`
+	}
+	if err := xml.Unmarshal(body, &result); err != nil || result.AccessKeyID == "" {
+		exit(fmt.Errorf("failed to parse the credentials: %v: %s", err, body))
+	}
+
+	fmt.Print(`{"backup-volume": {}}`)
+	os.Exit(0)
+}
+
+func TestBackupVolumeNameListWithWebIdentity(t *testing.T) {
+	assert := require.New(t)
+
+	dir := t.TempDir()
+	script := fmt.Sprintf("#!/bin/sh\nexec %q -test.run='^TestBackupStoreHelperProcess$' -- \"$@\"\n", os.Args[0])
+	assert.NoError(os.WriteFile(filepath.Join(dir, "longhorn"), []byte(script), 0755))
+	getEngineBinaryDirectoryForImage = func(string) string { return dir }
+	defer func() { getEngineBinaryDirectoryForImage = types.GetEngineBinaryDirectoryOnHostForImage }()
+	t.Setenv(testBackupStoreHelperEnv, "1")
+
+	sts := newSTSEmulator(t, testWebIdentityRoleArn, testWebIdentityToken)
+
+	type testCase struct {
+		roleArn string
+		token   string
+
+		expectedNames []string
+		expectError   bool
+	}
+	testCases := map[string]testCase{
+		"web identity": {
+			roleArn:       testWebIdentityRoleArn,
+			token:         testWebIdentityToken,
+			expectedNames: []string{"backup-volume"},
+		},
+		"token of another service account": {
+			roleArn:     testWebIdentityRoleArn,
+			token:       "another-service-account-token",
+			expectError: true,
+		},
+		"role not trusting the token": {
+			roleArn:     "arn:aws:iam::013456789:role/other",
+			token:       testWebIdentityToken,
+			expectError: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		tokenFile := filepath.Join(t.TempDir(), "token")
+		assert.NoError(os.WriteFile(tokenFile, []byte(tc.token), 0600), name)
+
+		credential := map[string]string{
+			types.AWSRoleArn:              tc.roleArn,
+			types.AWSWebIdentityTokenFile: tokenFile,
+			types.AWSRoleSessionName:      "longhorn",
+			types.AWSSTSEndpoint:          sts.URL,
+		}
+		btc := NewBackupTargetClient("longhornio/longhorn-engine:test", "s3://backupbucket@us-east-1/", credential, time.Minute)
+		names, err := btc.BackupVolumeNameList()
+		if tc.expectError {
+			assert.Error(err, name)
+			continue
+		}
+		assert.NoError(err, name)
+		assert.Equal(tc.expectedNames, names, name)
+	}
+}
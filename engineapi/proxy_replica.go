@@ -46,7 +46,14 @@ func (p *Proxy) ReplicaRebuildStatus(e *longhorn.Engine) (status map[string]*lon
 
 	status = make(map[string]*longhorn.RebuildStatus)
 	for k, v := range recv {
-		status[k] = (*longhorn.RebuildStatus)(v)
+		status[k] = &longhorn.RebuildStatus{
+			Error:                 v.Error,
+			IsRebuilding:          v.IsRebuilding,
+			Progress:              v.Progress,
+			State:                 v.State,
+			FromReplicaAddress:    v.FromReplicaAddress,
+			AppliedRebuildingMBps: v.AppliedRebuildingMBps,
+		}
 	}
 	return status, nil
 }
@@ -85,7 +85,12 @@ func (p *Proxy) SnapshotPurgeStatus(e *longhorn.Engine) (status map[string]*long
 
 	status = map[string]*longhorn.PurgeStatus{}
 	for k, v := range recv {
-		status[k] = (*longhorn.PurgeStatus)(v)
+		status[k] = &longhorn.PurgeStatus{
+			Error:     v.Error,
+			IsPurging: v.IsPurging,
+			Progress:  v.Progress,
+			State:     v.State,
+		}
 	}
 	return status, nil
 }
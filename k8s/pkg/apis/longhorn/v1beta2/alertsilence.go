@@ -0,0 +1,47 @@
+package v1beta2
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// AlertSilenceSpec defines the desired state of the Longhorn AlertSilence
+type AlertSilenceSpec struct {
+	// The names of the silenced alerts. All alerts are silenced if empty.
+	// +optional
+	AlertNames []string `json:"alertNames"`
+	// The glob patterns of the names of the resources whose alerts are silenced, such as the volume names, or the
+	// disks in the form of <node>/<disk>. The alerts of all resources are silenced if empty.
+	// +optional
+	Resources []string `json:"resources"`
+	// The time that the silence expires. The silence never expires if not set.
+	// +optional
+	// +nullable
+	EndsAt metav1.Time `json:"endsAt"`
+	// The reason of the silence.
+	// +optional
+	Comment string `json:"comment"`
+}
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:shortName=lhasi
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="EndsAt",type=string,JSONPath=`.spec.endsAt`,description="The time that the silence expires"
+// +kubebuilder:printcolumn:name="Comment",type=string,JSONPath=`.spec.comment`,description="The reason of the silence"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// AlertSilence is where Longhorn stores the silence of the matching alerts
+type AlertSilence struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AlertSilenceSpec `json:"spec,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AlertSilenceList is a list of AlertSilences
+type AlertSilenceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AlertSilence `json:"items"`
+}
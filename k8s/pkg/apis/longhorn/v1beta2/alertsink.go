@@ -0,0 +1,117 @@
+package v1beta2
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// +kubebuilder:validation:Enum=webhook;smtp;event
+type AlertSinkType string
+
+const (
+	AlertSinkTypeWebhook = AlertSinkType("webhook")
+	AlertSinkTypeSMTP    = AlertSinkType("smtp")
+	AlertSinkTypeEvent   = AlertSinkType("event")
+)
+
+// AlertSinkSpec defines the desired state of the Longhorn AlertSink
+type AlertSinkSpec struct {
+	// The type of the sink. A webhook sink posts the alerts to the URL as JSON, an smtp sink emails the alerts
+	// through the SMTP relay, and an event sink records the alerts as Kubernetes events of the alerting resources.
+	Type AlertSinkType `json:"type"`
+	// The URL the webhook sink posts the alerts to.
+	// +optional
+	URL string `json:"url"`
+	// The address of the SMTP relay in the form of host:port.
+	// +optional
+	SMTPAddress string `json:"smtpAddress"`
+	// The sender address of the emails.
+	// +optional
+	SMTPFrom string `json:"smtpFrom"`
+	// The recipient addresses of the emails.
+	// +optional
+	SMTPTo []string `json:"smtpTo"`
+	// The secret in the Longhorn namespace providing the SMTP_USERNAME and SMTP_PASSWORD to authenticate with the
+	// SMTP relay, or the WEBHOOK_AUTHORIZATION header sent to the webhook URL.
+	// +optional
+	CredentialSecret string `json:"credentialSecret"`
+	// The names of the alerts sent to the sink. All alerts are sent if empty.
+	// +optional
+	AlertNames []string `json:"alertNames"`
+	// The interval to send a still firing alert again. A firing alert is only sent once if 0.
+	// +optional
+	RepeatInterval metav1.Duration `json:"repeatInterval"`
+	// Send a notification when a firing alert is resolved.
+	// +optional
+	SendResolved bool `json:"sendResolved"`
+}
+
+// AlertSinkSentAlert is a firing alert sent to the sink
+type AlertSinkSentAlert struct {
+	// The alert name.
+	Name string `json:"name"`
+	// +optional
+	Severity string `json:"severity"`
+	// The kind of the alerting resource.
+	// +optional
+	Kind string `json:"kind"`
+	// The name of the alerting resource.
+	ResourceName string `json:"resourceName"`
+	// +optional
+	Node string `json:"node"`
+	// +optional
+	Message string `json:"message"`
+	// +optional
+	StartsAt string `json:"startsAt"`
+	// The last time that the alert was sent to the sink.
+	// +optional
+	// +nullable
+	LastSentAt metav1.Time `json:"lastSentAt"`
+}
+
+// AlertSinkStatus defines the observed state of the Longhorn AlertSink
+type AlertSinkStatus struct {
+	// The node ID of the responsible controller to evaluate the alerts and send them to this sink.
+	// +optional
+	OwnerID string `json:"ownerID"`
+	// The number of the firing alerts of the sink, excluding the silenced ones.
+	// +optional
+	FiringAlerts int `json:"firingAlerts"`
+	// The last time that the alerts were sent to the sink.
+	// +optional
+	// +nullable
+	LastSentAt metav1.Time `json:"lastSentAt"`
+	// The error of the last failed sending.
+	// +optional
+	LastError string `json:"lastError"`
+	// The firing alerts sent to the sink, which are sent again after the repeat interval, and sent as resolved once
+	// they no longer fire, even by another controller after an owner change.
+	// +optional
+	// +nullable
+	SentAlerts []AlertSinkSentAlert `json:"sentAlerts"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:shortName=lhask
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`,description="The type of the sink"
+// +kubebuilder:printcolumn:name="FiringAlerts",type=integer,JSONPath=`.status.firingAlerts`,description="The number of the firing alerts"
+// +kubebuilder:printcolumn:name="LastSentAt",type=string,JSONPath=`.status.lastSentAt`,description="The last time that the alerts were sent"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// AlertSink is where Longhorn stores the destination the alerts are sent to
+type AlertSink struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AlertSinkSpec   `json:"spec,omitempty"`
+	Status AlertSinkStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AlertSinkList is a list of AlertSinks
+type AlertSinkList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []AlertSink `json:"items"`
+}
@@ -4,8 +4,22 @@ import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 const (
 	BackupTargetConditionTypeUnavailable = "Unavailable"
+	BackupTargetConditionTypeDegraded    = "Degraded"
 
-	BackupTargetConditionReasonUnavailable = "Unavailable"
+	BackupTargetConditionReasonUnavailable   = "Unavailable"
+	BackupTargetConditionReasonHighErrorRate = "HighErrorRate"
+	BackupTargetConditionReasonHighLatency   = "HighLatency"
+)
+
+type BackupTargetErrorClass string
+
+const (
+	BackupTargetErrorClassNone    = BackupTargetErrorClass("")
+	BackupTargetErrorClassAuth    = BackupTargetErrorClass("auth")
+	BackupTargetErrorClassDNS     = BackupTargetErrorClass("dns")
+	BackupTargetErrorClassTimeout = BackupTargetErrorClass("timeout")
+	BackupTargetErrorClassServer  = BackupTargetErrorClass("server")
+	BackupTargetErrorClassUnknown = BackupTargetErrorClass("unknown")
 )
 
 // BackupTargetSpec defines the desired state of the Longhorn backup target
@@ -41,6 +55,47 @@ type BackupTargetStatus struct {
 	// +optional
 	// +nullable
 	LastSyncedAt metav1.Time `json:"lastSyncedAt"`
+	// The results of the recent health probes against the remote backup target.
+	// +optional
+	Health BackupTargetHealth `json:"health"`
+}
+
+// BackupTargetHealth summarizes the probes periodically done against the remote backup target, which list the backup
+// store, then do the put, list, get and delete round-trips of a probe object.
+type BackupTargetHealth struct {
+	// The last time that the controller probed the remote backup target.
+	// +optional
+	// +nullable
+	LastProbedAt metav1.Time `json:"lastProbedAt"`
+	// The number of recent probes the statistics are computed from.
+	// +optional
+	ProbeCount int `json:"probeCount"`
+	// The percentage of the recent probes that failed.
+	// +optional
+	ErrorRate int `json:"errorRate"`
+	// The median latency of the recent probes in milliseconds.
+	// +optional
+	LatencyP50 int64 `json:"latencyP50"`
+	// The 95th percentile latency of the recent probes in milliseconds.
+	// +optional
+	LatencyP95 int64 `json:"latencyP95"`
+	// The 99th percentile latency of the recent probes in milliseconds.
+	// +optional
+	LatencyP99 int64 `json:"latencyP99"`
+	// The class of the last probe error. Can be "auth", "dns", "timeout", "server" or "unknown".
+	// +optional
+	LastErrorClass BackupTargetErrorClass `json:"lastErrorClass"`
+	// The last probe error.
+	// +optional
+	LastError string `json:"lastError"`
+	// The last time that a probe failed.
+	// +optional
+	// +nullable
+	LastErrorAt metav1.Time `json:"lastErrorAt"`
+	// Whether the last probe found the remote backup target read-only, such as the backup target of a disaster recovery
+	// cluster. A read-only backup target is healthy as long as it can be listed.
+	// +optional
+	ReadOnly bool `json:"readOnly"`
 }
 
 // +genclient
@@ -53,6 +53,20 @@ type PurgeStatus struct {
 	Progress int `json:"progress"`
 	// +optional
 	State string `json:"state"`
+	// The estimated size in bytes of the data to be processed, set by the engine monitor when the operation starts.
+	// +optional
+	TotalBytes int64 `json:"totalBytes"`
+	// The size in bytes of the data processed so far, estimated from the progress and the total bytes since the
+	// engine doesn't report it.
+	// +optional
+	EstimatedTransferredBytes int64 `json:"estimatedTransferredBytes"`
+	// The processing rate in bytes per second, estimated from the progress and averaged since the operation started.
+	// +optional
+	BytesPerSecond int64 `json:"bytesPerSecond"`
+	// The time the progress last changed, in RFC3339. The estimated time until the operation completes is computed from
+	// it when it's read, so that the status doesn't change while the progress doesn't.
+	// +optional
+	SampledAt string `json:"sampledAt"`
 }
 
 type HashStatus struct {
@@ -79,6 +93,20 @@ type RebuildStatus struct {
 	FromReplicaAddress string `json:"fromReplicaAddress"`
 	// +optional
 	AppliedRebuildingMBps int64 `json:"appliedRebuildingMBps"`
+	// The estimated size in bytes of the data to be processed, set by the engine monitor when the operation starts.
+	// +optional
+	TotalBytes int64 `json:"totalBytes"`
+	// The size in bytes of the data processed so far, estimated from the progress and the total bytes since the
+	// engine doesn't report it.
+	// +optional
+	EstimatedTransferredBytes int64 `json:"estimatedTransferredBytes"`
+	// The processing rate in bytes per second, estimated from the progress and averaged since the operation started.
+	// +optional
+	BytesPerSecond int64 `json:"bytesPerSecond"`
+	// The time the progress last changed, in RFC3339. The estimated time until the operation completes is computed from
+	// it when it's read, so that the status doesn't change while the progress doesn't.
+	// +optional
+	SampledAt string `json:"sampledAt"`
 }
 
 type SnapshotCloneStatus struct {
@@ -201,6 +229,10 @@ type EngineStatus struct {
 	// +kubebuilder:validation:Type=string
 	// +optional
 	SnapshotMaxSize int64 `json:"snapshotMaxSize,string"`
+	// The time since when the sampled IOPS of the engine has stayed below the ColdBelowIOPS of the volume tiering
+	// policy. Empty if the IOPS is not sampled or not low.
+	// +optional
+	LowIOPSSince string `json:"lowIOPSSince"`
 }
 
 // +genclient
@@ -11,6 +11,7 @@ const (
 	NodeConditionTypeNFSClientInstalled  = "NFSClientInstalled"
 	NodeConditionTypeSchedulable         = "Schedulable"
 	NodeConditionTypeHugePagesAvailable  = "HugePagesAvailable"
+	NodeConditionTypeCapacityAtRisk      = "CapacityAtRisk"
 )
 
 const (
@@ -32,6 +33,10 @@ const (
 	NodeConditionReasonKubernetesNodeCordoned    = "KubernetesNodeCordoned"
 	NodeConditionReasonHugePagesNotConfigured    = "HugePagesNotConfigured"
 	NodeConditionReasonInsufficientHugePages     = "InsufficientHugePages"
+	NodeConditionReasonEnvironmentCheckPassed    = "EnvironmentCheckPassed"
+	NodeConditionReasonEnvironmentCheckFailed    = "EnvironmentCheckFailed"
+	NodeConditionReasonEnvironmentCheckWarning   = "EnvironmentCheckWarning"
+	NodeConditionReasonDiskProjectedFull         = "DiskProjectedFull"
 )
 
 const (
@@ -58,6 +63,7 @@ const (
 	ErrorReplicaScheduleEngineImageNotReady               = "none of the node candidates contains a ready engine image"
 	ErrorReplicaScheduleHardNodeAffinityNotSatisfied      = "hard affinity cannot be satisfied"
 	ErrorReplicaScheduleLinkedCloneNotSatisfied           = "linked clone replica cannot be satisfied"
+	ErrorReplicaScheduleTopologySpreadNotSatisfied        = "topology spread constraints cannot be satisfied"
 	ErrorReplicaScheduleSchedulingFailed                  = "replica scheduling failed"
 	ErrorReplicaScheduleUnusedFailedReplicaIsNotSupported = "unused failed replica is not supported"
 	ErrorReplicaScheduleReplicaAlreadyScheduled           = "replica already scheduled"
@@ -105,6 +111,11 @@ type DiskSpec struct {
 	StorageReserved int64 `json:"storageReserved"`
 	// +optional
 	Tags []string `json:"tags"`
+	// ReplacedDiskUUID is the UUID of the failed disk that this disk replaces. The replicas on the failed disk are
+	// moved to this disk and rebuilt in place, ahead of the other rebuildings, once it becomes ready. It is cleared
+	// once the replicas are moved.
+	// +optional
+	ReplacedDiskUUID string `json:"replacedDiskUUID"`
 }
 
 type DiskStatus struct {
@@ -137,6 +148,25 @@ type DiskStatus struct {
 	FSType string `json:"filesystemType"`
 	// +optional
 	InstanceManagerName string `json:"instanceManagerName"`
+	// The growth rate of the actual usage of the replicas on the disk in bytes per day, computed from the usage history.
+	// +optional
+	StorageGrowthRate int64 `json:"storageGrowthRate"`
+	// The time at which the disk is projected to become full at the current growth rate. Empty if the usage is not growing.
+	// +optional
+	ProjectedFullTime string `json:"projectedFullTime"`
+	// The recent samples of the actual usage of the replicas on the disk, from the oldest to the newest.
+	// +optional
+	// +nullable
+	UsageHistory []DiskUsageSample `json:"usageHistory"`
+}
+
+// DiskUsageSample is a sample of the actual usage of the replicas on a disk.
+type DiskUsageSample struct {
+	// +optional
+	Timestamp metav1.Time `json:"timestamp"`
+	// The sum of the actual sizes of the replicas on the disk in bytes.
+	// +optional
+	ReplicaUsage int64 `json:"replicaUsage"`
 }
 
 // NodeSpec defines the desired state of the Longhorn node
@@ -167,6 +197,10 @@ type NodeStatus struct {
 	Region string `json:"region"`
 	// +optional
 	Zone string `json:"zone"`
+	// The topology domains of the node, keyed by the topology levels defined in the topology-levels setting.
+	// +optional
+	// +nullable
+	Topology map[string]string `json:"topology"`
 	// +optional
 	SnapshotCheckStatus SnapshotCheckStatus `json:"snapshotCheckStatus"`
 	// +optional
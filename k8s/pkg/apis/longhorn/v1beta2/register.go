@@ -21,6 +21,10 @@ func Resource(resource string) schema.GroupResource {
 
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
+		&AlertSilence{},
+		&AlertSilenceList{},
+		&AlertSink{},
+		&AlertSinkList{},
 		&BackingImage{},
 		&BackingImageList{},
 		&BackingImageDataSource{},
@@ -55,6 +59,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ShareManagerList{},
 		&Snapshot{},
 		&SnapshotList{},
+		&StorageQuota{},
+		&StorageQuotaList{},
 		&SupportBundle{},
 		&SupportBundleList{},
 		&SystemBackup{},
@@ -65,6 +71,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&VolumeList{},
 		&VolumeAttachment{},
 		&VolumeAttachmentList{},
+		&VolumePolicy{},
+		&VolumePolicyList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -86,9 +86,48 @@ type ReplicaSpec struct {
 	SnapshotMaxSize int64 `json:"snapshotMaxSize,string"`
 }
 
+// ReplicaSalvageMetadata is the metadata of the data of a failed replica, inspected on the node of the replica to
+// compare the replicas before a salvage.
+type ReplicaSalvageMetadata struct {
+	// The FailedAt of the replica when the metadata was inspected. The metadata is inspected again after another failure.
+	// +optional
+	FailedAt string `json:"failedAt"`
+	// +optional
+	InspectedAt string `json:"inspectedAt"`
+	// The error of the inspection, if the metadata can't be read.
+	// +optional
+	Error string `json:"error"`
+	// The number of the writes to the replica. It's 0 if the revision counter is disabled.
+	// +kubebuilder:validation:Type=string
+	// +optional
+	RevisionCounter int64 `json:"revisionCounter,string"`
+	// +optional
+	HeadName string `json:"headName"`
+	// The actual size of the volume head file in bytes.
+	// +kubebuilder:validation:Type=string
+	// +optional
+	HeadSize int64 `json:"headSize,string"`
+	// The last time the volume head file was modified.
+	// +optional
+	HeadLastModifiedAt string `json:"headLastModifiedAt"`
+	// +optional
+	Dirty bool `json:"dirty"`
+	// Rebuilding is true if the replica failed before the rebuild completed.
+	// +optional
+	Rebuilding bool `json:"rebuilding"`
+	// The snapshots in the chain of the volume head, from the newest to the oldest.
+	// +optional
+	// +nullable
+	Chain []string `json:"chain"`
+}
+
 // ReplicaStatus defines the observed state of the Longhorn replica
 type ReplicaStatus struct {
 	InstanceStatus `json:""`
+	// The metadata of the data of the replica, inspected after the replica failed.
+	// +optional
+	// +nullable
+	SalvageMetadata *ReplicaSalvageMetadata `json:"salvageMetadata"`
 }
 
 // +genclient
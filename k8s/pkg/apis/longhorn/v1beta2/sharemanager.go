@@ -13,11 +13,58 @@ const (
 	ShareManagerStateError    = ShareManagerState("error")
 )
 
+type NFSExportSquash string
+
+const (
+	NFSExportSquashDefault = NFSExportSquash("")
+	NFSExportSquashNone    = NFSExportSquash("none")
+	NFSExportSquashRoot    = NFSExportSquash("root")
+	NFSExportSquashAll     = NFSExportSquash("all")
+)
+
+type NFSExportSecFlavor string
+
+const (
+	NFSExportSecFlavorDefault = NFSExportSecFlavor("")
+	NFSExportSecFlavorSys     = NFSExportSecFlavor("sys")
+	NFSExportSecFlavorKrb5    = NFSExportSecFlavor("krb5")
+	NFSExportSecFlavorKrb5i   = NFSExportSecFlavor("krb5i")
+	NFSExportSecFlavorKrb5p   = NFSExportSecFlavor("krb5p")
+)
+
+// NFSExportOptions restricts how the share manager exports an RWX volume over NFS. The empty options keep the defaults
+// of the share manager, which exports the volume read-write to every client.
+type NFSExportOptions struct {
+	// Squash maps the root user (root) or every user (all) of the clients to the anonymous user, or none of them (none).
+	// +optional
+	Squash NFSExportSquash `json:"squash"`
+	// The user ID of the anonymous user. Set this value to 0 to use the default of the share manager.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	AnonUID int64 `json:"anonUID"`
+	// The group ID of the anonymous user. Set this value to 0 to use the default of the share manager.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	AnonGID int64 `json:"anonGID"`
+	// The CIDRs of the clients allowed to mount the volume. Every client is allowed if it's empty.
+	// +optional
+	AllowedClients []string `json:"allowedClients"`
+	// The security flavor the clients authenticate with.
+	// +optional
+	Sec NFSExportSecFlavor `json:"sec"`
+	// ReadOnly exports the volume read-only.
+	// +optional
+	ReadOnly bool `json:"readOnly"`
+}
+
 // ShareManagerSpec defines the desired state of the Longhorn share manager
 type ShareManagerSpec struct {
 	// Share manager image used for creating a share manager pod
 	// +optional
 	Image string `json:"image"`
+	// The NFS export options of the volume, applied when the share manager pod is created.
+	// +optional
+	NFSExportOptions NFSExportOptions `json:"nfsExportOptions"`
 }
 
 // ShareManagerStatus defines the observed state of the Longhorn share manager
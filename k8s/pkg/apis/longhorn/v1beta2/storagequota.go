@@ -0,0 +1,91 @@
+package v1beta2
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+const (
+	StorageQuotaConditionTypeExceeded = "Exceeded"
+
+	StorageQuotaConditionReasonExceeded = "Exceeded"
+)
+
+// StorageQuotaSpec defines the desired state of the Longhorn StorageQuota
+type StorageQuotaSpec struct {
+	// The namespace of the PVCs of the volumes the quota applies to.
+	Namespace string `json:"namespace"`
+	// The maximum total size of the volumes multiplied by their numbers of replicas in bytes. No limit if 0.
+	// +kubebuilder:validation:Type=string
+	// +optional
+	MaxProvisionedSize int64 `json:"maxProvisionedSize,string"`
+	// The maximum total actual size of the snapshots of the volumes multiplied by their numbers of replicas in bytes.
+	// No limit if 0.
+	// +kubebuilder:validation:Type=string
+	// +optional
+	MaxSnapshotSize int64 `json:"maxSnapshotSize,string"`
+	// The maximum number of the snapshots of the volumes. No limit if 0.
+	// +optional
+	MaxSnapshotCount int `json:"maxSnapshotCount"`
+	// The maximum total size of the data uploaded by the backups of the volumes in bytes. No limit if 0.
+	// +kubebuilder:validation:Type=string
+	// +optional
+	MaxBackupSize int64 `json:"maxBackupSize,string"`
+}
+
+// StorageQuotaUsage is the storage consumed by the volumes of a namespace
+type StorageQuotaUsage struct {
+	// The total size of the volumes multiplied by their numbers of replicas in bytes.
+	// +kubebuilder:validation:Type=string
+	// +optional
+	ProvisionedSize int64 `json:"provisionedSize,string"`
+	// The total actual size of the snapshots multiplied by the numbers of replicas of their volumes in bytes.
+	// +kubebuilder:validation:Type=string
+	// +optional
+	SnapshotSize int64 `json:"snapshotSize,string"`
+	// The number of the snapshots.
+	// +optional
+	SnapshotCount int `json:"snapshotCount"`
+	// The total size of the data uploaded by the backups in bytes.
+	// +kubebuilder:validation:Type=string
+	// +optional
+	BackupSize int64 `json:"backupSize,string"`
+}
+
+// StorageQuotaStatus defines the observed state of the Longhorn StorageQuota
+type StorageQuotaStatus struct {
+	// The node ID of the responsible controller to reconcile this StorageQuota.
+	// +optional
+	OwnerID string `json:"ownerID"`
+	// The storage consumed by the volumes of the namespace.
+	// +optional
+	Usage StorageQuotaUsage `json:"usage"`
+	// +optional
+	// +nullable
+	Conditions []Condition `json:"conditions"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:shortName=lhsq
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Namespace",type=string,JSONPath=`.spec.namespace`,description="The namespace the quota applies to"
+// +kubebuilder:printcolumn:name="ProvisionedSize",type=string,JSONPath=`.status.usage.provisionedSize`,description="The provisioned size of the volumes multiplied by the numbers of replicas"
+// +kubebuilder:printcolumn:name="SnapshotCount",type=integer,JSONPath=`.status.usage.snapshotCount`,description="The number of the snapshots"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// StorageQuota is where Longhorn stores the storage quota of a namespace
+type StorageQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   StorageQuotaSpec   `json:"spec,omitempty"`
+	Status StorageQuotaStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// StorageQuotaList is a list of StorageQuotas
+type StorageQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []StorageQuota `json:"items"`
+}
@@ -63,12 +63,15 @@ const (
 	DataLocalityStrictLocal = DataLocality("strict-local")
 )
 
-// +kubebuilder:validation:Enum=rwo;rwx
+// +kubebuilder:validation:Enum=rwo;rwx;rox
 type AccessMode string
 
 const (
 	AccessModeReadWriteOnce = AccessMode("rwo")
 	AccessModeReadWriteMany = AccessMode("rwx")
+	// AccessModeReadOnlyMany volumes are served by a share manager like RWX volumes, and mounted read-only by the CSI
+	// plugin on the workload nodes.
+	AccessModeReadOnlyMany = AccessMode("rox")
 )
 
 // +kubebuilder:validation:Enum=ignored;disabled;least-effort;best-effort
@@ -126,6 +129,7 @@ const (
 	VolumeConditionTypeRestore             = "Restore"
 	VolumeConditionTypeTooManySnapshots    = "TooManySnapshots"
 	VolumeConditionTypeWaitForBackingImage = "WaitForBackingImage"
+	VolumeConditionTypeCapacityAtRisk      = "CapacityAtRisk"
 )
 
 const (
@@ -136,6 +140,7 @@ const (
 	VolumeConditionReasonTooManySnapshots              = "TooManySnapshots"
 	VolumeConditionReasonWaitForBackingImageFailed     = "GetBackingImageFailed"
 	VolumeConditionReasonWaitForBackingImageWaiting    = "Waiting"
+	VolumeConditionReasonDiskProjectedFull             = "DiskProjectedFull"
 )
 
 type SnapshotDataIntegrity string
@@ -200,6 +205,54 @@ const (
 	DataEngineTypeAll = DataEngineType("all")
 )
 
+// +kubebuilder:validation:Enum=hot;cold;""
+type StorageTier string
+
+const (
+	StorageTierNone = StorageTier("")
+	StorageTierHot  = StorageTier("hot")
+	StorageTierCold = StorageTier("cold")
+)
+
+// VolumeTieringPolicy moves the replicas of a volume between two tiers of disks. The replicas are placed on the disks
+// matching HotDiskSelector while the volume is in use, and are relocated to the disks matching ColdDiskSelector once no
+// workload has attached the volume, or the IOPS of the volume has stayed below ColdBelowIOPS, for ColdAfterDetachedDays
+// days.
+type VolumeTieringPolicy struct {
+	// The disk tags of the hot tier.
+	// +optional
+	HotDiskSelector []string `json:"hotDiskSelector"`
+	// The disk tags of the cold tier.
+	// +optional
+	ColdDiskSelector []string `json:"coldDiskSelector"`
+	// The number of days without workload attachment before the volume is moved to the cold tier. Set this value to 0 to
+	// disable tiering.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ColdAfterDetachedDays int64 `json:"coldAfterDetachedDays"`
+	// The IOPS below which an attached volume is considered idle, so it is moved to the cold tier once its IOPS stays
+	// below this value for ColdAfterDetachedDays days. Set this value to 0 to consider only the detached volumes idle.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	ColdBelowIOPS int64 `json:"coldBelowIOPS"`
+}
+
+// TopologySpreadConstraint restricts how the replicas of a volume are spread across the domains of a topology level.
+// The topology level is either region, zone, or a level defined in the topology-levels setting.
+type TopologySpreadConstraint struct {
+	// The topology level the constraint applies to, such as zone or rack.
+	// +optional
+	TopologyLevel string `json:"topologyLevel"`
+	// The maximum number of replicas in a single domain of the level. Set this value to 0 for no limit.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxReplicasPerDomain int `json:"maxReplicasPerDomain"`
+	// The minimum number of domains of the level the replicas should be spread across. Set this value to 0 for no limit.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinDomains int `json:"minDomains"`
+}
+
 type KubernetesStatus struct {
 	// +optional
 	PVName string `json:"pvName"`
@@ -329,6 +382,18 @@ type VolumeSpec struct {
 	// +kubebuilder:validation:Minimum=0
 	// +optional
 	ReplicaRebuildingBandwidthLimit int64 `json:"replicaRebuildingBandwidthLimit"`
+	// TieringPolicy relocates the replicas between the hot and cold disk tiers. The replicas are migrated one at a time
+	// through the replica eviction, so the migration is subject to ReplicaRebuildingBandwidthLimit.
+	// +optional
+	TieringPolicy VolumeTieringPolicy `json:"tieringPolicy"`
+	// TopologySpreadConstraints are the hard constraints of spreading the replicas across the topology domains.
+	// +optional
+	// +nullable
+	TopologySpreadConstraints []TopologySpreadConstraint `json:"topologySpreadConstraints"`
+	// NFSExportOptions restricts the clients of an RWX volume and how they access it. The options are applied the
+	// next time the share manager pod is created.
+	// +optional
+	NFSExportOptions NFSExportOptions `json:"nfsExportOptions"`
 }
 
 // VolumeStatus defines the observed state of the Longhorn volume
@@ -377,6 +442,13 @@ type VolumeStatus struct {
 	ShareEndpoint string `json:"shareEndpoint"`
 	// +optional
 	ShareState ShareManagerState `json:"shareState"`
+	// The disk tier that the replicas are placed on according to the tiering policy.
+	// +optional
+	StorageTier StorageTier `json:"storageTier"`
+	// The time since when no workload has attached the volume, or the IOPS of the volume has stayed below the
+	// ColdBelowIOPS of the tiering policy. Empty while the volume is in use.
+	// +optional
+	IdleSince string `json:"idleSince"`
 }
 
 // +genclient
@@ -52,6 +52,9 @@ const (
 	AttacherTypeVolumeExpansionController        = AttacherType("volume-expansion-controller")
 	AttacherTypeBackingImageDataSourceController = AttacherType("bim-ds-controller")
 	AttacherTypeVolumeRebuildingController       = AttacherType("volume-rebuilding-controller")
+	// AttacherTypeBackupTargetController consumes the share of the volume storing the backups of a file backup target,
+	// the same way as a workload.
+	AttacherTypeBackupTargetController = AttacherType("backup-target-controller")
 )
 
 const (
@@ -61,6 +64,7 @@ const (
 	AttacherPriorityLevelCSIAttacher                      = 900
 	AttacherPriorityLevelSalvageController                = 900
 	AttacherPriorityLevelShareManagerController           = 900
+	AttacherPriorityLevelBackupTargetController           = 900
 	AttacherPriorityLevelSnapshotController               = 800
 	AttacherPriorityLevelBackupController                 = 800
 	AttacherPriorityLevelVolumeCloneController            = 800
@@ -110,6 +114,8 @@ func GetAttacherPriorityLevel(t AttacherType) int {
 		return AttacherPriorityLevelVolumeExpansionController
 	case AttacherTypeBackingImageDataSourceController:
 		return AttacherPriorityLevelBackingImageDataSourceController
+	case AttacherTypeBackupTargetController:
+		return AttacherPriorityLevelBackupTargetController
 	default:
 		return 0
 	}
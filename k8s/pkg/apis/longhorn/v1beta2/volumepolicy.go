@@ -0,0 +1,104 @@
+package v1beta2
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+type VolumePolicyEnforcement string
+
+const (
+	VolumePolicyEnforcementDeny   = VolumePolicyEnforcement("deny")
+	VolumePolicyEnforcementMutate = VolumePolicyEnforcement("mutate")
+
+	VolumePolicyConditionTypeCompliant = "Compliant"
+
+	VolumePolicyConditionReasonViolatingVolumes = "ViolatingVolumes"
+)
+
+// VolumePolicySpec defines the desired state of the Longhorn VolumePolicy
+type VolumePolicySpec struct {
+	// The namespaces of the PVCs of the volumes the policy applies to. The policy applies to the volumes in all namespaces if empty.
+	// +optional
+	Namespaces []string `json:"namespaces"`
+	// The label selector of the volumes the policy applies to. The policy applies to all volumes if not set.
+	// +optional
+	// +nullable
+	VolumeSelector *metav1.LabelSelector `json:"volumeSelector"`
+	// How a new volume or an update violating the policy is handled. "deny", the default, rejects it. "mutate" raises the number of
+	// replicas and sets the data locality of the volume to satisfy the policy, and rejects the other violations.
+	// +kubebuilder:validation:Enum=deny;mutate
+	// +optional
+	Enforcement VolumePolicyEnforcement `json:"enforcement"`
+	// The minimum number of replicas of the volumes. No minimum if 0.
+	// +optional
+	MinNumberOfReplicas int `json:"minNumberOfReplicas"`
+	// The data engines the volumes are allowed to use. All data engines are allowed if empty.
+	// +kubebuilder:validation:items:Enum=v1;v2
+	// +optional
+	AllowedDataEngines []DataEngineType `json:"allowedDataEngines"`
+	// Require the volumes to be encrypted.
+	// +optional
+	RequireEncryption bool `json:"requireEncryption"`
+	// The maximum size of the volumes in bytes. No maximum if 0.
+	// +kubebuilder:validation:Type=string
+	// +optional
+	MaxSize int64 `json:"maxSize,string"`
+	// The backup targets the volumes are allowed to use. All backup targets are allowed if empty.
+	// +optional
+	AllowedBackupTargets []string `json:"allowedBackupTargets"`
+	// The data locality the volumes must use. Not enforced if empty.
+	// +kubebuilder:validation:Enum="";disabled;best-effort;strict-local
+	// +optional
+	DataLocality DataLocality `json:"dataLocality"`
+}
+
+// VolumePolicyViolation is an existing volume violating the policy
+type VolumePolicyViolation struct {
+	// The volume name.
+	Volume string `json:"volume"`
+	// The violations of the volume.
+	// +optional
+	Messages []string `json:"messages"`
+}
+
+// VolumePolicyStatus defines the observed state of the Longhorn VolumePolicy
+type VolumePolicyStatus struct {
+	// The node ID of the responsible controller to reconcile this VolumePolicy.
+	// +optional
+	OwnerID string `json:"ownerID"`
+	// The number of existing volumes the policy applies to.
+	// +optional
+	MatchedVolumeCount int `json:"matchedVolumeCount"`
+	// The existing volumes violating the policy, for example, the volumes created before the policy.
+	// +optional
+	// +nullable
+	ViolatingVolumes []VolumePolicyViolation `json:"violatingVolumes"`
+	// +optional
+	// +nullable
+	Conditions []Condition `json:"conditions"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:shortName=lhvp
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Enforcement",type=string,JSONPath=`.spec.enforcement`,description="How the violations are handled"
+// +kubebuilder:printcolumn:name="Matched",type=integer,JSONPath=`.status.matchedVolumeCount`,description="The number of volumes the policy applies to"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// VolumePolicy is where Longhorn stores the constraints enforced on the matching volumes
+type VolumePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VolumePolicySpec   `json:"spec,omitempty"`
+	Status VolumePolicyStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VolumePolicyList is a list of VolumePolicies
+type VolumePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VolumePolicy `json:"items"`
+}
@@ -22,9 +22,226 @@ limitations under the License.
 package v1beta2
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertSilence) DeepCopyInto(out *AlertSilence) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertSilence.
+func (in *AlertSilence) DeepCopy() *AlertSilence {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertSilence)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AlertSilence) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertSilenceList) DeepCopyInto(out *AlertSilenceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AlertSilence, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertSilenceList.
+func (in *AlertSilenceList) DeepCopy() *AlertSilenceList {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertSilenceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AlertSilenceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertSilenceSpec) DeepCopyInto(out *AlertSilenceSpec) {
+	*out = *in
+	if in.AlertNames != nil {
+		in, out := &in.AlertNames, &out.AlertNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.EndsAt.DeepCopyInto(&out.EndsAt)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertSilenceSpec.
+func (in *AlertSilenceSpec) DeepCopy() *AlertSilenceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertSilenceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertSink) DeepCopyInto(out *AlertSink) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertSink.
+func (in *AlertSink) DeepCopy() *AlertSink {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AlertSink) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertSinkList) DeepCopyInto(out *AlertSinkList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AlertSink, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertSinkList.
+func (in *AlertSinkList) DeepCopy() *AlertSinkList {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertSinkList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AlertSinkList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertSinkSentAlert) DeepCopyInto(out *AlertSinkSentAlert) {
+	*out = *in
+	in.LastSentAt.DeepCopyInto(&out.LastSentAt)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertSinkSentAlert.
+func (in *AlertSinkSentAlert) DeepCopy() *AlertSinkSentAlert {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertSinkSentAlert)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertSinkSpec) DeepCopyInto(out *AlertSinkSpec) {
+	*out = *in
+	if in.SMTPTo != nil {
+		in, out := &in.SMTPTo, &out.SMTPTo
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AlertNames != nil {
+		in, out := &in.AlertNames, &out.AlertNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.RepeatInterval = in.RepeatInterval
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertSinkSpec.
+func (in *AlertSinkSpec) DeepCopy() *AlertSinkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertSinkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AlertSinkStatus) DeepCopyInto(out *AlertSinkStatus) {
+	*out = *in
+	in.LastSentAt.DeepCopyInto(&out.LastSentAt)
+	if in.SentAlerts != nil {
+		in, out := &in.SentAlerts, &out.SentAlerts
+		*out = make([]AlertSinkSentAlert, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AlertSinkStatus.
+func (in *AlertSinkStatus) DeepCopy() *AlertSinkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AlertSinkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AttachmentTicket) DeepCopyInto(out *AttachmentTicket) {
 	*out = *in
@@ -761,6 +978,24 @@ func (in *BackupTarget) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupTargetHealth) DeepCopyInto(out *BackupTargetHealth) {
+	*out = *in
+	in.LastProbedAt.DeepCopyInto(&out.LastProbedAt)
+	in.LastErrorAt.DeepCopyInto(&out.LastErrorAt)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupTargetHealth.
+func (in *BackupTargetHealth) DeepCopy() *BackupTargetHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupTargetHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BackupTargetList) DeepCopyInto(out *BackupTargetList) {
 	*out = *in
@@ -821,6 +1056,7 @@ func (in *BackupTargetStatus) DeepCopyInto(out *BackupTargetStatus) {
 		copy(*out, *in)
 	}
 	in.LastSyncedAt.DeepCopyInto(&out.LastSyncedAt)
+	in.Health.DeepCopyInto(&out.Health)
 	return
 }
 
@@ -1037,6 +1273,13 @@ func (in *DiskStatus) DeepCopyInto(out *DiskStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.UsageHistory != nil {
+		in, out := &in.UsageHistory, &out.UsageHistory
+		*out = make([]DiskUsageSample, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1050,6 +1293,23 @@ func (in *DiskStatus) DeepCopy() *DiskStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskUsageSample) DeepCopyInto(out *DiskUsageSample) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskUsageSample.
+func (in *DiskUsageSample) DeepCopy() *DiskUsageSample {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskUsageSample)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Engine) DeepCopyInto(out *Engine) {
 	*out = *in
@@ -1655,6 +1915,27 @@ func (in *KubernetesStatus) DeepCopy() *KubernetesStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NFSExportOptions) DeepCopyInto(out *NFSExportOptions) {
+	*out = *in
+	if in.AllowedClients != nil {
+		in, out := &in.AllowedClients, &out.AllowedClients
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NFSExportOptions.
+func (in *NFSExportOptions) DeepCopy() *NFSExportOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(NFSExportOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Node) DeepCopyInto(out *Node) {
 	*out = *in
@@ -1767,6 +2048,13 @@ func (in *NodeStatus) DeepCopyInto(out *NodeStatus) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.Topology != nil {
+		in, out := &in.Topology, &out.Topology
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	in.SnapshotCheckStatus.DeepCopyInto(&out.SnapshotCheckStatus)
 	return
 }
@@ -2091,6 +2379,27 @@ func (in *ReplicaList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicaSalvageMetadata) DeepCopyInto(out *ReplicaSalvageMetadata) {
+	*out = *in
+	if in.Chain != nil {
+		in, out := &in.Chain, &out.Chain
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicaSalvageMetadata.
+func (in *ReplicaSalvageMetadata) DeepCopy() *ReplicaSalvageMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicaSalvageMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReplicaSpec) DeepCopyInto(out *ReplicaSpec) {
 	*out = *in
@@ -2112,6 +2421,11 @@ func (in *ReplicaSpec) DeepCopy() *ReplicaSpec {
 func (in *ReplicaStatus) DeepCopyInto(out *ReplicaStatus) {
 	*out = *in
 	in.InstanceStatus.DeepCopyInto(&out.InstanceStatus)
+	if in.SalvageMetadata != nil {
+		in, out := &in.SalvageMetadata, &out.SalvageMetadata
+		*out = new(ReplicaSalvageMetadata)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -2222,7 +2536,7 @@ func (in *ShareManager) DeepCopyInto(out *ShareManager) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	out.Status = in.Status
 	return
 }
@@ -2281,6 +2595,7 @@ func (in *ShareManagerList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ShareManagerSpec) DeepCopyInto(out *ShareManagerSpec) {
 	*out = *in
+	in.NFSExportOptions.DeepCopyInto(&out.NFSExportOptions)
 	return
 }
 
@@ -2487,6 +2802,121 @@ func (in *SnapshotStatus) DeepCopy() *SnapshotStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageQuota) DeepCopyInto(out *StorageQuota) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageQuota.
+func (in *StorageQuota) DeepCopy() *StorageQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *StorageQuota) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageQuotaList) DeepCopyInto(out *StorageQuotaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]StorageQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageQuotaList.
+func (in *StorageQuotaList) DeepCopy() *StorageQuotaList {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageQuotaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *StorageQuotaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageQuotaSpec) DeepCopyInto(out *StorageQuotaSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageQuotaSpec.
+func (in *StorageQuotaSpec) DeepCopy() *StorageQuotaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageQuotaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageQuotaStatus) DeepCopyInto(out *StorageQuotaStatus) {
+	*out = *in
+	out.Usage = in.Usage
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageQuotaStatus.
+func (in *StorageQuotaStatus) DeepCopy() *StorageQuotaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageQuotaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageQuotaUsage) DeepCopyInto(out *StorageQuotaUsage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageQuotaUsage.
+func (in *StorageQuotaUsage) DeepCopy() *StorageQuotaUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageQuotaUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SupportBundle) DeepCopyInto(out *SupportBundle) {
 	*out = *in
@@ -2783,6 +3213,22 @@ func (in *SystemRestoreStatus) DeepCopy() *SystemRestoreStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologySpreadConstraint) DeepCopyInto(out *TopologySpreadConstraint) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologySpreadConstraint.
+func (in *TopologySpreadConstraint) DeepCopy() *TopologySpreadConstraint {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologySpreadConstraint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *V2DataEngineSpec) DeepCopyInto(out *V2DataEngineSpec) {
 	*out = *in
@@ -3015,6 +3461,152 @@ func (in *VolumeList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumePolicy) DeepCopyInto(out *VolumePolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumePolicy.
+func (in *VolumePolicy) DeepCopy() *VolumePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumePolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumePolicyList) DeepCopyInto(out *VolumePolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VolumePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumePolicyList.
+func (in *VolumePolicyList) DeepCopy() *VolumePolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumePolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VolumePolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumePolicySpec) DeepCopyInto(out *VolumePolicySpec) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.VolumeSelector != nil {
+		in, out := &in.VolumeSelector, &out.VolumeSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowedDataEngines != nil {
+		in, out := &in.AllowedDataEngines, &out.AllowedDataEngines
+		*out = make([]DataEngineType, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedBackupTargets != nil {
+		in, out := &in.AllowedBackupTargets, &out.AllowedBackupTargets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumePolicySpec.
+func (in *VolumePolicySpec) DeepCopy() *VolumePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumePolicyStatus) DeepCopyInto(out *VolumePolicyStatus) {
+	*out = *in
+	if in.ViolatingVolumes != nil {
+		in, out := &in.ViolatingVolumes, &out.ViolatingVolumes
+		*out = make([]VolumePolicyViolation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumePolicyStatus.
+func (in *VolumePolicyStatus) DeepCopy() *VolumePolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumePolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumePolicyViolation) DeepCopyInto(out *VolumePolicyViolation) {
+	*out = *in
+	if in.Messages != nil {
+		in, out := &in.Messages, &out.Messages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumePolicyViolation.
+func (in *VolumePolicyViolation) DeepCopy() *VolumePolicyViolation {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumePolicyViolation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VolumeRecurringJob) DeepCopyInto(out *VolumeRecurringJob) {
 	*out = *in
@@ -3066,6 +3658,13 @@ func (in *VolumeSpec) DeepCopyInto(out *VolumeSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	in.TieringPolicy.DeepCopyInto(&out.TieringPolicy)
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]TopologySpreadConstraint, len(*in))
+		copy(*out, *in)
+	}
+	in.NFSExportOptions.DeepCopyInto(&out.NFSExportOptions)
 	return
 }
 
@@ -3102,6 +3701,32 @@ func (in *VolumeStatus) DeepCopy() *VolumeStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeTieringPolicy) DeepCopyInto(out *VolumeTieringPolicy) {
+	*out = *in
+	if in.HotDiskSelector != nil {
+		in, out := &in.HotDiskSelector, &out.HotDiskSelector
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ColdDiskSelector != nil {
+		in, out := &in.ColdDiskSelector, &out.ColdDiskSelector
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeTieringPolicy.
+func (in *VolumeTieringPolicy) DeepCopy() *VolumeTieringPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeTieringPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkloadStatus) DeepCopyInto(out *WorkloadStatus) {
 	*out = *in
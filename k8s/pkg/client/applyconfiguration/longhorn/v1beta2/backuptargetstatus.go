@@ -25,10 +25,11 @@ import (
 // BackupTargetStatusApplyConfiguration represents a declarative configuration of the BackupTargetStatus type for use
 // with apply.
 type BackupTargetStatusApplyConfiguration struct {
-	OwnerID      *string                       `json:"ownerID,omitempty"`
-	Available    *bool                         `json:"available,omitempty"`
-	Conditions   []ConditionApplyConfiguration `json:"conditions,omitempty"`
-	LastSyncedAt *v1.Time                      `json:"lastSyncedAt,omitempty"`
+	OwnerID      *string                               `json:"ownerID,omitempty"`
+	Available    *bool                                 `json:"available,omitempty"`
+	Conditions   []ConditionApplyConfiguration         `json:"conditions,omitempty"`
+	LastSyncedAt *v1.Time                              `json:"lastSyncedAt,omitempty"`
+	Health       *BackupTargetHealthApplyConfiguration `json:"health,omitempty"`
 }
 
 // BackupTargetStatusApplyConfiguration constructs a declarative configuration of the BackupTargetStatus type for use with
@@ -73,3 +74,11 @@ func (b *BackupTargetStatusApplyConfiguration) WithLastSyncedAt(value v1.Time) *
 	b.LastSyncedAt = &value
 	return b
 }
+
+// WithHealth sets the Health field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Health field is set to the value of the last call.
+func (b *BackupTargetStatusApplyConfiguration) WithHealth(value *BackupTargetHealthApplyConfiguration) *BackupTargetStatusApplyConfiguration {
+	b.Health = value
+	return b
+}
@@ -32,6 +32,7 @@ type DiskSpecApplyConfiguration struct {
 	EvictionRequested *bool                       `json:"evictionRequested,omitempty"`
 	StorageReserved   *int64                      `json:"storageReserved,omitempty"`
 	Tags              []string                    `json:"tags,omitempty"`
+	ReplacedDiskUUID  *string                     `json:"replacedDiskUUID,omitempty"`
 }
 
 // DiskSpecApplyConfiguration constructs a declarative configuration of the DiskSpec type for use with
@@ -97,3 +98,11 @@ func (b *DiskSpecApplyConfiguration) WithTags(values ...string) *DiskSpecApplyCo
 	}
 	return b
 }
+
+// WithReplacedDiskUUID sets the ReplacedDiskUUID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReplacedDiskUUID field is set to the value of the last call.
+func (b *DiskSpecApplyConfiguration) WithReplacedDiskUUID(value string) *DiskSpecApplyConfiguration {
+	b.ReplacedDiskUUID = &value
+	return b
+}
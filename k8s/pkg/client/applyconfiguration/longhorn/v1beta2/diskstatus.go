@@ -25,19 +25,22 @@ import (
 // DiskStatusApplyConfiguration represents a declarative configuration of the DiskStatus type for use
 // with apply.
 type DiskStatusApplyConfiguration struct {
-	Conditions            []ConditionApplyConfiguration `json:"conditions,omitempty"`
-	StorageAvailable      *int64                        `json:"storageAvailable,omitempty"`
-	StorageScheduled      *int64                        `json:"storageScheduled,omitempty"`
-	StorageMaximum        *int64                        `json:"storageMaximum,omitempty"`
-	ScheduledReplica      map[string]int64              `json:"scheduledReplica,omitempty"`
-	ScheduledBackingImage map[string]int64              `json:"scheduledBackingImage,omitempty"`
-	DiskUUID              *string                       `json:"diskUUID,omitempty"`
-	DiskName              *string                       `json:"diskName,omitempty"`
-	DiskPath              *string                       `json:"diskPath,omitempty"`
-	Type                  *longhornv1beta2.DiskType     `json:"diskType,omitempty"`
-	DiskDriver            *longhornv1beta2.DiskDriver   `json:"diskDriver,omitempty"`
-	FSType                *string                       `json:"filesystemType,omitempty"`
-	InstanceManagerName   *string                       `json:"instanceManagerName,omitempty"`
+	Conditions            []ConditionApplyConfiguration       `json:"conditions,omitempty"`
+	StorageAvailable      *int64                              `json:"storageAvailable,omitempty"`
+	StorageScheduled      *int64                              `json:"storageScheduled,omitempty"`
+	StorageMaximum        *int64                              `json:"storageMaximum,omitempty"`
+	ScheduledReplica      map[string]int64                    `json:"scheduledReplica,omitempty"`
+	ScheduledBackingImage map[string]int64                    `json:"scheduledBackingImage,omitempty"`
+	DiskUUID              *string                             `json:"diskUUID,omitempty"`
+	DiskName              *string                             `json:"diskName,omitempty"`
+	DiskPath              *string                             `json:"diskPath,omitempty"`
+	Type                  *longhornv1beta2.DiskType           `json:"diskType,omitempty"`
+	DiskDriver            *longhornv1beta2.DiskDriver         `json:"diskDriver,omitempty"`
+	FSType                *string                             `json:"filesystemType,omitempty"`
+	InstanceManagerName   *string                             `json:"instanceManagerName,omitempty"`
+	StorageGrowthRate     *int64                              `json:"storageGrowthRate,omitempty"`
+	ProjectedFullTime     *string                             `json:"projectedFullTime,omitempty"`
+	UsageHistory          []DiskUsageSampleApplyConfiguration `json:"usageHistory,omitempty"`
 }
 
 // DiskStatusApplyConfiguration constructs a declarative configuration of the DiskStatus type for use with
@@ -166,3 +169,32 @@ func (b *DiskStatusApplyConfiguration) WithInstanceManagerName(value string) *Di
 	b.InstanceManagerName = &value
 	return b
 }
+
+// WithStorageGrowthRate sets the StorageGrowthRate field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StorageGrowthRate field is set to the value of the last call.
+func (b *DiskStatusApplyConfiguration) WithStorageGrowthRate(value int64) *DiskStatusApplyConfiguration {
+	b.StorageGrowthRate = &value
+	return b
+}
+
+// WithProjectedFullTime sets the ProjectedFullTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ProjectedFullTime field is set to the value of the last call.
+func (b *DiskStatusApplyConfiguration) WithProjectedFullTime(value string) *DiskStatusApplyConfiguration {
+	b.ProjectedFullTime = &value
+	return b
+}
+
+// WithUsageHistory adds the given value to the UsageHistory field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the UsageHistory field.
+func (b *DiskStatusApplyConfiguration) WithUsageHistory(values ...*DiskUsageSampleApplyConfiguration) *DiskStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithUsageHistory")
+		}
+		b.UsageHistory = append(b.UsageHistory, *values[i])
+	}
+	return b
+}
@@ -29,6 +29,7 @@ type NodeStatusApplyConfiguration struct {
 	DiskStatus          map[string]*longhornv1beta2.DiskStatus `json:"diskStatus,omitempty"`
 	Region              *string                                `json:"region,omitempty"`
 	Zone                *string                                `json:"zone,omitempty"`
+	Topology            map[string]string                      `json:"topology,omitempty"`
 	SnapshotCheckStatus *SnapshotCheckStatusApplyConfiguration `json:"snapshotCheckStatus,omitempty"`
 	AutoEvicting        *bool                                  `json:"autoEvicting,omitempty"`
 }
@@ -82,6 +83,20 @@ func (b *NodeStatusApplyConfiguration) WithZone(value string) *NodeStatusApplyCo
 	return b
 }
 
+// WithTopology puts the entries into the Topology field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Topology field,
+// overwriting an existing map entries in Topology field with the same key.
+func (b *NodeStatusApplyConfiguration) WithTopology(entries map[string]string) *NodeStatusApplyConfiguration {
+	if b.Topology == nil && len(entries) > 0 {
+		b.Topology = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.Topology[k] = v
+	}
+	return b
+}
+
 // WithSnapshotCheckStatus sets the SnapshotCheckStatus field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the SnapshotCheckStatus field is set to the value of the last call.
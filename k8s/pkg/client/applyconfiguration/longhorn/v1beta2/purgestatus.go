@@ -21,10 +21,14 @@ package v1beta2
 // PurgeStatusApplyConfiguration represents a declarative configuration of the PurgeStatus type for use
 // with apply.
 type PurgeStatusApplyConfiguration struct {
-	Error     *string `json:"error,omitempty"`
-	IsPurging *bool   `json:"isPurging,omitempty"`
-	Progress  *int    `json:"progress,omitempty"`
-	State     *string `json:"state,omitempty"`
+	Error                     *string `json:"error,omitempty"`
+	IsPurging                 *bool   `json:"isPurging,omitempty"`
+	Progress                  *int    `json:"progress,omitempty"`
+	State                     *string `json:"state,omitempty"`
+	TotalBytes                *int64  `json:"totalBytes,omitempty"`
+	EstimatedTransferredBytes *int64  `json:"estimatedTransferredBytes,omitempty"`
+	BytesPerSecond            *int64  `json:"bytesPerSecond,omitempty"`
+	SampledAt                 *string `json:"sampledAt,omitempty"`
 }
 
 // PurgeStatusApplyConfiguration constructs a declarative configuration of the PurgeStatus type for use with
@@ -64,3 +68,35 @@ func (b *PurgeStatusApplyConfiguration) WithState(value string) *PurgeStatusAppl
 	b.State = &value
 	return b
 }
+
+// WithTotalBytes sets the TotalBytes field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TotalBytes field is set to the value of the last call.
+func (b *PurgeStatusApplyConfiguration) WithTotalBytes(value int64) *PurgeStatusApplyConfiguration {
+	b.TotalBytes = &value
+	return b
+}
+
+// WithEstimatedTransferredBytes sets the EstimatedTransferredBytes field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EstimatedTransferredBytes field is set to the value of the last call.
+func (b *PurgeStatusApplyConfiguration) WithEstimatedTransferredBytes(value int64) *PurgeStatusApplyConfiguration {
+	b.EstimatedTransferredBytes = &value
+	return b
+}
+
+// WithBytesPerSecond sets the BytesPerSecond field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BytesPerSecond field is set to the value of the last call.
+func (b *PurgeStatusApplyConfiguration) WithBytesPerSecond(value int64) *PurgeStatusApplyConfiguration {
+	b.BytesPerSecond = &value
+	return b
+}
+
+// WithSampledAt sets the SampledAt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SampledAt field is set to the value of the last call.
+func (b *PurgeStatusApplyConfiguration) WithSampledAt(value string) *PurgeStatusApplyConfiguration {
+	b.SampledAt = &value
+	return b
+}
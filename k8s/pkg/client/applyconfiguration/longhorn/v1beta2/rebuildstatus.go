@@ -21,12 +21,16 @@ package v1beta2
 // RebuildStatusApplyConfiguration represents a declarative configuration of the RebuildStatus type for use
 // with apply.
 type RebuildStatusApplyConfiguration struct {
-	Error                 *string `json:"error,omitempty"`
-	IsRebuilding          *bool   `json:"isRebuilding,omitempty"`
-	Progress              *int    `json:"progress,omitempty"`
-	State                 *string `json:"state,omitempty"`
-	FromReplicaAddress    *string `json:"fromReplicaAddress,omitempty"`
-	AppliedRebuildingMBps *int64  `json:"appliedRebuildingMBps,omitempty"`
+	Error                     *string `json:"error,omitempty"`
+	IsRebuilding              *bool   `json:"isRebuilding,omitempty"`
+	Progress                  *int    `json:"progress,omitempty"`
+	State                     *string `json:"state,omitempty"`
+	FromReplicaAddress        *string `json:"fromReplicaAddress,omitempty"`
+	AppliedRebuildingMBps     *int64  `json:"appliedRebuildingMBps,omitempty"`
+	TotalBytes                *int64  `json:"totalBytes,omitempty"`
+	EstimatedTransferredBytes *int64  `json:"estimatedTransferredBytes,omitempty"`
+	BytesPerSecond            *int64  `json:"bytesPerSecond,omitempty"`
+	SampledAt                 *string `json:"sampledAt,omitempty"`
 }
 
 // RebuildStatusApplyConfiguration constructs a declarative configuration of the RebuildStatus type for use with
@@ -82,3 +86,35 @@ func (b *RebuildStatusApplyConfiguration) WithAppliedRebuildingMBps(value int64)
 	b.AppliedRebuildingMBps = &value
 	return b
 }
+
+// WithTotalBytes sets the TotalBytes field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TotalBytes field is set to the value of the last call.
+func (b *RebuildStatusApplyConfiguration) WithTotalBytes(value int64) *RebuildStatusApplyConfiguration {
+	b.TotalBytes = &value
+	return b
+}
+
+// WithEstimatedTransferredBytes sets the EstimatedTransferredBytes field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EstimatedTransferredBytes field is set to the value of the last call.
+func (b *RebuildStatusApplyConfiguration) WithEstimatedTransferredBytes(value int64) *RebuildStatusApplyConfiguration {
+	b.EstimatedTransferredBytes = &value
+	return b
+}
+
+// WithBytesPerSecond sets the BytesPerSecond field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BytesPerSecond field is set to the value of the last call.
+func (b *RebuildStatusApplyConfiguration) WithBytesPerSecond(value int64) *RebuildStatusApplyConfiguration {
+	b.BytesPerSecond = &value
+	return b
+}
+
+// WithSampledAt sets the SampledAt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SampledAt field is set to the value of the last call.
+func (b *RebuildStatusApplyConfiguration) WithSampledAt(value string) *RebuildStatusApplyConfiguration {
+	b.SampledAt = &value
+	return b
+}
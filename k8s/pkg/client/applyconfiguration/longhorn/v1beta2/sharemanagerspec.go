@@ -21,7 +21,8 @@ package v1beta2
 // ShareManagerSpecApplyConfiguration represents a declarative configuration of the ShareManagerSpec type for use
 // with apply.
 type ShareManagerSpecApplyConfiguration struct {
-	Image *string `json:"image,omitempty"`
+	Image            *string                             `json:"image,omitempty"`
+	NFSExportOptions *NFSExportOptionsApplyConfiguration `json:"nfsExportOptions,omitempty"`
 }
 
 // ShareManagerSpecApplyConfiguration constructs a declarative configuration of the ShareManagerSpec type for use with
@@ -37,3 +38,11 @@ func (b *ShareManagerSpecApplyConfiguration) WithImage(value string) *ShareManag
 	b.Image = &value
 	return b
 }
+
+// WithNFSExportOptions sets the NFSExportOptions field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NFSExportOptions field is set to the value of the last call.
+func (b *ShareManagerSpecApplyConfiguration) WithNFSExportOptions(value *NFSExportOptionsApplyConfiguration) *ShareManagerSpecApplyConfiguration {
+	b.NFSExportOptions = value
+	return b
+}
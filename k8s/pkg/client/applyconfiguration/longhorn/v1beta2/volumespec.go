@@ -62,6 +62,9 @@ type VolumeSpecApplyConfiguration struct {
 	BackupTargetName                *string                                        `json:"backupTargetName,omitempty"`
 	OfflineRebuilding               *longhornv1beta2.VolumeOfflineRebuilding       `json:"offlineRebuilding,omitempty"`
 	ReplicaRebuildingBandwidthLimit *int64                                         `json:"replicaRebuildingBandwidthLimit,omitempty"`
+	TieringPolicy                   *VolumeTieringPolicyApplyConfiguration         `json:"tieringPolicy,omitempty"`
+	TopologySpreadConstraints       []TopologySpreadConstraintApplyConfiguration   `json:"topologySpreadConstraints,omitempty"`
+	NFSExportOptions                *NFSExportOptionsApplyConfiguration            `json:"nfsExportOptions,omitempty"`
 }
 
 // VolumeSpecApplyConfiguration constructs a declarative configuration of the VolumeSpec type for use with
@@ -369,3 +372,32 @@ func (b *VolumeSpecApplyConfiguration) WithReplicaRebuildingBandwidthLimit(value
 	b.ReplicaRebuildingBandwidthLimit = &value
 	return b
 }
+
+// WithTieringPolicy sets the TieringPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TieringPolicy field is set to the value of the last call.
+func (b *VolumeSpecApplyConfiguration) WithTieringPolicy(value *VolumeTieringPolicyApplyConfiguration) *VolumeSpecApplyConfiguration {
+	b.TieringPolicy = value
+	return b
+}
+
+// WithTopologySpreadConstraints adds the given value to the TopologySpreadConstraints field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the TopologySpreadConstraints field.
+func (b *VolumeSpecApplyConfiguration) WithTopologySpreadConstraints(values ...*TopologySpreadConstraintApplyConfiguration) *VolumeSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithTopologySpreadConstraints")
+		}
+		b.TopologySpreadConstraints = append(b.TopologySpreadConstraints, *values[i])
+	}
+	return b
+}
+
+// WithNFSExportOptions sets the NFSExportOptions field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NFSExportOptions field is set to the value of the last call.
+func (b *VolumeSpecApplyConfiguration) WithNFSExportOptions(value *NFSExportOptionsApplyConfiguration) *VolumeSpecApplyConfiguration {
+	b.NFSExportOptions = value
+	return b
+}
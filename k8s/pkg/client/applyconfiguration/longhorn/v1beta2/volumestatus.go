@@ -46,6 +46,8 @@ type VolumeStatusApplyConfiguration struct {
 	LastDegradedAt         *string                              `json:"lastDegradedAt,omitempty"`
 	ShareEndpoint          *string                              `json:"shareEndpoint,omitempty"`
 	ShareState             *longhornv1beta2.ShareManagerState   `json:"shareState,omitempty"`
+	StorageTier            *longhornv1beta2.StorageTier         `json:"storageTier,omitempty"`
+	IdleSince              *string                              `json:"idleSince,omitempty"`
 }
 
 // VolumeStatusApplyConfiguration constructs a declarative configuration of the VolumeStatus type for use with
@@ -226,3 +228,19 @@ func (b *VolumeStatusApplyConfiguration) WithShareState(value longhornv1beta2.Sh
 	b.ShareState = &value
 	return b
 }
+
+// WithStorageTier sets the StorageTier field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StorageTier field is set to the value of the last call.
+func (b *VolumeStatusApplyConfiguration) WithStorageTier(value longhornv1beta2.StorageTier) *VolumeStatusApplyConfiguration {
+	b.StorageTier = &value
+	return b
+}
+
+// WithIdleSince sets the IdleSince field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IdleSince field is set to the value of the last call.
+func (b *VolumeStatusApplyConfiguration) WithIdleSince(value string) *VolumeStatusApplyConfiguration {
+	b.IdleSince = &value
+	return b
+}
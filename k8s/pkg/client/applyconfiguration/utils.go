@@ -32,6 +32,18 @@ import (
 func ForKind(kind schema.GroupVersionKind) interface{} {
 	switch kind {
 	// Group=longhorn.io, Version=v1beta2
+	case v1beta2.SchemeGroupVersion.WithKind("AlertSilence"):
+		return &longhornv1beta2.AlertSilenceApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("AlertSilenceSpec"):
+		return &longhornv1beta2.AlertSilenceSpecApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("AlertSink"):
+		return &longhornv1beta2.AlertSinkApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("AlertSinkSentAlert"):
+		return &longhornv1beta2.AlertSinkSentAlertApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("AlertSinkSpec"):
+		return &longhornv1beta2.AlertSinkSpecApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("AlertSinkStatus"):
+		return &longhornv1beta2.AlertSinkStatusApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("AttachmentTicket"):
 		return &longhornv1beta2.AttachmentTicketApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("AttachmentTicketStatus"):
@@ -76,6 +88,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &longhornv1beta2.BackupStatusApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("BackupTarget"):
 		return &longhornv1beta2.BackupTargetApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("BackupTargetHealth"):
+		return &longhornv1beta2.BackupTargetHealthApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("BackupTargetSpec"):
 		return &longhornv1beta2.BackupTargetSpecApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("BackupTargetStatus"):
@@ -96,6 +110,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &longhornv1beta2.DiskSpecApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("DiskStatus"):
 		return &longhornv1beta2.DiskStatusApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("DiskUsageSample"):
+		return &longhornv1beta2.DiskUsageSampleApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("Engine"):
 		return &longhornv1beta2.EngineApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("EngineBackupStatus"):
@@ -130,6 +146,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &longhornv1beta2.InstanceStatusApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("KubernetesStatus"):
 		return &longhornv1beta2.KubernetesStatusApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("NFSExportOptions"):
+		return &longhornv1beta2.NFSExportOptionsApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("Node"):
 		return &longhornv1beta2.NodeApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("NodeSpec"):
@@ -180,6 +198,14 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &longhornv1beta2.SnapshotSpecApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("SnapshotStatus"):
 		return &longhornv1beta2.SnapshotStatusApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("StorageQuota"):
+		return &longhornv1beta2.StorageQuotaApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("StorageQuotaSpec"):
+		return &longhornv1beta2.StorageQuotaSpecApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("StorageQuotaStatus"):
+		return &longhornv1beta2.StorageQuotaStatusApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("StorageQuotaUsage"):
+		return &longhornv1beta2.StorageQuotaUsageApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("SupportBundle"):
 		return &longhornv1beta2.SupportBundleApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("SupportBundleSpec"):
@@ -198,6 +224,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &longhornv1beta2.SystemRestoreSpecApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("SystemRestoreStatus"):
 		return &longhornv1beta2.SystemRestoreStatusApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("TopologySpreadConstraint"):
+		return &longhornv1beta2.TopologySpreadConstraintApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("V2DataEngineSpec"):
 		return &longhornv1beta2.V2DataEngineSpecApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("V2DataEngineStatus"):
@@ -212,10 +240,20 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &longhornv1beta2.VolumeAttachmentStatusApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("VolumeCloneStatus"):
 		return &longhornv1beta2.VolumeCloneStatusApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("VolumePolicy"):
+		return &longhornv1beta2.VolumePolicyApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("VolumePolicySpec"):
+		return &longhornv1beta2.VolumePolicySpecApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("VolumePolicyStatus"):
+		return &longhornv1beta2.VolumePolicyStatusApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("VolumePolicyViolation"):
+		return &longhornv1beta2.VolumePolicyViolationApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("VolumeSpec"):
 		return &longhornv1beta2.VolumeSpecApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("VolumeStatus"):
 		return &longhornv1beta2.VolumeStatusApplyConfiguration{}
+	case v1beta2.SchemeGroupVersion.WithKind("VolumeTieringPolicy"):
+		return &longhornv1beta2.VolumeTieringPolicyApplyConfiguration{}
 	case v1beta2.SchemeGroupVersion.WithKind("WorkloadStatus"):
 		return &longhornv1beta2.WorkloadStatusApplyConfiguration{}
 
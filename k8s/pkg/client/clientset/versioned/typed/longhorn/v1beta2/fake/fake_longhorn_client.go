@@ -28,6 +28,14 @@ type FakeLonghornV1beta2 struct {
 	*testing.Fake
 }
 
+func (c *FakeLonghornV1beta2) AlertSilences(namespace string) v1beta2.AlertSilenceInterface {
+	return newFakeAlertSilences(c, namespace)
+}
+
+func (c *FakeLonghornV1beta2) AlertSinks(namespace string) v1beta2.AlertSinkInterface {
+	return newFakeAlertSinks(c, namespace)
+}
+
 func (c *FakeLonghornV1beta2) BackingImages(namespace string) v1beta2.BackingImageInterface {
 	return newFakeBackingImages(c, namespace)
 }
@@ -96,6 +104,10 @@ func (c *FakeLonghornV1beta2) Snapshots(namespace string) v1beta2.SnapshotInterf
 	return newFakeSnapshots(c, namespace)
 }
 
+func (c *FakeLonghornV1beta2) StorageQuotas(namespace string) v1beta2.StorageQuotaInterface {
+	return newFakeStorageQuotas(c, namespace)
+}
+
 func (c *FakeLonghornV1beta2) SupportBundles(namespace string) v1beta2.SupportBundleInterface {
 	return newFakeSupportBundles(c, namespace)
 }
@@ -116,6 +128,10 @@ func (c *FakeLonghornV1beta2) VolumeAttachments(namespace string) v1beta2.Volume
 	return newFakeVolumeAttachments(c, namespace)
 }
 
+func (c *FakeLonghornV1beta2) VolumePolicies(namespace string) v1beta2.VolumePolicyInterface {
+	return newFakeVolumePolicies(c, namespace)
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeLonghornV1beta2) RESTClient() rest.Interface {
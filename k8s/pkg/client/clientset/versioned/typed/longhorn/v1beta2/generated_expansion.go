@@ -18,6 +18,10 @@ limitations under the License.
 
 package v1beta2
 
+type AlertSilenceExpansion interface{}
+
+type AlertSinkExpansion interface{}
+
 type BackingImageExpansion interface{}
 
 type BackingImageDataSourceExpansion interface{}
@@ -52,6 +56,8 @@ type ShareManagerExpansion interface{}
 
 type SnapshotExpansion interface{}
 
+type StorageQuotaExpansion interface{}
+
 type SupportBundleExpansion interface{}
 
 type SystemBackupExpansion interface{}
@@ -61,3 +67,5 @@ type SystemRestoreExpansion interface{}
 type VolumeExpansion interface{}
 
 type VolumeAttachmentExpansion interface{}
+
+type VolumePolicyExpansion interface{}
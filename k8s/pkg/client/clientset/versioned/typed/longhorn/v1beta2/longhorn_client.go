@@ -28,6 +28,8 @@ import (
 
 type LonghornV1beta2Interface interface {
 	RESTClient() rest.Interface
+	AlertSilencesGetter
+	AlertSinksGetter
 	BackingImagesGetter
 	BackingImageDataSourcesGetter
 	BackingImageManagersGetter
@@ -45,11 +47,13 @@ type LonghornV1beta2Interface interface {
 	SettingsGetter
 	ShareManagersGetter
 	SnapshotsGetter
+	StorageQuotasGetter
 	SupportBundlesGetter
 	SystemBackupsGetter
 	SystemRestoresGetter
 	VolumesGetter
 	VolumeAttachmentsGetter
+	VolumePoliciesGetter
 }
 
 // LonghornV1beta2Client is used to interact with features provided by the longhorn.io group.
@@ -57,6 +61,14 @@ type LonghornV1beta2Client struct {
 	restClient rest.Interface
 }
 
+func (c *LonghornV1beta2Client) AlertSilences(namespace string) AlertSilenceInterface {
+	return newAlertSilences(c, namespace)
+}
+
+func (c *LonghornV1beta2Client) AlertSinks(namespace string) AlertSinkInterface {
+	return newAlertSinks(c, namespace)
+}
+
 func (c *LonghornV1beta2Client) BackingImages(namespace string) BackingImageInterface {
 	return newBackingImages(c, namespace)
 }
@@ -125,6 +137,10 @@ func (c *LonghornV1beta2Client) Snapshots(namespace string) SnapshotInterface {
 	return newSnapshots(c, namespace)
 }
 
+func (c *LonghornV1beta2Client) StorageQuotas(namespace string) StorageQuotaInterface {
+	return newStorageQuotas(c, namespace)
+}
+
 func (c *LonghornV1beta2Client) SupportBundles(namespace string) SupportBundleInterface {
 	return newSupportBundles(c, namespace)
 }
@@ -145,6 +161,10 @@ func (c *LonghornV1beta2Client) VolumeAttachments(namespace string) VolumeAttach
 	return newVolumeAttachments(c, namespace)
 }
 
+func (c *LonghornV1beta2Client) VolumePolicies(namespace string) VolumePolicyInterface {
+	return newVolumePolicies(c, namespace)
+}
+
 // NewForConfig creates a new LonghornV1beta2Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
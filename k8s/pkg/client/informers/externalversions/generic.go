@@ -53,6 +53,10 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=longhorn.io, Version=v1beta2
+	case v1beta2.SchemeGroupVersion.WithResource("alertsilences"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Longhorn().V1beta2().AlertSilences().Informer()}, nil
+	case v1beta2.SchemeGroupVersion.WithResource("alertsinks"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Longhorn().V1beta2().AlertSinks().Informer()}, nil
 	case v1beta2.SchemeGroupVersion.WithResource("backingimages"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Longhorn().V1beta2().BackingImages().Informer()}, nil
 	case v1beta2.SchemeGroupVersion.WithResource("backingimagedatasources"):
@@ -87,6 +91,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Longhorn().V1beta2().ShareManagers().Informer()}, nil
 	case v1beta2.SchemeGroupVersion.WithResource("snapshots"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Longhorn().V1beta2().Snapshots().Informer()}, nil
+	case v1beta2.SchemeGroupVersion.WithResource("storagequotas"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Longhorn().V1beta2().StorageQuotas().Informer()}, nil
 	case v1beta2.SchemeGroupVersion.WithResource("supportbundles"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Longhorn().V1beta2().SupportBundles().Informer()}, nil
 	case v1beta2.SchemeGroupVersion.WithResource("systembackups"):
@@ -97,6 +103,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Longhorn().V1beta2().Volumes().Informer()}, nil
 	case v1beta2.SchemeGroupVersion.WithResource("volumeattachments"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Longhorn().V1beta2().VolumeAttachments().Informer()}, nil
+	case v1beta2.SchemeGroupVersion.WithResource("volumepolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Longhorn().V1beta2().VolumePolicies().Informer()}, nil
 
 	}
 
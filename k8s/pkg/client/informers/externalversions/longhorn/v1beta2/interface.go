@@ -24,6 +24,10 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// AlertSilences returns a AlertSilenceInformer.
+	AlertSilences() AlertSilenceInformer
+	// AlertSinks returns a AlertSinkInformer.
+	AlertSinks() AlertSinkInformer
 	// BackingImages returns a BackingImageInformer.
 	BackingImages() BackingImageInformer
 	// BackingImageDataSources returns a BackingImageDataSourceInformer.
@@ -58,6 +62,8 @@ type Interface interface {
 	ShareManagers() ShareManagerInformer
 	// Snapshots returns a SnapshotInformer.
 	Snapshots() SnapshotInformer
+	// StorageQuotas returns a StorageQuotaInformer.
+	StorageQuotas() StorageQuotaInformer
 	// SupportBundles returns a SupportBundleInformer.
 	SupportBundles() SupportBundleInformer
 	// SystemBackups returns a SystemBackupInformer.
@@ -68,6 +74,8 @@ type Interface interface {
 	Volumes() VolumeInformer
 	// VolumeAttachments returns a VolumeAttachmentInformer.
 	VolumeAttachments() VolumeAttachmentInformer
+	// VolumePolicies returns a VolumePolicyInformer.
+	VolumePolicies() VolumePolicyInformer
 }
 
 type version struct {
@@ -81,6 +89,16 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// AlertSilences returns a AlertSilenceInformer.
+func (v *version) AlertSilences() AlertSilenceInformer {
+	return &alertSilenceInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// AlertSinks returns a AlertSinkInformer.
+func (v *version) AlertSinks() AlertSinkInformer {
+	return &alertSinkInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // BackingImages returns a BackingImageInformer.
 func (v *version) BackingImages() BackingImageInformer {
 	return &backingImageInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -166,6 +184,11 @@ func (v *version) Snapshots() SnapshotInformer {
 	return &snapshotInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// StorageQuotas returns a StorageQuotaInformer.
+func (v *version) StorageQuotas() StorageQuotaInformer {
+	return &storageQuotaInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // SupportBundles returns a SupportBundleInformer.
 func (v *version) SupportBundles() SupportBundleInformer {
 	return &supportBundleInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -190,3 +213,8 @@ func (v *version) Volumes() VolumeInformer {
 func (v *version) VolumeAttachments() VolumeAttachmentInformer {
 	return &volumeAttachmentInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// VolumePolicies returns a VolumePolicyInformer.
+func (v *version) VolumePolicies() VolumePolicyInformer {
+	return &volumePolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
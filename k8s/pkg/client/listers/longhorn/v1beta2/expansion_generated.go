@@ -18,6 +18,22 @@ limitations under the License.
 
 package v1beta2
 
+// AlertSilenceListerExpansion allows custom methods to be added to
+// AlertSilenceLister.
+type AlertSilenceListerExpansion interface{}
+
+// AlertSilenceNamespaceListerExpansion allows custom methods to be added to
+// AlertSilenceNamespaceLister.
+type AlertSilenceNamespaceListerExpansion interface{}
+
+// AlertSinkListerExpansion allows custom methods to be added to
+// AlertSinkLister.
+type AlertSinkListerExpansion interface{}
+
+// AlertSinkNamespaceListerExpansion allows custom methods to be added to
+// AlertSinkNamespaceLister.
+type AlertSinkNamespaceListerExpansion interface{}
+
 // BackingImageListerExpansion allows custom methods to be added to
 // BackingImageLister.
 type BackingImageListerExpansion interface{}
@@ -154,6 +170,14 @@ type SnapshotListerExpansion interface{}
 // SnapshotNamespaceLister.
 type SnapshotNamespaceListerExpansion interface{}
 
+// StorageQuotaListerExpansion allows custom methods to be added to
+// StorageQuotaLister.
+type StorageQuotaListerExpansion interface{}
+
+// StorageQuotaNamespaceListerExpansion allows custom methods to be added to
+// StorageQuotaNamespaceLister.
+type StorageQuotaNamespaceListerExpansion interface{}
+
 // SupportBundleListerExpansion allows custom methods to be added to
 // SupportBundleLister.
 type SupportBundleListerExpansion interface{}
@@ -193,3 +217,11 @@ type VolumeAttachmentListerExpansion interface{}
 // VolumeAttachmentNamespaceListerExpansion allows custom methods to be added to
 // VolumeAttachmentNamespaceLister.
 type VolumeAttachmentNamespaceListerExpansion interface{}
+
+// VolumePolicyListerExpansion allows custom methods to be added to
+// VolumePolicyLister.
+type VolumePolicyListerExpansion interface{}
+
+// VolumePolicyNamespaceListerExpansion allows custom methods to be added to
+// VolumePolicyNamespaceLister.
+type VolumePolicyNamespaceListerExpansion interface{}
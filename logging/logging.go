@@ -0,0 +1,292 @@
+package logging
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+
+	// The fields correlating the logs of a resource across the controllers.
+	FieldController  = "controller"
+	FieldVolume      = "volume"
+	FieldEngine      = "engine"
+	FieldReplica     = "replica"
+	FieldNode        = "node"
+	FieldReconcileID = "reconcileID"
+
+	reconcileIDLength = 8
+)
+
+var (
+	componentNameRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+	formatter = &switchFormatter{}
+	// jsonForced is set if the JSON format is required by the command line, which takes precedence over SetFormat.
+	jsonForced atomic.Bool
+
+	lock             sync.Mutex
+	globalLevel      = logrus.InfoLevel
+	componentLevels  = map[string]logrus.Level{}
+	componentLoggers = map[string]*logrus.Logger{}
+	// registeredComponents are the components known before their loggers are created, such as the controllers in a
+	// process which only validates the levels.
+	registeredComponents = map[string]struct{}{}
+)
+
+func init() {
+	formatter.set(FormatText)
+}
+
+// Init makes the standard logger, and the component loggers, use the formatter of the format. The JSON format can't be
+// changed by SetFormat afterwards if forceJSON is set.
+func Init(forceJSON bool) {
+	jsonForced.Store(forceJSON)
+	if forceJSON {
+		formatter.set(FormatJSON)
+	}
+	logrus.SetReportCaller(true)
+	logrus.SetFormatter(formatter)
+}
+
+// SetFormat switches the format of the logs at runtime.
+func SetFormat(format string) error {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format != FormatText && format != FormatJSON {
+		return fmt.Errorf("invalid log format %q, must be %v or %v", format, FormatText, FormatJSON)
+	}
+	if jsonForced.Load() {
+		format = FormatJSON
+	}
+	formatter.set(format)
+	return nil
+}
+
+// SetLevel sets the level of the standard logger and of the component loggers without their own level.
+func SetLevel(level logrus.Level) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	globalLevel = level
+	logrus.SetLevel(level)
+	for component, logger := range componentLoggers {
+		logger.SetLevel(effectiveLevel(component))
+	}
+}
+
+// SetComponentLevels replaces the levels of the component loggers. The components not in the levels use the level of
+// the standard logger.
+func SetComponentLevels(levels map[string]logrus.Level) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	componentLevels = map[string]logrus.Level{}
+	for component, level := range levels {
+		componentLevels[component] = level
+	}
+	for component, logger := range componentLoggers {
+		logger.SetLevel(effectiveLevel(component))
+	}
+}
+
+// ComponentLevels returns the levels of all the component loggers.
+func ComponentLevels() map[string]logrus.Level {
+	lock.Lock()
+	defer lock.Unlock()
+
+	levels := map[string]logrus.Level{}
+	for component := range componentLoggers {
+		levels[component] = effectiveLevel(component)
+	}
+	return levels
+}
+
+// RegisterComponents registers the components whose level can be set by SetComponentLevels, so that the levels of
+// the unknown components are rejected by ValidateComponentLevels.
+func RegisterComponents(components ...string) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	for _, component := range components {
+		registeredComponents[component] = struct{}{}
+	}
+}
+
+// ComponentLogger returns the logger of the component, such as engine-controller, whose level can be set on its own
+// by SetComponentLevels. It writes to the output of the standard logger with the same format and hooks.
+func ComponentLogger(component string) *logrus.Logger {
+	lock.Lock()
+	defer lock.Unlock()
+
+	if logger, ok := componentLoggers[component]; ok {
+		return logger
+	}
+
+	std := logrus.StandardLogger()
+	logger := logrus.New()
+	logger.Out = std.Out
+	logger.Hooks = std.Hooks
+	logger.Formatter = formatter
+	logger.ReportCaller = std.ReportCaller
+	logger.ExitFunc = std.ExitFunc
+	logger.SetLevel(effectiveLevel(component))
+	componentLoggers[component] = logger
+	registeredComponents[component] = struct{}{}
+	return logger
+}
+
+// WithComponent returns the entry with the fields of the logger, logging to the logger of the component.
+func WithComponent(logger logrus.FieldLogger, component string) *logrus.Entry {
+	entry := logger.WithFields(logrus.Fields{})
+	entry.Logger = ComponentLogger(component)
+	return entry
+}
+
+func effectiveLevel(component string) logrus.Level {
+	if level, ok := componentLevels[component]; ok {
+		return level
+	}
+	return globalLevel
+}
+
+// ParseComponentLevels parses the levels of the components separated by semicolons, for example
+// `engine-controller=debug; volume-controller=trace`.
+func ParseComponentLevels(value string) (map[string]logrus.Level, error) {
+	levels := map[string]logrus.Level{}
+	for _, item := range strings.Split(value, ";") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		parts := strings.Split(item, "=")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid component log level %q, must be in the form of <component>=<level>", item)
+		}
+		component := strings.TrimSpace(parts[0])
+		if !componentNameRegex.MatchString(component) {
+			return nil, fmt.Errorf("invalid component name %q", component)
+		}
+		if _, ok := levels[component]; ok {
+			return nil, fmt.Errorf("duplicate component %q", component)
+		}
+		level, err := logrus.ParseLevel(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, err
+		}
+		levels[component] = level
+	}
+	return levels, nil
+}
+
+// ValidateComponentLevels parses the levels of the components like ParseComponentLevels, and returns an error if any
+// of the components is not registered.
+func ValidateComponentLevels(value string) error {
+	levels, err := ParseComponentLevels(value)
+	if err != nil {
+		return err
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	for component := range levels {
+		if _, ok := registeredComponents[component]; !ok {
+			components := []string{}
+			for registered := range registeredComponents {
+				components = append(components, registered)
+			}
+			sort.Strings(components)
+			return fmt.Errorf("unknown component %q, must be one of %v", component, strings.Join(components, ", "))
+		}
+	}
+	return nil
+}
+
+// FormatComponentLevels returns the levels in the form parsed by ParseComponentLevels, sorted by the components.
+func FormatComponentLevels(levels map[string]logrus.Level) string {
+	components := []string{}
+	for component := range levels {
+		components = append(components, component)
+	}
+	sort.Strings(components)
+
+	items := []string{}
+	for _, component := range components {
+		items = append(items, fmt.Sprintf("%s=%s", component, levels[component]))
+	}
+	return strings.Join(items, "; ")
+}
+
+// NewReconcileID returns a random ID correlating the logs of a reconciliation.
+func NewReconcileID() string {
+	id := make([]byte, reconcileIDLength/2)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// ResourceFields returns the correlation fields of the resource, without the empty ones.
+func ResourceFields(volume, engine, replica, node string) logrus.Fields {
+	fields := logrus.Fields{}
+	for key, value := range map[string]string{
+		FieldVolume:  volume,
+		FieldEngine:  engine,
+		FieldReplica: replica,
+		FieldNode:    node,
+	} {
+		if value != "" {
+			fields[key] = value
+		}
+	}
+	return fields
+}
+
+// switchFormatter delegates to the formatter of the current format, so that the format can be switched at runtime.
+type switchFormatter struct {
+	current atomic.Pointer[formatterHolder]
+}
+
+// formatterHolder holds the formatters of different types in the same type of atomic pointer.
+type formatterHolder struct {
+	logrus.Formatter
+}
+
+func (f *switchFormatter) set(format string) {
+	f.current.Store(&formatterHolder{Formatter: newFormatter(format)})
+}
+
+func (f *switchFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	return f.current.Load().Format(entry)
+}
+
+func newFormatter(format string) logrus.Formatter {
+	if format == FormatJSON {
+		return &logrus.JSONFormatter{
+			CallerPrettyfier: callerPrettyfier,
+			TimestampFormat:  time.RFC3339Nano,
+		}
+	}
+	return &logrus.TextFormatter{
+		CallerPrettyfier: callerPrettyfier,
+		TimestampFormat:  time.RFC3339Nano,
+		FullTimestamp:    true,
+	}
+}
+
+func callerPrettyfier(f *runtime.Frame) (function string, file string) {
+	fileName := fmt.Sprintf("%s:%d", path.Base(f.File), f.Line)
+	funcName := path.Base(f.Function)
+	return funcName, fileName
+}
@@ -0,0 +1,160 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseComponentLevels(t *testing.T) {
+	assert := require.New(t)
+
+	type testCase struct {
+		value    string
+		expected map[string]logrus.Level
+		isError  bool
+	}
+	testCases := map[string]testCase{
+		"empty": {
+			value:    " ",
+			expected: map[string]logrus.Level{},
+		},
+		"multiple components": {
+			value: "engine-controller=Debug; volume-controller = trace;",
+			expected: map[string]logrus.Level{
+				"engine-controller": logrus.DebugLevel,
+				"volume-controller": logrus.TraceLevel,
+			},
+		},
+		"missing level": {
+			value:   "engine-controller",
+			isError: true,
+		},
+		"invalid level": {
+			value:   "engine-controller=verbose",
+			isError: true,
+		},
+		"invalid component": {
+			value:   "Engine_Controller=debug",
+			isError: true,
+		},
+		"duplicate component": {
+			value:   "engine-controller=debug; engine-controller=info",
+			isError: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		levels, err := ParseComponentLevels(tc.value)
+		if tc.isError {
+			assert.Error(err, name)
+			continue
+		}
+		assert.NoError(err, name)
+		assert.Equal(tc.expected, levels, name)
+		assert.Equal(tc.expected, mustParseComponentLevels(t, FormatComponentLevels(levels)), name)
+	}
+}
+
+func TestComponentLogger(t *testing.T) {
+	assert := require.New(t)
+
+	SetLevel(logrus.InfoLevel)
+	defer SetComponentLevels(nil)
+
+	engineLogger := ComponentLogger("test-engine-controller")
+	volumeLogger := ComponentLogger("test-volume-controller")
+	assert.Equal(engineLogger, ComponentLogger("test-engine-controller"))
+	assert.Equal(logrus.InfoLevel, engineLogger.GetLevel())
+
+	SetComponentLevels(map[string]logrus.Level{"test-engine-controller": logrus.DebugLevel})
+	assert.Equal(logrus.DebugLevel, engineLogger.GetLevel())
+	assert.Equal(logrus.InfoLevel, volumeLogger.GetLevel())
+
+	SetLevel(logrus.WarnLevel)
+	assert.Equal(logrus.DebugLevel, engineLogger.GetLevel())
+	assert.Equal(logrus.WarnLevel, volumeLogger.GetLevel())
+
+	SetComponentLevels(nil)
+	assert.Equal(logrus.WarnLevel, engineLogger.GetLevel())
+	SetLevel(logrus.InfoLevel)
+}
+
+func TestValidateComponentLevels(t *testing.T) {
+	assert := require.New(t)
+
+	RegisterComponents("test-registered-controller")
+	ComponentLogger("test-created-controller")
+
+	type testCase struct {
+		value   string
+		isError bool
+	}
+	testCases := map[string]testCase{
+		"empty": {
+			value: "",
+		},
+		"registered component": {
+			value: "test-registered-controller=debug",
+		},
+		"component with a logger": {
+			value: "test-created-controller=trace; test-registered-controller=info",
+		},
+		"unknown component": {
+			value:   "test-registered-controller=debug; test-unknown-controller=debug",
+			isError: true,
+		},
+		"invalid level": {
+			value:   "test-registered-controller=verbose",
+			isError: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		err := ValidateComponentLevels(tc.value)
+		if tc.isError {
+			assert.Error(err, name)
+			continue
+		}
+		assert.NoError(err, name)
+	}
+}
+
+func TestSetFormat(t *testing.T) {
+	assert := require.New(t)
+
+	defer func() {
+		assert.NoError(SetFormat(FormatText))
+	}()
+
+	buf := &bytes.Buffer{}
+	logger := logrus.New()
+	logger.Out = buf
+	logger.Formatter = formatter
+
+	assert.NoError(SetFormat("JSON"))
+	logger.WithFields(ResourceFields("vol-1", "", "vol-1-r-1", "node-1")).Info("test")
+
+	fields := map[string]any{}
+	assert.NoError(json.Unmarshal(buf.Bytes(), &fields))
+	assert.Equal("vol-1", fields[FieldVolume])
+	assert.Equal("vol-1-r-1", fields[FieldReplica])
+	assert.Equal("node-1", fields[FieldNode])
+	assert.NotContains(fields, FieldEngine)
+
+	buf.Reset()
+	assert.NoError(SetFormat(FormatText))
+	logger.Info("test")
+	assert.Error(json.Unmarshal(buf.Bytes(), &fields))
+
+	assert.Error(SetFormat("xml"))
+}
+
+func mustParseComponentLevels(t *testing.T, value string) map[string]logrus.Level {
+	levels, err := ParseComponentLevels(value)
+	require.NoError(t, err)
+	return levels
+}
@@ -3,14 +3,12 @@ package main
 import (
 	"fmt"
 	"os"
-	"path"
-	"runtime"
-	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli"
 
 	"github.com/longhorn/longhorn-manager/app"
+	"github.com/longhorn/longhorn-manager/logging"
 	"github.com/longhorn/longhorn-manager/meta"
 )
 
@@ -23,16 +21,7 @@ func onUsageError(c *cli.Context, err error, isSubcommand bool) error {
 }
 
 func main() {
-	logrus.SetReportCaller(true)
-	logrus.SetFormatter(&logrus.TextFormatter{
-		CallerPrettyfier: func(f *runtime.Frame) (function string, file string) {
-			fileName := fmt.Sprintf("%s:%d", path.Base(f.File), f.Line)
-			funcName := path.Base(f.Function)
-			return funcName, fileName
-		},
-		TimestampFormat: time.RFC3339Nano,
-		FullTimestamp:   true,
-	})
+	logging.Init(false)
 
 	a := cli.NewApp()
 	a.Usage = "Longhorn Manager"
@@ -40,10 +29,10 @@ func main() {
 
 	a.Before = func(c *cli.Context) error {
 		if c.GlobalBool("debug") {
-			logrus.SetLevel(logrus.DebugLevel)
+			logging.SetLevel(logrus.DebugLevel)
 		}
 		if c.GlobalBool("log-json") {
-			logrus.SetFormatter(&logrus.JSONFormatter{})
+			logging.Init(true)
 		}
 		return nil
 	}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/longhorn/longhorn-manager/datastore"
 	"github.com/longhorn/longhorn-manager/engineapi"
+	"github.com/longhorn/longhorn-manager/metrics_collector/operation"
 	"github.com/longhorn/longhorn-manager/types"
 	"github.com/longhorn/longhorn-manager/util"
 
@@ -124,7 +125,19 @@ func (m *VolumeManager) CreateSnapshot(snapshotName string, labels map[string]st
 	}
 	defer engineClientProxy.Close()
 
+	// The snapshot created by the engine directly doesn't pass the admission of the snapshot CRs
+	increment, err := m.ds.GetSnapshotStorageQuotaIncrement(vol)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.ds.ReserveStorageQuota(types.GetVolumePVCNamespace(vol), increment); err != nil {
+		return nil, errors.Wrapf(err, "failed to create snapshot for volume %v", volumeName)
+	}
+
+	// The snapshot created by the engine directly has no snapshot CR to record its creation by the snapshot controller
+	startTime := time.Now()
 	snapshotName, err = engineClientProxy.SnapshotCreate(e, snapshotName, labels, freezeFilesystem)
+	operation.ObserveDuration(operation.OperationSnapshot, string(e.Spec.DataEngine), operation.Outcome(err != nil), startTime)
 	if err != nil {
 		return nil, err
 	}
@@ -1,9 +1,46 @@
 package manager
 
 import (
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
+
+	"github.com/longhorn/longhorn-manager/types"
 )
 
 func (m *VolumeManager) GetLonghornEventList() (*corev1.EventList, error) {
 	return m.ds.GetLonghornEventList()
 }
+
+func (m *VolumeManager) GetLonghornResourceRawUncached(resource, name string) ([]byte, error) {
+	return m.ds.GetLonghornResourceRawUncached(resource, name)
+}
+
+func (m *VolumeManager) CreateTokenReview(review *authenticationv1.TokenReview) (*authenticationv1.TokenReview, error) {
+	return m.ds.CreateTokenReview(review)
+}
+
+func (m *VolumeManager) CreateSubjectAccessReview(review *authorizationv1.SubjectAccessReview) (*authorizationv1.SubjectAccessReview, error) {
+	return m.ds.CreateSubjectAccessReview(review)
+}
+
+// ListDailyUsages returns the daily usages from the date from to the date to, both inclusive. There is no bound if
+// the date is empty.
+func (m *VolumeManager) ListDailyUsages(from, to string) ([]*types.DailyUsage, error) {
+	usages, err := m.ds.ListDailyUsages()
+	if err != nil {
+		return nil, err
+	}
+	var result []*types.DailyUsage
+	for _, usage := range usages {
+		if (from != "" && usage.Date < from) || (to != "" && usage.Date > to) {
+			continue
+		}
+		result = append(result, usage)
+	}
+	return result, nil
+}
+
+func (m *VolumeManager) ListAlerts() ([]*types.Alert, error) {
+	return m.ds.ListAlerts()
+}
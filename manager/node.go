@@ -1,11 +1,14 @@
 package manager
 
 import (
+	"fmt"
+
 	"github.com/cockroachdb/errors"
 	"github.com/sirupsen/logrus"
 
 	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
 
+	"github.com/longhorn/longhorn-manager/types"
 	"github.com/longhorn/longhorn-manager/util"
 )
 
@@ -122,3 +125,47 @@ func (m *VolumeManager) DeleteNode(name string) error {
 	logrus.Infof("Deleted node %v", name)
 	return nil
 }
+
+// ReplaceDisk points the disk to a new path or device in place of the failed one, so the disk keeps its name, tags,
+// reservation and scheduling flags. The replicas on the failed disk are rebuilt on the replacement once it is ready.
+func (m *VolumeManager) ReplaceDisk(nodeName, diskName, path string, diskDriver longhorn.DiskDriver) (*longhorn.Node, error) {
+	node, err := m.ds.GetNode(nodeName)
+	if err != nil {
+		return nil, err
+	}
+
+	disk, ok := node.Spec.Disks[diskName]
+	if !ok {
+		return nil, fmt.Errorf("disk %v not found on node %v", diskName, nodeName)
+	}
+	diskStatus, ok := node.Status.DiskStatus[diskName]
+	if !ok || diskStatus.DiskUUID == "" {
+		return nil, fmt.Errorf("disk %v on node %v has never been ready, update its path instead", diskName, nodeName)
+	}
+	if types.GetCondition(diskStatus.Conditions, longhorn.DiskConditionTypeReady).Status == longhorn.ConditionStatusTrue {
+		return nil, fmt.Errorf("disk %v on node %v is still ready, evict its replicas instead of replacing it", diskName, nodeName)
+	}
+
+	if path == "" {
+		return nil, fmt.Errorf("invalid empty path for the replacement of disk %v", diskName)
+	}
+	for name, d := range node.Spec.Disks {
+		if name != diskName && d.Path == path {
+			return nil, fmt.Errorf("path %v is already used by disk %v on node %v", path, name, nodeName)
+		}
+	}
+
+	disk.Path = path
+	if diskDriver != longhorn.DiskDriverNone {
+		disk.DiskDriver = diskDriver
+	}
+	disk.ReplacedDiskUUID = diskStatus.DiskUUID
+	node.Spec.Disks[diskName] = disk
+
+	node, err = m.ds.UpdateNode(node)
+	if err != nil {
+		return nil, err
+	}
+	logrus.Infof("Replaced disk %v(%v) of node %v with path %v", diskName, disk.ReplacedDiskUUID, nodeName, path)
+	return node, nil
+}
@@ -0,0 +1,166 @@
+package manager
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/longhorn/longhorn-manager/types"
+	"github.com/longhorn/longhorn-manager/util"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+// PreviewRevert creates a temporary clone of the volume from the snapshot, which the volume clone controller mounts
+// read-only in a maintenance pod, so that the data can be inspected before reverting the volume. The clone is
+// garbage collected after the TTL.
+func (m *VolumeManager) PreviewRevert(volumeName, snapshotName, nodeID string, ttl time.Duration) (v *longhorn.Volume, err error) {
+	defer func() {
+		err = errors.Wrapf(err, "unable to preview the revert of volume %v to snapshot %v", volumeName, snapshotName)
+	}()
+
+	if snapshotName == "" {
+		return nil, fmt.Errorf("snapshot name required")
+	}
+	if ttl == 0 {
+		ttl = types.DefaultRevertPreviewTTL
+	}
+	if err := types.ValidateRevertPreviewTTL(ttl); err != nil {
+		return nil, err
+	}
+
+	source, err := m.ds.GetVolumeRO(volumeName)
+	if err != nil {
+		return nil, err
+	}
+	if types.IsRevertPreviewVolume(source) {
+		return nil, fmt.Errorf("volume %v is already a revert preview", volumeName)
+	}
+	snapshot, err := m.ds.GetSnapshotRO(snapshotName)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot.Spec.Volume != volumeName {
+		return nil, fmt.Errorf("snapshot %v doesn't belong to volume %v", snapshotName, volumeName)
+	}
+	if nodeID != "" {
+		if _, err := m.ds.GetNodeRO(nodeID); err != nil {
+			return nil, err
+		}
+	}
+
+	// The v1 data engine doesn't support linked clones, so the snapshot is fully copied
+	cloneMode := longhorn.CloneModeFullCopy
+	if types.IsDataEngineV2(source.Spec.DataEngine) {
+		cloneMode = longhorn.CloneModeLinkedClone
+	}
+
+	annotations := map[string]string{
+		types.GetLonghornLabelKey(types.RevertPreviewExpireAtAnnotationKeySuffix): time.Now().Add(ttl).UTC().Format(time.RFC3339),
+	}
+	if nodeID != "" {
+		annotations[types.GetLonghornLabelKey(types.RevertPreviewNodeAnnotationKeySuffix)] = nodeID
+	}
+
+	// The labels and the annotations are set on creation, so that the volume clone controller never sees a revert
+	// preview without its TTL
+	name := types.GetRevertPreviewVolumeName(volumeName)
+	v, err = m.create(name, &longhorn.VolumeSpec{
+		Size:                source.Spec.Size,
+		Frontend:            longhorn.VolumeFrontendBlockDev,
+		Encrypted:           source.Spec.Encrypted,
+		DataSource:          types.NewVolumeDataSourceTypeSnapshot(volumeName, snapshotName),
+		CloneMode:           cloneMode,
+		NumberOfReplicas:    1,
+		DataLocality:        longhorn.DataLocalityDisabled,
+		StaleReplicaTimeout: source.Spec.StaleReplicaTimeout,
+		BackingImage:        source.Spec.BackingImage,
+		DiskSelector:        source.Spec.DiskSelector,
+		NodeSelector:        source.Spec.NodeSelector,
+		DataEngine:          source.Spec.DataEngine,
+		BackupTargetName:    source.Spec.BackupTargetName,
+	}, types.GetRevertPreviewLabels(volumeName, snapshotName), annotations)
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.Infof("Created revert preview volume %v of volume %v from snapshot %v", v.Name, volumeName, snapshotName)
+	return v, nil
+}
+
+func (m *VolumeManager) getRevertPreviewVolume(volumeName, previewName string) (*longhorn.Volume, error) {
+	preview, err := m.ds.GetVolume(previewName)
+	if err != nil {
+		return nil, err
+	}
+	if preview.Labels[types.GetLonghornLabelKey(types.LonghornLabelRevertPreviewOf)] != volumeName {
+		return nil, fmt.Errorf("volume %v is not a revert preview of volume %v", previewName, volumeName)
+	}
+	return preview, nil
+}
+
+// expireRevertPreview makes the volume clone controller garbage collect the revert preview volume now. The update is
+// retried on conflicts with the latest revert preview volume.
+func (m *VolumeManager) expireRevertPreview(volumeName, previewName string) error {
+	_, err := util.RetryOnConflictCause(func() (interface{}, error) {
+		preview, err := m.getRevertPreviewVolume(volumeName, previewName)
+		if err != nil {
+			return nil, err
+		}
+		preview.Annotations[types.GetLonghornLabelKey(types.RevertPreviewExpireAtAnnotationKeySuffix)] = time.Now().UTC().Format(time.RFC3339)
+		return m.ds.UpdateVolume(preview)
+	})
+	return err
+}
+
+// PromoteRevertPreview reverts the volume to the snapshot of the revert preview, then deletes the revert preview. Like
+// reverting to a snapshot, the volume must be attached without the frontend.
+func (m *VolumeManager) PromoteRevertPreview(volumeName, previewName string) (v *longhorn.Volume, err error) {
+	defer func() {
+		err = errors.Wrapf(err, "unable to promote revert preview %v of volume %v", previewName, volumeName)
+	}()
+
+	preview, err := m.getRevertPreviewVolume(volumeName, previewName)
+	if err != nil {
+		return nil, err
+	}
+	snapshotName := preview.Labels[types.GetLonghornLabelKey(types.LonghornLabelRevertPreviewSnapshot)]
+
+	v, err = m.ds.GetVolumeRO(volumeName)
+	if err != nil {
+		return nil, err
+	}
+	if v.Status.IsStandby {
+		return nil, fmt.Errorf("cannot revert standby volume %v", volumeName)
+	}
+	if v.Spec.Frontend != "" && !v.Spec.DisableFrontend {
+		return nil, fmt.Errorf("volume %v must be attached with the frontend disabled to revert", volumeName)
+	}
+
+	// The revert is not retried on the conflicts of expiring the revert preview
+	if err := m.RevertSnapshot(snapshotName, volumeName); err != nil {
+		return nil, err
+	}
+	if err := m.expireRevertPreview(volumeName, previewName); err != nil {
+		return nil, err
+	}
+
+	logrus.Infof("Promoted revert preview %v of volume %v to snapshot %v", previewName, volumeName, snapshotName)
+	return v, nil
+}
+
+// DeleteRevertPreview deletes the revert preview of the volume before the TTL.
+func (m *VolumeManager) DeleteRevertPreview(volumeName, previewName string) (v *longhorn.Volume, err error) {
+	defer func() {
+		err = errors.Wrapf(err, "unable to delete revert preview %v of volume %v", previewName, volumeName)
+	}()
+
+	if err := m.expireRevertPreview(volumeName, previewName); err != nil {
+		return nil, err
+	}
+
+	logrus.Infof("Deleting revert preview %v of volume %v", previewName, volumeName)
+	return m.ds.GetVolumeRO(volumeName)
+}
@@ -19,6 +19,14 @@ func (m *VolumeManager) GetSetting(sName types.SettingName) (*longhorn.Setting,
 	return m.ds.GetSetting(sName)
 }
 
+func (m *VolumeManager) GetSettingAsBool(sName types.SettingName) (bool, error) {
+	return m.ds.GetSettingAsBool(sName)
+}
+
+func (m *VolumeManager) GetSettingAuditLogDestination() (string, error) {
+	return m.ds.GetSettingAuditLogDestination()
+}
+
 func (m *VolumeManager) ListSettings() (map[types.SettingName]*longhorn.Setting, error) {
 	return m.ds.ListSettings()
 }
@@ -0,0 +1,197 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/sirupsen/logrus"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/longhorn/longhorn-manager/types"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+// ExportSnapshot returns the backing image staging the export of the snapshot in the format, and creates it if it
+// doesn't exist. Every call postpones the garbage collection of the backing image, so that it's kept while the file is
+// being downloaded.
+func (m *VolumeManager) ExportSnapshot(volumeName, snapshotName, format string) (bi *longhorn.BackingImage, err error) {
+	defer func() {
+		err = errors.Wrapf(err, "unable to export snapshot %v of volume %v", snapshotName, volumeName)
+	}()
+
+	if err := types.ValidateSnapshotExportFormat(format); err != nil {
+		return nil, err
+	}
+
+	v, err := m.ds.GetVolumeRO(volumeName)
+	if err != nil {
+		return nil, err
+	}
+	if types.IsDataEngineV2(v.Spec.DataEngine) {
+		return nil, fmt.Errorf("exporting a snapshot of a v2 volume is not supported")
+	}
+	snapshot, err := m.ds.GetSnapshotRO(snapshotName)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot.Spec.Volume != volumeName {
+		return nil, fmt.Errorf("snapshot %v doesn't belong to volume %v", snapshotName, volumeName)
+	}
+	if !snapshot.Status.ReadyToUse {
+		return nil, fmt.Errorf("snapshot %v is not ready to use", snapshotName)
+	}
+
+	expireAt := time.Now().Add(types.SnapshotExportTTL).UTC().Format(time.RFC3339)
+	name := types.GetSnapshotExportBackingImageName(snapshotName, format)
+
+	bi, err = m.ds.GetBackingImage(name)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		bi = &longhorn.BackingImage{}
+		bi.Name = name
+		bi.Labels = types.GetSnapshotExportLabels(volumeName, snapshotName)
+		bi.Annotations = map[string]string{
+			types.GetLonghornLabelKey(types.SnapshotExportExpireAtAnnotationKeySuffix): expireAt,
+		}
+		bi.Spec = longhorn.BackingImageSpec{
+			Disks:           map[string]string{},
+			DiskFileSpecMap: map[string]*longhorn.BackingImageDiskFileSpec{},
+			SourceType:      longhorn.BackingImageDataSourceTypeExportFromVolume,
+			SourceParameters: map[string]string{
+				longhorn.DataSourceTypeExportFromVolumeParameterVolumeName:   volumeName,
+				longhorn.DataSourceTypeExportFromVolumeParameterSnapshotName: snapshotName,
+				DataSourceTypeExportFromVolumeParameterExportType:            format,
+			},
+			MinNumberOfCopies: 1,
+			DataEngine:        longhorn.DataEngineTypeV1,
+		}
+		if bi, err = m.ds.CreateBackingImage(bi); err != nil {
+			return nil, err
+		}
+		logrus.Infof("Created backing image %v to export snapshot %v of volume %v in %v", name, snapshotName, volumeName, format)
+		return bi, nil
+	}
+
+	return m.touchSnapshotExport(bi, volumeName, snapshotName)
+}
+
+// GetSnapshotExport returns the backing image staging the export of the snapshot in the format, created by
+// ExportSnapshot. Like ExportSnapshot, every call postpones the garbage collection of the backing image.
+func (m *VolumeManager) GetSnapshotExport(volumeName, snapshotName, format string) (bi *longhorn.BackingImage, err error) {
+	defer func() {
+		err = errors.Wrapf(err, "unable to get the export of snapshot %v of volume %v", snapshotName, volumeName)
+	}()
+
+	bi, err = m.ds.GetBackingImage(types.GetSnapshotExportBackingImageName(snapshotName, format))
+	if err != nil {
+		return nil, err
+	}
+	return m.touchSnapshotExport(bi, volumeName, snapshotName)
+}
+
+func (m *VolumeManager) touchSnapshotExport(bi *longhorn.BackingImage, volumeName, snapshotName string) (*longhorn.BackingImage, error) {
+	if bi.Labels[types.GetLonghornLabelKey(types.LonghornLabelSnapshotExportOf)] != volumeName {
+		return nil, fmt.Errorf("backing image %v already exists and doesn't export snapshot %v of volume %v", bi.Name, snapshotName, volumeName)
+	}
+	if bi.DeletionTimestamp != nil {
+		return nil, fmt.Errorf("backing image %v exporting the snapshot is being deleted", bi.Name)
+	}
+	if bi.Annotations == nil {
+		bi.Annotations = map[string]string{}
+	}
+	bi.Annotations[types.GetLonghornLabelKey(types.SnapshotExportExpireAtAnnotationKeySuffix)] = time.Now().Add(types.SnapshotExportTTL).UTC().Format(time.RFC3339)
+	return m.ds.UpdateBackingImage(bi)
+}
+
+// DeleteSnapshotExport deletes the backing image staging the export of the snapshot in the format before it expires.
+func (m *VolumeManager) DeleteSnapshotExport(volumeName, snapshotName, format string) (err error) {
+	defer func() {
+		err = errors.Wrapf(err, "unable to delete the export of snapshot %v of volume %v", snapshotName, volumeName)
+	}()
+
+	name := types.GetSnapshotExportBackingImageName(snapshotName, format)
+	bi, err := m.ds.GetBackingImageRO(name)
+	if err != nil {
+		return err
+	}
+	if bi.Labels[types.GetLonghornLabelKey(types.LonghornLabelSnapshotExportOf)] != volumeName {
+		return fmt.Errorf("backing image %v doesn't export snapshot %v of volume %v", name, snapshotName, volumeName)
+	}
+	return m.DeleteBackingImage(name)
+}
+
+// ImportVolumeFromFile creates the volume the file is imported to, and attaches it to the current node so that the
+// file can be written to its block device. The volume must be detached by FinishVolumeImport once the file is written.
+func (m *VolumeManager) ImportVolumeFromFile(ctx context.Context, name string, spec *longhorn.VolumeSpec) (v *longhorn.Volume, err error) {
+	defer func() {
+		err = errors.Wrapf(err, "unable to import volume %v from file", name)
+	}()
+
+	if spec.BackingImage != "" || spec.DataSource != "" || spec.FromBackup != "" {
+		return nil, fmt.Errorf("volume imported from file cannot have another data source")
+	}
+	if types.IsDataEngineV2(spec.DataEngine) {
+		return nil, fmt.Errorf("importing a file to a v2 volume is not supported")
+	}
+	spec.Frontend = longhorn.VolumeFrontendBlockDev
+
+	if v, err = m.Create(name, spec, nil, ""); err != nil {
+		return nil, err
+	}
+
+	attachmentID := types.GetVolumeImportAttachmentID(name)
+	if _, err = m.Attach(ctx, name, m.currentNodeID, false, "", string(longhorn.AttacherTypeLonghornAPI), attachmentID); err != nil {
+		if deleteErr := m.Delete(name); deleteErr != nil && !apierrors.IsNotFound(deleteErr) {
+			logrus.WithError(deleteErr).Warnf("Failed to clean up volume %v after failing to import it", name)
+		}
+		return nil, err
+	}
+
+	logrus.Infof("Created volume %v and attached it to node %v to import from file", name, m.currentNodeID)
+	return v, nil
+}
+
+// FinishVolumeImport detaches the volume once the file is imported. If the import failed, the volume is deleted.
+func (m *VolumeManager) FinishVolumeImport(ctx context.Context, name string, importErr error) error {
+	if importErr != nil {
+		if err := m.Delete(name); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "unable to clean up volume %v after failing to import it", name)
+		}
+		return nil
+	}
+
+	if _, err := m.Detach(ctx, name, types.GetVolumeImportAttachmentID(name), "", false); err != nil {
+		return errors.Wrapf(err, "unable to detach volume %v after importing it", name)
+	}
+	logrus.Infof("Imported volume %v from file", name)
+	return nil
+}
+
+// CleanupInterruptedVolumeImports deletes the volumes whose import from file to the current node was interrupted by a
+// restart of the manager. These volumes are still attached with the import ticket, but the file won't be written to
+// them anymore.
+func (m *VolumeManager) CleanupInterruptedVolumeImports() error {
+	vas, err := m.ds.ListLHVolumeAttachmentsRO()
+	if err != nil {
+		return errors.Wrap(err, "failed to list volume attachments to clean up interrupted volume imports")
+	}
+
+	var errs []error
+	for _, va := range vas {
+		ticket, ok := va.Spec.AttachmentTickets[types.GetVolumeImportAttachmentID(va.Spec.Volume)]
+		if !ok || ticket.NodeID != m.currentNodeID {
+			continue
+		}
+		logrus.Warnf("Deleting volume %v since its import from file was interrupted", va.Spec.Volume)
+		if err := m.FinishVolumeImport(context.Background(), va.Spec.Volume, fmt.Errorf("import interrupted")); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -1,14 +1,19 @@
 package manager
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"reflect"
 	"strconv"
 	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/sirupsen/logrus"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
 	"k8s.io/apimachinery/pkg/api/resource"
 
 	corev1 "k8s.io/api/core/v1"
@@ -18,12 +23,15 @@ import (
 	"github.com/longhorn/longhorn-manager/datastore"
 	"github.com/longhorn/longhorn-manager/engineapi"
 	"github.com/longhorn/longhorn-manager/scheduler"
+	"github.com/longhorn/longhorn-manager/tracing"
 	"github.com/longhorn/longhorn-manager/types"
 	"github.com/longhorn/longhorn-manager/util"
 
 	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
 )
 
+const managerTracerName = "github.com/longhorn/longhorn-manager/manager"
+
 type VolumeManager struct {
 	ds        *datastore.DataStore
 	scheduler *scheduler.ReplicaScheduler
@@ -127,14 +135,7 @@ func (m *VolumeManager) GetReplicasSorted(vName string) ([]*longhorn.Replica, er
 	return replicas, nil
 }
 
-func (m *VolumeManager) Create(name string, spec *longhorn.VolumeSpec, recurringJobSelector []longhorn.VolumeRecurringJob) (v *longhorn.Volume, err error) {
-	defer func() {
-		err = errors.Wrapf(err, "unable to create volume %v", name)
-		if err != nil {
-			logrus.Errorf("manager: unable to create volume %v: %+v: %v", name, spec, err)
-		}
-	}()
-
+func (m *VolumeManager) Create(name string, spec *longhorn.VolumeSpec, recurringJobSelector []longhorn.VolumeRecurringJob, pvcNamespace string) (v *longhorn.Volume, err error) {
 	labels := map[string]string{}
 	for _, job := range recurringJobSelector {
 		labelType := types.LonghornLabelRecurringJob
@@ -144,6 +145,21 @@ func (m *VolumeManager) Create(name string, spec *longhorn.VolumeSpec, recurring
 		key := types.GetRecurringJobLabelKey(labelType, job.Name)
 		labels[key] = types.LonghornLabelValueEnabled
 	}
+	if pvcNamespace != "" {
+		labels[types.GetLonghornLabelKey(types.LonghornLabelPVCNamespace)] = pvcNamespace
+	}
+	return m.create(name, spec, labels, nil)
+}
+
+// create creates the volume with the labels and the annotations, so that they are set by the time the controllers
+// see the volume.
+func (m *VolumeManager) create(name string, spec *longhorn.VolumeSpec, labels, annotations map[string]string) (v *longhorn.Volume, err error) {
+	defer func() {
+		err = errors.Wrapf(err, "unable to create volume %v", name)
+		if err != nil {
+			logrus.Errorf("manager: unable to create volume %v: %+v: %v", name, spec, err)
+		}
+	}()
 
 	if spec.DataSource != "" {
 		if err := m.verifyDataSourceForVolumeCreation(spec.DataSource, spec.Size); err != nil {
@@ -165,8 +181,9 @@ func (m *VolumeManager) Create(name string, spec *longhorn.VolumeSpec, recurring
 
 	v = &longhorn.Volume{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:   name,
-			Labels: labels,
+			Name:        name,
+			Labels:      labels,
+			Annotations: annotations,
 		},
 		Spec: longhorn.VolumeSpec{
 			Size:                            spec.Size,
@@ -201,6 +218,8 @@ func (m *VolumeManager) Create(name string, spec *longhorn.VolumeSpec, recurring
 			FreezeFilesystemForSnapshot:     spec.FreezeFilesystemForSnapshot,
 			BackupTargetName:                backupTargetName,
 			OfflineRebuilding:               spec.OfflineRebuilding,
+			TieringPolicy:                   spec.TieringPolicy,
+			TopologySpreadConstraints:       spec.TopologySpreadConstraints,
 			ReplicaRebuildingBandwidthLimit: spec.ReplicaRebuildingBandwidthLimit,
 		},
 	}
@@ -221,9 +240,12 @@ func (m *VolumeManager) Delete(name string) error {
 	return nil
 }
 
-func (m *VolumeManager) Attach(name, nodeID string, disableFrontend bool, attachedBy, attacherType, attachmentID string) (v *longhorn.Volume, err error) {
+func (m *VolumeManager) Attach(ctx context.Context, name, nodeID string, disableFrontend bool, attachedBy, attacherType, attachmentID string) (v *longhorn.Volume, err error) {
+	ctx, span := tracing.Tracer(managerTracerName).Start(ctx, "VolumeManager.Attach",
+		trace.WithAttributes(attribute.String("longhorn.volume", name), attribute.String("longhorn.node", nodeID)))
 	defer func() {
 		err = errors.Wrapf(err, "unable to attach volume %v to %v", name, nodeID)
+		tracing.EndSpan(span, err)
 	}()
 
 	node, err := m.ds.GetNode(nodeID)
@@ -282,6 +304,7 @@ func (m *VolumeManager) Attach(name, nodeID string, disableFrontend bool, attach
 		},
 	}
 
+	tracing.InjectAnnotations(ctx, va)
 	if _, err := m.ds.UpdateLHVolumeAttachment(va); err != nil {
 		return nil, err
 	}
@@ -290,9 +313,12 @@ func (m *VolumeManager) Attach(name, nodeID string, disableFrontend bool, attach
 }
 
 // Detach will handle regular detachment as well as cleaning up attachment Ticket created by upgrade path
-func (m *VolumeManager) Detach(name, attachmentID, hostID string, forceDetach bool) (v *longhorn.Volume, err error) {
+func (m *VolumeManager) Detach(ctx context.Context, name, attachmentID, hostID string, forceDetach bool) (v *longhorn.Volume, err error) {
+	ctx, span := tracing.Tracer(managerTracerName).Start(ctx, "VolumeManager.Detach",
+		trace.WithAttributes(attribute.String("longhorn.volume", name)))
 	defer func() {
 		err = errors.Wrapf(err, "unable to detach volume %v", name)
+		tracing.EndSpan(span, err)
 	}()
 
 	v, err = m.ds.GetVolume(name)
@@ -312,6 +338,7 @@ func (m *VolumeManager) Detach(name, attachmentID, hostID string, forceDetach bo
 	// if force detach, detach from all nodes by clearing the volumeattachment spec
 	if forceDetach {
 		va.Spec.AttachmentTickets = make(map[string]*longhorn.AttachmentTicket)
+		tracing.InjectAnnotations(ctx, va)
 		if _, err := m.ds.UpdateLHVolumeAttachment(va); err != nil {
 			return nil, err
 		}
@@ -320,6 +347,7 @@ func (m *VolumeManager) Detach(name, attachmentID, hostID string, forceDetach bo
 
 	delete(va.Spec.AttachmentTickets, attachmentID)
 
+	tracing.InjectAnnotations(ctx, va)
 	if _, err := m.ds.UpdateLHVolumeAttachment(va); err != nil {
 		return nil, err
 	}
@@ -356,28 +384,8 @@ func (m *VolumeManager) Salvage(volumeName string, replicaNames []string) (v *lo
 		if r.Spec.VolumeName != v.Name {
 			return nil, fmt.Errorf("replica %v doesn't belong to volume %v", r.Name, v.Name)
 		}
-		isDownOrDeleted, err := m.ds.IsNodeDownOrDeletedOrDelinquent(r.Spec.NodeID, v.Name)
-		if err != nil {
-			return nil, fmt.Errorf("failed to check if the related node %v is still running for replica %v", r.Spec.NodeID, name)
-		}
-		if isDownOrDeleted {
-			return nil, fmt.Errorf("unable to check if the related node %v is down or deleted for replica %v", r.Spec.NodeID, name)
-		}
-		node, err := m.ds.GetNode(r.Spec.NodeID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get the related node %v for replica %v", r.Spec.NodeID, name)
-		}
-		diskSchedulable := false
-		for _, diskStatus := range node.Status.DiskStatus {
-			if diskStatus.DiskUUID == r.Spec.DiskID {
-				if types.GetCondition(diskStatus.Conditions, longhorn.DiskConditionTypeSchedulable).Status == longhorn.ConditionStatusTrue {
-					diskSchedulable = true
-					break
-				}
-			}
-		}
-		if !diskSchedulable {
-			return nil, fmt.Errorf("disk with UUID %v on node %v is unschedulable for replica %v", r.Spec.DiskID, r.Spec.NodeID, name)
+		if err := m.checkReplicaSalvageable(v, r); err != nil {
+			return nil, err
 		}
 		if r.Spec.FailedAt == "" {
 			// already updated, ignore it for idempotency
@@ -393,6 +401,106 @@ func (m *VolumeManager) Salvage(volumeName string, replicaNames []string) (v *lo
 	return v, nil
 }
 
+// checkReplicaSalvageable returns an error if the node or the disk of the replica can't be used by a salvage.
+func (m *VolumeManager) checkReplicaSalvageable(v *longhorn.Volume, r *longhorn.Replica) error {
+	isDownOrDeleted, err := m.ds.IsNodeDownOrDeletedOrDelinquent(r.Spec.NodeID, v.Name)
+	if err != nil {
+		return fmt.Errorf("failed to check if the related node %v is still running for replica %v", r.Spec.NodeID, r.Name)
+	}
+	if isDownOrDeleted {
+		return fmt.Errorf("unable to check if the related node %v is down or deleted for replica %v", r.Spec.NodeID, r.Name)
+	}
+	node, err := m.ds.GetNodeRO(r.Spec.NodeID)
+	if err != nil {
+		return fmt.Errorf("failed to get the related node %v for replica %v", r.Spec.NodeID, r.Name)
+	}
+	diskSchedulable := false
+	for _, diskStatus := range node.Status.DiskStatus {
+		if diskStatus.DiskUUID == r.Spec.DiskID {
+			if types.GetCondition(diskStatus.Conditions, longhorn.DiskConditionTypeSchedulable).Status == longhorn.ConditionStatusTrue {
+				diskSchedulable = true
+				break
+			}
+		}
+	}
+	if !diskSchedulable {
+		return fmt.Errorf("disk with UUID %v on node %v is unschedulable for replica %v", r.Spec.DiskID, r.Spec.NodeID, r.Name)
+	}
+	return nil
+}
+
+// SalvageReport compares the data of the failed replicas of a faulted volume, inspected on the nodes of the replicas
+// after they failed, and recommends the replicas to salvage.
+func (m *VolumeManager) SalvageReport(volumeName string) (report *types.SalvageReport, err error) {
+	defer func() {
+		err = errors.Wrapf(err, "failed to get the salvage report of volume %v", volumeName)
+	}()
+
+	v, err := m.ds.GetVolumeRO(volumeName)
+	if err != nil {
+		return nil, err
+	}
+	if v.Status.Robustness != longhorn.VolumeRobustnessFaulted {
+		return nil, fmt.Errorf("invalid robustness state to salvage: %v", v.Status.Robustness)
+	}
+
+	replicas, err := m.ds.ListVolumeReplicasRO(volumeName)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []*types.SalvageCandidate
+	for _, r := range replicas {
+		if r.Spec.FailedAt == "" {
+			continue
+		}
+		candidate := &types.SalvageCandidate{
+			Replica:  r.Name,
+			NodeID:   r.Spec.NodeID,
+			DiskID:   r.Spec.DiskID,
+			FailedAt: r.Spec.FailedAt,
+			Metadata: r.Status.SalvageMetadata,
+		}
+		switch {
+		case !types.IsDataEngineV1(r.Spec.DataEngine):
+			candidate.Unusable = fmt.Sprintf("the data of %v replicas can't be inspected", r.Spec.DataEngine)
+		case r.Spec.HealthyAt == "":
+			candidate.Unusable = "the replica was never healthy, so its data is incomplete"
+		default:
+			if err := m.checkReplicaSalvageable(v, r); err != nil {
+				candidate.Unusable = err.Error()
+			}
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	report = &types.SalvageReport{
+		Volume:                  v.Name,
+		RevisionCounterDisabled: v.Spec.RevisionCounterDisabled,
+		Candidates:              types.RankSalvageCandidates(candidates, v.Spec.RevisionCounterDisabled),
+		Recommended:             []string{},
+	}
+	for _, c := range report.Candidates {
+		if c.Recommended {
+			report.Recommended = append(report.Recommended, c.Replica)
+		}
+	}
+	return report, nil
+}
+
+// SalvagePreview returns the outcome of salvaging the chosen replicas of a faulted volume, without salvaging them.
+func (m *VolumeManager) SalvagePreview(volumeName string, replicaNames []string) (*types.SalvagePreview, error) {
+	report, err := m.SalvageReport(volumeName)
+	if err != nil {
+		return nil, err
+	}
+	preview, err := types.GetSalvagePreview(report, replicaNames)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to preview the salvage of volume %v", volumeName)
+	}
+	return preview, nil
+}
+
 func (m *VolumeManager) Activate(volumeName string, frontend string) (v *longhorn.Volume, err error) {
 	defer func() {
 		err = errors.Wrapf(err, "unable to activate volume %v", volumeName)
@@ -631,6 +739,32 @@ func (m *VolumeManager) UpdateOfflineRebuilding(volumeName string, offlineRebuil
 	return v, nil
 }
 
+func (m *VolumeManager) UpdateTieringPolicy(name string, policy longhorn.VolumeTieringPolicy) (v *longhorn.Volume, err error) {
+	defer func() {
+		err = errors.Wrapf(err, "unable to update field TieringPolicy for volume %s", name)
+	}()
+
+	v, err = m.ds.GetVolume(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if reflect.DeepEqual(v.Spec.TieringPolicy, policy) {
+		logrus.Debugf("Volume %s already set field TieringPolicy to %+v", v.Name, policy)
+		return v, nil
+	}
+
+	oldPolicy := v.Spec.TieringPolicy
+	v.Spec.TieringPolicy = policy
+	v, err = m.ds.UpdateVolume(v)
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.Infof("Updated volume %s field TieringPolicy from %+v to %+v", v.Name, oldPolicy, policy)
+	return v, nil
+}
+
 func (m *VolumeManager) TrimFilesystem(name string) (v *longhorn.Volume, err error) {
 	defer func() {
 		err = errors.Wrapf(err, "unable to trim filesystem for volume %v", name)
@@ -655,7 +789,7 @@ func (m *VolumeManager) TrimFilesystem(name string) (v *longhorn.Volume, err err
 		}
 	}
 
-	if v.Spec.AccessMode == longhorn.AccessModeReadWriteMany {
+	if types.IsSharedAccessMode(v.Spec.AccessMode) {
 		return v, m.trimRWXVolumeFilesystem(name, v.Spec.Encrypted)
 	}
 	return v, m.trimNonRWXVolumeFilesystem(name, v.Spec.Encrypted)
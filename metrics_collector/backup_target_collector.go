@@ -0,0 +1,118 @@
+package metricscollector
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/types"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+type BackupTargetCollector struct {
+	*baseCollector
+
+	probeLatencyMetric   metricInfo
+	probeErrorRateMetric metricInfo
+	probeLastErrorMetric metricInfo
+	degradedMetric       metricInfo
+}
+
+func NewBackupTargetCollector(
+	logger logrus.FieldLogger,
+	nodeID string,
+	ds *datastore.DataStore) *BackupTargetCollector {
+
+	bc := &BackupTargetCollector{
+		baseCollector: newBaseCollector(subsystemBackupTarget, logger, nodeID, ds),
+	}
+
+	bc.probeLatencyMetric = metricInfo{
+		Desc: prometheus.NewDesc(
+			prometheus.BuildFQName(longhornName, subsystemBackupTarget, "probe_latency_milliseconds"),
+			"Latency percentiles of the recent health probes of this backup target",
+			[]string{backupTargetLabel, quantileLabel},
+			nil,
+		),
+		Type: prometheus.GaugeValue,
+	}
+
+	bc.probeErrorRateMetric = metricInfo{
+		Desc: prometheus.NewDesc(
+			prometheus.BuildFQName(longhornName, subsystemBackupTarget, "probe_error_rate_percentage"),
+			"Percentage of the recent health probes of this backup target that failed",
+			[]string{backupTargetLabel},
+			nil,
+		),
+		Type: prometheus.GaugeValue,
+	}
+
+	bc.probeLastErrorMetric = metricInfo{
+		Desc: prometheus.NewDesc(
+			prometheus.BuildFQName(longhornName, subsystemBackupTarget, "probe_last_error_timestamp_seconds"),
+			"Time of the last failed health probe of this backup target, labeled with the error class",
+			[]string{backupTargetLabel, errorClassLabel},
+			nil,
+		),
+		Type: prometheus.GaugeValue,
+	}
+
+	bc.degradedMetric = metricInfo{
+		Desc: prometheus.NewDesc(
+			prometheus.BuildFQName(longhornName, subsystemBackupTarget, "degraded"),
+			"Whether this backup target is degraded according to the health probes. 1 means degraded, 0 means healthy",
+			[]string{backupTargetLabel, conditionReasonLabel},
+			nil,
+		),
+		Type: prometheus.GaugeValue,
+	}
+
+	return bc
+}
+
+func (bc *BackupTargetCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- bc.probeLatencyMetric.Desc
+	ch <- bc.probeErrorRateMetric.Desc
+	ch <- bc.probeLastErrorMetric.Desc
+	ch <- bc.degradedMetric.Desc
+}
+
+func (bc *BackupTargetCollector) Collect(ch chan<- prometheus.Metric) {
+	defer func() {
+		if err := recover(); err != nil {
+			bc.logger.WithField("error", err).Warn("Panic during collecting metrics")
+		}
+	}()
+
+	backupTargets, err := bc.ds.ListBackupTargetsRO()
+	if err != nil {
+		bc.logger.WithError(err).Warn("Error during scrape")
+		return
+	}
+
+	for _, backupTarget := range backupTargets {
+		if backupTarget.Status.OwnerID != bc.currentNodeID {
+			continue
+		}
+		health := backupTarget.Status.Health
+		if health.LastProbedAt.IsZero() {
+			continue
+		}
+
+		ch <- prometheus.MustNewConstMetric(bc.probeLatencyMetric.Desc, bc.probeLatencyMetric.Type, float64(health.LatencyP50), backupTarget.Name, "0.5")
+		ch <- prometheus.MustNewConstMetric(bc.probeLatencyMetric.Desc, bc.probeLatencyMetric.Type, float64(health.LatencyP95), backupTarget.Name, "0.95")
+		ch <- prometheus.MustNewConstMetric(bc.probeLatencyMetric.Desc, bc.probeLatencyMetric.Type, float64(health.LatencyP99), backupTarget.Name, "0.99")
+		ch <- prometheus.MustNewConstMetric(bc.probeErrorRateMetric.Desc, bc.probeErrorRateMetric.Type, float64(health.ErrorRate), backupTarget.Name)
+		if !health.LastErrorAt.IsZero() {
+			ch <- prometheus.MustNewConstMetric(bc.probeLastErrorMetric.Desc, bc.probeLastErrorMetric.Type, float64(health.LastErrorAt.Unix()), backupTarget.Name, string(health.LastErrorClass))
+		}
+
+		condition := types.GetCondition(backupTarget.Status.Conditions, longhorn.BackupTargetConditionTypeDegraded)
+		degraded := 0
+		if condition.Status == longhorn.ConditionStatusTrue {
+			degraded = 1
+		}
+		ch <- prometheus.MustNewConstMetric(bc.degradedMetric.Desc, bc.degradedMetric.Type, float64(degraded), backupTarget.Name, condition.Reason)
+	}
+}
@@ -1,7 +1,9 @@
 package metricscollector
 
 import (
+	"math"
 	"strings"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/prometheus/client_golang/prometheus"
@@ -10,6 +12,7 @@ import (
 	"github.com/longhorn/longhorn-manager/datastore"
 	"github.com/longhorn/longhorn-manager/engineapi"
 	"github.com/longhorn/longhorn-manager/types"
+	"github.com/longhorn/longhorn-manager/util"
 
 	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
 )
@@ -22,6 +25,10 @@ type DiskCollector struct {
 	reservationMetric metricInfo
 	statusMetric      metricInfo
 
+	// Capacity forecast metrics
+	growthRateMetric metricInfo
+	timeToFullMetric metricInfo
+
 	// Performance metrics
 	readThroughputMetric  metricInfo
 	writeThroughputMetric metricInfo
@@ -80,6 +87,27 @@ func NewDiskCollector(
 		Type: prometheus.GaugeValue,
 	}
 
+	// Capacity forecast metrics
+	dc.growthRateMetric = metricInfo{
+		Desc: prometheus.NewDesc(
+			prometheus.BuildFQName(longhornName, subsystemDisk, "growth_rate_bytes_per_day"),
+			"The growth rate of the actual usage of the replicas on this disk (Bytes/day)",
+			[]string{nodeLabel, diskLabel},
+			nil,
+		),
+		Type: prometheus.GaugeValue,
+	}
+
+	dc.timeToFullMetric = metricInfo{
+		Desc: prometheus.NewDesc(
+			prometheus.BuildFQName(longhornName, subsystemDisk, "time_to_full_seconds"),
+			"The projected time until this disk becomes full at the current growth rate",
+			[]string{nodeLabel, diskLabel},
+			nil,
+		),
+		Type: prometheus.GaugeValue,
+	}
+
 	// Performance metrics
 	dc.readThroughputMetric = metricInfo{
 		Desc: prometheus.NewDesc(
@@ -149,6 +177,8 @@ func (dc *DiskCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- dc.usageMetric.Desc
 	ch <- dc.reservationMetric.Desc
 	ch <- dc.statusMetric.Desc
+	ch <- dc.growthRateMetric.Desc
+	ch <- dc.timeToFullMetric.Desc
 	ch <- dc.readThroughputMetric.Desc
 	ch <- dc.writeThroughputMetric.Desc
 	ch <- dc.readIOPSMetric.Desc
@@ -216,6 +246,15 @@ func (dc *DiskCollector) collectDiskStorage(ch chan<- prometheus.Metric) {
 		ch <- prometheus.MustNewConstMetric(dc.capacityMetric.Desc, dc.capacityMetric.Type, float64(storageCapacity), dc.currentNodeID, diskName)
 		ch <- prometheus.MustNewConstMetric(dc.usageMetric.Desc, dc.usageMetric.Type, float64(storageUsage), dc.currentNodeID, diskName)
 		ch <- prometheus.MustNewConstMetric(dc.reservationMetric.Desc, dc.reservationMetric.Type, float64(storageReservation), dc.currentNodeID, diskName)
+		ch <- prometheus.MustNewConstMetric(dc.growthRateMetric.Desc, dc.growthRateMetric.Type, float64(disk.Status.StorageGrowthRate), dc.currentNodeID, diskName)
+
+		if disk.Status.ProjectedFullTime != "" {
+			projectedFullTime, err := util.ParseTime(disk.Status.ProjectedFullTime)
+			if err == nil {
+				timeToFull := math.Max(time.Until(projectedFullTime).Seconds(), 0)
+				ch <- prometheus.MustNewConstMetric(dc.timeToFullMetric.Desc, dc.timeToFullMetric.Type, timeToFull, dc.currentNodeID, diskName)
+			}
+		}
 
 		if diskServiceClient != nil && disk.Spec.Type == longhorn.DiskTypeBlock {
 			diskMetrics, err := diskServiceClient.MetricsGet(string(disk.Spec.Type), diskName, diskPath, diskDriver)
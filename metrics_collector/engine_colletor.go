@@ -4,6 +4,7 @@ import (
 	"regexp"
 	"strings"
 	"sync"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/errors"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/types"
 
 	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
 )
@@ -23,6 +25,13 @@ type EngineCollector struct {
 	replicaModeMetric metricInfo
 	rebuildMetric     metricInfo
 
+	rebuildEstimatedTransferredBytesMetric metricInfo
+	rebuildRateMetric                      metricInfo
+	rebuildETAMetric                       metricInfo
+	purgeMetric                            metricInfo
+	purgeRateMetric                        metricInfo
+	purgeETAMetric                         metricInfo
+
 	pendingRebuildMetrics map[string]prometheus.Metric
 	mutex                 sync.Mutex
 }
@@ -77,6 +86,68 @@ func NewEngineCollector(
 		Type: prometheus.GaugeValue,
 	}
 
+	rebuildLabels := []string{nodeLabel, engineLabel, rebuildSrcLabel, rebuildDstLabel, pvcLabel, pvcNamespaceLabel}
+	ec.rebuildEstimatedTransferredBytesMetric = metricInfo{
+		Desc: prometheus.NewDesc(
+			prometheus.BuildFQName(longhornName, subsystemEngine, "rebuild_estimated_transferred_bytes"),
+			"Estimated bytes rebuilt so far for the replica of the engine, derived from the rebuild progress and the snapshot sizes",
+			rebuildLabels,
+			nil,
+		),
+		Type: prometheus.GaugeValue,
+	}
+
+	ec.rebuildRateMetric = metricInfo{
+		Desc: prometheus.NewDesc(
+			prometheus.BuildFQName(longhornName, subsystemEngine, "rebuild_bytes_per_second"),
+			"Estimated average rebuild rate in bytes per second for the replica of the engine, derived from the rebuild progress",
+			rebuildLabels,
+			nil,
+		),
+		Type: prometheus.GaugeValue,
+	}
+
+	ec.rebuildETAMetric = metricInfo{
+		Desc: prometheus.NewDesc(
+			prometheus.BuildFQName(longhornName, subsystemEngine, "rebuild_eta_seconds"),
+			"Estimated seconds until the rebuild of the replica of the engine completes, or -1 if unknown",
+			rebuildLabels,
+			nil,
+		),
+		Type: prometheus.GaugeValue,
+	}
+
+	purgeLabels := []string{nodeLabel, engineLabel, volumeLabel, replicaLabel}
+	ec.purgeMetric = metricInfo{
+		Desc: prometheus.NewDesc(
+			prometheus.BuildFQName(longhornName, subsystemEngine, "snapshot_purge_progress"),
+			"Snapshot purge progress percentage of the replica of the engine (0-100)",
+			purgeLabels,
+			nil,
+		),
+		Type: prometheus.GaugeValue,
+	}
+
+	ec.purgeRateMetric = metricInfo{
+		Desc: prometheus.NewDesc(
+			prometheus.BuildFQName(longhornName, subsystemEngine, "snapshot_purge_bytes_per_second"),
+			"Estimated average snapshot purge rate in bytes per second for the replica of the engine, derived from the purge progress",
+			purgeLabels,
+			nil,
+		),
+		Type: prometheus.GaugeValue,
+	}
+
+	ec.purgeETAMetric = metricInfo{
+		Desc: prometheus.NewDesc(
+			prometheus.BuildFQName(longhornName, subsystemEngine, "snapshot_purge_eta_seconds"),
+			"Estimated seconds until the snapshot purge of the replica of the engine completes, or -1 if unknown",
+			purgeLabels,
+			nil,
+		),
+		Type: prometheus.GaugeValue,
+	}
+
 	return ec
 }
 
@@ -85,6 +156,12 @@ func (ec *EngineCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- ec.stateMetric.Desc
 	ch <- ec.replicaModeMetric.Desc
 	ch <- ec.rebuildMetric.Desc
+	ch <- ec.rebuildEstimatedTransferredBytesMetric.Desc
+	ch <- ec.rebuildRateMetric.Desc
+	ch <- ec.rebuildETAMetric.Desc
+	ch <- ec.purgeMetric.Desc
+	ch <- ec.purgeRateMetric.Desc
+	ch <- ec.purgeETAMetric.Desc
 }
 
 func (ec *EngineCollector) Collect(ch chan<- prometheus.Metric) {
@@ -105,6 +182,7 @@ func (ec *EngineCollector) Collect(ch chan<- prometheus.Metric) {
 		ec.collectEngineState(ch, e)
 		ec.collectReplicaModes(ch, e)
 		ec.collectRebuildProgress(ch, e)
+		ec.collectPurgeProgress(ch, e)
 	}
 
 	ec.finalizeCompletedRebuilds(ch)
@@ -206,6 +284,27 @@ func (ec *EngineCollector) collectRebuildProgress(ch chan<- prometheus.Metric, e
 				v.Status.KubernetesStatus.Namespace,
 			)
 
+			for _, m := range []struct {
+				info  metricInfo
+				value int64
+			}{
+				{ec.rebuildEstimatedTransferredBytesMetric, rs.EstimatedTransferredBytes},
+				{ec.rebuildRateMetric, rs.BytesPerSecond},
+				{ec.rebuildETAMetric, types.GetProgressETASeconds(rs.TotalBytes, rs.EstimatedTransferredBytes, rs.BytesPerSecond, rs.SampledAt, time.Now())},
+			} {
+				ch <- prometheus.MustNewConstMetric(
+					m.info.Desc,
+					m.info.Type,
+					float64(m.value),
+					ec.currentNodeID,
+					e.Name,
+					srcAddress,
+					replicaAddress,
+					v.Status.KubernetesStatus.PVCName,
+					v.Status.KubernetesStatus.Namespace,
+				)
+			}
+
 			ec.pendingRebuildMetrics[replicaName] = prometheus.MustNewConstMetric(
 				ec.rebuildMetric.Desc,
 				ec.rebuildMetric.Type,
@@ -221,6 +320,36 @@ func (ec *EngineCollector) collectRebuildProgress(ch chan<- prometheus.Metric, e
 	}
 }
 
+func (ec *EngineCollector) collectPurgeProgress(ch chan<- prometheus.Metric, e *longhorn.Engine) {
+	for addr, ps := range e.Status.PurgeStatus {
+		if ps == nil || !ps.IsPurging {
+			continue
+		}
+		replicaName := ec.getReplicaNameByAddress(e, strings.TrimPrefix(addr, "tcp://"))
+		if replicaName == "" {
+			continue
+		}
+		for _, m := range []struct {
+			info  metricInfo
+			value int64
+		}{
+			{ec.purgeMetric, int64(ps.Progress)},
+			{ec.purgeRateMetric, ps.BytesPerSecond},
+			{ec.purgeETAMetric, types.GetProgressETASeconds(ps.TotalBytes, ps.EstimatedTransferredBytes, ps.BytesPerSecond, ps.SampledAt, time.Now())},
+		} {
+			ch <- prometheus.MustNewConstMetric(
+				m.info.Desc,
+				m.info.Type,
+				float64(m.value),
+				ec.currentNodeID,
+				e.Name,
+				e.Spec.VolumeName,
+				replicaName,
+			)
+		}
+	}
+}
+
 func (ec *EngineCollector) finalizeCompletedRebuilds(ch chan<- prometheus.Metric) {
 	ec.mutex.Lock()
 	defer ec.mutex.Unlock()
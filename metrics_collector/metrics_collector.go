@@ -29,8 +29,10 @@ func InitMetricsCollectorSystem(logger logrus.FieldLogger, currentNodeID string,
 	snapshotController := NewSnapshotCollector(logger, currentNodeID, ds)
 	backingImageCollector := NewBackingImageCollector(logger, currentNodeID, ds)
 	backupBackingImageCollector := NewBackupBackingImageCollector(logger, currentNodeID, ds)
+	backupTargetCollector := NewBackupTargetCollector(logger, currentNodeID, ds)
 	engineCollector := NewEngineCollector(logger, currentNodeID, ds)
 	ReplicaCollector := NewReplicaCollector(logger, currentNodeID, ds)
+	usageCollector := NewUsageCollector(logger, currentNodeID, ds)
 
 	if err := registry.Register(volumeCollector); err != nil {
 		logger.WithField("collector", subsystemVolume).WithError(err).Warn("Failed to register collector")
@@ -56,6 +58,10 @@ func InitMetricsCollectorSystem(logger logrus.FieldLogger, currentNodeID string,
 		logger.WithField("collector", subsystemBackupBackingImage).WithError(err).Warn("Failed to register collector")
 	}
 
+	if err := registry.Register(backupTargetCollector); err != nil {
+		logger.WithField("collector", subsystemBackupTarget).WithError(err).Warn("Failed to register collector")
+	}
+
 	if err := registry.Register(engineCollector); err != nil {
 		logger.WithField("collector", subsystemEngine).WithError(err).Warn("Failed to register collector")
 	}
@@ -64,6 +70,10 @@ func InitMetricsCollectorSystem(logger logrus.FieldLogger, currentNodeID string,
 		logger.WithField("collector", subsystemReplica).WithError(err).Warn("Failed to register collector")
 	}
 
+	if err := registry.Register(usageCollector); err != nil {
+		logger.WithField("collector", subsystemUsage).WithError(err).Warn("Failed to register collector")
+	}
+
 	namespace := os.Getenv(types.EnvPodNamespace)
 	if namespace == "" {
 		logger.Warnf("Cannot detect pod namespace, environment variable %v is missing, "+
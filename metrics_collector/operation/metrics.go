@@ -0,0 +1,145 @@
+// Package operation provides the histograms of the volume lifecycle operations.
+// The controllers record an operation once they observe its completion.
+package operation
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	"github.com/longhorn/longhorn-manager/metrics_collector/registry"
+)
+
+// Metrics subsystems and keys of the operation histograms.
+const (
+	LonghornName             = "longhorn"
+	VolumeSubsystem          = "volume"
+	ReplicaSubsystem         = "replica"
+	DurationKey              = "operation_duration_seconds"
+	RebuildEstimatedBytesKey = "rebuild_estimated_bytes"
+	OperationLabel           = "operation"
+	DataEngineLabel          = "data_engine"
+	OutcomeLabel             = "outcome"
+	OutcomeSuccess           = "success"
+	OutcomeFailure           = "failure"
+	OperationAttach          = "attach"
+	OperationDetach          = "detach"
+	OperationRebuild         = "rebuild"
+	OperationSnapshot        = "snapshot_create"
+	OperationPurge           = "snapshot_purge"
+	OperationBackup          = "backup"
+	OperationRestore         = "restore"
+	OperationExpansion       = "expansion"
+	OperationUpgrade         = "live_upgrade"
+)
+
+var (
+	// The operations take from a second to many hours, depending on the size of the volume.
+	durationBuckets = []float64{1, 2.5, 5, 10, 30, 60, 120, 300, 600, 1200, 1800, 3600, 7200, 14400, 28800, 86400}
+
+	duration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: LonghornName,
+		Subsystem: VolumeSubsystem,
+		Name:      DurationKey,
+		Help:      "How long in seconds a volume lifecycle operation takes to complete or fail",
+		Buckets:   durationBuckets,
+	}, []string{OperationLabel, DataEngineLabel, OutcomeLabel})
+
+	// The engine doesn't report the bytes transferred by a rebuild, so the size of the snapshots of the volume is
+	// recorded as the estimate of the data rebuilt.
+	rebuildEstimatedBytes = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: LonghornName,
+		Subsystem: ReplicaSubsystem,
+		Name:      RebuildEstimatedBytesKey,
+		Help:      "Estimated size in bytes of the data rebuilt for a replica, from the size of the snapshots of the volume when the rebuild completes or fails",
+		// From 64 MiB to 32 TiB
+		Buckets: prometheus.ExponentialBuckets(64*1024*1024, 2, 20),
+	}, []string{DataEngineLabel, OutcomeLabel})
+
+	metrics = []prometheus.Collector{
+		duration, rebuildEstimatedBytes,
+	}
+)
+
+func init() {
+	for _, m := range metrics {
+		if err := registry.Register(m); err != nil {
+			logrus.WithError(err).WithField("metric", m).Error("Failed to register operation metrics")
+		}
+	}
+}
+
+// Outcome returns the outcome label of an operation that failed if and only if failed is true.
+func Outcome(failed bool) string {
+	if failed {
+		return OutcomeFailure
+	}
+	return OutcomeSuccess
+}
+
+// ObserveDuration records the time elapsed since the operation started. An operation without a known start time is
+// ignored.
+func ObserveDuration(operation, dataEngine, outcome string, startTime time.Time) {
+	if startTime.IsZero() {
+		return
+	}
+	elapsed := time.Since(startTime)
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	duration.WithLabelValues(operation, dataEngine, outcome).Observe(elapsed.Seconds())
+}
+
+// ObserveRebuildEstimatedBytes records the estimated size of the data rebuilt for a replica.
+func ObserveRebuildEstimatedBytes(dataEngine, outcome string, size int64) {
+	if size <= 0 {
+		return
+	}
+	rebuildEstimatedBytes.WithLabelValues(dataEngine, outcome).Observe(float64(size))
+}
+
+// Tracker keeps the start times of the operations whose start is not recorded in the resources, such as volume
+// attachment. The start times are kept in memory, so an operation in progress while the owner of the resource changes
+// is not recorded.
+type Tracker struct {
+	lock sync.Mutex
+	// resource name -> operation -> start time
+	startTimes map[string]map[string]time.Time
+}
+
+func NewTracker() *Tracker {
+	return &Tracker{
+		startTimes: map[string]map[string]time.Time{},
+	}
+}
+
+// Start records the start time of the operation on the resource.
+func (t *Tracker) Start(operation, name string, now time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.startTimes[name] == nil {
+		t.startTimes[name] = map[string]time.Time{}
+	}
+	t.startTimes[name][operation] = now
+}
+
+// Finish forgets the operation on the resource and returns its start time, or a zero time if it was not started.
+func (t *Tracker) Finish(operation, name string) time.Time {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	startTime := t.startTimes[name][operation]
+	delete(t.startTimes[name], operation)
+	if len(t.startTimes[name]) == 0 {
+		delete(t.startTimes, name)
+	}
+	return startTime
+}
+
+// Forget forgets all operations on the resource.
+func (t *Tracker) Forget(name string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.startTimes, name)
+}
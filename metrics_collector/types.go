@@ -20,6 +20,8 @@ const (
 	subsystemSnapshot           = "snapshot"
 	subsystemBackingImage       = "backing_image"
 	subsystemBackupBackingImage = "backup_backing_image"
+	subsystemBackupTarget       = "backup_target"
+	subsystemUsage              = "usage"
 
 	nodeLabel               = "node"
 	diskLabel               = "disk"
@@ -47,6 +49,9 @@ const (
 	frontendLabel           = "frontend"
 	imageLabel              = "image"
 	modeLabel               = "mode"
+	backupTargetLabel       = "backup_target"
+	quantileLabel           = "quantile"
+	errorClassLabel         = "error_class"
 )
 
 type metricInfo struct {
@@ -0,0 +1,109 @@
+package metricscollector
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/types"
+)
+
+type UsageCollector struct {
+	*baseCollector
+
+	sizeMetric         metricInfo
+	actualSizeMetric   metricInfo
+	snapshotSizeMetric metricInfo
+	backupSizeMetric   metricInfo
+}
+
+func NewUsageCollector(
+	logger logrus.FieldLogger,
+	nodeID string,
+	ds *datastore.DataStore) *UsageCollector {
+
+	uc := &UsageCollector{
+		baseCollector: newBaseCollector(subsystemUsage, logger, nodeID, ds),
+	}
+
+	uc.sizeMetric = metricInfo{
+		Desc: prometheus.NewDesc(
+			prometheus.BuildFQName(longhornName, subsystemUsage, "size_bytes"),
+			"Configured size in bytes of this volume averaged over the whole of today, accounted up to the last sample",
+			[]string{volumeLabel, pvcLabel, pvcNamespaceLabel},
+			nil,
+		),
+		Type: prometheus.GaugeValue,
+	}
+
+	uc.actualSizeMetric = metricInfo{
+		Desc: prometheus.NewDesc(
+			prometheus.BuildFQName(longhornName, subsystemUsage, "actual_size_bytes"),
+			"Actual space in bytes used by all replicas of this volume averaged over the whole of today, accounted up to the last sample",
+			[]string{volumeLabel, pvcLabel, pvcNamespaceLabel},
+			nil,
+		),
+		Type: prometheus.GaugeValue,
+	}
+
+	uc.snapshotSizeMetric = metricInfo{
+		Desc: prometheus.NewDesc(
+			prometheus.BuildFQName(longhornName, subsystemUsage, "snapshot_size_bytes"),
+			"Actual space in bytes used by the snapshots in all replicas of this volume averaged over the whole of today, accounted up to the last sample",
+			[]string{volumeLabel, pvcLabel, pvcNamespaceLabel},
+			nil,
+		),
+		Type: prometheus.GaugeValue,
+	}
+
+	uc.backupSizeMetric = metricInfo{
+		Desc: prometheus.NewDesc(
+			prometheus.BuildFQName(longhornName, subsystemUsage, "backup_size_bytes"),
+			"Size in bytes of the backups of this volume in the backup target averaged over the whole of today, accounted up to the last sample",
+			[]string{volumeLabel, pvcLabel, pvcNamespaceLabel, backupTargetLabel},
+			nil,
+		),
+		Type: prometheus.GaugeValue,
+	}
+
+	return uc
+}
+
+func (uc *UsageCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- uc.sizeMetric.Desc
+	ch <- uc.actualSizeMetric.Desc
+	ch <- uc.snapshotSizeMetric.Desc
+	ch <- uc.backupSizeMetric.Desc
+}
+
+func (uc *UsageCollector) Collect(ch chan<- prometheus.Metric) {
+	defer func() {
+		if err := recover(); err != nil {
+			uc.logger.WithField("error", err).Warn("Panic during collecting metrics")
+		}
+	}()
+
+	usage, err := uc.ds.GetDailyUsage(types.GetUsageDate(time.Now()))
+	if err != nil {
+		if !datastore.ErrorIsNotFound(err) {
+			uc.logger.WithError(err).Warn("Error during scrape")
+		}
+		return
+	}
+
+	// Only the node taking the samples reports them, to avoid duplicate series
+	if usage.Sampler != uc.currentNodeID {
+		return
+	}
+
+	for _, volumeUsage := range usage.Volumes {
+		ch <- prometheus.MustNewConstMetric(uc.sizeMetric.Desc, uc.sizeMetric.Type, float64(volumeUsage.Size), volumeUsage.Volume, volumeUsage.PVC, volumeUsage.Namespace)
+		ch <- prometheus.MustNewConstMetric(uc.actualSizeMetric.Desc, uc.actualSizeMetric.Type, float64(volumeUsage.ActualSize), volumeUsage.Volume, volumeUsage.PVC, volumeUsage.Namespace)
+		ch <- prometheus.MustNewConstMetric(uc.snapshotSizeMetric.Desc, uc.snapshotSizeMetric.Type, float64(volumeUsage.SnapshotSize), volumeUsage.Volume, volumeUsage.PVC, volumeUsage.Namespace)
+		for backupTarget, size := range volumeUsage.BackupSize {
+			ch <- prometheus.MustNewConstMetric(uc.backupSizeMetric.Desc, uc.backupSizeMetric.Type, float64(size), volumeUsage.Volume, volumeUsage.PVC, volumeUsage.Namespace, backupTarget)
+		}
+	}
+}
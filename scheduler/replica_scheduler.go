@@ -371,8 +371,9 @@ func (rcs *ReplicaScheduler) getDiskCandidates(nodeInfo map[string]*longhorn.Nod
 		return diskCandidates, filterErrs
 	}
 
-	usedNodes, usedZones, onlyEvictingNodes, onlyEvictingZones := getCurrentNodesAndZones(replicas, nodeInfo,
-		ignoreFailedReplicas, creatingNewReplicasForReplenishment)
+	usedNodes, usedZones, onlyEvictingNodes, onlyEvictingZones, usedTopologyDomains := getCurrentNodesAndZones(replicas,
+		nodeInfo, getTopologySpreadLevels(volume), ignoreFailedReplicas, creatingNewReplicasForReplenishment)
+	excludedByTopologySpread := false
 
 	allowEmptyNodeSelectorVolume, err := rcs.ds.GetSettingAsBool(types.SettingNameAllowEmptyNodeSelectorVolume)
 	if err != nil {
@@ -405,6 +406,13 @@ func (rcs *ReplicaScheduler) getDiskCandidates(nodeInfo map[string]*longhorn.Nod
 			}
 		}
 
+		// If the Nodes violate the topology spread constraints of the volume, the constraints are hard so don't
+		// consider them at all.
+		if !isNodeSatisfyingTopologySpreadConstraints(node, volume.Spec.TopologySpreadConstraints, usedTopologyDomains) {
+			excludedByTopologySpread = true
+			continue
+		}
+
 		if _, ok := usedNodes[nodeName]; !ok {
 			unusedNodes[nodeName] = node
 		} else if replicaAutoBalance == longhorn.ReplicaAutoBalanceBestEffort {
@@ -465,6 +473,11 @@ func (rcs *ReplicaScheduler) getDiskCandidates(nodeInfo map[string]*longhorn.Nod
 		errs.AppendMultiError(filterErrs)
 	}
 
+	if excludedByTopologySpread {
+		errs.Append(longhorn.ErrorReplicaScheduleTopologySpreadNotSatisfied,
+			fmt.Errorf("nodes are excluded by topology spread constraints %+v of volume %v", volume.Spec.TopologySpreadConstraints, volume.Name))
+	}
+
 	return map[string]*Disk{}, errs
 }
 
@@ -551,10 +564,11 @@ func (rcs *ReplicaScheduler) filterNodeDisksForReplica(node *longhorn.Node, disk
 		}
 
 		// Check if the Disk's Tags are valid.
-		if !types.IsSelectorsInTags(diskSpec.Tags, volume.Spec.DiskSelector, allowEmptyDiskSelectorVolume) {
+		diskSelector := types.GetVolumeDiskSelector(volume)
+		if !types.IsSelectorsInTags(diskSpec.Tags, diskSelector, allowEmptyDiskSelectorVolume) {
 			errs.Append(longhorn.ErrorReplicaScheduleTagsNotFulfilled,
 				fmt.Errorf("disk %v on node %v does not match the disk selector %v for volume %v",
-					diskName, node.Name, volume.Spec.DiskSelector, volume.Name))
+					diskName, node.Name, diskSelector, volume.Name))
 			continue
 		}
 
@@ -767,6 +781,13 @@ func (rcs *ReplicaScheduler) CheckAndReuseFailedReplica(replicas map[string]*lon
 				reusedReplica = r
 				continue
 			}
+			// The replicas moved to a replacement disk are rebuilt first
+			if isReplacement := datastore.IsReplacementDiskRebuildReplica(r); isReplacement != datastore.IsReplacementDiskRebuildReplica(reusedReplica) {
+				if isReplacement {
+					reusedReplica = r
+				}
+				continue
+			}
 			reusedReplica = GetLatestFailedReplica(reusedReplica, r)
 		}
 	}
@@ -890,7 +911,7 @@ func (rcs *ReplicaScheduler) isFailedReplicaReusable(r *longhorn.Replica, v *lon
 			if !diskSpec.AllowScheduling || diskSpec.EvictionRequested {
 				return false, nil
 			}
-			if !types.IsSelectorsInTags(diskSpec.Tags, v.Spec.DiskSelector, allowEmptyDiskSelectorVolume) {
+			if !types.IsSelectorsInTags(diskSpec.Tags, types.GetVolumeDiskSelector(v), allowEmptyDiskSelectorVolume) {
 				return false, nil
 			}
 		}
@@ -1150,13 +1171,19 @@ func findDiskSpecAndDiskStatusInNode(diskUUID string, node *longhorn.Node) (long
 //   - Otherwise, getCurrentNodesAndZones does not consider a node or zone to be occupied by a failed replica that can
 //     no longer be used or is likely actively being replaced. This makes nodes and zones with useless replicas
 //     available for scheduling.
+//   - It also returns the number of replicas in the domains of the topology levels, keyed by the level and then the
+//     domain. Evicting replicas are leaving their domains, so they are not counted.
 func getCurrentNodesAndZones(replicas map[string]*longhorn.Replica, nodeInfo map[string]*longhorn.Node,
-	ignoreFailedReplicas, creatingNewReplicasForReplenishment bool) (map[string]*longhorn.Node,
-	map[string]bool, map[string]bool, map[string]bool) {
+	topologyLevels []string, ignoreFailedReplicas, creatingNewReplicasForReplenishment bool) (map[string]*longhorn.Node,
+	map[string]bool, map[string]bool, map[string]bool, map[string]map[string]int) {
 	usedNodes := map[string]*longhorn.Node{}
 	usedZones := map[string]bool{}
 	onlyEvictingNodes := map[string]bool{}
 	onlyEvictingZones := map[string]bool{}
+	usedDomains := map[string]map[string]int{}
+	for _, level := range topologyLevels {
+		usedDomains[level] = map[string]int{}
+	}
 
 	for _, r := range replicas {
 		if r.Spec.NodeID == "" {
@@ -1193,6 +1220,10 @@ func getCurrentNodesAndZones(replicas map[string]*longhorn.Replica, nodeInfo map
 				// There is now at least one replica on this node and in this zone that is not evicting.
 				onlyEvictingNodes[node.Name] = false
 				onlyEvictingZones[node.Status.Zone] = false
+				for _, level := range topologyLevels {
+					// For empty domain label, we treat them as one domain.
+					usedDomains[level][types.GetNodeTopologyDomain(node, level)]++
+				}
 			}
 
 			usedNodes[node.Name] = node
@@ -1201,7 +1232,35 @@ func getCurrentNodesAndZones(replicas map[string]*longhorn.Replica, nodeInfo map
 		}
 	}
 
-	return usedNodes, usedZones, onlyEvictingNodes, onlyEvictingZones
+	return usedNodes, usedZones, onlyEvictingNodes, onlyEvictingZones, usedDomains
+}
+
+// getTopologySpreadLevels returns the topology levels used by the topology spread constraints of the volume.
+func getTopologySpreadLevels(volume *longhorn.Volume) []string {
+	levels := []string{}
+	for _, constraint := range volume.Spec.TopologySpreadConstraints {
+		levels = append(levels, constraint.TopologyLevel)
+	}
+	return levels
+}
+
+// isNodeSatisfyingTopologySpreadConstraints returns true if a new replica can be scheduled to the node without
+// violating the topology spread constraints:
+//   - The domain of the node has fewer replicas than MaxReplicasPerDomain.
+//   - If the replicas are not spread across MinDomains domains yet, the domain of the node has no replica.
+func isNodeSatisfyingTopologySpreadConstraints(node *longhorn.Node, constraints []longhorn.TopologySpreadConstraint,
+	usedDomains map[string]map[string]int) bool {
+	for _, constraint := range constraints {
+		domains := usedDomains[constraint.TopologyLevel]
+		count := domains[types.GetNodeTopologyDomain(node, constraint.TopologyLevel)]
+		if constraint.MaxReplicasPerDomain > 0 && count >= constraint.MaxReplicasPerDomain {
+			return false
+		}
+		if constraint.MinDomains > 0 && len(domains) < constraint.MinDomains && count > 0 {
+			return false
+		}
+	}
+	return true
 }
 
 // timeToReplacementReplica returns the amount of time until Longhorn should create a new replica for a degraded volume,
@@ -1608,8 +1608,8 @@ func (s *TestSuite) TestGetCurrentNodesAndZones(c *C) {
 
 	for name, tc := range testCases {
 		fmt.Printf("testing %v\n", name)
-		usedNodes, usedZones, onlyEvictingNodes, onlyEvictingZones := getCurrentNodesAndZones(tc.replicas, tc.nodeInfo,
-			false, false)
+		usedNodes, usedZones, onlyEvictingNodes, onlyEvictingZones, _ := getCurrentNodesAndZones(tc.replicas, tc.nodeInfo,
+			nil, false, false)
 		verifyNodeNames(tc.expectUsedNodeNames, usedNodes)
 		verifyZoneNames(tc.expectUsedZoneNames, usedZones)
 		verifyOnlyEvictingNodeNames(tc.expectOnlyEvictingNodeNames, onlyEvictingNodes)
@@ -1617,6 +1617,108 @@ func (s *TestSuite) TestGetCurrentNodesAndZones(c *C) {
 	}
 }
 
+func (s *TestSuite) TestTopologySpreadConstraints(c *C) {
+	const (
+		TestReplica1 = "test-replica-1"
+		TestReplica2 = "test-replica-2"
+		TestNode4    = "test-node-name-4"
+		TestRack1    = "test-rack-1"
+		TestRack2    = "test-rack-2"
+	)
+
+	generateNode := func(nodeName, zoneName, rackName string) *longhorn.Node {
+		return &longhorn.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: nodeName,
+			},
+			Status: longhorn.NodeStatus{
+				Zone: zoneName,
+				Topology: map[string]string{
+					"rack": rackName,
+				},
+			},
+		}
+	}
+
+	generateReplica := func(replicaName, nodeName string, evictionRequested bool) *longhorn.Replica {
+		return &longhorn.Replica{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: replicaName,
+			},
+			Spec: longhorn.ReplicaSpec{
+				InstanceSpec: longhorn.InstanceSpec{
+					NodeID: nodeName,
+				},
+				EvictionRequested: evictionRequested,
+			},
+		}
+	}
+
+	nodeInfo := map[string]*longhorn.Node{
+		TestNode1: generateNode(TestNode1, TestZone1, TestRack1),
+		TestNode2: generateNode(TestNode2, TestZone1, TestRack2),
+		TestNode3: generateNode(TestNode3, TestZone2, TestRack1),
+		TestNode4: generateNode(TestNode4, TestZone2, ""),
+	}
+
+	// Max 1 replica per rack, at least 2 zones
+	constraints := []longhorn.TopologySpreadConstraint{
+		{TopologyLevel: "rack", MaxReplicasPerDomain: 1},
+		{TopologyLevel: types.TopologyLevelZone, MinDomains: 2},
+	}
+
+	testCases := map[string]struct {
+		replicas            map[string]*longhorn.Replica
+		expectedSchedulable map[string]bool
+	}{
+		"no replica": {
+			replicas: map[string]*longhorn.Replica{},
+			expectedSchedulable: map[string]bool{
+				TestNode1: true, TestNode2: true, TestNode3: true, TestNode4: true,
+			},
+		},
+		"one replica in zone 1 and rack 1": {
+			replicas: map[string]*longhorn.Replica{
+				TestReplica1: generateReplica(TestReplica1, TestNode1, false),
+			},
+			expectedSchedulable: map[string]bool{
+				TestNode1: false, TestNode2: false, TestNode3: false, TestNode4: true,
+			},
+		},
+		"replicas in two zones": {
+			replicas: map[string]*longhorn.Replica{
+				TestReplica1: generateReplica(TestReplica1, TestNode1, false),
+				TestReplica2: generateReplica(TestReplica2, TestNode4, false),
+			},
+			expectedSchedulable: map[string]bool{
+				TestNode1: false, TestNode2: true, TestNode3: false, TestNode4: false,
+			},
+		},
+		"evicting replica does not occupy its domains": {
+			replicas: map[string]*longhorn.Replica{
+				TestReplica1: generateReplica(TestReplica1, TestNode1, true),
+			},
+			expectedSchedulable: map[string]bool{
+				TestNode1: true, TestNode2: true, TestNode3: true, TestNode4: true,
+			},
+		},
+	}
+
+	volume := &longhorn.Volume{
+		Spec: longhorn.VolumeSpec{
+			TopologySpreadConstraints: constraints,
+		},
+	}
+	for name, tc := range testCases {
+		fmt.Printf("testing %v\n", name)
+		_, _, _, _, usedDomains := getCurrentNodesAndZones(tc.replicas, nodeInfo, getTopologySpreadLevels(volume), false, false)
+		for nodeName, expected := range tc.expectedSchedulable {
+			schedulable := isNodeSatisfyingTopologySpreadConstraints(nodeInfo[nodeName], constraints, usedDomains)
+			c.Assert(schedulable, Equals, expected, Commentf("test case %v, node %v", name, nodeName))
+		}
+	}
+}
+
 func (s *TestSuite) TestIsSchedulableToDiskConsiderDiskPressure(c *C) {
 	diskPressurePercentage := int64(80)
 	diskUUID := "disk-1"
@@ -0,0 +1,109 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// HeaderTraceParent is the W3C trace context header of the HTTP requests.
+	HeaderTraceParent = "traceparent"
+
+	// AnnotationTraceParent carries the W3C trace context of the span that last changed a Longhorn resource, so that
+	// the controllers reconciling the change continue the trace. The W3C tracestate is carried by
+	// longhorn.io/tracestate if there is one.
+	AnnotationTraceParent = annotationPrefix + HeaderTraceParent
+	// AnnotationTracedGeneration is the generation of the resource changed by the traced span. The controllers only
+	// continue the trace while reconciling this generation, so that the reconciliations of a later untraced change
+	// don't extend the trace.
+	AnnotationTracedGeneration = "longhorn.io/traced-generation"
+
+	annotationPrefix = "longhorn.io/"
+)
+
+var propagator = propagation.TraceContext{}
+
+// annotationCarrier carries the W3C trace context in the annotations of a resource.
+type annotationCarrier map[string]string
+
+func (c annotationCarrier) Get(key string) string {
+	return c[annotationPrefix+key]
+}
+
+func (c annotationCarrier) Set(key, value string) {
+	c[annotationPrefix+key] = value
+}
+
+func (c annotationCarrier) Keys() []string {
+	keys := []string{}
+	for key := range c {
+		if name, ok := strings.CutPrefix(key, annotationPrefix); ok {
+			keys = append(keys, name)
+		}
+	}
+	return keys
+}
+
+// ExtractHTTP returns the context carrying the remote span context of the traceparent header of the request, if
+// there is a valid one.
+func ExtractHTTP(ctx context.Context, header http.Header) context.Context {
+	return propagator.Extract(ctx, propagation.HeaderCarrier(header))
+}
+
+// InjectAnnotations sets the trace context annotations of the object to the span of the context. It must be called
+// right before updating the spec of the object, whose next generation is the traced one. It does nothing if the
+// context has no recording span.
+func InjectAnnotations(ctx context.Context, obj metav1.Object) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() || !span.SpanContext().IsValid() {
+		return
+	}
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	for _, key := range propagator.Fields() {
+		delete(annotations, annotationPrefix+key)
+	}
+	propagator.Inject(ctx, annotationCarrier(annotations))
+	annotations[AnnotationTracedGeneration] = strconv.FormatInt(obj.GetGeneration()+1, 10)
+	obj.SetAnnotations(annotations)
+}
+
+// ExtractAnnotations returns the context carrying the remote span context of the trace context annotations of the
+// object, unless they are missing or invalid, or the object is no longer at the traced generation.
+func ExtractAnnotations(ctx context.Context, obj metav1.Object) context.Context {
+	annotations := obj.GetAnnotations()
+	if annotations[AnnotationTracedGeneration] != strconv.FormatInt(obj.GetGeneration(), 10) {
+		return ctx
+	}
+	return propagator.Extract(ctx, annotationCarrier(annotations))
+}
+
+// StartSpanForObject starts a span continuing the trace of the trace context annotations of the object. The span
+// doesn't record anything if the object is not traced, so that the controllers only trace the reconciliations of the
+// changes made by the traced requests.
+func StartSpanForObject(obj metav1.Object, tracerName, spanName string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	ctx := ExtractAnnotations(context.Background(), obj)
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+	return Tracer(tracerName).Start(ctx, spanName, opts...)
+}
+
+// StartChildSpan starts a span as the child of the recording span of the context. The span doesn't record anything
+// if there is no such parent, so that an untraced operation doesn't start a new trace.
+func StartChildSpan(ctx context.Context, tracerName, spanName string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	if !trace.SpanFromContext(ctx).IsRecording() {
+		return ctx, trace.SpanFromContext(context.Background())
+	}
+	return Tracer(tracerName).Start(ctx, spanName, opts...)
+}
@@ -0,0 +1,211 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+
+	"github.com/longhorn/longhorn-manager/meta"
+)
+
+const (
+	ServiceName = "longhorn-manager"
+
+	otlpTracesPath = "/v1/traces"
+
+	// configSyncPeriod is how often the tracing settings are looked up, so that a change takes effect without
+	// restarting the manager.
+	configSyncPeriod = 30 * time.Second
+	shutdownTimeout  = 10 * time.Second
+
+	// attributeReconcileUnchanged marks the span of a reconciliation that didn't change the state, which is dropped.
+	attributeReconcileUnchanged = attribute.Key("longhorn.reconcile.unchanged")
+)
+
+var defaultProvider = &provider{}
+
+// Init sets up the provider of the tracers returned by Tracer. The spans are exported by the OpenTelemetry SDK to the
+// OTLP/HTTP endpoint returned by getEndpoint, and the new traces are sampled by the ratio returned by
+// getSamplingRatio. The settings are looked up periodically until the stop channel is closed, and tracing is disabled
+// while the endpoint is empty.
+func Init(nodeID string, getEndpoint func() (string, error), getSamplingRatio func() (float64, error), stopCh <-chan struct{}) {
+	res, err := newResource(nodeID)
+	if err != nil {
+		logrus.WithError(err).Warn("Failed to detect some resource attributes of the trace spans")
+	}
+	p := &provider{resource: res}
+	defaultProvider = p
+
+	go func() {
+		wait.Until(func() {
+			p.sync(getEndpoint, getSamplingRatio)
+		}, configSyncPeriod, stopCh)
+		p.shutdown()
+	}()
+}
+
+// Tracer returns the tracer of the instrumentation scope from the provider set up by Init. The tracer doesn't record
+// anything if tracing is disabled.
+func Tracer(name string) trace.Tracer {
+	return defaultProvider.tracer(name)
+}
+
+// newResource returns the resource of the spans of the manager. The standard OTEL_RESOURCE_ATTRIBUTES and
+// OTEL_SERVICE_NAME environment variables override the detected attributes.
+func newResource(nodeID string) (*resource.Resource, error) {
+	return resource.New(context.Background(),
+		resource.WithSchemaURL(semconv.SchemaURL),
+		resource.WithAttributes(
+			semconv.ServiceName(ServiceName),
+			semconv.ServiceVersion(meta.Version),
+			semconv.ServiceInstanceID(nodeID),
+			semconv.K8SNodeName(nodeID),
+		),
+		resource.WithTelemetrySDK(),
+		resource.WithFromEnv(),
+	)
+}
+
+type config struct {
+	endpoint      string
+	samplingRatio float64
+}
+
+// provider holds the SDK tracer provider of the current tracing settings, which is replaced when the settings change.
+type provider struct {
+	resource *resource.Resource
+
+	lock        sync.RWMutex
+	config      config
+	sdkProvider *sdktrace.TracerProvider
+}
+
+func (p *provider) tracer(name string) trace.Tracer {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	if p.sdkProvider == nil {
+		return noop.NewTracerProvider().Tracer(name)
+	}
+	return p.sdkProvider.Tracer(name)
+}
+
+func (p *provider) sync(getEndpoint func() (string, error), getSamplingRatio func() (float64, error)) {
+	endpoint, err := getEndpoint()
+	if err != nil {
+		logrus.WithError(err).Warn("Failed to get the tracing OTLP endpoint")
+		return
+	}
+	samplingRatio, err := getSamplingRatio()
+	if err != nil {
+		logrus.WithError(err).Warn("Failed to get the tracing sampling ratio")
+		return
+	}
+	if err := p.configure(config{endpoint: endpoint, samplingRatio: samplingRatio}); err != nil {
+		logrus.WithError(err).Warnf("Failed to set up the export of the trace spans to %v", endpoint)
+	}
+}
+
+// configure replaces the SDK tracer provider if the settings changed. The spans of the replaced provider are flushed.
+func (p *provider) configure(c config) error {
+	p.lock.Lock()
+	if c == p.config {
+		p.lock.Unlock()
+		return nil
+	}
+
+	var sdkProvider *sdktrace.TracerProvider
+	if c.endpoint != "" {
+		exporter, err := otlptracehttp.New(context.Background(),
+			otlptracehttp.WithEndpointURL(strings.TrimSuffix(c.endpoint, "/")+otlpTracesPath))
+		if err != nil {
+			p.lock.Unlock()
+			return err
+		}
+		sdkProvider = newSDKProvider(sdktrace.NewBatchSpanProcessor(exporter), c.samplingRatio, p.resource)
+	}
+
+	previous := p.sdkProvider
+	p.config, p.sdkProvider = c, sdkProvider
+	p.lock.Unlock()
+
+	shutdownSDKProvider(previous)
+	return nil
+}
+
+func (p *provider) shutdown() {
+	p.lock.Lock()
+	previous := p.sdkProvider
+	p.config, p.sdkProvider = config{}, nil
+	p.lock.Unlock()
+
+	shutdownSDKProvider(previous)
+}
+
+// newSDKProvider returns the SDK tracer provider passing the spans to the processor. The traces continuing a remote
+// span follow its sampling decision, while the new traces are sampled by the ratio.
+func newSDKProvider(processor sdktrace.SpanProcessor, samplingRatio float64, res *resource.Resource) *sdktrace.TracerProvider {
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(samplingRatio))),
+		sdktrace.WithResource(res),
+		sdktrace.WithSpanProcessor(&reconcileFilter{SpanProcessor: processor}),
+	)
+}
+
+func shutdownSDKProvider(sdkProvider *sdktrace.TracerProvider) {
+	if sdkProvider == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := sdkProvider.Shutdown(ctx); err != nil {
+		logrus.WithError(err).Warn("Failed to flush the trace spans")
+	}
+}
+
+// reconcileFilter drops the spans of the reconciliations that didn't change the state, so that the periodic resyncs
+// of a traced resource don't flood the trace.
+type reconcileFilter struct {
+	sdktrace.SpanProcessor
+}
+
+func (f *reconcileFilter) OnEnd(s sdktrace.ReadOnlySpan) {
+	for _, kv := range s.Attributes() {
+		if kv.Key == attributeReconcileUnchanged && kv.Value.AsBool() {
+			return
+		}
+	}
+	f.SpanProcessor.OnEnd(s)
+}
+
+// EndSpan records the error in the span if there is one, and ends the span.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// EndReconcileSpan ends the span of a reconciliation like EndSpan. The span is dropped if the reconciliation neither
+// changed the state nor failed.
+func EndReconcileSpan(span trace.Span, changed bool, err error) {
+	if !changed && err == nil {
+		span.SetAttributes(attributeReconcileUnchanged.Bool(true))
+	}
+	EndSpan(span, err)
+}
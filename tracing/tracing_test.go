@@ -0,0 +1,266 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+)
+
+func newTestProvider(samplingRatio float64) (*provider, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	res, _ := newResource("node-1")
+	p := &provider{
+		resource:    res,
+		config:      config{endpoint: "test", samplingRatio: samplingRatio},
+		sdkProvider: newSDKProvider(sdktrace.NewSimpleSpanProcessor(exporter), samplingRatio, res),
+	}
+	return p, exporter
+}
+
+func TestAnnotations(t *testing.T) {
+	assert := require.New(t)
+
+	p, _ := newTestProvider(1)
+	ctx, span := p.tracer("test").Start(context.Background(), "parent")
+	defer span.End()
+
+	obj := &metav1.ObjectMeta{Name: "vol", Generation: 3}
+	InjectAnnotations(ctx, obj)
+	assert.NotEmpty(obj.Annotations[AnnotationTraceParent])
+	assert.Equal("4", obj.Annotations[AnnotationTracedGeneration])
+
+	// The trace isn't continued before the spec update bumps the generation
+	assert.False(trace.SpanContextFromContext(ExtractAnnotations(context.Background(), obj)).IsValid())
+
+	obj.Generation = 4
+	extracted := trace.SpanContextFromContext(ExtractAnnotations(context.Background(), obj))
+	assert.Equal(span.SpanContext().TraceID(), extracted.TraceID())
+	assert.Equal(span.SpanContext().SpanID(), extracted.SpanID())
+	assert.True(extracted.IsRemote())
+	assert.True(extracted.IsSampled())
+
+	// A later untraced change of the spec doesn't continue the trace
+	obj.Generation = 5
+	assert.False(trace.SpanContextFromContext(ExtractAnnotations(context.Background(), obj)).IsValid())
+
+	// An untraced context doesn't change the annotations
+	untraced := &metav1.ObjectMeta{Name: "vol"}
+	InjectAnnotations(context.Background(), untraced)
+	assert.Empty(untraced.Annotations)
+}
+
+func TestExtractHTTP(t *testing.T) {
+	assert := require.New(t)
+
+	header := http.Header{}
+	header.Set(HeaderTraceParent, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	sc := trace.SpanContextFromContext(ExtractHTTP(context.Background(), header))
+	assert.True(sc.IsValid())
+	assert.True(sc.IsRemote())
+	assert.True(sc.IsSampled())
+	assert.Equal("4bf92f3577b34da6a3ce929d0e0e4736", sc.TraceID().String())
+	assert.Equal("00f067aa0ba902b7", sc.SpanID().String())
+
+	for _, invalid := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+	} {
+		header := http.Header{}
+		header.Set(HeaderTraceParent, invalid)
+		assert.False(trace.SpanContextFromContext(ExtractHTTP(context.Background(), header)).IsValid(), invalid)
+	}
+}
+
+func TestTracerDisabled(t *testing.T) {
+	assert := require.New(t)
+
+	p := &provider{}
+	parentCtx := trace.ContextWithRemoteSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1}, SpanID: trace.SpanID{1}, TraceFlags: trace.FlagsSampled,
+	}))
+	_, span := p.tracer("test").Start(parentCtx, "disabled")
+	assert.False(span.IsRecording())
+	span.End()
+}
+
+func TestSpans(t *testing.T) {
+	assert := require.New(t)
+
+	p, exporter := newTestProvider(1)
+	tracer := p.tracer("test")
+	ctx, parent := tracer.Start(context.Background(), "parent", trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attribute.String("longhorn.volume", "vol")))
+	_, child := tracer.Start(ctx, "child")
+	EndSpan(child, errors.New("failed"))
+	parent.End()
+
+	spans := exporter.GetSpans()
+	assert.Len(spans, 2)
+	childSpan, parentSpan := spans[0], spans[1]
+	assert.Equal("child", childSpan.Name)
+	assert.Equal(parentSpan.SpanContext.TraceID(), childSpan.SpanContext.TraceID())
+	assert.Equal(parentSpan.SpanContext.SpanID(), childSpan.Parent.SpanID())
+	assert.Equal(codes.Error, childSpan.Status.Code)
+	assert.Equal("failed", childSpan.Status.Description)
+	assert.Len(childSpan.Events, 1)
+	assert.Equal("exception", childSpan.Events[0].Name)
+	assert.Equal("test", childSpan.InstrumentationScope.Name)
+
+	assert.Equal("parent", parentSpan.Name)
+	assert.False(parentSpan.Parent.IsValid())
+	assert.Equal(trace.SpanKindServer, parentSpan.SpanKind)
+	assert.Equal(codes.Unset, parentSpan.Status.Code)
+
+	attributes := attribute.NewSet(parentSpan.Resource.Attributes()...)
+	for key, value := range map[attribute.Key]string{
+		semconv.ServiceNameKey:       ServiceName,
+		semconv.ServiceInstanceIDKey: "node-1",
+		semconv.K8SNodeNameKey:       "node-1",
+		semconv.TelemetrySDKNameKey:  "opentelemetry",
+	} {
+		v, ok := attributes.Value(key)
+		assert.True(ok, key)
+		assert.Equal(value, v.AsString(), key)
+	}
+}
+
+func TestResourceFromEnv(t *testing.T) {
+	assert := require.New(t)
+
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "k8s.cluster.name=cluster-1")
+	t.Setenv("OTEL_SERVICE_NAME", "longhorn-manager-test")
+	res, err := newResource("node-1")
+	assert.NoError(err)
+
+	attributes := res.Set()
+	v, _ := attributes.Value(semconv.K8SClusterNameKey)
+	assert.Equal("cluster-1", v.AsString())
+	v, _ = attributes.Value(semconv.ServiceNameKey)
+	assert.Equal("longhorn-manager-test", v.AsString())
+}
+
+func TestSampling(t *testing.T) {
+	assert := require.New(t)
+
+	p, exporter := newTestProvider(0)
+	tracer := p.tracer("test")
+
+	// A new trace isn't sampled with a zero ratio
+	_, span := tracer.Start(context.Background(), "new")
+	assert.False(span.IsRecording())
+	span.End()
+
+	// A trace continuing a sampled remote span follows its decision
+	sampledCtx := trace.ContextWithRemoteSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1}, SpanID: trace.SpanID{1}, TraceFlags: trace.FlagsSampled,
+	}))
+	_, span = tracer.Start(sampledCtx, "continued")
+	assert.True(span.IsRecording())
+	span.End()
+
+	spans := exporter.GetSpans()
+	assert.Len(spans, 1)
+	assert.Equal("continued", spans[0].Name)
+}
+
+func TestEndReconcileSpan(t *testing.T) {
+	assert := require.New(t)
+
+	p, exporter := newTestProvider(1)
+	tracer := p.tracer("test")
+
+	_, unchanged := tracer.Start(context.Background(), "unchanged")
+	EndReconcileSpan(unchanged, false, nil)
+	_, changed := tracer.Start(context.Background(), "changed")
+	EndReconcileSpan(changed, true, nil)
+	_, failed := tracer.Start(context.Background(), "failed")
+	EndReconcileSpan(failed, false, errors.New("failed"))
+
+	spans := exporter.GetSpans()
+	assert.Len(spans, 2)
+	assert.Equal("changed", spans[0].Name)
+	assert.Equal("failed", spans[1].Name)
+}
+
+func TestStartSpanForObject(t *testing.T) {
+	assert := require.New(t)
+
+	p, exporter := newTestProvider(1)
+	defaultProvider = p
+	defer func() {
+		defaultProvider = &provider{}
+	}()
+
+	// An untraced object doesn't start a new trace
+	_, span := StartSpanForObject(&metav1.ObjectMeta{Name: "vol"}, "test", "untraced")
+	assert.False(span.IsRecording())
+	ctx, span := StartChildSpan(context.Background(), "test", "untraced-child")
+	assert.False(span.IsRecording())
+	assert.Equal(context.Background(), ctx)
+
+	ctx, parent := Tracer("test").Start(context.Background(), "request")
+	obj := &metav1.ObjectMeta{Name: "vol"}
+	InjectAnnotations(ctx, obj)
+	obj.Generation = 1
+	parent.End()
+
+	ctx, span = StartSpanForObject(obj, "test", "reconcile")
+	assert.True(span.IsRecording())
+	_, child := StartChildSpan(ctx, "test", "call")
+	assert.True(child.IsRecording())
+	child.End()
+	span.End()
+
+	spans := exporter.GetSpans()
+	assert.Len(spans, 3)
+	assert.Equal(spans[0].SpanContext.TraceID(), spans[2].SpanContext.TraceID())
+	assert.Equal(spans[0].SpanContext.SpanID(), spans[2].Parent.SpanID())
+	assert.Equal(spans[2].SpanContext.SpanID(), spans[1].Parent.SpanID())
+}
+
+func TestExportOTLP(t *testing.T) {
+	assert := require.New(t)
+
+	var lock sync.Mutex
+	paths := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		paths = append(paths, r.URL.Path)
+	}))
+	defer server.Close()
+
+	p := &provider{resource: resource.Default()}
+	assert.NoError(p.configure(config{endpoint: server.URL + "/", samplingRatio: 1}))
+	_, span := p.tracer("test").Start(context.Background(), "exported")
+	assert.True(span.IsRecording())
+	span.End()
+
+	// Shutting down the provider flushes the spans
+	p.shutdown()
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Equal([]string{otlpTracesPath}, paths)
+
+	_, span = p.tracer("test").Start(context.Background(), "disabled")
+	assert.False(span.IsRecording())
+}
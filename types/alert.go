@@ -0,0 +1,266 @@
+package types
+
+import (
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"path"
+	"slices"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+const (
+	AlertNameVolumeDegraded          = "VolumeDegraded"
+	AlertNameVolumeFaulted           = "VolumeFaulted"
+	AlertNameTooManySnapshots        = "TooManySnapshots"
+	AlertNameNodeDown                = "NodeDown"
+	AlertNameDiskPressure            = "DiskPressure"
+	AlertNameDiskCapacityAtRisk      = "DiskCapacityAtRisk"
+	AlertNameBackupFailed            = "BackupFailed"
+	AlertNameBackupTargetUnavailable = "BackupTargetUnavailable"
+	AlertNameBackupTargetDegraded    = "BackupTargetDegraded"
+
+	AlertSeverityWarning  = "warning"
+	AlertSeverityCritical = "critical"
+
+	AlertStatusFiring   = "firing"
+	AlertStatusResolved = "resolved"
+
+	AlertKindVolume       = "Volume"
+	AlertKindNode         = "Node"
+	AlertKindDisk         = "Disk"
+	AlertKindBackup       = "Backup"
+	AlertKindBackupTarget = "BackupTarget"
+
+	// AlertSinkCredentialSMTPUsername and AlertSinkCredentialSMTPPassword are the keys of the credential secret of
+	// an smtp alert sink.
+	AlertSinkCredentialSMTPUsername = "SMTP_USERNAME"
+	AlertSinkCredentialSMTPPassword = "SMTP_PASSWORD"
+	// AlertSinkCredentialWebhookAuthorization is the key of the credential secret of a webhook alert sink, whose
+	// value is sent as the Authorization header.
+	AlertSinkCredentialWebhookAuthorization = "WEBHOOK_AUTHORIZATION"
+)
+
+// AlertNames are the names of the alerts Longhorn evaluates.
+var AlertNames = []string{
+	AlertNameVolumeDegraded,
+	AlertNameVolumeFaulted,
+	AlertNameTooManySnapshots,
+	AlertNameNodeDown,
+	AlertNameDiskPressure,
+	AlertNameDiskCapacityAtRisk,
+	AlertNameBackupFailed,
+	AlertNameBackupTargetUnavailable,
+	AlertNameBackupTargetDegraded,
+}
+
+// Alert is a problem of a resource derived from the conditions the controllers compute.
+type Alert struct {
+	Name     string `json:"name"`
+	Severity string `json:"severity"`
+	Status   string `json:"status"`
+	Kind     string `json:"kind"`
+	// Resource is the name of the alerting resource. It is in the form of <node>/<disk> for a disk.
+	Resource string `json:"resourceName"`
+	Node     string `json:"node,omitempty"`
+	Message  string `json:"message"`
+	// StartsAt is the time that the alert started firing, if it's known from the condition.
+	StartsAt string `json:"startsAt,omitempty"`
+	// EndsAt is the time that the alert was resolved.
+	EndsAt   string `json:"endsAt,omitempty"`
+	Silenced bool   `json:"silenced"`
+
+	// Object is the Longhorn object the alert is derived from, which the Kubernetes event of the alert is recorded for.
+	Object runtime.Object `json:"-"`
+}
+
+// Fingerprint identifies the alert of a resource across the evaluations.
+func (a *Alert) Fingerprint() string {
+	return a.Name + "/" + a.Resource
+}
+
+func newAlert(name, severity, kind, resource, node, message, startsAt string, object runtime.Object) *Alert {
+	return &Alert{
+		Name:     name,
+		Severity: severity,
+		Status:   AlertStatusFiring,
+		Kind:     kind,
+		Resource: resource,
+		Node:     node,
+		Message:  message,
+		StartsAt: startsAt,
+		Object:   object,
+	}
+}
+
+func ValidateAlertNames(names []string) error {
+	for _, name := range names {
+		if !slices.Contains(AlertNames, name) {
+			return fmt.Errorf("invalid alert name %v, must be one of %v", name, AlertNames)
+		}
+	}
+	return nil
+}
+
+// GetVolumeAlerts returns the alerts of the robustness and the snapshot count of the volume.
+func GetVolumeAlerts(v *longhorn.Volume) []*Alert {
+	if v.DeletionTimestamp != nil {
+		return nil
+	}
+
+	alerts := []*Alert{}
+	switch v.Status.Robustness {
+	case longhorn.VolumeRobustnessDegraded:
+		alerts = append(alerts, newAlert(AlertNameVolumeDegraded, AlertSeverityWarning, AlertKindVolume, v.Name, v.Status.OwnerID,
+			fmt.Sprintf("volume %v is degraded", v.Name), "", v))
+	case longhorn.VolumeRobustnessFaulted:
+		alerts = append(alerts, newAlert(AlertNameVolumeFaulted, AlertSeverityCritical, AlertKindVolume, v.Name, v.Status.OwnerID,
+			fmt.Sprintf("volume %v is faulted", v.Name), "", v))
+	}
+	if condition := GetCondition(v.Status.Conditions, longhorn.VolumeConditionTypeTooManySnapshots); condition.Status == longhorn.ConditionStatusTrue {
+		alerts = append(alerts, newAlert(AlertNameTooManySnapshots, AlertSeverityWarning, AlertKindVolume, v.Name, v.Status.OwnerID,
+			condition.Message, condition.LastTransitionTime, v))
+	}
+	return alerts
+}
+
+// GetNodeAlerts returns the alerts of the readiness and the capacity of the node, and the pressure of its disks.
+func GetNodeAlerts(node *longhorn.Node) []*Alert {
+	if node.DeletionTimestamp != nil {
+		return nil
+	}
+
+	alerts := []*Alert{}
+	if condition := GetCondition(node.Status.Conditions, longhorn.NodeConditionTypeReady); condition.Status == longhorn.ConditionStatusFalse {
+		alerts = append(alerts, newAlert(AlertNameNodeDown, AlertSeverityCritical, AlertKindNode, node.Name, node.Name,
+			fmt.Sprintf("node %v is down: %v", node.Name, condition.Message), condition.LastTransitionTime, node))
+	}
+	if condition := GetCondition(node.Status.Conditions, longhorn.NodeConditionTypeCapacityAtRisk); condition.Status == longhorn.ConditionStatusTrue {
+		alerts = append(alerts, newAlert(AlertNameDiskCapacityAtRisk, AlertSeverityWarning, AlertKindNode, node.Name, node.Name,
+			condition.Message, condition.LastTransitionTime, node))
+	}
+
+	diskNames := []string{}
+	for diskName := range node.Status.DiskStatus {
+		diskNames = append(diskNames, diskName)
+	}
+	slices.Sort(diskNames)
+	for _, diskName := range diskNames {
+		diskStatus := node.Status.DiskStatus[diskName]
+		if diskStatus == nil {
+			continue
+		}
+		condition := GetCondition(diskStatus.Conditions, longhorn.DiskConditionTypeSchedulable)
+		if condition.Status == longhorn.ConditionStatusFalse && condition.Reason == longhorn.DiskConditionReasonDiskPressure {
+			alerts = append(alerts, newAlert(AlertNameDiskPressure, AlertSeverityWarning, AlertKindDisk, node.Name+"/"+diskName, node.Name,
+				condition.Message, condition.LastTransitionTime, node))
+		}
+	}
+	return alerts
+}
+
+// GetBackupAlerts returns the alert of the failed backup.
+func GetBackupAlerts(backup *longhorn.Backup) []*Alert {
+	if backup.DeletionTimestamp != nil || backup.Status.State != longhorn.BackupStateError {
+		return nil
+	}
+	return []*Alert{
+		newAlert(AlertNameBackupFailed, AlertSeverityWarning, AlertKindBackup, backup.Name, backup.Status.OwnerID,
+			fmt.Sprintf("backup %v of volume %v failed: %v", backup.Name, backup.Status.VolumeName, backup.Status.Error), "", backup),
+	}
+}
+
+// GetBackupTargetAlerts returns the alerts of the availability and the health of the backup target.
+func GetBackupTargetAlerts(backupTarget *longhorn.BackupTarget) []*Alert {
+	if backupTarget.DeletionTimestamp != nil || backupTarget.Spec.BackupTargetURL == "" {
+		return nil
+	}
+
+	alerts := []*Alert{}
+	if condition := GetCondition(backupTarget.Status.Conditions, longhorn.BackupTargetConditionTypeUnavailable); condition.Status == longhorn.ConditionStatusTrue {
+		alerts = append(alerts, newAlert(AlertNameBackupTargetUnavailable, AlertSeverityCritical, AlertKindBackupTarget, backupTarget.Name,
+			backupTarget.Status.OwnerID, condition.Message, condition.LastTransitionTime, backupTarget))
+	} else if condition := GetCondition(backupTarget.Status.Conditions, longhorn.BackupTargetConditionTypeDegraded); condition.Status == longhorn.ConditionStatusTrue {
+		alerts = append(alerts, newAlert(AlertNameBackupTargetDegraded, AlertSeverityWarning, AlertKindBackupTarget, backupTarget.Name,
+			backupTarget.Status.OwnerID, condition.Message, condition.LastTransitionTime, backupTarget))
+	}
+	return alerts
+}
+
+// IsAlertSilenced returns true if any of the unexpired silences matches the alert.
+func IsAlertSilenced(alert *Alert, silences []*longhorn.AlertSilence, now time.Time) bool {
+	for _, silence := range silences {
+		if !silence.Spec.EndsAt.IsZero() && !now.Before(silence.Spec.EndsAt.Time) {
+			continue
+		}
+		if len(silence.Spec.AlertNames) > 0 && !slices.Contains(silence.Spec.AlertNames, alert.Name) {
+			continue
+		}
+		if len(silence.Spec.Resources) > 0 && !matchAnyPattern(silence.Spec.Resources, alert.Resource) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+func matchAnyPattern(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, err := path.Match(pattern, name); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateAlertSilenceResources returns an error if any of the resource patterns of a silence is malformed.
+func ValidateAlertSilenceResources(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid resource pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// ValidateAlertSinkSpec returns an error if the spec lacks the fields its sink type requires.
+func ValidateAlertSinkSpec(spec *longhorn.AlertSinkSpec) error {
+	switch spec.Type {
+	case longhorn.AlertSinkTypeWebhook:
+		u, err := url.Parse(spec.URL)
+		if err != nil {
+			return fmt.Errorf("invalid webhook URL %v: %w", spec.URL, err)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid webhook URL %v: must be an http or https URL", spec.URL)
+		}
+	case longhorn.AlertSinkTypeSMTP:
+		if _, _, err := net.SplitHostPort(spec.SMTPAddress); err != nil {
+			return fmt.Errorf("invalid SMTP relay address %v: must be in the form of host:port", spec.SMTPAddress)
+		}
+		if _, err := mail.ParseAddress(spec.SMTPFrom); err != nil {
+			return fmt.Errorf("invalid SMTP sender %v: %w", spec.SMTPFrom, err)
+		}
+		if len(spec.SMTPTo) == 0 {
+			return fmt.Errorf("SMTP recipients are required")
+		}
+		for _, to := range spec.SMTPTo {
+			if _, err := mail.ParseAddress(to); err != nil {
+				return fmt.Errorf("invalid SMTP recipient %v: %w", to, err)
+			}
+		}
+	case longhorn.AlertSinkTypeEvent:
+	default:
+		return fmt.Errorf("invalid alert sink type %v", spec.Type)
+	}
+
+	if spec.RepeatInterval.Duration < 0 {
+		return fmt.Errorf("invalid repeat interval %v: must not be negative", spec.RepeatInterval.Duration)
+	}
+	return ValidateAlertNames(spec.AlertNames)
+}
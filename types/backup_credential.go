@@ -0,0 +1,65 @@
+package types
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// ValidateBackupStoreCredential returns an error if the credential can't be used to access the backup store of the
+// type. Only the web identity of S3 is checked.
+func ValidateBackupStoreCredential(backupType string, credential map[string]string) error {
+	if backupType == BackupStoreTypeS3 {
+		return validateS3WebIdentityCredential(credential)
+	}
+	return nil
+}
+
+// RequireProjectedWebIdentityToken returns true if the credential assumes a role with the service account token
+// projected at DefaultAWSWebIdentityTokenFile, rather than with a token file provided by the user.
+func RequireProjectedWebIdentityToken(credential map[string]string) bool {
+	if credential[AWSRoleArn] == "" {
+		return false
+	}
+	tokenFile := credential[AWSWebIdentityTokenFile]
+	return tokenFile == "" || tokenFile == DefaultAWSWebIdentityTokenFile
+}
+
+func validateS3WebIdentityCredential(credential map[string]string) error {
+	roleArn := credential[AWSRoleArn]
+	if roleArn == "" {
+		for _, key := range []string{AWSWebIdentityTokenFile, AWSRoleSessionName, AWSSTSEndpoint} {
+			if credential[key] != "" {
+				return fmt.Errorf("%v requires %v", key, AWSRoleArn)
+			}
+		}
+		return nil
+	}
+
+	if parts := strings.Split(roleArn, ":"); len(parts) < 6 || parts[0] != "arn" {
+		return fmt.Errorf("invalid %v %q, must be an ARN", AWSRoleArn, roleArn)
+	}
+	if credential[AWSAccessKey] != "" || credential[AWSSecretKey] != "" {
+		return fmt.Errorf("%v cannot be used with %v or %v", AWSRoleArn, AWSAccessKey, AWSSecretKey)
+	}
+	if tokenFile := credential[AWSWebIdentityTokenFile]; tokenFile != "" && !filepath.IsAbs(tokenFile) {
+		return fmt.Errorf("invalid %v %q, must be an absolute path", AWSWebIdentityTokenFile, tokenFile)
+	}
+
+	return validateBackupStoreEndpoint(AWSSTSEndpoint, credential[AWSSTSEndpoint])
+}
+
+func validateBackupStoreEndpoint(key, endpoint string) error {
+	if endpoint == "" {
+		return nil
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid %v %q: %w", key, endpoint, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid %v %q, must be an http or https URL", key, endpoint)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package types
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/longhorn/longhorn-manager/util"
+)
+
+const (
+	// BackupTargetFileParameterVolume is the query parameter of a file backup target URL with the name of the RWX
+	// volume storing the backups, like file:///backupstore?volume=backups.
+	BackupTargetFileParameterVolume = "volume"
+
+	// FileBackupTargetNFSServerPrefix is the name prefix of the pod and the service of the NFS server serving the node
+	// path of a file backup target.
+	FileBackupTargetNFSServerPrefix = "backup-target-"
+	// FileBackupTargetNFSExportName is the name the NFS server exports the node path of a file backup target with.
+	FileBackupTargetNFSExportName = "backupstore"
+)
+
+// ParseFileBackupTargetURL returns where the backups of the file backup target are stored, which is either a
+// directory of an RWX volume, like file:///backupstore?volume=backups, or a directory of a node, like
+// file://node-1/mnt/backups. Only one of the volume name and the node ID is returned, and the directory is the
+// directory in the volume or on the node.
+func ParseFileBackupTargetURL(backupTargetURL string) (volumeName, nodeID, dir string, err error) {
+	u, err := url.Parse(backupTargetURL)
+	if err != nil {
+		return "", "", "", fmt.Errorf("failed to parse %v as url: %w", backupTargetURL, err)
+	}
+	if u.Scheme != BackupStoreTypeFile {
+		return "", "", "", fmt.Errorf("url %v is not a %v backup target", backupTargetURL, BackupStoreTypeFile)
+	}
+	if strings.Contains(u.Path, "..") {
+		return "", "", "", fmt.Errorf("url %v of a %v backup target cannot contain ..", backupTargetURL, BackupStoreTypeFile)
+	}
+
+	if !u.Query().Has(BackupTargetFileParameterVolume) {
+		if u.Host == "" {
+			return "", "", "", fmt.Errorf("url %v of a %v backup target should have either the node or the %v parameter",
+				backupTargetURL, BackupStoreTypeFile, BackupTargetFileParameterVolume)
+		}
+		if errs := validation.IsDNS1123Subdomain(u.Host); len(errs) > 0 {
+			return "", "", "", fmt.Errorf("url %v of a %v backup target has an invalid node %q: %v",
+				backupTargetURL, BackupStoreTypeFile, u.Host, strings.Join(errs, ", "))
+		}
+		dir = path.Clean("/" + u.Path)
+		if dir == "/" {
+			return "", "", "", fmt.Errorf("url %v of a %v backup target cannot store the backups in the root directory of node %v",
+				backupTargetURL, BackupStoreTypeFile, u.Host)
+		}
+		return "", u.Host, dir, nil
+	}
+
+	if u.Host != "" {
+		return "", "", "", fmt.Errorf("url %v of a %v backup target cannot have both the node and the %v parameter",
+			backupTargetURL, BackupStoreTypeFile, BackupTargetFileParameterVolume)
+	}
+	volumeName = u.Query().Get(BackupTargetFileParameterVolume)
+	if !util.ValidateName(volumeName) {
+		return "", "", "", fmt.Errorf("url %v of a %v backup target has an invalid %v parameter %q",
+			backupTargetURL, BackupStoreTypeFile, BackupTargetFileParameterVolume, volumeName)
+	}
+	return volumeName, "", path.Clean("/" + u.Path), nil
+}
+
+// GetFileBackupTargetNFSURL returns the NFS backup target URL of the directory in the share of the volume storing the
+// backups of a file backup target. The share endpoint is like nfs://10.43.0.10/backups.
+func GetFileBackupTargetNFSURL(shareEndpoint, dir string) (string, error) {
+	u, err := url.Parse(shareEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse share endpoint %v: %w", shareEndpoint, err)
+	}
+	if u.Scheme != BackupStoreTypeNFS || u.Host == "" {
+		return "", fmt.Errorf("invalid share endpoint %v", shareEndpoint)
+	}
+	return fmt.Sprintf("%s://%s:%s", BackupStoreTypeNFS, u.Host, path.Join(u.Path, dir)), nil
+}
+
+// GetFileBackupTargetNFSServerName returns the name of the pod and the service of the NFS server serving the node
+// path of the file backup target.
+func GetFileBackupTargetNFSServerName(backupTargetName string) string {
+	return FileBackupTargetNFSServerPrefix + backupTargetName
+}
+
+// GetFileBackupTargetNFSServerLabels returns the labels of the pod of the NFS server serving the node path of the file
+// backup target, which the service of the NFS server selects.
+func GetFileBackupTargetNFSServerLabels(backupTargetName string) map[string]string {
+	labels := GetBaseLabelsForSystemManagedComponent()
+	labels[GetLonghornLabelKey(LonghornLabelBackupTarget)] = backupTargetName
+	return labels
+}
@@ -24,6 +24,82 @@ const (
 	CSIPluginName      = "longhorn-csi-plugin"
 )
 
+const (
+	WebIdentityTokenDirectoryInContainer = "/var/run/secrets/longhorn.io/serviceaccount"
+	// WebIdentityTokenAudience is the audience of the projected service account token, expected by AWS STS and by
+	// most S3-compatible STS endpoints.
+	WebIdentityTokenAudience = "sts.amazonaws.com"
+
+	webIdentityTokenVolumeName        = "web-identity-token"
+	webIdentityTokenPath              = "token"
+	webIdentityTokenExpirationSeconds = 3600
+)
+
+// AddWebIdentityTokenToPod projects the service account token of a pod into DefaultAWSWebIdentityTokenFile, so that
+// the backup store can assume the role of a web identity credential. The kubelet rotates the token before it expires,
+// and the SDK reads the file again whenever it refreshes the credentials.
+func AddWebIdentityTokenToPod(pod *corev1.Pod) {
+	if pod == nil {
+		return
+	}
+	addWebIdentityTokenToPodSpec(&pod.Spec)
+}
+
+// AddWebIdentityTokenToDaemonSet projects the service account token into the pods of a daemonset, see
+// AddWebIdentityTokenToPod. It returns false if the pod template already has the projected token.
+func AddWebIdentityTokenToDaemonSet(daemonSet *appsv1.DaemonSet) bool {
+	if daemonSet == nil || HasWebIdentityToken(&daemonSet.Spec.Template.Spec) {
+		return false
+	}
+	return addWebIdentityTokenToPodSpec(&daemonSet.Spec.Template.Spec)
+}
+
+// HasWebIdentityToken returns true if the service account token is projected into the pod spec.
+func HasWebIdentityToken(podSpec *corev1.PodSpec) bool {
+	for _, volume := range podSpec.Volumes {
+		if volume.Name == webIdentityTokenVolumeName {
+			return true
+		}
+	}
+	return false
+}
+
+func addWebIdentityTokenToPodSpec(podSpec *corev1.PodSpec) bool {
+	if len(podSpec.Containers) == 0 {
+		return false
+	}
+
+	podSpec.Containers[0].Env = append(
+		podSpec.Containers[0].Env,
+		corev1.EnvVar{Name: AWSWebIdentityTokenFile, Value: DefaultAWSWebIdentityTokenFile},
+	)
+	podSpec.Containers[0].VolumeMounts = append(
+		podSpec.Containers[0].VolumeMounts,
+		corev1.VolumeMount{Name: webIdentityTokenVolumeName, MountPath: WebIdentityTokenDirectoryInContainer, ReadOnly: true},
+	)
+	expirationSeconds := int64(webIdentityTokenExpirationSeconds)
+	podSpec.Volumes = append(
+		podSpec.Volumes,
+		corev1.Volume{
+			Name: webIdentityTokenVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{
+					Sources: []corev1.VolumeProjection{
+						{
+							ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+								Audience:          WebIdentityTokenAudience,
+								ExpirationSeconds: &expirationSeconds,
+								Path:              webIdentityTokenPath,
+							},
+						},
+					},
+				},
+			},
+		},
+	)
+	return true
+}
+
 // AddGoCoverDirToPod adds GOCOVERDIR env and host path volume to a pod.
 // It's used to collect coverage data from a pod.
 func AddGoCoverDirToPod(pod *corev1.Pod) {
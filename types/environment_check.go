@@ -0,0 +1,139 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/cockroachdb/errors"
+	"gopkg.in/yaml.v2"
+
+	corev1 "k8s.io/api/core/v1"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+const (
+	EnvironmentCheckYAMLFileName = "environment-check.yaml"
+)
+
+type EnvironmentCheckSeverity string
+
+const (
+	EnvironmentCheckSeverityError   = EnvironmentCheckSeverity("error")
+	EnvironmentCheckSeverityWarning = EnvironmentCheckSeverity("warning")
+)
+
+var (
+	environmentCheckNameRegex = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+
+	builtinNodeConditionTypes = map[string]bool{
+		longhorn.NodeConditionTypeReady:               true,
+		longhorn.NodeConditionTypeMountPropagation:    true,
+		longhorn.NodeConditionTypeMultipathd:          true,
+		longhorn.NodeConditionTypeKernelModulesLoaded: true,
+		longhorn.NodeConditionTypeRequiredPackages:    true,
+		longhorn.NodeConditionTypeNFSClientInstalled:  true,
+		longhorn.NodeConditionTypeSchedulable:         true,
+		longhorn.NodeConditionTypeHugePagesAvailable:  true,
+		longhorn.NodeConditionTypeCapacityAtRisk:      true,
+	}
+)
+
+// EnvironmentCheck is a declarative node environment check defined in the environment check ConfigMap. The command is
+// executed in the host namespaces, and the check passes if the command succeeds and its output matches ExpectedOutput.
+// The result is reported as a node condition whose type is the check name.
+type EnvironmentCheck struct {
+	Name           string                   `yaml:"name"`
+	Command        []string                 `yaml:"command"`
+	ExpectedOutput string                   `yaml:"expectedOutput"`
+	Severity       EnvironmentCheckSeverity `yaml:"severity"`
+	DataEngine     longhorn.DataEngineType  `yaml:"dataEngine"`
+	Remediation    string                   `yaml:"remediation"`
+}
+
+// Validate checks the fields of the environment check and fills in the default severity.
+func (check *EnvironmentCheck) Validate() error {
+	if !environmentCheckNameRegex.MatchString(check.Name) {
+		return fmt.Errorf("invalid environment check name %q, should be in CamelCase starting with an upper case letter", check.Name)
+	}
+	if builtinNodeConditionTypes[check.Name] {
+		return fmt.Errorf("environment check name %v conflicts with a built-in node condition", check.Name)
+	}
+	if len(check.Command) == 0 || check.Command[0] == "" {
+		return fmt.Errorf("environment check %v has no command", check.Name)
+	}
+	if _, err := regexp.Compile(check.ExpectedOutput); err != nil {
+		return errors.Wrapf(err, "invalid expected output of environment check %v", check.Name)
+	}
+
+	switch check.Severity {
+	case "":
+		check.Severity = EnvironmentCheckSeverityError
+	case EnvironmentCheckSeverityError, EnvironmentCheckSeverityWarning:
+	default:
+		return fmt.Errorf("invalid severity %v of environment check %v", check.Severity, check.Name)
+	}
+
+	switch check.DataEngine {
+	case "", longhorn.DataEngineTypeV1, longhorn.DataEngineTypeV2:
+	default:
+		return fmt.Errorf("invalid data engine %v of environment check %v", check.DataEngine, check.Name)
+	}
+
+	return nil
+}
+
+// IsOutputExpected returns true if the command output matches the expected output of the check.
+func (check *EnvironmentCheck) IsOutputExpected(output string) bool {
+	if check.ExpectedOutput == "" {
+		return true
+	}
+	matched, err := regexp.MatchString(check.ExpectedOutput, output)
+	return err == nil && matched
+}
+
+// GetEnvironmentChecks retrieves the declarative environment checks from the provided ConfigMap. Invalid checks are
+// returned as errors alongside the valid ones, so a single mistake does not disable all checks.
+func GetEnvironmentChecks(cm *corev1.ConfigMap) (checks []EnvironmentCheck, invalid []error, err error) {
+	data, ok := cm.Data[EnvironmentCheckYAMLFileName]
+	if !ok || data == "" {
+		return []EnvironmentCheck{}, nil, nil
+	}
+
+	parsed := []EnvironmentCheck{}
+	if err := yaml.Unmarshal([]byte(data), &parsed); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to unmarshal %v in ConfigMap %v", EnvironmentCheckYAMLFileName, cm.Name)
+	}
+
+	names := map[string]bool{}
+	checks = []EnvironmentCheck{}
+	for _, check := range parsed {
+		if err := check.Validate(); err != nil {
+			invalid = append(invalid, err)
+			continue
+		}
+		if names[check.Name] {
+			invalid = append(invalid, fmt.Errorf("duplicate environment check %v", check.Name))
+			continue
+		}
+		names[check.Name] = true
+		checks = append(checks, check)
+	}
+
+	return checks, invalid, nil
+}
+
+// IsEnvironmentCheckCondition returns true if the node condition is reported by a declarative environment check,
+// including the checks that could not run since the namespace executor was unavailable.
+func IsEnvironmentCheckCondition(condition longhorn.Condition) bool {
+	switch condition.Reason {
+	case longhorn.NodeConditionReasonEnvironmentCheckPassed,
+		longhorn.NodeConditionReasonEnvironmentCheckFailed,
+		longhorn.NodeConditionReasonEnvironmentCheckWarning:
+		return true
+	case longhorn.NodeConditionReasonNamespaceExecutorErr:
+		// The built-in checks report the same reason
+		return !builtinNodeConditionTypes[condition.Type]
+	}
+	return false
+}
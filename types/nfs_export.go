@@ -0,0 +1,169 @@
+package types
+
+import (
+	"fmt"
+	"net"
+	"path/filepath"
+	"strings"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+// ValidateNFSExportOptions returns an error if the share manager can't export an RWX volume with the options.
+func ValidateNFSExportOptions(options longhorn.NFSExportOptions) error {
+	switch options.Squash {
+	case longhorn.NFSExportSquashDefault, longhorn.NFSExportSquashNone, longhorn.NFSExportSquashRoot, longhorn.NFSExportSquashAll:
+	default:
+		return fmt.Errorf("invalid NFS export squash %q, must be %v, %v or %v",
+			options.Squash, longhorn.NFSExportSquashNone, longhorn.NFSExportSquashRoot, longhorn.NFSExportSquashAll)
+	}
+
+	switch options.Sec {
+	case longhorn.NFSExportSecFlavorDefault, longhorn.NFSExportSecFlavorSys,
+		longhorn.NFSExportSecFlavorKrb5, longhorn.NFSExportSecFlavorKrb5i, longhorn.NFSExportSecFlavorKrb5p:
+	default:
+		return fmt.Errorf("invalid NFS export security flavor %q, must be %v, %v, %v or %v", options.Sec,
+			longhorn.NFSExportSecFlavorSys, longhorn.NFSExportSecFlavorKrb5, longhorn.NFSExportSecFlavorKrb5i, longhorn.NFSExportSecFlavorKrb5p)
+	}
+
+	if options.AnonUID < 0 {
+		return fmt.Errorf("invalid NFS export anonymous user ID %v, must not be negative", options.AnonUID)
+	}
+	if options.AnonGID < 0 {
+		return fmt.Errorf("invalid NFS export anonymous group ID %v, must not be negative", options.AnonGID)
+	}
+
+	for _, client := range options.AllowedClients {
+		if _, _, err := net.ParseCIDR(client); err != nil {
+			return fmt.Errorf("invalid NFS export allowed client %q, must be a CIDR: %w", client, err)
+		}
+	}
+	return nil
+}
+
+const (
+	// NFSGaneshaConfigPath is the NFS server config of the share manager. The share manager only writes its default
+	// config if the file doesn't exist, and it reuses the export block of the volume found in the config instead of
+	// generating one, so the export options are applied by writing the config before the share manager starts.
+	NFSGaneshaConfigPath = "/tmp/vfs.conf"
+
+	// FileBackupTargetNFSExportPath is where the NFS server serving the node path of a file backup target mounts it.
+	FileBackupTargetNFSExportPath = nfsGaneshaExportPath + "/" + FileBackupTargetNFSExportName
+
+	nfsGaneshaExportID   = "1"
+	nfsGaneshaExportPath = "/export"
+
+	// The share manager recovers the NFSv4 client state through the share manager, while the NFS server of a file
+	// backup target keeps it in the files of the pod.
+	nfsGaneshaRecoveryBackendLonghorn = "longhorn"
+	nfsGaneshaRecoveryBackendFS       = "fs"
+
+	fileBackupTargetNFSLeaseLifetime = 60
+	fileBackupTargetNFSGracePeriod   = 90
+)
+
+// nfsGaneshaConfig is the default config of the share manager, with the NFS server log written to the output of the
+// share manager.
+const nfsGaneshaConfig = `
+NFS_Core_Param
+{
+    NLM_Port = 0;
+    MNT_Port = 0;
+    RQUOTA_Port = 0;
+    Enable_NLM = false;
+    Enable_RQUOTA = false;
+    Enable_UDP = false;
+    fsid_device = false;
+    Protocols = 4;
+}
+
+LOG {
+	Default_Log_Level = INFO;
+
+	Facility {
+		name = FILE;
+		destination = "/proc/1/fd/1";
+		enable = active;
+	}
+}
+
+NFSV4
+{
+    Lease_Lifetime = %d;
+    Grace_Period = %d;
+    Minor_Versions = 1, 2;
+    RecoveryBackend = %s;
+    Only_Numeric_Owners = true;
+}
+
+Export_defaults
+{
+    Protocols = 4;
+    Transports = TCP;
+    Access_Type = None;
+    SecType = sys;
+    Squash = None;
+}
+`
+
+// GetNFSGaneshaConfig returns the NFS server config of the share manager exporting the volume with the options. The
+// export block has the volume marker the share manager looks up, so that it exports the volume with this block.
+func GetNFSGaneshaConfig(volumeName string, options longhorn.NFSExportOptions, leaseLifetime, gracePeriod int) string {
+	return getNFSGaneshaConfig(volumeName, options, leaseLifetime, gracePeriod, nfsGaneshaRecoveryBackendLonghorn)
+}
+
+// GetFileBackupTargetNFSServerConfig returns the config of the NFS server serving the node path of a file backup
+// target, which is mounted at FileBackupTargetNFSExportPath.
+func GetFileBackupTargetNFSServerConfig() string {
+	return getNFSGaneshaConfig(FileBackupTargetNFSExportName, longhorn.NFSExportOptions{}, fileBackupTargetNFSLeaseLifetime,
+		fileBackupTargetNFSGracePeriod, nfsGaneshaRecoveryBackendFS)
+}
+
+func getNFSGaneshaConfig(exportName string, options longhorn.NFSExportOptions, leaseLifetime, gracePeriod int, recoveryBackend string) string {
+	accessType := "RW"
+	if options.ReadOnly {
+		accessType = "RO"
+	}
+
+	squash := "None"
+	switch options.Squash {
+	case longhorn.NFSExportSquashRoot:
+		squash = "Root_Squash"
+	case longhorn.NFSExportSquashAll:
+		squash = "All_Squash"
+	}
+
+	secType := string(longhorn.NFSExportSecFlavorSys)
+	if options.Sec != longhorn.NFSExportSecFlavorDefault {
+		secType = string(options.Sec)
+	}
+
+	export := &strings.Builder{}
+	fmt.Fprintf(export, "\nEXPORT\n{\n")
+	fmt.Fprintf(export, "\tExport_Id = %v;#Volume=%v\n", nfsGaneshaExportID, exportName)
+	fmt.Fprintf(export, "\tPath = %v;\n", filepath.Join(nfsGaneshaExportPath, exportName))
+	fmt.Fprintf(export, "\tPseudo = %v;\n", filepath.Join("/", exportName))
+	fmt.Fprintf(export, "\tProtocols = 4;\n")
+	fmt.Fprintf(export, "\tTransports = TCP;\n")
+	// The clients out of the allowed CIDRs have no access
+	if len(options.AllowedClients) > 0 {
+		fmt.Fprintf(export, "\tAccess_Type = None;\n")
+	} else {
+		fmt.Fprintf(export, "\tAccess_Type = %v;\n", accessType)
+	}
+	fmt.Fprintf(export, "\tSquash = %v;\n", squash)
+	if options.AnonUID != 0 {
+		fmt.Fprintf(export, "\tAnonymous_Uid = %v;\n", options.AnonUID)
+	}
+	if options.AnonGID != 0 {
+		fmt.Fprintf(export, "\tAnonymous_Gid = %v;\n", options.AnonGID)
+	}
+	fmt.Fprintf(export, "\tSecType = %v;\n", secType)
+	fmt.Fprintf(export, "\tFilesystem_id = %v.0;\n", nfsGaneshaExportID)
+	if len(options.AllowedClients) > 0 {
+		fmt.Fprintf(export, "\tCLIENT {\n\t\tClients = %v;\n\t\tAccess_Type = %v;\n\t}\n", strings.Join(options.AllowedClients, ", "), accessType)
+	}
+	fmt.Fprintf(export, "\tFSAL {\n\t\tName = VFS;\n\t}\n}\n")
+
+	return fmt.Sprintf(nfsGaneshaConfig, leaseLifetime, gracePeriod, recoveryBackend) + export.String()
+}
@@ -0,0 +1,67 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/longhorn/longhorn-manager/util"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+const (
+	// LonghornLabelRevertPreviewOf is the label of a revert preview volume, with the name of the volume to revert.
+	LonghornLabelRevertPreviewOf = "revert-preview-of"
+	// LonghornLabelRevertPreviewSnapshot is the label of a revert preview volume, with the snapshot to revert to.
+	LonghornLabelRevertPreviewSnapshot = "revert-preview-snapshot"
+
+	RevertPreviewExpireAtAnnotationKeySuffix = "revert-preview-expire-at"
+	RevertPreviewNodeAnnotationKeySuffix     = "revert-preview-node"
+
+	DefaultRevertPreviewTTL = 4 * time.Hour
+	MaxRevertPreviewTTL     = 7 * 24 * time.Hour
+
+	// RevertPreviewMountPath is where the revert preview volume is mounted read-only in the maintenance pod.
+	RevertPreviewMountPath = "/preview"
+)
+
+// GetRevertPreviewVolumeName returns a new name of the revert preview volume of the volume.
+func GetRevertPreviewVolumeName(volumeName string) string {
+	return fmt.Sprintf("%s-preview-%s", volumeName, util.RandomID())
+}
+
+// GetRevertPreviewPodName returns the name of the maintenance pod mounting the revert preview volume.
+func GetRevertPreviewPodName(previewVolumeName string) string {
+	return "revert-preview-" + previewVolumeName
+}
+
+// IsRevertPreviewVolume returns true if the volume is a temporary clone to preview a snapshot revert.
+func IsRevertPreviewVolume(v *longhorn.Volume) bool {
+	return v.Labels[GetLonghornLabelKey(LonghornLabelRevertPreviewOf)] != ""
+}
+
+// GetRevertPreviewLabels returns the labels of the revert preview volume and its maintenance pod.
+func GetRevertPreviewLabels(volumeName, snapshotName string) map[string]string {
+	return map[string]string{
+		GetLonghornLabelKey(LonghornLabelRevertPreviewOf):       volumeName,
+		GetLonghornLabelKey(LonghornLabelRevertPreviewSnapshot): snapshotName,
+	}
+}
+
+// ValidateRevertPreviewTTL returns an error if the time to live of a revert preview volume is out of range.
+func ValidateRevertPreviewTTL(ttl time.Duration) error {
+	if ttl <= 0 || ttl > MaxRevertPreviewTTL {
+		return fmt.Errorf("invalid revert preview TTL %v, must be greater than 0 and at most %v", ttl, MaxRevertPreviewTTL)
+	}
+	return nil
+}
+
+// GetRevertPreviewExpireAt returns the time the revert preview volume is garbage collected.
+func GetRevertPreviewExpireAt(v *longhorn.Volume) (time.Time, error) {
+	value := v.Annotations[GetLonghornLabelKey(RevertPreviewExpireAtAnnotationKeySuffix)]
+	expireAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid expiration time %q of revert preview volume %v: %w", value, v.Name, err)
+	}
+	return expireAt, nil
+}
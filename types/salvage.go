@@ -0,0 +1,256 @@
+package types
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"time"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+// SalvageCandidate is a failed replica of a faulted volume, compared with the other failed replicas before a salvage.
+type SalvageCandidate struct {
+	Replica  string `json:"replica"`
+	NodeID   string `json:"nodeID"`
+	DiskID   string `json:"diskID"`
+	FailedAt string `json:"failedAt"`
+	// The reason the replica can't be salvaged, empty if it can.
+	Unusable string                           `json:"unusable"`
+	Metadata *longhorn.ReplicaSalvageMetadata `json:"metadata"`
+	// The rank of the replica among the usable replicas, starting from 1. It's 0 if the replica is unusable.
+	Rank        int  `json:"rank"`
+	Recommended bool `json:"recommended"`
+	// The snapshots in the chains of the other usable replicas but not in the chain of the replica.
+	MissingSnapshots []string `json:"missingSnapshots"`
+	Reasons          []string `json:"reasons"`
+}
+
+// SalvageReport is the comparison of the failed replicas of a faulted volume, with the recommended replicas to salvage.
+type SalvageReport struct {
+	Volume                  string              `json:"volume"`
+	RevisionCounterDisabled bool                `json:"revisionCounterDisabled"`
+	Candidates              []*SalvageCandidate `json:"candidates"`
+	Recommended             []string            `json:"recommended"`
+}
+
+// SalvagePreview is the outcome of salvaging the chosen replicas of a faulted volume.
+type SalvagePreview struct {
+	Volume   string   `json:"volume"`
+	Replicas []string `json:"replicas"`
+	// The replica the volume is recovered from. The other chosen replicas are rebuilt from it.
+	SourceReplica string `json:"sourceReplica"`
+	// The snapshots kept by the salvage, from the newest to the oldest.
+	Chain []string `json:"chain"`
+	// The snapshots in the chains of the other usable replicas, which are lost by the salvage.
+	LostSnapshots []string `json:"lostSnapshots"`
+	Warnings      []string `json:"warnings"`
+}
+
+func getSalvageCandidateHeadLastModifiedAt(c *SalvageCandidate) time.Time {
+	t, err := time.Parse(time.RFC3339Nano, c.Metadata.HeadLastModifiedAt)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// compareSalvageCandidates returns a negative number if the data of the candidate a is likely newer than the data of
+// the candidate b. The candidates are compared the same way the engine picks the source of a salvage: by the revision
+// counter if it's enabled, then by the last modified time and the size of the volume head.
+func compareSalvageCandidates(a, b *SalvageCandidate, revisionCounterDisabled bool) int {
+	if !revisionCounterDisabled && a.Metadata.RevisionCounter != b.Metadata.RevisionCounter {
+		if a.Metadata.RevisionCounter > b.Metadata.RevisionCounter {
+			return -1
+		}
+		return 1
+	}
+	if c := getSalvageCandidateHeadLastModifiedAt(b).Compare(getSalvageCandidateHeadLastModifiedAt(a)); c != 0 {
+		return c
+	}
+	if a.Metadata.HeadSize != b.Metadata.HeadSize {
+		if a.Metadata.HeadSize > b.Metadata.HeadSize {
+			return -1
+		}
+		return 1
+	}
+	if len(a.Metadata.Chain) != len(b.Metadata.Chain) {
+		return len(b.Metadata.Chain) - len(a.Metadata.Chain)
+	}
+	if a.Replica < b.Replica {
+		return -1
+	}
+	if a.Replica > b.Replica {
+		return 1
+	}
+	return 0
+}
+
+// isSameSalvageData returns true if the candidates likely have the same data. Without the revision counter, the volume
+// heads have to be last modified at the same time.
+func isSameSalvageData(a, b *SalvageCandidate, revisionCounterDisabled bool) bool {
+	if revisionCounterDisabled {
+		if a.Metadata.HeadLastModifiedAt != b.Metadata.HeadLastModifiedAt {
+			return false
+		}
+	} else if a.Metadata.RevisionCounter != b.Metadata.RevisionCounter {
+		return false
+	}
+	return a.Metadata.HeadSize == b.Metadata.HeadSize && slices.Equal(a.Metadata.Chain, b.Metadata.Chain)
+}
+
+// RankSalvageCandidates ranks the usable candidates by how likely their data is the newest, marks the recommended
+// candidates, and explains the ranking in the reasons of the candidates. The candidates with the same data as the
+// first one are recommended together, so that they don't have to be rebuilt after the salvage. The candidates are
+// sorted by the ranks, and the unusable ones are put at the end.
+func RankSalvageCandidates(candidates []*SalvageCandidate, revisionCounterDisabled bool) []*SalvageCandidate {
+	var usable, unusable []*SalvageCandidate
+	for _, c := range candidates {
+		c.Rank = 0
+		c.Recommended = false
+		c.MissingSnapshots = []string{}
+		c.Reasons = []string{}
+
+		switch {
+		case c.Unusable != "":
+		case c.Metadata == nil:
+			c.Unusable = "the data of the replica is not inspected yet"
+		case c.Metadata.Error != "":
+			c.Unusable = fmt.Sprintf("failed to inspect the data of the replica: %v", c.Metadata.Error)
+		case c.Metadata.Rebuilding:
+			c.Unusable = "the replica failed before the rebuild completed, so its data is incomplete"
+		}
+		if c.Unusable != "" {
+			unusable = append(unusable, c)
+			continue
+		}
+		usable = append(usable, c)
+	}
+
+	sort.SliceStable(usable, func(i, j int) bool {
+		return compareSalvageCandidates(usable[i], usable[j], revisionCounterDisabled) < 0
+	})
+	sort.SliceStable(unusable, func(i, j int) bool {
+		return unusable[i].Replica < unusable[j].Replica
+	})
+
+	snapshots := map[string]bool{}
+	for _, c := range usable {
+		for _, snapshot := range c.Metadata.Chain {
+			snapshots[snapshot] = true
+		}
+	}
+	snapshotNames := slices.Sorted(maps.Keys(snapshots))
+
+	for i, c := range usable {
+		c.Rank = i + 1
+		for _, snapshot := range snapshotNames {
+			if !slices.Contains(c.Metadata.Chain, snapshot) {
+				c.MissingSnapshots = append(c.MissingSnapshots, snapshot)
+			}
+		}
+
+		best := usable[0]
+		if !revisionCounterDisabled {
+			if c.Metadata.RevisionCounter == best.Metadata.RevisionCounter {
+				c.Reasons = append(c.Reasons, fmt.Sprintf("has the highest revision counter %v", c.Metadata.RevisionCounter))
+			} else {
+				c.Reasons = append(c.Reasons, fmt.Sprintf("has the revision counter %v, %v writes behind replica %v",
+					c.Metadata.RevisionCounter, best.Metadata.RevisionCounter-c.Metadata.RevisionCounter, best.Replica))
+			}
+		}
+		if c == best {
+			c.Reasons = append(c.Reasons, fmt.Sprintf("volume head was last modified at %v with size %v",
+				c.Metadata.HeadLastModifiedAt, c.Metadata.HeadSize))
+		} else {
+			relation := "before"
+			diff := getSalvageCandidateHeadLastModifiedAt(best).Sub(getSalvageCandidateHeadLastModifiedAt(c))
+			if diff < 0 {
+				relation, diff = "after", -diff
+			}
+			c.Reasons = append(c.Reasons, fmt.Sprintf("volume head was last modified at %v, %v %v replica %v, with size %v",
+				c.Metadata.HeadLastModifiedAt, diff, relation, best.Replica, c.Metadata.HeadSize))
+		}
+		if len(c.MissingSnapshots) == 0 {
+			c.Reasons = append(c.Reasons, fmt.Sprintf("has all %v snapshots of the failed replicas", len(c.Metadata.Chain)))
+		} else {
+			c.Reasons = append(c.Reasons, fmt.Sprintf("misses snapshots %v of the other failed replicas", c.MissingSnapshots))
+		}
+
+		if c == best {
+			c.Recommended = true
+			c.Reasons = append(c.Reasons, "most likely has the newest data")
+		} else if isSameSalvageData(c, best, revisionCounterDisabled) {
+			c.Recommended = true
+			c.Reasons = append(c.Reasons, fmt.Sprintf("has the same data as replica %v, so it doesn't have to be rebuilt", best.Replica))
+		}
+	}
+
+	for _, c := range unusable {
+		c.Reasons = append(c.Reasons, c.Unusable)
+	}
+
+	return append(usable, unusable...)
+}
+
+// GetSalvagePreview returns the outcome of salvaging the chosen replicas, based on the ranking of the report.
+func GetSalvagePreview(report *SalvageReport, replicaNames []string) (*SalvagePreview, error) {
+	if len(replicaNames) == 0 {
+		return nil, fmt.Errorf("no replica is chosen to salvage")
+	}
+
+	preview := &SalvagePreview{
+		Volume:        report.Volume,
+		Replicas:      replicaNames,
+		Chain:         []string{},
+		LostSnapshots: []string{},
+		Warnings:      []string{},
+	}
+
+	var source *SalvageCandidate
+	for _, name := range replicaNames {
+		var candidate *SalvageCandidate
+		for _, c := range report.Candidates {
+			if c.Replica == name {
+				candidate = c
+				break
+			}
+		}
+		if candidate == nil {
+			return nil, fmt.Errorf("replica %v is not a failed replica of volume %v", name, report.Volume)
+		}
+		if candidate.Unusable != "" {
+			return nil, fmt.Errorf("replica %v can't be salvaged: %v", name, candidate.Unusable)
+		}
+		if source == nil || candidate.Rank < source.Rank {
+			source = candidate
+		}
+	}
+
+	preview.SourceReplica = source.Replica
+	preview.Chain = source.Metadata.Chain
+	preview.LostSnapshots = source.MissingSnapshots
+
+	for _, c := range report.Candidates {
+		if c.Rank == 0 || c.Rank >= source.Rank {
+			continue
+		}
+		preview.Warnings = append(preview.Warnings, fmt.Sprintf("replica %v ranked %v is not chosen: %v", c.Replica, c.Rank, c.Reasons))
+	}
+	for _, name := range replicaNames {
+		if name == source.Replica {
+			continue
+		}
+		for _, c := range report.Candidates {
+			if c.Replica == name && !isSameSalvageData(c, source, report.RevisionCounterDisabled) {
+				preview.Warnings = append(preview.Warnings, fmt.Sprintf("replica %v has different data from replica %v and will be rebuilt from it", name, source.Replica))
+			}
+		}
+	}
+	if len(preview.LostSnapshots) != 0 {
+		preview.Warnings = append(preview.Warnings, fmt.Sprintf("snapshots %v will be lost", preview.LostSnapshots))
+	}
+
+	return preview, nil
+}
@@ -74,6 +74,7 @@ const (
 	SettingNameReplicaAutoBalanceDiskPressurePercentage                 = SettingName("replica-auto-balance-disk-pressure-percentage")
 	SettingNameStorageOverProvisioningPercentage                        = SettingName("storage-over-provisioning-percentage")
 	SettingNameStorageMinimalAvailablePercentage                        = SettingName("storage-minimal-available-percentage")
+	SettingNameStorageCapacityForecastDays                              = SettingName("storage-capacity-forecast-days")
 	SettingNameStorageReservedPercentageForDefaultDisk                  = SettingName("storage-reserved-percentage-for-default-disk")
 	SettingNameUpgradeChecker                                           = SettingName("upgrade-checker")
 	SettingNameUpgradeResponderURL                                      = SettingName("upgrade-responder-url")
@@ -90,8 +91,14 @@ const (
 	SettingNameAutoSalvage                                              = SettingName("auto-salvage")
 	SettingNameAutoDeletePodWhenVolumeDetachedUnexpectedly              = SettingName("auto-delete-pod-when-volume-detached-unexpectedly")
 	SettingNameRegistrySecret                                           = SettingName("registry-secret")
+	SettingNameAPIAuthentication                                        = SettingName("api-authentication")
+	SettingNameAuditLogDestination                                      = SettingName("audit-log-destination")
+	SettingNameAlertEvaluationInterval                                  = SettingName("alert-evaluation-interval")
+	SettingNameTracingOTLPEndpoint                                      = SettingName("tracing-otlp-endpoint")
+	SettingNameTracingSamplingRatio                                     = SettingName("tracing-sampling-ratio")
 	SettingNameDisableSchedulingOnCordonedNode                          = SettingName("disable-scheduling-on-cordoned-node")
 	SettingNameReplicaZoneSoftAntiAffinity                              = SettingName("replica-zone-soft-anti-affinity")
+	SettingNameTopologyLevels                                           = SettingName("topology-levels")
 	SettingNameNodeDownPodDeletionPolicy                                = SettingName("node-down-pod-deletion-policy")
 	SettingNameNodeDrainPolicy                                          = SettingName("node-drain-policy")
 	SettingNameDetachManuallyAttachedVolumesWhenCordoned                = SettingName("detach-manually-attached-volumes-when-cordoned")
@@ -136,6 +143,8 @@ const (
 	SettingNameBackupConcurrentLimit                                    = SettingName("backup-concurrent-limit")
 	SettingNameRestoreConcurrentLimit                                   = SettingName("restore-concurrent-limit")
 	SettingNameLogLevel                                                 = SettingName("log-level")
+	SettingNameLogFormat                                                = SettingName("log-format")
+	SettingNameComponentLogLevels                                       = SettingName("component-log-levels")
 	SettingNameReplicaDiskSoftAntiAffinity                              = SettingName("replica-disk-soft-anti-affinity")
 	SettingNameAllowEmptyNodeSelectorVolume                             = SettingName("allow-empty-node-selector-volume")
 	SettingNameAllowEmptyDiskSelectorVolume                             = SettingName("allow-empty-disk-selector-volume")
@@ -153,6 +162,9 @@ const (
 	SettingNameAutoCleanupSnapshotAfterOnDemandBackupCompleted          = SettingName("auto-cleanup-snapshot-after-on-demand-backup-completed")
 	SettingNameDefaultMinNumberOfBackingImageCopies                     = SettingName("default-min-number-of-backing-image-copies")
 	SettingNameBackupExecutionTimeout                                   = SettingName("backup-execution-timeout")
+	SettingNameBackupTargetHealthProbeInterval                          = SettingName("backup-target-health-probe-interval")
+	SettingNameBackupTargetDegradedErrorRateThreshold                   = SettingName("backup-target-degraded-error-rate-threshold")
+	SettingNameBackupTargetDegradedLatencyThreshold                     = SettingName("backup-target-degraded-latency-threshold")
 	SettingNameRWXVolumeFastFailover                                    = SettingName("rwx-volume-fast-failover")
 	SettingNameOfflineReplicaRebuilding                                 = SettingName("offline-replica-rebuilding")
 	SettingNameReplicaRebuildingBandwidthLimit                          = SettingName("replica-rebuilding-bandwidth-limit")
@@ -192,6 +204,7 @@ var (
 		SettingNameReplicaAutoBalanceDiskPressurePercentage,
 		SettingNameStorageOverProvisioningPercentage,
 		SettingNameStorageMinimalAvailablePercentage,
+		SettingNameStorageCapacityForecastDays,
 		SettingNameStorageReservedPercentageForDefaultDisk,
 		SettingNameUpgradeChecker,
 		SettingNameUpgradeResponderURL,
@@ -208,8 +221,14 @@ var (
 		SettingNameAutoSalvage,
 		SettingNameAutoDeletePodWhenVolumeDetachedUnexpectedly,
 		SettingNameRegistrySecret,
+		SettingNameAPIAuthentication,
+		SettingNameAuditLogDestination,
+		SettingNameAlertEvaluationInterval,
+		SettingNameTracingOTLPEndpoint,
+		SettingNameTracingSamplingRatio,
 		SettingNameDisableSchedulingOnCordonedNode,
 		SettingNameReplicaZoneSoftAntiAffinity,
+		SettingNameTopologyLevels,
 		SettingNameNodeDownPodDeletionPolicy,
 		SettingNameNodeDrainPolicy,
 		SettingNameDetachManuallyAttachedVolumesWhenCordoned,
@@ -253,6 +272,8 @@ var (
 		SettingNameBackupConcurrentLimit,
 		SettingNameRestoreConcurrentLimit,
 		SettingNameLogLevel,
+		SettingNameLogFormat,
+		SettingNameComponentLogLevels,
 		SettingNameV1DataEngine,
 		SettingNameV2DataEngine,
 		SettingNameDataEngineHugepageEnabled,
@@ -271,6 +292,9 @@ var (
 		SettingNameAutoCleanupSnapshotAfterOnDemandBackupCompleted,
 		SettingNameDefaultMinNumberOfBackingImageCopies,
 		SettingNameBackupExecutionTimeout,
+		SettingNameBackupTargetHealthProbeInterval,
+		SettingNameBackupTargetDegradedErrorRateThreshold,
+		SettingNameBackupTargetDegradedLatencyThreshold,
 		SettingNameRWXVolumeFastFailover,
 		SettingNameOfflineReplicaRebuilding,
 		SettingNameReplicaRebuildingBandwidthLimit,
@@ -338,6 +362,7 @@ var (
 		SettingNameReplicaAutoBalanceDiskPressurePercentage:                 SettingDefinitionReplicaAutoBalanceDiskPressurePercentage,
 		SettingNameStorageOverProvisioningPercentage:                        SettingDefinitionStorageOverProvisioningPercentage,
 		SettingNameStorageMinimalAvailablePercentage:                        SettingDefinitionStorageMinimalAvailablePercentage,
+		SettingNameStorageCapacityForecastDays:                              SettingDefinitionStorageCapacityForecastDays,
 		SettingNameStorageReservedPercentageForDefaultDisk:                  SettingDefinitionStorageReservedPercentageForDefaultDisk,
 		SettingNameUpgradeChecker:                                           SettingDefinitionUpgradeChecker,
 		SettingNameUpgradeResponderURL:                                      SettingDefinitionUpgradeResponderURL,
@@ -354,8 +379,14 @@ var (
 		SettingNameAutoSalvage:                                              SettingDefinitionAutoSalvage,
 		SettingNameAutoDeletePodWhenVolumeDetachedUnexpectedly:              SettingDefinitionAutoDeletePodWhenVolumeDetachedUnexpectedly,
 		SettingNameRegistrySecret:                                           SettingDefinitionRegistrySecret,
+		SettingNameAPIAuthentication:                                        SettingDefinitionAPIAuthentication,
+		SettingNameAuditLogDestination:                                      SettingDefinitionAuditLogDestination,
+		SettingNameAlertEvaluationInterval:                                  SettingDefinitionAlertEvaluationInterval,
+		SettingNameTracingOTLPEndpoint:                                      SettingDefinitionTracingOTLPEndpoint,
+		SettingNameTracingSamplingRatio:                                     SettingDefinitionTracingSamplingRatio,
 		SettingNameDisableSchedulingOnCordonedNode:                          SettingDefinitionDisableSchedulingOnCordonedNode,
 		SettingNameReplicaZoneSoftAntiAffinity:                              SettingDefinitionReplicaZoneSoftAntiAffinity,
+		SettingNameTopologyLevels:                                           SettingDefinitionTopologyLevels,
 		SettingNameNodeDownPodDeletionPolicy:                                SettingDefinitionNodeDownPodDeletionPolicy,
 		SettingNameNodeDrainPolicy:                                          SettingDefinitionNodeDrainPolicy,
 		SettingNameDetachManuallyAttachedVolumesWhenCordoned:                SettingDefinitionDetachManuallyAttachedVolumesWhenCordoned,
@@ -399,6 +430,8 @@ var (
 		SettingNameBackupConcurrentLimit:                                    SettingDefinitionBackupConcurrentLimit,
 		SettingNameRestoreConcurrentLimit:                                   SettingDefinitionRestoreConcurrentLimit,
 		SettingNameLogLevel:                                                 SettingDefinitionLogLevel,
+		SettingNameLogFormat:                                                SettingDefinitionLogFormat,
+		SettingNameComponentLogLevels:                                       SettingDefinitionComponentLogLevels,
 		SettingNameV1DataEngine:                                             SettingDefinitionV1DataEngine,
 		SettingNameV2DataEngine:                                             SettingDefinitionV2DataEngine,
 		SettingNameDataEngineHugepageEnabled:                                SettingDefinitionDataEngineHugepageEnabled,
@@ -416,6 +449,9 @@ var (
 		SettingNameAutoCleanupSnapshotAfterOnDemandBackupCompleted:          SettingDefinitionAutoCleanupSnapshotAfterOnDemandBackupCompleted,
 		SettingNameDefaultMinNumberOfBackingImageCopies:                     SettingDefinitionDefaultMinNumberOfBackingImageCopies,
 		SettingNameBackupExecutionTimeout:                                   SettingDefinitionBackupExecutionTimeout,
+		SettingNameBackupTargetHealthProbeInterval:                          SettingDefinitionBackupTargetHealthProbeInterval,
+		SettingNameBackupTargetDegradedErrorRateThreshold:                   SettingDefinitionBackupTargetDegradedErrorRateThreshold,
+		SettingNameBackupTargetDegradedLatencyThreshold:                     SettingDefinitionBackupTargetDegradedLatencyThreshold,
 		SettingNameRWXVolumeFastFailover:                                    SettingDefinitionRWXVolumeFastFailover,
 		SettingNameOfflineReplicaRebuilding:                                 SettingDefinitionOfflineReplicaRebuilding,
 		SettingNameReplicaRebuildingBandwidthLimit:                          SettingDefinitionReplicaRebuildingBandwidthLimit,
@@ -468,6 +504,50 @@ var (
 		},
 	}
 
+	SettingDefinitionBackupTargetHealthProbeInterval = SettingDefinition{
+		DisplayName: "Backup Target Health Probe Interval",
+		Description: "In seconds. The interval at which Longhorn probes each backup target by listing the backup volumes and reading the metadata of one of them. " +
+			"The latency and the errors of the recent probes are recorded in the backup target status. Set to 0 to disable the probing.",
+		Category:           SettingCategoryBackup,
+		Type:               SettingTypeInt,
+		Required:           true,
+		ReadOnly:           false,
+		DataEngineSpecific: false,
+		Default:            "60",
+		ValueIntRange: map[string]int{
+			ValueIntRangeMinimum: 0,
+		},
+	}
+
+	SettingDefinitionBackupTargetDegradedErrorRateThreshold = SettingDefinition{
+		DisplayName:        "Backup Target Degraded Error Rate Threshold",
+		Description:        "In percentage. The backup target is marked as degraded when the percentage of the recent health probes that failed reaches this value.",
+		Category:           SettingCategoryBackup,
+		Type:               SettingTypeInt,
+		Required:           true,
+		ReadOnly:           false,
+		DataEngineSpecific: false,
+		Default:            "20",
+		ValueIntRange: map[string]int{
+			ValueIntRangeMinimum: 1,
+			ValueIntRangeMaximum: 100,
+		},
+	}
+
+	SettingDefinitionBackupTargetDegradedLatencyThreshold = SettingDefinition{
+		DisplayName:        "Backup Target Degraded Latency Threshold",
+		Description:        "In milliseconds. The backup target is marked as degraded when the 95th percentile latency of the recent health probes reaches this value.",
+		Category:           SettingCategoryBackup,
+		Type:               SettingTypeInt,
+		Required:           true,
+		ReadOnly:           false,
+		DataEngineSpecific: false,
+		Default:            "10000",
+		ValueIntRange: map[string]int{
+			ValueIntRangeMinimum: 1,
+		},
+	}
+
 	SettingDefinitionRestoreVolumeRecurringJobs = SettingDefinition{
 		DisplayName: "Restore Volume Recurring Jobs",
 		Description: "Restore recurring jobs from the backup volume on the backup target and create recurring jobs if not exist during a backup restoration.\n\n" +
@@ -646,6 +726,22 @@ var (
 		},
 	}
 
+	SettingDefinitionStorageCapacityForecastDays = SettingDefinition{
+		DisplayName: "Storage Capacity Forecast Days",
+		Description: "Longhorn tracks the actual usage growth of the replicas on each disk and projects when the disk will become full. " +
+			"If a disk is projected to become full within this number of days, the node and the volumes with replicas on the disk get the CapacityAtRisk condition. " +
+			"Set the value to 0 to disable the alert.",
+		Category:           SettingCategoryScheduling,
+		Type:               SettingTypeInt,
+		Required:           true,
+		ReadOnly:           false,
+		DataEngineSpecific: false,
+		Default:            "7",
+		ValueIntRange: map[string]int{
+			ValueIntRangeMinimum: 0,
+		},
+	}
+
 	SettingDefinitionStorageReservedPercentageForDefaultDisk = SettingDefinition{
 		DisplayName:        "Storage Reserved Percentage For Default Disk",
 		Description:        "The reserved percentage specifies the percentage of disk space that will not be allocated to the default disk on each new Longhorn node",
@@ -857,6 +953,84 @@ var (
 		Default:            "",
 	}
 
+	SettingDefinitionAPIAuthentication = SettingDefinition{
+		DisplayName: "API Authentication",
+		Description: "If enabled, every request to the Longhorn manager API must carry a Kubernetes bearer token, either in the `Authorization: Bearer` header or as the password of the basic authentication. " +
+			"The token is authenticated by the Kubernetes TokenReview API, and each call is authorized by the Kubernetes SubjectAccessReview API against the verbs of the corresponding Longhorn resources in the Longhorn namespace. " +
+			"Unauthenticated requests are rejected with 401 and unauthorized requests with 403. \n\n" +
+			"Note that the clients of the API, including the Longhorn UI, must send a token once this setting is enabled.",
+		Category:           SettingCategoryGeneral,
+		Type:               SettingTypeBool,
+		Required:           true,
+		ReadOnly:           false,
+		DataEngineSpecific: false,
+		Default:            "false",
+	}
+
+	SettingDefinitionAuditLogDestination = SettingDefinition{
+		DisplayName: "Audit Log Destination",
+		Description: "The destination of the audit records of the mutating calls to the Longhorn manager API and of the Longhorn resource changes seen by the admission webhook. " +
+			"Each record is a JSON object with the user, the resource, the action, the change and the result. " +
+			"The user of an API request is only known if the client sends a bearer token, which is required when the API authentication is enabled. The destination can be: \n\n" +
+			"- An absolute file path in the manager container, for example `/var/lib/longhorn/logs/audit.log`. The file is rotated when it grows over 100 MiB, and 5 rotated files are kept. \n" +
+			"- The HTTP(S) URL of a collector, for example `http://audit-collector.longhorn-system:8080/`. Each record is sent by a POST request. \n\n" +
+			"Leave it empty to disable the audit log.",
+		Category:           SettingCategoryGeneral,
+		Type:               SettingTypeString,
+		Required:           false,
+		ReadOnly:           false,
+		DataEngineSpecific: false,
+		Default:            "",
+	}
+
+	SettingDefinitionAlertEvaluationInterval = SettingDefinition{
+		DisplayName: "Alert Evaluation Interval",
+		Description: "In seconds. The interval at which Longhorn evaluates the alerts, such as the degraded or faulted volumes, the disk pressure, the failed backups and the unavailable backup targets, " +
+			"and sends the firing and the resolved alerts to the AlertSinks.",
+		Category:           SettingCategoryGeneral,
+		Type:               SettingTypeInt,
+		Required:           true,
+		ReadOnly:           false,
+		DataEngineSpecific: false,
+		Default:            "30",
+		ValueIntRange: map[string]int{
+			ValueIntRangeMinimum: 5,
+		},
+	}
+
+	SettingDefinitionTracingOTLPEndpoint = SettingDefinition{
+		DisplayName: "Tracing OTLP Endpoint",
+		Description: "The HTTP(S) URL of the OpenTelemetry collector the Longhorn managers export the trace spans to over OTLP/HTTP, for example `http://localhost:4318`. " +
+			"The spans are posted to the `/v1/traces` path of the URL. \n\n" +
+			"The mutating calls to the Longhorn manager API are traced, and the trace context is propagated to the controllers via the `longhorn.io/traceparent` annotation of the Longhorn resources the calls change. " +
+			"The controllers only trace the reconciliations of the traced change that change the state. " +
+			"A `traceparent` header of the API request is honored. \n\n" +
+			"The standard `OTEL_RESOURCE_ATTRIBUTES` and `OTEL_SERVICE_NAME` environment variables of the Longhorn manager pods add to the resource attributes of the spans. \n\n" +
+			"Leave it empty to disable tracing.",
+		Category:           SettingCategoryGeneral,
+		Type:               SettingTypeString,
+		Required:           false,
+		ReadOnly:           false,
+		DataEngineSpecific: false,
+		Default:            "",
+	}
+
+	SettingDefinitionTracingSamplingRatio = SettingDefinition{
+		DisplayName: "Tracing Sampling Ratio",
+		Description: "The ratio of the traces started by the Longhorn manager API that are sampled, from 0 to 1. " +
+			"The traces continuing the `traceparent` header of an API request follow the sampling decision of the caller.",
+		Category:           SettingCategoryGeneral,
+		Type:               SettingTypeFloat,
+		Required:           true,
+		ReadOnly:           false,
+		DataEngineSpecific: false,
+		Default:            "1",
+		ValueFloatRange: map[string]float64{
+			ValueFloatRangeMinimum: 0,
+			ValueFloatRangeMaximum: 1,
+		},
+	}
+
 	SettingDefinitionDisableSchedulingOnCordonedNode = SettingDefinition{
 		DisplayName:        "Disable Scheduling On Cordoned Node",
 		Description:        `Disable Longhorn manager to schedule replica on Kubernetes cordoned node`,
@@ -879,6 +1053,22 @@ var (
 		Default:            "true",
 	}
 
+	SettingDefinitionTopologyLevels = SettingDefinition{
+		DisplayName: "Topology Levels",
+		Description: "The topology levels beyond region and zone, such as rack, row, or power domain, and the Kubernetes node labels they are taken from. " +
+			"Volumes can use the levels in the topology spread constraints to control how the replicas are spread across the topology domains. " +
+			"Nodes without the label are treated as in the same domain. " +
+			"Multiple levels are separated by semicolon. For example: \n\n" +
+			"* `rack:topology.longhorn.io/rack; row:topology.longhorn.io/row` \n\n" +
+			"The labels are also reported as the accessible topology of the CSI node.",
+		Category:           SettingCategoryScheduling,
+		Type:               SettingTypeString,
+		Required:           false,
+		ReadOnly:           false,
+		DataEngineSpecific: false,
+		Default:            "",
+	}
+
 	SettingDefinitionNodeDownPodDeletionPolicy = SettingDefinition{
 		DisplayName: "Pod Deletion Policy When Node is Down",
 		Description: "Defines the Longhorn action when a Volume is stuck with a StatefulSet/Deployment Pod on a node that is down.\n" +
@@ -1527,6 +1717,32 @@ var (
 		Choices:            []any{"Panic", "Fatal", "Error", "Warn", "Info", "Debug", "Trace"},
 	}
 
+	SettingDefinitionLogFormat = SettingDefinition{
+		DisplayName: "Log Format",
+		Description: "The format Text or JSON of the logs of longhorn manager. By default Text. " +
+			"The JSON format enabled by the --log-json flag of longhorn manager takes precedence over this setting.",
+		Category:           SettingCategoryGeneral,
+		Type:               SettingTypeString,
+		Required:           true,
+		ReadOnly:           false,
+		DataEngineSpecific: false,
+		Default:            "Text",
+		Choices:            []any{"Text", "JSON"},
+	}
+
+	SettingDefinitionComponentLogLevels = SettingDefinition{
+		DisplayName: "Component Log Levels",
+		Description: "The log levels of the controllers of longhorn manager, overriding the setting Log Level for them. " +
+			"The levels are separated by semicolons in the form of <controller>=<level>, for example `engine-controller=Debug; volume-controller=Trace`. " +
+			"The controllers not listed use the setting Log Level. By default empty.",
+		Category:           SettingCategoryGeneral,
+		Type:               SettingTypeString,
+		Required:           false,
+		ReadOnly:           false,
+		DataEngineSpecific: false,
+		Default:            "",
+	}
+
 	SettingDefinitionV1DataEngine = SettingDefinition{
 		DisplayName: "V1 Data Engine",
 		Description: "Setting that allows you to enable the V1 Data Engine. \n\n" +
@@ -2463,6 +2679,24 @@ func validateSettingString(name SettingName, definition SettingDefinition, value
 			if _, err := UnmarshalNodeSelector(strValue); err != nil {
 				return errors.Wrapf(err, "the value of %v is invalid", name)
 			}
+		case SettingNameTopologyLevels:
+			if _, err := UnmarshalTopologyLevels(strValue); err != nil {
+				return errors.Wrapf(err, "the value of %v is invalid", name)
+			}
+		case SettingNameAuditLogDestination:
+			if err := ValidateAuditLogDestination(strValue); err != nil {
+				return errors.Wrapf(err, "the value of %v is invalid", name)
+			}
+
+		case SettingNameComponentLogLevels:
+			if err := ValidateComponentLogLevels(strValue); err != nil {
+				return errors.Wrapf(err, "the value of %v is invalid", name)
+			}
+
+		case SettingNameTracingOTLPEndpoint:
+			if err := ValidateTracingOTLPEndpoint(strValue); err != nil {
+				return errors.Wrapf(err, "the value of %v is invalid", name)
+			}
 
 		case SettingNameStorageNetwork:
 			if err := ValidateStorageNetwork(strValue); err != nil {
@@ -0,0 +1,73 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+const (
+	// LonghornLabelSnapshotExportOf is the label of a backing image staging the export of a snapshot, with the name of
+	// the volume of the snapshot.
+	LonghornLabelSnapshotExportOf = "snapshot-export-of"
+	// LonghornLabelSnapshotExportSnapshot is the label of a backing image staging the export of a snapshot, with the
+	// name of the snapshot.
+	LonghornLabelSnapshotExportSnapshot = "snapshot-export-snapshot"
+
+	SnapshotExportExpireAtAnnotationKeySuffix = "snapshot-export-expire-at"
+
+	// SnapshotExportTTL is how long the file of an exported snapshot is kept after it's last requested.
+	SnapshotExportTTL = 24 * time.Hour
+
+	SnapshotExportFormatRAW   = "raw"
+	SnapshotExportFormatQCOW2 = "qcow2"
+
+	// VolumeImportDeviceDirectory is the directory of the block devices of the volumes in the longhorn-manager pod,
+	// which mounts the /dev of the host at /host/dev.
+	VolumeImportDeviceDirectory = "/host/dev/longhorn"
+
+	volumeImportAttachmentIDPrefix = "volume-import-"
+)
+
+// GetSnapshotExportBackingImageName returns the name of the backing image staging the export of the snapshot in the
+// format.
+func GetSnapshotExportBackingImageName(snapshotName, format string) string {
+	return fmt.Sprintf("export-%s-%s", snapshotName, format)
+}
+
+// GetVolumeImportAttachmentID returns the ID of the attachment ticket of a volume while a file is imported to it.
+func GetVolumeImportAttachmentID(volumeName string) string {
+	return volumeImportAttachmentIDPrefix + volumeName
+}
+
+// ValidateSnapshotExportFormat returns an error if the snapshot can't be exported in the format.
+func ValidateSnapshotExportFormat(format string) error {
+	if format != SnapshotExportFormatRAW && format != SnapshotExportFormatQCOW2 {
+		return fmt.Errorf("invalid snapshot export format %q, must be %v or %v", format, SnapshotExportFormatRAW, SnapshotExportFormatQCOW2)
+	}
+	return nil
+}
+
+// IsSnapshotExportBackingImage returns true if the backing image only stages the export of a snapshot.
+func IsSnapshotExportBackingImage(bi *longhorn.BackingImage) bool {
+	return bi.Labels[GetLonghornLabelKey(LonghornLabelSnapshotExportOf)] != ""
+}
+
+// GetSnapshotExportLabels returns the labels of the backing image staging the export of the snapshot.
+func GetSnapshotExportLabels(volumeName, snapshotName string) map[string]string {
+	labels := GetBackingImageLabels()
+	labels[GetLonghornLabelKey(LonghornLabelSnapshotExportOf)] = volumeName
+	labels[GetLonghornLabelKey(LonghornLabelSnapshotExportSnapshot)] = snapshotName
+	return labels
+}
+
+// GetSnapshotExportExpireAt returns the time the backing image staging the export of a snapshot is garbage collected.
+func GetSnapshotExportExpireAt(bi *longhorn.BackingImage) (time.Time, error) {
+	value := bi.Annotations[GetLonghornLabelKey(SnapshotExportExpireAtAnnotationKeySuffix)]
+	expireAt, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid expiration time %q of snapshot export backing image %v: %w", value, bi.Name, err)
+	}
+	return expireAt, nil
+}
@@ -0,0 +1,101 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+// StorageQuotaExceededCode is the cause type of the admission status and the code of the API error of a request
+// rejected by a storage quota, so that the callers of the API, like the CSI plugin, can tell the rejection from the
+// other failures.
+const StorageQuotaExceededCode = "StorageQuotaExceeded"
+
+// StorageQuotaExceededError is returned by CheckStorageQuota if a request exceeds the limits of a storage quota.
+type StorageQuotaExceededError struct {
+	Namespace  string
+	Quota      string
+	Violations []string
+}
+
+func (e *StorageQuotaExceededError) Error() string {
+	return fmt.Sprintf("namespace %v exceeds the storage quota %v: %v", e.Namespace, e.Quota, strings.Join(e.Violations, ", "))
+}
+
+// ValidateStorageQuotaSpec returns an error if the limits of the storage quota are invalid.
+func ValidateStorageQuotaSpec(spec *longhorn.StorageQuotaSpec) error {
+	if spec.Namespace == "" {
+		return fmt.Errorf("namespace is required")
+	}
+	if spec.MaxProvisionedSize < 0 {
+		return fmt.Errorf("invalid maximum provisioned size %v", spec.MaxProvisionedSize)
+	}
+	if spec.MaxSnapshotSize < 0 {
+		return fmt.Errorf("invalid maximum snapshot size %v", spec.MaxSnapshotSize)
+	}
+	if spec.MaxSnapshotCount < 0 {
+		return fmt.Errorf("invalid maximum snapshot count %v", spec.MaxSnapshotCount)
+	}
+	if spec.MaxBackupSize < 0 {
+		return fmt.Errorf("invalid maximum backup size %v", spec.MaxBackupSize)
+	}
+	return nil
+}
+
+// GetBackupPVCNamespace returns the namespace of the PVC of the volume when the backup was taken, which is recorded in
+// the KubernetesStatus label of the backup. Returns an empty string if unknown.
+func GetBackupPVCNamespace(backup *longhorn.Backup) string {
+	statusJSON, ok := backup.Status.Labels[KubernetesStatusLabel]
+	if !ok {
+		return ""
+	}
+	kubeStatus := &longhorn.KubernetesStatus{}
+	if err := json.Unmarshal([]byte(statusJSON), kubeStatus); err != nil {
+		return ""
+	}
+	return kubeStatus.Namespace
+}
+
+// GetStorageQuotaViolations returns the messages of the limits of the storage quota exceeded by the usage.
+func GetStorageQuotaViolations(spec *longhorn.StorageQuotaSpec, usage *longhorn.StorageQuotaUsage) []string {
+	var violations []string
+	if spec.MaxProvisionedSize > 0 && usage.ProvisionedSize > spec.MaxProvisionedSize {
+		violations = append(violations, fmt.Sprintf("provisioned size %v exceeds the maximum %v", usage.ProvisionedSize, spec.MaxProvisionedSize))
+	}
+	if spec.MaxSnapshotSize > 0 && usage.SnapshotSize > spec.MaxSnapshotSize {
+		violations = append(violations, fmt.Sprintf("snapshot size %v exceeds the maximum %v", usage.SnapshotSize, spec.MaxSnapshotSize))
+	}
+	if spec.MaxSnapshotCount > 0 && usage.SnapshotCount > spec.MaxSnapshotCount {
+		violations = append(violations, fmt.Sprintf("snapshot count %v exceeds the maximum %v", usage.SnapshotCount, spec.MaxSnapshotCount))
+	}
+	if spec.MaxBackupSize > 0 && usage.BackupSize > spec.MaxBackupSize {
+		violations = append(violations, fmt.Sprintf("backup size %v exceeds the maximum %v", usage.BackupSize, spec.MaxBackupSize))
+	}
+	return violations
+}
+
+// CheckStorageQuota returns an error if adding the increment to the usage exceeds any limit of the storage quota. Only
+// the limits of the resources the increment consumes are checked, so that a namespace already over one limit can still
+// consume the other resources.
+func CheckStorageQuota(quota *longhorn.StorageQuota, usage, increment *longhorn.StorageQuotaUsage) error {
+	spec := &quota.Spec
+	var violations []string
+	if increment.ProvisionedSize > 0 && spec.MaxProvisionedSize > 0 && usage.ProvisionedSize+increment.ProvisionedSize > spec.MaxProvisionedSize {
+		violations = append(violations, fmt.Sprintf("provisioned size %v plus %v exceeds the maximum %v", usage.ProvisionedSize, increment.ProvisionedSize, spec.MaxProvisionedSize))
+	}
+	if increment.SnapshotSize > 0 && spec.MaxSnapshotSize > 0 && usage.SnapshotSize+increment.SnapshotSize > spec.MaxSnapshotSize {
+		violations = append(violations, fmt.Sprintf("snapshot size %v plus %v exceeds the maximum %v", usage.SnapshotSize, increment.SnapshotSize, spec.MaxSnapshotSize))
+	}
+	if increment.SnapshotCount > 0 && spec.MaxSnapshotCount > 0 && usage.SnapshotCount+increment.SnapshotCount > spec.MaxSnapshotCount {
+		violations = append(violations, fmt.Sprintf("snapshot count %v plus %v exceeds the maximum %v", usage.SnapshotCount, increment.SnapshotCount, spec.MaxSnapshotCount))
+	}
+	if increment.BackupSize > 0 && spec.MaxBackupSize > 0 && usage.BackupSize+increment.BackupSize > spec.MaxBackupSize {
+		violations = append(violations, fmt.Sprintf("backup size %v plus %v exceeds the maximum %v", usage.BackupSize, increment.BackupSize, spec.MaxBackupSize))
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return &StorageQuotaExceededError{Namespace: spec.Namespace, Quota: quota.Name, Violations: violations}
+}
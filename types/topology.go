@@ -0,0 +1,154 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+const (
+	TopologyLevelRegion = "region"
+	TopologyLevelZone   = "zone"
+
+	topologySpreadFieldMaxReplicasPerDomain = "maxReplicasPerDomain"
+	topologySpreadFieldMinDomains           = "minDomains"
+)
+
+// UnmarshalTopologyLevels parses the topology-levels setting, in the form of `level1:label-key1; level2:label-key2`,
+// into a map from the topology level to the node label key.
+func UnmarshalTopologyLevels(topologyLevelsSetting string) (map[string]string, error) {
+	levels := map[string]string{}
+
+	topologyLevelsSetting = strings.Trim(topologyLevelsSetting, " ")
+	if topologyLevelsSetting == "" {
+		return levels, nil
+	}
+
+	labelKeys := map[string]bool{}
+	for _, item := range strings.Split(topologyLevelsSetting, ";") {
+		level, labelKey, err := validateAndUnmarshalLabel(item)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal topology levels")
+		}
+		if errs := validation.IsDNS1123Label(level); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid topology level %v: %v", level, strings.Join(errs, ", "))
+		}
+		if level == TopologyLevelRegion || level == TopologyLevelZone {
+			return nil, fmt.Errorf("topology level %v is built-in and cannot be redefined", level)
+		}
+		if errs := validation.IsQualifiedName(labelKey); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid label key %v of topology level %v: %v", labelKey, level, strings.Join(errs, ", "))
+		}
+		if _, exists := levels[level]; exists {
+			return nil, fmt.Errorf("duplicate topology level %v", level)
+		}
+		if labelKeys[labelKey] {
+			return nil, fmt.Errorf("label key %v is used by multiple topology levels", labelKey)
+		}
+		levels[level] = labelKey
+		labelKeys[labelKey] = true
+	}
+
+	return levels, nil
+}
+
+// GetTopologyDomains returns the topology domains of a node from its labels, keyed by the topology level. It returns
+// nil if no topology level is defined.
+func GetTopologyDomains(labels map[string]string, levels map[string]string) map[string]string {
+	if len(levels) == 0 {
+		return nil
+	}
+	domains := map[string]string{}
+	for level, labelKey := range levels {
+		domains[level] = labels[labelKey]
+	}
+	return domains
+}
+
+// GetNodeTopologyDomain returns the domain of the node at the topology level. Nodes without a domain at the level
+// return an empty string, so they are treated as in the same domain.
+func GetNodeTopologyDomain(node *longhorn.Node, level string) string {
+	switch level {
+	case TopologyLevelRegion:
+		return node.Status.Region
+	case TopologyLevelZone:
+		return node.Status.Zone
+	}
+	return node.Status.Topology[level]
+}
+
+// ValidateTopologySpreadConstraints checks the topology spread constraints of a volume. The levels are checked against
+// the topology levels defined in the setting, and the built-in region and zone levels.
+func ValidateTopologySpreadConstraints(constraints []longhorn.TopologySpreadConstraint, levels map[string]string) error {
+	seen := map[string]bool{}
+	for _, constraint := range constraints {
+		level := constraint.TopologyLevel
+		if level != TopologyLevelRegion && level != TopologyLevelZone {
+			if _, ok := levels[level]; !ok {
+				return fmt.Errorf("topology level %q is not defined in setting %v", level, SettingNameTopologyLevels)
+			}
+		}
+		if seen[level] {
+			return fmt.Errorf("duplicate topology spread constraint for level %v", level)
+		}
+		seen[level] = true
+
+		if constraint.MaxReplicasPerDomain < 0 || constraint.MinDomains < 0 {
+			return fmt.Errorf("invalid topology spread constraint for level %v: values cannot be negative", level)
+		}
+		if constraint.MaxReplicasPerDomain == 0 && constraint.MinDomains == 0 {
+			return fmt.Errorf("invalid topology spread constraint for level %v: either %v or %v should be set",
+				level, topologySpreadFieldMaxReplicasPerDomain, topologySpreadFieldMinDomains)
+		}
+	}
+	return nil
+}
+
+// ParseTopologySpreadConstraints parses the topology spread constraints in the form used by the StorageClass
+// parameter, for example `rack:maxReplicasPerDomain=1; zone:minDomains=2`. The fields of a level are separated by
+// comma.
+func ParseTopologySpreadConstraints(value string) ([]longhorn.TopologySpreadConstraint, error) {
+	constraints := []longhorn.TopologySpreadConstraint{}
+
+	value = strings.Trim(value, " ")
+	if value == "" {
+		return constraints, nil
+	}
+
+	for _, item := range strings.Split(value, ";") {
+		level, fields, found := strings.Cut(strings.Trim(item, " "), ":")
+		if !found {
+			return nil, fmt.Errorf("invalid topology spread constraint %v: should contain the separator ':'", item)
+		}
+		constraint := longhorn.TopologySpreadConstraint{
+			TopologyLevel: strings.Trim(level, " "),
+		}
+		for _, field := range strings.Split(fields, ",") {
+			key, rawValue, found := strings.Cut(strings.Trim(field, " "), "=")
+			if !found {
+				return nil, fmt.Errorf("invalid field %v of topology spread constraint %v: should contain the separator '='", field, item)
+			}
+			number, err := strconv.Atoi(strings.Trim(rawValue, " "))
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid value of field %v of topology spread constraint %v", key, item)
+			}
+			switch strings.Trim(key, " ") {
+			case topologySpreadFieldMaxReplicasPerDomain:
+				constraint.MaxReplicasPerDomain = number
+			case topologySpreadFieldMinDomains:
+				constraint.MinDomains = number
+			default:
+				return nil, fmt.Errorf("unknown field %v of topology spread constraint %v", key, item)
+			}
+		}
+		constraints = append(constraints, constraint)
+	}
+
+	return constraints, nil
+}
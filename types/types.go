@@ -3,10 +3,13 @@ package types
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"net"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -19,6 +22,7 @@ import (
 
 	lhns "github.com/longhorn/go-common-libs/ns"
 
+	"github.com/longhorn/longhorn-manager/logging"
 	"github.com/longhorn/longhorn-manager/util"
 
 	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
@@ -119,6 +123,8 @@ const (
 	TLSCertFile             = "tls.crt"
 	TLSKeyFile              = "tls.key"
 
+	ServiceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
 	DefaultBackupTargetName = "default"
 
 	LonghornNodeKey            = "longhornnode"
@@ -196,6 +202,8 @@ const (
 	LonghornLabelVersion                    = "version"
 	LonghornLabelAdmissionWebhook           = "admission-webhook"
 	LonghornLabelConversionWebhook          = "conversion-webhook"
+	LonghornLabelPVCNamespace               = "pvc-namespace"
+	LonghornLabelReplacementDiskRebuild     = "replacement-disk-rebuild"
 
 	LonghornRecoveryBackendServiceName = "longhorn-recovery-backend"
 
@@ -216,13 +224,14 @@ const (
 
 	DefaultDiskPrefix = "default-disk-"
 
-	DeprecatedProvisionerName           = "rancher.io/longhorn"
-	DepracatedDriverName                = "io.rancher.longhorn"
-	DefaultStorageClassConfigMapName    = "longhorn-storageclass"
-	DefaultDefaultSettingConfigMapName  = "longhorn-default-setting"
-	DefaultDefaultResourceConfigMapName = "longhorn-default-resource"
-	DefaultStorageClassName             = "longhorn"
-	ControlPlaneName                    = "longhorn-manager"
+	DeprecatedProvisionerName            = "rancher.io/longhorn"
+	DepracatedDriverName                 = "io.rancher.longhorn"
+	DefaultStorageClassConfigMapName     = "longhorn-storageclass"
+	DefaultDefaultSettingConfigMapName   = "longhorn-default-setting"
+	DefaultDefaultResourceConfigMapName  = "longhorn-default-resource"
+	DefaultEnvironmentCheckConfigMapName = "longhorn-environment-check"
+	DefaultStorageClassName              = "longhorn"
+	ControlPlaneName                     = "longhorn-manager"
 
 	DefaultRecurringJobConcurrency = 10
 
@@ -262,6 +271,8 @@ const (
 	BackupStoreTypeCIFS   = "cifs"
 	BackupStoreTypeNFS    = "nfs"
 	BackupStoreTypeAZBlob = "azblob"
+	// BackupStoreTypeFile stores the backups in a directory of an RWX volume, which is accessed through its share.
+	BackupStoreTypeFile = "file"
 
 	AWSIAMRoleAnnotation = "iam.amazonaws.com/role"
 	AWSIAMRoleArn        = "AWS_IAM_ROLE_ARN"
@@ -270,6 +281,19 @@ const (
 	AWSEndPoint          = "AWS_ENDPOINTS"
 	AWSCert              = "AWS_CERT"
 
+	// AWSRoleArn is the role assumed with the web identity token in AWSWebIdentityTokenFile. The credentials are
+	// refreshed by the SDK before they expire, reading the token file again.
+	AWSRoleArn              = "AWS_ROLE_ARN"
+	AWSWebIdentityTokenFile = "AWS_WEB_IDENTITY_TOKEN_FILE"
+	AWSRoleSessionName      = "AWS_ROLE_SESSION_NAME"
+	// AWSSTSEndpoint is the STS endpoint of an S3-compatible backup store that isn't AWS.
+	AWSSTSEndpoint = "AWS_ENDPOINT_URL_STS"
+
+	// DefaultAWSWebIdentityTokenFile is where the service account token is projected into the longhorn-manager,
+	// instance manager and backing image data source pods when a backup target credential requires it, see
+	// RequireProjectedWebIdentityToken.
+	DefaultAWSWebIdentityTokenFile = WebIdentityTokenDirectoryInContainer + "/" + webIdentityTokenPath
+
 	CIFSUsername = "CIFS_USERNAME"
 	CIFSPassword = "CIFS_PASSWORD"
 
@@ -913,7 +937,7 @@ func ValidateDataLocalityAndReplicaCount(mode longhorn.DataLocality, count int)
 }
 
 func ValidateDataLocalityAndAccessMode(locality longhorn.DataLocality, migratable bool, mode longhorn.AccessMode) error {
-	if mode == longhorn.AccessModeReadWriteMany && !migratable && locality == longhorn.DataLocalityStrictLocal {
+	if IsSharedAccessMode(mode) && !migratable && locality == longhorn.DataLocalityStrictLocal {
 		return fmt.Errorf("access mode %v (migratable: %v) is incompatible with data locality %v mode", mode, migratable, longhorn.DataLocalityStrictLocal)
 	}
 	return nil
@@ -939,12 +963,18 @@ func ValidateDataLocality(mode longhorn.DataLocality) error {
 }
 
 func ValidateAccessMode(mode longhorn.AccessMode) error {
-	if mode != longhorn.AccessModeReadWriteMany && mode != longhorn.AccessModeReadWriteOnce {
+	if mode != longhorn.AccessModeReadWriteMany && mode != longhorn.AccessModeReadWriteOnce && mode != longhorn.AccessModeReadOnlyMany {
 		return fmt.Errorf("invalid access mode: %v", mode)
 	}
 	return nil
 }
 
+// IsSharedAccessMode returns true if a volume in the access mode is used by multiple nodes through a share manager,
+// unless the volume is migratable.
+func IsSharedAccessMode(mode longhorn.AccessMode) bool {
+	return mode == longhorn.AccessModeReadWriteMany || mode == longhorn.AccessModeReadOnlyMany
+}
+
 func ValidateStorageNetwork(value string) (err error) {
 	if value == CniNetworkNone {
 		return nil
@@ -957,6 +987,42 @@ func ValidateStorageNetwork(value string) (err error) {
 	return nil
 }
 
+// ValidateAuditLogDestination checks that the audit log destination is either an absolute file path in the manager
+// container, or the HTTP(S) URL of a collector.
+func ValidateAuditLogDestination(value string) error {
+	if value == "" || filepath.IsAbs(value) {
+		return nil
+	}
+
+	u, err := url.Parse(value)
+	if err != nil {
+		return errors.Wrapf(err, "invalid audit log destination %v", value)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("audit log destination %v should be an absolute file path or an HTTP(S) URL", value)
+	}
+	return nil
+}
+
+func ValidateTracingOTLPEndpoint(value string) error {
+	if value == "" {
+		return nil
+	}
+
+	u, err := url.Parse(value)
+	if err != nil {
+		return errors.Wrapf(err, "invalid tracing OTLP endpoint %v", value)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("tracing OTLP endpoint %v should be an HTTP(S) URL", value)
+	}
+	return nil
+}
+
+func ValidateComponentLogLevels(value string) error {
+	return logging.ValidateComponentLevels(value)
+}
+
 func ValidateSnapshotDataIntegrity(mode string) error {
 	if mode != string(longhorn.SnapshotDataIntegrityDisabled) &&
 		mode != string(longhorn.SnapshotDataIntegrityEnabled) &&
@@ -1046,6 +1112,28 @@ func ValidateBackupBlockSize(volSize int64, backupBlockSize int64) error {
 	return nil
 }
 
+func ValidateVolumeTieringPolicy(policy longhorn.VolumeTieringPolicy) error {
+	if policy.ColdAfterDetachedDays < 0 {
+		return fmt.Errorf("coldAfterDetachedDays %v cannot be negative", policy.ColdAfterDetachedDays)
+	}
+	if policy.ColdBelowIOPS < 0 {
+		return fmt.Errorf("coldBelowIOPS %v cannot be negative", policy.ColdBelowIOPS)
+	}
+	if policy.ColdAfterDetachedDays == 0 && len(policy.HotDiskSelector) == 0 && len(policy.ColdDiskSelector) == 0 &&
+		policy.ColdBelowIOPS == 0 {
+		return nil
+	}
+	if policy.ColdAfterDetachedDays == 0 || len(policy.HotDiskSelector) == 0 || len(policy.ColdDiskSelector) == 0 {
+		return fmt.Errorf("tiering policy requires hotDiskSelector, coldDiskSelector and a positive coldAfterDetachedDays")
+	}
+	hotDiskSelector := slices.Sorted(slices.Values(policy.HotDiskSelector))
+	coldDiskSelector := slices.Sorted(slices.Values(policy.ColdDiskSelector))
+	if slices.Equal(hotDiskSelector, coldDiskSelector) {
+		return fmt.Errorf("hotDiskSelector and coldDiskSelector of the tiering policy cannot be the same")
+	}
+	return nil
+}
+
 func ValidateReplicaRebuildingBandwidthLimit(dataEengine longhorn.DataEngineType, replicaRebuildingBandwidthLimit int64) error {
 	if replicaRebuildingBandwidthLimit == 0 {
 		return nil
@@ -1336,6 +1424,45 @@ func IsSelectorsInTags(tags, selectors []string, allowEmptySelector bool) bool {
 	return true
 }
 
+// IsDiskCapacityAtRisk returns true if the disk is projected to become full within the forecast days.
+func IsDiskCapacityAtRisk(diskStatus *longhorn.DiskStatus, forecastDays int64, now time.Time) bool {
+	if diskStatus == nil || forecastDays <= 0 || diskStatus.ProjectedFullTime == "" {
+		return false
+	}
+	projectedFullTime, err := time.Parse(time.RFC3339, diskStatus.ProjectedFullTime)
+	if err != nil {
+		return false
+	}
+	return projectedFullTime.Before(now.Add(time.Duration(forecastDays) * 24 * time.Hour))
+}
+
+// IsVolumeTieringEnabled returns true if the volume has a complete tiering policy.
+func IsVolumeTieringEnabled(v *longhorn.Volume) bool {
+	policy := v.Spec.TieringPolicy
+	return policy.ColdAfterDetachedDays > 0 && len(policy.HotDiskSelector) > 0 && len(policy.ColdDiskSelector) > 0
+}
+
+// GetVolumeDiskSelector returns the disk selector used for scheduling the replicas of the volume, which is the volume
+// disk selector combined with the disk tags of the current storage tier.
+func GetVolumeDiskSelector(v *longhorn.Volume) []string {
+	if !IsVolumeTieringEnabled(v) {
+		return v.Spec.DiskSelector
+	}
+
+	tierSelector := v.Spec.TieringPolicy.HotDiskSelector
+	if v.Status.StorageTier == longhorn.StorageTierCold {
+		tierSelector = v.Spec.TieringPolicy.ColdDiskSelector
+	}
+
+	selector := append([]string{}, v.Spec.DiskSelector...)
+	for _, tag := range tierSelector {
+		if !util.Contains(selector, tag) {
+			selector = append(selector, tag)
+		}
+	}
+	return selector
+}
+
 func GetKubernetesProviderNameFromURL(providerURL string) string {
 	if providerURL == "" {
 		return ValueEmpty
@@ -1355,7 +1482,7 @@ func GetBackupTargetSchemeFromURL(backupTargetURL string) string {
 
 	scheme := util.GetSchemeFromURL(backupTargetURL)
 	switch scheme {
-	case "azblob", "cifs", "nfs", "s3":
+	case "azblob", "cifs", "file", "nfs", "s3":
 		return scheme
 	default:
 		return ValueUnknown
@@ -1411,3 +1538,24 @@ func GetBackingImageMonitorName(imName string) string {
 func GetV2BackingImageWithDiskUUIDName(biName, v2DiskUUID string) string {
 	return fmt.Sprintf("%v-%v", biName, v2DiskUUID)
 }
+
+// GetProgressETASeconds returns the estimated seconds from now until a rebuild or a snapshot purge completes, or -1 if
+// the rate is not known yet. The time elapsed since the progress was sampled is counted down, so that the ETA keeps
+// decreasing while the progress doesn't change.
+func GetProgressETASeconds(totalBytes, estimatedTransferredBytes, bytesPerSecond int64, sampledAt string, now time.Time) int64 {
+	if bytesPerSecond <= 0 {
+		return -1
+	}
+	remaining := totalBytes - estimatedTransferredBytes
+	if remaining <= 0 {
+		return 0
+	}
+	eta := float64(remaining) / float64(bytesPerSecond)
+	if sampleTime, err := util.ParseTime(sampledAt); err == nil {
+		eta -= now.Sub(sampleTime).Seconds()
+	}
+	if eta <= 0 {
+		return 0
+	}
+	return int64(math.Ceil(eta))
+}
@@ -1,15 +1,24 @@
 package types
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	. "gopkg.in/check.v1"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
 )
 
 const (
@@ -233,3 +242,1508 @@ func (s *TestSuite) TestGenerateEngineNameForVolume(c *C) {
 		c.Assert(actual, Equals, testCase.expectedEngineName, Commentf(TestErrResultFmt, testName))
 	}
 }
+
+func (s *TestSuite) TestGetEnvironmentChecks(c *C) {
+	type testCase struct {
+		input string
+
+		expectedChecks  []EnvironmentCheck
+		expectedInvalid int
+		expectError     bool
+	}
+	testCases := map[string]testCase{
+		"empty ConfigMap": {
+			input:          "",
+			expectedChecks: []EnvironmentCheck{},
+		},
+		"valid checks with default severity": {
+			input: `
+- name: IscsidRunning
+  command: ["systemctl", "is-active", "iscsid"]
+  expectedOutput: "^active$"
+  remediation: "Run systemctl enable --now iscsid"
+- name: HugePagesSysctl
+  command: ["sysctl", "-n", "vm.nr_hugepages"]
+  severity: warning
+  dataEngine: v2
+`,
+			expectedChecks: []EnvironmentCheck{
+				{
+					Name:           "IscsidRunning",
+					Command:        []string{"systemctl", "is-active", "iscsid"},
+					ExpectedOutput: "^active$",
+					Severity:       EnvironmentCheckSeverityError,
+					Remediation:    "Run systemctl enable --now iscsid",
+				},
+				{
+					Name:       "HugePagesSysctl",
+					Command:    []string{"sysctl", "-n", "vm.nr_hugepages"},
+					Severity:   EnvironmentCheckSeverityWarning,
+					DataEngine: "v2",
+				},
+			},
+		},
+		"invalid checks are skipped": {
+			input: `
+- name: Multipathd
+  command: ["true"]
+- name: lowerCase
+  command: ["true"]
+- name: NoCommand
+- name: BadSeverity
+  command: ["true"]
+  severity: fatal
+- name: BadExpectedOutput
+  command: ["true"]
+  expectedOutput: "("
+- name: Duplicated
+  command: ["true"]
+- name: Duplicated
+  command: ["false"]
+`,
+			expectedChecks: []EnvironmentCheck{
+				{
+					Name:     "Duplicated",
+					Command:  []string{"true"},
+					Severity: EnvironmentCheckSeverityError,
+				},
+			},
+			expectedInvalid: 6,
+		},
+		"malformed YAML": {
+			input:       "name: [",
+			expectError: true,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		fmt.Printf("testing %v\n", testName)
+
+		cm := &corev1.ConfigMap{Data: map[string]string{EnvironmentCheckYAMLFileName: testCase.input}}
+		checks, invalid, err := GetEnvironmentChecks(cm)
+		if testCase.expectError {
+			c.Assert(err, NotNil)
+			continue
+		}
+		c.Assert(err, IsNil, Commentf(TestErrErrorFmt, testName, err))
+		c.Assert(len(invalid), Equals, testCase.expectedInvalid, Commentf(TestErrResultFmt, testName))
+		c.Assert(reflect.DeepEqual(checks, testCase.expectedChecks), Equals, true, Commentf(TestErrResultFmt, testName))
+	}
+}
+
+func (s *TestSuite) TestIsEnvironmentCheckCondition(c *C) {
+	type testCase struct {
+		condition longhorn.Condition
+
+		expected bool
+	}
+	testCases := map[string]testCase{
+		"passed check": {
+			condition: longhorn.Condition{Type: "IscsidRunning", Reason: longhorn.NodeConditionReasonEnvironmentCheckPassed},
+			expected:  true,
+		},
+		"failed check": {
+			condition: longhorn.Condition{Type: "IscsidRunning", Reason: longhorn.NodeConditionReasonEnvironmentCheckFailed},
+			expected:  true,
+		},
+		"check with namespace executor error": {
+			condition: longhorn.Condition{Type: "IscsidRunning", Reason: longhorn.NodeConditionReasonNamespaceExecutorErr},
+			expected:  true,
+		},
+		"built-in check with namespace executor error": {
+			condition: longhorn.Condition{Type: longhorn.NodeConditionTypeMultipathd, Reason: longhorn.NodeConditionReasonNamespaceExecutorErr},
+			expected:  false,
+		},
+		"built-in condition": {
+			condition: longhorn.Condition{Type: longhorn.NodeConditionTypeReady, Reason: longhorn.NodeConditionReasonManagerPodDown},
+			expected:  false,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		fmt.Printf("testing %v\n", testName)
+
+		c.Assert(IsEnvironmentCheckCondition(testCase.condition), Equals, testCase.expected, Commentf(TestErrResultFmt, testName))
+	}
+}
+
+func (s *TestSuite) TestGetVolumeDiskSelector(c *C) {
+	type testCase struct {
+		diskSelector  []string
+		tieringPolicy longhorn.VolumeTieringPolicy
+		storageTier   longhorn.StorageTier
+
+		expected []string
+	}
+	tieringPolicy := longhorn.VolumeTieringPolicy{
+		HotDiskSelector:       []string{"nvme"},
+		ColdDiskSelector:      []string{"hdd"},
+		ColdAfterDetachedDays: 7,
+	}
+	testCases := map[string]testCase{
+		"tiering disabled": {
+			diskSelector: []string{"ssd"},
+			expected:     []string{"ssd"},
+		},
+		"hot tier": {
+			diskSelector:  []string{"fast"},
+			tieringPolicy: tieringPolicy,
+			storageTier:   longhorn.StorageTierHot,
+			expected:      []string{"fast", "nvme"},
+		},
+		"cold tier": {
+			diskSelector:  []string{},
+			tieringPolicy: tieringPolicy,
+			storageTier:   longhorn.StorageTierCold,
+			expected:      []string{"hdd"},
+		},
+		"tier not decided yet": {
+			tieringPolicy: tieringPolicy,
+			expected:      []string{"nvme"},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		fmt.Printf("testing %v\n", testName)
+
+		volume := &longhorn.Volume{
+			Spec: longhorn.VolumeSpec{
+				DiskSelector:  testCase.diskSelector,
+				TieringPolicy: testCase.tieringPolicy,
+			},
+			Status: longhorn.VolumeStatus{
+				StorageTier: testCase.storageTier,
+			},
+		}
+		actual := GetVolumeDiskSelector(volume)
+		c.Assert(reflect.DeepEqual(actual, testCase.expected), Equals, true, Commentf(TestErrResultFmt, testName))
+	}
+}
+
+func (s *TestSuite) TestValidateVolumeTieringPolicy(c *C) {
+	type testCase struct {
+		policy longhorn.VolumeTieringPolicy
+
+		expectError bool
+	}
+	testCases := map[string]testCase{
+		"disabled": {
+			policy: longhorn.VolumeTieringPolicy{},
+		},
+		"valid": {
+			policy: longhorn.VolumeTieringPolicy{HotDiskSelector: []string{"nvme"}, ColdDiskSelector: []string{"hdd"}, ColdAfterDetachedDays: 30},
+		},
+		"missing cold disk selector": {
+			policy:      longhorn.VolumeTieringPolicy{HotDiskSelector: []string{"nvme"}, ColdAfterDetachedDays: 30},
+			expectError: true,
+		},
+		"missing days": {
+			policy:      longhorn.VolumeTieringPolicy{HotDiskSelector: []string{"nvme"}, ColdDiskSelector: []string{"hdd"}},
+			expectError: true,
+		},
+		"negative days": {
+			policy:      longhorn.VolumeTieringPolicy{ColdAfterDetachedDays: -1},
+			expectError: true,
+		},
+		"valid with low IOPS": {
+			policy: longhorn.VolumeTieringPolicy{HotDiskSelector: []string{"nvme"}, ColdDiskSelector: []string{"hdd"}, ColdAfterDetachedDays: 7, ColdBelowIOPS: 10},
+		},
+		"negative IOPS": {
+			policy:      longhorn.VolumeTieringPolicy{HotDiskSelector: []string{"nvme"}, ColdDiskSelector: []string{"hdd"}, ColdAfterDetachedDays: 7, ColdBelowIOPS: -1},
+			expectError: true,
+		},
+		"low IOPS without tiers": {
+			policy:      longhorn.VolumeTieringPolicy{ColdBelowIOPS: 10},
+			expectError: true,
+		},
+		"same tiers": {
+			policy:      longhorn.VolumeTieringPolicy{HotDiskSelector: []string{"a", "b"}, ColdDiskSelector: []string{"b", "a"}, ColdAfterDetachedDays: 1},
+			expectError: true,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		fmt.Printf("testing %v\n", testName)
+
+		err := ValidateVolumeTieringPolicy(testCase.policy)
+		if testCase.expectError {
+			c.Assert(err, NotNil, Commentf(TestErrResultFmt, testName))
+		} else {
+			c.Assert(err, IsNil, Commentf(TestErrErrorFmt, testName, err))
+		}
+	}
+}
+
+func (s *TestSuite) TestIsDiskCapacityAtRisk(c *C) {
+	type testCase struct {
+		projectedFullTime string
+		forecastDays      int64
+
+		expected bool
+	}
+	now := time.Now()
+	testCases := map[string]testCase{
+		"not growing": {
+			forecastDays: 7,
+			expected:     false,
+		},
+		"projected full within forecast days": {
+			projectedFullTime: now.Add(3 * 24 * time.Hour).UTC().Format(time.RFC3339),
+			forecastDays:      7,
+			expected:          true,
+		},
+		"projected full after forecast days": {
+			projectedFullTime: now.Add(10 * 24 * time.Hour).UTC().Format(time.RFC3339),
+			forecastDays:      7,
+			expected:          false,
+		},
+		"forecast disabled": {
+			projectedFullTime: now.Add(3 * 24 * time.Hour).UTC().Format(time.RFC3339),
+			forecastDays:      0,
+			expected:          false,
+		},
+		"invalid projected full time": {
+			projectedFullTime: "invalid",
+			forecastDays:      7,
+			expected:          false,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		fmt.Printf("testing %v\n", testName)
+
+		diskStatus := &longhorn.DiskStatus{
+			ProjectedFullTime: testCase.projectedFullTime,
+		}
+		c.Assert(IsDiskCapacityAtRisk(diskStatus, testCase.forecastDays, now), Equals, testCase.expected, Commentf(TestErrResultFmt, testName))
+	}
+}
+
+func (s *TestSuite) TestParseTopologySpreadConstraints(c *C) {
+	type testCase struct {
+		topologyLevels string
+		constraints    string
+
+		expectedConstraints []longhorn.TopologySpreadConstraint
+		expectError         bool
+	}
+	testCases := map[string]testCase{
+		"empty": {
+			expectedConstraints: []longhorn.TopologySpreadConstraint{},
+		},
+		"rack and zone": {
+			topologyLevels: "rack:topology.longhorn.io/rack; row:topology.longhorn.io/row",
+			constraints:    "rack:maxReplicasPerDomain=1; zone:minDomains=2",
+			expectedConstraints: []longhorn.TopologySpreadConstraint{
+				{TopologyLevel: "rack", MaxReplicasPerDomain: 1},
+				{TopologyLevel: TopologyLevelZone, MinDomains: 2},
+			},
+		},
+		"multiple fields": {
+			constraints: "zone:maxReplicasPerDomain=2,minDomains=2",
+			expectedConstraints: []longhorn.TopologySpreadConstraint{
+				{TopologyLevel: TopologyLevelZone, MaxReplicasPerDomain: 2, MinDomains: 2},
+			},
+		},
+		"undefined level": {
+			constraints: "rack:maxReplicasPerDomain=1",
+			expectError: true,
+		},
+		"unknown field": {
+			constraints: "zone:maxReplicas=1",
+			expectError: true,
+		},
+		"no limit": {
+			constraints: "zone:minDomains=0",
+			expectError: true,
+		},
+		"redefined built-in level": {
+			topologyLevels: "zone:topology.longhorn.io/zone",
+			expectError:    true,
+		},
+		"invalid label key": {
+			topologyLevels: "rack:invalid key",
+			expectError:    true,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		fmt.Printf("testing %v\n", testName)
+
+		levels, err := UnmarshalTopologyLevels(testCase.topologyLevels)
+		if err == nil {
+			var constraints []longhorn.TopologySpreadConstraint
+			constraints, err = ParseTopologySpreadConstraints(testCase.constraints)
+			if err == nil {
+				err = ValidateTopologySpreadConstraints(constraints, levels)
+			}
+			if err == nil {
+				c.Assert(constraints, DeepEquals, testCase.expectedConstraints, Commentf(TestErrResultFmt, testName))
+			}
+		}
+		if testCase.expectError {
+			c.Assert(err, NotNil, Commentf(TestErrResultFmt, testName))
+		} else {
+			c.Assert(err, IsNil, Commentf(TestErrErrorFmt, testName, err))
+		}
+	}
+}
+
+func (s *TestSuite) TestIsVolumePolicyMatched(c *C) {
+	type testCase struct {
+		namespaces     []string
+		volumeSelector *metav1.LabelSelector
+		volumeLabels   map[string]string
+		pvcNamespace   string
+
+		expected    bool
+		expectError bool
+	}
+	testCases := map[string]testCase{
+		"match all": {
+			expected: true,
+		},
+		"namespace matched by the label": {
+			namespaces:   []string{"team-a", "team-b"},
+			volumeLabels: map[string]string{GetLonghornLabelKey(LonghornLabelPVCNamespace): "team-b"},
+			expected:     true,
+		},
+		"namespace matched by the Kubernetes status": {
+			namespaces:   []string{"team-a"},
+			pvcNamespace: "team-a",
+			expected:     true,
+		},
+		"namespace not matched": {
+			namespaces:   []string{"team-a"},
+			pvcNamespace: "team-b",
+			expected:     false,
+		},
+		"no PVC namespace": {
+			namespaces: []string{"team-a"},
+			expected:   false,
+		},
+		"selector matched": {
+			volumeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "gold"}},
+			volumeLabels:   map[string]string{"tier": "gold"},
+			expected:       true,
+		},
+		"selector not matched": {
+			volumeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "gold"}},
+			volumeLabels:   map[string]string{"tier": "silver"},
+			expected:       false,
+		},
+		"invalid selector": {
+			volumeSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "tier", Operator: "Invalid"}}},
+			expectError:    true,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		fmt.Printf("testing %v\n", testName)
+
+		policy := &longhorn.VolumePolicy{
+			Spec: longhorn.VolumePolicySpec{
+				Namespaces:     testCase.namespaces,
+				VolumeSelector: testCase.volumeSelector,
+			},
+		}
+		volume := &longhorn.Volume{}
+		volume.Labels = testCase.volumeLabels
+		volume.Status.KubernetesStatus.Namespace = testCase.pvcNamespace
+
+		matched, err := IsVolumePolicyMatched(policy, volume)
+		if testCase.expectError {
+			c.Assert(err, NotNil, Commentf(TestErrResultFmt, testName))
+			continue
+		}
+		c.Assert(err, IsNil, Commentf(TestErrErrorFmt, testName, err))
+		c.Assert(matched, Equals, testCase.expected, Commentf(TestErrResultFmt, testName))
+	}
+}
+
+func (s *TestSuite) TestGetVolumePolicyViolations(c *C) {
+	type testCase struct {
+		spec       longhorn.VolumePolicySpec
+		volumeSpec longhorn.VolumeSpec
+
+		expectedViolationCount int
+	}
+	compliantVolumeSpec := longhorn.VolumeSpec{
+		NumberOfReplicas: 3,
+		DataEngine:       longhorn.DataEngineTypeV1,
+		Encrypted:        true,
+		Size:             10 * 1024 * 1024 * 1024,
+		BackupTargetName: DefaultBackupTargetName,
+		DataLocality:     longhorn.DataLocalityBestEffort,
+	}
+	policySpec := longhorn.VolumePolicySpec{
+		MinNumberOfReplicas:  3,
+		AllowedDataEngines:   []longhorn.DataEngineType{longhorn.DataEngineTypeV1},
+		RequireEncryption:    true,
+		MaxSize:              10 * 1024 * 1024 * 1024,
+		AllowedBackupTargets: []string{DefaultBackupTargetName},
+		DataLocality:         longhorn.DataLocalityBestEffort,
+	}
+	testCases := map[string]testCase{
+		"no constraints": {
+			volumeSpec: longhorn.VolumeSpec{NumberOfReplicas: 1},
+		},
+		"compliant": {
+			spec:       policySpec,
+			volumeSpec: compliantVolumeSpec,
+		},
+		"violate all": {
+			spec: policySpec,
+			volumeSpec: longhorn.VolumeSpec{
+				NumberOfReplicas: 1,
+				DataEngine:       longhorn.DataEngineTypeV2,
+				Size:             20 * 1024 * 1024 * 1024,
+				BackupTargetName: "other",
+				DataLocality:     longhorn.DataLocalityDisabled,
+			},
+			expectedViolationCount: 6,
+		},
+		"empty backup target is the default one": {
+			spec: longhorn.VolumePolicySpec{AllowedBackupTargets: []string{DefaultBackupTargetName}},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		fmt.Printf("testing %v\n", testName)
+
+		volume := &longhorn.Volume{Spec: testCase.volumeSpec}
+		violations := GetVolumePolicyViolations(&testCase.spec, volume)
+		c.Assert(violations, HasLen, testCase.expectedViolationCount, Commentf(TestErrResultFmt, testName))
+	}
+}
+
+func (s *TestSuite) TestIsVolumePolicyOverlapping(c *C) {
+	type testCase struct {
+		a longhorn.VolumePolicySpec
+		b longhorn.VolumePolicySpec
+
+		expected bool
+	}
+	testCases := map[string]testCase{
+		"match all": {
+			expected: true,
+		},
+		"shared namespace": {
+			a:        longhorn.VolumePolicySpec{Namespaces: []string{"team-a", "team-b"}},
+			b:        longhorn.VolumePolicySpec{Namespaces: []string{"team-b"}},
+			expected: true,
+		},
+		"disjoint namespaces": {
+			a:        longhorn.VolumePolicySpec{Namespaces: []string{"team-a"}},
+			b:        longhorn.VolumePolicySpec{Namespaces: []string{"team-b"}},
+			expected: false,
+		},
+		"namespaces and all namespaces": {
+			a:        longhorn.VolumePolicySpec{Namespaces: []string{"team-a"}},
+			expected: true,
+		},
+		"different match labels": {
+			a:        longhorn.VolumePolicySpec{VolumeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "gold"}}},
+			b:        longhorn.VolumePolicySpec{VolumeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "silver"}}},
+			expected: false,
+		},
+		"match labels of different keys": {
+			a:        longhorn.VolumePolicySpec{VolumeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "gold"}}},
+			b:        longhorn.VolumePolicySpec{VolumeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}}},
+			expected: true,
+		},
+		"match expressions": {
+			a: longhorn.VolumePolicySpec{VolumeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "gold"}}},
+			b: longhorn.VolumePolicySpec{VolumeSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "tier", Operator: metav1.LabelSelectorOpNotIn, Values: []string{"gold"}},
+			}}},
+			expected: true,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		fmt.Printf("testing %v\n", testName)
+
+		c.Assert(IsVolumePolicyOverlapping(&testCase.a, &testCase.b), Equals, testCase.expected, Commentf(TestErrResultFmt, testName))
+		c.Assert(IsVolumePolicyOverlapping(&testCase.b, &testCase.a), Equals, testCase.expected, Commentf(TestErrResultFmt, testName))
+	}
+}
+
+func (s *TestSuite) TestValidateVolumePolicyConflict(c *C) {
+	type testCase struct {
+		a longhorn.VolumePolicySpec
+		b longhorn.VolumePolicySpec
+
+		expectError bool
+	}
+	testCases := map[string]testCase{
+		"no constraints": {},
+		"same data locality": {
+			a: longhorn.VolumePolicySpec{DataLocality: longhorn.DataLocalityBestEffort},
+			b: longhorn.VolumePolicySpec{DataLocality: longhorn.DataLocalityBestEffort},
+		},
+		"data locality and no data locality": {
+			a: longhorn.VolumePolicySpec{DataLocality: longhorn.DataLocalityBestEffort},
+		},
+		"conflicting data localities": {
+			a:           longhorn.VolumePolicySpec{Enforcement: longhorn.VolumePolicyEnforcementMutate, DataLocality: longhorn.DataLocalityBestEffort},
+			b:           longhorn.VolumePolicySpec{Enforcement: longhorn.VolumePolicyEnforcementMutate, DataLocality: longhorn.DataLocalityStrictLocal},
+			expectError: true,
+		},
+		"different minimum numbers of replicas": {
+			a: longhorn.VolumePolicySpec{MinNumberOfReplicas: 2},
+			b: longhorn.VolumePolicySpec{MinNumberOfReplicas: 3},
+		},
+		"strict-local and multiple replicas": {
+			a:           longhorn.VolumePolicySpec{DataLocality: longhorn.DataLocalityStrictLocal},
+			b:           longhorn.VolumePolicySpec{Enforcement: longhorn.VolumePolicyEnforcementMutate, MinNumberOfReplicas: 3},
+			expectError: true,
+		},
+		"strict-local and one replica": {
+			a: longhorn.VolumePolicySpec{DataLocality: longhorn.DataLocalityStrictLocal},
+			b: longhorn.VolumePolicySpec{MinNumberOfReplicas: 1},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		fmt.Printf("testing %v\n", testName)
+
+		for _, err := range []error{
+			ValidateVolumePolicyConflict(&testCase.a, &testCase.b),
+			ValidateVolumePolicyConflict(&testCase.b, &testCase.a),
+		} {
+			if testCase.expectError {
+				c.Assert(err, NotNil, Commentf(TestErrResultFmt, testName))
+			} else {
+				c.Assert(err, IsNil, Commentf(TestErrErrorFmt, testName, err))
+			}
+		}
+	}
+}
+
+func (s *TestSuite) TestCheckStorageQuota(c *C) {
+	type testCase struct {
+		spec      longhorn.StorageQuotaSpec
+		usage     longhorn.StorageQuotaUsage
+		increment longhorn.StorageQuotaUsage
+
+		expectError bool
+	}
+	testCases := map[string]testCase{
+		"no limits": {
+			spec:      longhorn.StorageQuotaSpec{Namespace: "default"},
+			usage:     longhorn.StorageQuotaUsage{ProvisionedSize: 100},
+			increment: longhorn.StorageQuotaUsage{ProvisionedSize: 100},
+		},
+		"within provisioned size": {
+			spec:      longhorn.StorageQuotaSpec{Namespace: "default", MaxProvisionedSize: 200},
+			usage:     longhorn.StorageQuotaUsage{ProvisionedSize: 100},
+			increment: longhorn.StorageQuotaUsage{ProvisionedSize: 100},
+		},
+		"exceed provisioned size": {
+			spec:        longhorn.StorageQuotaSpec{Namespace: "default", MaxProvisionedSize: 200},
+			usage:       longhorn.StorageQuotaUsage{ProvisionedSize: 100},
+			increment:   longhorn.StorageQuotaUsage{ProvisionedSize: 101},
+			expectError: true,
+		},
+		"exceed snapshot count": {
+			spec:        longhorn.StorageQuotaSpec{Namespace: "default", MaxSnapshotCount: 2},
+			usage:       longhorn.StorageQuotaUsage{SnapshotCount: 2},
+			increment:   longhorn.StorageQuotaUsage{SnapshotCount: 1},
+			expectError: true,
+		},
+		"exceed backup size": {
+			spec:        longhorn.StorageQuotaSpec{Namespace: "default", MaxBackupSize: 100},
+			usage:       longhorn.StorageQuotaUsage{BackupSize: 100},
+			increment:   longhorn.StorageQuotaUsage{BackupSize: 1},
+			expectError: true,
+		},
+		"other limits exceeded": {
+			spec:      longhorn.StorageQuotaSpec{Namespace: "default", MaxProvisionedSize: 200, MaxSnapshotSize: 100},
+			usage:     longhorn.StorageQuotaUsage{ProvisionedSize: 100, SnapshotSize: 300},
+			increment: longhorn.StorageQuotaUsage{ProvisionedSize: 100},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		fmt.Printf("testing %v\n", testName)
+
+		quota := &longhorn.StorageQuota{Spec: testCase.spec}
+		err := CheckStorageQuota(quota, &testCase.usage, &testCase.increment)
+		if testCase.expectError {
+			c.Assert(err, NotNil, Commentf(TestErrErrorFmt, testName, err))
+			var quotaErr *StorageQuotaExceededError
+			c.Assert(errors.As(err, &quotaErr), Equals, true, Commentf(TestErrResultFmt, testName))
+		} else {
+			c.Assert(err, IsNil, Commentf(TestErrErrorFmt, testName, err))
+		}
+	}
+}
+
+func (s *TestSuite) TestGetBackupPVCNamespace(c *C) {
+	type testCase struct {
+		labels map[string]string
+
+		expectedNamespace string
+	}
+	testCases := map[string]testCase{
+		"no label": {},
+		"kubernetes status": {
+			labels:            map[string]string{KubernetesStatusLabel: `{"namespace":"ns1","pvcName":"pvc1"}`},
+			expectedNamespace: "ns1",
+		},
+		"invalid kubernetes status": {
+			labels: map[string]string{KubernetesStatusLabel: "invalid"},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		fmt.Printf("testing %v\n", testName)
+
+		backup := &longhorn.Backup{Status: longhorn.BackupStatus{Labels: testCase.labels}}
+		c.Assert(GetBackupPVCNamespace(backup), Equals, testCase.expectedNamespace, Commentf(TestErrResultFmt, testName))
+	}
+}
+
+func (s *TestSuite) TestDailyUsageAddSamples(c *C) {
+	usage := &DailyUsage{Date: "2026-10-01"}
+
+	// The first sample accounts the day from its start
+	err := usage.AddSamples(map[string]*VolumeUsage{
+		"vol": {Volume: "vol", Namespace: "ns", Size: 100, ActualSize: 40, BackupSize: map[string]int64{"default": 20}},
+	}, time.Date(2026, 10, 1, 6, 0, 0, 0, time.UTC))
+	c.Assert(err, IsNil)
+	err = usage.AddSamples(map[string]*VolumeUsage{
+		"vol": {Volume: "vol", Namespace: "ns", Size: 100, ActualSize: 80, BackupSize: map[string]int64{"default": 20, "other": 40}},
+	}, time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC))
+	c.Assert(err, IsNil)
+
+	// Half of the day is accounted
+	volumeUsage := usage.Volumes["vol"]
+	c.Assert(volumeUsage, NotNil)
+	c.Assert(volumeUsage.Size, Equals, int64(50))
+	c.Assert(volumeUsage.ActualSize, Equals, int64(30))
+	c.Assert(volumeUsage.BackupSize, DeepEquals, map[string]int64{"default": 10, "other": 10})
+	c.Assert(usage.LastSampleTime, Equals, "2026-10-01T12:00:00Z")
+
+	// A sample of the next day completes the day up to its end, and a volume created during the day only accounts
+	// for the rest of the day
+	err = usage.AddSamples(map[string]*VolumeUsage{
+		"vol":  {Volume: "vol", Namespace: "ns", Size: 100},
+		"vol2": {Volume: "vol2", Namespace: "ns", Size: 200},
+	}, time.Date(2026, 10, 2, 1, 0, 0, 0, time.UTC))
+	c.Assert(err, IsNil)
+	c.Assert(usage.Volumes["vol"].Size, Equals, int64(100))
+	c.Assert(usage.Volumes["vol2"].Size, Equals, int64(100))
+	c.Assert(usage.LastSampleTime, Equals, "2026-10-02T00:00:00Z")
+
+	// The complete day isn't accounted again
+	err = usage.AddSamples(map[string]*VolumeUsage{
+		"vol": {Volume: "vol", Namespace: "ns", Size: 100},
+	}, time.Date(2026, 10, 2, 2, 0, 0, 0, time.UTC))
+	c.Assert(err, IsNil)
+	c.Assert(usage.Volumes["vol"].Size, Equals, int64(100))
+}
+
+func (s *TestSuite) TestSplitDailyUsage(c *C) {
+	usage := &DailyUsage{Date: "2026-10-01", Sampler: "node1", Volumes: map[string]*VolumeUsage{}}
+	for i := 0; i < 100; i++ {
+		name := fmt.Sprintf("vol-%03d", i)
+		usage.Volumes[name] = &VolumeUsage{Volume: name, Namespace: "ns", Size: int64(i)}
+	}
+
+	shards, err := SplitDailyUsage(usage, 1024)
+	c.Assert(err, IsNil)
+	c.Assert(len(shards) > 1, Equals, true)
+	for _, shard := range shards {
+		data, err := json.Marshal(shard.Volumes)
+		c.Assert(err, IsNil)
+		c.Assert(len(data) <= 1024, Equals, true)
+		c.Assert(shard.Date, Equals, usage.Date)
+		c.Assert(shard.Sampler, Equals, usage.Sampler)
+	}
+	c.Assert(MergeDailyUsages(shards), DeepEquals, usage)
+
+	shards, err = SplitDailyUsage(&DailyUsage{Date: "2026-10-01"}, 1024)
+	c.Assert(err, IsNil)
+	c.Assert(shards, HasLen, 1)
+}
+
+func (s *TestSuite) TestAggregateDailyUsages(c *C) {
+	usages := []*DailyUsage{
+		{
+			Date: "2026-10-01",
+			Volumes: map[string]*VolumeUsage{
+				"vol1": {Volume: "vol1", Namespace: "ns1", PVC: "pvc1", Labels: map[string]string{"team": "a"}, Size: 10, BackupSize: map[string]int64{"default": 1}},
+				"vol2": {Volume: "vol2", Namespace: "ns1", PVC: "pvc2", Labels: map[string]string{"team": "b"}, Size: 20},
+				"vol3": {Volume: "vol3", Namespace: "ns2", PVC: "pvc3", Labels: map[string]string{"team": "a"}, Size: 30, BackupSize: map[string]int64{"default": 2}},
+			},
+		},
+		{
+			Date: "2026-10-02",
+			Volumes: map[string]*VolumeUsage{
+				"vol1": {Volume: "vol1", Namespace: "ns1", PVC: "pvc1", Labels: map[string]string{"team": "a"}, Size: 40},
+			},
+		},
+	}
+
+	type testCase struct {
+		groupBy []string
+
+		expectedGroups []string
+		expectedSizes  []int64
+	}
+	testCases := map[string]testCase{
+		"no group": {
+			expectedGroups: []string{"2026-10-01 map[]", "2026-10-02 map[]"},
+			expectedSizes:  []int64{60, 40},
+		},
+		"by namespace": {
+			groupBy:        []string{UsageGroupByNamespace},
+			expectedGroups: []string{"2026-10-01 map[namespace:ns1]", "2026-10-01 map[namespace:ns2]", "2026-10-02 map[namespace:ns1]"},
+			expectedSizes:  []int64{30, 30, 40},
+		},
+		"by label": {
+			groupBy:        []string{UsageGroupByLabelPrefix + "team"},
+			expectedGroups: []string{"2026-10-01 map[label:team:a]", "2026-10-01 map[label:team:b]", "2026-10-02 map[label:team:a]"},
+			expectedSizes:  []int64{40, 20, 40},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		fmt.Printf("testing %v\n", testName)
+
+		totals := AggregateDailyUsages(usages, testCase.groupBy)
+		var groups []string
+		var sizes []int64
+		for _, total := range totals {
+			groups = append(groups, fmt.Sprintf("%v %v", total.Date, total.Group))
+			sizes = append(sizes, total.Size)
+		}
+		c.Assert(groups, DeepEquals, testCase.expectedGroups, Commentf(TestErrResultFmt, testName))
+		c.Assert(sizes, DeepEquals, testCase.expectedSizes, Commentf(TestErrResultFmt, testName))
+	}
+
+	c.Assert(ValidateUsageGroupBy([]string{UsageGroupByPVC, UsageGroupByLabelPrefix + "team"}), IsNil)
+	c.Assert(ValidateUsageGroupBy([]string{"node"}), NotNil)
+	c.Assert(ValidateUsageGroupBy([]string{UsageGroupByLabelPrefix}), NotNil)
+}
+
+func (s *TestSuite) TestRankSalvageCandidates(c *C) {
+	newCandidate := func(name string, revisionCounter int64, headLastModifiedAt string, headSize int64, chain ...string) *SalvageCandidate {
+		return &SalvageCandidate{
+			Replica: name,
+			Metadata: &longhorn.ReplicaSalvageMetadata{
+				RevisionCounter:    revisionCounter,
+				HeadLastModifiedAt: headLastModifiedAt,
+				HeadSize:           headSize,
+				Chain:              chain,
+			},
+		}
+	}
+
+	type testCase struct {
+		candidates              []*SalvageCandidate
+		revisionCounterDisabled bool
+
+		expectedOrder       []string
+		expectedRecommended []string
+		expectedMissing     map[string][]string
+	}
+	testCases := map[string]testCase{
+		"highest revision counter": {
+			candidates: []*SalvageCandidate{
+				newCandidate("r1", 10, "2024-01-01T00:00:02Z", 100, "snap2", "snap1"),
+				newCandidate("r2", 12, "2024-01-01T00:00:01Z", 100, "snap2", "snap1"),
+				newCandidate("r3", 11, "2024-01-01T00:00:03Z", 100, "snap1"),
+			},
+			expectedOrder:       []string{"r2", "r3", "r1"},
+			expectedRecommended: []string{"r2"},
+			expectedMissing:     map[string][]string{"r3": {"snap2"}},
+		},
+		"revision counter disabled": {
+			candidates: []*SalvageCandidate{
+				newCandidate("r1", 0, "2024-01-01T00:00:02Z", 100, "snap1"),
+				newCandidate("r2", 0, "2024-01-01T00:00:01Z", 200, "snap1"),
+				newCandidate("r3", 0, "2024-01-01T00:00:02Z", 200, "snap1"),
+			},
+			revisionCounterDisabled: true,
+			expectedOrder:           []string{"r3", "r1", "r2"},
+			expectedRecommended:     []string{"r3"},
+		},
+		"same data recommended together": {
+			candidates: []*SalvageCandidate{
+				newCandidate("r1", 12, "2024-01-01T00:00:01Z", 100, "snap1"),
+				newCandidate("r2", 12, "2024-01-01T00:00:02Z", 100, "snap1"),
+				newCandidate("r3", 11, "2024-01-01T00:00:03Z", 100, "snap1"),
+			},
+			expectedOrder:       []string{"r2", "r1", "r3"},
+			expectedRecommended: []string{"r2", "r1"},
+		},
+		"unusable replicas": {
+			candidates: []*SalvageCandidate{
+				{Replica: "r1"},
+				{Replica: "r2", Unusable: "node is down", Metadata: &longhorn.ReplicaSalvageMetadata{RevisionCounter: 20}},
+				{Replica: "r3", Metadata: &longhorn.ReplicaSalvageMetadata{RevisionCounter: 20, Rebuilding: true}},
+				{Replica: "r4", Metadata: &longhorn.ReplicaSalvageMetadata{Error: "missing volume.meta"}},
+				newCandidate("r5", 10, "2024-01-01T00:00:01Z", 100),
+			},
+			expectedOrder:       []string{"r5", "r1", "r2", "r3", "r4"},
+			expectedRecommended: []string{"r5"},
+		},
+	}
+
+	for testName, testCase := range testCases {
+		fmt.Printf("testing %v\n", testName)
+
+		candidates := RankSalvageCandidates(testCase.candidates, testCase.revisionCounterDisabled)
+
+		var order, recommended []string
+		for i, candidate := range candidates {
+			order = append(order, candidate.Replica)
+			if candidate.Recommended {
+				recommended = append(recommended, candidate.Replica)
+			}
+			if candidate.Unusable == "" {
+				c.Assert(candidate.Rank, Equals, i+1, Commentf(TestErrResultFmt, testName))
+				expectedMissing := testCase.expectedMissing[candidate.Replica]
+				if expectedMissing == nil {
+					expectedMissing = []string{}
+				}
+				c.Assert(candidate.MissingSnapshots, DeepEquals, expectedMissing, Commentf(TestErrResultFmt, testName))
+			} else {
+				c.Assert(candidate.Rank, Equals, 0, Commentf(TestErrResultFmt, testName))
+			}
+			c.Assert(len(candidate.Reasons) > 0, Equals, true, Commentf(TestErrResultFmt, testName))
+		}
+		c.Assert(order, DeepEquals, testCase.expectedOrder, Commentf(TestErrResultFmt, testName))
+		c.Assert(recommended, DeepEquals, testCase.expectedRecommended, Commentf(TestErrResultFmt, testName))
+	}
+}
+
+func (s *TestSuite) TestGetSalvagePreview(c *C) {
+	report := &SalvageReport{
+		Volume: "vol",
+		Candidates: RankSalvageCandidates([]*SalvageCandidate{
+			{Replica: "r1", Metadata: &longhorn.ReplicaSalvageMetadata{RevisionCounter: 12, HeadSize: 100, Chain: []string{"snap2", "snap1"}}},
+			{Replica: "r2", Metadata: &longhorn.ReplicaSalvageMetadata{RevisionCounter: 11, HeadSize: 100, Chain: []string{"snap1"}}},
+			{Replica: "r3", Unusable: "node is down"},
+		}, false),
+	}
+
+	type testCase struct {
+		replicas []string
+
+		expectError           bool
+		expectedSourceReplica string
+		expectedLostSnapshots []string
+		expectedWarnings      int
+	}
+	testCases := map[string]testCase{
+		"recommended replica": {
+			replicas:              []string{"r1"},
+			expectedSourceReplica: "r1",
+			expectedLostSnapshots: []string{},
+		},
+		"lower ranked replica": {
+			replicas:              []string{"r2"},
+			expectedSourceReplica: "r2",
+			expectedLostSnapshots: []string{"snap2"},
+			expectedWarnings:      2,
+		},
+		"replica rebuilt from the source": {
+			replicas:              []string{"r2", "r1"},
+			expectedSourceReplica: "r1",
+			expectedLostSnapshots: []string{},
+			expectedWarnings:      1,
+		},
+		"unusable replica": {
+			replicas:    []string{"r3"},
+			expectError: true,
+		},
+		"unknown replica": {
+			replicas:    []string{"r4"},
+			expectError: true,
+		},
+		"no replica": {
+			expectError: true,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		fmt.Printf("testing %v\n", testName)
+
+		preview, err := GetSalvagePreview(report, testCase.replicas)
+		if testCase.expectError {
+			c.Assert(err, NotNil, Commentf(TestErrErrorFmt, testName, err))
+			continue
+		}
+		c.Assert(err, IsNil, Commentf(TestErrErrorFmt, testName, err))
+		c.Assert(preview.SourceReplica, Equals, testCase.expectedSourceReplica, Commentf(TestErrResultFmt, testName))
+		c.Assert(preview.LostSnapshots, DeepEquals, testCase.expectedLostSnapshots, Commentf(TestErrResultFmt, testName))
+		c.Assert(len(preview.Warnings), Equals, testCase.expectedWarnings, Commentf(TestErrResultFmt, testName))
+	}
+}
+
+func (s *TestSuite) TestGetRevertPreviewExpireAt(c *C) {
+	type testCase struct {
+		annotations map[string]string
+
+		expectError      bool
+		expectedExpireAt time.Time
+	}
+	testCases := map[string]testCase{
+		"valid expiration time": {
+			annotations: map[string]string{
+				GetLonghornLabelKey(RevertPreviewExpireAtAnnotationKeySuffix): "2024-01-02T03:04:05Z",
+			},
+			expectedExpireAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+		"invalid expiration time": {
+			annotations: map[string]string{
+				GetLonghornLabelKey(RevertPreviewExpireAtAnnotationKeySuffix): "tomorrow",
+			},
+			expectError: true,
+		},
+		"missing expiration time": {
+			expectError: true,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		fmt.Printf("testing %v\n", testName)
+
+		v := &longhorn.Volume{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "vol-preview-abcd1234",
+				Annotations: testCase.annotations,
+			},
+		}
+		expireAt, err := GetRevertPreviewExpireAt(v)
+		if testCase.expectError {
+			c.Assert(err, NotNil, Commentf(TestErrResultFmt, testName))
+			continue
+		}
+		c.Assert(err, IsNil, Commentf(TestErrErrorFmt, testName, err))
+		c.Assert(expireAt.Equal(testCase.expectedExpireAt), Equals, true, Commentf(TestErrResultFmt, testName))
+	}
+
+	for ttl, valid := range map[time.Duration]bool{
+		0:                                 false,
+		-time.Hour:                        false,
+		time.Minute:                       true,
+		DefaultRevertPreviewTTL:           true,
+		MaxRevertPreviewTTL:               true,
+		MaxRevertPreviewTTL + time.Second: false,
+	} {
+		c.Assert(ValidateRevertPreviewTTL(ttl) == nil, Equals, valid, Commentf(TestErrResultFmt, ttl))
+	}
+}
+
+func (s *TestSuite) TestValidateSnapshotExportFormat(c *C) {
+	for format, valid := range map[string]bool{
+		SnapshotExportFormatRAW:   true,
+		SnapshotExportFormatQCOW2: true,
+		"":                        false,
+		"vmdk":                    false,
+	} {
+		c.Assert(ValidateSnapshotExportFormat(format) == nil, Equals, valid, Commentf(TestErrResultFmt, format))
+	}
+
+	c.Assert(GetSnapshotExportBackingImageName("snap", SnapshotExportFormatQCOW2), Equals, "export-snap-qcow2")
+}
+
+func (s *TestSuite) TestValidateNFSExportOptions(c *C) {
+	type testCase struct {
+		options longhorn.NFSExportOptions
+
+		expectError bool
+	}
+	testCases := map[string]testCase{
+		"default": {},
+		"all squash with allowed clients": {
+			options: longhorn.NFSExportOptions{
+				Squash:         longhorn.NFSExportSquashAll,
+				AnonUID:        65534,
+				AnonGID:        65534,
+				AllowedClients: []string{"10.0.0.0/8", "fd00::/8"},
+				Sec:            longhorn.NFSExportSecFlavorKrb5p,
+				ReadOnly:       true,
+			},
+		},
+		"invalid squash": {
+			options:     longhorn.NFSExportOptions{Squash: "some"},
+			expectError: true,
+		},
+		"invalid security flavor": {
+			options:     longhorn.NFSExportOptions{Sec: "krb4"},
+			expectError: true,
+		},
+		"negative anonymous user ID": {
+			options:     longhorn.NFSExportOptions{AnonUID: -1},
+			expectError: true,
+		},
+		"allowed client without prefix length": {
+			options:     longhorn.NFSExportOptions{AllowedClients: []string{"10.0.0.1"}},
+			expectError: true,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		fmt.Printf("testing %v\n", testName)
+
+		err := ValidateNFSExportOptions(testCase.options)
+		if testCase.expectError {
+			c.Assert(err, NotNil, Commentf(TestErrResultFmt, testName))
+		} else {
+			c.Assert(err, IsNil, Commentf(TestErrErrorFmt, testName, err))
+		}
+	}
+}
+
+func (s *TestSuite) TestValidateBackupStoreCredential(c *C) {
+	type testCase struct {
+		backupType string
+		credential map[string]string
+
+		expectError bool
+	}
+	testCases := map[string]testCase{
+		"s3 static keys": {
+			backupType: BackupStoreTypeS3,
+			credential: map[string]string{AWSAccessKey: "id", AWSSecretKey: "secret"},
+		},
+		"s3 web identity with sts endpoint": {
+			backupType: BackupStoreTypeS3,
+			credential: map[string]string{
+				AWSRoleArn:              "arn:aws:iam::013456789:role/longhorn",
+				AWSWebIdentityTokenFile: "/var/run/secrets/sts/token",
+				AWSSTSEndpoint:          "https://minio.default:9000",
+			},
+		},
+		"s3 web identity with static keys": {
+			backupType:  BackupStoreTypeS3,
+			credential:  map[string]string{AWSRoleArn: "arn:aws:iam::013456789:role/longhorn", AWSAccessKey: "id", AWSSecretKey: "secret"},
+			expectError: true,
+		},
+		"s3 invalid role arn": {
+			backupType:  BackupStoreTypeS3,
+			credential:  map[string]string{AWSRoleArn: "longhorn"},
+			expectError: true,
+		},
+		"s3 relative token file": {
+			backupType:  BackupStoreTypeS3,
+			credential:  map[string]string{AWSRoleArn: "arn:aws:iam::013456789:role/longhorn", AWSWebIdentityTokenFile: "token"},
+			expectError: true,
+		},
+		"s3 sts endpoint without role": {
+			backupType:  BackupStoreTypeS3,
+			credential:  map[string]string{AWSSTSEndpoint: "https://minio.default:9000"},
+			expectError: true,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		fmt.Printf("testing %v\n", testName)
+
+		err := ValidateBackupStoreCredential(testCase.backupType, testCase.credential)
+		if testCase.expectError {
+			c.Assert(err, NotNil, Commentf(TestErrResultFmt, testName))
+		} else {
+			c.Assert(err, IsNil, Commentf(TestErrErrorFmt, testName, err))
+		}
+	}
+}
+
+func (s *TestSuite) TestParseFileBackupTargetURL(c *C) {
+	type testCase struct {
+		backupTargetURL string
+		shareEndpoint   string
+
+		expectedVolumeName string
+		expectedNodeID     string
+		expectedDir        string
+		expectedNFSURL     string
+		expectError        bool
+	}
+	testCases := map[string]testCase{
+		"directory of volume": {
+			backupTargetURL:    "file:///backupstore/default?volume=backups",
+			shareEndpoint:      "nfs://10.43.0.10/backups",
+			expectedVolumeName: "backups",
+			expectedDir:        "/backupstore/default",
+			expectedNFSURL:     "nfs://10.43.0.10:/backups/backupstore/default",
+		},
+		"root of volume": {
+			backupTargetURL:    "file://?volume=backups",
+			shareEndpoint:      "nfs://backups.longhorn-system.svc.cluster.local/backups",
+			expectedVolumeName: "backups",
+			expectedDir:        "/",
+			expectedNFSURL:     "nfs://backups.longhorn-system.svc.cluster.local:/backups",
+		},
+		"directory of node": {
+			backupTargetURL: "file://node-1/mnt/backups/",
+			expectedNodeID:  "node-1",
+			expectedDir:     "/mnt/backups",
+		},
+		"missing node and volume": {
+			backupTargetURL: "file:///backupstore",
+			expectError:     true,
+		},
+		"root of node": {
+			backupTargetURL: "file://node-1/",
+			expectError:     true,
+		},
+		"node and volume": {
+			backupTargetURL: "file://node-1/backupstore?volume=backups",
+			expectError:     true,
+		},
+		"invalid volume": {
+			backupTargetURL: "file:///backupstore?volume=",
+			expectError:     true,
+		},
+		"parent directory": {
+			backupTargetURL: "file:///../backupstore?volume=backups",
+			expectError:     true,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		fmt.Printf("testing %v\n", testName)
+
+		volumeName, nodeID, dir, err := ParseFileBackupTargetURL(testCase.backupTargetURL)
+		if testCase.expectError {
+			c.Assert(err, NotNil, Commentf(TestErrResultFmt, testName))
+			continue
+		}
+		c.Assert(err, IsNil, Commentf(TestErrErrorFmt, testName, err))
+		c.Assert(volumeName, Equals, testCase.expectedVolumeName, Commentf(TestErrResultFmt, testName))
+		c.Assert(nodeID, Equals, testCase.expectedNodeID, Commentf(TestErrResultFmt, testName))
+		c.Assert(dir, Equals, testCase.expectedDir, Commentf(TestErrResultFmt, testName))
+		if testCase.shareEndpoint == "" {
+			continue
+		}
+
+		nfsURL, err := GetFileBackupTargetNFSURL(testCase.shareEndpoint, dir)
+		c.Assert(err, IsNil, Commentf(TestErrErrorFmt, testName, err))
+		c.Assert(nfsURL, Equals, testCase.expectedNFSURL, Commentf(TestErrResultFmt, testName))
+	}
+}
+
+func (s *TestSuite) TestGetAlerts(c *C) {
+	volume := &longhorn.Volume{
+		ObjectMeta: metav1.ObjectMeta{Name: "vol1"},
+		Status: longhorn.VolumeStatus{
+			Robustness: longhorn.VolumeRobustnessDegraded,
+			Conditions: []longhorn.Condition{
+				{Type: longhorn.VolumeConditionTypeTooManySnapshots, Status: longhorn.ConditionStatusTrue, Message: "too many snapshots"},
+			},
+		},
+	}
+	var names []string
+	for _, alert := range GetVolumeAlerts(volume) {
+		names = append(names, alert.Fingerprint())
+	}
+	c.Assert(names, DeepEquals, []string{"VolumeDegraded/vol1", "TooManySnapshots/vol1"})
+
+	node := &longhorn.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+		Status: longhorn.NodeStatus{
+			Conditions: []longhorn.Condition{
+				{Type: longhorn.NodeConditionTypeReady, Status: longhorn.ConditionStatusFalse, Message: "kubelet stopped"},
+			},
+			DiskStatus: map[string]*longhorn.DiskStatus{
+				"disk1": {Conditions: []longhorn.Condition{
+					{Type: longhorn.DiskConditionTypeSchedulable, Status: longhorn.ConditionStatusFalse, Reason: longhorn.DiskConditionReasonDiskPressure},
+				}},
+				"disk2": {Conditions: []longhorn.Condition{
+					{Type: longhorn.DiskConditionTypeSchedulable, Status: longhorn.ConditionStatusFalse, Reason: longhorn.DiskConditionReasonDiskNotReady},
+				}},
+			},
+		},
+	}
+	names = nil
+	for _, alert := range GetNodeAlerts(node) {
+		names = append(names, alert.Fingerprint())
+	}
+	c.Assert(names, DeepEquals, []string{"NodeDown/node1", "DiskPressure/node1/disk1"})
+
+	backupTarget := &longhorn.BackupTarget{
+		ObjectMeta: metav1.ObjectMeta{Name: "default"},
+		Spec:       longhorn.BackupTargetSpec{BackupTargetURL: "s3://bucket@us-east-1/"},
+		Status: longhorn.BackupTargetStatus{
+			Conditions: []longhorn.Condition{
+				{Type: longhorn.BackupTargetConditionTypeUnavailable, Status: longhorn.ConditionStatusTrue},
+				{Type: longhorn.BackupTargetConditionTypeDegraded, Status: longhorn.ConditionStatusTrue},
+			},
+		},
+	}
+	alerts := GetBackupTargetAlerts(backupTarget)
+	c.Assert(alerts, HasLen, 1)
+	c.Assert(alerts[0].Name, Equals, AlertNameBackupTargetUnavailable)
+
+	backup := &longhorn.Backup{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup1"},
+		Status:     longhorn.BackupStatus{State: longhorn.BackupStateError},
+	}
+	c.Assert(GetBackupAlerts(backup), HasLen, 1)
+	backup.Status.State = longhorn.BackupStateCompleted
+	c.Assert(GetBackupAlerts(backup), HasLen, 0)
+}
+
+func (s *TestSuite) TestIsAlertSilenced(c *C) {
+	now := time.Now()
+	alert := &Alert{Name: AlertNameDiskPressure, Resource: "node1/disk1"}
+
+	type testCase struct {
+		spec longhorn.AlertSilenceSpec
+
+		expectedSilenced bool
+	}
+	testCases := map[string]testCase{
+		"all alerts": {
+			expectedSilenced: true,
+		},
+		"by name": {
+			spec:             longhorn.AlertSilenceSpec{AlertNames: []string{AlertNameDiskPressure}},
+			expectedSilenced: true,
+		},
+		"other name": {
+			spec:             longhorn.AlertSilenceSpec{AlertNames: []string{AlertNameNodeDown}},
+			expectedSilenced: false,
+		},
+		"by resource pattern": {
+			spec:             longhorn.AlertSilenceSpec{Resources: []string{"node1/*"}},
+			expectedSilenced: true,
+		},
+		"other resource": {
+			spec:             longhorn.AlertSilenceSpec{Resources: []string{"node2/*"}},
+			expectedSilenced: false,
+		},
+		"unexpired": {
+			spec:             longhorn.AlertSilenceSpec{EndsAt: metav1.NewTime(now.Add(time.Hour))},
+			expectedSilenced: true,
+		},
+		"expired": {
+			spec:             longhorn.AlertSilenceSpec{EndsAt: metav1.NewTime(now.Add(-time.Hour))},
+			expectedSilenced: false,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		fmt.Printf("testing %v\n", testName)
+
+		silences := []*longhorn.AlertSilence{{Spec: testCase.spec}}
+		c.Assert(IsAlertSilenced(alert, silences, now), Equals, testCase.expectedSilenced, Commentf(TestErrResultFmt, testName))
+	}
+
+	c.Assert(ValidateAlertSilenceResources([]string{"node1/*"}), IsNil)
+	c.Assert(ValidateAlertSilenceResources([]string{"node1/["}), NotNil)
+}
+
+func (s *TestSuite) TestValidateAlertSinkSpec(c *C) {
+	type testCase struct {
+		spec longhorn.AlertSinkSpec
+
+		expectError bool
+	}
+	testCases := map[string]testCase{
+		"webhook": {
+			spec: longhorn.AlertSinkSpec{Type: longhorn.AlertSinkTypeWebhook, URL: "https://alerts.example.com/hook"},
+		},
+		"webhook without URL": {
+			spec:        longhorn.AlertSinkSpec{Type: longhorn.AlertSinkTypeWebhook},
+			expectError: true,
+		},
+		"smtp": {
+			spec: longhorn.AlertSinkSpec{Type: longhorn.AlertSinkTypeSMTP, SMTPAddress: "smtp.example.com:587",
+				SMTPFrom: "longhorn@example.com", SMTPTo: []string{"ops@example.com"}},
+		},
+		"smtp without port": {
+			spec: longhorn.AlertSinkSpec{Type: longhorn.AlertSinkTypeSMTP, SMTPAddress: "smtp.example.com",
+				SMTPFrom: "longhorn@example.com", SMTPTo: []string{"ops@example.com"}},
+			expectError: true,
+		},
+		"smtp without recipients": {
+			spec:        longhorn.AlertSinkSpec{Type: longhorn.AlertSinkTypeSMTP, SMTPAddress: "smtp.example.com:587", SMTPFrom: "longhorn@example.com"},
+			expectError: true,
+		},
+		"event": {
+			spec: longhorn.AlertSinkSpec{Type: longhorn.AlertSinkTypeEvent, AlertNames: []string{AlertNameVolumeFaulted}},
+		},
+		"unknown alert name": {
+			spec:        longhorn.AlertSinkSpec{Type: longhorn.AlertSinkTypeEvent, AlertNames: []string{"VolumeSlow"}},
+			expectError: true,
+		},
+	}
+
+	for testName, testCase := range testCases {
+		fmt.Printf("testing %v\n", testName)
+
+		err := ValidateAlertSinkSpec(&testCase.spec)
+		if testCase.expectError {
+			c.Assert(err, NotNil, Commentf(TestErrResultFmt, testName))
+			continue
+		}
+		c.Assert(err, IsNil, Commentf(TestErrErrorFmt, testName, err))
+	}
+}
+
+func (s *TestSuite) TestAddWebIdentityTokenToPod(c *C) {
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "instance-manager"}},
+		},
+	}
+	AddWebIdentityTokenToPod(pod)
+
+	container := pod.Spec.Containers[0]
+	c.Assert(container.Env, DeepEquals, []corev1.EnvVar{{Name: AWSWebIdentityTokenFile, Value: DefaultAWSWebIdentityTokenFile}})
+	c.Assert(container.VolumeMounts, HasLen, 1)
+	c.Assert(container.VolumeMounts[0].ReadOnly, Equals, true)
+	c.Assert(filepath.Dir(DefaultAWSWebIdentityTokenFile), Equals, container.VolumeMounts[0].MountPath)
+
+	c.Assert(pod.Spec.Volumes, HasLen, 1)
+	volume := pod.Spec.Volumes[0]
+	c.Assert(volume.Name, Equals, container.VolumeMounts[0].Name)
+	c.Assert(volume.Projected, NotNil)
+	c.Assert(volume.Projected.Sources, HasLen, 1)
+	token := volume.Projected.Sources[0].ServiceAccountToken
+	c.Assert(token, NotNil)
+	c.Assert(token.Audience, Equals, WebIdentityTokenAudience)
+	c.Assert(filepath.Join(container.VolumeMounts[0].MountPath, token.Path), Equals, DefaultAWSWebIdentityTokenFile)
+	c.Assert(*token.ExpirationSeconds > 0, Equals, true)
+}
+
+func (s *TestSuite) TestAddWebIdentityTokenToDaemonSet(c *C) {
+	daemonSet := &appsv1.DaemonSet{}
+	daemonSet.Spec.Template.Spec.Containers = []corev1.Container{{Name: LonghornManagerContainerName}}
+
+	c.Assert(HasWebIdentityToken(&daemonSet.Spec.Template.Spec), Equals, false)
+	c.Assert(AddWebIdentityTokenToDaemonSet(daemonSet), Equals, true)
+	c.Assert(HasWebIdentityToken(&daemonSet.Spec.Template.Spec), Equals, true)
+
+	// The projection is added once
+	c.Assert(AddWebIdentityTokenToDaemonSet(daemonSet), Equals, false)
+	c.Assert(daemonSet.Spec.Template.Spec.Volumes, HasLen, 1)
+	c.Assert(daemonSet.Spec.Template.Spec.Containers[0].VolumeMounts, HasLen, 1)
+	c.Assert(daemonSet.Spec.Template.Spec.Containers[0].Env, HasLen, 1)
+}
+
+func (s *TestSuite) TestRequireProjectedWebIdentityToken(c *C) {
+	roleArn := "arn:aws:iam::013456789:role/longhorn"
+	testCases := map[string]struct {
+		credential map[string]string
+		expected   bool
+	}{
+		"static keys": {
+			credential: map[string]string{AWSAccessKey: "id", AWSSecretKey: "secret"},
+		},
+		"web identity": {
+			credential: map[string]string{AWSRoleArn: roleArn},
+			expected:   true,
+		},
+		"web identity with the projected token file": {
+			credential: map[string]string{AWSRoleArn: roleArn, AWSWebIdentityTokenFile: DefaultAWSWebIdentityTokenFile},
+			expected:   true,
+		},
+		"web identity with a token file of the user": {
+			credential: map[string]string{AWSRoleArn: roleArn, AWSWebIdentityTokenFile: "/var/run/secrets/sts/token"},
+		},
+	}
+	for testName, testCase := range testCases {
+		c.Assert(RequireProjectedWebIdentityToken(testCase.credential), Equals, testCase.expected, Commentf(TestErrResultFmt, testName))
+	}
+}
+
+func (s *TestSuite) TestGetNFSGaneshaConfig(c *C) {
+	config := GetNFSGaneshaConfig("vol", longhorn.NFSExportOptions{}, 60, 90)
+	c.Assert(strings.Contains(config, "Lease_Lifetime = 60;"), Equals, true)
+	c.Assert(strings.Contains(config, "Grace_Period = 90;"), Equals, true)
+	c.Assert(strings.Contains(config, "\tExport_Id = 1;#Volume=vol\n"), Equals, true)
+	c.Assert(strings.Contains(config, "\tPath = /export/vol;\n\tPseudo = /vol;\n"), Equals, true)
+	c.Assert(strings.Contains(config, "\tAccess_Type = RW;\n\tSquash = None;\n\tSecType = sys;\n"), Equals, true)
+	c.Assert(strings.Contains(config, "CLIENT"), Equals, false)
+
+	config = GetNFSGaneshaConfig("vol", longhorn.NFSExportOptions{
+		Squash:         longhorn.NFSExportSquashAll,
+		AnonUID:        65534,
+		AnonGID:        65533,
+		AllowedClients: []string{"10.0.0.0/8", "fd00::/8"},
+		Sec:            longhorn.NFSExportSecFlavorKrb5p,
+		ReadOnly:       true,
+	}, 20, 30)
+	c.Assert(strings.Contains(config, "\tAccess_Type = None;\n\tSquash = All_Squash;\n\tAnonymous_Uid = 65534;\n\tAnonymous_Gid = 65533;\n\tSecType = krb5p;\n"), Equals, true)
+	c.Assert(strings.Contains(config, "\tCLIENT {\n\t\tClients = 10.0.0.0/8, fd00::/8;\n\t\tAccess_Type = RO;\n\t}\n"), Equals, true)
+
+	config = GetNFSGaneshaConfig("vol", longhorn.NFSExportOptions{Squash: longhorn.NFSExportSquashRoot}, 60, 90)
+	c.Assert(strings.Contains(config, "\tAccess_Type = RW;\n\tSquash = Root_Squash;\n"), Equals, true)
+	c.Assert(strings.Contains(config, "RecoveryBackend = longhorn;"), Equals, true)
+
+	config = GetFileBackupTargetNFSServerConfig()
+	c.Assert(strings.Contains(config, "RecoveryBackend = fs;"), Equals, true)
+	c.Assert(strings.Contains(config, "\tPath = "+FileBackupTargetNFSExportPath+";\n\tPseudo = /backupstore;\n"), Equals, true)
+	c.Assert(strings.Contains(config, "\tAccess_Type = RW;\n\tSquash = None;\n"), Equals, true)
+}
+
+func (s *TestSuite) TestGetProgressETASeconds(c *C) {
+	sampleTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	sampledAt := sampleTime.Format(time.RFC3339)
+	totalBytes := int64(100 * 1000 * 1000 * 1000)
+
+	type testCase struct {
+		estimatedTransferredBytes int64
+		bytesPerSecond            int64
+		sampledAt                 string
+		now                       time.Time
+		expectedETASeconds        int64
+	}
+	testCases := map[string]testCase{
+		"rate not known yet": {
+			estimatedTransferredBytes: totalBytes / 10,
+			sampledAt:                 sampledAt,
+			now:                       sampleTime,
+			expectedETASeconds:        -1,
+		},
+		"at the sample time": {
+			estimatedTransferredBytes: totalBytes / 5,
+			bytesPerSecond:            totalBytes / 10 / 100,
+			sampledAt:                 sampledAt,
+			now:                       sampleTime,
+			expectedETASeconds:        800,
+		},
+		"counted down from the sample time": {
+			estimatedTransferredBytes: totalBytes / 5,
+			bytesPerSecond:            totalBytes / 10 / 100,
+			sampledAt:                 sampledAt,
+			now:                       sampleTime.Add(200 * time.Second),
+			expectedETASeconds:        600,
+		},
+		"counted down past the estimate": {
+			estimatedTransferredBytes: totalBytes / 5,
+			bytesPerSecond:            totalBytes / 10 / 100,
+			sampledAt:                 sampledAt,
+			now:                       sampleTime.Add(1000 * time.Second),
+			expectedETASeconds:        0,
+		},
+		"invalid sample time": {
+			estimatedTransferredBytes: totalBytes / 5,
+			bytesPerSecond:            totalBytes / 10 / 100,
+			sampledAt:                 "",
+			now:                       sampleTime.Add(200 * time.Second),
+			expectedETASeconds:        800,
+		},
+		"completed": {
+			estimatedTransferredBytes: totalBytes,
+			bytesPerSecond:            totalBytes / 10 / 100,
+			sampledAt:                 sampledAt,
+			now:                       sampleTime,
+			expectedETASeconds:        0,
+		},
+	}
+
+	for name, tc := range testCases {
+		c.Assert(GetProgressETASeconds(totalBytes, tc.estimatedTransferredBytes, tc.bytesPerSecond, tc.sampledAt, tc.now),
+			Equals, tc.expectedETASeconds, Commentf(TestErrResultFmt, name))
+	}
+}
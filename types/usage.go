@@ -0,0 +1,257 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	UsageConfigMapNamePrefix = "longhorn-usage-"
+	UsageConfigMapDataKey    = "usage.json"
+	// UsageConfigMapMaxDataSize is the maximum size of the daily usage in a ConfigMap, which is well below the 1 MiB
+	// limit of the objects. The daily usage is sharded over as many ConfigMaps as needed.
+	UsageConfigMapMaxDataSize = 512 * 1024
+	// UsageDateLayout is the layout of the dates of the daily usage, which are in UTC.
+	UsageDateLayout = "2006-01-02"
+	// UsageRetentionDays is how many days of the daily usage are kept.
+	UsageRetentionDays = 90
+
+	LonghornLabelUsageDate = "usage-date"
+
+	UsageGroupByNamespace   = "namespace"
+	UsageGroupByPVC         = "pvc"
+	UsageGroupByVolume      = "volume"
+	UsageGroupByLabelPrefix = "label:"
+)
+
+// VolumeUsage is the storage consumed by a volume on a day, averaged over the whole day. A volume existing for half
+// of the day consumes half of its size.
+type VolumeUsage struct {
+	Volume           string            `json:"volume"`
+	Namespace        string            `json:"namespace"`
+	PVC              string            `json:"pvc"`
+	Labels           map[string]string `json:"labels,omitempty"`
+	NumberOfReplicas int               `json:"numberOfReplicas"`
+	Size             int64             `json:"size"`
+	// The actual size of the volume multiplied by the number of replicas, which is the space consumed on the disks.
+	ActualSize int64 `json:"actualSize"`
+	// The actual size of the snapshots multiplied by the number of replicas.
+	SnapshotSize int64 `json:"snapshotSize"`
+	// The size of the backups by the backup targets.
+	BackupSize map[string]int64 `json:"backupSize,omitempty"`
+}
+
+// DailyUsage is the storage consumed by the volumes on a day.
+type DailyUsage struct {
+	Date string `json:"date"`
+	// The node taking the samples.
+	Sampler string `json:"sampler"`
+	// The time of the last sample, up to which the day is accounted.
+	LastSampleTime string                  `json:"lastSampleTime,omitempty"`
+	Volumes        map[string]*VolumeUsage `json:"volumes"`
+}
+
+// UsageTotal is the storage consumed by a group of volumes on a day.
+type UsageTotal struct {
+	Date         string            `json:"date"`
+	Group        map[string]string `json:"group"`
+	VolumeCount  int               `json:"volumeCount"`
+	Size         int64             `json:"size"`
+	ActualSize   int64             `json:"actualSize"`
+	SnapshotSize int64             `json:"snapshotSize"`
+	BackupSize   map[string]int64  `json:"backupSize"`
+}
+
+// GetUsageDate returns the date of the daily usage the time falls in.
+func GetUsageDate(t time.Time) string {
+	return t.UTC().Format(UsageDateLayout)
+}
+
+// GetUsageConfigMapName returns the name of the ConfigMap persisting the shard of the daily usage of the date.
+func GetUsageConfigMapName(date string, shard int) string {
+	return fmt.Sprintf("%v%v-%v", UsageConfigMapNamePrefix, date, shard)
+}
+
+// AddSamples accounts the samples of the volumes taken at the time, from the last sample or the start of the day to
+// the time or the end of the day. The sizes are weighted by the accounted duration and averaged over the whole day,
+// so that the usage of the day is complete once the first sample of the next day is added to it.
+func (u *DailyUsage) AddSamples(samples map[string]*VolumeUsage, now time.Time) error {
+	start, err := time.Parse(UsageDateLayout, u.Date)
+	if err != nil {
+		return errors.Wrapf(err, "invalid date of the daily usage %v", u.Date)
+	}
+	end := start.AddDate(0, 0, 1)
+	if u.LastSampleTime != "" {
+		if start, err = time.Parse(time.RFC3339, u.LastSampleTime); err != nil {
+			return errors.Wrapf(err, "invalid last sample time of the daily usage %v", u.Date)
+		}
+	}
+	if now.After(end) {
+		now = end
+	}
+	if !now.After(start) {
+		return nil
+	}
+
+	// The dates are in UTC, whose days are 24 hours long
+	weight := float64(now.Sub(start)) / float64(24*time.Hour)
+	accumulate := func(total, value int64) int64 {
+		return total + int64(math.Round(float64(value)*weight))
+	}
+
+	if u.Volumes == nil {
+		u.Volumes = map[string]*VolumeUsage{}
+	}
+	for name, sample := range samples {
+		current, ok := u.Volumes[name]
+		if !ok {
+			current = &VolumeUsage{}
+			u.Volumes[name] = current
+		}
+
+		backupSize := map[string]int64{}
+		for backupTarget, size := range current.BackupSize {
+			backupSize[backupTarget] = size
+		}
+		for backupTarget, size := range sample.BackupSize {
+			backupSize[backupTarget] = accumulate(backupSize[backupTarget], size)
+		}
+
+		*current = VolumeUsage{
+			Volume:           sample.Volume,
+			Namespace:        sample.Namespace,
+			PVC:              sample.PVC,
+			Labels:           sample.Labels,
+			NumberOfReplicas: sample.NumberOfReplicas,
+			Size:             accumulate(current.Size, sample.Size),
+			ActualSize:       accumulate(current.ActualSize, sample.ActualSize),
+			SnapshotSize:     accumulate(current.SnapshotSize, sample.SnapshotSize),
+			BackupSize:       backupSize,
+		}
+	}
+	u.LastSampleTime = now.UTC().Format(time.RFC3339)
+	return nil
+}
+
+// SplitDailyUsage splits the daily usage into the shards persisted in the ConfigMaps, whose volumes don't exceed the
+// maximum size once encoded. There is always at least one shard.
+func SplitDailyUsage(usage *DailyUsage, maxSize int) ([]*DailyUsage, error) {
+	newShard := func() *DailyUsage {
+		return &DailyUsage{
+			Date:           usage.Date,
+			Sampler:        usage.Sampler,
+			LastSampleTime: usage.LastSampleTime,
+			Volumes:        map[string]*VolumeUsage{},
+		}
+	}
+
+	shard := newShard()
+	shards := []*DailyUsage{shard}
+	// The size of the encoded volumes of the shard, starting with the braces without the trailing comma
+	size := 1
+	for _, name := range slices.Sorted(maps.Keys(usage.Volumes)) {
+		data, err := json.Marshal(usage.Volumes[name])
+		if err != nil {
+			return nil, err
+		}
+		// The quoted name, the colon and the comma
+		entrySize := len(name) + len(data) + 4
+		if len(shard.Volumes) > 0 && size+entrySize > maxSize {
+			shard = newShard()
+			shards = append(shards, shard)
+			size = 1
+		}
+		shard.Volumes[name] = usage.Volumes[name]
+		size += entrySize
+	}
+	return shards, nil
+}
+
+// MergeDailyUsages merges the shards of the daily usage of a date.
+func MergeDailyUsages(shards []*DailyUsage) *DailyUsage {
+	usage := &DailyUsage{Volumes: map[string]*VolumeUsage{}}
+	for _, shard := range shards {
+		usage.Date = shard.Date
+		usage.Sampler = shard.Sampler
+		if shard.LastSampleTime > usage.LastSampleTime {
+			usage.LastSampleTime = shard.LastSampleTime
+		}
+		for name, volumeUsage := range shard.Volumes {
+			usage.Volumes[name] = volumeUsage
+		}
+	}
+	return usage
+}
+
+// ValidateUsageGroupBy returns an error if a dimension to group the usage by is unknown.
+func ValidateUsageGroupBy(groupBy []string) error {
+	for _, dimension := range groupBy {
+		switch {
+		case dimension == UsageGroupByNamespace, dimension == UsageGroupByPVC, dimension == UsageGroupByVolume:
+		case strings.HasPrefix(dimension, UsageGroupByLabelPrefix) && len(dimension) > len(UsageGroupByLabelPrefix):
+		default:
+			return fmt.Errorf("invalid usage group %v, must be %v, %v, %v or %v<LABEL KEY>",
+				dimension, UsageGroupByNamespace, UsageGroupByPVC, UsageGroupByVolume, UsageGroupByLabelPrefix)
+		}
+	}
+	return nil
+}
+
+func getVolumeUsageGroup(usage *VolumeUsage, groupBy []string) map[string]string {
+	group := map[string]string{}
+	for _, dimension := range groupBy {
+		switch dimension {
+		case UsageGroupByNamespace:
+			group[dimension] = usage.Namespace
+		case UsageGroupByPVC:
+			group[dimension] = usage.PVC
+		case UsageGroupByVolume:
+			group[dimension] = usage.Volume
+		default:
+			group[dimension] = usage.Labels[strings.TrimPrefix(dimension, UsageGroupByLabelPrefix)]
+		}
+	}
+	return group
+}
+
+// AggregateDailyUsages returns the totals of the daily usages by the days and the groups of the volumes. The volumes
+// are grouped by the namespace, the PVC, the volume name or the value of a label, and all volumes are in one group if
+// groupBy is empty. The totals are sorted by the dates and then the groups.
+func AggregateDailyUsages(usages []*DailyUsage, groupBy []string) []*UsageTotal {
+	var totals []*UsageTotal
+	for _, usage := range usages {
+		totalsByGroup := map[string]*UsageTotal{}
+		for _, volumeUsage := range usage.Volumes {
+			group := getVolumeUsageGroup(volumeUsage, groupBy)
+			key := ""
+			for _, dimension := range groupBy {
+				key += dimension + "=" + group[dimension] + "\x00"
+			}
+			total, ok := totalsByGroup[key]
+			if !ok {
+				total = &UsageTotal{Date: usage.Date, Group: group, BackupSize: map[string]int64{}}
+				totalsByGroup[key] = total
+			}
+			total.VolumeCount++
+			total.Size += volumeUsage.Size
+			total.ActualSize += volumeUsage.ActualSize
+			total.SnapshotSize += volumeUsage.SnapshotSize
+			for backupTarget, size := range volumeUsage.BackupSize {
+				total.BackupSize[backupTarget] += size
+			}
+		}
+		for _, key := range slices.Sorted(maps.Keys(totalsByGroup)) {
+			totals = append(totals, totalsByGroup[key])
+		}
+	}
+	sort.SliceStable(totals, func(i, j int) bool { return totals[i].Date < totals[j].Date })
+	return totals
+}
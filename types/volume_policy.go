@@ -0,0 +1,127 @@
+package types
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/cockroachdb/errors"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+)
+
+// ValidateVolumePolicySpec returns an error if the constraints of the volume policy are invalid.
+func ValidateVolumePolicySpec(spec *longhorn.VolumePolicySpec) error {
+	switch spec.Enforcement {
+	case "", longhorn.VolumePolicyEnforcementDeny, longhorn.VolumePolicyEnforcementMutate:
+	default:
+		return fmt.Errorf("invalid enforcement %v", spec.Enforcement)
+	}
+	if spec.VolumeSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(spec.VolumeSelector); err != nil {
+			return errors.Wrap(err, "invalid volume selector")
+		}
+	}
+	if spec.MinNumberOfReplicas < 0 {
+		return fmt.Errorf("invalid minimum number of replicas %v", spec.MinNumberOfReplicas)
+	}
+	if spec.MaxSize < 0 {
+		return fmt.Errorf("invalid maximum size %v", spec.MaxSize)
+	}
+	for _, dataEngine := range spec.AllowedDataEngines {
+		if !IsDataEngineV1(dataEngine) && !IsDataEngineV2(dataEngine) {
+			return fmt.Errorf("invalid data engine %v", dataEngine)
+		}
+	}
+	switch spec.DataLocality {
+	case "", longhorn.DataLocalityDisabled, longhorn.DataLocalityBestEffort, longhorn.DataLocalityStrictLocal:
+	default:
+		return fmt.Errorf("invalid data locality %v", spec.DataLocality)
+	}
+	if spec.DataLocality == longhorn.DataLocalityStrictLocal && spec.MinNumberOfReplicas > 1 {
+		return fmt.Errorf("data locality %v requires exactly one replica, but the minimum number of replicas is %v", spec.DataLocality, spec.MinNumberOfReplicas)
+	}
+	return nil
+}
+
+// IsVolumePolicyMatched returns true if the volume policy applies to the volume, by the namespace of the PVC and the
+// labels of the volume.
+func IsVolumePolicyMatched(policy *longhorn.VolumePolicy, v *longhorn.Volume) (bool, error) {
+	if len(policy.Spec.Namespaces) > 0 && !slices.Contains(policy.Spec.Namespaces, GetVolumePVCNamespace(v)) {
+		return false, nil
+	}
+	if policy.Spec.VolumeSelector == nil {
+		return true, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(policy.Spec.VolumeSelector)
+	if err != nil {
+		return false, errors.Wrapf(err, "invalid volume selector of volume policy %v", policy.Name)
+	}
+	return selector.Matches(labels.Set(v.Labels)), nil
+}
+
+// GetVolumePolicyViolations returns the violation messages of the volume by the violated constraints of the volume
+// policy, which are keyed by the field names of the constraints in the policy spec.
+func GetVolumePolicyViolations(spec *longhorn.VolumePolicySpec, v *longhorn.Volume) map[string]string {
+	violations := map[string]string{}
+	if spec.MinNumberOfReplicas > 0 && v.Spec.NumberOfReplicas < spec.MinNumberOfReplicas {
+		violations["minNumberOfReplicas"] = fmt.Sprintf("number of replicas %v is less than the minimum %v", v.Spec.NumberOfReplicas, spec.MinNumberOfReplicas)
+	}
+	if len(spec.AllowedDataEngines) > 0 && !slices.Contains(spec.AllowedDataEngines, v.Spec.DataEngine) {
+		violations["allowedDataEngines"] = fmt.Sprintf("data engine %v is not allowed, allowed data engines: %v", v.Spec.DataEngine, spec.AllowedDataEngines)
+	}
+	if spec.RequireEncryption && !v.Spec.Encrypted {
+		violations["requireEncryption"] = "volume is not encrypted"
+	}
+	if spec.MaxSize > 0 && v.Spec.Size > spec.MaxSize {
+		violations["maxSize"] = fmt.Sprintf("size %v exceeds the maximum %v", v.Spec.Size, spec.MaxSize)
+	}
+	if len(spec.AllowedBackupTargets) > 0 {
+		backupTargetName := v.Spec.BackupTargetName
+		if backupTargetName == "" {
+			backupTargetName = DefaultBackupTargetName
+		}
+		if !slices.Contains(spec.AllowedBackupTargets, backupTargetName) {
+			violations["allowedBackupTargets"] = fmt.Sprintf("backup target %v is not allowed, allowed backup targets: %v", backupTargetName, spec.AllowedBackupTargets)
+		}
+	}
+	if spec.DataLocality != "" && v.Spec.DataLocality != spec.DataLocality {
+		violations["dataLocality"] = fmt.Sprintf("data locality %v is not the required %v", v.Spec.DataLocality, spec.DataLocality)
+	}
+	return violations
+}
+
+// IsVolumePolicyOverlapping returns true if the volume policies may apply to the same volume. The volume selectors
+// are only told apart by the match labels of the same key requiring different values, so the policies selecting the
+// volumes in the other ways are considered overlapping.
+func IsVolumePolicyOverlapping(a, b *longhorn.VolumePolicySpec) bool {
+	if len(a.Namespaces) > 0 && len(b.Namespaces) > 0 &&
+		!slices.ContainsFunc(a.Namespaces, func(namespace string) bool { return slices.Contains(b.Namespaces, namespace) }) {
+		return false
+	}
+	if a.VolumeSelector == nil || b.VolumeSelector == nil {
+		return true
+	}
+	for key, value := range a.VolumeSelector.MatchLabels {
+		if otherValue, ok := b.VolumeSelector.MatchLabels[key]; ok && otherValue != value {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateVolumePolicyConflict returns an error if no volume satisfies both volume policies, which would reject all
+// the volumes the policies both apply to.
+func ValidateVolumePolicyConflict(a, b *longhorn.VolumePolicySpec) error {
+	if a.DataLocality != "" && b.DataLocality != "" && a.DataLocality != b.DataLocality {
+		return fmt.Errorf("data locality %v conflicts with data locality %v", a.DataLocality, b.DataLocality)
+	}
+	for _, pair := range [][2]*longhorn.VolumePolicySpec{{a, b}, {b, a}} {
+		if pair[0].DataLocality == longhorn.DataLocalityStrictLocal && pair[1].MinNumberOfReplicas > 1 {
+			return fmt.Errorf("data locality %v requires exactly one replica, but the minimum number of replicas is %v", pair[0].DataLocality, pair[1].MinNumberOfReplicas)
+		}
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"encoding/json"
 	"fmt"
 
 	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
@@ -53,3 +54,27 @@ func IsVolumeReady(v *longhorn.Volume, vrs []*longhorn.Replica, volOp string) (r
 	}
 	return true, ""
 }
+
+// GetVolumePVCNamespace returns the namespace of the PVC of the volume. The namespace is in the Kubernetes status once
+// the PV is bound, which is synced from the PVC and takes precedence. Before that, it's the namespace labeled on the
+// volume when it is provisioned by the CSI driver, which cannot be changed once set.
+func GetVolumePVCNamespace(v *longhorn.Volume) string {
+	if namespace := v.Status.KubernetesStatus.Namespace; namespace != "" {
+		return namespace
+	}
+	return v.Labels[GetLonghornLabelKey(LonghornLabelPVCNamespace)]
+}
+
+// GetBackupKubernetesStatus returns the Kubernetes status of the volume when the backup was taken, which is recorded
+// in the KubernetesStatus label of the backup. Returns nil if unknown.
+func GetBackupKubernetesStatus(backup *longhorn.Backup) *longhorn.KubernetesStatus {
+	statusJSON, ok := backup.Status.Labels[KubernetesStatusLabel]
+	if !ok {
+		return nil
+	}
+	kubeStatus := &longhorn.KubernetesStatus{}
+	if err := json.Unmarshal([]byte(statusJSON), kubeStatus); err != nil {
+		return nil
+	}
+	return kubeStatus
+}
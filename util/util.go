@@ -722,6 +722,94 @@ func GetVolumeMeta(path string) (*VolumeMeta, error) {
 	return meta, nil
 }
 
+const (
+	replicaVolumeMetaFile      = "volume.meta"
+	replicaRevisionCounterFile = "revision.counter"
+	replicaDiskMetaSuffix      = ".meta"
+	replicaSnapshotDiskPrefix  = "volume-snap-"
+	replicaSnapshotDiskSuffix  = ".img"
+)
+
+// ReplicaDataMetadata is the metadata of the data of a v1 replica on the host.
+type ReplicaDataMetadata struct {
+	RevisionCounter    int64
+	HeadName           string
+	HeadSize           int64
+	HeadLastModifiedAt time.Time
+	Dirty              bool
+	Rebuilding         bool
+	// The snapshots in the chain of the volume head, from the newest to the oldest.
+	Chain []string
+}
+
+type replicaDiskMeta struct {
+	Name   string
+	Parent string
+}
+
+// GetReplicaDataMetadata reads the metadata of the data of a stopped v1 replica from the data path on the host.
+func GetReplicaDataMetadata(dataPath string, revisionCounterDisabled bool) (*ReplicaDataMetadata, error) {
+	meta, err := GetVolumeMeta(filepath.Join(dataPath, replicaVolumeMetaFile))
+	if err != nil {
+		return nil, err
+	}
+	if meta.Head == "" {
+		return nil, fmt.Errorf("missing volume head in %v", dataPath)
+	}
+
+	headInfo, err := lhns.GetFileInfo(filepath.Join(dataPath, meta.Head))
+	if err != nil {
+		return nil, err
+	}
+	headSize := headInfo.Size()
+	// The head file is sparse, so the actual size is the size of the allocated blocks
+	if stat, ok := headInfo.Sys().(*syscall.Stat_t); ok {
+		headSize = stat.Blocks * 512
+	}
+
+	metadata := &ReplicaDataMetadata{
+		HeadName:           meta.Head,
+		HeadSize:           headSize,
+		HeadLastModifiedAt: headInfo.ModTime(),
+		Dirty:              meta.Dirty,
+		Rebuilding:         meta.Rebuilding,
+		Chain:              []string{},
+	}
+
+	if !revisionCounterDisabled {
+		content, err := lhns.ReadFileContent(filepath.Join(dataPath, replicaRevisionCounterFile))
+		if err != nil {
+			return nil, err
+		}
+		counter := strings.TrimSpace(strings.Trim(content, "\x00"))
+		if metadata.RevisionCounter, err = strconv.ParseInt(counter, 10, 64); err != nil {
+			return nil, errors.Wrapf(err, "invalid revision counter %q in %v", counter, dataPath)
+		}
+	}
+
+	visited := map[string]bool{}
+	for parent := meta.Parent; parent != ""; {
+		if visited[parent] {
+			return nil, fmt.Errorf("found a loop in the snapshot chain at %v in %v", parent, dataPath)
+		}
+		visited[parent] = true
+
+		metadata.Chain = append(metadata.Chain, strings.TrimSuffix(strings.TrimPrefix(parent, replicaSnapshotDiskPrefix), replicaSnapshotDiskSuffix))
+
+		content, err := lhns.ReadFileContent(filepath.Join(dataPath, parent+replicaDiskMetaSuffix))
+		if err != nil {
+			return nil, err
+		}
+		diskMeta := &replicaDiskMeta{}
+		if err := json.Unmarshal([]byte(content), diskMeta); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal the metadata of %v in %v", parent, dataPath)
+		}
+		parent = diskMeta.Parent
+	}
+
+	return metadata, nil
+}
+
 func CapitalizeFirstLetter(input string) string {
 	return strings.ToUpper(input[:1]) + input[1:]
 }
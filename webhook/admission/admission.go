@@ -11,6 +11,8 @@ import (
 
 	admissionv1 "k8s.io/api/admission/v1"
 
+	"github.com/longhorn/longhorn-manager/audit"
+
 	werror "github.com/longhorn/longhorn-manager/webhook/error"
 )
 
@@ -52,9 +54,12 @@ type Handler struct {
 	admitter      Admitter
 	admissionType string
 	logger        logrus.FieldLogger
+	auditor       *audit.Logger
 }
 
-func NewHandler(admitter Admitter, admissionType string) *Handler {
+// NewHandler creates the handler of the admitter. The auditor is optional, the admission requests are written to the
+// audit log if it is set.
+func NewHandler(admitter Admitter, admissionType string, auditor *audit.Logger) *Handler {
 	if err := admitter.Resource().Validate(); err != nil {
 		panic(err.Error())
 	}
@@ -62,11 +67,14 @@ func NewHandler(admitter Admitter, admissionType string) *Handler {
 		admitter:      admitter,
 		admissionType: admissionType,
 		logger:        logrus.StandardLogger().WithField("service", "admissionWebhook"),
+		auditor:       auditor,
 	}
 }
 
 func (v *Handler) Admit(response *webhook.Response, request *webhook.Request) error {
-	v.admit(response, NewRequest(request))
+	req := NewRequest(request)
+	v.admit(response, req)
+	v.audit(response, req)
 	return nil
 }
 
@@ -117,3 +125,45 @@ func (v *Handler) admit(response *webhook.Response, req *Request) {
 
 	response.Allowed = true
 }
+
+func (v *Handler) audit(response *webhook.Response, req *Request) {
+	if !v.auditor.Enabled() || (req.DryRun != nil && *req.DryRun) {
+		return
+	}
+
+	record := &audit.Record{
+		Source:    audit.SourceAdmission,
+		User:      req.UserInfo.Username,
+		Groups:    req.UserInfo.Groups,
+		Operation: string(req.Operation),
+		Resource:  req.Resource.Resource,
+		Namespace: req.Namespace,
+		Name:      req.Name,
+		Action:    req.SubResource,
+		Result:    audit.ResultSuccess,
+	}
+
+	var err error
+	switch req.Operation {
+	case admissionv1.Create, admissionv1.Update:
+		record.Diff, err = audit.GetDiff(req.OldObject.Raw, req.Object.Raw)
+		// Skip the updates that change nothing but the metadata ignored by the audit
+		if err == nil && req.Operation == admissionv1.Update && response.Allowed && string(record.Diff) == "{}" {
+			return
+		}
+	case admissionv1.Delete:
+		record.Deleted, err = audit.TrimObject(req.OldObject.Raw)
+	}
+	if err != nil {
+		v.logger.WithError(err).Warnf("Failed to get the change of %s for the audit record", req)
+	}
+
+	if !response.Allowed {
+		record.Result = audit.ResultDenied
+		if response.Result != nil {
+			record.StatusCode = int(response.Result.Code)
+			record.Error = response.Result.Message
+		}
+	}
+	v.auditor.Log(record)
+}
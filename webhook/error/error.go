@@ -4,6 +4,8 @@ import (
 	"net/http"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/longhorn/longhorn-manager/types"
 )
 
 type AdmitError struct {
@@ -94,3 +96,18 @@ func NewForbiddenError(message string) AdmitError {
 		reason:  metav1.StatusReasonForbidden,
 	}
 }
+
+// NewStorageQuotaExceededError returns HTTP status code 403 with the cause telling the rejection by a storage quota
+func NewStorageQuotaExceededError(message string) AdmitError {
+	return AdmitError{
+		code:    http.StatusForbidden,
+		message: message,
+		reason:  metav1.StatusReasonForbidden,
+		causes: []metav1.StatusCause{
+			{
+				Type:    types.StorageQuotaExceededCode,
+				Message: message,
+			},
+		},
+	}
+}
@@ -0,0 +1,58 @@
+package alertsilence
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	admissionregv1 "k8s.io/api/admissionregistration/v1"
+
+	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/types"
+	"github.com/longhorn/longhorn-manager/webhook/admission"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+	werror "github.com/longhorn/longhorn-manager/webhook/error"
+)
+
+type alertSilenceValidator struct {
+	admission.DefaultValidator
+	ds *datastore.DataStore
+}
+
+func NewValidator(ds *datastore.DataStore) admission.Validator {
+	return &alertSilenceValidator{ds: ds}
+}
+
+func (v *alertSilenceValidator) Resource() admission.Resource {
+	return admission.Resource{
+		Name:       "alertsilences",
+		Scope:      admissionregv1.NamespacedScope,
+		APIGroup:   longhorn.SchemeGroupVersion.Group,
+		APIVersion: longhorn.SchemeGroupVersion.Version,
+		ObjectType: &longhorn.AlertSilence{},
+		OperationTypes: []admissionregv1.OperationType{
+			admissionregv1.Create,
+			admissionregv1.Update,
+		},
+	}
+}
+
+func (v *alertSilenceValidator) Create(request *admission.Request, newObj runtime.Object) error {
+	silence, ok := newObj.(*longhorn.AlertSilence)
+	if !ok {
+		return werror.NewInvalidError(fmt.Sprintf("%v is not a *longhorn.AlertSilence", newObj), "")
+	}
+
+	if err := types.ValidateAlertNames(silence.Spec.AlertNames); err != nil {
+		return werror.NewInvalidError(err.Error(), "spec.alertNames")
+	}
+	if err := types.ValidateAlertSilenceResources(silence.Spec.Resources); err != nil {
+		return werror.NewInvalidError(err.Error(), "spec.resources")
+	}
+	return nil
+}
+
+func (v *alertSilenceValidator) Update(request *admission.Request, oldObj runtime.Object, newObj runtime.Object) error {
+	return v.Create(request, newObj)
+}
@@ -0,0 +1,55 @@
+package alertsink
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	admissionregv1 "k8s.io/api/admissionregistration/v1"
+
+	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/types"
+	"github.com/longhorn/longhorn-manager/webhook/admission"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+	werror "github.com/longhorn/longhorn-manager/webhook/error"
+)
+
+type alertSinkValidator struct {
+	admission.DefaultValidator
+	ds *datastore.DataStore
+}
+
+func NewValidator(ds *datastore.DataStore) admission.Validator {
+	return &alertSinkValidator{ds: ds}
+}
+
+func (v *alertSinkValidator) Resource() admission.Resource {
+	return admission.Resource{
+		Name:       "alertsinks",
+		Scope:      admissionregv1.NamespacedScope,
+		APIGroup:   longhorn.SchemeGroupVersion.Group,
+		APIVersion: longhorn.SchemeGroupVersion.Version,
+		ObjectType: &longhorn.AlertSink{},
+		OperationTypes: []admissionregv1.OperationType{
+			admissionregv1.Create,
+			admissionregv1.Update,
+		},
+	}
+}
+
+func (v *alertSinkValidator) Create(request *admission.Request, newObj runtime.Object) error {
+	alertSink, ok := newObj.(*longhorn.AlertSink)
+	if !ok {
+		return werror.NewInvalidError(fmt.Sprintf("%v is not a *longhorn.AlertSink", newObj), "")
+	}
+
+	if err := types.ValidateAlertSinkSpec(&alertSink.Spec); err != nil {
+		return werror.NewInvalidError(err.Error(), "spec")
+	}
+	return nil
+}
+
+func (v *alertSinkValidator) Update(request *admission.Request, oldObj runtime.Object, newObj runtime.Object) error {
+	return v.Create(request, newObj)
+}
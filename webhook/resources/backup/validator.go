@@ -75,6 +75,12 @@ func (b *backupValidator) Create(request *admission.Request, newObj runtime.Obje
 
 	labelVolumeName := backup.Labels[types.LonghornLabelBackupVolume]
 
+	if util.GetSchemeFromURL(backupTarget.Spec.BackupTargetURL) == types.BackupStoreTypeFile {
+		if volumeName, _, _, err := types.ParseFileBackupTargetURL(backupTarget.Spec.BackupTargetURL); err == nil && volumeName == labelVolumeName {
+			return werror.NewInvalidError(fmt.Sprintf("volume %v stores the backups of backup target %v and cannot be backed up to it", volumeName, backupTargetName), "")
+		}
+	}
+
 	if backup.Spec.SnapshotName != "" {
 		//check if label volume name matches snapshot volume name
 		snapshot, err := b.ds.GetSnapshotRO(backup.Spec.SnapshotName)
@@ -96,6 +102,10 @@ func (b *backupValidator) Create(request *admission.Request, newObj runtime.Obje
 		if volumeBackupTargetName != backupTargetName {
 			return werror.NewInvalidError(fmt.Sprintf("volume backup target %s and label backup target %s does not match", volumeBackupTargetName, backupTargetName), "")
 		}
+
+		if err := b.validateStorageQuota(backup, snapshot, volume); err != nil {
+			return err
+		}
 	}
 
 	if isLinkedClone, err := b.ds.IsVolumeLinkedCloneVolume(labelVolumeName); err != nil {
@@ -109,6 +119,20 @@ func (b *backupValidator) Create(request *admission.Request, newObj runtime.Obje
 	return nil
 }
 
+// validateStorageQuota rejects the new backup exceeding the backup size limit of the storage quota of the PVC namespace
+// of its volume, and reserves the backup in the quota otherwise. The backup uploads at least the data changed in the
+// snapshot, which is the estimated increment.
+func (b *backupValidator) validateStorageQuota(backup *longhorn.Backup, snapshot *longhorn.Snapshot, volume *longhorn.Volume) error {
+	namespace := types.GetVolumePVCNamespace(volume)
+	if err := b.ds.ReserveStorageQuota(namespace, &longhorn.StorageQuotaUsage{BackupSize: max(snapshot.Status.Size, 1)}); err != nil {
+		if datastore.ErrorIsStorageQuotaExceeded(err) {
+			return werror.NewStorageQuotaExceededError(fmt.Sprintf("backup %v of volume %v %v", backup.Name, volume.Name, err))
+		}
+		return werror.NewInternalError(fmt.Sprintf("failed to reserve the storage quota of namespace %v: %v", namespace, err))
+	}
+	return nil
+}
+
 func (b *backupValidator) Update(request *admission.Request, oldObj runtime.Object, newObj runtime.Object) error {
 	oldBackup, ok := oldObj.(*longhorn.Backup)
 	if !ok {
@@ -63,6 +63,10 @@ func (b *backupTargetValidator) Create(request *admission.Request, newObj runtim
 		return werror.NewInvalidError(err.Error(), "")
 	}
 
+	if err := b.validateBackupStoreCredential(backupTarget); err != nil {
+		return werror.NewInvalidError(err.Error(), "")
+	}
+
 	if err := b.handleAWSIAMRoleAnnotation(backupTarget, nil); err != nil {
 		return werror.NewInvalidError(err.Error(), "")
 	}
@@ -114,6 +118,9 @@ func (b *backupTargetValidator) Update(request *admission.Request, oldObj runtim
 	}
 
 	if urlChanged || secretChanged {
+		if err := b.validateBackupStoreCredential(newBackupTarget); err != nil {
+			return werror.NewInvalidError(err.Error(), "")
+		}
 		if err := b.validateDRVolume(newBackupTarget); err != nil {
 			return werror.NewInvalidError(err.Error(), "")
 		}
@@ -147,6 +154,10 @@ func (b *backupTargetValidator) validateCredentialSecret(secretName string) erro
 		types.AZBlobAccountKey,
 		types.AZBlobEndpoint,
 		types.AZBlobCert,
+		types.AWSRoleArn,
+		types.AWSWebIdentityTokenFile,
+		types.AWSRoleSessionName,
+		types.AWSSTSEndpoint,
 		types.HTTPSProxy,
 		types.HTTPProxy,
 		types.NOProxy,
@@ -182,6 +193,40 @@ func (b *backupTargetValidator) validateCredentialSecret(secretName string) erro
 	return nil
 }
 
+// validateBackupStoreCredential checks the credential secret can be used to access the backup store of the URL.
+func (b *backupTargetValidator) validateBackupStoreCredential(backupTarget *longhorn.BackupTarget) error {
+	if backupTarget.Spec.BackupTargetURL == "" {
+		return nil
+	}
+	backupType, err := util.CheckBackupType(backupTarget.Spec.BackupTargetURL)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse %v as url", backupTarget.Spec.BackupTargetURL)
+	}
+	if backupTarget.Spec.CredentialSecret == "" {
+		return nil
+	}
+
+	namespace, err := b.ds.GetLonghornNamespace()
+	if err != nil {
+		return errors.Wrapf(err, "failed to get Longhorn namespace")
+	}
+	secret, err := b.ds.GetSecretRO(namespace.Name, backupTarget.Spec.CredentialSecret)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get credential secret %v", backupTarget.Spec.CredentialSecret)
+	}
+	credential := map[string]string{}
+	for key, value := range secret.Data {
+		credential[key] = string(value)
+	}
+	if err := types.ValidateBackupStoreCredential(backupType, credential); err != nil {
+		return errors.Wrapf(err, "invalid credential secret %v for %v backup target", backupTarget.Spec.CredentialSecret, backupType)
+	}
+	return nil
+}
+
 func (b *backupTargetValidator) validateDRVolume(backupTarget *longhorn.BackupTarget) error {
 	vs, err := b.ds.ListDRVolumesRO()
 	if err != nil {
@@ -0,0 +1,97 @@
+package backuptarget
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/types"
+	"github.com/longhorn/longhorn-manager/util"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+	lhfake "github.com/longhorn/longhorn-manager/k8s/pkg/client/clientset/versioned/fake"
+)
+
+func TestValidateBackupStoreCredential(t *testing.T) {
+	assert := require.New(t)
+
+	const namespace = "longhorn-system"
+
+	type testCase struct {
+		backupTargetURL string
+		credential      map[string][]byte
+
+		expectError bool
+	}
+	testCases := map[string]testCase{
+		"s3 static keys": {
+			backupTargetURL: "s3://backupbucket@us-east-1/",
+			credential: map[string][]byte{
+				types.AWSAccessKey: []byte("id"),
+				types.AWSSecretKey: []byte("secret"),
+			},
+		},
+		"s3 web identity": {
+			backupTargetURL: "s3://backupbucket@us-east-1/",
+			credential: map[string][]byte{
+				types.AWSRoleArn:     []byte("arn:aws:iam::013456789:role/longhorn"),
+				types.AWSSTSEndpoint: []byte("http://minio.default:9000"),
+			},
+		},
+		"s3 web identity with static keys": {
+			backupTargetURL: "s3://backupbucket@us-east-1/",
+			credential: map[string][]byte{
+				types.AWSRoleArn:   []byte("arn:aws:iam::013456789:role/longhorn"),
+				types.AWSAccessKey: []byte("id"),
+				types.AWSSecretKey: []byte("secret"),
+			},
+			expectError: true,
+		},
+		"s3 web identity with relative token file": {
+			backupTargetURL: "s3://backupbucket@us-east-1/",
+			credential: map[string][]byte{
+				types.AWSRoleArn:              []byte("arn:aws:iam::013456789:role/longhorn"),
+				types.AWSWebIdentityTokenFile: []byte("token"),
+			},
+			expectError: true,
+		},
+		"nfs": {
+			backupTargetURL: "nfs://longhorn-test-nfs-svc.default:/opt/backupstore",
+		},
+	}
+
+	for name, tc := range testCases {
+		kubeClient := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}})
+		lhClient := lhfake.NewSimpleClientset()
+		informerFactories := util.NewInformerFactories(namespace, kubeClient, lhClient, 0)
+
+		backupTarget := &longhorn.BackupTarget{
+			ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: namespace},
+			Spec:       longhorn.BackupTargetSpec{BackupTargetURL: tc.backupTargetURL},
+		}
+		if tc.credential != nil {
+			backupTarget.Spec.CredentialSecret = "credential"
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: backupTarget.Spec.CredentialSecret, Namespace: namespace},
+				Data:       tc.credential,
+			}
+			assert.NoError(informerFactories.KubeNamespaceFilteredInformerFactory.Core().V1().Secrets().Informer().GetIndexer().Add(secret), name)
+		}
+
+		validator := &backupTargetValidator{
+			ds: datastore.NewDataStore(namespace, lhClient, kubeClient, apiextensionsfake.NewSimpleClientset(), informerFactories),
+		}
+		err := validator.validateBackupStoreCredential(backupTarget)
+		if tc.expectError {
+			assert.Error(err, name)
+		} else {
+			assert.NoError(err, name)
+		}
+	}
+}
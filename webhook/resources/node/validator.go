@@ -205,6 +205,22 @@ func (n *nodeValidator) Update(request *admission.Request, oldObj runtime.Object
 		}
 	}
 
+	// Validate disk replacement, only the failed disk currently recorded can be replaced
+	for name, newDisk := range newNode.Spec.Disks {
+		if newDisk.ReplacedDiskUUID == "" || newDisk.ReplacedDiskUUID == oldNode.Spec.Disks[name].ReplacedDiskUUID {
+			continue
+		}
+		diskStatus, ok := oldNode.Status.DiskStatus[name]
+		if !ok || diskStatus.DiskUUID != newDisk.ReplacedDiskUUID {
+			return werror.NewInvalidError(fmt.Sprintf("update disk on node %v error: The disk %v(%v) cannot replace disk UUID %v which is not recorded in its status",
+				newNode.Name, name, newDisk.Path, newDisk.ReplacedDiskUUID), "")
+		}
+		if types.GetCondition(diskStatus.Conditions, longhorn.DiskConditionTypeReady).Status == longhorn.ConditionStatusTrue {
+			return werror.NewInvalidError(fmt.Sprintf("update disk on node %v error: The disk %v(%v) is still ready and cannot be replaced",
+				newNode.Name, name, newDisk.Path), "")
+		}
+	}
+
 	return nil
 }
 
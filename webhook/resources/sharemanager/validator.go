@@ -0,0 +1,60 @@
+package sharemanager
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	admissionregv1 "k8s.io/api/admissionregistration/v1"
+
+	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/types"
+	"github.com/longhorn/longhorn-manager/webhook/admission"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+	werror "github.com/longhorn/longhorn-manager/webhook/error"
+)
+
+type shareManagerValidator struct {
+	admission.DefaultValidator
+	ds *datastore.DataStore
+}
+
+func NewValidator(ds *datastore.DataStore) admission.Validator {
+	return &shareManagerValidator{ds: ds}
+}
+
+func (s *shareManagerValidator) Resource() admission.Resource {
+	return admission.Resource{
+		Name:       "sharemanagers",
+		Scope:      admissionregv1.NamespacedScope,
+		APIGroup:   longhorn.SchemeGroupVersion.Group,
+		APIVersion: longhorn.SchemeGroupVersion.Version,
+		ObjectType: &longhorn.ShareManager{},
+		OperationTypes: []admissionregv1.OperationType{
+			admissionregv1.Create,
+			admissionregv1.Update,
+		},
+	}
+}
+
+func (s *shareManagerValidator) Create(request *admission.Request, newObj runtime.Object) error {
+	return validate(newObj)
+}
+
+func (s *shareManagerValidator) Update(request *admission.Request, oldObj runtime.Object, newObj runtime.Object) error {
+	return validate(newObj)
+}
+
+// validate contains functionality shared by Create and Update.
+func validate(newObj runtime.Object) error {
+	shareManager, ok := newObj.(*longhorn.ShareManager)
+	if !ok {
+		return werror.NewInvalidError(fmt.Sprintf("%v is not a *longhorn.ShareManager", newObj), "")
+	}
+
+	if err := types.ValidateNFSExportOptions(shareManager.Spec.NFSExportOptions); err != nil {
+		return werror.NewInvalidError(err.Error(), "spec.nfsExportOptions")
+	}
+	return nil
+}
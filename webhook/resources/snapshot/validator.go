@@ -59,6 +59,33 @@ func (o *snapshotValidator) Create(request *admission.Request, newObj runtime.Ob
 		return werror.NewInvalidError(fmt.Sprintf("snapshot is not allowed for linked-clone volume %v", snapshot.Spec.Volume), "")
 	}
 
+	if snapshot.Spec.CreateSnapshot {
+		if err := o.validateStorageQuota(snapshot); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateStorageQuota rejects the new snapshot exceeding the snapshot limits of the storage quota of the PVC
+// namespace of its volume, and reserves the snapshot in the quota otherwise.
+func (o *snapshotValidator) validateStorageQuota(snapshot *longhorn.Snapshot) error {
+	volume, err := o.ds.GetVolumeRO(snapshot.Spec.Volume)
+	if err != nil {
+		return werror.NewInternalError(fmt.Sprintf("failed to get volume %v: %v", snapshot.Spec.Volume, err))
+	}
+	increment, err := o.ds.GetSnapshotStorageQuotaIncrement(volume)
+	if err != nil {
+		return werror.NewInternalError(err.Error())
+	}
+	namespace := types.GetVolumePVCNamespace(volume)
+	if err := o.ds.ReserveStorageQuota(namespace, increment); err != nil {
+		if datastore.ErrorIsStorageQuotaExceeded(err) {
+			return werror.NewStorageQuotaExceededError(fmt.Sprintf("snapshot %v of volume %v %v", snapshot.Name, volume.Name, err))
+		}
+		return werror.NewInternalError(fmt.Sprintf("failed to reserve the storage quota of namespace %v: %v", namespace, err))
+	}
 	return nil
 }
 
@@ -0,0 +1,63 @@
+package storagequota
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	admissionregv1 "k8s.io/api/admissionregistration/v1"
+
+	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/types"
+	"github.com/longhorn/longhorn-manager/webhook/admission"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+	werror "github.com/longhorn/longhorn-manager/webhook/error"
+)
+
+type storageQuotaValidator struct {
+	admission.DefaultValidator
+	ds *datastore.DataStore
+}
+
+func NewValidator(ds *datastore.DataStore) admission.Validator {
+	return &storageQuotaValidator{ds: ds}
+}
+
+func (v *storageQuotaValidator) Resource() admission.Resource {
+	return admission.Resource{
+		Name:       "storagequotas",
+		Scope:      admissionregv1.NamespacedScope,
+		APIGroup:   longhorn.SchemeGroupVersion.Group,
+		APIVersion: longhorn.SchemeGroupVersion.Version,
+		ObjectType: &longhorn.StorageQuota{},
+		OperationTypes: []admissionregv1.OperationType{
+			admissionregv1.Create,
+			admissionregv1.Update,
+		},
+	}
+}
+
+func (v *storageQuotaValidator) Create(request *admission.Request, newObj runtime.Object) error {
+	quota, ok := newObj.(*longhorn.StorageQuota)
+	if !ok {
+		return werror.NewInvalidError(fmt.Sprintf("%v is not a *longhorn.StorageQuota", newObj), "")
+	}
+
+	if err := types.ValidateStorageQuotaSpec(&quota.Spec); err != nil {
+		return werror.NewInvalidError(err.Error(), "spec")
+	}
+
+	existing, err := v.ds.GetStorageQuotaForNamespaceRO(quota.Spec.Namespace)
+	if err != nil {
+		return werror.NewInternalError(fmt.Sprintf("failed to get storage quota of namespace %v: %v", quota.Spec.Namespace, err))
+	}
+	if existing != nil && existing.Name != quota.Name {
+		return werror.NewInvalidError(fmt.Sprintf("namespace %v already has storage quota %v", quota.Spec.Namespace, existing.Name), "spec.namespace")
+	}
+	return nil
+}
+
+func (v *storageQuotaValidator) Update(request *admission.Request, oldObj runtime.Object, newObj runtime.Object) error {
+	return v.Create(request, newObj)
+}
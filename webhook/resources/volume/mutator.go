@@ -2,6 +2,7 @@ package volume
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 
 	"github.com/cockroachdb/errors"
@@ -97,8 +98,10 @@ func (v *volumeMutator) Create(request *admission.Request, newObj runtime.Object
 		patchOps = append(patchOps, fmt.Sprintf(`{"op": "replace", "path": "/metadata/name", "value": "%s"}`, name))
 	}
 
-	if volume.Spec.NumberOfReplicas == 0 {
-		numberOfReplicas, err := v.getDefaultReplicaCount(volume.Spec.DataEngine)
+	numberOfReplicas := volume.Spec.NumberOfReplicas
+	if numberOfReplicas == 0 {
+		var err error
+		numberOfReplicas, err = v.getDefaultReplicaCount(volume.Spec.DataEngine)
 		if err != nil {
 			err = errors.Wrap(err, "failed to get valid number for setting default replica count")
 			return nil, werror.NewInvalidError(err.Error(), "")
@@ -107,13 +110,15 @@ func (v *volumeMutator) Create(request *admission.Request, newObj runtime.Object
 		patchOps = append(patchOps, fmt.Sprintf(`{"op": "replace", "path": "/spec/numberOfReplicas", "value": %v}`, numberOfReplicas))
 	}
 
-	if string(volume.Spec.DataLocality) == "" {
+	dataLocality := volume.Spec.DataLocality
+	if string(dataLocality) == "" {
 		defaultDataLocality, err := v.ds.GetSettingValueExisted(types.SettingNameDefaultDataLocality)
 		if err != nil {
 			err = errors.Wrapf(err, "failed to get valid mode for setting default data locality for volume: %v", name)
 			return nil, werror.NewInvalidError(err.Error(), "")
 		}
 		patchOps = append(patchOps, fmt.Sprintf(`{"op": "replace", "path": "/spec/dataLocality", "value": "%s"}`, defaultDataLocality))
+		dataLocality = longhorn.DataLocality(defaultDataLocality)
 	}
 
 	if string(volume.Spec.AccessMode) == "" {
@@ -254,8 +259,13 @@ func (v *volumeMutator) Create(request *admission.Request, newObj runtime.Object
 		}
 	}
 
+	patchOpsForPolicies, err := v.getVolumePolicyPatchOps(nil, volume, numberOfReplicas, dataLocality)
+	if err != nil {
+		return nil, err
+	}
+	patchOps = append(patchOps, patchOpsForPolicies...)
+
 	var patchOpsInCommon admission.PatchOps
-	var err error
 	if patchOpsInCommon, err = v.mutate(newObj, moreLabels); err != nil {
 		return nil, err
 	}
@@ -304,13 +314,18 @@ func (v *volumeMutator) Update(request *admission.Request, oldObj runtime.Object
 		patchOps = append(patchOps, fmt.Sprintf(`{"op": "replace", "path": "/spec/snapshotMaxSize", "value": "%s"}`, strconv.FormatInt(volume.Spec.Size*2, 10)))
 	}
 
+	patchOpsForPolicies, err := v.getVolumePolicyPatchOps(oldVolume, volume, volume.Spec.NumberOfReplicas, volume.Spec.DataLocality)
+	if err != nil {
+		return nil, err
+	}
+	patchOps = append(patchOps, patchOpsForPolicies...)
+
 	moreLabels := map[string]string{}
 	if oldVolume.Spec.BackupTargetName != volume.Spec.BackupTargetName {
 		moreLabels[types.LonghornLabelBackupTarget] = volume.Spec.BackupTargetName
 	}
 
 	var patchOpsInCommon admission.PatchOps
-	var err error
 	if patchOpsInCommon, err = v.mutate(newObj, moreLabels); err != nil {
 		return nil, err
 	}
@@ -425,6 +440,50 @@ func (v *volumeMutator) mutate(newObj runtime.Object, moreLabels map[string]stri
 	return patchOps, nil
 }
 
+// getVolumePolicyPatchOps returns the patch ops raising the number of replicas and setting the data locality of the
+// volume for the matching volume policies in the mutate mode. The other violations are rejected by the validator. For
+// an update, only the fields changed by the update are mutated, so that the volumes existing before the policies are
+// not changed behind the users.
+func (v *volumeMutator) getVolumePolicyPatchOps(oldVolume, volume *longhorn.Volume, numberOfReplicas int, dataLocality longhorn.DataLocality) (admission.PatchOps, error) {
+	policies, err := v.ds.ListVolumePoliciesRO()
+	if err != nil {
+		return nil, werror.NewInternalError(fmt.Sprintf("failed to list volume policies: %v", err))
+	}
+	// The policies forcing conflicting data localities of the same volumes are rejected at admission. The ones admitted
+	// before are applied in the order of the names, so the first policy forcing the data locality wins.
+	sort.Slice(policies, func(i, j int) bool { return policies[i].Name < policies[j].Name })
+
+	minNumberOfReplicas := 0
+	requiredDataLocality := longhorn.DataLocality("")
+	for _, policy := range policies {
+		if policy.Spec.Enforcement != longhorn.VolumePolicyEnforcementMutate {
+			continue
+		}
+		matched, err := types.IsVolumePolicyMatched(policy, volume)
+		if err != nil {
+			return nil, werror.NewInternalError(err.Error())
+		}
+		if !matched {
+			continue
+		}
+		minNumberOfReplicas = max(minNumberOfReplicas, policy.Spec.MinNumberOfReplicas)
+		if requiredDataLocality == "" {
+			requiredDataLocality = policy.Spec.DataLocality
+		}
+	}
+
+	var patchOps admission.PatchOps
+	if numberOfReplicas < minNumberOfReplicas && (oldVolume == nil || oldVolume.Spec.NumberOfReplicas != numberOfReplicas) {
+		logrus.Infof("Raising the number of replicas of volume %v from %v to %v for the volume policies", volume.Name, numberOfReplicas, minNumberOfReplicas)
+		patchOps = append(patchOps, fmt.Sprintf(`{"op": "replace", "path": "/spec/numberOfReplicas", "value": %v}`, minNumberOfReplicas))
+	}
+	if requiredDataLocality != "" && dataLocality != requiredDataLocality && (oldVolume == nil || oldVolume.Spec.DataLocality != dataLocality) {
+		logrus.Infof("Setting the data locality of volume %v from %v to %v for the volume policies", volume.Name, dataLocality, requiredDataLocality)
+		patchOps = append(patchOps, fmt.Sprintf(`{"op": "replace", "path": "/spec/dataLocality", "value": "%s"}`, requiredDataLocality))
+	}
+	return patchOps, nil
+}
+
 func (v *volumeMutator) getDefaultReplicaCount(dataEngine longhorn.DataEngineType) (int, error) {
 	c, err := v.ds.GetSettingAsIntByDataEngine(types.SettingNameDefaultReplicaCount, dataEngine)
 	if err != nil {
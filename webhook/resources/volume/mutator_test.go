@@ -0,0 +1,143 @@
+package volume
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/types"
+	"github.com/longhorn/longhorn-manager/util"
+	"github.com/longhorn/longhorn-manager/webhook/admission"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+	lhfake "github.com/longhorn/longhorn-manager/k8s/pkg/client/clientset/versioned/fake"
+)
+
+func TestGetVolumePolicyPatchOps(t *testing.T) {
+	assert := require.New(t)
+
+	raiseToThree := longhorn.VolumePolicySpec{
+		Enforcement:         longhorn.VolumePolicyEnforcementMutate,
+		MinNumberOfReplicas: 3,
+	}
+	raiseToTwo := longhorn.VolumePolicySpec{
+		Enforcement:         longhorn.VolumePolicyEnforcementMutate,
+		MinNumberOfReplicas: 2,
+	}
+	bestEffort := longhorn.VolumePolicySpec{
+		Enforcement:  longhorn.VolumePolicyEnforcementMutate,
+		DataLocality: longhorn.DataLocalityBestEffort,
+	}
+
+	type testCase struct {
+		policies      map[string]longhorn.VolumePolicySpec
+		oldVolumeSpec *longhorn.VolumeSpec
+		volumeSpec    longhorn.VolumeSpec
+
+		expectPatchOps admission.PatchOps
+	}
+	testCases := map[string]testCase{
+		"no policies": {
+			volumeSpec: longhorn.VolumeSpec{NumberOfReplicas: 1, DataLocality: longhorn.DataLocalityDisabled},
+		},
+		"deny policy": {
+			policies:   map[string]longhorn.VolumePolicySpec{"min-replicas": {MinNumberOfReplicas: 3}},
+			volumeSpec: longhorn.VolumeSpec{NumberOfReplicas: 1, DataLocality: longhorn.DataLocalityDisabled},
+		},
+		"unmatched policy": {
+			policies: map[string]longhorn.VolumePolicySpec{
+				"min-replicas": {
+					Namespaces:          []string{"prod"},
+					Enforcement:         longhorn.VolumePolicyEnforcementMutate,
+					MinNumberOfReplicas: 3,
+				},
+			},
+			volumeSpec: longhorn.VolumeSpec{NumberOfReplicas: 1, DataLocality: longhorn.DataLocalityDisabled},
+		},
+		"compliant volume": {
+			policies:   map[string]longhorn.VolumePolicySpec{"min-three-replicas": raiseToThree},
+			volumeSpec: longhorn.VolumeSpec{NumberOfReplicas: 3, DataLocality: longhorn.DataLocalityDisabled},
+		},
+		"raised number of replicas": {
+			policies:   map[string]longhorn.VolumePolicySpec{"min-three-replicas": raiseToThree},
+			volumeSpec: longhorn.VolumeSpec{NumberOfReplicas: 1, DataLocality: longhorn.DataLocalityDisabled},
+			expectPatchOps: admission.PatchOps{
+				`{"op": "replace", "path": "/spec/numberOfReplicas", "value": 3}`,
+			},
+		},
+		"number of replicas raised to the highest minimum": {
+			policies: map[string]longhorn.VolumePolicySpec{
+				"min-two-replicas":   raiseToTwo,
+				"min-three-replicas": raiseToThree,
+			},
+			volumeSpec: longhorn.VolumeSpec{NumberOfReplicas: 1, DataLocality: longhorn.DataLocalityDisabled},
+			expectPatchOps: admission.PatchOps{
+				`{"op": "replace", "path": "/spec/numberOfReplicas", "value": 3}`,
+			},
+		},
+		"overridden data locality": {
+			policies:   map[string]longhorn.VolumePolicySpec{"best-effort": bestEffort},
+			volumeSpec: longhorn.VolumeSpec{NumberOfReplicas: 1, DataLocality: longhorn.DataLocalityDisabled},
+			expectPatchOps: admission.PatchOps{
+				`{"op": "replace", "path": "/spec/dataLocality", "value": "best-effort"}`,
+			},
+		},
+		"update keeping the existing fields": {
+			policies: map[string]longhorn.VolumePolicySpec{
+				"min-three-replicas": raiseToThree,
+				"best-effort":        bestEffort,
+			},
+			oldVolumeSpec: &longhorn.VolumeSpec{NumberOfReplicas: 1, DataLocality: longhorn.DataLocalityDisabled},
+			volumeSpec:    longhorn.VolumeSpec{NumberOfReplicas: 1, DataLocality: longhorn.DataLocalityDisabled},
+		},
+		"update changing the fields": {
+			policies: map[string]longhorn.VolumePolicySpec{
+				"min-three-replicas": raiseToThree,
+				"best-effort":        bestEffort,
+			},
+			oldVolumeSpec: &longhorn.VolumeSpec{NumberOfReplicas: 1, DataLocality: longhorn.DataLocalityBestEffort},
+			volumeSpec:    longhorn.VolumeSpec{NumberOfReplicas: 2, DataLocality: longhorn.DataLocalityDisabled},
+			expectPatchOps: admission.PatchOps{
+				`{"op": "replace", "path": "/spec/numberOfReplicas", "value": 3}`,
+				`{"op": "replace", "path": "/spec/dataLocality", "value": "best-effort"}`,
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		kubeClient := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}})
+		lhClient := lhfake.NewSimpleClientset()
+		informerFactories := util.NewInformerFactories(testNamespace, kubeClient, lhClient, 0)
+		policyIndexer := informerFactories.LhInformerFactory.Longhorn().V1beta2().VolumePolicies().Informer().GetIndexer()
+		for policyName, spec := range tc.policies {
+			policy := &longhorn.VolumePolicy{ObjectMeta: metav1.ObjectMeta{Name: policyName, Namespace: testNamespace}, Spec: spec}
+			assert.NoError(policyIndexer.Add(policy), name)
+		}
+		mutator := &volumeMutator{
+			ds: datastore.NewDataStore(testNamespace, lhClient, kubeClient, apiextensionsfake.NewSimpleClientset(), informerFactories),
+		}
+
+		labels := map[string]string{types.GetLonghornLabelKey(types.LonghornLabelPVCNamespace): "default"}
+		volume := &longhorn.Volume{
+			ObjectMeta: metav1.ObjectMeta{Name: "vol", Namespace: testNamespace, Labels: labels},
+			Spec:       tc.volumeSpec,
+		}
+		var oldVolume *longhorn.Volume
+		if tc.oldVolumeSpec != nil {
+			oldVolume = &longhorn.Volume{
+				ObjectMeta: metav1.ObjectMeta{Name: "vol", Namespace: testNamespace, Labels: labels},
+				Spec:       *tc.oldVolumeSpec,
+			}
+		}
+
+		patchOps, err := mutator.getVolumePolicyPatchOps(oldVolume, volume, volume.Spec.NumberOfReplicas, volume.Spec.DataLocality)
+		assert.NoError(err, name)
+		assert.Equal(tc.expectPatchOps, patchOps, name)
+	}
+}
@@ -2,6 +2,9 @@ package volume
 
 import (
 	"fmt"
+	"maps"
+	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -47,6 +50,7 @@ func (v *volumeValidator) Resource() admission.Resource {
 		OperationTypes: []admissionregv1.OperationType{
 			admissionregv1.Create,
 			admissionregv1.Update,
+			admissionregv1.Delete,
 		},
 	}
 }
@@ -113,6 +117,18 @@ func (v *volumeValidator) Create(request *admission.Request, newObj runtime.Obje
 		return werror.NewInvalidError(err.Error(), "spec.replicaRebuildingBandwidthLimit")
 	}
 
+	if err := types.ValidateVolumeTieringPolicy(volume.Spec.TieringPolicy); err != nil {
+		return werror.NewInvalidError(err.Error(), "spec.tieringPolicy")
+	}
+
+	if err := types.ValidateNFSExportOptions(volume.Spec.NFSExportOptions); err != nil {
+		return werror.NewInvalidError(err.Error(), "spec.nfsExportOptions")
+	}
+
+	if err := v.validateTopologySpreadConstraints(volume.Spec.TopologySpreadConstraints); err != nil {
+		return err
+	}
+
 	if volume.Spec.BackingImage != "" {
 		backingImage, err := v.ds.GetBackingImage(volume.Spec.BackingImage)
 		if err != nil {
@@ -200,6 +216,14 @@ func (v *volumeValidator) Create(request *admission.Request, newObj runtime.Obje
 		return werror.NewInvalidError(err.Error(), "spec.backupTargetName")
 	}
 
+	if err := v.validateVolumePolicies(nil, volume); err != nil {
+		return err
+	}
+
+	if err := v.validateStorageQuota(nil, volume); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -217,6 +241,12 @@ func (v *volumeValidator) Update(request *admission.Request, oldObj runtime.Obje
 		return werror.NewInvalidError(err.Error(), "spec.size")
 	}
 
+	// The storage quotas and the volume policies apply by the PVC namespace
+	pvcNamespaceLabel := types.GetLonghornLabelKey(types.LonghornLabelPVCNamespace)
+	if oldNamespace := oldVolume.Labels[pvcNamespaceLabel]; oldNamespace != "" && newVolume.Labels[pvcNamespaceLabel] != oldNamespace {
+		return werror.NewInvalidError(fmt.Sprintf("label %v cannot be changed once set", pvcNamespaceLabel), "metadata.labels")
+	}
+
 	if err := validateDataLocalityUpdate(oldVolume, newVolume); err != nil {
 		return werror.NewInvalidError(err.Error(), "spec.dataLocality")
 	}
@@ -304,6 +334,20 @@ func (v *volumeValidator) Update(request *admission.Request, oldObj runtime.Obje
 		return werror.NewInvalidError(err.Error(), "spec.replicaRebuildingBandwidthLimit")
 	}
 
+	if err := types.ValidateVolumeTieringPolicy(newVolume.Spec.TieringPolicy); err != nil {
+		return werror.NewInvalidError(err.Error(), "spec.tieringPolicy")
+	}
+
+	if err := types.ValidateNFSExportOptions(newVolume.Spec.NFSExportOptions); err != nil {
+		return werror.NewInvalidError(err.Error(), "spec.nfsExportOptions")
+	}
+
+	if !reflect.DeepEqual(oldVolume.Spec.TopologySpreadConstraints, newVolume.Spec.TopologySpreadConstraints) {
+		if err := v.validateTopologySpreadConstraints(newVolume.Spec.TopologySpreadConstraints); err != nil {
+			return err
+		}
+	}
+
 	if oldVolume.Spec.DataEngine != "" {
 		if oldVolume.Spec.DataEngine != newVolume.Spec.DataEngine {
 			err := fmt.Errorf("changing data engine for volume %v is not supported", oldVolume.Name)
@@ -351,6 +395,38 @@ func (v *volumeValidator) Update(request *admission.Request, oldObj runtime.Obje
 	if err := validateRecurringJobLabels(newVolume); err != nil {
 		return err
 	}
+	if err := v.validateVolumePolicies(oldVolume, newVolume); err != nil {
+		return err
+	}
+	if err := v.validateStorageQuota(oldVolume, newVolume); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (v *volumeValidator) Delete(request *admission.Request, oldObj runtime.Object) error {
+	volume, ok := oldObj.(*longhorn.Volume)
+	if !ok {
+		return werror.NewInvalidError(fmt.Sprintf("%v is not a *longhorn.Volume", oldObj), "")
+	}
+
+	// The uninstallation deletes the volumes before the backup targets, and it requires the deleting confirmation flag
+	confirmed, err := v.ds.GetSettingAsBool(types.SettingNameDeletingConfirmationFlag)
+	if err != nil {
+		return werror.NewInternalError(fmt.Sprintf("failed to get setting %v: %v", types.SettingNameDeletingConfirmationFlag, err))
+	}
+	if confirmed {
+		return nil
+	}
+
+	backupTarget, err := v.ds.GetBackupTargetStoredInVolume(volume.Name)
+	if err != nil {
+		return werror.NewInternalError(fmt.Sprintf("failed to get the backup target storing its backups in volume %v: %v", volume.Name, err))
+	}
+	if backupTarget != nil {
+		return werror.NewInvalidError(fmt.Sprintf("volume %v stores the backups of backup target %v and cannot be deleted until the backup target is changed or deleted",
+			volume.Name, backupTarget.Name), "")
+	}
 	return nil
 }
 
@@ -518,6 +594,81 @@ func validateSnapshotMaxSize(size, snapshotMaxSize int64) error {
 	return nil
 }
 
+func (v *volumeValidator) validateTopologySpreadConstraints(constraints []longhorn.TopologySpreadConstraint) error {
+	if len(constraints) == 0 {
+		return nil
+	}
+	topologyLevels, err := v.ds.GetSettingTopologyLevels()
+	if err != nil {
+		return werror.NewInternalError(fmt.Sprintf("can't get setting %v, err %v", types.SettingNameTopologyLevels, err))
+	}
+	if err := types.ValidateTopologySpreadConstraints(constraints, topologyLevels); err != nil {
+		return werror.NewInvalidError(err.Error(), "spec.topologySpreadConstraints")
+	}
+	return nil
+}
+
+// validateVolumePolicies rejects the volume violating the matching volume policies. For an update, only the
+// constraints newly violated by the update are rejected, so that the volumes existing before the policies can still be
+// updated and operated. The existing violations are reported in the status of the policies instead.
+func (v *volumeValidator) validateVolumePolicies(oldVolume, newVolume *longhorn.Volume) error {
+	policies, err := v.ds.ListVolumePoliciesRO()
+	if err != nil {
+		return werror.NewInternalError(fmt.Sprintf("failed to list volume policies: %v", err))
+	}
+	for _, policy := range policies {
+		violations, err := getVolumePolicyViolations(policy, newVolume)
+		if err != nil {
+			return werror.NewInternalError(err.Error())
+		}
+		if oldVolume != nil && len(violations) > 0 {
+			oldViolations, err := getVolumePolicyViolations(policy, oldVolume)
+			if err != nil {
+				return werror.NewInternalError(err.Error())
+			}
+			for constraint := range oldViolations {
+				delete(violations, constraint)
+			}
+		}
+		if len(violations) == 0 {
+			continue
+		}
+		messages := slices.Sorted(maps.Values(violations))
+		return werror.NewInvalidError(fmt.Sprintf("volume %v violates volume policy %v: %v", newVolume.Name, policy.Name, strings.Join(messages, "; ")), "spec")
+	}
+	return nil
+}
+
+func getVolumePolicyViolations(policy *longhorn.VolumePolicy, volume *longhorn.Volume) (map[string]string, error) {
+	matched, err := types.IsVolumePolicyMatched(policy, volume)
+	if err != nil || !matched {
+		return nil, err
+	}
+	return types.GetVolumePolicyViolations(&policy.Spec, volume), nil
+}
+
+// validateStorageQuota rejects the volume exceeding the provisioned size limit of the storage quota of its PVC
+// namespace, and reserves the provisioned size in the quota otherwise. For an update, only the increase of the
+// provisioned size is checked.
+func (v *volumeValidator) validateStorageQuota(oldVolume, newVolume *longhorn.Volume) error {
+	namespace := types.GetVolumePVCNamespace(newVolume)
+	provisionedSize := newVolume.Spec.Size * int64(newVolume.Spec.NumberOfReplicas)
+	if oldVolume != nil && types.GetVolumePVCNamespace(oldVolume) == namespace {
+		provisionedSize -= oldVolume.Spec.Size * int64(oldVolume.Spec.NumberOfReplicas)
+	}
+	if provisionedSize <= 0 {
+		return nil
+	}
+
+	if err := v.ds.ReserveStorageQuota(namespace, &longhorn.StorageQuotaUsage{ProvisionedSize: provisionedSize}); err != nil {
+		if datastore.ErrorIsStorageQuotaExceeded(err) {
+			return werror.NewStorageQuotaExceededError(fmt.Sprintf("volume %v %v", newVolume.Name, err))
+		}
+		return werror.NewInternalError(fmt.Sprintf("failed to reserve the storage quota of namespace %v: %v", namespace, err))
+	}
+	return nil
+}
+
 func (v *volumeValidator) validateBackupTarget(oldBackupTarget, newBackupTarget string) error {
 	if newBackupTarget == "" {
 		return fmt.Errorf("backup target name cannot be empty when creating a volume or updating from an existing backup target")
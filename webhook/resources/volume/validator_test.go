@@ -0,0 +1,233 @@
+package volume
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/types"
+	"github.com/longhorn/longhorn-manager/util"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+	lhfake "github.com/longhorn/longhorn-manager/k8s/pkg/client/clientset/versioned/fake"
+	werror "github.com/longhorn/longhorn-manager/webhook/error"
+)
+
+const testNamespace = "longhorn-system"
+
+func TestValidateVolumePolicies(t *testing.T) {
+	assert := require.New(t)
+
+	type testCase struct {
+		policies      map[string]longhorn.VolumePolicySpec
+		oldVolumeSpec *longhorn.VolumeSpec
+		volumeSpec    longhorn.VolumeSpec
+
+		expectError bool
+	}
+	testCases := map[string]testCase{
+		"no policies": {
+			volumeSpec: longhorn.VolumeSpec{NumberOfReplicas: 1},
+		},
+		"compliant volume": {
+			policies:   map[string]longhorn.VolumePolicySpec{"min-replicas": {MinNumberOfReplicas: 3}},
+			volumeSpec: longhorn.VolumeSpec{NumberOfReplicas: 3},
+		},
+		"violating volume": {
+			policies:    map[string]longhorn.VolumePolicySpec{"min-replicas": {MinNumberOfReplicas: 3}},
+			volumeSpec:  longhorn.VolumeSpec{NumberOfReplicas: 2},
+			expectError: true,
+		},
+		"violating volume in an unmatched namespace": {
+			policies:   map[string]longhorn.VolumePolicySpec{"min-replicas": {Namespaces: []string{"prod"}, MinNumberOfReplicas: 3}},
+			volumeSpec: longhorn.VolumeSpec{NumberOfReplicas: 2},
+		},
+		"violating volume unmatched by the selector": {
+			policies: map[string]longhorn.VolumePolicySpec{
+				"min-replicas": {
+					VolumeSelector:      &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "silver"}},
+					MinNumberOfReplicas: 3,
+				},
+			},
+			volumeSpec: longhorn.VolumeSpec{NumberOfReplicas: 2},
+		},
+		"volume compliant with one policy only": {
+			policies: map[string]longhorn.VolumePolicySpec{
+				"min-replicas": {MinNumberOfReplicas: 3},
+				"v2-only":      {AllowedDataEngines: []longhorn.DataEngineType{longhorn.DataEngineTypeV2}},
+			},
+			volumeSpec:  longhorn.VolumeSpec{NumberOfReplicas: 3, DataEngine: longhorn.DataEngineTypeV1},
+			expectError: true,
+		},
+		"update keeping an existing violation": {
+			policies:      map[string]longhorn.VolumePolicySpec{"min-replicas": {MinNumberOfReplicas: 3}},
+			oldVolumeSpec: &longhorn.VolumeSpec{NumberOfReplicas: 2, DataLocality: longhorn.DataLocalityDisabled},
+			volumeSpec:    longhorn.VolumeSpec{NumberOfReplicas: 2, DataLocality: longhorn.DataLocalityBestEffort},
+		},
+		"update adding a violation": {
+			policies: map[string]longhorn.VolumePolicySpec{
+				"min-replicas":      {MinNumberOfReplicas: 3},
+				"disabled-locality": {DataLocality: longhorn.DataLocalityDisabled},
+			},
+			oldVolumeSpec: &longhorn.VolumeSpec{NumberOfReplicas: 2, DataLocality: longhorn.DataLocalityDisabled},
+			volumeSpec:    longhorn.VolumeSpec{NumberOfReplicas: 2, DataLocality: longhorn.DataLocalityBestEffort},
+			expectError:   true,
+		},
+	}
+
+	for name, tc := range testCases {
+		kubeClient := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}})
+		lhClient := lhfake.NewSimpleClientset()
+		informerFactories := util.NewInformerFactories(testNamespace, kubeClient, lhClient, 0)
+		policyIndexer := informerFactories.LhInformerFactory.Longhorn().V1beta2().VolumePolicies().Informer().GetIndexer()
+		for policyName, spec := range tc.policies {
+			policy := &longhorn.VolumePolicy{ObjectMeta: metav1.ObjectMeta{Name: policyName, Namespace: testNamespace}, Spec: spec}
+			assert.NoError(policyIndexer.Add(policy), name)
+		}
+		validator := &volumeValidator{
+			ds: datastore.NewDataStore(testNamespace, lhClient, kubeClient, apiextensionsfake.NewSimpleClientset(), informerFactories),
+		}
+
+		labels := map[string]string{
+			types.GetLonghornLabelKey(types.LonghornLabelPVCNamespace): "default",
+			"tier": "gold",
+		}
+		newVolume := &longhorn.Volume{
+			ObjectMeta: metav1.ObjectMeta{Name: "vol", Namespace: testNamespace, Labels: labels},
+			Spec:       tc.volumeSpec,
+		}
+		var oldVolume *longhorn.Volume
+		if tc.oldVolumeSpec != nil {
+			oldVolume = &longhorn.Volume{
+				ObjectMeta: metav1.ObjectMeta{Name: "vol", Namespace: testNamespace, Labels: labels},
+				Spec:       *tc.oldVolumeSpec,
+			}
+		}
+
+		err := validator.validateVolumePolicies(oldVolume, newVolume)
+		if tc.expectError {
+			assert.Error(err, name)
+		} else {
+			assert.NoError(err, name)
+		}
+	}
+}
+
+func TestValidateStorageQuotaReservesUsage(t *testing.T) {
+	assert := require.New(t)
+
+	kubeClient := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}})
+	lhClient := lhfake.NewSimpleClientset()
+	informerFactories := util.NewInformerFactories(testNamespace, kubeClient, lhClient, 0)
+
+	quota := &longhorn.StorageQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-a", Namespace: testNamespace},
+		Spec:       longhorn.StorageQuotaSpec{Namespace: "team-a", MaxProvisionedSize: 300},
+	}
+	quota, err := lhClient.LonghornV1beta2().StorageQuotas(testNamespace).Create(context.TODO(), quota, metav1.CreateOptions{})
+	assert.NoError(err)
+	assert.NoError(informerFactories.LhInformerFactory.Longhorn().V1beta2().StorageQuotas().Informer().GetIndexer().Add(quota))
+
+	validator := &volumeValidator{
+		ds: datastore.NewDataStore(testNamespace, lhClient, kubeClient, apiextensionsfake.NewSimpleClientset(), informerFactories),
+	}
+
+	// The admitted volumes aren't in the informer caches, so only the reservations in the quota status count them
+	type testCase struct {
+		size int64
+
+		expectError bool
+	}
+	testCases := []testCase{
+		{size: 200},
+		{size: 100},
+		{size: 1, expectError: true},
+	}
+	for i, tc := range testCases {
+		volume := &longhorn.Volume{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("vol-%d", i),
+				Namespace: testNamespace,
+				Labels:    map[string]string{types.GetLonghornLabelKey(types.LonghornLabelPVCNamespace): "team-a"},
+			},
+			Spec: longhorn.VolumeSpec{Size: tc.size, NumberOfReplicas: 1},
+		}
+		err := validator.validateStorageQuota(nil, volume)
+		if tc.expectError {
+			var admitErr werror.AdmitError
+			assert.ErrorAs(err, &admitErr, volume.Name)
+			assert.Equal(types.StorageQuotaExceededCode, string(admitErr.AsResult().Details.Causes[0].Type), volume.Name)
+			continue
+		}
+		assert.NoError(err, volume.Name)
+	}
+
+	quota, err = lhClient.LonghornV1beta2().StorageQuotas(testNamespace).Get(context.TODO(), quota.Name, metav1.GetOptions{})
+	assert.NoError(err)
+	assert.Equal(int64(300), quota.Status.Usage.ProvisionedSize)
+}
+
+func TestDeleteVolumeStoringBackups(t *testing.T) {
+	assert := require.New(t)
+
+	type testCase struct {
+		backupTargetURL  string
+		confirmedDeletes bool
+
+		expectError bool
+	}
+	testCases := map[string]testCase{
+		"volume storing the backups": {
+			backupTargetURL: "file:///backupstore?volume=vol",
+			expectError:     true,
+		},
+		"volume storing the backups during the uninstallation": {
+			backupTargetURL:  "file:///backupstore?volume=vol",
+			confirmedDeletes: true,
+		},
+		"another volume storing the backups": {
+			backupTargetURL: "file:///backupstore?volume=backups",
+		},
+		"node storing the backups": {
+			backupTargetURL: "file://node-1/mnt/backupstore",
+		},
+		"remote backup store": {
+			backupTargetURL: "s3://backupbucket@us-east-1/backupstore",
+		},
+	}
+
+	for name, tc := range testCases {
+		kubeClient := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}})
+		lhClient := lhfake.NewSimpleClientset()
+		informerFactories := util.NewInformerFactories(testNamespace, kubeClient, lhClient, 0)
+		backupTarget := &longhorn.BackupTarget{
+			ObjectMeta: metav1.ObjectMeta{Name: types.DefaultBackupTargetName, Namespace: testNamespace},
+			Spec:       longhorn.BackupTargetSpec{BackupTargetURL: tc.backupTargetURL},
+		}
+		assert.NoError(informerFactories.LhInformerFactory.Longhorn().V1beta2().BackupTargets().Informer().GetIndexer().Add(backupTarget), name)
+		setting := &longhorn.Setting{
+			ObjectMeta: metav1.ObjectMeta{Name: string(types.SettingNameDeletingConfirmationFlag), Namespace: testNamespace},
+			Value:      fmt.Sprint(tc.confirmedDeletes),
+		}
+		assert.NoError(informerFactories.LhInformerFactory.Longhorn().V1beta2().Settings().Informer().GetIndexer().Add(setting), name)
+		validator := &volumeValidator{
+			ds: datastore.NewDataStore(testNamespace, lhClient, kubeClient, apiextensionsfake.NewSimpleClientset(), informerFactories),
+		}
+
+		volume := &longhorn.Volume{ObjectMeta: metav1.ObjectMeta{Name: "vol", Namespace: testNamespace}}
+		err := validator.Delete(nil, volume)
+		if tc.expectError {
+			assert.Error(err, name)
+		} else {
+			assert.NoError(err, name)
+		}
+	}
+}
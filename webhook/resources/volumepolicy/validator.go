@@ -0,0 +1,91 @@
+package volumepolicy
+
+import (
+	"fmt"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	admissionregv1 "k8s.io/api/admissionregistration/v1"
+
+	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/types"
+	"github.com/longhorn/longhorn-manager/webhook/admission"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+	werror "github.com/longhorn/longhorn-manager/webhook/error"
+)
+
+type volumePolicyValidator struct {
+	admission.DefaultValidator
+	ds *datastore.DataStore
+}
+
+func NewValidator(ds *datastore.DataStore) admission.Validator {
+	return &volumePolicyValidator{ds: ds}
+}
+
+func (v *volumePolicyValidator) Resource() admission.Resource {
+	return admission.Resource{
+		Name:       "volumepolicies",
+		Scope:      admissionregv1.NamespacedScope,
+		APIGroup:   longhorn.SchemeGroupVersion.Group,
+		APIVersion: longhorn.SchemeGroupVersion.Version,
+		ObjectType: &longhorn.VolumePolicy{},
+		OperationTypes: []admissionregv1.OperationType{
+			admissionregv1.Create,
+			admissionregv1.Update,
+		},
+	}
+}
+
+func (v *volumePolicyValidator) Create(request *admission.Request, newObj runtime.Object) error {
+	policy, ok := newObj.(*longhorn.VolumePolicy)
+	if !ok {
+		return werror.NewInvalidError(fmt.Sprintf("%v is not a *longhorn.VolumePolicy", newObj), "")
+	}
+
+	if err := types.ValidateVolumePolicySpec(&policy.Spec); err != nil {
+		return werror.NewInvalidError(err.Error(), "spec")
+	}
+	return v.validateConflictingPolicies(policy)
+}
+
+func (v *volumePolicyValidator) Update(request *admission.Request, oldObj runtime.Object, newObj runtime.Object) error {
+	oldPolicy, ok := oldObj.(*longhorn.VolumePolicy)
+	if !ok {
+		return werror.NewInvalidError(fmt.Sprintf("%v is not a *longhorn.VolumePolicy", oldObj), "")
+	}
+	newPolicy, ok := newObj.(*longhorn.VolumePolicy)
+	if !ok {
+		return werror.NewInvalidError(fmt.Sprintf("%v is not a *longhorn.VolumePolicy", newObj), "")
+	}
+
+	if err := types.ValidateVolumePolicySpec(&newPolicy.Spec); err != nil {
+		return werror.NewInvalidError(err.Error(), "spec")
+	}
+	// The status updates and the updates keeping the constraints don't add any conflict
+	if reflect.DeepEqual(oldPolicy.Spec, newPolicy.Spec) {
+		return nil
+	}
+	return v.validateConflictingPolicies(newPolicy)
+}
+
+// validateConflictingPolicies rejects the volume policy if it may apply to the same volumes as another policy and no
+// volume satisfies both, otherwise the volume mutator and validator would reject every volume they both apply to.
+func (v *volumePolicyValidator) validateConflictingPolicies(policy *longhorn.VolumePolicy) error {
+	policies, err := v.ds.ListVolumePoliciesRO()
+	if err != nil {
+		return werror.NewInternalError(fmt.Sprintf("failed to list volume policies: %v", err))
+	}
+	for _, other := range policies {
+		// The existing object of the updated policy is replaced by the update
+		if other.Name == policy.Name || !types.IsVolumePolicyOverlapping(&policy.Spec, &other.Spec) {
+			continue
+		}
+		if err := types.ValidateVolumePolicyConflict(&policy.Spec, &other.Spec); err != nil {
+			return werror.NewInvalidError(fmt.Sprintf("volume policy %v conflicts with volume policy %v applying to the same volumes: %v", policy.Name, other.Name, err), "spec")
+		}
+	}
+	return nil
+}
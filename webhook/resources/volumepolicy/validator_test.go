@@ -0,0 +1,115 @@
+package volumepolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/longhorn/longhorn-manager/datastore"
+	"github.com/longhorn/longhorn-manager/util"
+
+	longhorn "github.com/longhorn/longhorn-manager/k8s/pkg/apis/longhorn/v1beta2"
+	lhfake "github.com/longhorn/longhorn-manager/k8s/pkg/client/clientset/versioned/fake"
+)
+
+const testNamespace = "longhorn-system"
+
+func TestValidateConflictingPolicies(t *testing.T) {
+	assert := require.New(t)
+
+	bestEffort := longhorn.VolumePolicySpec{
+		Enforcement:  longhorn.VolumePolicyEnforcementMutate,
+		DataLocality: longhorn.DataLocalityBestEffort,
+	}
+
+	type testCase struct {
+		existingPolicies map[string]longhorn.VolumePolicySpec
+		name             string
+		oldSpec          *longhorn.VolumePolicySpec
+		spec             longhorn.VolumePolicySpec
+
+		expectError bool
+	}
+	testCases := map[string]testCase{
+		"no other policies": {
+			name: "strict-local",
+			spec: longhorn.VolumePolicySpec{Enforcement: longhorn.VolumePolicyEnforcementMutate, DataLocality: longhorn.DataLocalityStrictLocal},
+		},
+		"conflicting data locality": {
+			existingPolicies: map[string]longhorn.VolumePolicySpec{"best-effort": bestEffort},
+			name:             "strict-local",
+			spec:             longhorn.VolumePolicySpec{Enforcement: longhorn.VolumePolicyEnforcementMutate, DataLocality: longhorn.DataLocalityStrictLocal},
+			expectError:      true,
+		},
+		"conflicting data locality in another namespace": {
+			existingPolicies: map[string]longhorn.VolumePolicySpec{
+				"best-effort": {
+					Namespaces:   []string{"dev"},
+					Enforcement:  longhorn.VolumePolicyEnforcementMutate,
+					DataLocality: longhorn.DataLocalityBestEffort,
+				},
+			},
+			name: "strict-local",
+			spec: longhorn.VolumePolicySpec{
+				Namespaces:   []string{"prod"},
+				Enforcement:  longhorn.VolumePolicyEnforcementMutate,
+				DataLocality: longhorn.DataLocalityStrictLocal,
+			},
+		},
+		"conflicting minimum number of replicas": {
+			existingPolicies: map[string]longhorn.VolumePolicySpec{"strict-local": {DataLocality: longhorn.DataLocalityStrictLocal}},
+			name:             "min-replicas",
+			spec:             longhorn.VolumePolicySpec{Enforcement: longhorn.VolumePolicyEnforcementMutate, MinNumberOfReplicas: 3},
+			expectError:      true,
+		},
+		"update of the policy itself": {
+			existingPolicies: map[string]longhorn.VolumePolicySpec{"locality": bestEffort},
+			name:             "locality",
+			oldSpec:          &bestEffort,
+			spec:             longhorn.VolumePolicySpec{Enforcement: longhorn.VolumePolicyEnforcementMutate, DataLocality: longhorn.DataLocalityStrictLocal},
+		},
+		"update adding a conflict": {
+			existingPolicies: map[string]longhorn.VolumePolicySpec{
+				"best-effort": bestEffort,
+				"locality":    {Namespaces: []string{"prod"}, DataLocality: longhorn.DataLocalityStrictLocal},
+			},
+			name:        "locality",
+			oldSpec:     &longhorn.VolumePolicySpec{Namespaces: []string{"prod"}, DataLocality: longhorn.DataLocalityStrictLocal},
+			spec:        longhorn.VolumePolicySpec{DataLocality: longhorn.DataLocalityStrictLocal},
+			expectError: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		kubeClient := fake.NewSimpleClientset(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}})
+		lhClient := lhfake.NewSimpleClientset()
+		informerFactories := util.NewInformerFactories(testNamespace, kubeClient, lhClient, 0)
+		policyIndexer := informerFactories.LhInformerFactory.Longhorn().V1beta2().VolumePolicies().Informer().GetIndexer()
+		for policyName, spec := range tc.existingPolicies {
+			policy := &longhorn.VolumePolicy{ObjectMeta: metav1.ObjectMeta{Name: policyName, Namespace: testNamespace}, Spec: spec}
+			assert.NoError(policyIndexer.Add(policy), name)
+		}
+		validator := &volumePolicyValidator{
+			ds: datastore.NewDataStore(testNamespace, lhClient, kubeClient, apiextensionsfake.NewSimpleClientset(), informerFactories),
+		}
+
+		policy := &longhorn.VolumePolicy{ObjectMeta: metav1.ObjectMeta{Name: tc.name, Namespace: testNamespace}, Spec: tc.spec}
+		var err error
+		if tc.oldSpec == nil {
+			err = validator.Create(nil, policy)
+		} else {
+			oldPolicy := &longhorn.VolumePolicy{ObjectMeta: metav1.ObjectMeta{Name: tc.name, Namespace: testNamespace}, Spec: *tc.oldSpec}
+			err = validator.Update(nil, oldPolicy, policy)
+		}
+		if tc.expectError {
+			assert.Error(err, name)
+		} else {
+			assert.NoError(err, name)
+		}
+	}
+}
@@ -58,7 +58,7 @@ func Mutation(ds *datastore.DataStore) (http.Handler, []admission.Resource, erro
 
 	router := webhook.NewRouter()
 	for _, m := range mutators {
-		addHandler(router, admission.AdmissionTypeMutation, m)
+		addHandler(router, admission.AdmissionTypeMutation, m, nil)
 		resources = append(resources, m.Resource())
 	}
 
@@ -5,10 +5,13 @@ import (
 
 	"github.com/rancher/wrangler/v3/pkg/webhook"
 
+	"github.com/longhorn/longhorn-manager/audit"
 	"github.com/longhorn/longhorn-manager/datastore"
 	"github.com/longhorn/longhorn-manager/types"
 	"github.com/longhorn/longhorn-manager/util"
 	"github.com/longhorn/longhorn-manager/webhook/admission"
+	"github.com/longhorn/longhorn-manager/webhook/resources/alertsilence"
+	"github.com/longhorn/longhorn-manager/webhook/resources/alertsink"
 	"github.com/longhorn/longhorn-manager/webhook/resources/backingimage"
 	"github.com/longhorn/longhorn-manager/webhook/resources/backup"
 	"github.com/longhorn/longhorn-manager/webhook/resources/backupbackingimage"
@@ -24,12 +27,15 @@ import (
 	"github.com/longhorn/longhorn-manager/webhook/resources/recurringjob"
 	"github.com/longhorn/longhorn-manager/webhook/resources/replica"
 	"github.com/longhorn/longhorn-manager/webhook/resources/setting"
+	"github.com/longhorn/longhorn-manager/webhook/resources/sharemanager"
 	"github.com/longhorn/longhorn-manager/webhook/resources/snapshot"
+	"github.com/longhorn/longhorn-manager/webhook/resources/storagequota"
 	"github.com/longhorn/longhorn-manager/webhook/resources/supportbundle"
 	"github.com/longhorn/longhorn-manager/webhook/resources/systembackup"
 	"github.com/longhorn/longhorn-manager/webhook/resources/systemrestore"
 	"github.com/longhorn/longhorn-manager/webhook/resources/volume"
 	"github.com/longhorn/longhorn-manager/webhook/resources/volumeattachment"
+	"github.com/longhorn/longhorn-manager/webhook/resources/volumepolicy"
 )
 
 func Validation(ds *datastore.DataStore) (http.Handler, []admission.Resource, error) {
@@ -50,11 +56,16 @@ func Validation(ds *datastore.DataStore) (http.Handler, []admission.Resource, er
 		backuptarget.NewValidator(ds),
 		volume.NewValidator(ds, currentNodeID),
 		orphan.NewValidator(ds),
+		sharemanager.NewValidator(ds),
 		snapshot.NewValidator(ds),
 		supportbundle.NewValidator(ds),
 		systembackup.NewValidator(ds),
 		systemrestore.NewValidator(ds),
 		volumeattachment.NewValidator(ds),
+		volumepolicy.NewValidator(ds),
+		storagequota.NewValidator(ds),
+		alertsink.NewValidator(ds),
+		alertsilence.NewValidator(ds),
 		engine.NewValidator(ds),
 		replica.NewValidator(ds),
 		instancemanager.NewValidator(ds),
@@ -63,9 +74,13 @@ func Validation(ds *datastore.DataStore) (http.Handler, []admission.Resource, er
 		kubernetesnode.NewValidator(ds),
 	}
 
+	// Only the validation webhook writes the audit records, since it sees the final objects and decides whether the
+	// operations are allowed.
+	auditor := audit.NewLogger(currentNodeID, ds.GetSettingAuditLogDestination)
+
 	router := webhook.NewRouter()
 	for _, v := range validators {
-		addHandler(router, admission.AdmissionTypeValidation, admission.NewValidatorAdapter(v))
+		addHandler(router, admission.AdmissionTypeValidation, admission.NewValidatorAdapter(v), auditor)
 		resources = append(resources, v.Resource())
 	}
 